@@ -0,0 +1,217 @@
+package canvas
+
+import (
+	"image/color"
+	"math"
+	"strings"
+)
+
+// BlendMode is the blend mode that determines how colors are mixed with the colors of the backdrop, see https://www.w3.org/TR/compositing-1/#blending. The separable blend modes mix each color component independently, while the non-separable blend modes (HueBlend, SaturationBlend, ColorBlend, and LuminosityBlend) mix the colors as a whole.
+type BlendMode int
+
+// see BlendMode
+const (
+	NormalBlend BlendMode = iota
+	MultiplyBlend
+	ScreenBlend
+	OverlayBlend
+	DarkenBlend
+	LightenBlend
+	ColorDodgeBlend
+	ColorBurnBlend
+	HardLightBlend
+	SoftLightBlend
+	DifferenceBlend
+	ExclusionBlend
+	HueBlend
+	SaturationBlend
+	ColorBlend
+	LuminosityBlend
+)
+
+var blendModeNames = []string{"Normal", "Multiply", "Screen", "Overlay", "Darken", "Lighten", "ColorDodge", "ColorBurn", "HardLight", "SoftLight", "Difference", "Exclusion", "Hue", "Saturation", "Color", "Luminosity"}
+
+// String returns the name of the blend mode as used by PDF.
+func (mode BlendMode) String() string {
+	if mode < 0 || int(mode) >= len(blendModeNames) {
+		return blendModeNames[0]
+	}
+	return blendModeNames[mode]
+}
+
+// CSS returns the name of the blend mode as used by the CSS mix-blend-mode property.
+func (mode BlendMode) CSS() string {
+	switch mode {
+	case ColorDodgeBlend:
+		return "color-dodge"
+	case ColorBurnBlend:
+		return "color-burn"
+	case HardLightBlend:
+		return "hard-light"
+	case SoftLightBlend:
+		return "soft-light"
+	}
+	return strings.ToLower(mode.String())
+}
+
+// IsSeparable returns true if the blend mode mixes each color component independently.
+func (mode BlendMode) IsSeparable() bool {
+	return mode < HueBlend
+}
+
+// Blend composites the source color over the backdrop color using the blend mode. Both colors and the result are alpha-premultiplied, and are assumed to be in the color space in which blending takes place.
+func (mode BlendMode) Blend(backdrop, source color.RGBA) color.RGBA {
+	if mode == NormalBlend || backdrop.A == 0 || source.A == 0 {
+		// source over
+		a := 255 - uint32(source.A)
+		return color.RGBA{
+			uint8(uint32(source.R) + (uint32(backdrop.R)*a+127)/255),
+			uint8(uint32(source.G) + (uint32(backdrop.G)*a+127)/255),
+			uint8(uint32(source.B) + (uint32(backdrop.B)*a+127)/255),
+			uint8(uint32(source.A) + (uint32(backdrop.A)*a+127)/255),
+		}
+	}
+
+	ab, as := float64(backdrop.A)/255.0, float64(source.A)/255.0
+	cb := [3]float64{float64(backdrop.R) / 255.0 / ab, float64(backdrop.G) / 255.0 / ab, float64(backdrop.B) / 255.0 / ab}
+	cs := [3]float64{float64(source.R) / 255.0 / as, float64(source.G) / 255.0 / as, float64(source.B) / 255.0 / as}
+	for i := range cb {
+		cb[i] = math.Min(cb[i], 1.0)
+		cs[i] = math.Min(cs[i], 1.0)
+	}
+	b := mode.blend(cb, cs)
+
+	// see https://www.w3.org/TR/compositing-1/#generalformula
+	ao := as + ab*(1.0-as)
+	co := [3]float64{}
+	for i := range co {
+		co[i] = as*(1.0-ab)*cs[i] + ab*(1.0-as)*cb[i] + as*ab*b[i]
+	}
+	for i := range co {
+		co[i] = math.Max(0.0, math.Min(ao, co[i]))
+	}
+	return color.RGBA{
+		uint8(co[0]*255.0 + 0.5),
+		uint8(co[1]*255.0 + 0.5),
+		uint8(co[2]*255.0 + 0.5),
+		uint8(ao*255.0 + 0.5),
+	}
+}
+
+// blend returns the mixed color of the non-premultiplied backdrop and source colors.
+func (mode BlendMode) blend(cb, cs [3]float64) [3]float64 {
+	if mode.IsSeparable() {
+		b := [3]float64{}
+		for i := range b {
+			b[i] = mode.blendComponent(cb[i], cs[i])
+		}
+		return b
+	}
+
+	switch mode {
+	case HueBlend:
+		return setLum(setSat(cs, sat(cb)), lum(cb))
+	case SaturationBlend:
+		return setLum(setSat(cb, sat(cs)), lum(cb))
+	case ColorBlend:
+		return setLum(cs, lum(cb))
+	case LuminosityBlend:
+		return setLum(cb, lum(cs))
+	}
+	return cs
+}
+
+func (mode BlendMode) blendComponent(cb, cs float64) float64 {
+	switch mode {
+	case MultiplyBlend:
+		return cb * cs
+	case ScreenBlend:
+		return cb + cs - cb*cs
+	case OverlayBlend:
+		return HardLightBlend.blendComponent(cs, cb)
+	case DarkenBlend:
+		return math.Min(cb, cs)
+	case LightenBlend:
+		return math.Max(cb, cs)
+	case ColorDodgeBlend:
+		if cb == 0.0 {
+			return 0.0
+		} else if cs == 1.0 {
+			return 1.0
+		}
+		return math.Min(1.0, cb/(1.0-cs))
+	case ColorBurnBlend:
+		if cb == 1.0 {
+			return 1.0
+		} else if cs == 0.0 {
+			return 0.0
+		}
+		return 1.0 - math.Min(1.0, (1.0-cb)/cs)
+	case HardLightBlend:
+		if cs <= 0.5 {
+			return cb * 2.0 * cs
+		}
+		return ScreenBlend.blendComponent(cb, 2.0*cs-1.0)
+	case SoftLightBlend:
+		if cs <= 0.5 {
+			return cb - (1.0-2.0*cs)*cb*(1.0-cb)
+		}
+		d := math.Sqrt(cb)
+		if cb <= 0.25 {
+			d = ((16.0*cb-12.0)*cb + 4.0) * cb
+		}
+		return cb + (2.0*cs-1.0)*(d-cb)
+	case DifferenceBlend:
+		return math.Abs(cb - cs)
+	case ExclusionBlend:
+		return cb + cs - 2.0*cb*cs
+	}
+	return cs
+}
+
+func lum(c [3]float64) float64 {
+	return 0.3*c[0] + 0.59*c[1] + 0.11*c[2]
+}
+
+func clipColor(c [3]float64) [3]float64 {
+	l := lum(c)
+	n := math.Min(c[0], math.Min(c[1], c[2]))
+	x := math.Max(c[0], math.Max(c[1], c[2]))
+	for i := range c {
+		if n < 0.0 {
+			c[i] = l + (c[i]-l)*l/(l-n)
+		}
+		if 1.0 < x {
+			c[i] = l + (c[i]-l)*(1.0-l)/(x-l)
+		}
+	}
+	return c
+}
+
+func setLum(c [3]float64, l float64) [3]float64 {
+	d := l - lum(c)
+	return clipColor([3]float64{c[0] + d, c[1] + d, c[2] + d})
+}
+
+func sat(c [3]float64) float64 {
+	return math.Max(c[0], math.Max(c[1], c[2])) - math.Min(c[0], math.Min(c[1], c[2]))
+}
+
+func setSat(c [3]float64, s float64) [3]float64 {
+	imin, imid, imax := 0, 1, 2
+	if c[imid] < c[imin] {
+		imin, imid = imid, imin
+	}
+	if c[imax] < c[imid] {
+		imid, imax = imax, imid
+		if c[imid] < c[imin] {
+			imin, imid = imid, imin
+		}
+	}
+	r := [3]float64{}
+	if c[imin] < c[imax] {
+		r[imid] = (c[imid] - c[imin]) * s / (c[imax] - c[imin])
+		r[imax] = s
+	}
+	return r
+}
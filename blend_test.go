@@ -0,0 +1,51 @@
+package canvas
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/tdewolff/test"
+)
+
+func TestBlendMode(t *testing.T) {
+	gray := color.RGBA{128, 128, 128, 255}
+	var tts = []struct {
+		mode     BlendMode
+		backdrop color.RGBA
+		source   color.RGBA
+		col      color.RGBA
+	}{
+		{NormalBlend, Red, color.RGBA{0, 0, 128, 128}, color.RGBA{127, 0, 128, 255}},
+		{MultiplyBlend, gray, Red, color.RGBA{128, 0, 0, 255}},
+		{ScreenBlend, gray, Red, color.RGBA{255, 128, 128, 255}},
+		{DarkenBlend, Yellow, Red, Red},
+		{LightenBlend, Blue, Red, color.RGBA{255, 0, 255, 255}},
+		{DifferenceBlend, White, Red, color.RGBA{0, 255, 255, 255}},
+		{ExclusionBlend, Black, Red, Red},
+		{ColorDodgeBlend, Black, White, Black},
+		{ColorBurnBlend, White, Black, White},
+		{HardLightBlend, gray, White, White},
+		{OverlayBlend, White, gray, White},
+		{LuminosityBlend, Red, White, White},
+		{ColorBlend, White, Red, White},
+		{SaturationBlend, gray, Red, gray},
+		{HueBlend, Black, Red, Black},
+		{MultiplyBlend, Transparent, Red, Red},
+		{MultiplyBlend, Red, Transparent, Red},
+		{MultiplyBlend, White, color.RGBA{0, 0, 128, 128}, color.RGBA{127, 127, 255, 255}},
+	}
+	for _, tt := range tts {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			test.T(t, tt.mode.Blend(tt.backdrop, tt.source), tt.col)
+		})
+	}
+}
+
+func TestBlendModeNames(t *testing.T) {
+	test.String(t, ColorDodgeBlend.String(), "ColorDodge")
+	test.String(t, ColorDodgeBlend.CSS(), "color-dodge")
+	test.String(t, LuminosityBlend.CSS(), "luminosity")
+	test.String(t, BlendMode(100).String(), "Normal")
+	test.That(t, ExclusionBlend.IsSeparable())
+	test.That(t, !HueBlend.IsSeparable())
+}
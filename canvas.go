@@ -8,8 +8,8 @@ import (
 	"sort"
 )
 
-//const mmPerPx = 25.4 / 96.0
-//const pxPerMm = 96.0 / 25.4
+// const mmPerPx = 25.4 / 96.0
+// const pxPerMm = 96.0 / 25.4
 const mmPerPt = 25.4 / 72.0
 const ptPerMm = 72.0 / 25.4
 const mmPerInch = 25.4
@@ -98,26 +98,33 @@ const (
 
 ////////////////////////////////////////////////////////////////
 
-// Style is the path style that defines how to draw the path. When FillColor is transparent it will not fill the path. If StrokeColor is transparent or StrokeWidth is zero, it will not stroke the path. If Dashes is an empty array, it will not draw dashes but instead a solid stroke line. FillRule determines how to fill the path when paths overlap and have certain directions (clockwise, counter clockwise).
+// Style is the path style that defines how to draw the path. When FillColor is transparent it will not fill the path. If StrokeColor is transparent or StrokeWidth is zero, it will not stroke the path. If Dashes is an empty array, it will not draw dashes but instead a solid stroke line. FillRule determines how to fill the path when paths overlap and have certain directions (clockwise, counter clockwise). FillGradient and StrokeGradient take precedence over FillColor and StrokeColor respectively when they are set, and FillPattern and StrokePattern take precedence over both. BlendMode determines how the path is mixed with the backdrop.
 type Style struct {
-	FillColor    color.RGBA
-	StrokeColor  color.RGBA
-	StrokeWidth  float64
-	StrokeCapper Capper
-	StrokeJoiner Joiner
-	DashOffset   float64
-	Dashes       []float64
-	FillRule     // TODO: test for all renderers
+	FillColor      color.RGBA
+	FillNative     NativeColor // fill color in its native color space, FillColor must hold its sRGB conversion
+	FillGradient   Gradient
+	FillPattern    *Pattern
+	StrokeColor    color.RGBA
+	StrokeNative   NativeColor // stroke color in its native color space, StrokeColor must hold its sRGB conversion
+	StrokeGradient Gradient
+	StrokePattern  *Pattern
+	StrokeWidth    float64
+	StrokeCapper   Capper
+	StrokeJoiner   Joiner
+	DashOffset     float64
+	Dashes         []float64
+	FillRule       // TODO: test for all renderers
+	BlendMode      BlendMode
 }
 
 // HasFill returns true if the style has a fill
 func (style Style) HasFill() bool {
-	return style.FillColor.A != 0
+	return style.FillPattern != nil || style.FillGradient != nil || style.FillColor.A != 0
 }
 
 // HasStroke returns true if the style has a stroke
 func (style Style) HasStroke() bool {
-	return style.StrokeColor.A != 0 && 0.0 < style.StrokeWidth
+	return (style.StrokePattern != nil || style.StrokeGradient != nil || style.StrokeColor.A != 0) && 0.0 < style.StrokeWidth
 }
 
 // IsDashed returns true if the style has dashes
@@ -137,12 +144,46 @@ var DefaultStyle = Style{
 	FillRule:     NonZero,
 }
 
-// Renderer is an interface that renderers implement. It defines the size of the target (in mm) and functions to render paths, text objects and images.
+// MaskType is the type of soft mask, which determines how the mask's content is converted to opacity values.
+type MaskType int
+
+// see MaskType
+const (
+	LuminosityMask MaskType = iota // opacity is the luminosity of the mask's content composited on black
+	AlphaMask                      // opacity is the alpha of the mask's content
+)
+
+func (maskType MaskType) String() string {
+	if maskType == AlphaMask {
+		return "Alpha"
+	}
+	return "Luminosity"
+}
+
+// Group is a transparency group. Its content is composited separately, and the result is painted with the given opacity, optional soft mask, and blend mode. The mask is a canvas that is drawn in the same coordinate system as the group's content.
+type Group struct {
+	Opacity   float64
+	Mask      *Canvas
+	MaskType  MaskType
+	BlendMode BlendMode
+}
+
+// Renderer is an interface that renderers implement. It defines the size of the target (in mm) and functions to render paths, text objects and images. PushClip intersects the clipping region with the path using the fill rule, which restricts all subsequent rendering until the matching PopClip. PushGroup starts a transparency group that collects all subsequent rendering until the matching PopGroup, where m is the transformation of the group's mask. Clipping paths and groups are properly nested.
 type Renderer interface {
 	Size() (float64, float64)
 	RenderPath(path *Path, style Style, m Matrix)
 	RenderText(text *Text, m Matrix)
 	RenderImage(img image.Image, m Matrix)
+	PushClip(path *Path, fillRule FillRule, m Matrix)
+	PopClip()
+	PushGroup(group Group, m Matrix)
+	PopGroup()
+}
+
+// Linker is an optional interface for renderers that support hyperlinks and named destinations. RenderLink turns the area of the rectangle into a link to the URI, where a URI starting with # refers to the named destination in the same document. RenderDestination defines a named destination at the given position.
+type Linker interface {
+	RenderLink(uri string, rect Rect, m Matrix)
+	RenderDestination(name string, pos Point, m Matrix)
 }
 
 ////////////////////////////////////////////////////////////////
@@ -161,6 +202,7 @@ type ContextState struct {
 	view        Matrix
 	coordView   Matrix
 	coordSystem CoordSystem
+	scopes      int // number of clipping paths and groups pushed to the renderer
 }
 
 // Context maintains the state for the current path, path style, and view transformation matrix.
@@ -169,7 +211,8 @@ type Context struct {
 
 	path *Path
 	ContextState
-	stack []ContextState
+	stack      []ContextState
+	scopeStack []bool // clipping paths (false) and groups (true) pushed to the renderer
 }
 
 // NewContext returns a new context which is a wrapper around a renderer. Contexts maintain the state of the current path, path style, and view transformation matrix.
@@ -206,16 +249,29 @@ func (c *Context) Size() (float64, float64) {
 
 // Push saves the current draw state so that it can be popped later on.
 func (c *Context) Push() {
+	c.scopes = len(c.scopeStack)
 	c.stack = append(c.stack, c.ContextState)
 }
 
-// Pop restores the last pushed draw state and uses that as the current draw state. If there are no states on the stack, this will do nothing.
+// Pop restores the last pushed draw state and uses that as the current draw state, which includes removing clipping paths and ending groups started after the corresponding Push. If there are no states on the stack, this will do nothing.
 func (c *Context) Pop() {
 	if len(c.stack) == 0 {
 		return
 	}
 	c.ContextState = c.stack[len(c.stack)-1]
 	c.stack = c.stack[:len(c.stack)-1]
+	c.popScopes(c.ContextState.scopes)
+}
+
+// popScopes removes clipping paths and ends groups until n remain.
+func (c *Context) popScopes(n int) {
+	for ; n < len(c.scopeStack); c.scopeStack = c.scopeStack[:len(c.scopeStack)-1] {
+		if c.scopeStack[len(c.scopeStack)-1] {
+			c.Renderer.PopGroup()
+		} else {
+			c.Renderer.PopClip()
+		}
+	}
 }
 
 // CoordView returns the current affine transformation matrix through which all operation coordinates will be transformed.
@@ -313,36 +369,42 @@ func (c *Context) ShearAbout(sx, sy, x, y float64) {
 	c.view = c.view.Mul(Identity.ShearAbout(sx, sy, x, y))
 }
 
-// SetFillColor sets the color to be used for filling operations.
+// SetFillColor sets the color to be used for filling operations. It removes any fill gradient or pattern. A NativeColor is kept in its color space for renderers that support it.
 func (c *Context) SetFillColor(col color.Color) {
-	r, g, b, a := col.RGBA()
-	// RGBA returns an alpha-premultiplied color so that c <= a. We silently correct the color by clipping r,g,b to a
-	if a < r {
-		r = a
-	}
-	if a < g {
-		g = a
-	}
-	if a < b {
-		b = a
-	}
-	c.Style.FillColor = color.RGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), uint8(a >> 8)}
+	c.Style.FillColor = rgbaColor(col)
+	c.Style.FillNative, _ = col.(NativeColor)
+	c.Style.FillGradient = nil
+	c.Style.FillPattern = nil
+}
+
+// SetFillGradient sets the gradient to be used for filling operations. It removes any fill pattern. The gradient is defined in the same coordinate system as the paths that are drawn.
+func (c *Context) SetFillGradient(gradient Gradient) {
+	c.Style.FillGradient = gradient
+	c.Style.FillPattern = nil
 }
 
-// SetStrokeColor sets the color to be used for stroking operations.
+// SetFillPattern sets the pattern to be used for filling operations. The pattern is defined in the same coordinate system as the paths that are drawn.
+func (c *Context) SetFillPattern(pattern *Pattern) {
+	c.Style.FillPattern = pattern
+}
+
+// SetStrokeColor sets the color to be used for stroking operations. It removes any stroke gradient or pattern. A NativeColor is kept in its color space for renderers that support it.
 func (c *Context) SetStrokeColor(col color.Color) {
-	r, g, b, a := col.RGBA()
-	// RGBA returns an alpha-premultiplied color so that c <= a. We silently correct the color by clipping r,g,b to a
-	if a < r {
-		r = a
-	}
-	if a < g {
-		g = a
-	}
-	if a < b {
-		b = a
-	}
-	c.Style.StrokeColor = color.RGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), uint8(a >> 8)}
+	c.Style.StrokeColor = rgbaColor(col)
+	c.Style.StrokeNative, _ = col.(NativeColor)
+	c.Style.StrokeGradient = nil
+	c.Style.StrokePattern = nil
+}
+
+// SetStrokeGradient sets the gradient to be used for stroking operations. It removes any stroke pattern. The gradient is defined in the same coordinate system as the paths that are drawn.
+func (c *Context) SetStrokeGradient(gradient Gradient) {
+	c.Style.StrokeGradient = gradient
+	c.Style.StrokePattern = nil
+}
+
+// SetStrokePattern sets the pattern to be used for stroking operations. The pattern is defined in the same coordinate system as the paths that are drawn.
+func (c *Context) SetStrokePattern(pattern *Pattern) {
+	c.Style.StrokePattern = pattern
 }
 
 // SetStrokeWidth sets the width in millimeters for stroking operations.
@@ -371,6 +433,11 @@ func (c *Context) SetFillRule(rule FillRule) {
 	c.Style.FillRule = rule
 }
 
+// SetBlendMode sets the blend mode to be used for drawing paths and groups. To blend text or images with the backdrop, draw them within a group.
+func (c *Context) SetBlendMode(mode BlendMode) {
+	c.Style.BlendMode = mode
+}
+
 // ResetStyle resets the draw state to its default (colors, stroke widths, dashes, ...).
 func (c *Context) ResetStyle() {
 	c.Style = DefaultStyle
@@ -425,19 +492,19 @@ func (c *Context) Close() {
 
 // Fill fills the current path and resets the path.
 func (c *Context) Fill() {
-	strokeColor := c.Style.StrokeColor
-	c.Style.StrokeColor = Transparent
+	strokeColor, strokeGradient, strokePattern := c.Style.StrokeColor, c.Style.StrokeGradient, c.Style.StrokePattern
+	c.Style.StrokeColor, c.Style.StrokeGradient, c.Style.StrokePattern = Transparent, nil, nil
 	c.DrawPath(0.0, 0.0, c.path)
-	c.Style.StrokeColor = strokeColor
+	c.Style.StrokeColor, c.Style.StrokeGradient, c.Style.StrokePattern = strokeColor, strokeGradient, strokePattern
 	c.path = &Path{}
 }
 
 // Stroke strokes the current path and resets the path.
 func (c *Context) Stroke() {
-	fillColor := c.Style.FillColor
-	c.Style.FillColor = Transparent
+	fillColor, fillGradient, fillPattern := c.Style.FillColor, c.Style.FillGradient, c.Style.FillPattern
+	c.Style.FillColor, c.Style.FillGradient, c.Style.FillPattern = Transparent, nil, nil
 	c.DrawPath(0.0, 0.0, c.path)
-	c.Style.FillColor = fillColor
+	c.Style.FillColor, c.Style.FillGradient, c.Style.FillPattern = fillColor, fillGradient, fillPattern
 	c.path = &Path{}
 }
 
@@ -447,6 +514,44 @@ func (c *Context) FillStroke() {
 	c.path = &Path{}
 }
 
+// Clip intersects the clipping region with the current path using the current fill rule, and resets the path. All subsequent drawing operations are restricted to the clipping region. The clipping region is restored by Pop, so call Push before Clip to be able to remove it later on.
+func (c *Context) Clip() {
+	c.ClipPath(0.0, 0.0, c.path)
+	c.path = &Path{}
+}
+
+// ClipPath intersects the clipping region with the path at position (x,y) using the current fill rule. See Clip.
+func (c *Context) ClipPath(x, y float64, path *Path) {
+	c.Renderer.PushClip(path, c.Style.FillRule, c.pathView(x, y))
+	c.scopeStack = append(c.scopeStack, false)
+}
+
+// BeginGroup starts a transparency group with the given opacity. All subsequent drawing operations are composited separately and painted together when EndGroup is called, so that overlapping shapes within the group do not show through each other.
+func (c *Context) BeginGroup(opacity float64) {
+	c.BeginMaskedGroup(opacity, nil, LuminosityMask)
+}
+
+// BeginMaskedGroup starts a transparency group with the given opacity and soft mask. The mask canvas is drawn in the current coordinate system with its origin at (0,0), and its luminosity or alpha values determine the opacity of the group's content. The group is blended with the backdrop using the current blend mode. See BeginGroup.
+func (c *Context) BeginMaskedGroup(opacity float64, mask *Canvas, maskType MaskType) {
+	c.Renderer.PushGroup(Group{
+		Opacity:   opacity,
+		Mask:      mask,
+		MaskType:  maskType,
+		BlendMode: c.Style.BlendMode,
+	}, c.pathView(0.0, 0.0))
+	c.scopeStack = append(c.scopeStack, true)
+}
+
+// EndGroup ends the last started transparency group, including removing clipping paths set within the group, and paints its content. If there is no group, this will do nothing.
+func (c *Context) EndGroup() {
+	for i := len(c.scopeStack) - 1; 0 <= i; i-- {
+		if c.scopeStack[i] {
+			c.popScopes(i)
+			return
+		}
+	}
+}
+
 // FitImage fits an image to a rectangle using different fit strategies.
 func (c *Context) FitImage(img image.Image, rect Rect, fit ImageFit) {
 	if img.Bounds().Size().Eq(image.Point{}) || rect.W == 0 || rect.H == 0 {
@@ -514,23 +619,30 @@ func (c *Context) DrawPath(x, y float64, paths ...*Path) {
 		return
 	}
 
-	coord := c.coordView.Dot(Point{x, y})
-	m := Identity
-	if c.coordSystem == CartesianIV {
-		m = m.ReflectYAbout(c.Height() / 2.0)
-	}
-	m = m.Mul(c.view).Translate(coord.X, coord.Y)
+	m := c.pathView(x, y)
 	for _, path := range paths {
 		var ok bool
 		style := c.Style
 		style.Dashes, ok = path.checkDash(c.Style.DashOffset, c.Style.Dashes)
 		if !ok {
 			style.StrokeColor = Transparent
+			style.StrokeGradient = nil
+			style.StrokePattern = nil
 		}
 		c.RenderPath(path, style, m)
 	}
 }
 
+// pathView returns the transformation matrix for paths drawn at position (x,y).
+func (c *Context) pathView(x, y float64) Matrix {
+	coord := c.coordView.Dot(Point{x, y})
+	m := Identity
+	if c.coordSystem == CartesianIV {
+		m = m.ReflectYAbout(c.Height() / 2.0)
+	}
+	return m.Mul(c.view).Translate(coord.X, coord.Y)
+}
+
 // DrawText draws text at position (x,y) using the current draw state.
 func (c *Context) DrawText(x, y float64, texts ...*Text) {
 	coordView := Identity
@@ -566,24 +678,72 @@ func (c *Context) DrawImage(x, y float64, img image.Image, resolution Resolution
 	c.RenderImage(img, m)
 }
 
+// AddLink turns the rectangle at position (x,y) with width w and height h into a hyperlink to the URI, where a URI starting with # refers to a named destination. It is ignored if the renderer does not implement Linker.
+func (c *Context) AddLink(uri string, x, y, w, h float64) {
+	if linker, ok := c.Renderer.(Linker); ok {
+		linker.RenderLink(uri, Rect{0.0, 0.0, w, h}, c.pathView(x, y))
+	}
+}
+
+// AddDestination defines a named destination at position (x,y), which can be referred to by links with the URI #name. It is ignored if the renderer does not implement Linker.
+func (c *Context) AddDestination(name string, x, y float64) {
+	if linker, ok := c.Renderer.(Linker); ok {
+		linker.RenderDestination(name, Point{0.0, 0.0}, c.pathView(x, y))
+	}
+}
+
 ////////////////////////////////////////////////////////////////
 ////////////////////////////////////////////////////////////////
 ////////////////////////////////////////////////////////////////
 
+// scope is either a clipping path or a transparency group that applies to a list of layers.
+type scope struct {
+	path     *Path
+	fillRule FillRule
+	group    *Group // if set, this is a group instead of a clipping path
+	m        Matrix
+	zindex   int // z-index at which the group was pushed
+	seq      int // order in which the group was pushed
+}
+
+// link is a hyperlink or a named destination.
+type link struct {
+	uri  string // URI or destination name
+	rect Rect   // position of the destination at (X,Y)
+	dest bool
+}
+
 type layer struct {
-	// path, text OR img is set
+	// path, text, img OR link is set
 	path *Path
 	text *Text
 	img  image.Image
+	link *link
+
+	m      Matrix
+	style  Style    // only for path
+	scopes []*scope // clipping paths and groups in effect
+	zindex int
+	seq    int // order in which the layer was rendered
+}
 
-	m     Matrix
-	style Style // only for path
+// order returns the z-index and sequence number of each enclosing group followed by those of the layer, so that a group is drawn as a whole at the position where it was pushed.
+func (l layer) order() [][2]int {
+	order := [][2]int{}
+	for _, s := range l.scopes {
+		if s.group != nil {
+			order = append(order, [2]int{s.zindex, s.seq})
+		}
+	}
+	return append(order, [2]int{l.zindex, l.seq})
 }
 
 // Canvas stores all drawing operations as layers that can be re-rendered to other renderers.
 type Canvas struct {
 	layers map[int][]layer
+	scopes []*scope
 	zindex int
+	seq    int
 	W, H   float64
 }
 
@@ -609,17 +769,69 @@ func (c *Canvas) Size() (float64, float64) {
 // RenderPath renders a path to the canvas using a style and a transformation matrix.
 func (c *Canvas) RenderPath(path *Path, style Style, m Matrix) {
 	path = path.Copy()
-	c.layers[c.zindex] = append(c.layers[c.zindex], layer{path: path, m: m, style: style})
+	c.addLayer(layer{path: path, m: m, style: style})
 }
 
 // RenderText renders a text object to the canvas using a transformation matrix.
 func (c *Canvas) RenderText(text *Text, m Matrix) {
-	c.layers[c.zindex] = append(c.layers[c.zindex], layer{text: text, m: m})
+	c.addLayer(layer{text: text, m: m})
 }
 
 // RenderImage renders an image to the canvas using a transformation matrix.
 func (c *Canvas) RenderImage(img image.Image, m Matrix) {
-	c.layers[c.zindex] = append(c.layers[c.zindex], layer{img: img, m: m})
+	c.addLayer(layer{img: img, m: m})
+}
+
+// RenderLink turns the area of a rectangle into a hyperlink to the URI using a transformation matrix. URIs starting with # refer to a named destination. Links are passed on to renderers that implement Linker.
+func (c *Canvas) RenderLink(uri string, rect Rect, m Matrix) {
+	c.addLayer(layer{link: &link{uri: uri, rect: rect}, m: m})
+}
+
+// RenderDestination defines a named destination at a position using a transformation matrix, which can be referred to by links with the URI #name.
+func (c *Canvas) RenderDestination(name string, pos Point, m Matrix) {
+	c.addLayer(layer{link: &link{uri: name, rect: Rect{X: pos.X, Y: pos.Y}, dest: true}, m: m})
+}
+
+// PushClip intersects the clipping region with a path using a fill rule and a transformation matrix. All subsequently rendered layers are clipped until PopClip is called.
+func (c *Canvas) PushClip(path *Path, fillRule FillRule, m Matrix) {
+	c.pushScope(&scope{path: path.Copy(), fillRule: fillRule, m: m})
+}
+
+// PopClip removes the last pushed clipping path.
+func (c *Canvas) PopClip() {
+	if len(c.scopes) == 0 || c.scopes[len(c.scopes)-1].group != nil {
+		return
+	}
+	c.scopes = c.scopes[:len(c.scopes)-1]
+}
+
+// PushGroup starts a transparency group that contains all subsequently rendered layers until PopGroup is called. The transformation matrix applies to the group's mask. The group is drawn as a whole at the current z-index, see SetZIndex.
+func (c *Canvas) PushGroup(group Group, m Matrix) {
+	c.pushScope(&scope{group: &group, m: m, zindex: c.zindex, seq: c.seq})
+	c.seq++
+}
+
+// PopGroup ends the last started transparency group, including the clipping paths pushed within the group.
+func (c *Canvas) PopGroup() {
+	for i := len(c.scopes) - 1; 0 <= i; i-- {
+		if c.scopes[i].group != nil {
+			c.scopes = c.scopes[:i]
+			return
+		}
+	}
+}
+
+func (c *Canvas) addLayer(l layer) {
+	l.scopes = c.scopes
+	l.zindex = c.zindex
+	l.seq = c.seq
+	c.seq++
+	c.layers[c.zindex] = append(c.layers[c.zindex], l)
+}
+
+func (c *Canvas) pushScope(s *scope) {
+	// force a copy so that layers keep their own list of scopes
+	c.scopes = append(c.scopes[:len(c.scopes):len(c.scopes)], s)
 }
 
 // Empty return true if the canvas is empty.
@@ -630,9 +842,11 @@ func (c *Canvas) Empty() bool {
 // Reset empties the canvas.
 func (c *Canvas) Reset() {
 	c.layers = map[int][]layer{}
+	c.scopes = nil
+	c.seq = 0
 }
 
-// SetZIndex sets the z-index.
+// SetZIndex sets the z-index. Within a transparency group, the z-index only orders the layers inside the group, while the group itself is drawn at the z-index that was set when the group was pushed.
 func (c *Canvas) SetZIndex(zindex int) {
 	c.zindex = zindex
 }
@@ -640,13 +854,16 @@ func (c *Canvas) SetZIndex(zindex int) {
 // Fit shrinks the canvas' size so all elements fit with a given margin in millimeters.
 func (c *Canvas) Fit(margin float64) {
 	rect := Rect{}
+	first := true
 	// TODO: slow when we have many paths (see Graph example)
 	for _, layers := range c.layers {
-		for i, l := range layers {
+		for _, l := range layers {
 			bounds := Rect{}
-			if l.path != nil {
+			if l.link != nil {
+				continue
+			} else if l.path != nil {
 				bounds = l.path.Bounds()
-				if l.style.StrokeColor.A != 0 && 0.0 < l.style.StrokeWidth {
+				if l.style.HasStroke() {
 					bounds.X -= l.style.StrokeWidth / 2.0
 					bounds.Y -= l.style.StrokeWidth / 2.0
 					bounds.W += l.style.StrokeWidth
@@ -659,16 +876,24 @@ func (c *Canvas) Fit(margin float64) {
 				bounds = Rect{0.0, 0.0, float64(size.X), float64(size.Y)}
 			}
 			bounds = bounds.Transform(l.m)
-			if i == 0 {
+			if first {
 				rect = bounds
+				first = false
 			} else {
 				rect = rect.Add(bounds)
 			}
 		}
 	}
+	translated := map[*scope]bool{}
 	for _, layers := range c.layers {
 		for i := range layers {
 			layers[i].m = Identity.Translate(-rect.X+margin, -rect.Y+margin).Mul(layers[i].m)
+			for _, s := range layers[i].scopes {
+				if !translated[s] {
+					s.m = Identity.Translate(-rect.X+margin, -rect.Y+margin).Mul(s.m)
+					translated[s] = true
+				}
+			}
 		}
 	}
 	c.W = rect.W + 2*margin
@@ -691,23 +916,75 @@ func (c *Canvas) RenderTo(r Renderer) {
 		view = viewer.View()
 	}
 
-	zindices := []int{}
-	for zindex := range c.layers {
-		zindices = append(zindices, zindex)
+	// sort layers by z-index and rendering order while keeping the layers of a group together, so that each group is pushed only once
+	layers := []layer{}
+	orders := map[int][][2]int{}
+	for _, ls := range c.layers {
+		for _, l := range ls {
+			orders[l.seq] = l.order()
+			layers = append(layers, l)
+		}
 	}
-	sort.Ints(zindices)
+	sort.Slice(layers, func(i, j int) bool {
+		a, b := orders[layers[i].seq], orders[layers[j].seq]
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k][0] != b[k][0] {
+				return a[k][0] < b[k][0]
+			} else if a[k][1] != b[k][1] {
+				return a[k][1] < b[k][1]
+			}
+		}
+		return len(a) < len(b)
+	})
+
+	linker, _ := r.(Linker)
+	var scopes []*scope
+	for _, l := range layers {
+		if l.link != nil {
+			// links are not affected by clipping paths and groups
+			if linker != nil && l.link.dest {
+				linker.RenderDestination(l.link.uri, Point{l.link.rect.X, l.link.rect.Y}, view.Mul(l.m))
+			} else if linker != nil {
+				linker.RenderLink(l.link.uri, l.link.rect, view.Mul(l.m))
+			}
+			continue
+		}
 
-	for _, zindex := range zindices {
-		for _, l := range c.layers[zindex] {
-			m := view.Mul(l.m)
-			if l.path != nil {
-				r.RenderPath(l.path, l.style, m)
-			} else if l.text != nil {
-				r.RenderText(l.text, m)
-			} else if l.img != nil {
-				r.RenderImage(l.img, m)
+		// pop and push the clipping paths and groups that differ from the previous layer
+		n := 0
+		for n < len(scopes) && n < len(l.scopes) && scopes[n] == l.scopes[n] {
+			n++
+		}
+		popScopes(r, scopes[n:])
+		for _, s := range l.scopes[n:] {
+			if s.group != nil {
+				r.PushGroup(*s.group, view.Mul(s.m))
+			} else {
+				r.PushClip(s.path, s.fillRule, view.Mul(s.m))
 			}
 		}
+		scopes = l.scopes
+
+		m := view.Mul(l.m)
+		if l.path != nil {
+			r.RenderPath(l.path, l.style, m)
+		} else if l.text != nil {
+			r.RenderText(l.text, m)
+		} else if l.img != nil {
+			r.RenderImage(l.img, m)
+		}
+	}
+	popScopes(r, scopes)
+}
+
+// popScopes pops the clipping paths and groups from the renderer in reverse order.
+func popScopes(r Renderer, scopes []*scope) {
+	for i := len(scopes) - 1; 0 <= i; i-- {
+		if scopes[i].group != nil {
+			r.PopGroup()
+		} else {
+			r.PopClip()
+		}
 	}
 }
 
@@ -2,6 +2,7 @@ package canvas
 
 import (
 	"image"
+	"image/color"
 	"testing"
 
 	"github.com/tdewolff/test"
@@ -83,3 +84,141 @@ func TestCanvasFit(t *testing.T) {
 	test.Float(t, c.W, 20)
 	test.Float(t, c.H, 20)
 }
+
+// recordedOps returns the operations of a recorder, where clipping paths are described by their start position and groups by their opacity.
+func recordedOps(r *Recorder) []string {
+	ops := []string{}
+	for _, op := range r.Ops {
+		switch op.Op {
+		case "clip":
+			ops = append(ops, "push "+op.Matrix.Dot(op.Path.StartPos()).String())
+		case "group":
+			ops = append(ops, "group "+num(op.Group.Opacity).String())
+		case "link":
+			ops = append(ops, "link "+op.Name+" "+op.Rect.Transform(op.Matrix).String())
+		case "dest":
+			ops = append(ops, "dest "+op.Name+" "+op.Matrix.Dot(op.Pos).String())
+		default:
+			ops = append(ops, op.Op)
+		}
+	}
+	return ops
+}
+
+func TestCanvasClip(t *testing.T) {
+	c := New(100, 100)
+	ctx := NewContext(c)
+	ctx.Push()
+	ctx.MoveTo(10.0, 10.0)
+	ctx.LineTo(20.0, 10.0)
+	ctx.LineTo(20.0, 20.0)
+	ctx.Close()
+	ctx.Clip()
+	test.That(t, ctx.path.Empty(), "path must be reset after clipping")
+	ctx.DrawPath(0.0, 0.0, Rectangle(50.0, 50.0))
+	ctx.Push()
+	ctx.ClipPath(30.0, 30.0, Rectangle(10.0, 10.0))
+	ctx.DrawPath(0.0, 0.0, Rectangle(50.0, 50.0))
+	ctx.Pop()
+	ctx.DrawPath(0.0, 0.0, Rectangle(50.0, 50.0))
+	ctx.Pop()
+	ctx.Pop() // does nothing
+	ctx.DrawPath(0.0, 0.0, Rectangle(50.0, 50.0))
+
+	r := &Recorder{}
+	c.RenderTo(r)
+	test.T(t, recordedOps(r), []string{"push (10,10)", "path", "push (30,30)", "path", "pop", "path", "pop", "path"})
+
+	c.Fit(0.0)
+	r = &Recorder{}
+	c.RenderTo(r)
+	test.T(t, recordedOps(r), []string{"push (10,10)", "path", "push (30,30)", "path", "pop", "path", "pop", "path"})
+
+	// clipping paths are popped after the last layer
+	c = New(100, 100)
+	ctx = NewContext(c)
+	ctx.ClipPath(5.0, 5.0, Rectangle(10.0, 10.0))
+	ctx.DrawPath(0.0, 0.0, Rectangle(50.0, 50.0))
+	r = &Recorder{}
+	c.RenderTo(r)
+	test.T(t, recordedOps(r), []string{"push (5,5)", "path", "pop"})
+}
+
+func TestCanvasGroup(t *testing.T) {
+	c := New(100, 100)
+	ctx := NewContext(c)
+	ctx.BeginGroup(0.5)
+	ctx.DrawPath(0.0, 0.0, Rectangle(50.0, 50.0))
+	ctx.ClipPath(10.0, 10.0, Rectangle(10.0, 10.0))
+	ctx.DrawPath(0.0, 0.0, Rectangle(50.0, 50.0))
+	ctx.EndGroup() // also removes the clipping path
+	ctx.DrawPath(0.0, 0.0, Rectangle(50.0, 50.0))
+	ctx.Push()
+	ctx.BeginGroup(0.25)
+	ctx.DrawPath(0.0, 0.0, Rectangle(50.0, 50.0))
+	ctx.Pop()      // also ends the group
+	ctx.EndGroup() // does nothing
+	ctx.DrawPath(0.0, 0.0, Rectangle(50.0, 50.0))
+
+	r := &Recorder{}
+	c.RenderTo(r)
+	test.T(t, recordedOps(r), []string{"group .5", "path", "push (10,10)", "path", "pop", "end", "path", "group .25", "path", "end", "path"})
+}
+
+func TestCanvasGroupZIndex(t *testing.T) {
+	// a group is drawn once at the z-index where it was pushed, z-indices within the group only order its layers
+	img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	c := New(100, 100)
+	c.RenderPath(Rectangle(50.0, 50.0), DefaultStyle, Identity)
+	c.PushGroup(Group{Opacity: 0.5}, Identity)
+	c.SetZIndex(1)
+	c.RenderImage(img, Identity)
+	c.SetZIndex(0)
+	c.RenderPath(Rectangle(50.0, 50.0), DefaultStyle, Identity)
+	c.PopGroup()
+	c.RenderImage(img, Identity)
+	c.SetZIndex(-1)
+	c.RenderImage(img, Identity)
+	c.SetZIndex(1)
+	c.RenderPath(Rectangle(50.0, 50.0), DefaultStyle, Identity)
+
+	r := &Recorder{}
+	c.RenderTo(r)
+	test.T(t, recordedOps(r), []string{"image", "path", "group .5", "path", "image", "end", "image", "path"})
+}
+
+func TestCanvasLink(t *testing.T) {
+	c := New(100, 100)
+	ctx := NewContext(c)
+	ctx.DrawPath(0.0, 0.0, Rectangle(50.0, 50.0))
+	ctx.AddLink("https://example.com", 10.0, 20.0, 30.0, 5.0)
+	ctx.Translate(5.0, 5.0)
+	ctx.AddDestination("top", 0.0, 95.0)
+
+	r := &Recorder{}
+	c.RenderTo(r)
+	test.T(t, recordedOps(r), []string{"path", "link https://example.com (10,20)-(40,25)", "dest top (5,100)"})
+
+	// renderers that do not implement Linker ignore links
+	r = &Recorder{}
+	c.RenderTo(struct{ Renderer }{r})
+	test.T(t, recordedOps(r), []string{"path"})
+}
+
+func TestContextNativeColor(t *testing.T) {
+	c := New(100, 100)
+	ctx := NewContext(c)
+	ctx.SetFillColor(CMYK(0.0, 1.0, 1.0, 0.0))
+	test.T(t, ctx.Style.FillColor, Red)
+	test.T(t, ctx.Style.FillNative, NativeColor(CMYK(0.0, 1.0, 1.0, 0.0)))
+	ctx.SetFillColor(Blue)
+	test.T(t, ctx.Style.FillNative, nil)
+
+	spot := NewSpotColor("Reflex Blue", CMYK(1.0, 0.0, 0.0, 0.0), 0.5)
+	ctx.SetStrokeColor(spot)
+	test.T(t, ctx.Style.StrokeColor, color.RGBA{128, 255, 255, 255})
+	test.T(t, ctx.Style.StrokeNative, NativeColor(spot))
+	test.T(t, rgbaColor(NewSpotColor("Red", Red, 0.5)), color.RGBA{255, 128, 128, 255})
+	test.T(t, rgbaColor(CMYKColor{0.0, 0.0, 0.0, 1.0, 0.5}), color.RGBA{0, 0, 0, 128})
+	test.T(t, rgbaColor(NewICCColor(nil, 1.0, 0.0, 0.0)), Red)
+}
@@ -11,6 +11,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"strings"
 	"unicode"
 
 	"github.com/LaminoidStudio/Canvas"
@@ -22,15 +23,18 @@ import (
 )
 
 type Show struct {
-	Index   int     `short:"i" desc:"Font index for font collections"`
-	GlyphID uint16  `short:"g" desc:"Glyph ID"`
-	Char    string  `short:"c" desc:"Unicode character"`
-	Width   int     `desc:"Image width"`
-	PPEM    uint16  `default:"40" desc:"Pixels per em-square"`
-	Scale   int     `default:"4" desc:"Image scale"`
-	Ratio   float64 `desc:"Image width/height ratio"`
-	Output  string  `short:"o" desc:"Output filename"`
-	Input   string  `index:"0" desc:"Input file"`
+	Index      int     `short:"i" desc:"Font index for font collections"`
+	GlyphID    uint16  `short:"g" desc:"Glyph ID"`
+	Char       string  `short:"c" desc:"Unicode character"`
+	Variations string  `short:"v" desc:"Variations for variable fonts, such as wght=700"`
+	Instance   int     `default:"-1" desc:"Named instance index for variable fonts"`
+	Width      int     `desc:"Image width"`
+	PPEM       uint16  `default:"40" desc:"Pixels per em-square"`
+	Hinting    string  `default:"none" desc:"Hinting at the given PPEM: none, font (TrueType instructions), or auto"`
+	Scale      int     `default:"4" desc:"Image scale"`
+	Ratio      float64 `desc:"Image width/height ratio"`
+	Output     string  `short:"o" desc:"Output filename"`
+	Input      string  `index:"0" desc:"Input file"`
 }
 
 type Info struct {
@@ -42,10 +46,19 @@ type Info struct {
 	Input   string `index:"0" desc:"Input file"`
 }
 
+type Convert struct {
+	Index  int    `short:"i" desc:"Font index for font collections"`
+	Format string `short:"f" desc:"Output format: ttf, otf, woff, or woff2, by default taken from the output filename"`
+	Chars  string `short:"c" desc:"Subset the font to the glyphs of these characters"`
+	Output string `short:"o" desc:"Output filename"`
+	Input  string `index:"0" desc:"Input file"`
+}
+
 func main() {
 	root := argp.New("Toolkit for TTF and OTF files")
 	root.AddCmd(&Show{}, "show", "Show glyphs in terminal or output to image")
 	root.AddCmd(&Info{}, "info", "Get font info")
+	root.AddCmd(&Convert{}, "convert", "Convert between TTF, OTF, WOFF, and WOFF2 formats")
 	root.Parse()
 	root.PrintHelp()
 }
@@ -63,6 +76,35 @@ func (cmd *Show) Run() error {
 		return err
 	}
 
+	if cmd.Instance != -1 {
+		if err := sfnt.SetNamedInstance(cmd.Instance); err != nil {
+			return err
+		}
+	}
+	if cmd.Variations != "" {
+		values, err := font.ParseVariations(cmd.Variations)
+		if err != nil {
+			return err
+		}
+		variations := sfnt.Variations()
+		for tag, value := range values {
+			variations[tag] = value
+		}
+		sfnt.SetVariations(variations)
+	}
+
+	var hinting font.Hinting
+	switch cmd.Hinting {
+	case "none":
+		hinting = font.NoHinting
+	case "font":
+		hinting = font.FontHinting
+	case "auto":
+		hinting = font.AutoHinting
+	default:
+		return fmt.Errorf("hinting must be none, font, or auto")
+	}
+
 	if cmd.Char != "" {
 		rs := []rune(cmd.Char)
 		if len(rs) != 1 {
@@ -75,6 +117,14 @@ func (cmd *Show) Run() error {
 	if name := sfnt.GlyphName(cmd.GlyphID); name != "" {
 		fmt.Println("Name:", name)
 	}
+	if sfnt.IsVariable() {
+		variations := sfnt.Variations()
+		fmt.Printf("Variations:")
+		for _, axis := range sfnt.VariationAxes() {
+			fmt.Printf(" %s=%v", strings.TrimSpace(axis.Tag), variations[axis.Tag])
+		}
+		fmt.Printf("\n")
+	}
 
 	if cmd.Width != 0 {
 		if cmd.Width < 0 {
@@ -101,7 +151,7 @@ func (cmd *Show) Run() error {
 	f := float64(cmd.PPEM) / float64(sfnt.Head.UnitsPerEm)
 
 	p := &canvas.Path{}
-	err = sfnt.GlyphPath(p, cmd.GlyphID, cmd.PPEM, 0.0, float64(descent), 1.0, font.NoHinting)
+	err = sfnt.GlyphPath(p, cmd.GlyphID, cmd.PPEM, 0.0, float64(descent), 1.0, hinting)
 	if err != nil {
 		return err
 	}
@@ -199,6 +249,59 @@ func printASCII(img image.Image) {
 	}
 }
 
+func (cmd *Convert) Run() error {
+	if cmd.Output == "" {
+		return fmt.Errorf("output filename must be set")
+	}
+	format := strings.ToLower(cmd.Format)
+	if format == "" {
+		format = strings.ToLower(strings.TrimPrefix(filepath.Ext(cmd.Output), "."))
+	}
+	if format != "ttf" && format != "otf" && format != "woff" && format != "woff2" {
+		return fmt.Errorf("output format must be TTF, OTF, WOFF, or WOFF2")
+	}
+
+	b, err := ioutil.ReadFile(cmd.Input)
+	if err != nil {
+		return err
+	}
+
+	sfnt, err := font.ParseFont(b, cmd.Index)
+	if err != nil {
+		return err
+	}
+
+	out := sfnt.Data
+	if cmd.Chars != "" {
+		glyphIDs := []uint16{0}
+		seen := map[uint16]bool{0: true}
+		for _, r := range cmd.Chars {
+			if glyphID := sfnt.GlyphIndex(r); !seen[glyphID] {
+				glyphIDs = append(glyphIDs, glyphID)
+				seen[glyphID] = true
+			}
+		}
+		out, _ = sfnt.Subset(glyphIDs, font.WriteMinTables)
+	}
+
+	switch format {
+	case "woff":
+		if out, err = font.WriteWOFF(out); err != nil {
+			return fmt.Errorf("WOFF: %w", err)
+		}
+	case "woff2":
+		if out, err = font.WriteWOFF2(out); err != nil {
+			return fmt.Errorf("WOFF2: %w", err)
+		}
+	}
+
+	if err := ioutil.WriteFile(cmd.Output, out, 0644); err != nil {
+		return err
+	}
+	fmt.Printf("%s: %d bytes\n%s: %d bytes (%.1f%%)\n", cmd.Input, len(b), cmd.Output, len(out), 100.0*float64(len(out))/float64(len(b)))
+	return nil
+}
+
 func (cmd *Info) Run() error {
 	b, err := ioutil.ReadFile(cmd.Input)
 	if err != nil {
@@ -233,5 +336,20 @@ func (cmd *Info) Run() error {
 		length := r.ReadUint32()
 		fmt.Printf("  %2d  %s  checksum=0x%08X  offset=%*d  length=%*d\n", i, tag, checksum, nLen, offset, nLen, length)
 	}
+
+	if sfnt.IsVariable() {
+		fmt.Printf("\nVariation axes:\n")
+		for _, axis := range sfnt.VariationAxes() {
+			fmt.Printf("  %s  min=%v  default=%v  max=%v\n", axis.Tag, axis.Min, axis.Default, axis.Max)
+		}
+		fmt.Printf("\nNamed instances:\n")
+		for i, instance := range sfnt.NamedInstances() {
+			name := ""
+			if records := sfnt.Name.Get(instance.SubfamilyNameID); 0 < len(records) {
+				name = records[0].String()
+			}
+			fmt.Printf("  %2d  %v  %s\n", i, instance.Coordinates, name)
+		}
+	}
 	return nil
 }
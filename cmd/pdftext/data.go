@@ -1,4286 +0,0 @@
-package main
-
-var charsetName = map[string]rune{
-	"A":                                   '\u0041', // A
-	"AE":                                  '\u00c6', // Æ
-	"AEacute":                             '\u01fc', // Ǽ
-	"AEmacron":                            '\u01e2', // Ǣ
-	"AEsmall":                             '\uf7e6', // 
-	"Aacute":                              '\u00c1', // Á
-	"Aacutesmall":                         '\uf7e1', // 
-	"Abreve":                              '\u0102', // Ă
-	"Abreveacute":                         '\u1eae', // Ắ
-	"Abrevecyrillic":                      '\u04d0', // Ӑ
-	"Abrevedotbelow":                      '\u1eb6', // Ặ
-	"Abrevegrave":                         '\u1eb0', // Ằ
-	"Abrevehookabove":                     '\u1eb2', // Ẳ
-	"Abrevetilde":                         '\u1eb4', // Ẵ
-	"Acaron":                              '\u01cd', // Ǎ
-	"Acircle":                             '\u24b6', // Ⓐ
-	"Acircumflex":                         '\u00c2', // Â
-	"Acircumflexacute":                    '\u1ea4', // Ấ
-	"Acircumflexdotbelow":                 '\u1eac', // Ậ
-	"Acircumflexgrave":                    '\u1ea6', // Ầ
-	"Acircumflexhookabove":                '\u1ea8', // Ẩ
-	"Acircumflexsmall":                    '\uf7e2', // 
-	"Acircumflextilde":                    '\u1eaa', // Ẫ
-	"Acute":                               '\uf6c9', // 
-	"Acutesmall":                          '\uf7b4', // 
-	"Acyrillic":                           '\u0410', // А
-	"Adblgrave":                           '\u0200', // Ȁ
-	"Adieresis":                           '\u00c4', // Ä
-	"Adieresiscyrillic":                   '\u04d2', // Ӓ
-	"Adieresismacron":                     '\u01de', // Ǟ
-	"Adieresissmall":                      '\uf7e4', // 
-	"Adotbelow":                           '\u1ea0', // Ạ
-	"Adotmacron":                          '\u01e0', // Ǡ
-	"Agrave":                              '\u00c0', // À
-	"Agravesmall":                         '\uf7e0', // 
-	"Ahookabove":                          '\u1ea2', // Ả
-	"Aiecyrillic":                         '\u04d4', // Ӕ
-	"Ainvertedbreve":                      '\u0202', // Ȃ
-	"Alpha":                               '\u0391', // Α
-	"Alphatonos":                          '\u0386', // Ά
-	"Amacron":                             '\u0100', // Ā
-	"Amonospace":                          '\uff21', // Ａ
-	"Aogonek":                             '\u0104', // Ą
-	"Aring":                               '\u00c5', // Å
-	"Aringacute":                          '\u01fa', // Ǻ
-	"Aringbelow":                          '\u1e00', // Ḁ
-	"Aringsmall":                          '\uf7e5', // 
-	"Asmall":                              '\uf761', // 
-	"Atilde":                              '\u00c3', // Ã
-	"Atildesmall":                         '\uf7e3', // 
-	"Aybarmenian":                         '\u0531', // Ա
-	"B":                                   '\u0042', // B
-	"Bcircle":                             '\u24b7', // Ⓑ
-	"Bdotaccent":                          '\u1e02', // Ḃ
-	"Bdotbelow":                           '\u1e04', // Ḅ
-	"Becyrillic":                          '\u0411', // Б
-	"Benarmenian":                         '\u0532', // Բ
-	"Beta":                                '\u0392', // Β
-	"Bhook":                               '\u0181', // Ɓ
-	"Blinebelow":                          '\u1e06', // Ḇ
-	"Bmonospace":                          '\uff22', // Ｂ
-	"Brevesmall":                          '\uf6f4', // 
-	"Bsmall":                              '\uf762', // 
-	"Btopbar":                             '\u0182', // Ƃ
-	"C":                                   '\u0043', // C
-	"Caarmenian":                          '\u053e', // Ծ
-	"Cacute":                              '\u0106', // Ć
-	"Caron":                               '\uf6ca', // 
-	"Caronsmall":                          '\uf6f5', // 
-	"Ccaron":                              '\u010c', // Č
-	"Ccedilla":                            '\u00c7', // Ç
-	"Ccedillaacute":                       '\u1e08', // Ḉ
-	"Ccedillasmall":                       '\uf7e7', // 
-	"Ccircle":                             '\u24b8', // Ⓒ
-	"Ccircumflex":                         '\u0108', // Ĉ
-	"Cdot":                                '\u010a', // Ċ
-	"Cdotaccent":                          '\u010a', // Ċ
-	"Cedillasmall":                        '\uf7b8', // 
-	"Chaarmenian":                         '\u0549', // Չ
-	"Cheabkhasiancyrillic":                '\u04bc', // Ҽ
-	"Checyrillic":                         '\u0427', // Ч
-	"Chedescenderabkhasiancyrillic":       '\u04be', // Ҿ
-	"Chedescendercyrillic":                '\u04b6', // Ҷ
-	"Chedieresiscyrillic":                 '\u04f4', // Ӵ
-	"Cheharmenian":                        '\u0543', // Ճ
-	"Chekhakassiancyrillic":               '\u04cb', // Ӌ
-	"Cheverticalstrokecyrillic":           '\u04b8', // Ҹ
-	"Chi":                                 '\u03a7', // Χ
-	"Chook":                               '\u0187', // Ƈ
-	"Circumflexsmall":                     '\uf6f6', // 
-	"Cmonospace":                          '\uff23', // Ｃ
-	"Coarmenian":                          '\u0551', // Ց
-	"Csmall":                              '\uf763', // 
-	"D":                                   '\u0044', // D
-	"DZ":                                  '\u01f1', // Ǳ
-	"DZcaron":                             '\u01c4', // Ǆ
-	"Daarmenian":                          '\u0534', // Դ
-	"Dafrican":                            '\u0189', // Ɖ
-	"Dcaron":                              '\u010e', // Ď
-	"Dcedilla":                            '\u1e10', // Ḑ
-	"Dcircle":                             '\u24b9', // Ⓓ
-	"Dcircumflexbelow":                    '\u1e12', // Ḓ
-	"Dcroat":                              '\u0110', // Đ
-	"Ddotaccent":                          '\u1e0a', // Ḋ
-	"Ddotbelow":                           '\u1e0c', // Ḍ
-	"Decyrillic":                          '\u0414', // Д
-	"Deicoptic":                           '\u03ee', // Ϯ
-	"Delta":                               '\u2206', // ∆
-	"Deltagreek":                          '\u0394', // Δ
-	"Dhook":                               '\u018a', // Ɗ
-	"Dieresis":                            '\uf6cb', // 
-	"DieresisAcute":                       '\uf6cc', // 
-	"DieresisGrave":                       '\uf6cd', // 
-	"Dieresissmall":                       '\uf7a8', // 
-	"Digammagreek":                        '\u03dc', // Ϝ
-	"Djecyrillic":                         '\u0402', // Ђ
-	"Dlinebelow":                          '\u1e0e', // Ḏ
-	"Dmonospace":                          '\uff24', // Ｄ
-	"Dotaccentsmall":                      '\uf6f7', // 
-	"Dslash":                              '\u0110', // Đ
-	"Dsmall":                              '\uf764', // 
-	"Dtopbar":                             '\u018b', // Ƌ
-	"Dz":                                  '\u01f2', // ǲ
-	"Dzcaron":                             '\u01c5', // ǅ
-	"Dzeabkhasiancyrillic":                '\u04e0', // Ӡ
-	"Dzecyrillic":                         '\u0405', // Ѕ
-	"Dzhecyrillic":                        '\u040f', // Џ
-	"E":                                   '\u0045', // E
-	"Eacute":                              '\u00c9', // É
-	"Eacutesmall":                         '\uf7e9', // 
-	"Ebreve":                              '\u0114', // Ĕ
-	"Ecaron":                              '\u011a', // Ě
-	"Ecedillabreve":                       '\u1e1c', // Ḝ
-	"Echarmenian":                         '\u0535', // Ե
-	"Ecircle":                             '\u24ba', // Ⓔ
-	"Ecircumflex":                         '\u00ca', // Ê
-	"Ecircumflexacute":                    '\u1ebe', // Ế
-	"Ecircumflexbelow":                    '\u1e18', // Ḙ
-	"Ecircumflexdotbelow":                 '\u1ec6', // Ệ
-	"Ecircumflexgrave":                    '\u1ec0', // Ề
-	"Ecircumflexhookabove":                '\u1ec2', // Ể
-	"Ecircumflexsmall":                    '\uf7ea', // 
-	"Ecircumflextilde":                    '\u1ec4', // Ễ
-	"Ecyrillic":                           '\u0404', // Є
-	"Edblgrave":                           '\u0204', // Ȅ
-	"Edieresis":                           '\u00cb', // Ë
-	"Edieresissmall":                      '\uf7eb', // 
-	"Edot":                                '\u0116', // Ė
-	"Edotaccent":                          '\u0116', // Ė
-	"Edotbelow":                           '\u1eb8', // Ẹ
-	"Efcyrillic":                          '\u0424', // Ф
-	"Egrave":                              '\u00c8', // È
-	"Egravesmall":                         '\uf7e8', // 
-	"Eharmenian":                          '\u0537', // Է
-	"Ehookabove":                          '\u1eba', // Ẻ
-	"Eightroman":                          '\u2167', // Ⅷ
-	"Einvertedbreve":                      '\u0206', // Ȇ
-	"Eiotifiedcyrillic":                   '\u0464', // Ѥ
-	"Elcyrillic":                          '\u041b', // Л
-	"Elevenroman":                         '\u216a', // Ⅺ
-	"Emacron":                             '\u0112', // Ē
-	"Emacronacute":                        '\u1e16', // Ḗ
-	"Emacrongrave":                        '\u1e14', // Ḕ
-	"Emcyrillic":                          '\u041c', // М
-	"Emonospace":                          '\uff25', // Ｅ
-	"Encyrillic":                          '\u041d', // Н
-	"Endescendercyrillic":                 '\u04a2', // Ң
-	"Eng":                                 '\u014a', // Ŋ
-	"Enghecyrillic":                       '\u04a4', // Ҥ
-	"Enhookcyrillic":                      '\u04c7', // Ӈ
-	"Eogonek":                             '\u0118', // Ę
-	"Eopen":                               '\u0190', // Ɛ
-	"Epsilon":                             '\u0395', // Ε
-	"Epsilontonos":                        '\u0388', // Έ
-	"Ercyrillic":                          '\u0420', // Р
-	"Ereversed":                           '\u018e', // Ǝ
-	"Ereversedcyrillic":                   '\u042d', // Э
-	"Escyrillic":                          '\u0421', // С
-	"Esdescendercyrillic":                 '\u04aa', // Ҫ
-	"Esh":                                 '\u01a9', // Ʃ
-	"Esmall":                              '\uf765', // 
-	"Eta":                                 '\u0397', // Η
-	"Etarmenian":                          '\u0538', // Ը
-	"Etatonos":                            '\u0389', // Ή
-	"Eth":                                 '\u00d0', // Ð
-	"Ethsmall":                            '\uf7f0', // 
-	"Etilde":                              '\u1ebc', // Ẽ
-	"Etildebelow":                         '\u1e1a', // Ḛ
-	"Euro":                                '\u20ac', // €
-	"Ezh":                                 '\u01b7', // Ʒ
-	"Ezhcaron":                            '\u01ee', // Ǯ
-	"Ezhreversed":                         '\u01b8', // Ƹ
-	"F":                                   '\u0046', // F
-	"Fcircle":                             '\u24bb', // Ⓕ
-	"Fdotaccent":                          '\u1e1e', // Ḟ
-	"Feharmenian":                         '\u0556', // Ֆ
-	"Feicoptic":                           '\u03e4', // Ϥ
-	"Fhook":                               '\u0191', // Ƒ
-	"Fitacyrillic":                        '\u0472', // Ѳ
-	"Fiveroman":                           '\u2164', // Ⅴ
-	"Fmonospace":                          '\uff26', // Ｆ
-	"Fourroman":                           '\u2163', // Ⅳ
-	"Fsmall":                              '\uf766', // 
-	"G":                                   '\u0047', // G
-	"GBsquare":                            '\u3387', // ㎇
-	"Gacute":                              '\u01f4', // Ǵ
-	"Gamma":                               '\u0393', // Γ
-	"Gammaafrican":                        '\u0194', // Ɣ
-	"Gangiacoptic":                        '\u03ea', // Ϫ
-	"Gbreve":                              '\u011e', // Ğ
-	"Gcaron":                              '\u01e6', // Ǧ
-	"Gcedilla":                            '\u0122', // Ģ
-	"Gcircle":                             '\u24bc', // Ⓖ
-	"Gcircumflex":                         '\u011c', // Ĝ
-	"Gcommaaccent":                        '\u0122', // Ģ
-	"Gdot":                                '\u0120', // Ġ
-	"Gdotaccent":                          '\u0120', // Ġ
-	"Gecyrillic":                          '\u0413', // Г
-	"Ghadarmenian":                        '\u0542', // Ղ
-	"Ghemiddlehookcyrillic":               '\u0494', // Ҕ
-	"Ghestrokecyrillic":                   '\u0492', // Ғ
-	"Gheupturncyrillic":                   '\u0490', // Ґ
-	"Ghook":                               '\u0193', // Ɠ
-	"Gimarmenian":                         '\u0533', // Գ
-	"Gjecyrillic":                         '\u0403', // Ѓ
-	"Gmacron":                             '\u1e20', // Ḡ
-	"Gmonospace":                          '\uff27', // Ｇ
-	"Grave":                               '\uf6ce', // 
-	"Gravesmall":                          '\uf760', // 
-	"Gsmall":                              '\uf767', // 
-	"Gsmallhook":                          '\u029b', // ʛ
-	"Gstroke":                             '\u01e4', // Ǥ
-	"H":                                   '\u0048', // H
-	"H18533":                              '\u25cf', // ●
-	"H18543":                              '\u25aa', // ▪
-	"H18551":                              '\u25ab', // ▫
-	"H22073":                              '\u25a1', // □
-	"HPsquare":                            '\u33cb', // ㏋
-	"Haabkhasiancyrillic":                 '\u04a8', // Ҩ
-	"Hadescendercyrillic":                 '\u04b2', // Ҳ
-	"Hardsigncyrillic":                    '\u042a', // Ъ
-	"Hbar":                                '\u0126', // Ħ
-	"Hbrevebelow":                         '\u1e2a', // Ḫ
-	"Hcedilla":                            '\u1e28', // Ḩ
-	"Hcircle":                             '\u24bd', // Ⓗ
-	"Hcircumflex":                         '\u0124', // Ĥ
-	"Hdieresis":                           '\u1e26', // Ḧ
-	"Hdotaccent":                          '\u1e22', // Ḣ
-	"Hdotbelow":                           '\u1e24', // Ḥ
-	"Hmonospace":                          '\uff28', // Ｈ
-	"Hoarmenian":                          '\u0540', // Հ
-	"Horicoptic":                          '\u03e8', // Ϩ
-	"Hsmall":                              '\uf768', // 
-	"Hungarumlaut":                        '\uf6cf', // 
-	"Hungarumlautsmall":                   '\uf6f8', // 
-	"Hzsquare":                            '\u3390', // ㎐
-	"I":                                   '\u0049', // I
-	"IAcyrillic":                          '\u042f', // Я
-	"IJ":                                  '\u0132', // Ĳ
-	"IUcyrillic":                          '\u042e', // Ю
-	"Iacute":                              '\u00cd', // Í
-	"Iacutesmall":                         '\uf7ed', // 
-	"Ibreve":                              '\u012c', // Ĭ
-	"Icaron":                              '\u01cf', // Ǐ
-	"Icircle":                             '\u24be', // Ⓘ
-	"Icircumflex":                         '\u00ce', // Î
-	"Icircumflexsmall":                    '\uf7ee', // 
-	"Icyrillic":                           '\u0406', // І
-	"Idblgrave":                           '\u0208', // Ȉ
-	"Idieresis":                           '\u00cf', // Ï
-	"Idieresisacute":                      '\u1e2e', // Ḯ
-	"Idieresiscyrillic":                   '\u04e4', // Ӥ
-	"Idieresissmall":                      '\uf7ef', // 
-	"Idot":                                '\u0130', // İ
-	"Idotaccent":                          '\u0130', // İ
-	"Idotbelow":                           '\u1eca', // Ị
-	"Iebrevecyrillic":                     '\u04d6', // Ӗ
-	"Iecyrillic":                          '\u0415', // Е
-	"Ifraktur":                            '\u2111', // ℑ
-	"Igrave":                              '\u00cc', // Ì
-	"Igravesmall":                         '\uf7ec', // 
-	"Ihookabove":                          '\u1ec8', // Ỉ
-	"Iicyrillic":                          '\u0418', // И
-	"Iinvertedbreve":                      '\u020a', // Ȋ
-	"Iishortcyrillic":                     '\u0419', // Й
-	"Imacron":                             '\u012a', // Ī
-	"Imacroncyrillic":                     '\u04e2', // Ӣ
-	"Imonospace":                          '\uff29', // Ｉ
-	"Iniarmenian":                         '\u053b', // Ի
-	"Iocyrillic":                          '\u0401', // Ё
-	"Iogonek":                             '\u012e', // Į
-	"Iota":                                '\u0399', // Ι
-	"Iotaafrican":                         '\u0196', // Ɩ
-	"Iotadieresis":                        '\u03aa', // Ϊ
-	"Iotatonos":                           '\u038a', // Ί
-	"Ismall":                              '\uf769', // 
-	"Istroke":                             '\u0197', // Ɨ
-	"Itilde":                              '\u0128', // Ĩ
-	"Itildebelow":                         '\u1e2c', // Ḭ
-	"Izhitsacyrillic":                     '\u0474', // Ѵ
-	"Izhitsadblgravecyrillic":             '\u0476', // Ѷ
-	"J":                                   '\u004a', // J
-	"Jaarmenian":                          '\u0541', // Ձ
-	"Jcircle":                             '\u24bf', // Ⓙ
-	"Jcircumflex":                         '\u0134', // Ĵ
-	"Jecyrillic":                          '\u0408', // Ј
-	"Jheharmenian":                        '\u054b', // Ջ
-	"Jmonospace":                          '\uff2a', // Ｊ
-	"Jsmall":                              '\uf76a', // 
-	"K":                                   '\u004b', // K
-	"KBsquare":                            '\u3385', // ㎅
-	"KKsquare":                            '\u33cd', // ㏍
-	"Kabashkircyrillic":                   '\u04a0', // Ҡ
-	"Kacute":                              '\u1e30', // Ḱ
-	"Kacyrillic":                          '\u041a', // К
-	"Kadescendercyrillic":                 '\u049a', // Қ
-	"Kahookcyrillic":                      '\u04c3', // Ӄ
-	"Kappa":                               '\u039a', // Κ
-	"Kastrokecyrillic":                    '\u049e', // Ҟ
-	"Kaverticalstrokecyrillic":            '\u049c', // Ҝ
-	"Kcaron":                              '\u01e8', // Ǩ
-	"Kcedilla":                            '\u0136', // Ķ
-	"Kcircle":                             '\u24c0', // Ⓚ
-	"Kcommaaccent":                        '\u0136', // Ķ
-	"Kdotbelow":                           '\u1e32', // Ḳ
-	"Keharmenian":                         '\u0554', // Ք
-	"Kenarmenian":                         '\u053f', // Կ
-	"Khacyrillic":                         '\u0425', // Х
-	"Kheicoptic":                          '\u03e6', // Ϧ
-	"Khook":                               '\u0198', // Ƙ
-	"Kjecyrillic":                         '\u040c', // Ќ
-	"Klinebelow":                          '\u1e34', // Ḵ
-	"Kmonospace":                          '\uff2b', // Ｋ
-	"Koppacyrillic":                       '\u0480', // Ҁ
-	"Koppagreek":                          '\u03de', // Ϟ
-	"Ksicyrillic":                         '\u046e', // Ѯ
-	"Ksmall":                              '\uf76b', // 
-	"L":                                   '\u004c', // L
-	"LJ":                                  '\u01c7', // Ǉ
-	"LL":                                  '\uf6bf', // 
-	"Lacute":                              '\u0139', // Ĺ
-	"Lambda":                              '\u039b', // Λ
-	"Lcaron":                              '\u013d', // Ľ
-	"Lcedilla":                            '\u013b', // Ļ
-	"Lcircle":                             '\u24c1', // Ⓛ
-	"Lcircumflexbelow":                    '\u1e3c', // Ḽ
-	"Lcommaaccent":                        '\u013b', // Ļ
-	"Ldot":                                '\u013f', // Ŀ
-	"Ldotaccent":                          '\u013f', // Ŀ
-	"Ldotbelow":                           '\u1e36', // Ḷ
-	"Ldotbelowmacron":                     '\u1e38', // Ḹ
-	"Liwnarmenian":                        '\u053c', // Լ
-	"Lj":                                  '\u01c8', // ǈ
-	"Ljecyrillic":                         '\u0409', // Љ
-	"Llinebelow":                          '\u1e3a', // Ḻ
-	"Lmonospace":                          '\uff2c', // Ｌ
-	"Lslash":                              '\u0141', // Ł
-	"Lslashsmall":                         '\uf6f9', // 
-	"Lsmall":                              '\uf76c', // 
-	"M":                                   '\u004d', // M
-	"MBsquare":                            '\u3386', // ㎆
-	"Macron":                              '\uf6d0', // 
-	"Macronsmall":                         '\uf7af', // 
-	"Macute":                              '\u1e3e', // Ḿ
-	"Mcircle":                             '\u24c2', // Ⓜ
-	"Mdotaccent":                          '\u1e40', // Ṁ
-	"Mdotbelow":                           '\u1e42', // Ṃ
-	"Menarmenian":                         '\u0544', // Մ
-	"Mmonospace":                          '\uff2d', // Ｍ
-	"Msmall":                              '\uf76d', // 
-	"Mturned":                             '\u019c', // Ɯ
-	"Mu":                                  '\u039c', // Μ
-	"N":                                   '\u004e', // N
-	"NJ":                                  '\u01ca', // Ǌ
-	"Nacute":                              '\u0143', // Ń
-	"Ncaron":                              '\u0147', // Ň
-	"Ncedilla":                            '\u0145', // Ņ
-	"Ncircle":                             '\u24c3', // Ⓝ
-	"Ncircumflexbelow":                    '\u1e4a', // Ṋ
-	"Ncommaaccent":                        '\u0145', // Ņ
-	"Ndotaccent":                          '\u1e44', // Ṅ
-	"Ndotbelow":                           '\u1e46', // Ṇ
-	"Nhookleft":                           '\u019d', // Ɲ
-	"Nineroman":                           '\u2168', // Ⅸ
-	"Nj":                                  '\u01cb', // ǋ
-	"Njecyrillic":                         '\u040a', // Њ
-	"Nlinebelow":                          '\u1e48', // Ṉ
-	"Nmonospace":                          '\uff2e', // Ｎ
-	"Nowarmenian":                         '\u0546', // Ն
-	"Nsmall":                              '\uf76e', // 
-	"Ntilde":                              '\u00d1', // Ñ
-	"Ntildesmall":                         '\uf7f1', // 
-	"Nu":                                  '\u039d', // Ν
-	"O":                                   '\u004f', // O
-	"OE":                                  '\u0152', // Œ
-	"OEsmall":                             '\uf6fa', // 
-	"Oacute":                              '\u00d3', // Ó
-	"Oacutesmall":                         '\uf7f3', // 
-	"Obarredcyrillic":                     '\u04e8', // Ө
-	"Obarreddieresiscyrillic":             '\u04ea', // Ӫ
-	"Obreve":                              '\u014e', // Ŏ
-	"Ocaron":                              '\u01d1', // Ǒ
-	"Ocenteredtilde":                      '\u019f', // Ɵ
-	"Ocircle":                             '\u24c4', // Ⓞ
-	"Ocircumflex":                         '\u00d4', // Ô
-	"Ocircumflexacute":                    '\u1ed0', // Ố
-	"Ocircumflexdotbelow":                 '\u1ed8', // Ộ
-	"Ocircumflexgrave":                    '\u1ed2', // Ồ
-	"Ocircumflexhookabove":                '\u1ed4', // Ổ
-	"Ocircumflexsmall":                    '\uf7f4', // 
-	"Ocircumflextilde":                    '\u1ed6', // Ỗ
-	"Ocyrillic":                           '\u041e', // О
-	"Odblacute":                           '\u0150', // Ő
-	"Odblgrave":                           '\u020c', // Ȍ
-	"Odieresis":                           '\u00d6', // Ö
-	"Odieresiscyrillic":                   '\u04e6', // Ӧ
-	"Odieresissmall":                      '\uf7f6', // 
-	"Odotbelow":                           '\u1ecc', // Ọ
-	"Ogoneksmall":                         '\uf6fb', // 
-	"Ograve":                              '\u00d2', // Ò
-	"Ogravesmall":                         '\uf7f2', // 
-	"Oharmenian":                          '\u0555', // Օ
-	"Ohm":                                 '\u2126', // Ω
-	"Ohookabove":                          '\u1ece', // Ỏ
-	"Ohorn":                               '\u01a0', // Ơ
-	"Ohornacute":                          '\u1eda', // Ớ
-	"Ohorndotbelow":                       '\u1ee2', // Ợ
-	"Ohorngrave":                          '\u1edc', // Ờ
-	"Ohornhookabove":                      '\u1ede', // Ở
-	"Ohorntilde":                          '\u1ee0', // Ỡ
-	"Ohungarumlaut":                       '\u0150', // Ő
-	"Oi":                                  '\u01a2', // Ƣ
-	"Oinvertedbreve":                      '\u020e', // Ȏ
-	"Omacron":                             '\u014c', // Ō
-	"Omacronacute":                        '\u1e52', // Ṓ
-	"Omacrongrave":                        '\u1e50', // Ṑ
-	"Omega":                               '\u2126', // Ω
-	"Omegacyrillic":                       '\u0460', // Ѡ
-	"Omegagreek":                          '\u03a9', // Ω
-	"Omegaroundcyrillic":                  '\u047a', // Ѻ
-	"Omegatitlocyrillic":                  '\u047c', // Ѽ
-	"Omegatonos":                          '\u038f', // Ώ
-	"Omicron":                             '\u039f', // Ο
-	"Omicrontonos":                        '\u038c', // Ό
-	"Omonospace":                          '\uff2f', // Ｏ
-	"Oneroman":                            '\u2160', // Ⅰ
-	"Oogonek":                             '\u01ea', // Ǫ
-	"Oogonekmacron":                       '\u01ec', // Ǭ
-	"Oopen":                               '\u0186', // Ɔ
-	"Oslash":                              '\u00d8', // Ø
-	"Oslashacute":                         '\u01fe', // Ǿ
-	"Oslashsmall":                         '\uf7f8', // 
-	"Osmall":                              '\uf76f', // 
-	"Ostrokeacute":                        '\u01fe', // Ǿ
-	"Otcyrillic":                          '\u047e', // Ѿ
-	"Otilde":                              '\u00d5', // Õ
-	"Otildeacute":                         '\u1e4c', // Ṍ
-	"Otildedieresis":                      '\u1e4e', // Ṏ
-	"Otildesmall":                         '\uf7f5', // 
-	"P":                                   '\u0050', // P
-	"Pacute":                              '\u1e54', // Ṕ
-	"Pcircle":                             '\u24c5', // Ⓟ
-	"Pdotaccent":                          '\u1e56', // Ṗ
-	"Pecyrillic":                          '\u041f', // П
-	"Peharmenian":                         '\u054a', // Պ
-	"Pemiddlehookcyrillic":                '\u04a6', // Ҧ
-	"Phi":                                 '\u03a6', // Φ
-	"Phook":                               '\u01a4', // Ƥ
-	"Pi":                                  '\u03a0', // Π
-	"Piwrarmenian":                        '\u0553', // Փ
-	"Pmonospace":                          '\uff30', // Ｐ
-	"Psi":                                 '\u03a8', // Ψ
-	"Psicyrillic":                         '\u0470', // Ѱ
-	"Psmall":                              '\uf770', // 
-	"Q":                                   '\u0051', // Q
-	"Qcircle":                             '\u24c6', // Ⓠ
-	"Qmonospace":                          '\uff31', // Ｑ
-	"Qsmall":                              '\uf771', // 
-	"R":                                   '\u0052', // R
-	"Raarmenian":                          '\u054c', // Ռ
-	"Racute":                              '\u0154', // Ŕ
-	"Rcaron":                              '\u0158', // Ř
-	"Rcedilla":                            '\u0156', // Ŗ
-	"Rcircle":                             '\u24c7', // Ⓡ
-	"Rcommaaccent":                        '\u0156', // Ŗ
-	"Rdblgrave":                           '\u0210', // Ȑ
-	"Rdotaccent":                          '\u1e58', // Ṙ
-	"Rdotbelow":                           '\u1e5a', // Ṛ
-	"Rdotbelowmacron":                     '\u1e5c', // Ṝ
-	"Reharmenian":                         '\u0550', // Ր
-	"Rfraktur":                            '\u211c', // ℜ
-	"Rho":                                 '\u03a1', // Ρ
-	"Ringsmall":                           '\uf6fc', // 
-	"Rinvertedbreve":                      '\u0212', // Ȓ
-	"Rlinebelow":                          '\u1e5e', // Ṟ
-	"Rmonospace":                          '\uff32', // Ｒ
-	"Rsmall":                              '\uf772', // 
-	"Rsmallinverted":                      '\u0281', // ʁ
-	"Rsmallinvertedsuperior":              '\u02b6', // ʶ
-	"S":                                   '\u0053', // S
-	"SF010000":                            '\u250c', // ┌
-	"SF020000":                            '\u2514', // └
-	"SF030000":                            '\u2510', // ┐
-	"SF040000":                            '\u2518', // ┘
-	"SF050000":                            '\u253c', // ┼
-	"SF060000":                            '\u252c', // ┬
-	"SF070000":                            '\u2534', // ┴
-	"SF080000":                            '\u251c', // ├
-	"SF090000":                            '\u2524', // ┤
-	"SF100000":                            '\u2500', // ─
-	"SF110000":                            '\u2502', // │
-	"SF190000":                            '\u2561', // ╡
-	"SF200000":                            '\u2562', // ╢
-	"SF210000":                            '\u2556', // ╖
-	"SF220000":                            '\u2555', // ╕
-	"SF230000":                            '\u2563', // ╣
-	"SF240000":                            '\u2551', // ║
-	"SF250000":                            '\u2557', // ╗
-	"SF260000":                            '\u255d', // ╝
-	"SF270000":                            '\u255c', // ╜
-	"SF280000":                            '\u255b', // ╛
-	"SF360000":                            '\u255e', // ╞
-	"SF370000":                            '\u255f', // ╟
-	"SF380000":                            '\u255a', // ╚
-	"SF390000":                            '\u2554', // ╔
-	"SF400000":                            '\u2569', // ╩
-	"SF410000":                            '\u2566', // ╦
-	"SF420000":                            '\u2560', // ╠
-	"SF430000":                            '\u2550', // ═
-	"SF440000":                            '\u256c', // ╬
-	"SF450000":                            '\u2567', // ╧
-	"SF460000":                            '\u2568', // ╨
-	"SF470000":                            '\u2564', // ╤
-	"SF480000":                            '\u2565', // ╥
-	"SF490000":                            '\u2559', // ╙
-	"SF500000":                            '\u2558', // ╘
-	"SF510000":                            '\u2552', // ╒
-	"SF520000":                            '\u2553', // ╓
-	"SF530000":                            '\u256b', // ╫
-	"SF540000":                            '\u256a', // ╪
-	"Sacute":                              '\u015a', // Ś
-	"Sacutedotaccent":                     '\u1e64', // Ṥ
-	"Sampigreek":                          '\u03e0', // Ϡ
-	"Scaron":                              '\u0160', // Š
-	"Scarondotaccent":                     '\u1e66', // Ṧ
-	"Scaronsmall":                         '\uf6fd', // 
-	"Scedilla":                            '\u015e', // Ş
-	"Schwa":                               '\u018f', // Ə
-	"Schwacyrillic":                       '\u04d8', // Ә
-	"Schwadieresiscyrillic":               '\u04da', // Ӛ
-	"Scircle":                             '\u24c8', // Ⓢ
-	"Scircumflex":                         '\u015c', // Ŝ
-	"Scommaaccent":                        '\u0218', // Ș
-	"Sdotaccent":                          '\u1e60', // Ṡ
-	"Sdotbelow":                           '\u1e62', // Ṣ
-	"Sdotbelowdotaccent":                  '\u1e68', // Ṩ
-	"Seharmenian":                         '\u054d', // Ս
-	"Sevenroman":                          '\u2166', // Ⅶ
-	"Shaarmenian":                         '\u0547', // Շ
-	"Shacyrillic":                         '\u0428', // Ш
-	"Shchacyrillic":                       '\u0429', // Щ
-	"Sheicoptic":                          '\u03e2', // Ϣ
-	"Shhacyrillic":                        '\u04ba', // Һ
-	"Shimacoptic":                         '\u03ec', // Ϭ
-	"Sigma":                               '\u03a3', // Σ
-	"Sixroman":                            '\u2165', // Ⅵ
-	"Smonospace":                          '\uff33', // Ｓ
-	"Softsigncyrillic":                    '\u042c', // Ь
-	"Ssmall":                              '\uf773', // 
-	"Stigmagreek":                         '\u03da', // Ϛ
-	"T":                                   '\u0054', // T
-	"Tau":                                 '\u03a4', // Τ
-	"Tbar":                                '\u0166', // Ŧ
-	"Tcaron":                              '\u0164', // Ť
-	"Tcedilla":                            '\u0162', // Ţ
-	"Tcircle":                             '\u24c9', // Ⓣ
-	"Tcircumflexbelow":                    '\u1e70', // Ṱ
-	"Tcommaaccent":                        '\u0162', // Ţ
-	"Tdotaccent":                          '\u1e6a', // Ṫ
-	"Tdotbelow":                           '\u1e6c', // Ṭ
-	"Tecyrillic":                          '\u0422', // Т
-	"Tedescendercyrillic":                 '\u04ac', // Ҭ
-	"Tenroman":                            '\u2169', // Ⅹ
-	"Tetsecyrillic":                       '\u04b4', // Ҵ
-	"Theta":                               '\u0398', // Θ
-	"Thook":                               '\u01ac', // Ƭ
-	"Thorn":                               '\u00de', // Þ
-	"Thornsmall":                          '\uf7fe', // 
-	"Threeroman":                          '\u2162', // Ⅲ
-	"Tildesmall":                          '\uf6fe', // 
-	"Tiwnarmenian":                        '\u054f', // Տ
-	"Tlinebelow":                          '\u1e6e', // Ṯ
-	"Tmonospace":                          '\uff34', // Ｔ
-	"Toarmenian":                          '\u0539', // Թ
-	"Tonefive":                            '\u01bc', // Ƽ
-	"Tonesix":                             '\u0184', // Ƅ
-	"Tonetwo":                             '\u01a7', // Ƨ
-	"Tretroflexhook":                      '\u01ae', // Ʈ
-	"Tsecyrillic":                         '\u0426', // Ц
-	"Tshecyrillic":                        '\u040b', // Ћ
-	"Tsmall":                              '\uf774', // 
-	"Twelveroman":                         '\u216b', // Ⅻ
-	"Tworoman":                            '\u2161', // Ⅱ
-	"U":                                   '\u0055', // U
-	"Uacute":                              '\u00da', // Ú
-	"Uacutesmall":                         '\uf7fa', // 
-	"Ubreve":                              '\u016c', // Ŭ
-	"Ucaron":                              '\u01d3', // Ǔ
-	"Ucircle":                             '\u24ca', // Ⓤ
-	"Ucircumflex":                         '\u00db', // Û
-	"Ucircumflexbelow":                    '\u1e76', // Ṷ
-	"Ucircumflexsmall":                    '\uf7fb', // 
-	"Ucyrillic":                           '\u0423', // У
-	"Udblacute":                           '\u0170', // Ű
-	"Udblgrave":                           '\u0214', // Ȕ
-	"Udieresis":                           '\u00dc', // Ü
-	"Udieresisacute":                      '\u01d7', // Ǘ
-	"Udieresisbelow":                      '\u1e72', // Ṳ
-	"Udieresiscaron":                      '\u01d9', // Ǚ
-	"Udieresiscyrillic":                   '\u04f0', // Ӱ
-	"Udieresisgrave":                      '\u01db', // Ǜ
-	"Udieresismacron":                     '\u01d5', // Ǖ
-	"Udieresissmall":                      '\uf7fc', // 
-	"Udotbelow":                           '\u1ee4', // Ụ
-	"Ugrave":                              '\u00d9', // Ù
-	"Ugravesmall":                         '\uf7f9', // 
-	"Uhookabove":                          '\u1ee6', // Ủ
-	"Uhorn":                               '\u01af', // Ư
-	"Uhornacute":                          '\u1ee8', // Ứ
-	"Uhorndotbelow":                       '\u1ef0', // Ự
-	"Uhorngrave":                          '\u1eea', // Ừ
-	"Uhornhookabove":                      '\u1eec', // Ử
-	"Uhorntilde":                          '\u1eee', // Ữ
-	"Uhungarumlaut":                       '\u0170', // Ű
-	"Uhungarumlautcyrillic":               '\u04f2', // Ӳ
-	"Uinvertedbreve":                      '\u0216', // Ȗ
-	"Ukcyrillic":                          '\u0478', // Ѹ
-	"Umacron":                             '\u016a', // Ū
-	"Umacroncyrillic":                     '\u04ee', // Ӯ
-	"Umacrondieresis":                     '\u1e7a', // Ṻ
-	"Umonospace":                          '\uff35', // Ｕ
-	"Uogonek":                             '\u0172', // Ų
-	"Upsilon":                             '\u03a5', // Υ
-	"Upsilon1":                            '\u03d2', // ϒ
-	"Upsilonacutehooksymbolgreek":         '\u03d3', // ϓ
-	"Upsilonafrican":                      '\u01b1', // Ʊ
-	"Upsilondieresis":                     '\u03ab', // Ϋ
-	"Upsilondieresishooksymbolgreek":      '\u03d4', // ϔ
-	"Upsilonhooksymbol":                   '\u03d2', // ϒ
-	"Upsilontonos":                        '\u038e', // Ύ
-	"Uring":                               '\u016e', // Ů
-	"Ushortcyrillic":                      '\u040e', // Ў
-	"Usmall":                              '\uf775', // 
-	"Ustraightcyrillic":                   '\u04ae', // Ү
-	"Ustraightstrokecyrillic":             '\u04b0', // Ұ
-	"Utilde":                              '\u0168', // Ũ
-	"Utildeacute":                         '\u1e78', // Ṹ
-	"Utildebelow":                         '\u1e74', // Ṵ
-	"V":                                   '\u0056', // V
-	"Vcircle":                             '\u24cb', // Ⓥ
-	"Vdotbelow":                           '\u1e7e', // Ṿ
-	"Vecyrillic":                          '\u0412', // В
-	"Vewarmenian":                         '\u054e', // Վ
-	"Vhook":                               '\u01b2', // Ʋ
-	"Vmonospace":                          '\uff36', // Ｖ
-	"Voarmenian":                          '\u0548', // Ո
-	"Vsmall":                              '\uf776', // 
-	"Vtilde":                              '\u1e7c', // Ṽ
-	"W":                                   '\u0057', // W
-	"Wacute":                              '\u1e82', // Ẃ
-	"Wcircle":                             '\u24cc', // Ⓦ
-	"Wcircumflex":                         '\u0174', // Ŵ
-	"Wdieresis":                           '\u1e84', // Ẅ
-	"Wdotaccent":                          '\u1e86', // Ẇ
-	"Wdotbelow":                           '\u1e88', // Ẉ
-	"Wgrave":                              '\u1e80', // Ẁ
-	"Wmonospace":                          '\uff37', // Ｗ
-	"Wsmall":                              '\uf777', // 
-	"X":                                   '\u0058', // X
-	"Xcircle":                             '\u24cd', // Ⓧ
-	"Xdieresis":                           '\u1e8c', // Ẍ
-	"Xdotaccent":                          '\u1e8a', // Ẋ
-	"Xeharmenian":                         '\u053d', // Խ
-	"Xi":                                  '\u039e', // Ξ
-	"Xmonospace":                          '\uff38', // Ｘ
-	"Xsmall":                              '\uf778', // 
-	"Y":                                   '\u0059', // Y
-	"Yacute":                              '\u00dd', // Ý
-	"Yacutesmall":                         '\uf7fd', // 
-	"Yatcyrillic":                         '\u0462', // Ѣ
-	"Ycircle":                             '\u24ce', // Ⓨ
-	"Ycircumflex":                         '\u0176', // Ŷ
-	"Ydieresis":                           '\u0178', // Ÿ
-	"Ydieresissmall":                      '\uf7ff', // 
-	"Ydotaccent":                          '\u1e8e', // Ẏ
-	"Ydotbelow":                           '\u1ef4', // Ỵ
-	"Yericyrillic":                        '\u042b', // Ы
-	"Yerudieresiscyrillic":                '\u04f8', // Ӹ
-	"Ygrave":                              '\u1ef2', // Ỳ
-	"Yhook":                               '\u01b3', // Ƴ
-	"Yhookabove":                          '\u1ef6', // Ỷ
-	"Yiarmenian":                          '\u0545', // Յ
-	"Yicyrillic":                          '\u0407', // Ї
-	"Yiwnarmenian":                        '\u0552', // Ւ
-	"Ymonospace":                          '\uff39', // Ｙ
-	"Ysmall":                              '\uf779', // 
-	"Ytilde":                              '\u1ef8', // Ỹ
-	"Yusbigcyrillic":                      '\u046a', // Ѫ
-	"Yusbigiotifiedcyrillic":              '\u046c', // Ѭ
-	"Yuslittlecyrillic":                   '\u0466', // Ѧ
-	"Yuslittleiotifiedcyrillic":           '\u0468', // Ѩ
-	"Z":                                   '\u005a', // Z
-	"Zaarmenian":                          '\u0536', // Զ
-	"Zacute":                              '\u0179', // Ź
-	"Zcaron":                              '\u017d', // Ž
-	"Zcaronsmall":                         '\uf6ff', // 
-	"Zcircle":                             '\u24cf', // Ⓩ
-	"Zcircumflex":                         '\u1e90', // Ẑ
-	"Zdot":                                '\u017b', // Ż
-	"Zdotaccent":                          '\u017b', // Ż
-	"Zdotbelow":                           '\u1e92', // Ẓ
-	"Zecyrillic":                          '\u0417', // З
-	"Zedescendercyrillic":                 '\u0498', // Ҙ
-	"Zedieresiscyrillic":                  '\u04de', // Ӟ
-	"Zeta":                                '\u0396', // Ζ
-	"Zhearmenian":                         '\u053a', // Ժ
-	"Zhebrevecyrillic":                    '\u04c1', // Ӂ
-	"Zhecyrillic":                         '\u0416', // Ж
-	"Zhedescendercyrillic":                '\u0496', // Җ
-	"Zhedieresiscyrillic":                 '\u04dc', // Ӝ
-	"Zlinebelow":                          '\u1e94', // Ẕ
-	"Zmonospace":                          '\uff3a', // Ｚ
-	"Zsmall":                              '\uf77a', // 
-	"Zstroke":                             '\u01b5', // Ƶ
-	"a":                                   '\u0061', // a
-	"aabengali":                           '\u0986', // আ
-	"aacute":                              '\u00e1', // á
-	"aadeva":                              '\u0906', // आ
-	"aagujarati":                          '\u0a86', // આ
-	"aagurmukhi":                          '\u0a06', // ਆ
-	"aamatragurmukhi":                     '\u0a3e', // ਾ
-	"aarusquare":                          '\u3303', // ㌃
-	"aavowelsignbengali":                  '\u09be', // া
-	"aavowelsigndeva":                     '\u093e', // ा
-	"aavowelsigngujarati":                 '\u0abe', // ા
-	"abbreviationmarkarmenian":            '\u055f', // ՟
-	"abbreviationsigndeva":                '\u0970', // ॰
-	"abengali":                            '\u0985', // অ
-	"abopomofo":                           '\u311a', // ㄚ
-	"abreve":                              '\u0103', // ă
-	"abreveacute":                         '\u1eaf', // ắ
-	"abrevecyrillic":                      '\u04d1', // ӑ
-	"abrevedotbelow":                      '\u1eb7', // ặ
-	"abrevegrave":                         '\u1eb1', // ằ
-	"abrevehookabove":                     '\u1eb3', // ẳ
-	"abrevetilde":                         '\u1eb5', // ẵ
-	"acaron":                              '\u01ce', // ǎ
-	"acircle":                             '\u24d0', // ⓐ
-	"acircumflex":                         '\u00e2', // â
-	"acircumflexacute":                    '\u1ea5', // ấ
-	"acircumflexdotbelow":                 '\u1ead', // ậ
-	"acircumflexgrave":                    '\u1ea7', // ầ
-	"acircumflexhookabove":                '\u1ea9', // ẩ
-	"acircumflextilde":                    '\u1eab', // ẫ
-	"acute":                               '\u00b4', // ´
-	"acutebelowcmb":                       '\u0317', // ̗
-	"acutecmb":                            '\u0301', // ́
-	"acutecomb":                           '\u0301', // ́
-	"acutedeva":                           '\u0954', // ॔
-	"acutelowmod":                         '\u02cf', // ˏ
-	"acutetonecmb":                        '\u0341', // ́
-	"acyrillic":                           '\u0430', // а
-	"adblgrave":                           '\u0201', // ȁ
-	"addakgurmukhi":                       '\u0a71', // ੱ
-	"adeva":                               '\u0905', // अ
-	"adieresis":                           '\u00e4', // ä
-	"adieresiscyrillic":                   '\u04d3', // ӓ
-	"adieresismacron":                     '\u01df', // ǟ
-	"adotbelow":                           '\u1ea1', // ạ
-	"adotmacron":                          '\u01e1', // ǡ
-	"ae":                                  '\u00e6', // æ
-	"aeacute":                             '\u01fd', // ǽ
-	"aekorean":                            '\u3150', // ㅐ
-	"aemacron":                            '\u01e3', // ǣ
-	"afii00208":                           '\u2015', // ―
-	"afii08941":                           '\u20a4', // ₤
-	"afii10017":                           '\u0410', // А
-	"afii10018":                           '\u0411', // Б
-	"afii10019":                           '\u0412', // В
-	"afii10020":                           '\u0413', // Г
-	"afii10021":                           '\u0414', // Д
-	"afii10022":                           '\u0415', // Е
-	"afii10023":                           '\u0401', // Ё
-	"afii10024":                           '\u0416', // Ж
-	"afii10025":                           '\u0417', // З
-	"afii10026":                           '\u0418', // И
-	"afii10027":                           '\u0419', // Й
-	"afii10028":                           '\u041a', // К
-	"afii10029":                           '\u041b', // Л
-	"afii10030":                           '\u041c', // М
-	"afii10031":                           '\u041d', // Н
-	"afii10032":                           '\u041e', // О
-	"afii10033":                           '\u041f', // П
-	"afii10034":                           '\u0420', // Р
-	"afii10035":                           '\u0421', // С
-	"afii10036":                           '\u0422', // Т
-	"afii10037":                           '\u0423', // У
-	"afii10038":                           '\u0424', // Ф
-	"afii10039":                           '\u0425', // Х
-	"afii10040":                           '\u0426', // Ц
-	"afii10041":                           '\u0427', // Ч
-	"afii10042":                           '\u0428', // Ш
-	"afii10043":                           '\u0429', // Щ
-	"afii10044":                           '\u042a', // Ъ
-	"afii10045":                           '\u042b', // Ы
-	"afii10046":                           '\u042c', // Ь
-	"afii10047":                           '\u042d', // Э
-	"afii10048":                           '\u042e', // Ю
-	"afii10049":                           '\u042f', // Я
-	"afii10050":                           '\u0490', // Ґ
-	"afii10051":                           '\u0402', // Ђ
-	"afii10052":                           '\u0403', // Ѓ
-	"afii10053":                           '\u0404', // Є
-	"afii10054":                           '\u0405', // Ѕ
-	"afii10055":                           '\u0406', // І
-	"afii10056":                           '\u0407', // Ї
-	"afii10057":                           '\u0408', // Ј
-	"afii10058":                           '\u0409', // Љ
-	"afii10059":                           '\u040a', // Њ
-	"afii10060":                           '\u040b', // Ћ
-	"afii10061":                           '\u040c', // Ќ
-	"afii10062":                           '\u040e', // Ў
-	"afii10063":                           '\uf6c4', // 
-	"afii10064":                           '\uf6c5', // 
-	"afii10065":                           '\u0430', // а
-	"afii10066":                           '\u0431', // б
-	"afii10067":                           '\u0432', // в
-	"afii10068":                           '\u0433', // г
-	"afii10069":                           '\u0434', // д
-	"afii10070":                           '\u0435', // е
-	"afii10071":                           '\u0451', // ё
-	"afii10072":                           '\u0436', // ж
-	"afii10073":                           '\u0437', // з
-	"afii10074":                           '\u0438', // и
-	"afii10075":                           '\u0439', // й
-	"afii10076":                           '\u043a', // к
-	"afii10077":                           '\u043b', // л
-	"afii10078":                           '\u043c', // м
-	"afii10079":                           '\u043d', // н
-	"afii10080":                           '\u043e', // о
-	"afii10081":                           '\u043f', // п
-	"afii10082":                           '\u0440', // р
-	"afii10083":                           '\u0441', // с
-	"afii10084":                           '\u0442', // т
-	"afii10085":                           '\u0443', // у
-	"afii10086":                           '\u0444', // ф
-	"afii10087":                           '\u0445', // х
-	"afii10088":                           '\u0446', // ц
-	"afii10089":                           '\u0447', // ч
-	"afii10090":                           '\u0448', // ш
-	"afii10091":                           '\u0449', // щ
-	"afii10092":                           '\u044a', // ъ
-	"afii10093":                           '\u044b', // ы
-	"afii10094":                           '\u044c', // ь
-	"afii10095":                           '\u044d', // э
-	"afii10096":                           '\u044e', // ю
-	"afii10097":                           '\u044f', // я
-	"afii10098":                           '\u0491', // ґ
-	"afii10099":                           '\u0452', // ђ
-	"afii10100":                           '\u0453', // ѓ
-	"afii10101":                           '\u0454', // є
-	"afii10102":                           '\u0455', // ѕ
-	"afii10103":                           '\u0456', // і
-	"afii10104":                           '\u0457', // ї
-	"afii10105":                           '\u0458', // ј
-	"afii10106":                           '\u0459', // љ
-	"afii10107":                           '\u045a', // њ
-	"afii10108":                           '\u045b', // ћ
-	"afii10109":                           '\u045c', // ќ
-	"afii10110":                           '\u045e', // ў
-	"afii10145":                           '\u040f', // Џ
-	"afii10146":                           '\u0462', // Ѣ
-	"afii10147":                           '\u0472', // Ѳ
-	"afii10148":                           '\u0474', // Ѵ
-	"afii10192":                           '\uf6c6', // 
-	"afii10193":                           '\u045f', // џ
-	"afii10194":                           '\u0463', // ѣ
-	"afii10195":                           '\u0473', // ѳ
-	"afii10196":                           '\u0475', // ѵ
-	"afii10831":                           '\uf6c7', // 
-	"afii10832":                           '\uf6c8', // 
-	"afii10846":                           '\u04d9', // ә
-	"afii299":                             '\u200e', // ‎
-	"afii300":                             '\u200f', // ‏
-	"afii301":                             '\u200d', // ‍
-	"afii57381":                           '\u066a', // ٪
-	"afii57388":                           '\u060c', // ،
-	"afii57392":                           '\u0660', // ٠
-	"afii57393":                           '\u0661', // ١
-	"afii57394":                           '\u0662', // ٢
-	"afii57395":                           '\u0663', // ٣
-	"afii57396":                           '\u0664', // ٤
-	"afii57397":                           '\u0665', // ٥
-	"afii57398":                           '\u0666', // ٦
-	"afii57399":                           '\u0667', // ٧
-	"afii57400":                           '\u0668', // ٨
-	"afii57401":                           '\u0669', // ٩
-	"afii57403":                           '\u061b', // ؛
-	"afii57407":                           '\u061f', // ؟
-	"afii57409":                           '\u0621', // ء
-	"afii57410":                           '\u0622', // آ
-	"afii57411":                           '\u0623', // أ
-	"afii57412":                           '\u0624', // ؤ
-	"afii57413":                           '\u0625', // إ
-	"afii57414":                           '\u0626', // ئ
-	"afii57415":                           '\u0627', // ا
-	"afii57416":                           '\u0628', // ب
-	"afii57417":                           '\u0629', // ة
-	"afii57418":                           '\u062a', // ت
-	"afii57419":                           '\u062b', // ث
-	"afii57420":                           '\u062c', // ج
-	"afii57421":                           '\u062d', // ح
-	"afii57422":                           '\u062e', // خ
-	"afii57423":                           '\u062f', // د
-	"afii57424":                           '\u0630', // ذ
-	"afii57425":                           '\u0631', // ر
-	"afii57426":                           '\u0632', // ز
-	"afii57427":                           '\u0633', // س
-	"afii57428":                           '\u0634', // ش
-	"afii57429":                           '\u0635', // ص
-	"afii57430":                           '\u0636', // ض
-	"afii57431":                           '\u0637', // ط
-	"afii57432":                           '\u0638', // ظ
-	"afii57433":                           '\u0639', // ع
-	"afii57434":                           '\u063a', // غ
-	"afii57440":                           '\u0640', // ـ
-	"afii57441":                           '\u0641', // ف
-	"afii57442":                           '\u0642', // ق
-	"afii57443":                           '\u0643', // ك
-	"afii57444":                           '\u0644', // ل
-	"afii57445":                           '\u0645', // م
-	"afii57446":                           '\u0646', // ن
-	"afii57448":                           '\u0648', // و
-	"afii57449":                           '\u0649', // ى
-	"afii57450":                           '\u064a', // ي
-	"afii57451":                           '\u064b', // ً
-	"afii57452":                           '\u064c', // ٌ
-	"afii57453":                           '\u064d', // ٍ
-	"afii57454":                           '\u064e', // َ
-	"afii57455":                           '\u064f', // ُ
-	"afii57456":                           '\u0650', // ِ
-	"afii57457":                           '\u0651', // ّ
-	"afii57458":                           '\u0652', // ْ
-	"afii57470":                           '\u0647', // ه
-	"afii57505":                           '\u06a4', // ڤ
-	"afii57506":                           '\u067e', // پ
-	"afii57507":                           '\u0686', // چ
-	"afii57508":                           '\u0698', // ژ
-	"afii57509":                           '\u06af', // گ
-	"afii57511":                           '\u0679', // ٹ
-	"afii57512":                           '\u0688', // ڈ
-	"afii57513":                           '\u0691', // ڑ
-	"afii57514":                           '\u06ba', // ں
-	"afii57519":                           '\u06d2', // ے
-	"afii57534":                           '\u06d5', // ە
-	"afii57636":                           '\u20aa', // ₪
-	"afii57645":                           '\u05be', // ־
-	"afii57658":                           '\u05c3', // ׃
-	"afii57664":                           '\u05d0', // א
-	"afii57665":                           '\u05d1', // ב
-	"afii57666":                           '\u05d2', // ג
-	"afii57667":                           '\u05d3', // ד
-	"afii57668":                           '\u05d4', // ה
-	"afii57669":                           '\u05d5', // ו
-	"afii57670":                           '\u05d6', // ז
-	"afii57671":                           '\u05d7', // ח
-	"afii57672":                           '\u05d8', // ט
-	"afii57673":                           '\u05d9', // י
-	"afii57674":                           '\u05da', // ך
-	"afii57675":                           '\u05db', // כ
-	"afii57676":                           '\u05dc', // ל
-	"afii57677":                           '\u05dd', // ם
-	"afii57678":                           '\u05de', // מ
-	"afii57679":                           '\u05df', // ן
-	"afii57680":                           '\u05e0', // נ
-	"afii57681":                           '\u05e1', // ס
-	"afii57682":                           '\u05e2', // ע
-	"afii57683":                           '\u05e3', // ף
-	"afii57684":                           '\u05e4', // פ
-	"afii57685":                           '\u05e5', // ץ
-	"afii57686":                           '\u05e6', // צ
-	"afii57687":                           '\u05e7', // ק
-	"afii57688":                           '\u05e8', // ר
-	"afii57689":                           '\u05e9', // ש
-	"afii57690":                           '\u05ea', // ת
-	"afii57694":                           '\ufb2a', // שׁ
-	"afii57695":                           '\ufb2b', // שׂ
-	"afii57700":                           '\ufb4b', // וֹ
-	"afii57705":                           '\ufb1f', // ײַ
-	"afii57716":                           '\u05f0', // װ
-	"afii57717":                           '\u05f1', // ױ
-	"afii57718":                           '\u05f2', // ײ
-	"afii57723":                           '\ufb35', // וּ
-	"afii57793":                           '\u05b4', // ִ
-	"afii57794":                           '\u05b5', // ֵ
-	"afii57795":                           '\u05b6', // ֶ
-	"afii57796":                           '\u05bb', // ֻ
-	"afii57797":                           '\u05b8', // ָ
-	"afii57798":                           '\u05b7', // ַ
-	"afii57799":                           '\u05b0', // ְ
-	"afii57800":                           '\u05b2', // ֲ
-	"afii57801":                           '\u05b1', // ֱ
-	"afii57802":                           '\u05b3', // ֳ
-	"afii57803":                           '\u05c2', // ׂ
-	"afii57804":                           '\u05c1', // ׁ
-	"afii57806":                           '\u05b9', // ֹ
-	"afii57807":                           '\u05bc', // ּ
-	"afii57839":                           '\u05bd', // ֽ
-	"afii57841":                           '\u05bf', // ֿ
-	"afii57842":                           '\u05c0', // ׀
-	"afii57929":                           '\u02bc', // ʼ
-	"afii61248":                           '\u2105', // ℅
-	"afii61289":                           '\u2113', // ℓ
-	"afii61352":                           '\u2116', // №
-	"afii61573":                           '\u202c', // ‬
-	"afii61574":                           '\u202d', // ‭
-	"afii61575":                           '\u202e', // ‮
-	"afii61664":                           '\u200c', // ‌
-	"afii63167":                           '\u066d', // ٭
-	"afii64937":                           '\u02bd', // ʽ
-	"agrave":                              '\u00e0', // à
-	"agujarati":                           '\u0a85', // અ
-	"agurmukhi":                           '\u0a05', // ਅ
-	"ahiragana":                           '\u3042', // あ
-	"ahookabove":                          '\u1ea3', // ả
-	"aibengali":                           '\u0990', // ঐ
-	"aibopomofo":                          '\u311e', // ㄞ
-	"aideva":                              '\u0910', // ऐ
-	"aiecyrillic":                         '\u04d5', // ӕ
-	"aigujarati":                          '\u0a90', // ઐ
-	"aigurmukhi":                          '\u0a10', // ਐ
-	"aimatragurmukhi":                     '\u0a48', // ੈ
-	"ainarabic":                           '\u0639', // ع
-	"ainfinalarabic":                      '\ufeca', // ﻊ
-	"aininitialarabic":                    '\ufecb', // ﻋ
-	"ainmedialarabic":                     '\ufecc', // ﻌ
-	"ainvertedbreve":                      '\u0203', // ȃ
-	"aivowelsignbengali":                  '\u09c8', // ৈ
-	"aivowelsigndeva":                     '\u0948', // ै
-	"aivowelsigngujarati":                 '\u0ac8', // ૈ
-	"akatakana":                           '\u30a2', // ア
-	"akatakanahalfwidth":                  '\uff71', // ｱ
-	"akorean":                             '\u314f', // ㅏ
-	"alef":                                '\u05d0', // א
-	"alefarabic":                          '\u0627', // ا
-	"alefdageshhebrew":                    '\ufb30', // אּ
-	"aleffinalarabic":                     '\ufe8e', // ﺎ
-	"alefhamzaabovearabic":                '\u0623', // أ
-	"alefhamzaabovefinalarabic":           '\ufe84', // ﺄ
-	"alefhamzabelowarabic":                '\u0625', // إ
-	"alefhamzabelowfinalarabic":           '\ufe88', // ﺈ
-	"alefhebrew":                          '\u05d0', // א
-	"aleflamedhebrew":                     '\ufb4f', // ﭏ
-	"alefmaddaabovearabic":                '\u0622', // آ
-	"alefmaddaabovefinalarabic":           '\ufe82', // ﺂ
-	"alefmaksuraarabic":                   '\u0649', // ى
-	"alefmaksurafinalarabic":              '\ufef0', // ﻰ
-	"alefmaksurainitialarabic":            '\ufef3', // ﻳ
-	"alefmaksuramedialarabic":             '\ufef4', // ﻴ
-	"alefpatahhebrew":                     '\ufb2e', // אַ
-	"alefqamatshebrew":                    '\ufb2f', // אָ
-	"aleph":                               '\u2135', // ℵ
-	"allequal":                            '\u224c', // ≌
-	"alpha":                               '\u03b1', // α
-	"alphatonos":                          '\u03ac', // ά
-	"amacron":                             '\u0101', // ā
-	"amonospace":                          '\uff41', // ａ
-	"ampersand":                           '\u0026', // &
-	"ampersandmonospace":                  '\uff06', // ＆
-	"ampersandsmall":                      '\uf726', // 
-	"amsquare":                            '\u33c2', // ㏂
-	"anbopomofo":                          '\u3122', // ㄢ
-	"angbopomofo":                         '\u3124', // ㄤ
-	"angkhankhuthai":                      '\u0e5a', // ๚
-	"angle":                               '\u2220', // ∠
-	"anglebracketleft":                    '\u3008', // 〈
-	"anglebracketleftvertical":            '\ufe3f', // ︿
-	"anglebracketright":                   '\u3009', // 〉
-	"anglebracketrightvertical":           '\ufe40', // ﹀
-	"angleleft":                           '\u2329', // 〈
-	"angleright":                          '\u232a', // 〉
-	"angstrom":                            '\u212b', // Å
-	"anoteleia":                           '\u0387', // ·
-	"anudattadeva":                        '\u0952', // ॒
-	"anusvarabengali":                     '\u0982', // ং
-	"anusvaradeva":                        '\u0902', // ं
-	"anusvaragujarati":                    '\u0a82', // ં
-	"aogonek":                             '\u0105', // ą
-	"apaatosquare":                        '\u3300', // ㌀
-	"aparen":                              '\u249c', // ⒜
-	"apostrophearmenian":                  '\u055a', // ՚
-	"apostrophemod":                       '\u02bc', // ʼ
-	"apple":                               '\uf8ff', // 
-	"approaches":                          '\u2250', // ≐
-	"approxequal":                         '\u2248', // ≈
-	"approxequalorimage":                  '\u2252', // ≒
-	"approximatelyequal":                  '\u2245', // ≅
-	"araeaekorean":                        '\u318e', // ㆎ
-	"araeakorean":                         '\u318d', // ㆍ
-	"arc":                                 '\u2312', // ⌒
-	"arighthalfring":                      '\u1e9a', // ẚ
-	"aring":                               '\u00e5', // å
-	"aringacute":                          '\u01fb', // ǻ
-	"aringbelow":                          '\u1e01', // ḁ
-	"arrowboth":                           '\u2194', // ↔
-	"arrowdashdown":                       '\u21e3', // ⇣
-	"arrowdashleft":                       '\u21e0', // ⇠
-	"arrowdashright":                      '\u21e2', // ⇢
-	"arrowdashup":                         '\u21e1', // ⇡
-	"arrowdblboth":                        '\u21d4', // ⇔
-	"arrowdbldown":                        '\u21d3', // ⇓
-	"arrowdblleft":                        '\u21d0', // ⇐
-	"arrowdblright":                       '\u21d2', // ⇒
-	"arrowdblup":                          '\u21d1', // ⇑
-	"arrowdown":                           '\u2193', // ↓
-	"arrowdownleft":                       '\u2199', // ↙
-	"arrowdownright":                      '\u2198', // ↘
-	"arrowdownwhite":                      '\u21e9', // ⇩
-	"arrowheaddownmod":                    '\u02c5', // ˅
-	"arrowheadleftmod":                    '\u02c2', // ˂
-	"arrowheadrightmod":                   '\u02c3', // ˃
-	"arrowheadupmod":                      '\u02c4', // ˄
-	"arrowhorizex":                        '\uf8e7', // 
-	"arrowleft":                           '\u2190', // ←
-	"arrowleftdbl":                        '\u21d0', // ⇐
-	"arrowleftdblstroke":                  '\u21cd', // ⇍
-	"arrowleftoverright":                  '\u21c6', // ⇆
-	"arrowleftwhite":                      '\u21e6', // ⇦
-	"arrowright":                          '\u2192', // →
-	"arrowrightdblstroke":                 '\u21cf', // ⇏
-	"arrowrightheavy":                     '\u279e', // ➞
-	"arrowrightoverleft":                  '\u21c4', // ⇄
-	"arrowrightwhite":                     '\u21e8', // ⇨
-	"arrowtableft":                        '\u21e4', // ⇤
-	"arrowtabright":                       '\u21e5', // ⇥
-	"arrowup":                             '\u2191', // ↑
-	"arrowupdn":                           '\u2195', // ↕
-	"arrowupdnbse":                        '\u21a8', // ↨
-	"arrowupdownbase":                     '\u21a8', // ↨
-	"arrowupleft":                         '\u2196', // ↖
-	"arrowupleftofdown":                   '\u21c5', // ⇅
-	"arrowupright":                        '\u2197', // ↗
-	"arrowupwhite":                        '\u21e7', // ⇧
-	"arrowvertex":                         '\uf8e6', // 
-	"asciicircum":                         '\u005e', // ^
-	"asciicircummonospace":                '\uff3e', // ＾
-	"asciitilde":                          '\u007e', // ~
-	"asciitildemonospace":                 '\uff5e', // ～
-	"ascript":                             '\u0251', // ɑ
-	"ascriptturned":                       '\u0252', // ɒ
-	"asmallhiragana":                      '\u3041', // ぁ
-	"asmallkatakana":                      '\u30a1', // ァ
-	"asmallkatakanahalfwidth":             '\uff67', // ｧ
-	"asterisk":                            '\u002a', // *
-	"asteriskaltonearabic":                '\u066d', // ٭
-	"asteriskarabic":                      '\u066d', // ٭
-	"asteriskmath":                        '\u2217', // ∗
-	"asteriskmonospace":                   '\uff0a', // ＊
-	"asterisksmall":                       '\ufe61', // ﹡
-	"asterism":                            '\u2042', // ⁂
-	"asuperior":                           '\uf6e9', // 
-	"asymptoticallyequal":                 '\u2243', // ≃
-	"at":                                  '\u0040', // @
-	"atilde":                              '\u00e3', // ã
-	"atmonospace":                         '\uff20', // ＠
-	"atsmall":                             '\ufe6b', // ﹫
-	"aturned":                             '\u0250', // ɐ
-	"aubengali":                           '\u0994', // ঔ
-	"aubopomofo":                          '\u3120', // ㄠ
-	"audeva":                              '\u0914', // औ
-	"augujarati":                          '\u0a94', // ઔ
-	"augurmukhi":                          '\u0a14', // ਔ
-	"aulengthmarkbengali":                 '\u09d7', // ৗ
-	"aumatragurmukhi":                     '\u0a4c', // ੌ
-	"auvowelsignbengali":                  '\u09cc', // ৌ
-	"auvowelsigndeva":                     '\u094c', // ौ
-	"auvowelsigngujarati":                 '\u0acc', // ૌ
-	"avagrahadeva":                        '\u093d', // ऽ
-	"aybarmenian":                         '\u0561', // ա
-	"ayin":                                '\u05e2', // ע
-	"ayinaltonehebrew":                    '\ufb20', // ﬠ
-	"ayinhebrew":                          '\u05e2', // ע
-	"b":                                   '\u0062', // b
-	"babengali":                           '\u09ac', // ব
-	"backslash":                           '\u005c', // \\
-	"backslashmonospace":                  '\uff3c', // ＼
-	"badeva":                              '\u092c', // ब
-	"bagujarati":                          '\u0aac', // બ
-	"bagurmukhi":                          '\u0a2c', // ਬ
-	"bahiragana":                          '\u3070', // ば
-	"bahtthai":                            '\u0e3f', // ฿
-	"bakatakana":                          '\u30d0', // バ
-	"bar":                                 '\u007c', // |
-	"barmonospace":                        '\uff5c', // ｜
-	"bbopomofo":                           '\u3105', // ㄅ
-	"bcircle":                             '\u24d1', // ⓑ
-	"bdotaccent":                          '\u1e03', // ḃ
-	"bdotbelow":                           '\u1e05', // ḅ
-	"beamedsixteenthnotes":                '\u266c', // ♬
-	"because":                             '\u2235', // ∵
-	"becyrillic":                          '\u0431', // б
-	"beharabic":                           '\u0628', // ب
-	"behfinalarabic":                      '\ufe90', // ﺐ
-	"behinitialarabic":                    '\ufe91', // ﺑ
-	"behiragana":                          '\u3079', // べ
-	"behmedialarabic":                     '\ufe92', // ﺒ
-	"behmeeminitialarabic":                '\ufc9f', // ﲟ
-	"behmeemisolatedarabic":               '\ufc08', // ﰈ
-	"behnoonfinalarabic":                  '\ufc6d', // ﱭ
-	"bekatakana":                          '\u30d9', // ベ
-	"benarmenian":                         '\u0562', // բ
-	"bet":                                 '\u05d1', // ב
-	"beta":                                '\u03b2', // β
-	"betasymbolgreek":                     '\u03d0', // ϐ
-	"betdagesh":                           '\ufb31', // בּ
-	"betdageshhebrew":                     '\ufb31', // בּ
-	"bethebrew":                           '\u05d1', // ב
-	"betrafehebrew":                       '\ufb4c', // בֿ
-	"bhabengali":                          '\u09ad', // ভ
-	"bhadeva":                             '\u092d', // भ
-	"bhagujarati":                         '\u0aad', // ભ
-	"bhagurmukhi":                         '\u0a2d', // ਭ
-	"bhook":                               '\u0253', // ɓ
-	"bihiragana":                          '\u3073', // び
-	"bikatakana":                          '\u30d3', // ビ
-	"bilabialclick":                       '\u0298', // ʘ
-	"bindigurmukhi":                       '\u0a02', // ਂ
-	"birusquare":                          '\u3331', // ㌱
-	"blackcircle":                         '\u25cf', // ●
-	"blackdiamond":                        '\u25c6', // ◆
-	"blackdownpointingtriangle":           '\u25bc', // ▼
-	"blackleftpointingpointer":            '\u25c4', // ◄
-	"blackleftpointingtriangle":           '\u25c0', // ◀
-	"blacklenticularbracketleft":          '\u3010', // 【
-	"blacklenticularbracketleftvertical":  '\ufe3b', // ︻
-	"blacklenticularbracketright":         '\u3011', // 】
-	"blacklenticularbracketrightvertical": '\ufe3c', // ︼
-	"blacklowerlefttriangle":              '\u25e3', // ◣
-	"blacklowerrighttriangle":             '\u25e2', // ◢
-	"blackrectangle":                      '\u25ac', // ▬
-	"blackrightpointingpointer":           '\u25ba', // ►
-	"blackrightpointingtriangle":          '\u25b6', // ▶
-	"blacksmallsquare":                    '\u25aa', // ▪
-	"blacksmilingface":                    '\u263b', // ☻
-	"blacksquare":                         '\u25a0', // ■
-	"blackstar":                           '\u2605', // ★
-	"blackupperlefttriangle":              '\u25e4', // ◤
-	"blackupperrighttriangle":             '\u25e5', // ◥
-	"blackuppointingsmalltriangle":        '\u25b4', // ▴
-	"blackuppointingtriangle":             '\u25b2', // ▲
-	"blank":                               '\u2423', // ␣
-	"blinebelow":                          '\u1e07', // ḇ
-	"block":                               '\u2588', // █
-	"bmonospace":                          '\uff42', // ｂ
-	"bobaimaithai":                        '\u0e1a', // บ
-	"bohiragana":                          '\u307c', // ぼ
-	"bokatakana":                          '\u30dc', // ボ
-	"bparen":                              '\u249d', // ⒝
-	"bqsquare":                            '\u33c3', // ㏃
-	"braceex":                             '\uf8f4', // 
-	"braceleft":                           '\u007b', // {
-	"braceleftbt":                         '\uf8f3', // 
-	"braceleftmid":                        '\uf8f2', // 
-	"braceleftmonospace":                  '\uff5b', // ｛
-	"braceleftsmall":                      '\ufe5b', // ﹛
-	"bracelefttp":                         '\uf8f1', // 
-	"braceleftvertical":                   '\ufe37', // ︷
-	"braceright":                          '\u007d', // }
-	"bracerightbt":                        '\uf8fe', // 
-	"bracerightmid":                       '\uf8fd', // 
-	"bracerightmonospace":                 '\uff5d', // ｝
-	"bracerightsmall":                     '\ufe5c', // ﹜
-	"bracerighttp":                        '\uf8fc', // 
-	"bracerightvertical":                  '\ufe38', // ︸
-	"bracketleft":                         '\u005b', // [
-	"bracketleftbt":                       '\uf8f0', // 
-	"bracketleftex":                       '\uf8ef', // 
-	"bracketleftmonospace":                '\uff3b', // ［
-	"bracketlefttp":                       '\uf8ee', // 
-	"bracketright":                        '\u005d', // ]
-	"bracketrightbt":                      '\uf8fb', // 
-	"bracketrightex":                      '\uf8fa', // 
-	"bracketrightmonospace":               '\uff3d', // ］
-	"bracketrighttp":                      '\uf8f9', // 
-	"breve":                               '\u02d8', // ˘
-	"brevebelowcmb":                       '\u032e', // ̮
-	"brevecmb":                            '\u0306', // ̆
-	"breveinvertedbelowcmb":               '\u032f', // ̯
-	"breveinvertedcmb":                    '\u0311', // ̑
-	"breveinverteddoublecmb":              '\u0361', // ͡
-	"bridgebelowcmb":                      '\u032a', // ̪
-	"bridgeinvertedbelowcmb":              '\u033a', // ̺
-	"brokenbar":                           '\u00a6', // ¦
-	"bstroke":                             '\u0180', // ƀ
-	"bsuperior":                           '\uf6ea', // 
-	"btopbar":                             '\u0183', // ƃ
-	"buhiragana":                          '\u3076', // ぶ
-	"bukatakana":                          '\u30d6', // ブ
-	"bullet":                              '\u2022', // •
-	"bulletinverse":                       '\u25d8', // ◘
-	"bulletoperator":                      '\u2219', // ∙
-	"bullseye":                            '\u25ce', // ◎
-	"c":                                   '\u0063', // c
-	"caarmenian":                          '\u056e', // ծ
-	"cabengali":                           '\u099a', // চ
-	"cacute":                              '\u0107', // ć
-	"cadeva":                              '\u091a', // च
-	"cagujarati":                          '\u0a9a', // ચ
-	"cagurmukhi":                          '\u0a1a', // ਚ
-	"calsquare":                           '\u3388', // ㎈
-	"candrabindubengali":                  '\u0981', // ঁ
-	"candrabinducmb":                      '\u0310', // ̐
-	"candrabindudeva":                     '\u0901', // ँ
-	"candrabindugujarati":                 '\u0a81', // ઁ
-	"capslock":                            '\u21ea', // ⇪
-	"careof":                              '\u2105', // ℅
-	"caron":                               '\u02c7', // ˇ
-	"caronbelowcmb":                       '\u032c', // ̬
-	"caroncmb":                            '\u030c', // ̌
-	"carriagereturn":                      '\u21b5', // ↵
-	"cbopomofo":                           '\u3118', // ㄘ
-	"ccaron":                              '\u010d', // č
-	"ccedilla":                            '\u00e7', // ç
-	"ccedillaacute":                       '\u1e09', // ḉ
-	"ccircle":                             '\u24d2', // ⓒ
-	"ccircumflex":                         '\u0109', // ĉ
-	"ccurl":                               '\u0255', // ɕ
-	"cdot":                                '\u010b', // ċ
-	"cdotaccent":                          '\u010b', // ċ
-	"cdsquare":                            '\u33c5', // ㏅
-	"cedilla":                             '\u00b8', // ¸
-	"cedillacmb":                          '\u0327', // ̧
-	"cent":                                '\u00a2', // ¢
-	"centigrade":                          '\u2103', // ℃
-	"centinferior":                        '\uf6df', // 
-	"centmonospace":                       '\uffe0', // ￠
-	"centoldstyle":                        '\uf7a2', // 
-	"centsuperior":                        '\uf6e0', // 
-	"chaarmenian":                         '\u0579', // չ
-	"chabengali":                          '\u099b', // ছ
-	"chadeva":                             '\u091b', // छ
-	"chagujarati":                         '\u0a9b', // છ
-	"chagurmukhi":                         '\u0a1b', // ਛ
-	"chbopomofo":                          '\u3114', // ㄔ
-	"cheabkhasiancyrillic":                '\u04bd', // ҽ
-	"checkmark":                           '\u2713', // ✓
-	"checyrillic":                         '\u0447', // ч
-	"chedescenderabkhasiancyrillic":       '\u04bf', // ҿ
-	"chedescendercyrillic":                '\u04b7', // ҷ
-	"chedieresiscyrillic":                 '\u04f5', // ӵ
-	"cheharmenian":                        '\u0573', // ճ
-	"chekhakassiancyrillic":               '\u04cc', // ӌ
-	"cheverticalstrokecyrillic":           '\u04b9', // ҹ
-	"chi":                                 '\u03c7', // χ
-	"chieuchacirclekorean":                '\u3277', // ㉷
-	"chieuchaparenkorean":                 '\u3217', // ㈗
-	"chieuchcirclekorean":                 '\u3269', // ㉩
-	"chieuchkorean":                       '\u314a', // ㅊ
-	"chieuchparenkorean":                  '\u3209', // ㈉
-	"chochangthai":                        '\u0e0a', // ช
-	"chochanthai":                         '\u0e08', // จ
-	"chochingthai":                        '\u0e09', // ฉ
-	"chochoethai":                         '\u0e0c', // ฌ
-	"chook":                               '\u0188', // ƈ
-	"cieucacirclekorean":                  '\u3276', // ㉶
-	"cieucaparenkorean":                   '\u3216', // ㈖
-	"cieuccirclekorean":                   '\u3268', // ㉨
-	"cieuckorean":                         '\u3148', // ㅈ
-	"cieucparenkorean":                    '\u3208', // ㈈
-	"cieucuparenkorean":                   '\u321c', // ㈜
-	"circle":                              '\u25cb', // ○
-	"circlemultiply":                      '\u2297', // ⊗
-	"circleot":                            '\u2299', // ⊙
-	"circleplus":                          '\u2295', // ⊕
-	"circlepostalmark":                    '\u3036', // 〶
-	"circlewithlefthalfblack":             '\u25d0', // ◐
-	"circlewithrighthalfblack":            '\u25d1', // ◑
-	"circumflex":                          '\u02c6', // ˆ
-	"circumflexbelowcmb":                  '\u032d', // ̭
-	"circumflexcmb":                       '\u0302', // ̂
-	"clear":                               '\u2327', // ⌧
-	"clickalveolar":                       '\u01c2', // ǂ
-	"clickdental":                         '\u01c0', // ǀ
-	"clicklateral":                        '\u01c1', // ǁ
-	"clickretroflex":                      '\u01c3', // ǃ
-	"club":                                '\u2663', // ♣
-	"clubsuitblack":                       '\u2663', // ♣
-	"clubsuitwhite":                       '\u2667', // ♧
-	"cmcubedsquare":                       '\u33a4', // ㎤
-	"cmonospace":                          '\uff43', // ｃ
-	"cmsquaredsquare":                     '\u33a0', // ㎠
-	"coarmenian":                          '\u0581', // ց
-	"colon":                               '\u003a', // :
-	"colonmonetary":                       '\u20a1', // ₡
-	"colonmonospace":                      '\uff1a', // ：
-	"colonsign":                           '\u20a1', // ₡
-	"colonsmall":                          '\ufe55', // ﹕
-	"colontriangularhalfmod":              '\u02d1', // ˑ
-	"colontriangularmod":                  '\u02d0', // ː
-	"comma":                               '\u002c', // ,
-	"commaabovecmb":                       '\u0313', // ̓
-	"commaaboverightcmb":                  '\u0315', // ̕
-	"commaaccent":                         '\uf6c3', // 
-	"commaarabic":                         '\u060c', // ،
-	"commaarmenian":                       '\u055d', // ՝
-	"commainferior":                       '\uf6e1', // 
-	"commamonospace":                      '\uff0c', // ，
-	"commareversedabovecmb":               '\u0314', // ̔
-	"commareversedmod":                    '\u02bd', // ʽ
-	"commasmall":                          '\ufe50', // ﹐
-	"commasuperior":                       '\uf6e2', // 
-	"commaturnedabovecmb":                 '\u0312', // ̒
-	"commaturnedmod":                      '\u02bb', // ʻ
-	"compass":                             '\u263c', // ☼
-	"congruent":                           '\u2245', // ≅
-	"contourintegral":                     '\u222e', // ∮
-	"control":                             '\u2303', // ⌃
-	"controlACK":                          '\u0006', // 
-	"controlBEL":                          '\u0007', // 
-	"controlBS":                           '\u0008', // 
-	"controlCAN":                          '\u0018', // 
-	"controlCR":                           '\u000d', //
-	"controlDC1":                          '\u0011', // 
-	"controlDC2":                          '\u0012', // 
-	"controlDC3":                          '\u0013', // 
-	"controlDC4":                          '\u0014', // 
-	"controlDEL":                          '\u007f', // 
-	"controlDLE":                          '\u0010', // 
-	"controlEM":                           '\u0019', // 
-	"controlENQ":                          '\u0005', // 
-	"controlEOT":                          '\u0004', // 
-	"controlESC":                          '\u001b', // 
-	"controlETB":                          '\u0017', // 
-	"controlETX":                          '\u0003', // 
-	"controlFF":                           '\u000c', //
-	"controlFS":                           '\u001c', // 
-	"controlGS":                           '\u001d', // 
-	"controlHT":                           '\u0009', //
-	"controlLF":                           '\u000a', //
-
-	"controlNAK":                  '\u0015', // 
-	"controlRS":                   '\u001e', // 
-	"controlSI":                   '\u000f', // 
-	"controlSO":                   '\u000e', // 
-	"controlSOT":                  '\u0002', // 
-	"controlSTX":                  '\u0001', // 
-	"controlSUB":                  '\u001a', // 
-	"controlSYN":                  '\u0016', // 
-	"controlUS":                   '\u001f', // 
-	"controlVT":                   '\u000b', //
-	"copyright":                   '\u00a9', // ©
-	"copyrightsans":               '\uf8e9', // 
-	"copyrightserif":              '\uf6d9', // 
-	"cornerbracketleft":           '\u300c', // 「
-	"cornerbracketlefthalfwidth":  '\uff62', // ｢
-	"cornerbracketleftvertical":   '\ufe41', // ﹁
-	"cornerbracketright":          '\u300d', // 」
-	"cornerbracketrighthalfwidth": '\uff63', // ｣
-	"cornerbracketrightvertical":  '\ufe42', // ﹂
-	"corporationsquare":           '\u337f', // ㍿
-	"cosquare":                    '\u33c7', // ㏇
-	"coverkgsquare":               '\u33c6', // ㏆
-	"cparen":                      '\u249e', // ⒞
-	"cruzeiro":                    '\u20a2', // ₢
-	"cstretched":                  '\u0297', // ʗ
-	"curlyand":                    '\u22cf', // ⋏
-	"curlyor":                     '\u22ce', // ⋎
-	"currency":                    '\u00a4', // ¤
-	"cyrBreve":                    '\uf6d1', // 
-	"cyrFlex":                     '\uf6d2', // 
-	"cyrbreve":                    '\uf6d4', // 
-	"cyrflex":                     '\uf6d5', // 
-	"d":                           '\u0064', // d
-	"daarmenian":                  '\u0564', // դ
-	"dabengali":                   '\u09a6', // দ
-	"dadarabic":                   '\u0636', // ض
-	"dadeva":                      '\u0926', // द
-	"dadfinalarabic":              '\ufebe', // ﺾ
-	"dadinitialarabic":            '\ufebf', // ﺿ
-	"dadmedialarabic":             '\ufec0', // ﻀ
-	"dagesh":                      '\u05bc', // ּ
-	"dageshhebrew":                '\u05bc', // ּ
-	"dagger":                      '\u2020', // †
-	"daggerdbl":                   '\u2021', // ‡
-	"dagujarati":                  '\u0aa6', // દ
-	"dagurmukhi":                  '\u0a26', // ਦ
-	"dahiragana":                  '\u3060', // だ
-	"dakatakana":                  '\u30c0', // ダ
-	"dalarabic":                   '\u062f', // د
-	"dalet":                       '\u05d3', // ד
-	"daletdagesh":                 '\ufb33', // דּ
-	"daletdageshhebrew":           '\ufb33', // דּ
-	//    "dalethatafpatah": '\u05d3', // דֲ
-	//    "dalethatafpatahhebrew": '\u05d3', // דֲ
-	//    "dalethatafsegol": '\u05d3', // דֱ
-	//    "dalethatafsegolhebrew": '\u05d3', // דֱ
-	"dalethebrew": '\u05d3', // ד
-	//    "dalethiriq": '\u05d3', // דִ
-	//    "dalethiriqhebrew": '\u05d3', // דִ
-	//    "daletholam": '\u05d3', // דֹ
-	//    "daletholamhebrew": '\u05d3', // דֹ
-	//    "daletpatah": '\u05d3', // דַ
-	//    "daletpatahhebrew": '\u05d3', // דַ
-	//    "daletqamats": '\u05d3', // דָ
-	//    "daletqamatshebrew": '\u05d3', // דָ
-	//    "daletqubuts": '\u05d3', // דֻ
-	//    "daletqubutshebrew": '\u05d3', // דֻ
-	//    "daletsegol": '\u05d3', // דֶ
-	//    "daletsegolhebrew": '\u05d3', // דֶ
-	//    "daletsheva": '\u05d3', // דְ
-	//    "daletshevahebrew": '\u05d3', // דְ
-	//    "dalettsere": '\u05d3', // דֵ
-	//    "dalettserehebrew": '\u05d3', // דֵ
-	"dalfinalarabic":                      '\ufeaa', // ﺪ
-	"dammaarabic":                         '\u064f', // ُ
-	"dammalowarabic":                      '\u064f', // ُ
-	"dammatanaltonearabic":                '\u064c', // ٌ
-	"dammatanarabic":                      '\u064c', // ٌ
-	"danda":                               '\u0964', // ।
-	"dargahebrew":                         '\u05a7', // ֧
-	"dargalefthebrew":                     '\u05a7', // ֧
-	"dasiapneumatacyrilliccmb":            '\u0485', // ҅
-	"dblGrave":                            '\uf6d3', // 
-	"dblanglebracketleft":                 '\u300a', // 《
-	"dblanglebracketleftvertical":         '\ufe3d', // ︽
-	"dblanglebracketright":                '\u300b', // 》
-	"dblanglebracketrightvertical":        '\ufe3e', // ︾
-	"dblarchinvertedbelowcmb":             '\u032b', // ̫
-	"dblarrowleft":                        '\u21d4', // ⇔
-	"dblarrowright":                       '\u21d2', // ⇒
-	"dbldanda":                            '\u0965', // ॥
-	"dblgrave":                            '\uf6d6', // 
-	"dblgravecmb":                         '\u030f', // ̏
-	"dblintegral":                         '\u222c', // ∬
-	"dbllowline":                          '\u2017', // ‗
-	"dbllowlinecmb":                       '\u0333', // ̳
-	"dbloverlinecmb":                      '\u033f', // ̿
-	"dblprimemod":                         '\u02ba', // ʺ
-	"dblverticalbar":                      '\u2016', // ‖
-	"dblverticallineabovecmb":             '\u030e', // ̎
-	"dbopomofo":                           '\u3109', // ㄉ
-	"dbsquare":                            '\u33c8', // ㏈
-	"dcaron":                              '\u010f', // ď
-	"dcedilla":                            '\u1e11', // ḑ
-	"dcircle":                             '\u24d3', // ⓓ
-	"dcircumflexbelow":                    '\u1e13', // ḓ
-	"dcroat":                              '\u0111', // đ
-	"ddabengali":                          '\u09a1', // ড
-	"ddadeva":                             '\u0921', // ड
-	"ddagujarati":                         '\u0aa1', // ડ
-	"ddagurmukhi":                         '\u0a21', // ਡ
-	"ddalarabic":                          '\u0688', // ڈ
-	"ddalfinalarabic":                     '\ufb89', // ﮉ
-	"dddhadeva":                           '\u095c', // ड़
-	"ddhabengali":                         '\u09a2', // ঢ
-	"ddhadeva":                            '\u0922', // ढ
-	"ddhagujarati":                        '\u0aa2', // ઢ
-	"ddhagurmukhi":                        '\u0a22', // ਢ
-	"ddotaccent":                          '\u1e0b', // ḋ
-	"ddotbelow":                           '\u1e0d', // ḍ
-	"decimalseparatorarabic":              '\u066b', // ٫
-	"decimalseparatorpersian":             '\u066b', // ٫
-	"decyrillic":                          '\u0434', // д
-	"degree":                              '\u00b0', // °
-	"dehihebrew":                          '\u05ad', // ֭
-	"dehiragana":                          '\u3067', // で
-	"deicoptic":                           '\u03ef', // ϯ
-	"dekatakana":                          '\u30c7', // デ
-	"deleteleft":                          '\u232b', // ⌫
-	"deleteright":                         '\u2326', // ⌦
-	"delta":                               '\u03b4', // δ
-	"deltaturned":                         '\u018d', // ƍ
-	"denominatorminusonenumeratorbengali": '\u09f8', // ৸
-	"dezh":                                '\u02a4', // ʤ
-	"dhabengali":                          '\u09a7', // ধ
-	"dhadeva":                             '\u0927', // ध
-	"dhagujarati":                         '\u0aa7', // ધ
-	"dhagurmukhi":                         '\u0a27', // ਧ
-	"dhook":                               '\u0257', // ɗ
-	"dialytikatonos":                      '\u0385', // ΅
-	"dialytikatonoscmb":                   '\u0344', // ̈́
-	"diamond":                             '\u2666', // ♦
-	"diamondsuitwhite":                    '\u2662', // ♢
-	"dieresis":                            '\u00a8', // ¨
-	"dieresisacute":                       '\uf6d7', // 
-	"dieresisbelowcmb":                    '\u0324', // ̤
-	"dieresiscmb":                         '\u0308', // ̈
-	"dieresisgrave":                       '\uf6d8', // 
-	"dieresistonos":                       '\u0385', // ΅
-	"dihiragana":                          '\u3062', // ぢ
-	"dikatakana":                          '\u30c2', // ヂ
-	"dittomark":                           '\u3003', // 〃
-	"divide":                              '\u00f7', // ÷
-	"divides":                             '\u2223', // ∣
-	"divisionslash":                       '\u2215', // ∕
-	"djecyrillic":                         '\u0452', // ђ
-	"dkshade":                             '\u2593', // ▓
-	"dlinebelow":                          '\u1e0f', // ḏ
-	"dlsquare":                            '\u3397', // ㎗
-	"dmacron":                             '\u0111', // đ
-	"dmonospace":                          '\uff44', // ｄ
-	"dnblock":                             '\u2584', // ▄
-	"dochadathai":                         '\u0e0e', // ฎ
-	"dodekthai":                           '\u0e14', // ด
-	"dohiragana":                          '\u3069', // ど
-	"dokatakana":                          '\u30c9', // ド
-	"dollar":                              '\u0024', // $
-	"dollarinferior":                      '\uf6e3', // 
-	"dollarmonospace":                     '\uff04', // ＄
-	"dollaroldstyle":                      '\uf724', // 
-	"dollarsmall":                         '\ufe69', // ﹩
-	"dollarsuperior":                      '\uf6e4', // 
-	"dong":                                '\u20ab', // ₫
-	"dorusquare":                          '\u3326', // ㌦
-	"dotaccent":                           '\u02d9', // ˙
-	"dotaccentcmb":                        '\u0307', // ̇
-	"dotbelowcmb":                         '\u0323', // ̣
-	"dotbelowcomb":                        '\u0323', // ̣
-	"dotkatakana":                         '\u30fb', // ・
-	"dotlessi":                            '\u0131', // ı
-	"dotlessj":                            '\uf6be', // 
-	"dotlessjstrokehook":                  '\u0284', // ʄ
-	"dotmath":                             '\u22c5', // ⋅
-	"dottedcircle":                        '\u25cc', // ◌
-	"doubleyodpatah":                      '\ufb1f', // ײַ
-	"doubleyodpatahhebrew":                '\ufb1f', // ײַ
-	"downtackbelowcmb":                    '\u031e', // ̞
-	"downtackmod":                         '\u02d5', // ˕
-	"dparen":                              '\u249f', // ⒟
-	"dsuperior":                           '\uf6eb', // 
-	"dtail":                               '\u0256', // ɖ
-	"dtopbar":                             '\u018c', // ƌ
-	"duhiragana":                          '\u3065', // づ
-	"dukatakana":                          '\u30c5', // ヅ
-	"dz":                                  '\u01f3', // ǳ
-	"dzaltone":                            '\u02a3', // ʣ
-	"dzcaron":                             '\u01c6', // ǆ
-	"dzcurl":                              '\u02a5', // ʥ
-	"dzeabkhasiancyrillic":                '\u04e1', // ӡ
-	"dzecyrillic":                         '\u0455', // ѕ
-	"dzhecyrillic":                        '\u045f', // џ
-	"e":                                   '\u0065', // e
-	"eacute":                              '\u00e9', // é
-	"earth":                               '\u2641', // ♁
-	"ebengali":                            '\u098f', // এ
-	"ebopomofo":                           '\u311c', // ㄜ
-	"ebreve":                              '\u0115', // ĕ
-	"ecandradeva":                         '\u090d', // ऍ
-	"ecandragujarati":                     '\u0a8d', // ઍ
-	"ecandravowelsigndeva":                '\u0945', // ॅ
-	"ecandravowelsigngujarati":            '\u0ac5', // ૅ
-	"ecaron":                              '\u011b', // ě
-	"ecedillabreve":                       '\u1e1d', // ḝ
-	"echarmenian":                         '\u0565', // ե
-	"echyiwnarmenian":                     '\u0587', // և
-	"ecircle":                             '\u24d4', // ⓔ
-	"ecircumflex":                         '\u00ea', // ê
-	"ecircumflexacute":                    '\u1ebf', // ế
-	"ecircumflexbelow":                    '\u1e19', // ḙ
-	"ecircumflexdotbelow":                 '\u1ec7', // ệ
-	"ecircumflexgrave":                    '\u1ec1', // ề
-	"ecircumflexhookabove":                '\u1ec3', // ể
-	"ecircumflextilde":                    '\u1ec5', // ễ
-	"ecyrillic":                           '\u0454', // є
-	"edblgrave":                           '\u0205', // ȅ
-	"edeva":                               '\u090f', // ए
-	"edieresis":                           '\u00eb', // ë
-	"edot":                                '\u0117', // ė
-	"edotaccent":                          '\u0117', // ė
-	"edotbelow":                           '\u1eb9', // ẹ
-	"eegurmukhi":                          '\u0a0f', // ਏ
-	"eematragurmukhi":                     '\u0a47', // ੇ
-	"efcyrillic":                          '\u0444', // ф
-	"egrave":                              '\u00e8', // è
-	"egujarati":                           '\u0a8f', // એ
-	"eharmenian":                          '\u0567', // է
-	"ehbopomofo":                          '\u311d', // ㄝ
-	"ehiragana":                           '\u3048', // え
-	"ehookabove":                          '\u1ebb', // ẻ
-	"eibopomofo":                          '\u311f', // ㄟ
-	"eight":                               '\u0038', // 8
-	"eightarabic":                         '\u0668', // ٨
-	"eightbengali":                        '\u09ee', // ৮
-	"eightcircle":                         '\u2467', // ⑧
-	"eightcircleinversesansserif":         '\u2791', // ➑
-	"eightdeva":                           '\u096e', // ८
-	"eighteencircle":                      '\u2471', // ⑱
-	"eighteenparen":                       '\u2485', // ⒅
-	"eighteenperiod":                      '\u2499', // ⒙
-	"eightgujarati":                       '\u0aee', // ૮
-	"eightgurmukhi":                       '\u0a6e', // ੮
-	"eighthackarabic":                     '\u0668', // ٨
-	"eighthangzhou":                       '\u3028', // 〨
-	"eighthnotebeamed":                    '\u266b', // ♫
-	"eightideographicparen":               '\u3227', // ㈧
-	"eightinferior":                       '\u2088', // ₈
-	"eightmonospace":                      '\uff18', // ８
-	"eightoldstyle":                       '\uf738', // 
-	"eightparen":                          '\u247b', // ⑻
-	"eightperiod":                         '\u248f', // ⒏
-	"eightpersian":                        '\u06f8', // ۸
-	"eightroman":                          '\u2177', // ⅷ
-	"eightsuperior":                       '\u2078', // ⁸
-	"eightthai":                           '\u0e58', // ๘
-	"einvertedbreve":                      '\u0207', // ȇ
-	"eiotifiedcyrillic":                   '\u0465', // ѥ
-	"ekatakana":                           '\u30a8', // エ
-	"ekatakanahalfwidth":                  '\uff74', // ｴ
-	"ekonkargurmukhi":                     '\u0a74', // ੴ
-	"ekorean":                             '\u3154', // ㅔ
-	"elcyrillic":                          '\u043b', // л
-	"element":                             '\u2208', // ∈
-	"elevencircle":                        '\u246a', // ⑪
-	"elevenparen":                         '\u247e', // ⑾
-	"elevenperiod":                        '\u2492', // ⒒
-	"elevenroman":                         '\u217a', // ⅺ
-	"ellipsis":                            '\u2026', // …
-	"ellipsisvertical":                    '\u22ee', // ⋮
-	"emacron":                             '\u0113', // ē
-	"emacronacute":                        '\u1e17', // ḗ
-	"emacrongrave":                        '\u1e15', // ḕ
-	"emcyrillic":                          '\u043c', // м
-	"emdash":                              '\u2014', // —
-	"emdashvertical":                      '\ufe31', // ︱
-	"emonospace":                          '\uff45', // ｅ
-	"emphasismarkarmenian":                '\u055b', // ՛
-	"emptyset":                            '\u2205', // ∅
-	"enbopomofo":                          '\u3123', // ㄣ
-	"encyrillic":                          '\u043d', // н
-	"endash":                              '\u2013', // –
-	"endashvertical":                      '\ufe32', // ︲
-	"endescendercyrillic":                 '\u04a3', // ң
-	"eng":                                 '\u014b', // ŋ
-	"engbopomofo":                         '\u3125', // ㄥ
-	"enghecyrillic":                       '\u04a5', // ҥ
-	"enhookcyrillic":                      '\u04c8', // ӈ
-	"enspace":                             '\u2002', //
-	"eogonek":                             '\u0119', // ę
-	"eokorean":                            '\u3153', // ㅓ
-	"eopen":                               '\u025b', // ɛ
-	"eopenclosed":                         '\u029a', // ʚ
-	"eopenreversed":                       '\u025c', // ɜ
-	"eopenreversedclosed":                 '\u025e', // ɞ
-	"eopenreversedhook":                   '\u025d', // ɝ
-	"eparen":                              '\u24a0', // ⒠
-	"epsilon":                             '\u03b5', // ε
-	"epsilontonos":                        '\u03ad', // έ
-	"equal":                               '\u003d', // =
-	"equalmonospace":                      '\uff1d', // ＝
-	"equalsmall":                          '\ufe66', // ﹦
-	"equalsuperior":                       '\u207c', // ⁼
-	"equivalence":                         '\u2261', // ≡
-	"erbopomofo":                          '\u3126', // ㄦ
-	"ercyrillic":                          '\u0440', // р
-	"ereversed":                           '\u0258', // ɘ
-	"ereversedcyrillic":                   '\u044d', // э
-	"escyrillic":                          '\u0441', // с
-	"esdescendercyrillic":                 '\u04ab', // ҫ
-	"esh":                                 '\u0283', // ʃ
-	"eshcurl":                             '\u0286', // ʆ
-	"eshortdeva":                          '\u090e', // ऎ
-	"eshortvowelsigndeva":                 '\u0946', // ॆ
-	"eshreversedloop":                     '\u01aa', // ƪ
-	"eshsquatreversed":                    '\u0285', // ʅ
-	"esmallhiragana":                      '\u3047', // ぇ
-	"esmallkatakana":                      '\u30a7', // ェ
-	"esmallkatakanahalfwidth":             '\uff6a', // ｪ
-	"estimated":                           '\u212e', // ℮
-	"esuperior":                           '\uf6ec', // 
-	"eta":                                 '\u03b7', // η
-	"etarmenian":                          '\u0568', // ը
-	"etatonos":                            '\u03ae', // ή
-	"eth":                                 '\u00f0', // ð
-	"etilde":                              '\u1ebd', // ẽ
-	"etildebelow":                         '\u1e1b', // ḛ
-	"etnahtafoukhhebrew":                  '\u0591', // ֑
-	"etnahtafoukhlefthebrew":              '\u0591', // ֑
-	"etnahtahebrew":                       '\u0591', // ֑
-	"etnahtalefthebrew":                   '\u0591', // ֑
-	"eturned":                             '\u01dd', // ǝ
-	"eukorean":                            '\u3161', // ㅡ
-	"euro":                                '\u20ac', // €
-	"evowelsignbengali":                   '\u09c7', // ে
-	"evowelsigndeva":                      '\u0947', // े
-	"evowelsigngujarati":                  '\u0ac7', // ે
-	"exclam":                              '\u0021', // !
-	"exclamarmenian":                      '\u055c', // ՜
-	"exclamdbl":                           '\u203c', // ‼
-	"exclamdown":                          '\u00a1', // ¡
-	"exclamdownsmall":                     '\uf7a1', // 
-	"exclammonospace":                     '\uff01', // ！
-	"exclamsmall":                         '\uf721', // 
-	"existential":                         '\u2203', // ∃
-	"ezh":                                 '\u0292', // ʒ
-	"ezhcaron":                            '\u01ef', // ǯ
-	"ezhcurl":                             '\u0293', // ʓ
-	"ezhreversed":                         '\u01b9', // ƹ
-	"ezhtail":                             '\u01ba', // ƺ
-	"f":                                   '\u0066', // f
-	"fadeva":                              '\u095e', // फ़
-	"fagurmukhi":                          '\u0a5e', // ਫ਼
-	"fahrenheit":                          '\u2109', // ℉
-	"fathaarabic":                         '\u064e', // َ
-	"fathalowarabic":                      '\u064e', // َ
-	"fathatanarabic":                      '\u064b', // ً
-	"fbopomofo":                           '\u3108', // ㄈ
-	"fcircle":                             '\u24d5', // ⓕ
-	"fdotaccent":                          '\u1e1f', // ḟ
-	"feharabic":                           '\u0641', // ف
-	"feharmenian":                         '\u0586', // ֆ
-	"fehfinalarabic":                      '\ufed2', // ﻒ
-	"fehinitialarabic":                    '\ufed3', // ﻓ
-	"fehmedialarabic":                     '\ufed4', // ﻔ
-	"feicoptic":                           '\u03e5', // ϥ
-	"female":                              '\u2640', // ♀
-	"ff":                                  '\ufb00', // ﬀ
-	"ffi":                                 '\ufb03', // ﬃ
-	"ffl":                                 '\ufb04', // ﬄ
-	"fi":                                  '\ufb01', // ﬁ
-	"fifteencircle":                       '\u246e', // ⑮
-	"fifteenparen":                        '\u2482', // ⒂
-	"fifteenperiod":                       '\u2496', // ⒖
-	"figuredash":                          '\u2012', // ‒
-	"filledbox":                           '\u25a0', // ■
-	"filledrect":                          '\u25ac', // ▬
-	"finalkaf":                            '\u05da', // ך
-	"finalkafdagesh":                      '\ufb3a', // ךּ
-	"finalkafdageshhebrew":                '\ufb3a', // ךּ
-	"finalkafhebrew":                      '\u05da', // ך
-	//    "finalkafqamats": '\u05da', // ךָ
-	//    "finalkafqamatshebrew": '\u05da', // ךָ
-	//    "finalkafsheva": '\u05da', // ךְ
-	//    "finalkafshevahebrew": '\u05da', // ךְ
-	"finalmem":                    '\u05dd', // ם
-	"finalmemhebrew":              '\u05dd', // ם
-	"finalnun":                    '\u05df', // ן
-	"finalnunhebrew":              '\u05df', // ן
-	"finalpe":                     '\u05e3', // ף
-	"finalpehebrew":               '\u05e3', // ף
-	"finaltsadi":                  '\u05e5', // ץ
-	"finaltsadihebrew":            '\u05e5', // ץ
-	"firsttonechinese":            '\u02c9', // ˉ
-	"fisheye":                     '\u25c9', // ◉
-	"fitacyrillic":                '\u0473', // ѳ
-	"five":                        '\u0035', // 5
-	"fivearabic":                  '\u0665', // ٥
-	"fivebengali":                 '\u09eb', // ৫
-	"fivecircle":                  '\u2464', // ⑤
-	"fivecircleinversesansserif":  '\u278e', // ➎
-	"fivedeva":                    '\u096b', // ५
-	"fiveeighths":                 '\u215d', // ⅝
-	"fivegujarati":                '\u0aeb', // ૫
-	"fivegurmukhi":                '\u0a6b', // ੫
-	"fivehackarabic":              '\u0665', // ٥
-	"fivehangzhou":                '\u3025', // 〥
-	"fiveideographicparen":        '\u3224', // ㈤
-	"fiveinferior":                '\u2085', // ₅
-	"fivemonospace":               '\uff15', // ５
-	"fiveoldstyle":                '\uf735', // 
-	"fiveparen":                   '\u2478', // ⑸
-	"fiveperiod":                  '\u248c', // ⒌
-	"fivepersian":                 '\u06f5', // ۵
-	"fiveroman":                   '\u2174', // ⅴ
-	"fivesuperior":                '\u2075', // ⁵
-	"fivethai":                    '\u0e55', // ๕
-	"fl":                          '\ufb02', // ﬂ
-	"florin":                      '\u0192', // ƒ
-	"fmonospace":                  '\uff46', // ｆ
-	"fmsquare":                    '\u3399', // ㎙
-	"fofanthai":                   '\u0e1f', // ฟ
-	"fofathai":                    '\u0e1d', // ฝ
-	"fongmanthai":                 '\u0e4f', // ๏
-	"forall":                      '\u2200', // ∀
-	"four":                        '\u0034', // 4
-	"fourarabic":                  '\u0664', // ٤
-	"fourbengali":                 '\u09ea', // ৪
-	"fourcircle":                  '\u2463', // ④
-	"fourcircleinversesansserif":  '\u278d', // ➍
-	"fourdeva":                    '\u096a', // ४
-	"fourgujarati":                '\u0aea', // ૪
-	"fourgurmukhi":                '\u0a6a', // ੪
-	"fourhackarabic":              '\u0664', // ٤
-	"fourhangzhou":                '\u3024', // 〤
-	"fourideographicparen":        '\u3223', // ㈣
-	"fourinferior":                '\u2084', // ₄
-	"fourmonospace":               '\uff14', // ４
-	"fournumeratorbengali":        '\u09f7', // ৷
-	"fouroldstyle":                '\uf734', // 
-	"fourparen":                   '\u2477', // ⑷
-	"fourperiod":                  '\u248b', // ⒋
-	"fourpersian":                 '\u06f4', // ۴
-	"fourroman":                   '\u2173', // ⅳ
-	"foursuperior":                '\u2074', // ⁴
-	"fourteencircle":              '\u246d', // ⑭
-	"fourteenparen":               '\u2481', // ⒁
-	"fourteenperiod":              '\u2495', // ⒕
-	"fourthai":                    '\u0e54', // ๔
-	"fourthtonechinese":           '\u02cb', // ˋ
-	"fparen":                      '\u24a1', // ⒡
-	"fraction":                    '\u2044', // ⁄
-	"franc":                       '\u20a3', // ₣
-	"g":                           '\u0067', // g
-	"gabengali":                   '\u0997', // গ
-	"gacute":                      '\u01f5', // ǵ
-	"gadeva":                      '\u0917', // ग
-	"gafarabic":                   '\u06af', // گ
-	"gaffinalarabic":              '\ufb93', // ﮓ
-	"gafinitialarabic":            '\ufb94', // ﮔ
-	"gafmedialarabic":             '\ufb95', // ﮕ
-	"gagujarati":                  '\u0a97', // ગ
-	"gagurmukhi":                  '\u0a17', // ਗ
-	"gahiragana":                  '\u304c', // が
-	"gakatakana":                  '\u30ac', // ガ
-	"gamma":                       '\u03b3', // γ
-	"gammalatinsmall":             '\u0263', // ɣ
-	"gammasuperior":               '\u02e0', // ˠ
-	"gangiacoptic":                '\u03eb', // ϫ
-	"gbopomofo":                   '\u310d', // ㄍ
-	"gbreve":                      '\u011f', // ğ
-	"gcaron":                      '\u01e7', // ǧ
-	"gcedilla":                    '\u0123', // ģ
-	"gcircle":                     '\u24d6', // ⓖ
-	"gcircumflex":                 '\u011d', // ĝ
-	"gcommaaccent":                '\u0123', // ģ
-	"gdot":                        '\u0121', // ġ
-	"gdotaccent":                  '\u0121', // ġ
-	"gecyrillic":                  '\u0433', // г
-	"gehiragana":                  '\u3052', // げ
-	"gekatakana":                  '\u30b2', // ゲ
-	"geometricallyequal":          '\u2251', // ≑
-	"gereshaccenthebrew":          '\u059c', // ֜
-	"gereshhebrew":                '\u05f3', // ׳
-	"gereshmuqdamhebrew":          '\u059d', // ֝
-	"germandbls":                  '\u00df', // ß
-	"gershayimaccenthebrew":       '\u059e', // ֞
-	"gershayimhebrew":             '\u05f4', // ״
-	"getamark":                    '\u3013', // 〓
-	"ghabengali":                  '\u0998', // ঘ
-	"ghadarmenian":                '\u0572', // ղ
-	"ghadeva":                     '\u0918', // घ
-	"ghagujarati":                 '\u0a98', // ઘ
-	"ghagurmukhi":                 '\u0a18', // ਘ
-	"ghainarabic":                 '\u063a', // غ
-	"ghainfinalarabic":            '\ufece', // ﻎ
-	"ghaininitialarabic":          '\ufecf', // ﻏ
-	"ghainmedialarabic":           '\ufed0', // ﻐ
-	"ghemiddlehookcyrillic":       '\u0495', // ҕ
-	"ghestrokecyrillic":           '\u0493', // ғ
-	"gheupturncyrillic":           '\u0491', // ґ
-	"ghhadeva":                    '\u095a', // ग़
-	"ghhagurmukhi":                '\u0a5a', // ਗ਼
-	"ghook":                       '\u0260', // ɠ
-	"ghzsquare":                   '\u3393', // ㎓
-	"gihiragana":                  '\u304e', // ぎ
-	"gikatakana":                  '\u30ae', // ギ
-	"gimarmenian":                 '\u0563', // գ
-	"gimel":                       '\u05d2', // ג
-	"gimeldagesh":                 '\ufb32', // גּ
-	"gimeldageshhebrew":           '\ufb32', // גּ
-	"gimelhebrew":                 '\u05d2', // ג
-	"gjecyrillic":                 '\u0453', // ѓ
-	"glottalinvertedstroke":       '\u01be', // ƾ
-	"glottalstop":                 '\u0294', // ʔ
-	"glottalstopinverted":         '\u0296', // ʖ
-	"glottalstopmod":              '\u02c0', // ˀ
-	"glottalstopreversed":         '\u0295', // ʕ
-	"glottalstopreversedmod":      '\u02c1', // ˁ
-	"glottalstopreversedsuperior": '\u02e4', // ˤ
-	"glottalstopstroke":           '\u02a1', // ʡ
-	"glottalstopstrokereversed":   '\u02a2', // ʢ
-	"gmacron":                     '\u1e21', // ḡ
-	"gmonospace":                  '\uff47', // ｇ
-	"gohiragana":                  '\u3054', // ご
-	"gokatakana":                  '\u30b4', // ゴ
-	"gparen":                      '\u24a2', // ⒢
-	"gpasquare":                   '\u33ac', // ㎬
-	"gradient":                    '\u2207', // ∇
-	"grave":                       '\u0060', // `
-	"gravebelowcmb":               '\u0316', // ̖
-	"gravecmb":                    '\u0300', // ̀
-	"gravecomb":                   '\u0300', // ̀
-	"gravedeva":                   '\u0953', // ॓
-	"gravelowmod":                 '\u02ce', // ˎ
-	"gravemonospace":              '\uff40', // ｀
-	"gravetonecmb":                '\u0340', // ̀
-	"greater":                     '\u003e', // >
-	"greaterequal":                '\u2265', // ≥
-	"greaterequalorless":          '\u22db', // ⋛
-	"greatermonospace":            '\uff1e', // ＞
-	"greaterorequivalent":         '\u2273', // ≳
-	"greaterorless":               '\u2277', // ≷
-	"greateroverequal":            '\u2267', // ≧
-	"greatersmall":                '\ufe65', // ﹥
-	"gscript":                     '\u0261', // ɡ
-	"gstroke":                     '\u01e5', // ǥ
-	"guhiragana":                  '\u3050', // ぐ
-	"guillemotleft":               '\u00ab', // «
-	"guillemotright":              '\u00bb', // »
-	"guilsinglleft":               '\u2039', // ‹
-	"guilsinglright":              '\u203a', // ›
-	"gukatakana":                  '\u30b0', // グ
-	"guramusquare":                '\u3318', // ㌘
-	"gysquare":                    '\u33c9', // ㏉
-	"h":                           '\u0068', // h
-	"haabkhasiancyrillic":         '\u04a9', // ҩ
-	"haaltonearabic":              '\u06c1', // ہ
-	"habengali":                   '\u09b9', // হ
-	"hadescendercyrillic":         '\u04b3', // ҳ
-	"hadeva":                      '\u0939', // ह
-	"hagujarati":                  '\u0ab9', // હ
-	"hagurmukhi":                  '\u0a39', // ਹ
-	"haharabic":                   '\u062d', // ح
-	"hahfinalarabic":              '\ufea2', // ﺢ
-	"hahinitialarabic":            '\ufea3', // ﺣ
-	"hahiragana":                  '\u306f', // は
-	"hahmedialarabic":             '\ufea4', // ﺤ
-	"haitusquare":                 '\u332a', // ㌪
-	"hakatakana":                  '\u30cf', // ハ
-	"hakatakanahalfwidth":         '\uff8a', // ﾊ
-	"halantgurmukhi":              '\u0a4d', // ੍
-	"hamzaarabic":                 '\u0621', // ء
-	//    "hamzadammaarabic": '\u0621', // ءُ
-	//    "hamzadammatanarabic": '\u0621', // ءٌ
-	//    "hamzafathaarabic": '\u0621', // ءَ
-	//    "hamzafathatanarabic": '\u0621', // ءً
-	"hamzalowarabic": '\u0621', // ء
-	//    "hamzalowkasraarabic": '\u0621', // ءِ
-	//    "hamzalowkasratanarabic": '\u0621', // ءٍ
-	//    "hamzasukunarabic": '\u0621', // ءْ
-	"hangulfiller":                    '\u3164', // ㅤ
-	"hardsigncyrillic":                '\u044a', // ъ
-	"harpoonleftbarbup":               '\u21bc', // ↼
-	"harpoonrightbarbup":              '\u21c0', // ⇀
-	"hasquare":                        '\u33ca', // ㏊
-	"hatafpatah":                      '\u05b2', // ֲ
-	"hatafpatah16":                    '\u05b2', // ֲ
-	"hatafpatah23":                    '\u05b2', // ֲ
-	"hatafpatah2f":                    '\u05b2', // ֲ
-	"hatafpatahhebrew":                '\u05b2', // ֲ
-	"hatafpatahnarrowhebrew":          '\u05b2', // ֲ
-	"hatafpatahquarterhebrew":         '\u05b2', // ֲ
-	"hatafpatahwidehebrew":            '\u05b2', // ֲ
-	"hatafqamats":                     '\u05b3', // ֳ
-	"hatafqamats1b":                   '\u05b3', // ֳ
-	"hatafqamats28":                   '\u05b3', // ֳ
-	"hatafqamats34":                   '\u05b3', // ֳ
-	"hatafqamatshebrew":               '\u05b3', // ֳ
-	"hatafqamatsnarrowhebrew":         '\u05b3', // ֳ
-	"hatafqamatsquarterhebrew":        '\u05b3', // ֳ
-	"hatafqamatswidehebrew":           '\u05b3', // ֳ
-	"hatafsegol":                      '\u05b1', // ֱ
-	"hatafsegol17":                    '\u05b1', // ֱ
-	"hatafsegol24":                    '\u05b1', // ֱ
-	"hatafsegol30":                    '\u05b1', // ֱ
-	"hatafsegolhebrew":                '\u05b1', // ֱ
-	"hatafsegolnarrowhebrew":          '\u05b1', // ֱ
-	"hatafsegolquarterhebrew":         '\u05b1', // ֱ
-	"hatafsegolwidehebrew":            '\u05b1', // ֱ
-	"hbar":                            '\u0127', // ħ
-	"hbopomofo":                       '\u310f', // ㄏ
-	"hbrevebelow":                     '\u1e2b', // ḫ
-	"hcedilla":                        '\u1e29', // ḩ
-	"hcircle":                         '\u24d7', // ⓗ
-	"hcircumflex":                     '\u0125', // ĥ
-	"hdieresis":                       '\u1e27', // ḧ
-	"hdotaccent":                      '\u1e23', // ḣ
-	"hdotbelow":                       '\u1e25', // ḥ
-	"he":                              '\u05d4', // ה
-	"heart":                           '\u2665', // ♥
-	"heartsuitblack":                  '\u2665', // ♥
-	"heartsuitwhite":                  '\u2661', // ♡
-	"hedagesh":                        '\ufb34', // הּ
-	"hedageshhebrew":                  '\ufb34', // הּ
-	"hehaltonearabic":                 '\u06c1', // ہ
-	"heharabic":                       '\u0647', // ه
-	"hehebrew":                        '\u05d4', // ה
-	"hehfinalaltonearabic":            '\ufba7', // ﮧ
-	"hehfinalalttwoarabic":            '\ufeea', // ﻪ
-	"hehfinalarabic":                  '\ufeea', // ﻪ
-	"hehhamzaabovefinalarabic":        '\ufba5', // ﮥ
-	"hehhamzaaboveisolatedarabic":     '\ufba4', // ﮤ
-	"hehinitialaltonearabic":          '\ufba8', // ﮨ
-	"hehinitialarabic":                '\ufeeb', // ﻫ
-	"hehiragana":                      '\u3078', // へ
-	"hehmedialaltonearabic":           '\ufba9', // ﮩ
-	"hehmedialarabic":                 '\ufeec', // ﻬ
-	"heiseierasquare":                 '\u337b', // ㍻
-	"hekatakana":                      '\u30d8', // ヘ
-	"hekatakanahalfwidth":             '\uff8d', // ﾍ
-	"hekutaarusquare":                 '\u3336', // ㌶
-	"henghook":                        '\u0267', // ɧ
-	"herutusquare":                    '\u3339', // ㌹
-	"het":                             '\u05d7', // ח
-	"hethebrew":                       '\u05d7', // ח
-	"hhook":                           '\u0266', // ɦ
-	"hhooksuperior":                   '\u02b1', // ʱ
-	"hieuhacirclekorean":              '\u327b', // ㉻
-	"hieuhaparenkorean":               '\u321b', // ㈛
-	"hieuhcirclekorean":               '\u326d', // ㉭
-	"hieuhkorean":                     '\u314e', // ㅎ
-	"hieuhparenkorean":                '\u320d', // ㈍
-	"hihiragana":                      '\u3072', // ひ
-	"hikatakana":                      '\u30d2', // ヒ
-	"hikatakanahalfwidth":             '\uff8b', // ﾋ
-	"hiriq":                           '\u05b4', // ִ
-	"hiriq14":                         '\u05b4', // ִ
-	"hiriq21":                         '\u05b4', // ִ
-	"hiriq2d":                         '\u05b4', // ִ
-	"hiriqhebrew":                     '\u05b4', // ִ
-	"hiriqnarrowhebrew":               '\u05b4', // ִ
-	"hiriqquarterhebrew":              '\u05b4', // ִ
-	"hiriqwidehebrew":                 '\u05b4', // ִ
-	"hlinebelow":                      '\u1e96', // ẖ
-	"hmonospace":                      '\uff48', // ｈ
-	"hoarmenian":                      '\u0570', // հ
-	"hohipthai":                       '\u0e2b', // ห
-	"hohiragana":                      '\u307b', // ほ
-	"hokatakana":                      '\u30db', // ホ
-	"hokatakanahalfwidth":             '\uff8e', // ﾎ
-	"holam":                           '\u05b9', // ֹ
-	"holam19":                         '\u05b9', // ֹ
-	"holam26":                         '\u05b9', // ֹ
-	"holam32":                         '\u05b9', // ֹ
-	"holamhebrew":                     '\u05b9', // ֹ
-	"holamnarrowhebrew":               '\u05b9', // ֹ
-	"holamquarterhebrew":              '\u05b9', // ֹ
-	"holamwidehebrew":                 '\u05b9', // ֹ
-	"honokhukthai":                    '\u0e2e', // ฮ
-	"hookabovecomb":                   '\u0309', // ̉
-	"hookcmb":                         '\u0309', // ̉
-	"hookpalatalizedbelowcmb":         '\u0321', // ̡
-	"hookretroflexbelowcmb":           '\u0322', // ̢
-	"hoonsquare":                      '\u3342', // ㍂
-	"horicoptic":                      '\u03e9', // ϩ
-	"horizontalbar":                   '\u2015', // ―
-	"horncmb":                         '\u031b', // ̛
-	"hotsprings":                      '\u2668', // ♨
-	"house":                           '\u2302', // ⌂
-	"hparen":                          '\u24a3', // ⒣
-	"hsuperior":                       '\u02b0', // ʰ
-	"hturned":                         '\u0265', // ɥ
-	"huhiragana":                      '\u3075', // ふ
-	"huiitosquare":                    '\u3333', // ㌳
-	"hukatakana":                      '\u30d5', // フ
-	"hukatakanahalfwidth":             '\uff8c', // ﾌ
-	"hungarumlaut":                    '\u02dd', // ˝
-	"hungarumlautcmb":                 '\u030b', // ̋
-	"hv":                              '\u0195', // ƕ
-	"hyphen":                          '\u002d', // -
-	"hypheninferior":                  '\uf6e5', // 
-	"hyphenmonospace":                 '\uff0d', // －
-	"hyphensmall":                     '\ufe63', // ﹣
-	"hyphensuperior":                  '\uf6e6', // 
-	"hyphentwo":                       '\u2010', // ‐
-	"i":                               '\u0069', // i
-	"iacute":                          '\u00ed', // í
-	"iacyrillic":                      '\u044f', // я
-	"ibengali":                        '\u0987', // ই
-	"ibopomofo":                       '\u3127', // ㄧ
-	"ibreve":                          '\u012d', // ĭ
-	"icaron":                          '\u01d0', // ǐ
-	"icircle":                         '\u24d8', // ⓘ
-	"icircumflex":                     '\u00ee', // î
-	"icyrillic":                       '\u0456', // і
-	"idblgrave":                       '\u0209', // ȉ
-	"ideographearthcircle":            '\u328f', // ㊏
-	"ideographfirecircle":             '\u328b', // ㊋
-	"ideographicallianceparen":        '\u323f', // ㈿
-	"ideographiccallparen":            '\u323a', // ㈺
-	"ideographiccentrecircle":         '\u32a5', // ㊥
-	"ideographicclose":                '\u3006', // 〆
-	"ideographiccomma":                '\u3001', // 、
-	"ideographiccommaleft":            '\uff64', // ､
-	"ideographiccongratulationparen":  '\u3237', // ㈷
-	"ideographiccorrectcircle":        '\u32a3', // ㊣
-	"ideographicearthparen":           '\u322f', // ㈯
-	"ideographicenterpriseparen":      '\u323d', // ㈽
-	"ideographicexcellentcircle":      '\u329d', // ㊝
-	"ideographicfestivalparen":        '\u3240', // ㉀
-	"ideographicfinancialcircle":      '\u3296', // ㊖
-	"ideographicfinancialparen":       '\u3236', // ㈶
-	"ideographicfireparen":            '\u322b', // ㈫
-	"ideographichaveparen":            '\u3232', // ㈲
-	"ideographichighcircle":           '\u32a4', // ㊤
-	"ideographiciterationmark":        '\u3005', // 々
-	"ideographiclaborcircle":          '\u3298', // ㊘
-	"ideographiclaborparen":           '\u3238', // ㈸
-	"ideographicleftcircle":           '\u32a7', // ㊧
-	"ideographiclowcircle":            '\u32a6', // ㊦
-	"ideographicmedicinecircle":       '\u32a9', // ㊩
-	"ideographicmetalparen":           '\u322e', // ㈮
-	"ideographicmoonparen":            '\u322a', // ㈪
-	"ideographicnameparen":            '\u3234', // ㈴
-	"ideographicperiod":               '\u3002', // 。
-	"ideographicprintcircle":          '\u329e', // ㊞
-	"ideographicreachparen":           '\u3243', // ㉃
-	"ideographicrepresentparen":       '\u3239', // ㈹
-	"ideographicresourceparen":        '\u323e', // ㈾
-	"ideographicrightcircle":          '\u32a8', // ㊨
-	"ideographicsecretcircle":         '\u3299', // ㊙
-	"ideographicselfparen":            '\u3242', // ㉂
-	"ideographicsocietyparen":         '\u3233', // ㈳
-	"ideographicspace":                '\u3000', //
-	"ideographicspecialparen":         '\u3235', // ㈵
-	"ideographicstockparen":           '\u3231', // ㈱
-	"ideographicstudyparen":           '\u323b', // ㈻
-	"ideographicsunparen":             '\u3230', // ㈰
-	"ideographicsuperviseparen":       '\u323c', // ㈼
-	"ideographicwaterparen":           '\u322c', // ㈬
-	"ideographicwoodparen":            '\u322d', // ㈭
-	"ideographiczero":                 '\u3007', // 〇
-	"ideographmetalcircle":            '\u328e', // ㊎
-	"ideographmooncircle":             '\u328a', // ㊊
-	"ideographnamecircle":             '\u3294', // ㊔
-	"ideographsuncircle":              '\u3290', // ㊐
-	"ideographwatercircle":            '\u328c', // ㊌
-	"ideographwoodcircle":             '\u328d', // ㊍
-	"ideva":                           '\u0907', // इ
-	"idieresis":                       '\u00ef', // ï
-	"idieresisacute":                  '\u1e2f', // ḯ
-	"idieresiscyrillic":               '\u04e5', // ӥ
-	"idotbelow":                       '\u1ecb', // ị
-	"iebrevecyrillic":                 '\u04d7', // ӗ
-	"iecyrillic":                      '\u0435', // е
-	"ieungacirclekorean":              '\u3275', // ㉵
-	"ieungaparenkorean":               '\u3215', // ㈕
-	"ieungcirclekorean":               '\u3267', // ㉧
-	"ieungkorean":                     '\u3147', // ㅇ
-	"ieungparenkorean":                '\u3207', // ㈇
-	"igrave":                          '\u00ec', // ì
-	"igujarati":                       '\u0a87', // ઇ
-	"igurmukhi":                       '\u0a07', // ਇ
-	"ihiragana":                       '\u3044', // い
-	"ihookabove":                      '\u1ec9', // ỉ
-	"iibengali":                       '\u0988', // ঈ
-	"iicyrillic":                      '\u0438', // и
-	"iideva":                          '\u0908', // ई
-	"iigujarati":                      '\u0a88', // ઈ
-	"iigurmukhi":                      '\u0a08', // ਈ
-	"iimatragurmukhi":                 '\u0a40', // ੀ
-	"iinvertedbreve":                  '\u020b', // ȋ
-	"iishortcyrillic":                 '\u0439', // й
-	"iivowelsignbengali":              '\u09c0', // ী
-	"iivowelsigndeva":                 '\u0940', // ी
-	"iivowelsigngujarati":             '\u0ac0', // ી
-	"ij":                              '\u0133', // ĳ
-	"ikatakana":                       '\u30a4', // イ
-	"ikatakanahalfwidth":              '\uff72', // ｲ
-	"ikorean":                         '\u3163', // ㅣ
-	"ilde":                            '\u02dc', // ˜
-	"iluyhebrew":                      '\u05ac', // ֬
-	"imacron":                         '\u012b', // ī
-	"imacroncyrillic":                 '\u04e3', // ӣ
-	"imageorapproximatelyequal":       '\u2253', // ≓
-	"imatragurmukhi":                  '\u0a3f', // ਿ
-	"imonospace":                      '\uff49', // ｉ
-	"increment":                       '\u2206', // ∆
-	"infinity":                        '\u221e', // ∞
-	"iniarmenian":                     '\u056b', // ի
-	"integral":                        '\u222b', // ∫
-	"integralbottom":                  '\u2321', // ⌡
-	"integralbt":                      '\u2321', // ⌡
-	"integralex":                      '\uf8f5', // 
-	"integraltop":                     '\u2320', // ⌠
-	"integraltp":                      '\u2320', // ⌠
-	"intersection":                    '\u2229', // ∩
-	"intisquare":                      '\u3305', // ㌅
-	"invbullet":                       '\u25d8', // ◘
-	"invcircle":                       '\u25d9', // ◙
-	"invsmileface":                    '\u263b', // ☻
-	"iocyrillic":                      '\u0451', // ё
-	"iogonek":                         '\u012f', // į
-	"iota":                            '\u03b9', // ι
-	"iotadieresis":                    '\u03ca', // ϊ
-	"iotadieresistonos":               '\u0390', // ΐ
-	"iotalatin":                       '\u0269', // ɩ
-	"iotatonos":                       '\u03af', // ί
-	"iparen":                          '\u24a4', // ⒤
-	"irigurmukhi":                     '\u0a72', // ੲ
-	"ismallhiragana":                  '\u3043', // ぃ
-	"ismallkatakana":                  '\u30a3', // ィ
-	"ismallkatakanahalfwidth":         '\uff68', // ｨ
-	"issharbengali":                   '\u09fa', // ৺
-	"istroke":                         '\u0268', // ɨ
-	"isuperior":                       '\uf6ed', // 
-	"iterationhiragana":               '\u309d', // ゝ
-	"iterationkatakana":               '\u30fd', // ヽ
-	"itilde":                          '\u0129', // ĩ
-	"itildebelow":                     '\u1e2d', // ḭ
-	"iubopomofo":                      '\u3129', // ㄩ
-	"iucyrillic":                      '\u044e', // ю
-	"ivowelsignbengali":               '\u09bf', // ি
-	"ivowelsigndeva":                  '\u093f', // ि
-	"ivowelsigngujarati":              '\u0abf', // િ
-	"izhitsacyrillic":                 '\u0475', // ѵ
-	"izhitsadblgravecyrillic":         '\u0477', // ѷ
-	"j":                               '\u006a', // j
-	"jaarmenian":                      '\u0571', // ձ
-	"jabengali":                       '\u099c', // জ
-	"jadeva":                          '\u091c', // ज
-	"jagujarati":                      '\u0a9c', // જ
-	"jagurmukhi":                      '\u0a1c', // ਜ
-	"jbopomofo":                       '\u3110', // ㄐ
-	"jcaron":                          '\u01f0', // ǰ
-	"jcircle":                         '\u24d9', // ⓙ
-	"jcircumflex":                     '\u0135', // ĵ
-	"jcrossedtail":                    '\u029d', // ʝ
-	"jdotlessstroke":                  '\u025f', // ɟ
-	"jecyrillic":                      '\u0458', // ј
-	"jeemarabic":                      '\u062c', // ج
-	"jeemfinalarabic":                 '\ufe9e', // ﺞ
-	"jeeminitialarabic":               '\ufe9f', // ﺟ
-	"jeemmedialarabic":                '\ufea0', // ﺠ
-	"jeharabic":                       '\u0698', // ژ
-	"jehfinalarabic":                  '\ufb8b', // ﮋ
-	"jhabengali":                      '\u099d', // ঝ
-	"jhadeva":                         '\u091d', // झ
-	"jhagujarati":                     '\u0a9d', // ઝ
-	"jhagurmukhi":                     '\u0a1d', // ਝ
-	"jheharmenian":                    '\u057b', // ջ
-	"jis":                             '\u3004', // 〄
-	"jmonospace":                      '\uff4a', // ｊ
-	"jparen":                          '\u24a5', // ⒥
-	"jsuperior":                       '\u02b2', // ʲ
-	"k":                               '\u006b', // k
-	"kabashkircyrillic":               '\u04a1', // ҡ
-	"kabengali":                       '\u0995', // ক
-	"kacute":                          '\u1e31', // ḱ
-	"kacyrillic":                      '\u043a', // к
-	"kadescendercyrillic":             '\u049b', // қ
-	"kadeva":                          '\u0915', // क
-	"kaf":                             '\u05db', // כ
-	"kafarabic":                       '\u0643', // ك
-	"kafdagesh":                       '\ufb3b', // כּ
-	"kafdageshhebrew":                 '\ufb3b', // כּ
-	"kaffinalarabic":                  '\ufeda', // ﻚ
-	"kafhebrew":                       '\u05db', // כ
-	"kafinitialarabic":                '\ufedb', // ﻛ
-	"kafmedialarabic":                 '\ufedc', // ﻜ
-	"kafrafehebrew":                   '\ufb4d', // כֿ
-	"kagujarati":                      '\u0a95', // ક
-	"kagurmukhi":                      '\u0a15', // ਕ
-	"kahiragana":                      '\u304b', // か
-	"kahookcyrillic":                  '\u04c4', // ӄ
-	"kakatakana":                      '\u30ab', // カ
-	"kakatakanahalfwidth":             '\uff76', // ｶ
-	"kappa":                           '\u03ba', // κ
-	"kappasymbolgreek":                '\u03f0', // ϰ
-	"kapyeounmieumkorean":             '\u3171', // ㅱ
-	"kapyeounphieuphkorean":           '\u3184', // ㆄ
-	"kapyeounpieupkorean":             '\u3178', // ㅸ
-	"kapyeounssangpieupkorean":        '\u3179', // ㅹ
-	"karoriisquare":                   '\u330d', // ㌍
-	"kashidaautoarabic":               '\u0640', // ـ
-	"kashidaautonosidebearingarabic":  '\u0640', // ـ
-	"kasmallkatakana":                 '\u30f5', // ヵ
-	"kasquare":                        '\u3384', // ㎄
-	"kasraarabic":                     '\u0650', // ِ
-	"kasratanarabic":                  '\u064d', // ٍ
-	"kastrokecyrillic":                '\u049f', // ҟ
-	"katahiraprolongmarkhalfwidth":    '\uff70', // ｰ
-	"kaverticalstrokecyrillic":        '\u049d', // ҝ
-	"kbopomofo":                       '\u310e', // ㄎ
-	"kcalsquare":                      '\u3389', // ㎉
-	"kcaron":                          '\u01e9', // ǩ
-	"kcedilla":                        '\u0137', // ķ
-	"kcircle":                         '\u24da', // ⓚ
-	"kcommaaccent":                    '\u0137', // ķ
-	"kdotbelow":                       '\u1e33', // ḳ
-	"keharmenian":                     '\u0584', // ք
-	"kehiragana":                      '\u3051', // け
-	"kekatakana":                      '\u30b1', // ケ
-	"kekatakanahalfwidth":             '\uff79', // ｹ
-	"kenarmenian":                     '\u056f', // կ
-	"kesmallkatakana":                 '\u30f6', // ヶ
-	"kgreenlandic":                    '\u0138', // ĸ
-	"khabengali":                      '\u0996', // খ
-	"khacyrillic":                     '\u0445', // х
-	"khadeva":                         '\u0916', // ख
-	"khagujarati":                     '\u0a96', // ખ
-	"khagurmukhi":                     '\u0a16', // ਖ
-	"khaharabic":                      '\u062e', // خ
-	"khahfinalarabic":                 '\ufea6', // ﺦ
-	"khahinitialarabic":               '\ufea7', // ﺧ
-	"khahmedialarabic":                '\ufea8', // ﺨ
-	"kheicoptic":                      '\u03e7', // ϧ
-	"khhadeva":                        '\u0959', // ख़
-	"khhagurmukhi":                    '\u0a59', // ਖ਼
-	"khieukhacirclekorean":            '\u3278', // ㉸
-	"khieukhaparenkorean":             '\u3218', // ㈘
-	"khieukhcirclekorean":             '\u326a', // ㉪
-	"khieukhkorean":                   '\u314b', // ㅋ
-	"khieukhparenkorean":              '\u320a', // ㈊
-	"khokhaithai":                     '\u0e02', // ข
-	"khokhonthai":                     '\u0e05', // ฅ
-	"khokhuatthai":                    '\u0e03', // ฃ
-	"khokhwaithai":                    '\u0e04', // ค
-	"khomutthai":                      '\u0e5b', // ๛
-	"khook":                           '\u0199', // ƙ
-	"khorakhangthai":                  '\u0e06', // ฆ
-	"khzsquare":                       '\u3391', // ㎑
-	"kihiragana":                      '\u304d', // き
-	"kikatakana":                      '\u30ad', // キ
-	"kikatakanahalfwidth":             '\uff77', // ｷ
-	"kiroguramusquare":                '\u3315', // ㌕
-	"kiromeetorusquare":               '\u3316', // ㌖
-	"kirosquare":                      '\u3314', // ㌔
-	"kiyeokacirclekorean":             '\u326e', // ㉮
-	"kiyeokaparenkorean":              '\u320e', // ㈎
-	"kiyeokcirclekorean":              '\u3260', // ㉠
-	"kiyeokkorean":                    '\u3131', // ㄱ
-	"kiyeokparenkorean":               '\u3200', // ㈀
-	"kiyeoksioskorean":                '\u3133', // ㄳ
-	"kjecyrillic":                     '\u045c', // ќ
-	"klinebelow":                      '\u1e35', // ḵ
-	"klsquare":                        '\u3398', // ㎘
-	"kmcubedsquare":                   '\u33a6', // ㎦
-	"kmonospace":                      '\uff4b', // ｋ
-	"kmsquaredsquare":                 '\u33a2', // ㎢
-	"kohiragana":                      '\u3053', // こ
-	"kohmsquare":                      '\u33c0', // ㏀
-	"kokaithai":                       '\u0e01', // ก
-	"kokatakana":                      '\u30b3', // コ
-	"kokatakanahalfwidth":             '\uff7a', // ｺ
-	"kooposquare":                     '\u331e', // ㌞
-	"koppacyrillic":                   '\u0481', // ҁ
-	"koreanstandardsymbol":            '\u327f', // ㉿
-	"koroniscmb":                      '\u0343', // ̓
-	"kparen":                          '\u24a6', // ⒦
-	"kpasquare":                       '\u33aa', // ㎪
-	"ksicyrillic":                     '\u046f', // ѯ
-	"ktsquare":                        '\u33cf', // ㏏
-	"kturned":                         '\u029e', // ʞ
-	"kuhiragana":                      '\u304f', // く
-	"kukatakana":                      '\u30af', // ク
-	"kukatakanahalfwidth":             '\uff78', // ｸ
-	"kvsquare":                        '\u33b8', // ㎸
-	"kwsquare":                        '\u33be', // ㎾
-	"l":                               '\u006c', // l
-	"labengali":                       '\u09b2', // ল
-	"lacute":                          '\u013a', // ĺ
-	"ladeva":                          '\u0932', // ल
-	"lagujarati":                      '\u0ab2', // લ
-	"lagurmukhi":                      '\u0a32', // ਲ
-	"lakkhangyaothai":                 '\u0e45', // ๅ
-	"lamaleffinalarabic":              '\ufefc', // ﻼ
-	"lamalefhamzaabovefinalarabic":    '\ufef8', // ﻸ
-	"lamalefhamzaaboveisolatedarabic": '\ufef7', // ﻷ
-	"lamalefhamzabelowfinalarabic":    '\ufefa', // ﻺ
-	"lamalefhamzabelowisolatedarabic": '\ufef9', // ﻹ
-	"lamalefisolatedarabic":           '\ufefb', // ﻻ
-	"lamalefmaddaabovefinalarabic":    '\ufef6', // ﻶ
-	"lamalefmaddaaboveisolatedarabic": '\ufef5', // ﻵ
-	"lamarabic":                       '\u0644', // ل
-	"lambda":                          '\u03bb', // λ
-	"lambdastroke":                    '\u019b', // ƛ
-	"lamed":                           '\u05dc', // ל
-	"lameddagesh":                     '\ufb3c', // לּ
-	"lameddageshhebrew":               '\ufb3c', // לּ
-	"lamedhebrew":                     '\u05dc', // ל
-	//    "lamedholam": '\u05dc', // לֹ
-	//    "lamedholamdagesh": '\u05dc', // לֹּ
-	//    "lamedholamdageshhebrew": '\u05dc', // לֹּ
-	//    "lamedholamhebrew": '\u05dc', // לֹ
-	"lamfinalarabic":          '\ufede', // ﻞ
-	"lamhahinitialarabic":     '\ufcca', // ﳊ
-	"laminitialarabic":        '\ufedf', // ﻟ
-	"lamjeeminitialarabic":    '\ufcc9', // ﳉ
-	"lamkhahinitialarabic":    '\ufccb', // ﳋ
-	"lamlamhehisolatedarabic": '\ufdf2', // ﷲ
-	"lammedialarabic":         '\ufee0', // ﻠ
-	"lammeemhahinitialarabic": '\ufd88', // ﶈ
-	"lammeeminitialarabic":    '\ufccc', // ﳌ
-	//    "lammeemjeeminitialarabic": '\ufedf', // ﻟﻤﺠ
-	//    "lammeemkhahinitialarabic": '\ufedf', // ﻟﻤﺨ
-	"largecircle":                '\u25ef', // ◯
-	"lbar":                       '\u019a', // ƚ
-	"lbelt":                      '\u026c', // ɬ
-	"lbopomofo":                  '\u310c', // ㄌ
-	"lcaron":                     '\u013e', // ľ
-	"lcedilla":                   '\u013c', // ļ
-	"lcircle":                    '\u24db', // ⓛ
-	"lcircumflexbelow":           '\u1e3d', // ḽ
-	"lcommaaccent":               '\u013c', // ļ
-	"ldot":                       '\u0140', // ŀ
-	"ldotaccent":                 '\u0140', // ŀ
-	"ldotbelow":                  '\u1e37', // ḷ
-	"ldotbelowmacron":            '\u1e39', // ḹ
-	"leftangleabovecmb":          '\u031a', // ̚
-	"lefttackbelowcmb":           '\u0318', // ̘
-	"less":                       '\u003c', // <
-	"lessequal":                  '\u2264', // ≤
-	"lessequalorgreater":         '\u22da', // ⋚
-	"lessmonospace":              '\uff1c', // ＜
-	"lessorequivalent":           '\u2272', // ≲
-	"lessorgreater":              '\u2276', // ≶
-	"lessoverequal":              '\u2266', // ≦
-	"lesssmall":                  '\ufe64', // ﹤
-	"lezh":                       '\u026e', // ɮ
-	"lfblock":                    '\u258c', // ▌
-	"lhookretroflex":             '\u026d', // ɭ
-	"lira":                       '\u20a4', // ₤
-	"liwnarmenian":               '\u056c', // լ
-	"lj":                         '\u01c9', // ǉ
-	"ljecyrillic":                '\u0459', // љ
-	"ll":                         '\uf6c0', // 
-	"lladeva":                    '\u0933', // ळ
-	"llagujarati":                '\u0ab3', // ળ
-	"llinebelow":                 '\u1e3b', // ḻ
-	"llladeva":                   '\u0934', // ऴ
-	"llvocalicbengali":           '\u09e1', // ৡ
-	"llvocalicdeva":              '\u0961', // ॡ
-	"llvocalicvowelsignbengali":  '\u09e3', // ৣ
-	"llvocalicvowelsigndeva":     '\u0963', // ॣ
-	"lmiddletilde":               '\u026b', // ɫ
-	"lmonospace":                 '\uff4c', // ｌ
-	"lmsquare":                   '\u33d0', // ㏐
-	"lochulathai":                '\u0e2c', // ฬ
-	"logicaland":                 '\u2227', // ∧
-	"logicalnot":                 '\u00ac', // ¬
-	"logicalnotreversed":         '\u2310', // ⌐
-	"logicalor":                  '\u2228', // ∨
-	"lolingthai":                 '\u0e25', // ล
-	"longs":                      '\u017f', // ſ
-	"lowlinecenterline":          '\ufe4e', // ﹎
-	"lowlinecmb":                 '\u0332', // ̲
-	"lowlinedashed":              '\ufe4d', // ﹍
-	"lozenge":                    '\u25ca', // ◊
-	"lparen":                     '\u24a7', // ⒧
-	"lslash":                     '\u0142', // ł
-	"lsquare":                    '\u2113', // ℓ
-	"lsuperior":                  '\uf6ee', // 
-	"ltshade":                    '\u2591', // ░
-	"luthai":                     '\u0e26', // ฦ
-	"lvocalicbengali":            '\u098c', // ঌ
-	"lvocalicdeva":               '\u090c', // ऌ
-	"lvocalicvowelsignbengali":   '\u09e2', // ৢ
-	"lvocalicvowelsigndeva":      '\u0962', // ॢ
-	"lxsquare":                   '\u33d3', // ㏓
-	"m":                          '\u006d', // m
-	"mabengali":                  '\u09ae', // ম
-	"macron":                     '\u00af', // ¯
-	"macronbelowcmb":             '\u0331', // ̱
-	"macroncmb":                  '\u0304', // ̄
-	"macronlowmod":               '\u02cd', // ˍ
-	"macronmonospace":            '\uffe3', // ￣
-	"macute":                     '\u1e3f', // ḿ
-	"madeva":                     '\u092e', // म
-	"magujarati":                 '\u0aae', // મ
-	"magurmukhi":                 '\u0a2e', // ਮ
-	"mahapakhhebrew":             '\u05a4', // ֤
-	"mahapakhlefthebrew":         '\u05a4', // ֤
-	"mahiragana":                 '\u307e', // ま
-	"maichattawalowleftthai":     '\uf895', // 
-	"maichattawalowrightthai":    '\uf894', // 
-	"maichattawathai":            '\u0e4b', // ๋
-	"maichattawaupperleftthai":   '\uf893', // 
-	"maieklowleftthai":           '\uf88c', // 
-	"maieklowrightthai":          '\uf88b', // 
-	"maiekthai":                  '\u0e48', // ่
-	"maiekupperleftthai":         '\uf88a', // 
-	"maihanakatleftthai":         '\uf884', // 
-	"maihanakatthai":             '\u0e31', // ั
-	"maitaikhuleftthai":          '\uf889', // 
-	"maitaikhuthai":              '\u0e47', // ็
-	"maitholowleftthai":          '\uf88f', // 
-	"maitholowrightthai":         '\uf88e', // 
-	"maithothai":                 '\u0e49', // ้
-	"maithoupperleftthai":        '\uf88d', // 
-	"maitrilowleftthai":          '\uf892', // 
-	"maitrilowrightthai":         '\uf891', // 
-	"maitrithai":                 '\u0e4a', // ๊
-	"maitriupperleftthai":        '\uf890', // 
-	"maiyamokthai":               '\u0e46', // ๆ
-	"makatakana":                 '\u30de', // マ
-	"makatakanahalfwidth":        '\uff8f', // ﾏ
-	"male":                       '\u2642', // ♂
-	"mansyonsquare":              '\u3347', // ㍇
-	"maqafhebrew":                '\u05be', // ־
-	"mars":                       '\u2642', // ♂
-	"masoracirclehebrew":         '\u05af', // ֯
-	"masquare":                   '\u3383', // ㎃
-	"mbopomofo":                  '\u3107', // ㄇ
-	"mbsquare":                   '\u33d4', // ㏔
-	"mcircle":                    '\u24dc', // ⓜ
-	"mcubedsquare":               '\u33a5', // ㎥
-	"mdotaccent":                 '\u1e41', // ṁ
-	"mdotbelow":                  '\u1e43', // ṃ
-	"meemarabic":                 '\u0645', // م
-	"meemfinalarabic":            '\ufee2', // ﻢ
-	"meeminitialarabic":          '\ufee3', // ﻣ
-	"meemmedialarabic":           '\ufee4', // ﻤ
-	"meemmeeminitialarabic":      '\ufcd1', // ﳑ
-	"meemmeemisolatedarabic":     '\ufc48', // ﱈ
-	"meetorusquare":              '\u334d', // ㍍
-	"mehiragana":                 '\u3081', // め
-	"meizierasquare":             '\u337e', // ㍾
-	"mekatakana":                 '\u30e1', // メ
-	"mekatakanahalfwidth":        '\uff92', // ﾒ
-	"mem":                        '\u05de', // מ
-	"memdagesh":                  '\ufb3e', // מּ
-	"memdageshhebrew":            '\ufb3e', // מּ
-	"memhebrew":                  '\u05de', // מ
-	"menarmenian":                '\u0574', // մ
-	"merkhahebrew":               '\u05a5', // ֥
-	"merkhakefulahebrew":         '\u05a6', // ֦
-	"merkhakefulalefthebrew":     '\u05a6', // ֦
-	"merkhalefthebrew":           '\u05a5', // ֥
-	"mhook":                      '\u0271', // ɱ
-	"mhzsquare":                  '\u3392', // ㎒
-	"middledotkatakanahalfwidth": '\uff65', // ･
-	"middot":                     '\u00b7', // ·
-	"mieumacirclekorean":         '\u3272', // ㉲
-	"mieumaparenkorean":          '\u3212', // ㈒
-	"mieumcirclekorean":          '\u3264', // ㉤
-	"mieumkorean":                '\u3141', // ㅁ
-	"mieumpansioskorean":         '\u3170', // ㅰ
-	"mieumparenkorean":           '\u3204', // ㈄
-	"mieumpieupkorean":           '\u316e', // ㅮ
-	"mieumsioskorean":            '\u316f', // ㅯ
-	"mihiragana":                 '\u307f', // み
-	"mikatakana":                 '\u30df', // ミ
-	"mikatakanahalfwidth":        '\uff90', // ﾐ
-	"minus":                      '\u2212', // −
-	"minusbelowcmb":              '\u0320', // ̠
-	"minuscircle":                '\u2296', // ⊖
-	"minusmod":                   '\u02d7', // ˗
-	"minusplus":                  '\u2213', // ∓
-	"minute":                     '\u2032', // ′
-	"miribaarusquare":            '\u334a', // ㍊
-	"mirisquare":                 '\u3349', // ㍉
-	"mlonglegturned":             '\u0270', // ɰ
-	"mlsquare":                   '\u3396', // ㎖
-	"mmcubedsquare":              '\u33a3', // ㎣
-	"mmonospace":                 '\uff4d', // ｍ
-	"mmsquaredsquare":            '\u339f', // ㎟
-	"mohiragana":                 '\u3082', // も
-	"mohmsquare":                 '\u33c1', // ㏁
-	"mokatakana":                 '\u30e2', // モ
-	"mokatakanahalfwidth":        '\uff93', // ﾓ
-	"molsquare":                  '\u33d6', // ㏖
-	"momathai":                   '\u0e21', // ม
-	"moverssquare":               '\u33a7', // ㎧
-	"moverssquaredsquare":        '\u33a8', // ㎨
-	"mparen":                     '\u24a8', // ⒨
-	"mpasquare":                  '\u33ab', // ㎫
-	"mssquare":                   '\u33b3', // ㎳
-	"msuperior":                  '\uf6ef', // 
-	"mturned":                    '\u026f', // ɯ
-	"mu":                         '\u00b5', // µ
-	"mu1":                        '\u00b5', // µ
-	"muasquare":                  '\u3382', // ㎂
-	"muchgreater":                '\u226b', // ≫
-	"muchless":                   '\u226a', // ≪
-	"mufsquare":                  '\u338c', // ㎌
-	"mugreek":                    '\u03bc', // μ
-	"mugsquare":                  '\u338d', // ㎍
-	"muhiragana":                 '\u3080', // む
-	"mukatakana":                 '\u30e0', // ム
-	"mukatakanahalfwidth":        '\uff91', // ﾑ
-	"mulsquare":                  '\u3395', // ㎕
-	"multiply":                   '\u00d7', // ×
-	"mumsquare":                  '\u339b', // ㎛
-	"munahhebrew":                '\u05a3', // ֣
-	"munahlefthebrew":            '\u05a3', // ֣
-	"musicalnote":                '\u266a', // ♪
-	"musicalnotedbl":             '\u266b', // ♫
-	"musicflatsign":              '\u266d', // ♭
-	"musicsharpsign":             '\u266f', // ♯
-	"mussquare":                  '\u33b2', // ㎲
-	"muvsquare":                  '\u33b6', // ㎶
-	"muwsquare":                  '\u33bc', // ㎼
-	"mvmegasquare":               '\u33b9', // ㎹
-	"mvsquare":                   '\u33b7', // ㎷
-	"mwmegasquare":               '\u33bf', // ㎿
-	"mwsquare":                   '\u33bd', // ㎽
-	"n":                          '\u006e', // n
-	"nabengali":                  '\u09a8', // ন
-	"nabla":                      '\u2207', // ∇
-	"nacute":                     '\u0144', // ń
-	"nadeva":                     '\u0928', // न
-	"nagujarati":                 '\u0aa8', // ન
-	"nagurmukhi":                 '\u0a28', // ਨ
-	"nahiragana":                 '\u306a', // な
-	"nakatakana":                 '\u30ca', // ナ
-	"nakatakanahalfwidth":        '\uff85', // ﾅ
-	"napostrophe":                '\u0149', // ŉ
-	"nasquare":                   '\u3381', // ㎁
-	"nbopomofo":                  '\u310b', // ㄋ
-	"nbspace":                    '\u00a0', //
-	"ncaron":                     '\u0148', // ň
-	"ncedilla":                   '\u0146', // ņ
-	"ncircle":                    '\u24dd', // ⓝ
-	"ncircumflexbelow":           '\u1e4b', // ṋ
-	"ncommaaccent":               '\u0146', // ņ
-	"ndotaccent":                 '\u1e45', // ṅ
-	"ndotbelow":                  '\u1e47', // ṇ
-	"nehiragana":                 '\u306d', // ね
-	"nekatakana":                 '\u30cd', // ネ
-	"nekatakanahalfwidth":        '\uff88', // ﾈ
-	"newsheqelsign":              '\u20aa', // ₪
-	"nfsquare":                   '\u338b', // ㎋
-	"ngabengali":                 '\u0999', // ঙ
-	"ngadeva":                    '\u0919', // ङ
-	"ngagujarati":                '\u0a99', // ઙ
-	"ngagurmukhi":                '\u0a19', // ਙ
-	"ngonguthai":                 '\u0e07', // ง
-	"nhiragana":                  '\u3093', // ん
-	"nhookleft":                  '\u0272', // ɲ
-	"nhookretroflex":             '\u0273', // ɳ
-	"nieunacirclekorean":         '\u326f', // ㉯
-	"nieunaparenkorean":          '\u320f', // ㈏
-	"nieuncieuckorean":           '\u3135', // ㄵ
-	"nieuncirclekorean":          '\u3261', // ㉡
-	"nieunhieuhkorean":           '\u3136', // ㄶ
-	"nieunkorean":                '\u3134', // ㄴ
-	"nieunpansioskorean":         '\u3168', // ㅨ
-	"nieunparenkorean":           '\u3201', // ㈁
-	"nieunsioskorean":            '\u3167', // ㅧ
-	"nieuntikeutkorean":          '\u3166', // ㅦ
-	"nihiragana":                 '\u306b', // に
-	"nikatakana":                 '\u30cb', // ニ
-	"nikatakanahalfwidth":        '\uff86', // ﾆ
-	"nikhahitleftthai":           '\uf899', // 
-	"nikhahitthai":               '\u0e4d', // ํ
-	"nine":                       '\u0039', // 9
-	"ninearabic":                 '\u0669', // ٩
-	"ninebengali":                '\u09ef', // ৯
-	"ninecircle":                 '\u2468', // ⑨
-	"ninecircleinversesansserif": '\u2792', // ➒
-	"ninedeva":                   '\u096f', // ९
-	"ninegujarati":               '\u0aef', // ૯
-	"ninegurmukhi":               '\u0a6f', // ੯
-	"ninehackarabic":             '\u0669', // ٩
-	"ninehangzhou":               '\u3029', // 〩
-	"nineideographicparen":       '\u3228', // ㈨
-	"nineinferior":               '\u2089', // ₉
-	"ninemonospace":              '\uff19', // ９
-	"nineoldstyle":               '\uf739', // 
-	"nineparen":                  '\u247c', // ⑼
-	"nineperiod":                 '\u2490', // ⒐
-	"ninepersian":                '\u06f9', // ۹
-	"nineroman":                  '\u2178', // ⅸ
-	"ninesuperior":               '\u2079', // ⁹
-	"nineteencircle":             '\u2472', // ⑲
-	"nineteenparen":              '\u2486', // ⒆
-	"nineteenperiod":             '\u249a', // ⒚
-	"ninethai":                   '\u0e59', // ๙
-	"nj":                         '\u01cc', // ǌ
-	"njecyrillic":                '\u045a', // њ
-	"nkatakana":                  '\u30f3', // ン
-	"nkatakanahalfwidth":         '\uff9d', // ﾝ
-	"nlegrightlong":              '\u019e', // ƞ
-	"nlinebelow":                 '\u1e49', // ṉ
-	"nmonospace":                 '\uff4e', // ｎ
-	"nmsquare":                   '\u339a', // ㎚
-	"nnabengali":                 '\u09a3', // ণ
-	"nnadeva":                    '\u0923', // ण
-	"nnagujarati":                '\u0aa3', // ણ
-	"nnagurmukhi":                '\u0a23', // ਣ
-	"nnnadeva":                   '\u0929', // ऩ
-	"nohiragana":                 '\u306e', // の
-	"nokatakana":                 '\u30ce', // ノ
-	"nokatakanahalfwidth":        '\uff89', // ﾉ
-	"nonbreakingspace":           '\u00a0', //
-	"nonenthai":                  '\u0e13', // ณ
-	"nonuthai":                   '\u0e19', // น
-	"noonarabic":                 '\u0646', // ن
-	"noonfinalarabic":            '\ufee6', // ﻦ
-	"noonghunnaarabic":           '\u06ba', // ں
-	"noonghunnafinalarabic":      '\ufb9f', // ﮟ
-	//    "noonhehinitialarabic": '\ufee7', // ﻧﻬ
-	"nooninitialarabic":         '\ufee7', // ﻧ
-	"noonjeeminitialarabic":     '\ufcd2', // ﳒ
-	"noonjeemisolatedarabic":    '\ufc4b', // ﱋ
-	"noonmedialarabic":          '\ufee8', // ﻨ
-	"noonmeeminitialarabic":     '\ufcd5', // ﳕ
-	"noonmeemisolatedarabic":    '\ufc4e', // ﱎ
-	"noonnoonfinalarabic":       '\ufc8d', // ﲍ
-	"notcontains":               '\u220c', // ∌
-	"notelement":                '\u2209', // ∉
-	"notelementof":              '\u2209', // ∉
-	"notequal":                  '\u2260', // ≠
-	"notgreater":                '\u226f', // ≯
-	"notgreaternorequal":        '\u2271', // ≱
-	"notgreaternorless":         '\u2279', // ≹
-	"notidentical":              '\u2262', // ≢
-	"notless":                   '\u226e', // ≮
-	"notlessnorequal":           '\u2270', // ≰
-	"notparallel":               '\u2226', // ∦
-	"notprecedes":               '\u2280', // ⊀
-	"notsubset":                 '\u2284', // ⊄
-	"notsucceeds":               '\u2281', // ⊁
-	"notsuperset":               '\u2285', // ⊅
-	"nowarmenian":               '\u0576', // ն
-	"nparen":                    '\u24a9', // ⒩
-	"nssquare":                  '\u33b1', // ㎱
-	"nsuperior":                 '\u207f', // ⁿ
-	"ntilde":                    '\u00f1', // ñ
-	"nu":                        '\u03bd', // ν
-	"nuhiragana":                '\u306c', // ぬ
-	"nukatakana":                '\u30cc', // ヌ
-	"nukatakanahalfwidth":       '\uff87', // ﾇ
-	"nuktabengali":              '\u09bc', // ়
-	"nuktadeva":                 '\u093c', // ़
-	"nuktagujarati":             '\u0abc', // ઼
-	"nuktagurmukhi":             '\u0a3c', // ਼
-	"numbersign":                '\u0023', // #
-	"numbersignmonospace":       '\uff03', // ＃
-	"numbersignsmall":           '\ufe5f', // ﹟
-	"numeralsigngreek":          '\u0374', // ʹ
-	"numeralsignlowergreek":     '\u0375', // ͵
-	"numero":                    '\u2116', // №
-	"nun":                       '\u05e0', // נ
-	"nundagesh":                 '\ufb40', // נּ
-	"nundageshhebrew":           '\ufb40', // נּ
-	"nunhebrew":                 '\u05e0', // נ
-	"nvsquare":                  '\u33b5', // ㎵
-	"nwsquare":                  '\u33bb', // ㎻
-	"nyabengali":                '\u099e', // ঞ
-	"nyadeva":                   '\u091e', // ञ
-	"nyagujarati":               '\u0a9e', // ઞ
-	"nyagurmukhi":               '\u0a1e', // ਞ
-	"o":                         '\u006f', // o
-	"oacute":                    '\u00f3', // ó
-	"oangthai":                  '\u0e2d', // อ
-	"obarred":                   '\u0275', // ɵ
-	"obarredcyrillic":           '\u04e9', // ө
-	"obarreddieresiscyrillic":   '\u04eb', // ӫ
-	"obengali":                  '\u0993', // ও
-	"obopomofo":                 '\u311b', // ㄛ
-	"obreve":                    '\u014f', // ŏ
-	"ocandradeva":               '\u0911', // ऑ
-	"ocandragujarati":           '\u0a91', // ઑ
-	"ocandravowelsigndeva":      '\u0949', // ॉ
-	"ocandravowelsigngujarati":  '\u0ac9', // ૉ
-	"ocaron":                    '\u01d2', // ǒ
-	"ocircle":                   '\u24de', // ⓞ
-	"ocircumflex":               '\u00f4', // ô
-	"ocircumflexacute":          '\u1ed1', // ố
-	"ocircumflexdotbelow":       '\u1ed9', // ộ
-	"ocircumflexgrave":          '\u1ed3', // ồ
-	"ocircumflexhookabove":      '\u1ed5', // ổ
-	"ocircumflextilde":          '\u1ed7', // ỗ
-	"ocyrillic":                 '\u043e', // о
-	"odblacute":                 '\u0151', // ő
-	"odblgrave":                 '\u020d', // ȍ
-	"odeva":                     '\u0913', // ओ
-	"odieresis":                 '\u00f6', // ö
-	"odieresiscyrillic":         '\u04e7', // ӧ
-	"odotbelow":                 '\u1ecd', // ọ
-	"oe":                        '\u0153', // œ
-	"oekorean":                  '\u315a', // ㅚ
-	"ogonek":                    '\u02db', // ˛
-	"ogonekcmb":                 '\u0328', // ̨
-	"ograve":                    '\u00f2', // ò
-	"ogujarati":                 '\u0a93', // ઓ
-	"oharmenian":                '\u0585', // օ
-	"ohiragana":                 '\u304a', // お
-	"ohookabove":                '\u1ecf', // ỏ
-	"ohorn":                     '\u01a1', // ơ
-	"ohornacute":                '\u1edb', // ớ
-	"ohorndotbelow":             '\u1ee3', // ợ
-	"ohorngrave":                '\u1edd', // ờ
-	"ohornhookabove":            '\u1edf', // ở
-	"ohorntilde":                '\u1ee1', // ỡ
-	"ohungarumlaut":             '\u0151', // ő
-	"oi":                        '\u01a3', // ƣ
-	"oinvertedbreve":            '\u020f', // ȏ
-	"okatakana":                 '\u30aa', // オ
-	"okatakanahalfwidth":        '\uff75', // ｵ
-	"okorean":                   '\u3157', // ㅗ
-	"olehebrew":                 '\u05ab', // ֫
-	"omacron":                   '\u014d', // ō
-	"omacronacute":              '\u1e53', // ṓ
-	"omacrongrave":              '\u1e51', // ṑ
-	"omdeva":                    '\u0950', // ॐ
-	"omega":                     '\u03c9', // ω
-	"omega1":                    '\u03d6', // ϖ
-	"omegacyrillic":             '\u0461', // ѡ
-	"omegalatinclosed":          '\u0277', // ɷ
-	"omegaroundcyrillic":        '\u047b', // ѻ
-	"omegatitlocyrillic":        '\u047d', // ѽ
-	"omegatonos":                '\u03ce', // ώ
-	"omgujarati":                '\u0ad0', // ૐ
-	"omicron":                   '\u03bf', // ο
-	"omicrontonos":              '\u03cc', // ό
-	"omonospace":                '\uff4f', // ｏ
-	"one":                       '\u0031', // 1
-	"onearabic":                 '\u0661', // ١
-	"onebengali":                '\u09e7', // ১
-	"onecircle":                 '\u2460', // ①
-	"onecircleinversesansserif": '\u278a', // ➊
-	"onedeva":                   '\u0967', // १
-	"onedotenleader":            '\u2024', // ․
-	"oneeighth":                 '\u215b', // ⅛
-	"onefitted":                 '\uf6dc', // 
-	"onegujarati":               '\u0ae7', // ૧
-	"onegurmukhi":               '\u0a67', // ੧
-	"onehackarabic":             '\u0661', // ١
-	"onehalf":                   '\u00bd', // ½
-	"onehangzhou":               '\u3021', // 〡
-	"oneideographicparen":       '\u3220', // ㈠
-	"oneinferior":               '\u2081', // ₁
-	"onemonospace":              '\uff11', // １
-	"onenumeratorbengali":       '\u09f4', // ৴
-	"oneoldstyle":               '\uf731', // 
-	"oneparen":                  '\u2474', // ⑴
-	"oneperiod":                 '\u2488', // ⒈
-	"onepersian":                '\u06f1', // ۱
-	"onequarter":                '\u00bc', // ¼
-	"oneroman":                  '\u2170', // ⅰ
-	"onesuperior":               '\u00b9', // ¹
-	"onethai":                   '\u0e51', // ๑
-	"onethird":                  '\u2153', // ⅓
-	"oogonek":                   '\u01eb', // ǫ
-	"oogonekmacron":             '\u01ed', // ǭ
-	"oogurmukhi":                '\u0a13', // ਓ
-	"oomatragurmukhi":           '\u0a4b', // ੋ
-	"oopen":                     '\u0254', // ɔ
-	"oparen":                    '\u24aa', // ⒪
-	"openbullet":                '\u25e6', // ◦
-	"option":                    '\u2325', // ⌥
-	"ordfeminine":               '\u00aa', // ª
-	"ordmasculine":              '\u00ba', // º
-	"orthogonal":                '\u221f', // ∟
-	"oshortdeva":                '\u0912', // ऒ
-	"oshortvowelsigndeva":       '\u094a', // ॊ
-	"oslash":                    '\u00f8', // ø
-	"oslashacute":               '\u01ff', // ǿ
-	"osmallhiragana":            '\u3049', // ぉ
-	"osmallkatakana":            '\u30a9', // ォ
-	"osmallkatakanahalfwidth":   '\uff6b', // ｫ
-	"ostrokeacute":              '\u01ff', // ǿ
-	"osuperior":                 '\uf6f0', // 
-	"otcyrillic":                '\u047f', // ѿ
-	"otilde":                    '\u00f5', // õ
-	"otildeacute":               '\u1e4d', // ṍ
-	"otildedieresis":            '\u1e4f', // ṏ
-	"oubopomofo":                '\u3121', // ㄡ
-	"overline":                  '\u203e', // ‾
-	"overlinecenterline":        '\ufe4a', // ﹊
-	"overlinecmb":               '\u0305', // ̅
-	"overlinedashed":            '\ufe49', // ﹉
-	"overlinedblwavy":           '\ufe4c', // ﹌
-	"overlinewavy":              '\ufe4b', // ﹋
-	"overscore":                 '\u00af', // ¯
-	"ovowelsignbengali":         '\u09cb', // ো
-	"ovowelsigndeva":            '\u094b', // ो
-	"ovowelsigngujarati":        '\u0acb', // ો
-	"p":                         '\u0070', // p
-	"paampssquare":              '\u3380', // ㎀
-	"paasentosquare":            '\u332b', // ㌫
-	"pabengali":                 '\u09aa', // প
-	"pacute":                    '\u1e55', // ṕ
-	"padeva":                    '\u092a', // प
-	"pagedown":                  '\u21df', // ⇟
-	"pageup":                    '\u21de', // ⇞
-	"pagujarati":                '\u0aaa', // પ
-	"pagurmukhi":                '\u0a2a', // ਪ
-	"pahiragana":                '\u3071', // ぱ
-	"paiyannoithai":             '\u0e2f', // ฯ
-	"pakatakana":                '\u30d1', // パ
-	"palatalizationcyrilliccmb": '\u0484', // ҄
-	"palochkacyrillic":          '\u04c0', // Ӏ
-	"pansioskorean":             '\u317f', // ㅿ
-	"paragraph":                 '\u00b6', // ¶
-	"parallel":                  '\u2225', // ∥
-	"parenleft":                 '\u0028', // (
-	"parenleftaltonearabic":     '\ufd3e', // ﴾
-	"parenleftbt":               '\uf8ed', // 
-	"parenleftex":               '\uf8ec', // 
-	"parenleftinferior":         '\u208d', // ₍
-	"parenleftmonospace":        '\uff08', // （
-	"parenleftsmall":            '\ufe59', // ﹙
-	"parenleftsuperior":         '\u207d', // ⁽
-	"parenlefttp":               '\uf8eb', // 
-	"parenleftvertical":         '\ufe35', // ︵
-	"parenright":                '\u0029', // )
-	"parenrightaltonearabic":    '\ufd3f', // ﴿
-	"parenrightbt":              '\uf8f8', // 
-	"parenrightex":              '\uf8f7', // 
-	"parenrightinferior":        '\u208e', // ₎
-	"parenrightmonospace":       '\uff09', // ）
-	"parenrightsmall":           '\ufe5a', // ﹚
-	"parenrightsuperior":        '\u207e', // ⁾
-	"parenrighttp":              '\uf8f6', // 
-	"parenrightvertical":        '\ufe36', // ︶
-	"partialdiff":               '\u2202', // ∂
-	"paseqhebrew":               '\u05c0', // ׀
-	"pashtahebrew":              '\u0599', // ֙
-	"pasquare":                  '\u33a9', // ㎩
-	"patah":                     '\u05b7', // ַ
-	"patah11":                   '\u05b7', // ַ
-	"patah1d":                   '\u05b7', // ַ
-	"patah2a":                   '\u05b7', // ַ
-	"patahhebrew":               '\u05b7', // ַ
-	"patahnarrowhebrew":         '\u05b7', // ַ
-	"patahquarterhebrew":        '\u05b7', // ַ
-	"patahwidehebrew":           '\u05b7', // ַ
-	"pazerhebrew":               '\u05a1', // ֡
-	"pbopomofo":                 '\u3106', // ㄆ
-	"pcircle":                   '\u24df', // ⓟ
-	"pdotaccent":                '\u1e57', // ṗ
-	"pe":                        '\u05e4', // פ
-	"pecyrillic":                '\u043f', // п
-	"pedagesh":                  '\ufb44', // פּ
-	"pedageshhebrew":            '\ufb44', // פּ
-	"peezisquare":               '\u333b', // ㌻
-	"pefinaldageshhebrew":       '\ufb43', // ףּ
-	"peharabic":                 '\u067e', // پ
-	"peharmenian":               '\u057a', // պ
-	"pehebrew":                  '\u05e4', // פ
-	"pehfinalarabic":            '\ufb57', // ﭗ
-	"pehinitialarabic":          '\ufb58', // ﭘ
-	"pehiragana":                '\u307a', // ぺ
-	"pehmedialarabic":           '\ufb59', // ﭙ
-	"pekatakana":                '\u30da', // ペ
-	"pemiddlehookcyrillic":      '\u04a7', // ҧ
-	"perafehebrew":              '\ufb4e', // פֿ
-	"percent":                   '\u0025', // %
-	"percentarabic":             '\u066a', // ٪
-	"percentmonospace":          '\uff05', // ％
-	"percentsmall":              '\ufe6a', // ﹪
-	"period":                    '\u002e', // .
-	"periodarmenian":            '\u0589', // ։
-	"periodcentered":            '\u00b7', // ·
-	"periodhalfwidth":           '\uff61', // ｡
-	"periodinferior":            '\uf6e7', // 
-	"periodmonospace":           '\uff0e', // ．
-	"periodsmall":               '\ufe52', // ﹒
-	"periodsuperior":            '\uf6e8', // 
-	"perispomenigreekcmb":       '\u0342', // ͂
-	"perpendicular":             '\u22a5', // ⊥
-	"perthousand":               '\u2030', // ‰
-	"peseta":                    '\u20a7', // ₧
-	"pfsquare":                  '\u338a', // ㎊
-	"phabengali":                '\u09ab', // ফ
-	"phadeva":                   '\u092b', // फ
-	"phagujarati":               '\u0aab', // ફ
-	"phagurmukhi":               '\u0a2b', // ਫ
-	"phi":                       '\u03c6', // φ
-	"phi1":                      '\u03d5', // ϕ
-	"phieuphacirclekorean":      '\u327a', // ㉺
-	"phieuphaparenkorean":       '\u321a', // ㈚
-	"phieuphcirclekorean":       '\u326c', // ㉬
-	"phieuphkorean":             '\u314d', // ㅍ
-	"phieuphparenkorean":        '\u320c', // ㈌
-	"philatin":                  '\u0278', // ɸ
-	"phinthuthai":               '\u0e3a', // ฺ
-	"phisymbolgreek":            '\u03d5', // ϕ
-	"phook":                     '\u01a5', // ƥ
-	"phophanthai":               '\u0e1e', // พ
-	"phophungthai":              '\u0e1c', // ผ
-	"phosamphaothai":            '\u0e20', // ภ
-	"pi":                        '\u03c0', // π
-	"pieupacirclekorean":        '\u3273', // ㉳
-	"pieupaparenkorean":         '\u3213', // ㈓
-	"pieupcieuckorean":          '\u3176', // ㅶ
-	"pieupcirclekorean":         '\u3265', // ㉥
-	"pieupkiyeokkorean":         '\u3172', // ㅲ
-	"pieupkorean":               '\u3142', // ㅂ
-	"pieupparenkorean":          '\u3205', // ㈅
-	"pieupsioskiyeokkorean":     '\u3174', // ㅴ
-	"pieupsioskorean":           '\u3144', // ㅄ
-	"pieupsiostikeutkorean":     '\u3175', // ㅵ
-	"pieupthieuthkorean":        '\u3177', // ㅷ
-	"pieuptikeutkorean":         '\u3173', // ㅳ
-	"pihiragana":                '\u3074', // ぴ
-	"pikatakana":                '\u30d4', // ピ
-	"pisymbolgreek":             '\u03d6', // ϖ
-	"piwrarmenian":              '\u0583', // փ
-	"plus":                      '\u002b', // +
-	"plusbelowcmb":              '\u031f', // ̟
-	"pluscircle":                '\u2295', // ⊕
-	"plusminus":                 '\u00b1', // ±
-	"plusmod":                   '\u02d6', // ˖
-	"plusmonospace":             '\uff0b', // ＋
-	"plussmall":                 '\ufe62', // ﹢
-	"plussuperior":              '\u207a', // ⁺
-	"pmonospace":                '\uff50', // ｐ
-	"pmsquare":                  '\u33d8', // ㏘
-	"pohiragana":                '\u307d', // ぽ
-	"pointingindexdownwhite":    '\u261f', // ☟
-	"pointingindexleftwhite":    '\u261c', // ☜
-	"pointingindexrightwhite":   '\u261e', // ☞
-	"pointingindexupwhite":      '\u261d', // ☝
-	"pokatakana":                '\u30dd', // ポ
-	"poplathai":                 '\u0e1b', // ป
-	"postalmark":                '\u3012', // 〒
-	"postalmarkface":            '\u3020', // 〠
-	"pparen":                    '\u24ab', // ⒫
-	"precedes":                  '\u227a', // ≺
-	"prescription":              '\u211e', // ℞
-	"primemod":                  '\u02b9', // ʹ
-	"primereversed":             '\u2035', // ‵
-	"product":                   '\u220f', // ∏
-	"projective":                '\u2305', // ⌅
-	"prolongedkana":             '\u30fc', // ー
-	"propellor":                 '\u2318', // ⌘
-	"propersubset":              '\u2282', // ⊂
-	"propersuperset":            '\u2283', // ⊃
-	"proportion":                '\u2237', // ∷
-	"proportional":              '\u221d', // ∝
-	"psi":                       '\u03c8', // ψ
-	"psicyrillic":               '\u0471', // ѱ
-	"psilipneumatacyrilliccmb":  '\u0486', // ҆
-	"pssquare":                  '\u33b0', // ㎰
-	"puhiragana":                '\u3077', // ぷ
-	"pukatakana":                '\u30d7', // プ
-	"pvsquare":                  '\u33b4', // ㎴
-	"pwsquare":                  '\u33ba', // ㎺
-	"q":                         '\u0071', // q
-	"qadeva":                    '\u0958', // क़
-	"qadmahebrew":               '\u05a8', // ֨
-	"qafarabic":                 '\u0642', // ق
-	"qaffinalarabic":            '\ufed6', // ﻖ
-	"qafinitialarabic":          '\ufed7', // ﻗ
-	"qafmedialarabic":           '\ufed8', // ﻘ
-	"qamats":                    '\u05b8', // ָ
-	"qamats10":                  '\u05b8', // ָ
-	"qamats1a":                  '\u05b8', // ָ
-	"qamats1c":                  '\u05b8', // ָ
-	"qamats27":                  '\u05b8', // ָ
-	"qamats29":                  '\u05b8', // ָ
-	"qamats33":                  '\u05b8', // ָ
-	"qamatsde":                  '\u05b8', // ָ
-	"qamatshebrew":              '\u05b8', // ָ
-	"qamatsnarrowhebrew":        '\u05b8', // ָ
-	"qamatsqatanhebrew":         '\u05b8', // ָ
-	"qamatsqatannarrowhebrew":   '\u05b8', // ָ
-	"qamatsqatanquarterhebrew":  '\u05b8', // ָ
-	"qamatsqatanwidehebrew":     '\u05b8', // ָ
-	"qamatsquarterhebrew":       '\u05b8', // ָ
-	"qamatswidehebrew":          '\u05b8', // ָ
-	"qarneyparahebrew":          '\u059f', // ֟
-	"qbopomofo":                 '\u3111', // ㄑ
-	"qcircle":                   '\u24e0', // ⓠ
-	"qhook":                     '\u02a0', // ʠ
-	"qmonospace":                '\uff51', // ｑ
-	"qof":                       '\u05e7', // ק
-	"qofdagesh":                 '\ufb47', // קּ
-	"qofdageshhebrew":           '\ufb47', // קּ
-	//    "qofhatafpatah": '\u05e7', // קֲ
-	//    "qofhatafpatahhebrew": '\u05e7', // קֲ
-	//    "qofhatafsegol": '\u05e7', // קֱ
-	//    "qofhatafsegolhebrew": '\u05e7', // קֱ
-	"qofhebrew": '\u05e7', // ק
-	//    "qofhiriq": '\u05e7', // קִ
-	//    "qofhiriqhebrew": '\u05e7', // קִ
-	//    "qofholam": '\u05e7', // קֹ
-	//    "qofholamhebrew": '\u05e7', // קֹ
-	//    "qofpatah": '\u05e7', // קַ
-	//    "qofpatahhebrew": '\u05e7', // קַ
-	//    "qofqamats": '\u05e7', // קָ
-	//    "qofqamatshebrew": '\u05e7', // קָ
-	//    "qofqubuts": '\u05e7', // קֻ
-	//    "qofqubutshebrew": '\u05e7', // קֻ
-	//    "qofsegol": '\u05e7', // קֶ
-	//    "qofsegolhebrew": '\u05e7', // קֶ
-	//    "qofsheva": '\u05e7', // קְ
-	//    "qofshevahebrew": '\u05e7', // קְ
-	//    "qoftsere": '\u05e7', // קֵ
-	//    "qoftserehebrew": '\u05e7', // קֵ
-	"qparen":                  '\u24ac', // ⒬
-	"quarternote":             '\u2669', // ♩
-	"qubuts":                  '\u05bb', // ֻ
-	"qubuts18":                '\u05bb', // ֻ
-	"qubuts25":                '\u05bb', // ֻ
-	"qubuts31":                '\u05bb', // ֻ
-	"qubutshebrew":            '\u05bb', // ֻ
-	"qubutsnarrowhebrew":      '\u05bb', // ֻ
-	"qubutsquarterhebrew":     '\u05bb', // ֻ
-	"qubutswidehebrew":        '\u05bb', // ֻ
-	"question":                '\u003f', // ?
-	"questionarabic":          '\u061f', // ؟
-	"questionarmenian":        '\u055e', // ՞
-	"questiondown":            '\u00bf', // ¿
-	"questiondownsmall":       '\uf7bf', // 
-	"questiongreek":           '\u037e', // ;
-	"questionmonospace":       '\uff1f', // ？
-	"questionsmall":           '\uf73f', // 
-	"quotedbl":                '\u0022', // "
-	"quotedblbase":            '\u201e', // „
-	"quotedblleft":            '\u201c', // “
-	"quotedblmonospace":       '\uff02', // ＂
-	"quotedblprime":           '\u301e', // 〞
-	"quotedblprimereversed":   '\u301d', // 〝
-	"quotedblright":           '\u201d', // ”
-	"quoteleft":               '\u2018', // ‘
-	"quoteleftreversed":       '\u201b', // ‛
-	"quotereversed":           '\u201b', // ‛
-	"quoteright":              '\u2019', // ’
-	"quoterightn":             '\u0149', // ŉ
-	"quotesinglbase":          '\u201a', // ‚
-	"quotesingle":             '\u0027', // \'
-	"quotesinglemonospace":    '\uff07', // ＇
-	"r":                       '\u0072', // r
-	"raarmenian":              '\u057c', // ռ
-	"rabengali":               '\u09b0', // র
-	"racute":                  '\u0155', // ŕ
-	"radeva":                  '\u0930', // र
-	"radical":                 '\u221a', // √
-	"radicalex":               '\uf8e5', // 
-	"radoverssquare":          '\u33ae', // ㎮
-	"radoverssquaredsquare":   '\u33af', // ㎯
-	"radsquare":               '\u33ad', // ㎭
-	"rafe":                    '\u05bf', // ֿ
-	"rafehebrew":              '\u05bf', // ֿ
-	"ragujarati":              '\u0ab0', // ર
-	"ragurmukhi":              '\u0a30', // ਰ
-	"rahiragana":              '\u3089', // ら
-	"rakatakana":              '\u30e9', // ラ
-	"rakatakanahalfwidth":     '\uff97', // ﾗ
-	"ralowerdiagonalbengali":  '\u09f1', // ৱ
-	"ramiddlediagonalbengali": '\u09f0', // ৰ
-	"ramshorn":                '\u0264', // ɤ
-	"ratio":                   '\u2236', // ∶
-	"rbopomofo":               '\u3116', // ㄖ
-	"rcaron":                  '\u0159', // ř
-	"rcedilla":                '\u0157', // ŗ
-	"rcircle":                 '\u24e1', // ⓡ
-	"rcommaaccent":            '\u0157', // ŗ
-	"rdblgrave":               '\u0211', // ȑ
-	"rdotaccent":              '\u1e59', // ṙ
-	"rdotbelow":               '\u1e5b', // ṛ
-	"rdotbelowmacron":         '\u1e5d', // ṝ
-	"referencemark":           '\u203b', // ※
-	"reflexsubset":            '\u2286', // ⊆
-	"reflexsuperset":          '\u2287', // ⊇
-	"registered":              '\u00ae', // ®
-	"registersans":            '\uf8e8', // 
-	"registerserif":           '\uf6da', // 
-	"reharabic":               '\u0631', // ر
-	"reharmenian":             '\u0580', // ր
-	"rehfinalarabic":          '\ufeae', // ﺮ
-	"rehiragana":              '\u308c', // れ
-	//    "rehyehaleflamarabic": '\u0631', // رﻳﺎل
-	"rekatakana":          '\u30ec', // レ
-	"rekatakanahalfwidth": '\uff9a', // ﾚ
-	"resh":                '\u05e8', // ר
-	"reshdageshhebrew":    '\ufb48', // רּ
-	//    "reshhatafpatah": '\u05e8', // רֲ
-	//    "reshhatafpatahhebrew": '\u05e8', // רֲ
-	//    "reshhatafsegol": '\u05e8', // רֱ
-	//    "reshhatafsegolhebrew": '\u05e8', // רֱ
-	"reshhebrew": '\u05e8', // ר
-	//    "reshhiriq": '\u05e8', // רִ
-	//    "reshhiriqhebrew": '\u05e8', // רִ
-	//    "reshholam": '\u05e8', // רֹ
-	//    "reshholamhebrew": '\u05e8', // רֹ
-	//    "reshpatah": '\u05e8', // רַ
-	//    "reshpatahhebrew": '\u05e8', // רַ
-	//    "reshqamats": '\u05e8', // רָ
-	//    "reshqamatshebrew": '\u05e8', // רָ
-	//    "reshqubuts": '\u05e8', // רֻ
-	//    "reshqubutshebrew": '\u05e8', // רֻ
-	//    "reshsegol": '\u05e8', // רֶ
-	//    "reshsegolhebrew": '\u05e8', // רֶ
-	//    "reshsheva": '\u05e8', // רְ
-	//    "reshshevahebrew": '\u05e8', // רְ
-	//    "reshtsere": '\u05e8', // רֵ
-	//    "reshtserehebrew": '\u05e8', // רֵ
-	"reversedtilde":                   '\u223d', // ∽
-	"reviahebrew":                     '\u0597', // ֗
-	"reviamugrashhebrew":              '\u0597', // ֗
-	"revlogicalnot":                   '\u2310', // ⌐
-	"rfishhook":                       '\u027e', // ɾ
-	"rfishhookreversed":               '\u027f', // ɿ
-	"rhabengali":                      '\u09dd', // ঢ়
-	"rhadeva":                         '\u095d', // ढ़
-	"rho":                             '\u03c1', // ρ
-	"rhook":                           '\u027d', // ɽ
-	"rhookturned":                     '\u027b', // ɻ
-	"rhookturnedsuperior":             '\u02b5', // ʵ
-	"rhosymbolgreek":                  '\u03f1', // ϱ
-	"rhotichookmod":                   '\u02de', // ˞
-	"rieulacirclekorean":              '\u3271', // ㉱
-	"rieulaparenkorean":               '\u3211', // ㈑
-	"rieulcirclekorean":               '\u3263', // ㉣
-	"rieulhieuhkorean":                '\u3140', // ㅀ
-	"rieulkiyeokkorean":               '\u313a', // ㄺ
-	"rieulkiyeoksioskorean":           '\u3169', // ㅩ
-	"rieulkorean":                     '\u3139', // ㄹ
-	"rieulmieumkorean":                '\u313b', // ㄻ
-	"rieulpansioskorean":              '\u316c', // ㅬ
-	"rieulparenkorean":                '\u3203', // ㈃
-	"rieulphieuphkorean":              '\u313f', // ㄿ
-	"rieulpieupkorean":                '\u313c', // ㄼ
-	"rieulpieupsioskorean":            '\u316b', // ㅫ
-	"rieulsioskorean":                 '\u313d', // ㄽ
-	"rieulthieuthkorean":              '\u313e', // ㄾ
-	"rieultikeutkorean":               '\u316a', // ㅪ
-	"rieulyeorinhieuhkorean":          '\u316d', // ㅭ
-	"rightangle":                      '\u221f', // ∟
-	"righttackbelowcmb":               '\u0319', // ̙
-	"righttriangle":                   '\u22bf', // ⊿
-	"rihiragana":                      '\u308a', // り
-	"rikatakana":                      '\u30ea', // リ
-	"rikatakanahalfwidth":             '\uff98', // ﾘ
-	"ring":                            '\u02da', // ˚
-	"ringbelowcmb":                    '\u0325', // ̥
-	"ringcmb":                         '\u030a', // ̊
-	"ringhalfleft":                    '\u02bf', // ʿ
-	"ringhalfleftarmenian":            '\u0559', // ՙ
-	"ringhalfleftbelowcmb":            '\u031c', // ̜
-	"ringhalfleftcentered":            '\u02d3', // ˓
-	"ringhalfright":                   '\u02be', // ʾ
-	"ringhalfrightbelowcmb":           '\u0339', // ̹
-	"ringhalfrightcentered":           '\u02d2', // ˒
-	"rinvertedbreve":                  '\u0213', // ȓ
-	"rittorusquare":                   '\u3351', // ㍑
-	"rlinebelow":                      '\u1e5f', // ṟ
-	"rlongleg":                        '\u027c', // ɼ
-	"rlonglegturned":                  '\u027a', // ɺ
-	"rmonospace":                      '\uff52', // ｒ
-	"rohiragana":                      '\u308d', // ろ
-	"rokatakana":                      '\u30ed', // ロ
-	"rokatakanahalfwidth":             '\uff9b', // ﾛ
-	"roruathai":                       '\u0e23', // ร
-	"rparen":                          '\u24ad', // ⒭
-	"rrabengali":                      '\u09dc', // ড়
-	"rradeva":                         '\u0931', // ऱ
-	"rragurmukhi":                     '\u0a5c', // ੜ
-	"rreharabic":                      '\u0691', // ڑ
-	"rrehfinalarabic":                 '\ufb8d', // ﮍ
-	"rrvocalicbengali":                '\u09e0', // ৠ
-	"rrvocalicdeva":                   '\u0960', // ॠ
-	"rrvocalicgujarati":               '\u0ae0', // ૠ
-	"rrvocalicvowelsignbengali":       '\u09c4', // ৄ
-	"rrvocalicvowelsigndeva":          '\u0944', // ॄ
-	"rrvocalicvowelsigngujarati":      '\u0ac4', // ૄ
-	"rsuperior":                       '\uf6f1', // 
-	"rtblock":                         '\u2590', // ▐
-	"rturned":                         '\u0279', // ɹ
-	"rturnedsuperior":                 '\u02b4', // ʴ
-	"ruhiragana":                      '\u308b', // る
-	"rukatakana":                      '\u30eb', // ル
-	"rukatakanahalfwidth":             '\uff99', // ﾙ
-	"rupeemarkbengali":                '\u09f2', // ৲
-	"rupeesignbengali":                '\u09f3', // ৳
-	"rupiah":                          '\uf6dd', // 
-	"ruthai":                          '\u0e24', // ฤ
-	"rvocalicbengali":                 '\u098b', // ঋ
-	"rvocalicdeva":                    '\u090b', // ऋ
-	"rvocalicgujarati":                '\u0a8b', // ઋ
-	"rvocalicvowelsignbengali":        '\u09c3', // ৃ
-	"rvocalicvowelsigndeva":           '\u0943', // ृ
-	"rvocalicvowelsigngujarati":       '\u0ac3', // ૃ
-	"s":                               '\u0073', // s
-	"sabengali":                       '\u09b8', // স
-	"sacute":                          '\u015b', // ś
-	"sacutedotaccent":                 '\u1e65', // ṥ
-	"sadarabic":                       '\u0635', // ص
-	"sadeva":                          '\u0938', // स
-	"sadfinalarabic":                  '\ufeba', // ﺺ
-	"sadinitialarabic":                '\ufebb', // ﺻ
-	"sadmedialarabic":                 '\ufebc', // ﺼ
-	"sagujarati":                      '\u0ab8', // સ
-	"sagurmukhi":                      '\u0a38', // ਸ
-	"sahiragana":                      '\u3055', // さ
-	"sakatakana":                      '\u30b5', // サ
-	"sakatakanahalfwidth":             '\uff7b', // ｻ
-	"sallallahoualayhewasallamarabic": '\ufdfa', // ﷺ
-	"samekh":                          '\u05e1', // ס
-	"samekhdagesh":                    '\ufb41', // סּ
-	"samekhdageshhebrew":              '\ufb41', // סּ
-	"samekhhebrew":                    '\u05e1', // ס
-	"saraaathai":                      '\u0e32', // า
-	"saraaethai":                      '\u0e41', // แ
-	"saraaimaimalaithai":              '\u0e44', // ไ
-	"saraaimaimuanthai":               '\u0e43', // ใ
-	"saraamthai":                      '\u0e33', // ำ
-	"saraathai":                       '\u0e30', // ะ
-	"saraethai":                       '\u0e40', // เ
-	"saraiileftthai":                  '\uf886', // 
-	"saraiithai":                      '\u0e35', // ี
-	"saraileftthai":                   '\uf885', // 
-	"saraithai":                       '\u0e34', // ิ
-	"saraothai":                       '\u0e42', // โ
-	"saraueeleftthai":                 '\uf888', // 
-	"saraueethai":                     '\u0e37', // ื
-	"saraueleftthai":                  '\uf887', // 
-	"sarauethai":                      '\u0e36', // ึ
-	"sarauthai":                       '\u0e38', // ุ
-	"sarauuthai":                      '\u0e39', // ู
-	"sbopomofo":                       '\u3119', // ㄙ
-	"scaron":                          '\u0161', // š
-	"scarondotaccent":                 '\u1e67', // ṧ
-	"scedilla":                        '\u015f', // ş
-	"schwa":                           '\u0259', // ə
-	"schwacyrillic":                   '\u04d9', // ә
-	"schwadieresiscyrillic":           '\u04db', // ӛ
-	"schwahook":                       '\u025a', // ɚ
-	"scircle":                         '\u24e2', // ⓢ
-	"scircumflex":                     '\u015d', // ŝ
-	"scommaaccent":                    '\u0219', // ș
-	"sdotaccent":                      '\u1e61', // ṡ
-	"sdotbelow":                       '\u1e63', // ṣ
-	"sdotbelowdotaccent":              '\u1e69', // ṩ
-	"seagullbelowcmb":                 '\u033c', // ̼
-	"second":                          '\u2033', // ″
-	"secondtonechinese":               '\u02ca', // ˊ
-	"section":                         '\u00a7', // §
-	"seenarabic":                      '\u0633', // س
-	"seenfinalarabic":                 '\ufeb2', // ﺲ
-	"seeninitialarabic":               '\ufeb3', // ﺳ
-	"seenmedialarabic":                '\ufeb4', // ﺴ
-	"segol":                           '\u05b6', // ֶ
-	"segol13":                         '\u05b6', // ֶ
-	"segol1f":                         '\u05b6', // ֶ
-	"segol2c":                         '\u05b6', // ֶ
-	"segolhebrew":                     '\u05b6', // ֶ
-	"segolnarrowhebrew":               '\u05b6', // ֶ
-	"segolquarterhebrew":              '\u05b6', // ֶ
-	"segoltahebrew":                   '\u0592', // ֒
-	"segolwidehebrew":                 '\u05b6', // ֶ
-	"seharmenian":                     '\u057d', // ս
-	"sehiragana":                      '\u305b', // せ
-	"sekatakana":                      '\u30bb', // セ
-	"sekatakanahalfwidth":             '\uff7e', // ｾ
-	"semicolon":                       '\u003b', // ;
-	"semicolonarabic":                 '\u061b', // ؛
-	"semicolonmonospace":              '\uff1b', // ；
-	"semicolonsmall":                  '\ufe54', // ﹔
-	"semivoicedmarkkana":              '\u309c', // ゜
-	"semivoicedmarkkanahalfwidth":     '\uff9f', // ﾟ
-	"sentisquare":                     '\u3322', // ㌢
-	"sentosquare":                     '\u3323', // ㌣
-	"seven":                           '\u0037', // 7
-	"sevenarabic":                     '\u0667', // ٧
-	"sevenbengali":                    '\u09ed', // ৭
-	"sevencircle":                     '\u2466', // ⑦
-	"sevencircleinversesansserif":     '\u2790', // ➐
-	"sevendeva":                       '\u096d', // ७
-	"seveneighths":                    '\u215e', // ⅞
-	"sevengujarati":                   '\u0aed', // ૭
-	"sevengurmukhi":                   '\u0a6d', // ੭
-	"sevenhackarabic":                 '\u0667', // ٧
-	"sevenhangzhou":                   '\u3027', // 〧
-	"sevenideographicparen":           '\u3226', // ㈦
-	"seveninferior":                   '\u2087', // ₇
-	"sevenmonospace":                  '\uff17', // ７
-	"sevenoldstyle":                   '\uf737', // 
-	"sevenparen":                      '\u247a', // ⑺
-	"sevenperiod":                     '\u248e', // ⒎
-	"sevenpersian":                    '\u06f7', // ۷
-	"sevenroman":                      '\u2176', // ⅶ
-	"sevensuperior":                   '\u2077', // ⁷
-	"seventeencircle":                 '\u2470', // ⑰
-	"seventeenparen":                  '\u2484', // ⒄
-	"seventeenperiod":                 '\u2498', // ⒘
-	"seventhai":                       '\u0e57', // ๗
-	"sfthyphen":                       '\u00ad', // ­
-	"shaarmenian":                     '\u0577', // շ
-	"shabengali":                      '\u09b6', // শ
-	"shacyrillic":                     '\u0448', // ш
-	"shaddaarabic":                    '\u0651', // ّ
-	"shaddadammaarabic":               '\ufc61', // ﱡ
-	"shaddadammatanarabic":            '\ufc5e', // ﱞ
-	"shaddafathaarabic":               '\ufc60', // ﱠ
-	//    "shaddafathatanarabic": '\u0651', // ًّ
-	"shaddakasraarabic":                 '\ufc62', // ﱢ
-	"shaddakasratanarabic":              '\ufc5f', // ﱟ
-	"shade":                             '\u2592', // ▒
-	"shadedark":                         '\u2593', // ▓
-	"shadelight":                        '\u2591', // ░
-	"shademedium":                       '\u2592', // ▒
-	"shadeva":                           '\u0936', // श
-	"shagujarati":                       '\u0ab6', // શ
-	"shagurmukhi":                       '\u0a36', // ਸ਼
-	"shalshelethebrew":                  '\u0593', // ֓
-	"shbopomofo":                        '\u3115', // ㄕ
-	"shchacyrillic":                     '\u0449', // щ
-	"sheenarabic":                       '\u0634', // ش
-	"sheenfinalarabic":                  '\ufeb6', // ﺶ
-	"sheeninitialarabic":                '\ufeb7', // ﺷ
-	"sheenmedialarabic":                 '\ufeb8', // ﺸ
-	"sheicoptic":                        '\u03e3', // ϣ
-	"sheqel":                            '\u20aa', // ₪
-	"sheqelhebrew":                      '\u20aa', // ₪
-	"sheva":                             '\u05b0', // ְ
-	"sheva115":                          '\u05b0', // ְ
-	"sheva15":                           '\u05b0', // ְ
-	"sheva22":                           '\u05b0', // ְ
-	"sheva2e":                           '\u05b0', // ְ
-	"shevahebrew":                       '\u05b0', // ְ
-	"shevanarrowhebrew":                 '\u05b0', // ְ
-	"shevaquarterhebrew":                '\u05b0', // ְ
-	"shevawidehebrew":                   '\u05b0', // ְ
-	"shhacyrillic":                      '\u04bb', // һ
-	"shimacoptic":                       '\u03ed', // ϭ
-	"shin":                              '\u05e9', // ש
-	"shindagesh":                        '\ufb49', // שּ
-	"shindageshhebrew":                  '\ufb49', // שּ
-	"shindageshshindot":                 '\ufb2c', // שּׁ
-	"shindageshshindothebrew":           '\ufb2c', // שּׁ
-	"shindageshsindot":                  '\ufb2d', // שּׂ
-	"shindageshsindothebrew":            '\ufb2d', // שּׂ
-	"shindothebrew":                     '\u05c1', // ׁ
-	"shinhebrew":                        '\u05e9', // ש
-	"shinshindot":                       '\ufb2a', // שׁ
-	"shinshindothebrew":                 '\ufb2a', // שׁ
-	"shinsindot":                        '\ufb2b', // שׂ
-	"shinsindothebrew":                  '\ufb2b', // שׂ
-	"shook":                             '\u0282', // ʂ
-	"sigma":                             '\u03c3', // σ
-	"sigma1":                            '\u03c2', // ς
-	"sigmafinal":                        '\u03c2', // ς
-	"sigmalunatesymbolgreek":            '\u03f2', // ϲ
-	"sihiragana":                        '\u3057', // し
-	"sikatakana":                        '\u30b7', // シ
-	"sikatakanahalfwidth":               '\uff7c', // ｼ
-	"siluqhebrew":                       '\u05bd', // ֽ
-	"siluqlefthebrew":                   '\u05bd', // ֽ
-	"similar":                           '\u223c', // ∼
-	"sindothebrew":                      '\u05c2', // ׂ
-	"siosacirclekorean":                 '\u3274', // ㉴
-	"siosaparenkorean":                  '\u3214', // ㈔
-	"sioscieuckorean":                   '\u317e', // ㅾ
-	"sioscirclekorean":                  '\u3266', // ㉦
-	"sioskiyeokkorean":                  '\u317a', // ㅺ
-	"sioskorean":                        '\u3145', // ㅅ
-	"siosnieunkorean":                   '\u317b', // ㅻ
-	"siosparenkorean":                   '\u3206', // ㈆
-	"siospieupkorean":                   '\u317d', // ㅽ
-	"siostikeutkorean":                  '\u317c', // ㅼ
-	"six":                               '\u0036', // 6
-	"sixarabic":                         '\u0666', // ٦
-	"sixbengali":                        '\u09ec', // ৬
-	"sixcircle":                         '\u2465', // ⑥
-	"sixcircleinversesansserif":         '\u278f', // ➏
-	"sixdeva":                           '\u096c', // ६
-	"sixgujarati":                       '\u0aec', // ૬
-	"sixgurmukhi":                       '\u0a6c', // ੬
-	"sixhackarabic":                     '\u0666', // ٦
-	"sixhangzhou":                       '\u3026', // 〦
-	"sixideographicparen":               '\u3225', // ㈥
-	"sixinferior":                       '\u2086', // ₆
-	"sixmonospace":                      '\uff16', // ６
-	"sixoldstyle":                       '\uf736', // 
-	"sixparen":                          '\u2479', // ⑹
-	"sixperiod":                         '\u248d', // ⒍
-	"sixpersian":                        '\u06f6', // ۶
-	"sixroman":                          '\u2175', // ⅵ
-	"sixsuperior":                       '\u2076', // ⁶
-	"sixteencircle":                     '\u246f', // ⑯
-	"sixteencurrencydenominatorbengali": '\u09f9', // ৹
-	"sixteenparen":                      '\u2483', // ⒃
-	"sixteenperiod":                     '\u2497', // ⒗
-	"sixthai":                           '\u0e56', // ๖
-	"slash":                             '\u002f', // /
-	"slashmonospace":                    '\uff0f', // ／
-	"slong":                             '\u017f', // ſ
-	"slongdotaccent":                    '\u1e9b', // ẛ
-	"smileface":                         '\u263a', // ☺
-	"smonospace":                        '\uff53', // ｓ
-	"sofpasuqhebrew":                    '\u05c3', // ׃
-	"softhyphen":                        '\u00ad', // ­
-	"softsigncyrillic":                  '\u044c', // ь
-	"sohiragana":                        '\u305d', // そ
-	"sokatakana":                        '\u30bd', // ソ
-	"sokatakanahalfwidth":               '\uff7f', // ｿ
-	"soliduslongoverlaycmb":             '\u0338', // ̸
-	"solidusshortoverlaycmb":            '\u0337', // ̷
-	"sorusithai":                        '\u0e29', // ษ
-	"sosalathai":                        '\u0e28', // ศ
-	"sosothai":                          '\u0e0b', // ซ
-	"sosuathai":                         '\u0e2a', // ส
-	"space":                             '\u0020', //
-	"spacehackarabic":                   '\u0020', //
-	"spade":                             '\u2660', // ♠
-	"spadesuitblack":                    '\u2660', // ♠
-	"spadesuitwhite":                    '\u2664', // ♤
-	"sparen":                            '\u24ae', // ⒮
-	"squarebelowcmb":                    '\u033b', // ̻
-	"squarecc":                          '\u33c4', // ㏄
-	"squarecm":                          '\u339d', // ㎝
-	"squarediagonalcrosshatchfill":      '\u25a9', // ▩
-	"squarehorizontalfill":              '\u25a4', // ▤
-	"squarekg":                          '\u338f', // ㎏
-	"squarekm":                          '\u339e', // ㎞
-	"squarekmcapital":                   '\u33ce', // ㏎
-	"squareln":                          '\u33d1', // ㏑
-	"squarelog":                         '\u33d2', // ㏒
-	"squaremg":                          '\u338e', // ㎎
-	"squaremil":                         '\u33d5', // ㏕
-	"squaremm":                          '\u339c', // ㎜
-	"squaremsquared":                    '\u33a1', // ㎡
-	"squareorthogonalcrosshatchfill":    '\u25a6', // ▦
-	"squareupperlefttolowerrightfill":   '\u25a7', // ▧
-	"squareupperrighttolowerleftfill":   '\u25a8', // ▨
-	"squareverticalfill":                '\u25a5', // ▥
-	"squarewhitewithsmallblack":         '\u25a3', // ▣
-	"srsquare":                          '\u33db', // ㏛
-	"ssabengali":                        '\u09b7', // ষ
-	"ssadeva":                           '\u0937', // ष
-	"ssagujarati":                       '\u0ab7', // ષ
-	"ssangcieuckorean":                  '\u3149', // ㅉ
-	"ssanghieuhkorean":                  '\u3185', // ㆅ
-	"ssangieungkorean":                  '\u3180', // ㆀ
-	"ssangkiyeokkorean":                 '\u3132', // ㄲ
-	"ssangnieunkorean":                  '\u3165', // ㅥ
-	"ssangpieupkorean":                  '\u3143', // ㅃ
-	"ssangsioskorean":                   '\u3146', // ㅆ
-	"ssangtikeutkorean":                 '\u3138', // ㄸ
-	"ssuperior":                         '\uf6f2', // 
-	"sterling":                          '\u00a3', // £
-	"sterlingmonospace":                 '\uffe1', // ￡
-	"strokelongoverlaycmb":              '\u0336', // ̶
-	"strokeshortoverlaycmb":             '\u0335', // ̵
-	"subset":                            '\u2282', // ⊂
-	"subsetnotequal":                    '\u228a', // ⊊
-	"subsetorequal":                     '\u2286', // ⊆
-	"succeeds":                          '\u227b', // ≻
-	"suchthat":                          '\u220b', // ∋
-	"suhiragana":                        '\u3059', // す
-	"sukatakana":                        '\u30b9', // ス
-	"sukatakanahalfwidth":               '\uff7d', // ｽ
-	"sukunarabic":                       '\u0652', // ْ
-	"summation":                         '\u2211', // ∑
-	"sun":                               '\u263c', // ☼
-	"superset":                          '\u2283', // ⊃
-	"supersetnotequal":                  '\u228b', // ⊋
-	"supersetorequal":                   '\u2287', // ⊇
-	"svsquare":                          '\u33dc', // ㏜
-	"syouwaerasquare":                   '\u337c', // ㍼
-	"t":                                 '\u0074', // t
-	"tabengali":                         '\u09a4', // ত
-	"tackdown":                          '\u22a4', // ⊤
-	"tackleft":                          '\u22a3', // ⊣
-	"tadeva":                            '\u0924', // त
-	"tagujarati":                        '\u0aa4', // ત
-	"tagurmukhi":                        '\u0a24', // ਤ
-	"taharabic":                         '\u0637', // ط
-	"tahfinalarabic":                    '\ufec2', // ﻂ
-	"tahinitialarabic":                  '\ufec3', // ﻃ
-	"tahiragana":                        '\u305f', // た
-	"tahmedialarabic":                   '\ufec4', // ﻄ
-	"taisyouerasquare":                  '\u337d', // ㍽
-	"takatakana":                        '\u30bf', // タ
-	"takatakanahalfwidth":               '\uff80', // ﾀ
-	"tatweelarabic":                     '\u0640', // ـ
-	"tau":                               '\u03c4', // τ
-	"tav":                               '\u05ea', // ת
-	"tavdages":                          '\ufb4a', // תּ
-	"tavdagesh":                         '\ufb4a', // תּ
-	"tavdageshhebrew":                   '\ufb4a', // תּ
-	"tavhebrew":                         '\u05ea', // ת
-	"tbar":                              '\u0167', // ŧ
-	"tbopomofo":                         '\u310a', // ㄊ
-	"tcaron":                            '\u0165', // ť
-	"tccurl":                            '\u02a8', // ʨ
-	"tcedilla":                          '\u0163', // ţ
-	"tcheharabic":                       '\u0686', // چ
-	"tchehfinalarabic":                  '\ufb7b', // ﭻ
-	"tchehinitialarabic":                '\ufb7c', // ﭼ
-	"tchehmedialarabic":                 '\ufb7d', // ﭽ
-	//    "tchehmeeminitialarabic": '\ufb7c', // ﭼﻤ
-	"tcircle":                           '\u24e3', // ⓣ
-	"tcircumflexbelow":                  '\u1e71', // ṱ
-	"tcommaaccent":                      '\u0163', // ţ
-	"tdieresis":                         '\u1e97', // ẗ
-	"tdotaccent":                        '\u1e6b', // ṫ
-	"tdotbelow":                         '\u1e6d', // ṭ
-	"tecyrillic":                        '\u0442', // т
-	"tedescendercyrillic":               '\u04ad', // ҭ
-	"teharabic":                         '\u062a', // ت
-	"tehfinalarabic":                    '\ufe96', // ﺖ
-	"tehhahinitialarabic":               '\ufca2', // ﲢ
-	"tehhahisolatedarabic":              '\ufc0c', // ﰌ
-	"tehinitialarabic":                  '\ufe97', // ﺗ
-	"tehiragana":                        '\u3066', // て
-	"tehjeeminitialarabic":              '\ufca1', // ﲡ
-	"tehjeemisolatedarabic":             '\ufc0b', // ﰋ
-	"tehmarbutaarabic":                  '\u0629', // ة
-	"tehmarbutafinalarabic":             '\ufe94', // ﺔ
-	"tehmedialarabic":                   '\ufe98', // ﺘ
-	"tehmeeminitialarabic":              '\ufca4', // ﲤ
-	"tehmeemisolatedarabic":             '\ufc0e', // ﰎ
-	"tehnoonfinalarabic":                '\ufc73', // ﱳ
-	"tekatakana":                        '\u30c6', // テ
-	"tekatakanahalfwidth":               '\uff83', // ﾃ
-	"telephone":                         '\u2121', // ℡
-	"telephoneblack":                    '\u260e', // ☎
-	"telishagedolahebrew":               '\u05a0', // ֠
-	"telishaqetanahebrew":               '\u05a9', // ֩
-	"tencircle":                         '\u2469', // ⑩
-	"tenideographicparen":               '\u3229', // ㈩
-	"tenparen":                          '\u247d', // ⑽
-	"tenperiod":                         '\u2491', // ⒑
-	"tenroman":                          '\u2179', // ⅹ
-	"tesh":                              '\u02a7', // ʧ
-	"tet":                               '\u05d8', // ט
-	"tetdagesh":                         '\ufb38', // טּ
-	"tetdageshhebrew":                   '\ufb38', // טּ
-	"tethebrew":                         '\u05d8', // ט
-	"tetsecyrillic":                     '\u04b5', // ҵ
-	"tevirhebrew":                       '\u059b', // ֛
-	"tevirlefthebrew":                   '\u059b', // ֛
-	"thabengali":                        '\u09a5', // থ
-	"thadeva":                           '\u0925', // थ
-	"thagujarati":                       '\u0aa5', // થ
-	"thagurmukhi":                       '\u0a25', // ਥ
-	"thalarabic":                        '\u0630', // ذ
-	"thalfinalarabic":                   '\ufeac', // ﺬ
-	"thanthakhatlowleftthai":            '\uf898', // 
-	"thanthakhatlowrightthai":           '\uf897', // 
-	"thanthakhatthai":                   '\u0e4c', // ์
-	"thanthakhatupperleftthai":          '\uf896', // 
-	"theharabic":                        '\u062b', // ث
-	"thehfinalarabic":                   '\ufe9a', // ﺚ
-	"thehinitialarabic":                 '\ufe9b', // ﺛ
-	"thehmedialarabic":                  '\ufe9c', // ﺜ
-	"thereexists":                       '\u2203', // ∃
-	"therefore":                         '\u2234', // ∴
-	"theta":                             '\u03b8', // θ
-	"theta1":                            '\u03d1', // ϑ
-	"thetasymbolgreek":                  '\u03d1', // ϑ
-	"thieuthacirclekorean":              '\u3279', // ㉹
-	"thieuthaparenkorean":               '\u3219', // ㈙
-	"thieuthcirclekorean":               '\u326b', // ㉫
-	"thieuthkorean":                     '\u314c', // ㅌ
-	"thieuthparenkorean":                '\u320b', // ㈋
-	"thirteencircle":                    '\u246c', // ⑬
-	"thirteenparen":                     '\u2480', // ⒀
-	"thirteenperiod":                    '\u2494', // ⒔
-	"thonangmonthothai":                 '\u0e11', // ฑ
-	"thook":                             '\u01ad', // ƭ
-	"thophuthaothai":                    '\u0e12', // ฒ
-	"thorn":                             '\u00fe', // þ
-	"thothahanthai":                     '\u0e17', // ท
-	"thothanthai":                       '\u0e10', // ฐ
-	"thothongthai":                      '\u0e18', // ธ
-	"thothungthai":                      '\u0e16', // ถ
-	"thousandcyrillic":                  '\u0482', // ҂
-	"thousandsseparatorarabic":          '\u066c', // ٬
-	"thousandsseparatorpersian":         '\u066c', // ٬
-	"three":                             '\u0033', // 3
-	"threearabic":                       '\u0663', // ٣
-	"threebengali":                      '\u09e9', // ৩
-	"threecircle":                       '\u2462', // ③
-	"threecircleinversesansserif":       '\u278c', // ➌
-	"threedeva":                         '\u0969', // ३
-	"threeeighths":                      '\u215c', // ⅜
-	"threegujarati":                     '\u0ae9', // ૩
-	"threegurmukhi":                     '\u0a69', // ੩
-	"threehackarabic":                   '\u0663', // ٣
-	"threehangzhou":                     '\u3023', // 〣
-	"threeideographicparen":             '\u3222', // ㈢
-	"threeinferior":                     '\u2083', // ₃
-	"threemonospace":                    '\uff13', // ３
-	"threenumeratorbengali":             '\u09f6', // ৶
-	"threeoldstyle":                     '\uf733', // 
-	"threeparen":                        '\u2476', // ⑶
-	"threeperiod":                       '\u248a', // ⒊
-	"threepersian":                      '\u06f3', // ۳
-	"threequarters":                     '\u00be', // ¾
-	"threequartersemdash":               '\uf6de', // 
-	"threeroman":                        '\u2172', // ⅲ
-	"threesuperior":                     '\u00b3', // ³
-	"threethai":                         '\u0e53', // ๓
-	"thzsquare":                         '\u3394', // ㎔
-	"tihiragana":                        '\u3061', // ち
-	"tikatakana":                        '\u30c1', // チ
-	"tikatakanahalfwidth":               '\uff81', // ﾁ
-	"tikeutacirclekorean":               '\u3270', // ㉰
-	"tikeutaparenkorean":                '\u3210', // ㈐
-	"tikeutcirclekorean":                '\u3262', // ㉢
-	"tikeutkorean":                      '\u3137', // ㄷ
-	"tikeutparenkorean":                 '\u3202', // ㈂
-	"tilde":                             '\u02dc', // ˜
-	"tildebelowcmb":                     '\u0330', // ̰
-	"tildecmb":                          '\u0303', // ̃
-	"tildecomb":                         '\u0303', // ̃
-	"tildedoublecmb":                    '\u0360', // ͠
-	"tildeoperator":                     '\u223c', // ∼
-	"tildeoverlaycmb":                   '\u0334', // ̴
-	"tildeverticalcmb":                  '\u033e', // ̾
-	"timescircle":                       '\u2297', // ⊗
-	"tipehahebrew":                      '\u0596', // ֖
-	"tipehalefthebrew":                  '\u0596', // ֖
-	"tippigurmukhi":                     '\u0a70', // ੰ
-	"titlocyrilliccmb":                  '\u0483', // ҃
-	"tiwnarmenian":                      '\u057f', // տ
-	"tlinebelow":                        '\u1e6f', // ṯ
-	"tmonospace":                        '\uff54', // ｔ
-	"toarmenian":                        '\u0569', // թ
-	"tohiragana":                        '\u3068', // と
-	"tokatakana":                        '\u30c8', // ト
-	"tokatakanahalfwidth":               '\uff84', // ﾄ
-	"tonebarextrahighmod":               '\u02e5', // ˥
-	"tonebarextralowmod":                '\u02e9', // ˩
-	"tonebarhighmod":                    '\u02e6', // ˦
-	"tonebarlowmod":                     '\u02e8', // ˨
-	"tonebarmidmod":                     '\u02e7', // ˧
-	"tonefive":                          '\u01bd', // ƽ
-	"tonesix":                           '\u0185', // ƅ
-	"tonetwo":                           '\u01a8', // ƨ
-	"tonos":                             '\u0384', // ΄
-	"tonsquare":                         '\u3327', // ㌧
-	"topatakthai":                       '\u0e0f', // ฏ
-	"tortoiseshellbracketleft":          '\u3014', // 〔
-	"tortoiseshellbracketleftsmall":     '\ufe5d', // ﹝
-	"tortoiseshellbracketleftvertical":  '\ufe39', // ︹
-	"tortoiseshellbracketright":         '\u3015', // 〕
-	"tortoiseshellbracketrightsmall":    '\ufe5e', // ﹞
-	"tortoiseshellbracketrightvertical": '\ufe3a', // ︺
-	"totaothai":                         '\u0e15', // ต
-	"tpalatalhook":                      '\u01ab', // ƫ
-	"tparen":                            '\u24af', // ⒯
-	"trademark":                         '\u2122', // ™
-	"trademarksans":                     '\uf8ea', // 
-	"trademarkserif":                    '\uf6db', // 
-	"tretroflexhook":                    '\u0288', // ʈ
-	"triagdn":                           '\u25bc', // ▼
-	"triaglf":                           '\u25c4', // ◄
-	"triagrt":                           '\u25ba', // ►
-	"triagup":                           '\u25b2', // ▲
-	"ts":                                '\u02a6', // ʦ
-	"tsadi":                             '\u05e6', // צ
-	"tsadidagesh":                       '\ufb46', // צּ
-	"tsadidageshhebrew":                 '\ufb46', // צּ
-	"tsadihebrew":                       '\u05e6', // צ
-	"tsecyrillic":                       '\u0446', // ц
-	"tsere":                             '\u05b5', // ֵ
-	"tsere12":                           '\u05b5', // ֵ
-	"tsere1e":                           '\u05b5', // ֵ
-	"tsere2b":                           '\u05b5', // ֵ
-	"tserehebrew":                       '\u05b5', // ֵ
-	"tserenarrowhebrew":                 '\u05b5', // ֵ
-	"tserequarterhebrew":                '\u05b5', // ֵ
-	"tserewidehebrew":                   '\u05b5', // ֵ
-	"tshecyrillic":                      '\u045b', // ћ
-	"tsuperior":                         '\uf6f3', // 
-	"ttabengali":                        '\u099f', // ট
-	"ttadeva":                           '\u091f', // ट
-	"ttagujarati":                       '\u0a9f', // ટ
-	"ttagurmukhi":                       '\u0a1f', // ਟ
-	"tteharabic":                        '\u0679', // ٹ
-	"ttehfinalarabic":                   '\ufb67', // ﭧ
-	"ttehinitialarabic":                 '\ufb68', // ﭨ
-	"ttehmedialarabic":                  '\ufb69', // ﭩ
-	"tthabengali":                       '\u09a0', // ঠ
-	"tthadeva":                          '\u0920', // ठ
-	"tthagujarati":                      '\u0aa0', // ઠ
-	"tthagurmukhi":                      '\u0a20', // ਠ
-	"tturned":                           '\u0287', // ʇ
-	"tuhiragana":                        '\u3064', // つ
-	"tukatakana":                        '\u30c4', // ツ
-	"tukatakanahalfwidth":               '\uff82', // ﾂ
-	"tusmallhiragana":                   '\u3063', // っ
-	"tusmallkatakana":                   '\u30c3', // ッ
-	"tusmallkatakanahalfwidth":          '\uff6f', // ｯ
-	"twelvecircle":                      '\u246b', // ⑫
-	"twelveparen":                       '\u247f', // ⑿
-	"twelveperiod":                      '\u2493', // ⒓
-	"twelveroman":                       '\u217b', // ⅻ
-	"twentycircle":                      '\u2473', // ⑳
-	"twentyhangzhou":                    '\u5344', // 卄
-	"twentyparen":                       '\u2487', // ⒇
-	"twentyperiod":                      '\u249b', // ⒛
-	"two":                               '\u0032', // 2
-	"twoarabic":                         '\u0662', // ٢
-	"twobengali":                        '\u09e8', // ২
-	"twocircle":                         '\u2461', // ②
-	"twocircleinversesansserif":         '\u278b', // ➋
-	"twodeva":                           '\u0968', // २
-	"twodotenleader":                    '\u2025', // ‥
-	"twodotleader":                      '\u2025', // ‥
-	"twodotleadervertical":              '\ufe30', // ︰
-	"twogujarati":                       '\u0ae8', // ૨
-	"twogurmukhi":                       '\u0a68', // ੨
-	"twohackarabic":                     '\u0662', // ٢
-	"twohangzhou":                       '\u3022', // 〢
-	"twoideographicparen":               '\u3221', // ㈡
-	"twoinferior":                       '\u2082', // ₂
-	"twomonospace":                      '\uff12', // ２
-	"twonumeratorbengali":               '\u09f5', // ৵
-	"twooldstyle":                       '\uf732', // 
-	"twoparen":                          '\u2475', // ⑵
-	"twoperiod":                         '\u2489', // ⒉
-	"twopersian":                        '\u06f2', // ۲
-	"tworoman":                          '\u2171', // ⅱ
-	"twostroke":                         '\u01bb', // ƻ
-	"twosuperior":                       '\u00b2', // ²
-	"twothai":                           '\u0e52', // ๒
-	"twothirds":                         '\u2154', // ⅔
-	"u":                                 '\u0075', // u
-	"uacute":                            '\u00fa', // ú
-	"ubar":                              '\u0289', // ʉ
-	"ubengali":                          '\u0989', // উ
-	"ubopomofo":                         '\u3128', // ㄨ
-	"ubreve":                            '\u016d', // ŭ
-	"ucaron":                            '\u01d4', // ǔ
-	"ucircle":                           '\u24e4', // ⓤ
-	"ucircumflex":                       '\u00fb', // û
-	"ucircumflexbelow":                  '\u1e77', // ṷ
-	"ucyrillic":                         '\u0443', // у
-	"udattadeva":                        '\u0951', // ॑
-	"udblacute":                         '\u0171', // ű
-	"udblgrave":                         '\u0215', // ȕ
-	"udeva":                             '\u0909', // उ
-	"udieresis":                         '\u00fc', // ü
-	"udieresisacute":                    '\u01d8', // ǘ
-	"udieresisbelow":                    '\u1e73', // ṳ
-	"udieresiscaron":                    '\u01da', // ǚ
-	"udieresiscyrillic":                 '\u04f1', // ӱ
-	"udieresisgrave":                    '\u01dc', // ǜ
-	"udieresismacron":                   '\u01d6', // ǖ
-	"udotbelow":                         '\u1ee5', // ụ
-	"ugrave":                            '\u00f9', // ù
-	"ugujarati":                         '\u0a89', // ઉ
-	"ugurmukhi":                         '\u0a09', // ਉ
-	"uhiragana":                         '\u3046', // う
-	"uhookabove":                        '\u1ee7', // ủ
-	"uhorn":                             '\u01b0', // ư
-	"uhornacute":                        '\u1ee9', // ứ
-	"uhorndotbelow":                     '\u1ef1', // ự
-	"uhorngrave":                        '\u1eeb', // ừ
-	"uhornhookabove":                    '\u1eed', // ử
-	"uhorntilde":                        '\u1eef', // ữ
-	"uhungarumlaut":                     '\u0171', // ű
-	"uhungarumlautcyrillic":             '\u04f3', // ӳ
-	"uinvertedbreve":                    '\u0217', // ȗ
-	"ukatakana":                         '\u30a6', // ウ
-	"ukatakanahalfwidth":                '\uff73', // ｳ
-	"ukcyrillic":                        '\u0479', // ѹ
-	"ukorean":                           '\u315c', // ㅜ
-	"umacron":                           '\u016b', // ū
-	"umacroncyrillic":                   '\u04ef', // ӯ
-	"umacrondieresis":                   '\u1e7b', // ṻ
-	"umatragurmukhi":                    '\u0a41', // ੁ
-	"umonospace":                        '\uff55', // ｕ
-	"underscore":                        '\u005f', // _
-	"underscoredbl":                     '\u2017', // ‗
-	"underscoremonospace":               '\uff3f', // ＿
-	"underscorevertical":                '\ufe33', // ︳
-	"underscorewavy":                    '\ufe4f', // ﹏
-	"union":                             '\u222a', // ∪
-	"universal":                         '\u2200', // ∀
-	"uogonek":                           '\u0173', // ų
-	"uparen":                            '\u24b0', // ⒰
-	"upblock":                           '\u2580', // ▀
-	"upperdothebrew":                    '\u05c4', // ׄ
-	"upsilon":                           '\u03c5', // υ
-	"upsilondieresis":                   '\u03cb', // ϋ
-	"upsilondieresistonos":              '\u03b0', // ΰ
-	"upsilonlatin":                      '\u028a', // ʊ
-	"upsilontonos":                      '\u03cd', // ύ
-	"uptackbelowcmb":                    '\u031d', // ̝
-	"uptackmod":                         '\u02d4', // ˔
-	"uragurmukhi":                       '\u0a73', // ੳ
-	"uring":                             '\u016f', // ů
-	"ushortcyrillic":                    '\u045e', // ў
-	"usmallhiragana":                    '\u3045', // ぅ
-	"usmallkatakana":                    '\u30a5', // ゥ
-	"usmallkatakanahalfwidth":           '\uff69', // ｩ
-	"ustraightcyrillic":                 '\u04af', // ү
-	"ustraightstrokecyrillic":           '\u04b1', // ұ
-	"utilde":                            '\u0169', // ũ
-	"utildeacute":                       '\u1e79', // ṹ
-	"utildebelow":                       '\u1e75', // ṵ
-	"uubengali":                         '\u098a', // ঊ
-	"uudeva":                            '\u090a', // ऊ
-	"uugujarati":                        '\u0a8a', // ઊ
-	"uugurmukhi":                        '\u0a0a', // ਊ
-	"uumatragurmukhi":                   '\u0a42', // ੂ
-	"uuvowelsignbengali":                '\u09c2', // ূ
-	"uuvowelsigndeva":                   '\u0942', // ू
-	"uuvowelsigngujarati":               '\u0ac2', // ૂ
-	"uvowelsignbengali":                 '\u09c1', // ু
-	"uvowelsigndeva":                    '\u0941', // ु
-	"uvowelsigngujarati":                '\u0ac1', // ુ
-	"v":                                 '\u0076', // v
-	"vadeva":                            '\u0935', // व
-	"vagujarati":                        '\u0ab5', // વ
-	"vagurmukhi":                        '\u0a35', // ਵ
-	"vakatakana":                        '\u30f7', // ヷ
-	"vav":                               '\u05d5', // ו
-	"vavdagesh":                         '\ufb35', // וּ
-	"vavdagesh65":                       '\ufb35', // וּ
-	"vavdageshhebrew":                   '\ufb35', // וּ
-	"vavhebrew":                         '\u05d5', // ו
-	"vavholam":                          '\ufb4b', // וֹ
-	"vavholamhebrew":                    '\ufb4b', // וֹ
-	"vavvavhebrew":                      '\u05f0', // װ
-	"vavyodhebrew":                      '\u05f1', // ױ
-	"vcircle":                           '\u24e5', // ⓥ
-	"vdotbelow":                         '\u1e7f', // ṿ
-	"vecyrillic":                        '\u0432', // в
-	"veharabic":                         '\u06a4', // ڤ
-	"vehfinalarabic":                    '\ufb6b', // ﭫ
-	"vehinitialarabic":                  '\ufb6c', // ﭬ
-	"vehmedialarabic":                   '\ufb6d', // ﭭ
-	"vekatakana":                        '\u30f9', // ヹ
-	"venus":                             '\u2640', // ♀
-	"verticalbar":                       '\u007c', // |
-	"verticallineabovecmb":              '\u030d', // ̍
-	"verticallinebelowcmb":              '\u0329', // ̩
-	"verticallinelowmod":                '\u02cc', // ˌ
-	"verticallinemod":                   '\u02c8', // ˈ
-	"vewarmenian":                       '\u057e', // վ
-	"vhook":                             '\u028b', // ʋ
-	"vikatakana":                        '\u30f8', // ヸ
-	"viramabengali":                     '\u09cd', // ্
-	"viramadeva":                        '\u094d', // ्
-	"viramagujarati":                    '\u0acd', // ્
-	"visargabengali":                    '\u0983', // ঃ
-	"visargadeva":                       '\u0903', // ः
-	"visargagujarati":                   '\u0a83', // ઃ
-	"vmonospace":                        '\uff56', // ｖ
-	"voarmenian":                        '\u0578', // ո
-	"voicediterationhiragana":           '\u309e', // ゞ
-	"voicediterationkatakana":           '\u30fe', // ヾ
-	"voicedmarkkana":                    '\u309b', // ゛
-	"voicedmarkkanahalfwidth":           '\uff9e', // ﾞ
-	"vokatakana":                        '\u30fa', // ヺ
-	"vparen":                            '\u24b1', // ⒱
-	"vtilde":                            '\u1e7d', // ṽ
-	"vturned":                           '\u028c', // ʌ
-	"vuhiragana":                        '\u3094', // ゔ
-	"vukatakana":                        '\u30f4', // ヴ
-	"w":                                 '\u0077', // w
-	"wacute":                            '\u1e83', // ẃ
-	"waekorean":                         '\u3159', // ㅙ
-	"wahiragana":                        '\u308f', // わ
-	"wakatakana":                        '\u30ef', // ワ
-	"wakatakanahalfwidth":               '\uff9c', // ﾜ
-	"wakorean":                          '\u3158', // ㅘ
-	"wasmallhiragana":                   '\u308e', // ゎ
-	"wasmallkatakana":                   '\u30ee', // ヮ
-	"wattosquare":                       '\u3357', // ㍗
-	"wavedash":                          '\u301c', // 〜
-	"wavyunderscorevertical":            '\ufe34', // ︴
-	"wawarabic":                         '\u0648', // و
-	"wawfinalarabic":                    '\ufeee', // ﻮ
-	"wawhamzaabovearabic":               '\u0624', // ؤ
-	"wawhamzaabovefinalarabic":          '\ufe86', // ﺆ
-	"wbsquare":                          '\u33dd', // ㏝
-	"wcircle":                           '\u24e6', // ⓦ
-	"wcircumflex":                       '\u0175', // ŵ
-	"wdieresis":                         '\u1e85', // ẅ
-	"wdotaccent":                        '\u1e87', // ẇ
-	"wdotbelow":                         '\u1e89', // ẉ
-	"wehiragana":                        '\u3091', // ゑ
-	"weierstrass":                       '\u2118', // ℘
-	"wekatakana":                        '\u30f1', // ヱ
-	"wekorean":                          '\u315e', // ㅞ
-	"weokorean":                         '\u315d', // ㅝ
-	"wgrave":                            '\u1e81', // ẁ
-	"whitebullet":                       '\u25e6', // ◦
-	"whitecircle":                       '\u25cb', // ○
-	"whitecircleinverse":                '\u25d9', // ◙
-	"whitecornerbracketleft":            '\u300e', // 『
-	"whitecornerbracketleftvertical":    '\ufe43', // ﹃
-	"whitecornerbracketright":           '\u300f', // 』
-	"whitecornerbracketrightvertical":   '\ufe44', // ﹄
-	"whitediamond":                      '\u25c7', // ◇
-	"whitediamondcontainingblacksmalldiamond": '\u25c8', // ◈
-	"whitedownpointingsmalltriangle":          '\u25bf', // ▿
-	"whitedownpointingtriangle":               '\u25bd', // ▽
-	"whiteleftpointingsmalltriangle":          '\u25c3', // ◃
-	"whiteleftpointingtriangle":               '\u25c1', // ◁
-	"whitelenticularbracketleft":              '\u3016', // 〖
-	"whitelenticularbracketright":             '\u3017', // 〗
-	"whiterightpointingsmalltriangle":         '\u25b9', // ▹
-	"whiterightpointingtriangle":              '\u25b7', // ▷
-	"whitesmallsquare":                        '\u25ab', // ▫
-	"whitesmilingface":                        '\u263a', // ☺
-	"whitesquare":                             '\u25a1', // □
-	"whitestar":                               '\u2606', // ☆
-	"whitetelephone":                          '\u260f', // ☏
-	"whitetortoiseshellbracketleft":           '\u3018', // 〘
-	"whitetortoiseshellbracketright":          '\u3019', // 〙
-	"whiteuppointingsmalltriangle":            '\u25b5', // ▵
-	"whiteuppointingtriangle":                 '\u25b3', // △
-	"wihiragana":                              '\u3090', // ゐ
-	"wikatakana":                              '\u30f0', // ヰ
-	"wikorean":                                '\u315f', // ㅟ
-	"wmonospace":                              '\uff57', // ｗ
-	"wohiragana":                              '\u3092', // を
-	"wokatakana":                              '\u30f2', // ヲ
-	"wokatakanahalfwidth":                     '\uff66', // ｦ
-	"won":                                     '\u20a9', // ₩
-	"wonmonospace":                            '\uffe6', // ￦
-	"wowaenthai":                              '\u0e27', // ว
-	"wparen":                                  '\u24b2', // ⒲
-	"wring":                                   '\u1e98', // ẘ
-	"wsuperior":                               '\u02b7', // ʷ
-	"wturned":                                 '\u028d', // ʍ
-	"wynn":                                    '\u01bf', // ƿ
-	"x":                                       '\u0078', // x
-	"xabovecmb":                               '\u033d', // ̽
-	"xbopomofo":                               '\u3112', // ㄒ
-	"xcircle":                                 '\u24e7', // ⓧ
-	"xdieresis":                               '\u1e8d', // ẍ
-	"xdotaccent":                              '\u1e8b', // ẋ
-	"xeharmenian":                             '\u056d', // խ
-	"xi":                                      '\u03be', // ξ
-	"xmonospace":                              '\uff58', // ｘ
-	"xparen":                                  '\u24b3', // ⒳
-	"xsuperior":                               '\u02e3', // ˣ
-	"y":                                       '\u0079', // y
-	"yaadosquare":                             '\u334e', // ㍎
-	"yabengali":                               '\u09af', // য
-	"yacute":                                  '\u00fd', // ý
-	"yadeva":                                  '\u092f', // य
-	"yaekorean":                               '\u3152', // ㅒ
-	"yagujarati":                              '\u0aaf', // ય
-	"yagurmukhi":                              '\u0a2f', // ਯ
-	"yahiragana":                              '\u3084', // や
-	"yakatakana":                              '\u30e4', // ヤ
-	"yakatakanahalfwidth":                     '\uff94', // ﾔ
-	"yakorean":                                '\u3151', // ㅑ
-	"yamakkanthai":                            '\u0e4e', // ๎
-	"yasmallhiragana":                         '\u3083', // ゃ
-	"yasmallkatakana":                         '\u30e3', // ャ
-	"yasmallkatakanahalfwidth":                '\uff6c', // ｬ
-	"yatcyrillic":                             '\u0463', // ѣ
-	"ycircle":                                 '\u24e8', // ⓨ
-	"ycircumflex":                             '\u0177', // ŷ
-	"ydieresis":                               '\u00ff', // ÿ
-	"ydotaccent":                              '\u1e8f', // ẏ
-	"ydotbelow":                               '\u1ef5', // ỵ
-	"yeharabic":                               '\u064a', // ي
-	"yehbarreearabic":                         '\u06d2', // ے
-	"yehbarreefinalarabic":                    '\ufbaf', // ﮯ
-	"yehfinalarabic":                          '\ufef2', // ﻲ
-	"yehhamzaabovearabic":                     '\u0626', // ئ
-	"yehhamzaabovefinalarabic":                '\ufe8a', // ﺊ
-	"yehhamzaaboveinitialarabic":              '\ufe8b', // ﺋ
-	"yehhamzaabovemedialarabic":               '\ufe8c', // ﺌ
-	"yehinitialarabic":                        '\ufef3', // ﻳ
-	"yehmedialarabic":                         '\ufef4', // ﻴ
-	"yehmeeminitialarabic":                    '\ufcdd', // ﳝ
-	"yehmeemisolatedarabic":                   '\ufc58', // ﱘ
-	"yehnoonfinalarabic":                      '\ufc94', // ﲔ
-	"yehthreedotsbelowarabic":                 '\u06d1', // ۑ
-	"yekorean":                                '\u3156', // ㅖ
-	"yen":                                     '\u00a5', // ¥
-	"yenmonospace":                            '\uffe5', // ￥
-	"yeokorean":                               '\u3155', // ㅕ
-	"yeorinhieuhkorean":                       '\u3186', // ㆆ
-	"yerahbenyomohebrew":                      '\u05aa', // ֪
-	"yerahbenyomolefthebrew":                  '\u05aa', // ֪
-	"yericyrillic":                            '\u044b', // ы
-	"yerudieresiscyrillic":                    '\u04f9', // ӹ
-	"yesieungkorean":                          '\u3181', // ㆁ
-	"yesieungpansioskorean":                   '\u3183', // ㆃ
-	"yesieungsioskorean":                      '\u3182', // ㆂ
-	"yetivhebrew":                             '\u059a', // ֚
-	"ygrave":                                  '\u1ef3', // ỳ
-	"yhook":                                   '\u01b4', // ƴ
-	"yhookabove":                              '\u1ef7', // ỷ
-	"yiarmenian":                              '\u0575', // յ
-	"yicyrillic":                              '\u0457', // ї
-	"yikorean":                                '\u3162', // ㅢ
-	"yinyang":                                 '\u262f', // ☯
-	"yiwnarmenian":                            '\u0582', // ւ
-	"ymonospace":                              '\uff59', // ｙ
-	"yod":                                     '\u05d9', // י
-	"yoddagesh":                               '\ufb39', // יּ
-	"yoddageshhebrew":                         '\ufb39', // יּ
-	"yodhebrew":                               '\u05d9', // י
-	"yodyodhebrew":                            '\u05f2', // ײ
-	"yodyodpatahhebrew":                       '\ufb1f', // ײַ
-	"yohiragana":                              '\u3088', // よ
-	"yoikorean":                               '\u3189', // ㆉ
-	"yokatakana":                              '\u30e8', // ヨ
-	"yokatakanahalfwidth":                     '\uff96', // ﾖ
-	"yokorean":                                '\u315b', // ㅛ
-	"yosmallhiragana":                         '\u3087', // ょ
-	"yosmallkatakana":                         '\u30e7', // ョ
-	"yosmallkatakanahalfwidth":                '\uff6e', // ｮ
-	"yotgreek":                                '\u03f3', // ϳ
-	"yoyaekorean":                             '\u3188', // ㆈ
-	"yoyakorean":                              '\u3187', // ㆇ
-	"yoyakthai":                               '\u0e22', // ย
-	"yoyingthai":                              '\u0e0d', // ญ
-	"yparen":                                  '\u24b4', // ⒴
-	"ypogegrammeni":                           '\u037a', // ͺ
-	"ypogegrammenigreekcmb":                   '\u0345', // ͅ
-	"yr":                                      '\u01a6', // Ʀ
-	"yring":                                   '\u1e99', // ẙ
-	"ysuperior":                               '\u02b8', // ʸ
-	"ytilde":                                  '\u1ef9', // ỹ
-	"yturned":                                 '\u028e', // ʎ
-	"yuhiragana":                              '\u3086', // ゆ
-	"yuikorean":                               '\u318c', // ㆌ
-	"yukatakana":                              '\u30e6', // ユ
-	"yukatakanahalfwidth":                     '\uff95', // ﾕ
-	"yukorean":                                '\u3160', // ㅠ
-	"yusbigcyrillic":                          '\u046b', // ѫ
-	"yusbigiotifiedcyrillic":                  '\u046d', // ѭ
-	"yuslittlecyrillic":                       '\u0467', // ѧ
-	"yuslittleiotifiedcyrillic":               '\u0469', // ѩ
-	"yusmallhiragana":                         '\u3085', // ゅ
-	"yusmallkatakana":                         '\u30e5', // ュ
-	"yusmallkatakanahalfwidth":                '\uff6d', // ｭ
-	"yuyekorean":                              '\u318b', // ㆋ
-	"yuyeokorean":                             '\u318a', // ㆊ
-	"yyabengali":                              '\u09df', // য়
-	"yyadeva":                                 '\u095f', // य़
-	"z":                                       '\u007a', // z
-	"zaarmenian":                              '\u0566', // զ
-	"zacute":                                  '\u017a', // ź
-	"zadeva":                                  '\u095b', // ज़
-	"zagurmukhi":                              '\u0a5b', // ਜ਼
-	"zaharabic":                               '\u0638', // ظ
-	"zahfinalarabic":                          '\ufec6', // ﻆ
-	"zahinitialarabic":                        '\ufec7', // ﻇ
-	"zahiragana":                              '\u3056', // ざ
-	"zahmedialarabic":                         '\ufec8', // ﻈ
-	"zainarabic":                              '\u0632', // ز
-	"zainfinalarabic":                         '\ufeb0', // ﺰ
-	"zakatakana":                              '\u30b6', // ザ
-	"zaqefgadolhebrew":                        '\u0595', // ֕
-	"zaqefqatanhebrew":                        '\u0594', // ֔
-	"zarqahebrew":                             '\u0598', // ֘
-	"zayin":                                   '\u05d6', // ז
-	"zayindagesh":                             '\ufb36', // זּ
-	"zayindageshhebrew":                       '\ufb36', // זּ
-	"zayinhebrew":                             '\u05d6', // ז
-	"zbopomofo":                               '\u3117', // ㄗ
-	"zcaron":                                  '\u017e', // ž
-	"zcircle":                                 '\u24e9', // ⓩ
-	"zcircumflex":                             '\u1e91', // ẑ
-	"zcurl":                                   '\u0291', // ʑ
-	"zdot":                                    '\u017c', // ż
-	"zdotaccent":                              '\u017c', // ż
-	"zdotbelow":                               '\u1e93', // ẓ
-	"zecyrillic":                              '\u0437', // з
-	"zedescendercyrillic":                     '\u0499', // ҙ
-	"zedieresiscyrillic":                      '\u04df', // ӟ
-	"zehiragana":                              '\u305c', // ぜ
-	"zekatakana":                              '\u30bc', // ゼ
-	"zero":                                    '\u0030', // 0
-	"zeroarabic":                              '\u0660', // ٠
-	"zerobengali":                             '\u09e6', // ০
-	"zerodeva":                                '\u0966', // ०
-	"zerogujarati":                            '\u0ae6', // ૦
-	"zerogurmukhi":                            '\u0a66', // ੦
-	"zerohackarabic":                          '\u0660', // ٠
-	"zeroinferior":                            '\u2080', // ₀
-	"zeromonospace":                           '\uff10', // ０
-	"zerooldstyle":                            '\uf730', // 
-	"zeropersian":                             '\u06f0', // ۰
-	"zerosuperior":                            '\u2070', // ⁰
-	"zerothai":                                '\u0e50', // ๐
-	"zerowidthjoiner":                         '\ufeff',
-	"zerowidthnonjoiner":                      '\u200c', // ‌
-	"zerowidthspace":                          '\u200b', // ​
-	"zeta":                                    '\u03b6', // ζ
-	"zhbopomofo":                              '\u3113', // ㄓ
-	"zhearmenian":                             '\u056a', // ժ
-	"zhebrevecyrillic":                        '\u04c2', // ӂ
-	"zhecyrillic":                             '\u0436', // ж
-	"zhedescendercyrillic":                    '\u0497', // җ
-	"zhedieresiscyrillic":                     '\u04dd', // ӝ
-	"zihiragana":                              '\u3058', // じ
-	"zikatakana":                              '\u30b8', // ジ
-	"zinorhebrew":                             '\u05ae', // ֮
-	"zlinebelow":                              '\u1e95', // ẕ
-	"zmonospace":                              '\uff5a', // ｚ
-	"zohiragana":                              '\u305e', // ぞ
-	"zokatakana":                              '\u30be', // ゾ
-	"zparen":                                  '\u24b5', // ⒵
-	"zretroflexhook":                          '\u0290', // ʐ
-	"zstroke":                                 '\u01b6', // ƶ
-	"zuhiragana":                              '\u305a', // ず
-	"zukatakana":                              '\u30ba', // ズ
-}
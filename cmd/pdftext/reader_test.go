@@ -1,18 +1,43 @@
 package main
 
-//import (
-//	"os"
-//	"testing"
-//)
-//
-//func TestReader(t *testing.T) {
-//	r, err := os.Open("../../test/test.pdf")
-//	if err != nil {
-//		panic(err)
-//	}
-//
-//	_, err = NewPDFReader(r)
-//	if err != nil {
-//		panic(err)
-//	}
-//}
+import (
+	"bytes"
+	"testing"
+
+	"github.com/LaminoidStudio/Canvas"
+	"github.com/LaminoidStudio/Canvas/internal/pdfedit"
+	"github.com/LaminoidStudio/Canvas/renderers/pdf"
+	"github.com/tdewolff/test"
+)
+
+func TestReader(t *testing.T) {
+	family := canvas.NewFontFamily("dejavu-serif")
+	test.Error(t, family.LoadFontFile("../../resources/DejaVuSerif.ttf", canvas.FontRegular))
+	face := family.Face(12.0, canvas.Black, canvas.FontRegular, canvas.FontNormal)
+
+	buf := &bytes.Buffer{}
+	w := pdf.New(buf, 100.0, 50.0, nil)
+	w.RenderText(canvas.NewTextLine(face, "Hello", canvas.Left), canvas.Identity.Translate(10.0, 20.0))
+	test.Error(t, w.Close())
+
+	doc, err := pdfedit.Open(bytes.NewReader(buf.Bytes()), "")
+	test.Error(t, err)
+	strs, err := doc.TextStrings(0)
+	test.Error(t, err)
+	test.T(t, len(strs), 1)
+	test.T(t, strs[0].Text, "Hello")
+	test.Error(t, doc.ReplaceText(0, "", 0, "Hole", 0.0, 0.0))
+
+	out := &bytes.Buffer{}
+	test.Error(t, doc.Write(out))
+
+	doc, err = pdfedit.Open(bytes.NewReader(out.Bytes()), "")
+	test.Error(t, err)
+	strs, err = doc.TextStrings(0)
+	test.Error(t, err)
+	test.T(t, len(strs), 1)
+	test.T(t, strs[0].Text, "Hole")
+
+	_, err = pdfedit.Open(bytes.NewReader([]byte("not a PDF")), "")
+	test.That(t, err != nil, "must fail for invalid document")
+}
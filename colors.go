@@ -167,6 +167,108 @@ func RGBA(r, g, b uint8, a float64) color.RGBA {
 	}
 }
 
+// NativeColor is a color in a color space other than sRGB, which is either a CMYKColor, SpotColor, or ICCColor. Renderers that support the color space write the color natively, such as the PDF and PostScript renderers, while other renderers use the conversion to sRGB from its RGBA method.
+type NativeColor interface {
+	color.Color
+	nativeColor()
+}
+
+// CMYKColor is a color in the device CMYK color space with components and alpha between 0 and 1.
+type CMYKColor struct {
+	C, M, Y, K float64
+	A          float64
+}
+
+// CMYK returns an opaque CMYK color with components between 0 and 1.
+func CMYK(c, m, y, k float64) CMYKColor {
+	return CMYKColor{c, m, y, k, 1.0}
+}
+
+func (CMYKColor) nativeColor() {}
+
+// RGBA returns the alpha-premultiplied sRGB color using a naive conversion without color management.
+func (c CMYKColor) RGBA() (uint32, uint32, uint32, uint32) {
+	k := 1.0 - clampUnit(c.K)
+	return colorRGBA((1.0-clampUnit(c.C))*k, (1.0-clampUnit(c.M))*k, (1.0-clampUnit(c.Y))*k, c.A)
+}
+
+// SpotColor is a named colorant (a separation) at a tint between 0 and 1, where a tint of zero is white (no ink). The alternate color is the color at full tint and is used by renderers and devices that do not have the colorant, it is usually a CMYKColor.
+type SpotColor struct {
+	Name      string
+	Tint      float64
+	Alternate color.Color
+	A         float64
+}
+
+// NewSpotColor returns an opaque spot color with the given name, alternate color at full tint, and tint between 0 and 1.
+func NewSpotColor(name string, alternate color.Color, tint float64) SpotColor {
+	return SpotColor{name, tint, alternate, 1.0}
+}
+
+func (SpotColor) nativeColor() {}
+
+// RGBA returns the alpha-premultiplied sRGB color by interpolating between white and the alternate color.
+func (c SpotColor) RGBA() (uint32, uint32, uint32, uint32) {
+	if cmyk, ok := c.Alternate.(CMYKColor); ok {
+		t := clampUnit(c.Tint)
+		return CMYKColor{cmyk.C * t, cmyk.M * t, cmyk.Y * t, cmyk.K * t, c.A}.RGBA()
+	}
+	r, g, b := 1.0, 1.0, 1.0
+	if c.Alternate != nil {
+		R, G, B, A := c.Alternate.RGBA()
+		if A != 0 {
+			r, g, b = float64(R)/float64(A), float64(G)/float64(A), float64(B)/float64(A)
+		}
+	}
+	t := clampUnit(c.Tint)
+	return colorRGBA(1.0-t*(1.0-r), 1.0-t*(1.0-g), 1.0-t*(1.0-b), c.A)
+}
+
+// ICCColor is a color in the color space of an ICC profile, with components and alpha between 0 and 1. Without a profile, the components are interpreted as device gray, RGB, or CMYK depending on their number.
+type ICCColor struct {
+	Profile    *ICCProfile
+	Components []float64
+	A          float64
+}
+
+// NewICCColor returns an opaque color in the color space of an ICC profile, with components between 0 and 1.
+func NewICCColor(profile *ICCProfile, components ...float64) ICCColor {
+	return ICCColor{profile, components, 1.0}
+}
+
+func (ICCColor) nativeColor() {}
+
+// RGBA returns the alpha-premultiplied sRGB color using the color transform of the ICC profile.
+func (c ICCColor) RGBA() (uint32, uint32, uint32, uint32) {
+	if c.Profile != nil {
+		r, g, b := c.Profile.ToRGB(c.Components)
+		return colorRGBA(r, g, b, c.A)
+	}
+	comp := func(i int) float64 {
+		if i < len(c.Components) {
+			return clampUnit(c.Components[i])
+		}
+		return 0.0
+	}
+	switch len(c.Components) {
+	case 1:
+		return colorRGBA(comp(0), comp(0), comp(0), c.A)
+	case 4:
+		return CMYKColor{comp(0), comp(1), comp(2), comp(3), c.A}.RGBA()
+	}
+	return colorRGBA(comp(0), comp(1), comp(2), c.A)
+}
+
+func clampUnit(v float64) float64 {
+	return math.Max(0.0, math.Min(1.0, v))
+}
+
+// colorRGBA returns the 16-bit alpha-premultiplied components of a color with non-premultiplied components between 0 and 1.
+func colorRGBA(r, g, b, a float64) (uint32, uint32, uint32, uint32) {
+	a = clampUnit(a)
+	return uint32(clampUnit(r)*a*65535.0 + 0.5), uint32(clampUnit(g)*a*65535.0 + 0.5), uint32(clampUnit(b)*a*65535.0 + 0.5), uint32(a*65535.0 + 0.5)
+}
+
 // Transparent when used as a fill or stroke color will indicate that the fill or stroke will not be drawn.
 var Transparent = color.RGBA{0x00, 0x00, 0x00, 0x00} // rgba(0, 0, 0, 0)
 
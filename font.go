@@ -6,6 +6,9 @@ import (
 	"io/ioutil"
 	"math"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/LaminoidStudio/Canvas/font"
 	"github.com/LaminoidStudio/Canvas/text"
@@ -125,6 +128,10 @@ type Font struct {
 	shaper     text.Shaper
 	variations string
 	features   string
+	palette    int
+
+	colorGlyphs   map[colorGlyphKey]*Canvas
+	colorGlyphsMu sync.Mutex
 }
 
 // LoadLocalFont loads a font from the system's fonts.
@@ -201,10 +208,29 @@ func (f *Font) Style() FontStyle {
 	return f.style
 }
 
-// SetVariations sets the font variations (not yet supported).
+// SetVariations sets the font variations of a variable font as a comma-separated list of axis values, such as "wght=700,wdth=75". They apply to text shaping, glyph outlines, advances, and font metrics.
 func (f *Font) SetVariations(variations string) {
-	// TODO: support font variations
+	f.colorGlyphsMu.Lock()
+	defer f.colorGlyphsMu.Unlock()
 	f.variations = variations
+	f.colorGlyphs = nil
+	if values, err := font.ParseVariations(variations); err == nil {
+		f.SFNT.SetVariations(values)
+	}
+}
+
+// SetNamedInstance sets the font variations to those of the named instance with the given index of a variable font, such as "Bold".
+func (f *Font) SetNamedInstance(index int) error {
+	instances := f.SFNT.NamedInstances()
+	if index < 0 || len(instances) <= index {
+		return fmt.Errorf("bad named instance %d", index)
+	}
+	variations := []string{}
+	for i, axis := range f.SFNT.VariationAxes() {
+		variations = append(variations, strings.TrimSpace(axis.Tag)+"="+strconv.FormatFloat(instances[index].Coordinates[i], 'g', -1, 64))
+	}
+	f.SetVariations(strings.Join(variations, ","))
+	return nil
 }
 
 // SetFeatures sets the font features (not yet supported).
@@ -223,6 +249,7 @@ func (f *Font) Face(size float64, col color.Color, deco ...FontDecorator) *FontF
 
 	r, g, b, a := col.RGBA()
 	face.Color = color.RGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), uint8(a >> 8)}
+	face.NativeColor, _ = col.(NativeColor)
 	face.Deco = deco
 	face.mmPerEm = face.Size / float64(face.Font.Head.UnitsPerEm)
 	return face
@@ -256,7 +283,7 @@ func (family *FontFamily) Name() string {
 	return family.name
 }
 
-// SetVariations sets the font variations (not yet supported).
+// SetVariations sets the font variations of all fonts in the family, see Font.SetVariations.
 func (family *FontFamily) SetVariations(variations string) {
 	for _, font := range family.fonts {
 		font.SetVariations(variations)
@@ -437,17 +464,21 @@ type FontFace struct {
 	Style   FontStyle
 	Variant FontVariant
 
-	Color color.RGBA
-	Deco  []FontDecorator
+	Color       color.RGBA
+	NativeColor NativeColor // color in its native color space, Color must hold its sRGB conversion
+	Deco        []FontDecorator
 
 	// faux styles for bold, italic, and sub- and superscript
 	FauxBold, FauxItalic float64
 	XOffset, YOffset     int32
 
-	Language  string
+	Language  string // BCP 47 language tag used for shaping and hyphenation, see text.RegisterHyphenator
 	Script    text.Script
 	Direction text.Direction // TODO: really needed here?
 
+	// hinting of glyph outlines when rasterized, which aligns them to the pixel grid for crisp text at small sizes
+	Hinting font.Hinting
+
 	// letter spacing
 	// stroke and stroke color
 	// line height
@@ -508,7 +539,15 @@ func (face *FontFace) Metrics() FontMetrics {
 // PPEM returns the pixels-per-EM for a given resolution of the font face.
 func (face *FontFace) PPEM(resolution Resolution) uint16 {
 	// ppem is for hinting purposes only, this does not influence glyph advances
-	return uint16(resolution.DPMM() * face.mmPerEm)
+	return uint16(resolution.DPMM()*face.Size + 0.5)
+}
+
+// shapingPPEM returns the ppem passed to the shaper, which applies the device adjustments of GPOS for that size. These are meant for hinted text, so that zero is returned when hinting is disabled and the layout does not depend on the resolution.
+func (face *FontFace) shapingPPEM() uint16 {
+	if face.Hinting == font.NoHinting {
+		return 0
+	}
+	return face.PPEM(DefaultResolution)
 }
 
 // LineHeight returns the height (ascent+descent) of a line.
@@ -519,8 +558,7 @@ func (face *FontFace) LineHeight() float64 {
 
 // TextWidth returns the width of a given string in millimeters.
 func (face *FontFace) TextWidth(s string) float64 {
-	ppem := face.PPEM(DefaultResolution)
-	glyphs := face.Font.shaper.Shape(s, ppem, face.Direction, face.Script, face.Language, face.Font.features, face.Font.variations)
+	glyphs := face.Font.shaper.Shape(s, face.shapingPPEM(), face.Direction, face.Script, face.Language, face.Font.features, face.Font.variations)
 	return face.textWidth(glyphs)
 }
 
@@ -563,17 +601,17 @@ func (face *FontFace) Decorate(width float64) *Path {
 
 // ToPath converts a string to its glyph paths.
 func (face *FontFace) ToPath(s string) (*Path, float64, error) {
-	ppem := face.PPEM(DefaultResolution)
-	glyphs := face.Font.shaper.Shape(s, ppem, face.Direction, face.Script, face.Language, face.Font.features, face.Font.variations)
-	return face.toPath(glyphs, ppem)
+	glyphs := face.Font.shaper.Shape(s, face.shapingPPEM(), face.Direction, face.Script, face.Language, face.Font.features, face.Font.variations)
+	return face.toPath(glyphs, 0)
 }
 
+// toPath converts glyphs to their paths, which are hinted at the given ppem when the face has hinting enabled. A ppem of zero returns the unhinted outlines.
 func (face *FontFace) toPath(glyphs []text.Glyph, ppem uint16) (*Path, float64, error) {
 	p := &Path{}
 	f := face.mmPerEm
 	x, y := face.XOffset, face.YOffset
 	for _, glyph := range glyphs {
-		err := face.Font.GlyphPath(p, glyph.ID, ppem, f*float64(x+glyph.XOffset), f*float64(y+glyph.YOffset), f, font.NoHinting)
+		err := face.Font.GlyphPath(p, glyph.ID, ppem, f*float64(x+glyph.XOffset), f*float64(y+glyph.YOffset), f, face.Hinting)
 		if err != nil {
 			return p, 0.0, err
 		}
@@ -25,12 +25,14 @@ type Pather interface {
 	Close()
 }
 
-// Hinting specifies the type of hinting to use (none supported yes).
+// Hinting specifies the type of hinting to use. FontHinting executes the TrueType instructions of the font and leaves CFF fonts unhinted, while AutoHinting aligns the horizontal edges of any font to the pixel grid without using the font's instructions.
 type Hinting int
 
 // see Hinting
 const (
 	NoHinting Hinting = iota
+	FontHinting
+	AutoHinting
 )
 
 // SFNT is a parsed OpenType font.
@@ -65,6 +67,24 @@ type SFNT struct {
 	Gpos *gposgsubTable
 	Gsub *gposgsubTable
 	Jsft *jsftTable
+	Math *mathTable
+
+	// color
+	Colr *colrTable
+	Cpal *cpalTable
+	Sbix *sbixTable
+	Cblc *cblcTable // with CBDT
+	Svg  *svgTable
+
+	// variations
+	Fvar *fvarTable
+	Avar *avarTable
+	Gvar *gvarTable
+	Hvar *hvarTable
+	Mvar *mvarTable
+
+	design []float64 // design coordinates of the current instance
+	coords []float64 // normalized coordinates of the current instance, nil for the default instance
 	//Gasp *gaspTable // TODO
 	//Base *baseTable // TODO
 	//Prep *baseTable // TODO
@@ -87,9 +107,11 @@ func (sfnt *SFNT) GlyphName(glyphID uint16) string {
 	return sfnt.Post.Get(glyphID)
 }
 
-// GlyphPath draws the glyph's contour as a path to the pather interface. It will use the specified ppem (pixels-per-EM) for hinting purposes. The path is draws to the (x,y) coordinate and scaled using the given scale factor.
+// GlyphPath draws the glyph's contour as a path to the pather interface. It will use the specified ppem (pixels-per-EM) for hinting purposes, where a ppem of zero disables hinting. The path is draws to the (x,y) coordinate and scaled using the given scale factor.
 func (sfnt *SFNT) GlyphPath(p Pather, glyphID, ppem uint16, x, y, scale float64, hinting Hinting) error {
-	if sfnt.IsTrueType {
+	if hinting == AutoHinting && ppem != 0 {
+		return sfnt.autohint(p, glyphID, ppem, x, y, scale)
+	} else if sfnt.IsTrueType {
 		return sfnt.Glyf.ToPath(p, glyphID, ppem, x, y, scale, hinting)
 	} else if sfnt.IsCFF {
 		return sfnt.CFF.ToPath(p, glyphID, ppem, x, y, scale, hinting)
@@ -97,9 +119,19 @@ func (sfnt *SFNT) GlyphPath(p Pather, glyphID, ppem uint16, x, y, scale float64,
 	return fmt.Errorf("only TrueType and CFF are supported")
 }
 
-// GlyphAdvance returns the (horizontal) advance width of the glyph.
+// GlyphAdvance returns the (horizontal) advance width of the glyph. For variable fonts, the advance at the current variations is returned.
 func (sfnt *SFNT) GlyphAdvance(glyphID uint16) uint16 {
-	return sfnt.Hmtx.Advance(glyphID)
+	advance := sfnt.Hmtx.Advance(glyphID)
+	if sfnt.coords != nil {
+		var delta float64
+		if sfnt.Hvar != nil {
+			delta = sfnt.Hvar.Advance(glyphID, sfnt.coords)
+		} else if sfnt.Gvar != nil && sfnt.Glyf != nil {
+			delta = sfnt.Glyf.AdvanceDelta(glyphID)
+		}
+		return uint16(math.Max(0.0, float64(advance)+math.Round(delta)))
+	}
+	return advance
 }
 
 // GlyphVerticalAdvance returns the vertical advance width of the glyph.
@@ -166,9 +198,11 @@ func (sfnt *SFNT) GlyphBounds(glyphID uint16) (int16, int16, int16, int16, error
 		}
 		return contour.XMin, contour.YMin, contour.XMax, contour.YMax, nil
 	} else if sfnt.IsCFF {
-		p := &boundsPather{}
+		p := &boundsPather{math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)}
 		if err := sfnt.CFF.ToPath(p, glyphID, 0, 0, 0, 1.0, NoHinting); err != nil {
 			return 0, 0, 0, 0, err
+		} else if p.xmax < p.xmin {
+			return 0, 0, 0, 0, nil // empty glyph
 		}
 		return int16(p.xmin), int16(p.ymin), int16(math.Ceil(p.xmax)), int16(math.Ceil(p.ymax)), nil
 	}
@@ -193,6 +227,20 @@ func ParseEmbeddedSFNT(b []byte, index int) (*SFNT, error) {
 	return parseSFNT(b, index, true)
 }
 
+// ParseCFF parses a bare CFF font program, such as the FontFile3 streams of subtype Type1C and CIDFontType0C embedded in PDFs. Only the CFF table is available in the returned font.
+func ParseCFF(b []byte) (*SFNT, error) {
+	sfnt := &SFNT{}
+	sfnt.Data = b
+	sfnt.Version = "OTTO"
+	sfnt.IsCFF = true
+	sfnt.Tables = map[string][]byte{"CFF ": b}
+	if err := sfnt.parseCFF(); err != nil {
+		return nil, err
+	}
+	sfnt.Maxp = &maxpTable{NumGlyphs: uint16(sfnt.CFF.charStrings.Len())}
+	return sfnt, nil
+}
+
 func parseSFNT(b []byte, index int, embedded bool) (*SFNT, error) {
 	if len(b) < 12 || uint(math.MaxUint32) < uint(len(b)) {
 		return nil, ErrInvalidFontData
@@ -316,7 +364,20 @@ func parseSFNT(b []byte, index int, embedded bool) (*SFNT, error) {
 	if embedded && sfnt.IsCFF {
 		if err := sfnt.parseCFF(); err != nil {
 			return nil, err
-		} else if err := sfnt.parseCmap(); err != nil {
+		}
+		if _, ok := tables["head"]; ok {
+			if err := sfnt.parseHead(); err != nil {
+				return nil, err
+			}
+		}
+		if _, ok := tables["maxp"]; ok {
+			if err := sfnt.parseMaxp(); err != nil {
+				return nil, err
+			}
+		} else {
+			sfnt.Maxp = &maxpTable{NumGlyphs: uint16(sfnt.CFF.charStrings.Len())}
+		}
+		if err := sfnt.parseCmap(); err != nil {
 			return nil, err
 		}
 		return sfnt, nil
@@ -348,14 +409,32 @@ func parseSFNT(b []byte, index int, embedded bool) (*SFNT, error) {
 			err = sfnt.parseCFF()
 		case "CFF2":
 			err = sfnt.parseCFF2()
+		case "CBLC":
+			err = sfnt.parseCBLC()
+		case "COLR":
+			err = sfnt.parseCOLR()
+		case "CPAL":
+			err = sfnt.parseCPAL()
+		case "HVAR":
+			err = sfnt.parseHVAR()
+		case "MATH":
+			err = sfnt.parseMATH()
+		case "MVAR":
+			err = sfnt.parseMVAR()
+		case "avar":
+			err = sfnt.parseAvar()
 		case "cmap":
 			err = sfnt.parseCmap()
+		case "fvar":
+			err = sfnt.parseFvar()
 		case "glyf":
 			err = sfnt.parseGlyf()
 		case "GPOS":
 			err = sfnt.parseGPOS()
 		case "GSUB":
 			err = sfnt.parseGSUB()
+		case "gvar":
+			err = sfnt.parseGvar()
 		case "hmtx":
 			err = sfnt.parseHmtx()
 		case "kern":
@@ -366,6 +445,10 @@ func parseSFNT(b []byte, index int, embedded bool) (*SFNT, error) {
 			err = sfnt.parseOS2()
 		case "post":
 			err = sfnt.parsePost()
+		case "sbix":
+			err = sfnt.parseSbix()
+		case "SVG ":
+			err = sfnt.parseSVG()
 		case "vhea":
 			err = sfnt.parseVhea()
 		case "vmtx":
@@ -378,6 +461,9 @@ func parseSFNT(b []byte, index int, embedded bool) (*SFNT, error) {
 	if sfnt.OS2 != nil && sfnt.OS2.Version <= 1 {
 		sfnt.estimateOS2()
 	}
+	if sfnt.Glyf != nil {
+		sfnt.Glyf.hinter = newTTHinter(sfnt)
+	}
 	return sfnt, nil
 }
 
@@ -409,6 +495,12 @@ func (subtable *cmapFormat0) ToUnicode(glyphID uint16) (rune, bool) {
 	return r, ok
 }
 
+func (subtable *cmapFormat0) ForEach(f func(rune, uint16)) {
+	for r, id := range subtable.GlyphIdArray {
+		f(rune(r), uint16(id))
+	}
+}
+
 type cmapFormat4 struct {
 	StartCode     []uint16
 	EndCode       []uint16
@@ -443,28 +535,39 @@ func (subtable *cmapFormat4) Get(r rune) (uint16, bool) {
 func (subtable *cmapFormat4) ToUnicode(glyphID uint16) (rune, bool) {
 	if subtable.UnicodeMap == nil {
 		subtable.UnicodeMap = map[uint16]rune{}
-		n := len(subtable.StartCode)
-		for i := 0; i < n; i++ {
-			for r := subtable.StartCode[i]; r < subtable.EndCode[i]; r++ {
-				var id uint16
-				if subtable.IdRangeOffset[i] == 0 {
-					// is modulo 65536 with the idDelta cast and addition overflow
-					id = uint16(subtable.IdDelta[i]) + r
-				} else {
-					// idRangeOffset/2  ->  offset value to index of words
-					// r-startCode  ->  difference of rune with startCode
-					// -(n-1)  ->  subtract offset from the current idRangeOffset item
-					index := int(subtable.IdRangeOffset[i]/2) + int(r-subtable.StartCode[i]) - (n - i)
-					id = subtable.GlyphIdArray[index]
-				}
-				subtable.UnicodeMap[id] = rune(r)
+		subtable.ForEach(func(r rune, id uint16) {
+			if _, ok := subtable.UnicodeMap[id]; !ok {
+				subtable.UnicodeMap[id] = r
 			}
-		}
+		})
 	}
 	r, ok := subtable.UnicodeMap[glyphID]
 	return r, ok
 }
 
+func (subtable *cmapFormat4) ForEach(f func(rune, uint16)) {
+	n := len(subtable.StartCode)
+	for i := 0; i < n; i++ {
+		for r := uint32(subtable.StartCode[i]); r <= uint32(subtable.EndCode[i]); r++ {
+			var id uint16
+			if subtable.IdRangeOffset[i] == 0 {
+				// is modulo 65536 with the idDelta cast and addition overflow
+				id = uint16(subtable.IdDelta[i]) + uint16(r)
+			} else {
+				// idRangeOffset/2  ->  offset value to index of words
+				// r-startCode  ->  difference of rune with startCode
+				// -(n-1)  ->  subtract offset from the current idRangeOffset item
+				index := int(subtable.IdRangeOffset[i]/2) + int(uint16(r)-subtable.StartCode[i]) - (n - i)
+				if index < 0 || len(subtable.GlyphIdArray) <= index {
+					continue
+				}
+				id = subtable.GlyphIdArray[index]
+			}
+			f(rune(r), id)
+		}
+	}
+}
+
 type cmapFormat6 struct {
 	FirstCode    uint16
 	GlyphIdArray []uint16
@@ -486,6 +589,12 @@ func (subtable *cmapFormat6) ToUnicode(glyphID uint16) (rune, bool) {
 	return 0, false
 }
 
+func (subtable *cmapFormat6) ForEach(f func(rune, uint16)) {
+	for i, id := range subtable.GlyphIdArray {
+		f(rune(subtable.FirstCode)+rune(i), id)
+	}
+}
+
 type cmapFormat12 struct {
 	StartCharCode []uint32
 	EndCharCode   []uint32
@@ -509,17 +618,25 @@ func (subtable *cmapFormat12) Get(r rune) (uint16, bool) {
 func (subtable *cmapFormat12) ToUnicode(glyphID uint16) (rune, bool) {
 	if subtable.UnicodeMap == nil {
 		subtable.UnicodeMap = map[uint16]rune{}
-		for i := 0; i < len(subtable.StartCharCode); i++ {
-			for r := subtable.StartCharCode[i]; r < subtable.EndCharCode[i]; r++ {
-				id := uint16((uint32(r) - subtable.StartCharCode[i]) + subtable.StartGlyphID[i])
-				subtable.UnicodeMap[id] = rune(r)
+		subtable.ForEach(func(r rune, id uint16) {
+			if _, ok := subtable.UnicodeMap[id]; !ok {
+				subtable.UnicodeMap[id] = r
 			}
-		}
+		})
 	}
 	r, ok := subtable.UnicodeMap[glyphID]
 	return r, ok
 }
 
+func (subtable *cmapFormat12) ForEach(f func(rune, uint16)) {
+	for i := 0; i < len(subtable.StartCharCode); i++ {
+		for r := subtable.StartCharCode[i]; r <= subtable.EndCharCode[i] && r <= 0x10FFFF; r++ {
+			id := uint16((r - subtable.StartCharCode[i]) + subtable.StartGlyphID[i])
+			f(rune(r), id)
+		}
+	}
+}
+
 type cmapEncodingRecord struct {
 	PlatformID uint16
 	EncodingID uint16
@@ -530,6 +647,7 @@ type cmapEncodingRecord struct {
 type cmapSubtable interface {
 	Get(rune) (uint16, bool)
 	ToUnicode(uint16) (rune, bool)
+	ForEach(func(rune, uint16))
 }
 
 type cmapTable struct {
@@ -546,6 +664,19 @@ func (cmap *cmapTable) Get(r rune) uint16 {
 	return 0
 }
 
+// Runes returns the glyph IDs of all runes that map to a glyph other than .notdef, where the first subtable mapping a rune takes precedence as for Get.
+func (cmap *cmapTable) Runes() map[rune]uint16 {
+	runes := map[rune]uint16{}
+	for _, subtable := range cmap.Subtables {
+		subtable.ForEach(func(r rune, glyphID uint16) {
+			if _, ok := runes[r]; !ok && glyphID != 0 {
+				runes[r] = glyphID
+			}
+		})
+	}
+	return runes
+}
+
 func (cmap *cmapTable) ToUnicode(glyphID uint16) rune {
 	for _, subtable := range cmap.Subtables {
 		if r, ok := subtable.ToUnicode(glyphID); ok {
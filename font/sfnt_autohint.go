@@ -0,0 +1,327 @@
+package font
+
+import (
+	"math"
+	"sort"
+)
+
+// glyphRecorder records a glyph outline so that it can be replayed with transformed coordinates.
+type glyphRecorder struct {
+	ops []byte // M, L, Q, C, or Z
+	pts [][2]float64
+}
+
+func (r *glyphRecorder) MoveTo(x, y float64) {
+	r.ops = append(r.ops, 'M')
+	r.pts = append(r.pts, [2]float64{x, y})
+}
+
+func (r *glyphRecorder) LineTo(x, y float64) {
+	r.ops = append(r.ops, 'L')
+	r.pts = append(r.pts, [2]float64{x, y})
+}
+
+func (r *glyphRecorder) QuadTo(cpx, cpy, x, y float64) {
+	r.ops = append(r.ops, 'Q')
+	r.pts = append(r.pts, [2]float64{cpx, cpy}, [2]float64{x, y})
+}
+
+func (r *glyphRecorder) CubeTo(cpx1, cpy1, cpx2, cpy2, x, y float64) {
+	r.ops = append(r.ops, 'C')
+	r.pts = append(r.pts, [2]float64{cpx1, cpy1}, [2]float64{cpx2, cpy2}, [2]float64{x, y})
+}
+
+func (r *glyphRecorder) Close() {
+	r.ops = append(r.ops, 'Z')
+}
+
+// replay draws the recorded outline to the pather, mapping the vertical coordinates by fy.
+func (r *glyphRecorder) replay(p Pather, x, y, f float64, fy func(float64) float64) {
+	i := 0
+	for _, op := range r.ops {
+		pts := r.pts[i:]
+		switch op {
+		case 'M':
+			p.MoveTo(x+f*pts[0][0], y+f*fy(pts[0][1]))
+			i++
+		case 'L':
+			p.LineTo(x+f*pts[0][0], y+f*fy(pts[0][1]))
+			i++
+		case 'Q':
+			p.QuadTo(x+f*pts[0][0], y+f*fy(pts[0][1]), x+f*pts[1][0], y+f*fy(pts[1][1]))
+			i += 2
+		case 'C':
+			p.CubeTo(x+f*pts[0][0], y+f*fy(pts[0][1]), x+f*pts[1][0], y+f*fy(pts[1][1]), x+f*pts[2][0], y+f*fy(pts[2][1]))
+			i += 3
+		case 'Z':
+			p.Close()
+		}
+	}
+}
+
+// area returns the signed area of the outline using its control points, which is positive for counter clockwise outlines.
+func (r *glyphRecorder) area() float64 {
+	area := 0.0
+	i := 0
+	var start, cur [2]float64
+	for _, op := range r.ops {
+		n := 0
+		switch op {
+		case 'M':
+			start, cur = r.pts[i], r.pts[i]
+			i++
+			continue
+		case 'L':
+			n = 1
+		case 'Q':
+			n = 2
+		case 'C':
+			n = 3
+		case 'Z':
+			area += cur[0]*start[1] - start[0]*cur[1]
+			cur = start
+			continue
+		}
+		for _, pt := range r.pts[i : i+n] {
+			area += cur[0]*pt[1] - pt[0]*cur[1]
+			cur = pt
+		}
+		i += n
+	}
+	return area / 2.0
+}
+
+// horizontalEdges returns the heights of horizontal segments and of vertical extrema, weighted by their horizontal extent. Edges are at the top of the filled area when they run in the direction given by topDir.
+func (r *glyphRecorder) horizontalEdges(fuzz float64) []autohintEdge {
+	// the filled area is left of the path for counter clockwise outlines, so that top edges run to the left
+	topDir := 1.0
+	if 0.0 < r.area() {
+		topDir = -1.0
+	}
+
+	edges := []autohintEdge{}
+	add := func(y, dx float64) {
+		edges = append(edges, autohintEdge{y: y, lo: y, hi: y, weight: math.Abs(dx), dir: topDir * dx})
+	}
+
+	i := 0
+	var start, cur [2]float64
+	for _, op := range r.ops {
+		pts := r.pts[i:]
+		switch op {
+		case 'M':
+			start, cur = pts[0], pts[0]
+			i++
+		case 'L':
+			if math.Abs(pts[0][1]-cur[1]) <= fuzz && fuzz < math.Abs(pts[0][0]-cur[0]) {
+				add((cur[1]+pts[0][1])/2.0, pts[0][0]-cur[0])
+			}
+			cur = pts[0]
+			i++
+		case 'Q':
+			if math.Abs(pts[0][1]-cur[1]) <= fuzz {
+				add(cur[1], pts[0][0]-cur[0])
+			}
+			if math.Abs(pts[0][1]-pts[1][1]) <= fuzz {
+				add(pts[1][1], pts[1][0]-pts[0][0])
+			}
+			cur = pts[1]
+			i += 2
+		case 'C':
+			if math.Abs(pts[0][1]-cur[1]) <= fuzz {
+				add(cur[1], pts[0][0]-cur[0])
+			}
+			if math.Abs(pts[1][1]-pts[2][1]) <= fuzz {
+				add(pts[2][1], pts[2][0]-pts[1][0])
+			}
+			cur = pts[2]
+			i += 3
+		case 'Z':
+			if math.Abs(start[1]-cur[1]) <= fuzz && fuzz < math.Abs(start[0]-cur[0]) {
+				add((cur[1]+start[1])/2.0, start[0]-cur[0])
+			}
+			cur = start
+		}
+	}
+	if len(edges) == 0 {
+		return edges
+	}
+
+	// merge edges at nearly the same height
+	sort.Slice(edges, func(i, j int) bool { return edges[i].y < edges[j].y })
+	merged := edges[:1]
+	for _, edge := range edges[1:] {
+		last := &merged[len(merged)-1]
+		if edge.y-last.hi <= fuzz {
+			if last.weight < edge.weight {
+				last.y = edge.y
+			}
+			last.hi = edge.y
+			last.weight += edge.weight
+			last.dir += edge.dir
+		} else {
+			merged = append(merged, edge)
+		}
+	}
+	return merged
+}
+
+type autohintEdge struct {
+	y, weight float64
+	lo, hi    float64 // range of the merged segments
+	dir       float64 // positive for edges at the top of the filled area
+	target    float64
+	fixed     bool
+}
+
+// blueZone is an alignment zone with a flat edge and an overshoot edge, such as the baseline or the x-height.
+type blueZone struct {
+	flat, overshoot float64
+}
+
+func (zone blueZone) isTop() bool {
+	return zone.flat < zone.overshoot
+}
+
+func (zone blueZone) contains(y, fuzz float64) bool {
+	lo, hi := zone.flat, zone.overshoot
+	if hi < lo {
+		lo, hi = hi, lo
+	}
+	return lo-fuzz <= y && y <= hi+fuzz
+}
+
+// blueZones returns the alignment zones of the font, which are taken from the CFF private DICT or estimated from the OS/2 table.
+func (sfnt *SFNT) blueZones(glyphID uint16) []blueZone {
+	zones := []blueZone{}
+	if sfnt.IsCFF {
+		if private, err := sfnt.CFF.fonts.GetPrivate(uint32(glyphID)); err == nil && 2 <= len(private.BlueValues) {
+			// values are delta encoded, the first pair is the baseline zone and the others are top zones
+			var v float64
+			for i := 0; i+1 < len(private.BlueValues); i += 2 {
+				lo := v + private.BlueValues[i]
+				hi := lo + private.BlueValues[i+1]
+				v = hi
+				if i == 0 {
+					zones = append(zones, blueZone{hi, lo})
+				} else {
+					zones = append(zones, blueZone{lo, hi})
+				}
+			}
+			// other blues are bottom zones
+			v = 0.0
+			for i := 0; i+1 < len(private.OtherBlues); i += 2 {
+				lo := v + private.OtherBlues[i]
+				hi := lo + private.OtherBlues[i+1]
+				v = hi
+				zones = append(zones, blueZone{hi, lo})
+			}
+			return zones
+		}
+	}
+
+	overshoot := 0.02 * float64(sfnt.Head.UnitsPerEm)
+	zones = append(zones, blueZone{0.0, -overshoot})
+	if sfnt.OS2 != nil {
+		if 0 < sfnt.OS2.SxHeight {
+			zones = append(zones, blueZone{float64(sfnt.OS2.SxHeight), float64(sfnt.OS2.SxHeight) + overshoot})
+		}
+		if 0 < sfnt.OS2.SCapHeight {
+			zones = append(zones, blueZone{float64(sfnt.OS2.SCapHeight), float64(sfnt.OS2.SCapHeight) + overshoot})
+		}
+	}
+	return zones
+}
+
+// autohint draws the glyph with its horizontal edges aligned to the pixel grid at the given ppem. Edges in alignment zones snap to the zone, suppressing overshoots smaller than half a pixel, and stems keep a width of at least one pixel. Other coordinates are interpolated between the edges, and horizontal coordinates are left unchanged.
+func (sfnt *SFNT) autohint(p Pather, glyphID, ppem uint16, x, y, f float64) error {
+	r := &glyphRecorder{}
+	if err := sfnt.GlyphPath(r, glyphID, 0, 0.0, 0.0, 1.0, NoHinting); err != nil {
+		return err
+	}
+
+	upem := float64(sfnt.Head.UnitsPerEm)
+	px := upem / float64(ppem) // pixel size in font units
+	snap := func(v float64) float64 {
+		return math.Round(v/px) * px
+	}
+
+	fuzz := upem / 200.0
+	edges := r.horizontalEdges(fuzz)
+	zones := sfnt.blueZones(glyphID)
+	for i := range edges {
+		for _, zone := range zones {
+			if zone.contains(edges[i].y, fuzz) && zone.isTop() == (0.0 < edges[i].dir) {
+				edges[i].fixed = true
+				if math.Abs(edges[i].y-zone.flat) < px/2.0 {
+					// anchor the edge at the flat part of its merged segments, if any
+					edges[i].y = math.Max(edges[i].lo, math.Min(zone.flat, edges[i].hi))
+					edges[i].target = snap(zone.flat)
+				} else {
+					edges[i].target = snap(edges[i].y)
+				}
+				break
+			}
+		}
+	}
+
+	// align stems to fixed edges, starting with the longest edges
+	maxStem := 0.25 * upem
+	order := make([]int, len(edges))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return edges[order[i]].weight > edges[order[j]].weight })
+	for _, i := range order {
+		if edges[i].fixed {
+			continue
+		}
+		ref := -1
+		for _, j := range []int{i - 1, i + 1} {
+			if 0 <= j && j < len(edges) && edges[j].fixed && math.Abs(edges[i].y-edges[j].y) <= maxStem {
+				if ref == -1 || math.Abs(edges[i].y-edges[j].y) < math.Abs(edges[i].y-edges[ref].y) {
+					ref = j
+				}
+			}
+		}
+		if ref == -1 {
+			edges[i].target = snap(edges[i].y)
+		} else {
+			width := math.Max(px, snap(math.Abs(edges[i].y-edges[ref].y)))
+			if edges[i].y < edges[ref].y {
+				width = -width
+			}
+			edges[i].target = edges[ref].target + width
+		}
+		edges[i].fixed = true
+	}
+
+	// keep the edges in order
+	for i := 1; i < len(edges); i++ {
+		if prev := edges[i-1].hi + edges[i-1].target - edges[i-1].y; edges[i].lo+edges[i].target-edges[i].y < prev {
+			edges[i].target = prev + edges[i].y - edges[i].lo
+		}
+	}
+
+	fy := func(v float64) float64 {
+		if len(edges) == 0 {
+			return v
+		} else if v <= edges[0].hi {
+			return v + edges[0].target - edges[0].y
+		} else if edges[len(edges)-1].lo <= v {
+			return v + edges[len(edges)-1].target - edges[len(edges)-1].y
+		}
+
+		// edges move as a whole, and coordinates in between are interpolated
+		i := sort.Search(len(edges), func(i int) bool { return v < edges[i].lo })
+		e0, e1 := edges[i-1], edges[i]
+		if v <= e0.hi {
+			return v + e0.target - e0.y
+		}
+		y0, y1 := e0.hi+e0.target-e0.y, e1.lo+e1.target-e1.y
+		t := (v - e0.hi) / (e1.lo - e0.hi)
+		return y0 + t*(y1-y0)
+	}
+	r.replay(p, x, y, f, fy)
+	return nil
+}
@@ -15,6 +15,12 @@ type cffTable struct {
 	charStrings *cffINDEX
 	globalSubrs *cffINDEX
 	fonts       *cffFontINDEX
+	vstore      *itemVariationStore // CFF2
+	coords      []float64           // normalized variation coordinates, nil for the default instance
+
+	strings  *cffINDEX
+	charset  []uint16        // SID or CID for each glyph ID
+	encoding map[byte]uint16 // character code to glyph ID for custom encodings
 }
 
 func (sfnt *SFNT) parseCFF() error {
@@ -78,7 +84,7 @@ func (sfnt *SFNT) parseCFF() error {
 		if len(b) < topDICT.PrivateOffset || len(b)-topDICT.PrivateOffset < topDICT.PrivateLength {
 			return fmt.Errorf("CFF: bad Private DICT offset")
 		}
-		privateDICT, err := parsePrivateDICT(b[topDICT.PrivateOffset:topDICT.PrivateOffset+topDICT.PrivateLength], false)
+		privateDICT, err := parsePrivateDICT(b[topDICT.PrivateOffset:topDICT.PrivateOffset+topDICT.PrivateLength], false, nil)
 		if err != nil {
 			return fmt.Errorf("CFF: Private DICT: %w", err)
 		}
@@ -97,6 +103,7 @@ func (sfnt *SFNT) parseCFF() error {
 
 		sfnt.CFF = &cffTable{
 			version:     1,
+			top:         topDICT,
 			charStrings: charStringsINDEX,
 			globalSubrs: globalSubrsINDEX,
 			fonts: &cffFontINDEX{
@@ -105,27 +112,134 @@ func (sfnt *SFNT) parseCFF() error {
 				first:           []uint32{0, uint32(charStringsINDEX.Len())},
 				fd:              []uint16{0},
 			},
+			strings: stringINDEX,
 		}
 	} else {
 		// CID font
-		fonts, err := parseFontINDEX(b, topDICT.FDArray, topDICT.FDSelect, charStringsINDEX.Len(), false)
+		fonts, err := parseFontINDEX(b, topDICT.FDArray, topDICT.FDSelect, charStringsINDEX.Len(), false, nil)
 		if err != nil {
 			return fmt.Errorf("CFF: %w", err)
 		}
 
 		sfnt.CFF = &cffTable{
 			version:     1,
+			top:         topDICT,
 			charStrings: charStringsINDEX,
 			globalSubrs: globalSubrsINDEX,
 			fonts:       fonts,
+			strings:     stringINDEX,
+		}
+	}
+
+	if sfnt.CFF.charset, err = parseCharset(b, topDICT.Charset, charStringsINDEX.Len()); err != nil {
+		return fmt.Errorf("CFF: %w", err)
+	}
+	if !topDICT.IsCID && 1 < topDICT.Encoding {
+		if sfnt.CFF.encoding, err = parseEncoding(b, topDICT.Encoding, sfnt.CFF.charset); err != nil {
+			return fmt.Errorf("CFF: %w", err)
 		}
 	}
 	return nil
 }
 
-func (sfnt *SFNT) parseCFF2() error {
-	return fmt.Errorf("CFF2: not supported")
+// parseCharset returns the SID (or CID for CID fonts) for each glyph ID. The predefined ISOAdobe charset maps each glyph ID to the equally numbered SID, the predefined Expert and ExpertSubset charsets are not supported.
+func parseCharset(b []byte, offset, nGlyphs int) ([]uint16, error) {
+	charset := make([]uint16, nGlyphs)
+	if offset == 0 {
+		for i := range charset {
+			charset[i] = uint16(i)
+		}
+		return charset, nil
+	} else if offset == 1 || offset == 2 {
+		return nil, nil
+	} else if len(b) <= offset {
+		return nil, fmt.Errorf("bad charset offset")
+	}
 
+	r := NewBinaryReader(b)
+	r.Seek(uint32(offset))
+	format := r.ReadUint8()
+	if format == 0 {
+		for i := 1; i < nGlyphs; i++ {
+			charset[i] = r.ReadUint16()
+		}
+	} else if format == 1 || format == 2 {
+		for i := 1; i < nGlyphs; {
+			first := r.ReadUint16()
+			var nLeft int
+			if format == 1 {
+				nLeft = int(r.ReadUint8())
+			} else {
+				nLeft = int(r.ReadUint16())
+			}
+			if r.EOF() {
+				break
+			}
+			for j := 0; j <= nLeft && i < nGlyphs; j++ {
+				charset[i] = first + uint16(j)
+				i++
+			}
+		}
+	} else {
+		return nil, fmt.Errorf("bad charset format")
+	}
+	if r.EOF() {
+		return nil, fmt.Errorf("bad charset")
+	}
+	return charset, nil
+}
+
+// parseEncoding returns the mapping from character codes to glyph IDs for custom encodings.
+func parseEncoding(b []byte, offset int, charset []uint16) (map[byte]uint16, error) {
+	if len(b) <= offset {
+		return nil, fmt.Errorf("bad encoding offset")
+	}
+
+	encoding := map[byte]uint16{}
+	r := NewBinaryReader(b)
+	r.Seek(uint32(offset))
+	format := r.ReadUint8()
+	switch format & 0x7F {
+	case 0:
+		nCodes := int(r.ReadUint8())
+		for i := 1; i <= nCodes; i++ {
+			encoding[r.ReadUint8()] = uint16(i)
+		}
+	case 1:
+		nRanges := int(r.ReadUint8())
+		glyphID := uint16(1)
+		for i := 0; i < nRanges; i++ {
+			first := int(r.ReadUint8())
+			nLeft := int(r.ReadUint8())
+			for j := 0; j <= nLeft && first+j < 256; j++ {
+				encoding[byte(first+j)] = glyphID
+				glyphID++
+			}
+		}
+	default:
+		return nil, fmt.Errorf("bad encoding format")
+	}
+	if format&0x80 != 0 {
+		// supplements
+		nSups := int(r.ReadUint8())
+		for i := 0; i < nSups; i++ {
+			code := r.ReadUint8()
+			sid := r.ReadUint16()
+			for glyphID, glyphSID := range charset {
+				if glyphSID == sid {
+					encoding[code] = uint16(glyphID)
+					break
+				}
+			}
+		}
+	}
+	if r.EOF() {
+		return nil, fmt.Errorf("bad encoding")
+	}
+	return encoding, nil
+}
+
+func (sfnt *SFNT) parseCFF2() error {
 	b, ok := sfnt.Tables["CFF2"]
 	if !ok {
 		return fmt.Errorf("CFF2: missing table")
@@ -159,16 +273,33 @@ func (sfnt *SFNT) parseCFF2() error {
 		return fmt.Errorf("CFF2: CharStrings INDEX: %w", err)
 	}
 
-	fonts, err := parseFontINDEX(b, topDICT.FDArray, topDICT.FDSelect, charStringsINDEX.Len(), true)
+	var vstore *itemVariationStore
+	if topDICT.Vstore != 0 {
+		if len(b)-2 < topDICT.Vstore {
+			return fmt.Errorf("CFF2: bad VariationStore offset")
+		}
+		r.Seek(uint32(topDICT.Vstore))
+		length := r.ReadUint16()
+		if r.Len() < uint32(length) {
+			return fmt.Errorf("CFF2: bad VariationStore")
+		}
+		if vstore, err = parseItemVariationStore(r.ReadBytes(uint32(length))); err != nil {
+			return fmt.Errorf("CFF2: VariationStore: %w", err)
+		}
+	}
+
+	fonts, err := parseFontINDEX(b, topDICT.FDArray, topDICT.FDSelect, charStringsINDEX.Len(), true, vstore)
 	if err != nil {
 		return fmt.Errorf("CFF2: %w", err)
 	}
 
 	sfnt.CFF = &cffTable{
 		version:     2,
+		top:         topDICT,
 		charStrings: charStringsINDEX,
 		globalSubrs: globalSubrsINDEX,
 		fonts:       fonts,
+		vstore:      vstore,
 	}
 	return nil
 }
@@ -181,6 +312,53 @@ func (cff *cffTable) TopDICT() *cffTopDICT {
 	return cff.top
 }
 
+// GlyphName returns the name of the glyph for non-CID fonts.
+func (cff *cffTable) GlyphName(glyphID uint16) string {
+	if cff.top == nil || cff.top.IsCID || len(cff.charset) <= int(glyphID) || cff.strings == nil {
+		return ""
+	}
+	return cff.strings.GetSID(int(cff.charset[glyphID]))
+}
+
+// GlyphIndexByName returns the glyph ID for the glyph with the given name for non-CID fonts.
+func (cff *cffTable) GlyphIndexByName(name string) (uint16, bool) {
+	if cff.top == nil || cff.top.IsCID || cff.strings == nil {
+		return 0, false
+	}
+	for glyphID, sid := range cff.charset {
+		if cff.strings.GetSID(int(sid)) == name {
+			return uint16(glyphID), true
+		}
+	}
+	return 0, false
+}
+
+// GlyphIndexByCID returns the glyph ID for the given CID for CID fonts. For non-CID fonts the CID equals the glyph ID.
+func (cff *cffTable) GlyphIndexByCID(cid uint16) (uint16, bool) {
+	if cff.top == nil || !cff.top.IsCID {
+		return cid, int(cid) < cff.charStrings.Len()
+	}
+	for glyphID, glyphCID := range cff.charset {
+		if glyphCID == cid {
+			return uint16(glyphID), true
+		}
+	}
+	return 0, false
+}
+
+// FontMatrix returns the matrix that maps glyph space to text space, which usually is a scaling by 0.001.
+func (cff *cffTable) FontMatrix() [6]float64 {
+	if cff.top == nil {
+		return [6]float64{0.001, 0.0, 0.0, 0.001, 0.0, 0.0}
+	}
+	return cff.top.FontMatrix
+}
+
+// Encoding returns the custom encoding of the font that maps character codes to glyph IDs, or nil if the font uses the standard or expert encoding.
+func (cff *cffTable) Encoding() map[byte]uint16 {
+	return cff.encoding
+}
+
 func (cff *cffTable) PrivateDICT(glyphID uint16) (*cffPrivateDICT, error) {
 	return cff.fonts.GetPrivate(uint32(glyphID))
 }
@@ -202,6 +380,12 @@ func (cff *cffTable) ToPath(p Pather, glyphID, ppem uint16, x0, y0, f float64, h
 	if err != nil {
 		return fmt.Errorf("%v: %w", table, err)
 	}
+	privateDICT, err := cff.fonts.GetPrivate(uint32(glyphID))
+	if err != nil {
+		return fmt.Errorf("%v: %w", table, err)
+	}
+	vsindex := privateDICT.Vsindex
+	var scalars []float64 // region scalars for blend
 
 	// x,y are raise to most-significant 16 bits and treat less-significant bits as fraction
 	var x, y int32
@@ -639,14 +823,41 @@ func (cff *cffTable) ToPath(p Pather, glyphID, ppem uint16, x0, y0, f float64, h
 				// blend
 				if cff.version == 1 {
 					return fmt.Errorf("CFF: unsupported operator %d", b0)
+				} else if len(stack) == 0 {
+					return errBadNumOperands
+				} else if cff.vstore == nil || len(cff.vstore.data) <= vsindex {
+					return fmt.Errorf("CFF2: bad vsindex %d", vsindex)
+				}
+				if scalars == nil {
+					scalars = cff.vstore.Scalars(uint16(vsindex), cff.coords)
+				}
+
+				// the operands are n default values, followed by k deltas for each of them, and n
+				n, k := int(stack[len(stack)-1]>>16), len(scalars)
+				stack = stack[:len(stack)-1]
+				if n < 0 || len(stack) < n*(k+1) {
+					return errBadNumOperands
+				}
+				i0 := len(stack) - n*(k+1)
+				deltas := stack[i0+n:]
+				for i := 0; i < n; i++ {
+					v := float64(stack[i0+i])
+					for j := 0; j < k; j++ {
+						v += scalars[j] * float64(deltas[i*k+j])
+					}
+					stack[i0+i] = int32(math.Round(v))
 				}
-				// TODO: blend
+				stack = stack[:i0+n]
 			case 15:
 				// vsindex
 				if cff.version == 1 {
 					return fmt.Errorf("CFF: unsupported operator %d", b0)
+				} else if len(stack) != 1 {
+					return errBadNumOperands
 				}
-				// TODO: vsindex
+				vsindex = int(stack[0] >> 16)
+				scalars = nil
+				stack = stack[:0]
 			default:
 				if 256 <= b0 {
 					return fmt.Errorf("%v: unsupported operator 12 %d", table, b0-256)
@@ -658,6 +869,7 @@ func (cff *cffTable) ToPath(p Pather, glyphID, ppem uint16, x0, y0, f float64, h
 	if cff.version == 1 {
 		return fmt.Errorf("CFF: charstring must end with endchar operator")
 	}
+	p.Close()
 	return nil
 }
 
@@ -811,7 +1023,6 @@ type cffPrivateDICT struct {
 
 	// CFF2
 	Vsindex int
-	Blend   []float64
 }
 
 func parseTopDICT(b []byte, stringINDEX *cffINDEX) (*cffTopDICT, error) {
@@ -822,7 +1033,7 @@ func parseTopDICT(b []byte, stringINDEX *cffINDEX) (*cffTopDICT, error) {
 		FontMatrix:         [6]float64{0.001, 0.0, 0.0, 0.001, 0.0, 0.0},
 		CIDCount:           8720,
 	}
-	return dict, parseDICT(b, false, func(b0 int, is []int, fs []float64) bool {
+	return dict, parseDICT(b, false, nil, func(b0 int, is []int, fs []float64) bool {
 		switch b0 {
 		case 0:
 			dict.Version = stringINDEX.GetSID(is[0])
@@ -907,7 +1118,7 @@ func parseTopDICT(b []byte, stringINDEX *cffINDEX) (*cffTopDICT, error) {
 
 func parseFontDICT(b []byte, isCFF2 bool) (*cffFontDICT, error) {
 	dict := &cffFontDICT{}
-	return dict, parseDICT(b, isCFF2, func(b0 int, is []int, fs []float64) bool {
+	return dict, parseDICT(b, isCFF2, nil, func(b0 int, is []int, fs []float64) bool {
 		switch b0 {
 		case 18:
 			dict.PrivateOffset = is[1]
@@ -923,7 +1134,7 @@ func parseFontDICT(b []byte, isCFF2 bool) (*cffFontDICT, error) {
 	})
 }
 
-func parsePrivateDICT(b []byte, isCFF2 bool) (*cffPrivateDICT, error) {
+func parsePrivateDICT(b []byte, isCFF2 bool, vstore *itemVariationStore) (*cffPrivateDICT, error) {
 	dict := &cffPrivateDICT{
 		BlueScale:       0.039625,
 		BlueShift:       7.0,
@@ -931,7 +1142,7 @@ func parsePrivateDICT(b []byte, isCFF2 bool) (*cffPrivateDICT, error) {
 		ExpansionFactor: 0.06,
 	}
 
-	return dict, parseDICT(b, isCFF2, func(b0 int, is []int, fs []float64) bool {
+	return dict, parseDICT(b, isCFF2, vstore, func(b0 int, is []int, fs []float64) bool {
 		switch b0 {
 		case 6:
 			dict.BlueValues = fs
@@ -971,8 +1182,6 @@ func parsePrivateDICT(b []byte, isCFF2 bool) (*cffPrivateDICT, error) {
 			dict.NominalWidthX = fs[0]
 		case 22:
 			dict.Vsindex = is[0]
-		case 23:
-			dict.Blend = fs
 		default:
 			return false
 		}
@@ -984,7 +1193,7 @@ func parseTopDICT2(b []byte) (*cffTopDICT, error) {
 	dict := &cffTopDICT{
 		FontMatrix: [6]float64{0.001, 0.0, 0.0, 0.001, 0.0, 0.0},
 	}
-	return dict, parseDICT(b, true, func(b0 int, is []int, fs []float64) bool {
+	return dict, parseDICT(b, true, nil, func(b0 int, is []int, fs []float64) bool {
 		switch b0 {
 		case 256 + 7:
 			copy(dict.FontMatrix[:], fs)
@@ -1003,7 +1212,8 @@ func parseTopDICT2(b []byte) (*cffTopDICT, error) {
 	})
 }
 
-func parseDICT(b []byte, isCFF2 bool, callback func(b0 int, is []int, fs []float64) bool) error {
+// parseDICT parses a DICT and calls the callback for each operator with its operands. For CFF2, the blend operator is resolved to the values of the default instance using the number of regions from the variation store.
+func parseDICT(b []byte, isCFF2 bool, vstore *itemVariationStore, callback func(b0 int, is []int, fs []float64) bool) error {
 	opSize := map[int]int{
 		256 + 7:  6,
 		5:        4,
@@ -1022,9 +1232,23 @@ func parseDICT(b []byte, isCFF2 bool, callback func(b0 int, is []int, fs []float
 	r := NewBinaryReader(b)
 	ints := []int{}
 	reals := []float64{}
+	vsindex := 0
 	for 0 < r.Len() {
 		b0 := int(r.ReadUint8())
-		if b0 < 22 {
+		if isCFF2 && b0 == 23 {
+			// blend
+			if len(ints) == 0 {
+				return fmt.Errorf("too few operands for operator")
+			} else if vstore == nil || len(vstore.data) <= vsindex {
+				return fmt.Errorf("bad vsindex %d", vsindex)
+			}
+			n, k := ints[len(ints)-1], len(vstore.data[vsindex].regionIndices)
+			if n < 0 || len(ints)-1 < n*(k+1) {
+				return fmt.Errorf("too few operands for operator")
+			}
+			ints = ints[:len(ints)-1-n*k]
+			reals = reals[:len(reals)-1-n*k]
+		} else if b0 < 22 || isCFF2 && (b0 == 22 || b0 == 24) {
 			// operator
 			if b0 == 12 {
 				b0 = 256 + int(r.ReadUint8())
@@ -1042,10 +1266,14 @@ func parseDICT(b []byte, isCFF2 bool, callback func(b0 int, is []int, fs []float
 				return fmt.Errorf("too few operands for operator")
 			}
 
-			is := ints[len(ints)-size:]
-			fs := reals[len(reals)-size:]
+			// copy operands as the callback may keep them while the stack is reused
+			is := append([]int{}, ints[len(ints)-size:]...)
+			fs := append([]float64{}, reals[len(reals)-size:]...)
 			ints = ints[:len(ints)-size]
 			reals = reals[:len(reals)-size]
+			if isCFF2 && b0 == 22 {
+				vsindex = is[0]
+			}
 
 			if ok := callback(b0, is, fs); !ok {
 				return fmt.Errorf("bad operator")
@@ -1155,14 +1383,14 @@ func (t *cffFontINDEX) GetLocalSubrs(glyphID uint32) (*cffINDEX, error) {
 	return t.localSubrsINDEX[i], nil
 }
 
-func parseFontINDEX(b []byte, fdArray, fdSelect, nGlyphs int, isCFF2 bool) (*cffFontINDEX, error) {
+func parseFontINDEX(b []byte, fdArray, fdSelect, nGlyphs int, isCFF2 bool, vstore *itemVariationStore) (*cffFontINDEX, error) {
 	if len(b) < fdArray {
 		return nil, fmt.Errorf("bad Font INDEX offset")
 	}
 
 	r := NewBinaryReader(b)
 	r.Seek(uint32(fdArray))
-	fontINDEX, err := parseINDEX(r, false)
+	fontINDEX, err := parseINDEX(r, isCFF2)
 	if err != nil {
 		return nil, fmt.Errorf("Font INDEX: %w", err)
 	}
@@ -1178,7 +1406,7 @@ func parseFontINDEX(b []byte, fdArray, fdSelect, nGlyphs int, isCFF2 bool) (*cff
 		if len(b) < fontDICT.PrivateOffset || len(b)-fontDICT.PrivateOffset < fontDICT.PrivateLength {
 			return nil, fmt.Errorf("Font DICT: bad Private DICT offset")
 		}
-		privateDICT, err := parsePrivateDICT(b[fontDICT.PrivateOffset:fontDICT.PrivateOffset+fontDICT.PrivateLength], isCFF2)
+		privateDICT, err := parsePrivateDICT(b[fontDICT.PrivateOffset:fontDICT.PrivateOffset+fontDICT.PrivateLength], isCFF2, vstore)
 		if err != nil {
 			return nil, fmt.Errorf("Private DICT: %w", err)
 		}
@@ -1198,6 +1426,16 @@ func parseFontINDEX(b []byte, fdArray, fdSelect, nGlyphs int, isCFF2 bool) (*cff
 		}
 	}
 
+	if isCFF2 && fdSelect == 0 {
+		// FDSelect is optional for a single Font DICT
+		if len(fonts.privateDICT) != 1 {
+			return nil, fmt.Errorf("FDSelect: missing")
+		}
+		fonts.first = []uint32{0, uint32(nGlyphs)}
+		fonts.fd = []uint16{0}
+		return fonts, nil
+	}
+
 	r.Seek(uint32(fdSelect))
 	format := r.ReadUint8()
 	if format == 0 {
@@ -0,0 +1,1104 @@
+package font
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"sort"
+)
+
+// ForegroundPaletteIndex is the palette index that refers to the foreground color of the text instead of to a palette entry.
+const ForegroundPaletteIndex = 0xFFFF
+
+// maxPaintDepth is the maximum nesting depth of paint tables, which protects against cycles in malformed fonts.
+const maxPaintDepth = 64
+
+// Extend specifies how a color line continues outside of its first and last color stop.
+type Extend uint8
+
+// see Extend
+const (
+	ExtendPad Extend = iota
+	ExtendRepeat
+	ExtendReflect
+)
+
+// CompositeMode specifies how the source paint is composited onto the backdrop paint, being either a Porter-Duff compositing operator or a blend mode.
+type CompositeMode uint8
+
+// see CompositeMode
+const (
+	CompositeClear CompositeMode = iota
+	CompositeSrc
+	CompositeDest
+	CompositeSrcOver
+	CompositeDestOver
+	CompositeSrcIn
+	CompositeDestIn
+	CompositeSrcOut
+	CompositeDestOut
+	CompositeSrcAtop
+	CompositeDestAtop
+	CompositeXor
+	CompositePlus
+	CompositeScreen
+	CompositeOverlay
+	CompositeDarken
+	CompositeLighten
+	CompositeColorDodge
+	CompositeColorBurn
+	CompositeHardLight
+	CompositeSoftLight
+	CompositeDifference
+	CompositeExclusion
+	CompositeMultiply
+	CompositeHue
+	CompositeSaturation
+	CompositeColor
+	CompositeLuminosity
+)
+
+// Paint is a node of the paint graph of a color glyph from the COLR table, which is one of PaintLayers, PaintSolid, PaintLinearGradient, PaintRadialGradient, PaintSweepGradient, PaintGlyph, PaintColrGlyph, PaintTransform, or PaintComposite. Coordinates are in font units and variations are not applied.
+type Paint interface{}
+
+// PaintLayers paints its layers on top of each other, from bottom to top.
+type PaintLayers struct {
+	Layers []Paint
+}
+
+// PaintSolid fills with a palette color, where the alpha is multiplied with the alpha of the color.
+type PaintSolid struct {
+	PaletteIndex uint16
+	Alpha        float64
+}
+
+// ColorStop is a color of a color line at an offset that is usually between 0 and 1.
+type ColorStop struct {
+	Offset       float64
+	PaletteIndex uint16
+	Alpha        float64
+}
+
+// ColorLine is the list of color stops of a gradient.
+type ColorLine struct {
+	Extend
+	Stops []ColorStop
+}
+
+// PaintLinearGradient fills with a linear gradient from P0 to P1, where the gradient is rotated such that its color is constant along lines parallel to P0P2.
+type PaintLinearGradient struct {
+	ColorLine
+	X0, Y0, X1, Y1, X2, Y2 float64
+}
+
+// PaintRadialGradient fills with a gradient between the circle at (X0,Y0) with radius R0 and the circle at (X1,Y1) with radius R1.
+type PaintRadialGradient struct {
+	ColorLine
+	X0, Y0, R0, X1, Y1, R1 float64
+}
+
+// PaintSweepGradient fills with a gradient that sweeps counter clockwise around the center from the start angle to the end angle, in degrees.
+type PaintSweepGradient struct {
+	ColorLine
+	CenterX, CenterY     float64
+	StartAngle, EndAngle float64
+}
+
+// PaintGlyph fills the outline of a glyph with a paint.
+type PaintGlyph struct {
+	GlyphID uint16
+	Paint
+}
+
+// PaintColrGlyph paints the color glyph with the given glyph ID.
+type PaintColrGlyph struct {
+	GlyphID uint16
+}
+
+// PaintTransform paints using an affine transformation, where x' = XX*x + XY*y + DX and y' = YX*x + YY*y + DY. All translations, scalings, rotations and skews are converted to a PaintTransform.
+type PaintTransform struct {
+	XX, YX, XY, YY, DX, DY float64
+	Paint
+}
+
+// PaintComposite composites the source paint onto the backdrop paint.
+type PaintComposite struct {
+	Source   Paint
+	Mode     CompositeMode
+	Backdrop Paint
+}
+
+// GlyphBitmap is the color bitmap of a glyph from the sbix or CBDT table. Left and Bottom are the position of the bottom-left corner of the image relative to the glyph origin in pixels, and PPEM is the number of pixels per em of the bitmap.
+type GlyphBitmap struct {
+	Format       string // png, jpg, or tiff
+	Data         []byte
+	PPEM         uint16
+	Left, Bottom float64
+}
+
+// NumPalettes returns the number of color palettes in the CPAL table.
+func (sfnt *SFNT) NumPalettes() int {
+	if sfnt.Cpal == nil {
+		return 0
+	}
+	return len(sfnt.Cpal.Palettes)
+}
+
+// Palette returns the colors of a palette from the CPAL table, or nil if it does not exist.
+func (sfnt *SFNT) Palette(palette int) []color.NRGBA {
+	if sfnt.Cpal == nil || palette < 0 || len(sfnt.Cpal.Palettes) <= palette {
+		return nil
+	}
+	return sfnt.Cpal.Palettes[palette]
+}
+
+// IsColorGlyph returns true if the glyph has a color representation in the COLR, sbix, CBDT, or SVG table.
+func (sfnt *SFNT) IsColorGlyph(glyphID uint16) bool {
+	if sfnt.Colr != nil && sfnt.Colr.has(glyphID) {
+		return true
+	} else if sfnt.Sbix != nil && sfnt.Sbix.has(glyphID) {
+		return true
+	} else if sfnt.Cblc != nil && sfnt.Cblc.has(glyphID) {
+		return true
+	} else if sfnt.Svg != nil && sfnt.Svg.has(glyphID) {
+		return true
+	}
+	return false
+}
+
+// GlyphPaint returns the paint graph of a color glyph from the COLR table, or nil if the glyph has none. Layered glyphs of COLR version 0 are returned as a PaintLayers of PaintGlyph with PaintSolid.
+func (sfnt *SFNT) GlyphPaint(glyphID uint16) Paint {
+	if sfnt.Colr == nil {
+		return nil
+	}
+	return sfnt.Colr.Paint(glyphID)
+}
+
+// GlyphClipBox returns the clip box (xmin,ymin,xmax,ymax) of a color glyph from the COLR table, and false if it has none.
+func (sfnt *SFNT) GlyphClipBox(glyphID uint16) (int16, int16, int16, int16, bool) {
+	if sfnt.Colr == nil {
+		return 0, 0, 0, 0, false
+	}
+	return sfnt.Colr.ClipBox(glyphID)
+}
+
+// GlyphBitmap returns the color bitmap of a glyph from the sbix or CBDT table that is the most suitable for the given pixels per em, which is the smallest bitmap that is at least as large or otherwise the largest bitmap. It returns nil if the glyph has no bitmap.
+func (sfnt *SFNT) GlyphBitmap(glyphID, ppem uint16) *GlyphBitmap {
+	if sfnt.Sbix != nil {
+		if bitmap := sfnt.Sbix.Get(glyphID, ppem); bitmap != nil {
+			return bitmap
+		}
+	}
+	if sfnt.Cblc != nil {
+		return sfnt.Cblc.Get(sfnt.Tables["CBDT"], glyphID, ppem)
+	}
+	return nil
+}
+
+// GlyphSVG returns the (decompressed) SVG document of the SVG table that contains the glyph, or nil if the glyph has none. The glyph is the element with ID "glyph" followed by the glyph ID, and its coordinates are in font units with the y-axis pointing down.
+func (sfnt *SFNT) GlyphSVG(glyphID uint16) ([]byte, error) {
+	if sfnt.Svg == nil {
+		return nil, nil
+	}
+	return sfnt.Svg.Get(glyphID)
+}
+
+////////////////////////////////////////////////////////////////
+
+type cpalTable struct {
+	Palettes [][]color.NRGBA
+}
+
+func (sfnt *SFNT) parseCPAL() error {
+	b, ok := sfnt.Tables["CPAL"]
+	if !ok {
+		return fmt.Errorf("CPAL: missing table")
+	} else if len(b) < 12 {
+		return fmt.Errorf("CPAL: bad table")
+	}
+
+	r := NewBinaryReader(b)
+	version := r.ReadUint16()
+	if 1 < version {
+		return fmt.Errorf("CPAL: bad version %d", version)
+	}
+	numPaletteEntries := r.ReadUint16()
+	numPalettes := r.ReadUint16()
+	numColorRecords := r.ReadUint16()
+	colorRecordsArrayOffset := r.ReadUint32()
+	if uint32(len(b)) < colorRecordsArrayOffset || uint32(len(b))-colorRecordsArrayOffset < 4*uint32(numColorRecords) {
+		return fmt.Errorf("CPAL: bad color records")
+	}
+
+	sfnt.Cpal = &cpalTable{
+		Palettes: make([][]color.NRGBA, numPalettes),
+	}
+	for i := range sfnt.Cpal.Palettes {
+		first := uint32(r.ReadUint16())
+		if r.EOF() || uint32(numColorRecords) < first+uint32(numPaletteEntries) {
+			return fmt.Errorf("CPAL: bad palette %d", i)
+		}
+		palette := make([]color.NRGBA, numPaletteEntries)
+		for j := range palette {
+			c := b[colorRecordsArrayOffset+4*(first+uint32(j)):]
+			palette[j] = color.NRGBA{c[2], c[1], c[0], c[3]} // BGRA
+		}
+		sfnt.Cpal.Palettes[i] = palette
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////
+
+type colrBaseGlyph struct {
+	GlyphID    uint16
+	FirstLayer uint16
+	NumLayers  uint16
+}
+
+type colrLayer struct {
+	GlyphID      uint16
+	PaletteIndex uint16
+}
+
+type colrBaseGlyphPaint struct {
+	GlyphID uint16
+	Offset  uint32 // from the start of the table
+}
+
+type colrClip struct {
+	StartGlyphID, EndGlyphID uint16
+	XMin, YMin, XMax, YMax   int16
+}
+
+type colrTable struct {
+	data []byte
+
+	// version 0
+	BaseGlyphs []colrBaseGlyph
+	Layers     []colrLayer
+
+	// version 1
+	BaseGlyphPaints []colrBaseGlyphPaint
+	LayerPaints     []uint32 // offsets from the start of the table
+	Clips           []colrClip
+}
+
+func (colr *colrTable) has(glyphID uint16) bool {
+	i := sort.Search(len(colr.BaseGlyphPaints), func(i int) bool { return glyphID <= colr.BaseGlyphPaints[i].GlyphID })
+	if i < len(colr.BaseGlyphPaints) && colr.BaseGlyphPaints[i].GlyphID == glyphID {
+		return true
+	}
+	j := sort.Search(len(colr.BaseGlyphs), func(i int) bool { return glyphID <= colr.BaseGlyphs[i].GlyphID })
+	return j < len(colr.BaseGlyphs) && colr.BaseGlyphs[j].GlyphID == glyphID
+}
+
+// Paint returns the paint graph of a base glyph, where the paint of version 1 takes precedence over the layers of version 0.
+func (colr *colrTable) Paint(glyphID uint16) Paint {
+	i := sort.Search(len(colr.BaseGlyphPaints), func(i int) bool { return glyphID <= colr.BaseGlyphPaints[i].GlyphID })
+	if i < len(colr.BaseGlyphPaints) && colr.BaseGlyphPaints[i].GlyphID == glyphID {
+		return colr.parsePaint(colr.BaseGlyphPaints[i].Offset, 0)
+	}
+
+	j := sort.Search(len(colr.BaseGlyphs), func(i int) bool { return glyphID <= colr.BaseGlyphs[i].GlyphID })
+	if j < len(colr.BaseGlyphs) && colr.BaseGlyphs[j].GlyphID == glyphID {
+		base := colr.BaseGlyphs[j]
+		paint := PaintLayers{}
+		for k := uint32(base.FirstLayer); k < uint32(base.FirstLayer)+uint32(base.NumLayers) && k < uint32(len(colr.Layers)); k++ {
+			layer := colr.Layers[k]
+			paint.Layers = append(paint.Layers, PaintGlyph{
+				GlyphID: layer.GlyphID,
+				Paint:   PaintSolid{PaletteIndex: layer.PaletteIndex, Alpha: 1.0},
+			})
+		}
+		return paint
+	}
+	return nil
+}
+
+// ClipBox returns the clip box of a base glyph of version 1.
+func (colr *colrTable) ClipBox(glyphID uint16) (int16, int16, int16, int16, bool) {
+	i := sort.Search(len(colr.Clips), func(i int) bool { return glyphID <= colr.Clips[i].EndGlyphID })
+	if i < len(colr.Clips) && colr.Clips[i].StartGlyphID <= glyphID {
+		clip := colr.Clips[i]
+		return clip.XMin, clip.YMin, clip.XMax, clip.YMax, true
+	}
+	return 0, 0, 0, 0, false
+}
+
+func readUint24(r *BinaryReader) uint32 {
+	return uint32(r.ReadUint8())<<16 | uint32(r.ReadUint16())
+}
+
+func readF2Dot14(r *BinaryReader) float64 {
+	return float64(r.ReadInt16()) / (1 << 14)
+}
+
+func readFixed(r *BinaryReader) float64 {
+	return float64(r.ReadInt32()) / (1 << 16)
+}
+
+// parseColorLine parses a (variable) color line at the given offset from the start of the table.
+func (colr *colrTable) parseColorLine(offset uint32, variable bool) (ColorLine, bool) {
+	r := NewBinaryReader(colr.data)
+	r.Seek(offset)
+	colorLine := ColorLine{}
+	colorLine.Extend = Extend(r.ReadUint8())
+	if ExtendReflect < colorLine.Extend {
+		colorLine.Extend = ExtendPad
+	}
+	numStops := r.ReadUint16()
+	size := uint32(6)
+	if variable {
+		size += 4
+	}
+	if r.EOF() || r.Len() < uint32(numStops)*size {
+		return ColorLine{}, false
+	}
+	colorLine.Stops = make([]ColorStop, numStops)
+	for i := range colorLine.Stops {
+		colorLine.Stops[i].Offset = readF2Dot14(r)
+		colorLine.Stops[i].PaletteIndex = r.ReadUint16()
+		colorLine.Stops[i].Alpha = readF2Dot14(r)
+		if variable {
+			_ = r.ReadUint32() // varIndexBase
+		}
+	}
+	sort.SliceStable(colorLine.Stops, func(i, j int) bool {
+		return colorLine.Stops[i].Offset < colorLine.Stops[j].Offset
+	})
+	return colorLine, true
+}
+
+// parsePaint parses the paint table at the given offset from the start of the table. It returns nil for malformed or unknown paint tables. Variable paint tables are parsed using their default values.
+func (colr *colrTable) parsePaint(offset uint32, depth int) Paint {
+	if maxPaintDepth < depth {
+		return nil
+	}
+
+	r := NewBinaryReader(colr.data)
+	r.Seek(offset)
+	format := r.ReadUint8()
+	variable := 3 <= format && format <= 31 && format%2 == 1 && format != 11 // odd formats from 3 to 31 except PaintColrGlyph are variable versions
+	if variable {
+		format-- // parse as the non-variable version, the varIndexBase follows the fields
+	}
+
+	// child returns the paint at the Offset24 read from the reader
+	child := func() Paint {
+		childOffset := readUint24(r)
+		if r.EOF() || childOffset == 0 {
+			return nil
+		}
+		return colr.parsePaint(offset+childOffset, depth+1)
+	}
+	// transform returns the paint transformed by T(cx,cy) * m * T(-cx,-cy)
+	transform := func(paint Paint, xx, yx, xy, yy, cx, cy float64) Paint {
+		if paint == nil || r.EOF() {
+			return nil
+		}
+		return PaintTransform{
+			XX: xx, YX: yx, XY: xy, YY: yy,
+			DX:    cx - xx*cx - xy*cy,
+			DY:    cy - yx*cx - yy*cy,
+			Paint: paint,
+		}
+	}
+
+	switch format {
+	case 1: // PaintColrLayers
+		numLayers := uint32(r.ReadUint8())
+		firstLayerIndex := r.ReadUint32()
+		if r.EOF() || uint32(len(colr.LayerPaints)) < firstLayerIndex || uint32(len(colr.LayerPaints))-firstLayerIndex < numLayers {
+			return nil
+		}
+		paint := PaintLayers{}
+		for _, layerOffset := range colr.LayerPaints[firstLayerIndex : firstLayerIndex+numLayers] {
+			if layer := colr.parsePaint(layerOffset, depth+1); layer != nil {
+				paint.Layers = append(paint.Layers, layer)
+			}
+		}
+		return paint
+	case 2: // PaintSolid
+		paint := PaintSolid{}
+		paint.PaletteIndex = r.ReadUint16()
+		paint.Alpha = readF2Dot14(r)
+		if r.EOF() {
+			return nil
+		}
+		return paint
+	case 4: // PaintLinearGradient
+		colorLineOffset := readUint24(r)
+		paint := PaintLinearGradient{}
+		paint.X0 = float64(r.ReadInt16())
+		paint.Y0 = float64(r.ReadInt16())
+		paint.X1 = float64(r.ReadInt16())
+		paint.Y1 = float64(r.ReadInt16())
+		paint.X2 = float64(r.ReadInt16())
+		paint.Y2 = float64(r.ReadInt16())
+		var ok bool
+		if paint.ColorLine, ok = colr.parseColorLine(offset+colorLineOffset, variable); !ok || r.EOF() {
+			return nil
+		}
+		return paint
+	case 6: // PaintRadialGradient
+		colorLineOffset := readUint24(r)
+		paint := PaintRadialGradient{}
+		paint.X0 = float64(r.ReadInt16())
+		paint.Y0 = float64(r.ReadInt16())
+		paint.R0 = float64(r.ReadUint16())
+		paint.X1 = float64(r.ReadInt16())
+		paint.Y1 = float64(r.ReadInt16())
+		paint.R1 = float64(r.ReadUint16())
+		var ok bool
+		if paint.ColorLine, ok = colr.parseColorLine(offset+colorLineOffset, variable); !ok || r.EOF() {
+			return nil
+		}
+		return paint
+	case 8: // PaintSweepGradient
+		colorLineOffset := readUint24(r)
+		paint := PaintSweepGradient{}
+		paint.CenterX = float64(r.ReadInt16())
+		paint.CenterY = float64(r.ReadInt16())
+		paint.StartAngle = (readF2Dot14(r) + 1.0) * 180.0
+		paint.EndAngle = (readF2Dot14(r) + 1.0) * 180.0
+		var ok bool
+		if paint.ColorLine, ok = colr.parseColorLine(offset+colorLineOffset, variable); !ok || r.EOF() {
+			return nil
+		}
+		return paint
+	case 10: // PaintGlyph
+		paint := child()
+		glyphID := r.ReadUint16()
+		if paint == nil || r.EOF() {
+			return nil
+		}
+		return PaintGlyph{GlyphID: glyphID, Paint: paint}
+	case 11: // PaintColrGlyph
+		glyphID := r.ReadUint16()
+		if r.EOF() {
+			return nil
+		}
+		return PaintColrGlyph{GlyphID: glyphID}
+	case 12: // PaintTransform
+		paint := child()
+		transformOffset := readUint24(r)
+		t := NewBinaryReader(colr.data)
+		t.Seek(offset + transformOffset)
+		xx, yx, xy, yy := readFixed(t), readFixed(t), readFixed(t), readFixed(t)
+		dx, dy := readFixed(t), readFixed(t)
+		if paint == nil || r.EOF() || t.EOF() {
+			return nil
+		}
+		return PaintTransform{XX: xx, YX: yx, XY: xy, YY: yy, DX: dx, DY: dy, Paint: paint}
+	case 14: // PaintTranslate
+		paint := child()
+		dx := float64(r.ReadInt16())
+		dy := float64(r.ReadInt16())
+		if paint == nil || r.EOF() {
+			return nil
+		}
+		return PaintTransform{XX: 1.0, YY: 1.0, DX: dx, DY: dy, Paint: paint}
+	case 16, 18: // PaintScale, PaintScaleAroundCenter
+		paint := child()
+		sx, sy := readF2Dot14(r), readF2Dot14(r)
+		cx, cy := 0.0, 0.0
+		if format == 18 {
+			cx, cy = float64(r.ReadInt16()), float64(r.ReadInt16())
+		}
+		return transform(paint, sx, 0.0, 0.0, sy, cx, cy)
+	case 20, 22: // PaintScaleUniform, PaintScaleUniformAroundCenter
+		paint := child()
+		s := readF2Dot14(r)
+		cx, cy := 0.0, 0.0
+		if format == 22 {
+			cx, cy = float64(r.ReadInt16()), float64(r.ReadInt16())
+		}
+		return transform(paint, s, 0.0, 0.0, s, cx, cy)
+	case 24, 26: // PaintRotate, PaintRotateAroundCenter
+		paint := child()
+		angle := readF2Dot14(r) * math.Pi // counter clockwise
+		cx, cy := 0.0, 0.0
+		if format == 26 {
+			cx, cy = float64(r.ReadInt16()), float64(r.ReadInt16())
+		}
+		sin, cos := math.Sincos(angle)
+		return transform(paint, cos, sin, -sin, cos, cx, cy)
+	case 28, 30: // PaintSkew, PaintSkewAroundCenter
+		paint := child()
+		xSkew := readF2Dot14(r) * math.Pi // counter clockwise
+		ySkew := readF2Dot14(r) * math.Pi
+		cx, cy := 0.0, 0.0
+		if format == 30 {
+			cx, cy = float64(r.ReadInt16()), float64(r.ReadInt16())
+		}
+		return transform(paint, 1.0, math.Tan(ySkew), -math.Tan(xSkew), 1.0, cx, cy)
+	case 32: // PaintComposite
+		source := child()
+		mode := CompositeMode(r.ReadUint8())
+		backdrop := child()
+		if source == nil || backdrop == nil || r.EOF() || CompositeLuminosity < mode {
+			return nil
+		}
+		return PaintComposite{Source: source, Mode: mode, Backdrop: backdrop}
+	}
+	return nil
+}
+
+func (sfnt *SFNT) parseCOLR() error {
+	b, ok := sfnt.Tables["COLR"]
+	if !ok {
+		return fmt.Errorf("COLR: missing table")
+	} else if len(b) < 14 {
+		return fmt.Errorf("COLR: bad table")
+	}
+
+	r := NewBinaryReader(b)
+	version := r.ReadUint16()
+	if 1 < version {
+		return fmt.Errorf("COLR: bad version %d", version)
+	} else if version == 1 && len(b) < 34 {
+		return fmt.Errorf("COLR: bad table")
+	}
+	numBaseGlyphRecords := r.ReadUint16()
+	baseGlyphRecordsOffset := r.ReadUint32()
+	layerRecordsOffset := r.ReadUint32()
+	numLayerRecords := r.ReadUint16()
+
+	sfnt.Colr = &colrTable{
+		data: b,
+	}
+	if 0 < numBaseGlyphRecords {
+		if uint32(len(b)) < baseGlyphRecordsOffset || uint32(len(b))-baseGlyphRecordsOffset < 6*uint32(numBaseGlyphRecords) {
+			return fmt.Errorf("COLR: bad base glyph records")
+		}
+		sfnt.Colr.BaseGlyphs = make([]colrBaseGlyph, numBaseGlyphRecords)
+		s := NewBinaryReader(b[baseGlyphRecordsOffset:])
+		for i := range sfnt.Colr.BaseGlyphs {
+			sfnt.Colr.BaseGlyphs[i].GlyphID = s.ReadUint16()
+			sfnt.Colr.BaseGlyphs[i].FirstLayer = s.ReadUint16()
+			sfnt.Colr.BaseGlyphs[i].NumLayers = s.ReadUint16()
+			if 0 < i && sfnt.Colr.BaseGlyphs[i].GlyphID <= sfnt.Colr.BaseGlyphs[i-1].GlyphID {
+				return fmt.Errorf("COLR: base glyph records not sorted")
+			}
+		}
+	}
+	if 0 < numLayerRecords {
+		if uint32(len(b)) < layerRecordsOffset || uint32(len(b))-layerRecordsOffset < 4*uint32(numLayerRecords) {
+			return fmt.Errorf("COLR: bad layer records")
+		}
+		sfnt.Colr.Layers = make([]colrLayer, numLayerRecords)
+		s := NewBinaryReader(b[layerRecordsOffset:])
+		for i := range sfnt.Colr.Layers {
+			sfnt.Colr.Layers[i].GlyphID = s.ReadUint16()
+			sfnt.Colr.Layers[i].PaletteIndex = s.ReadUint16()
+		}
+	}
+	if version == 0 {
+		return nil
+	}
+
+	baseGlyphListOffset := r.ReadUint32()
+	layerListOffset := r.ReadUint32()
+	clipListOffset := r.ReadUint32()
+	_ = r.ReadUint32() // varIndexMapOffset
+	_ = r.ReadUint32() // itemVariationStoreOffset
+
+	if baseGlyphListOffset != 0 {
+		r.Seek(baseGlyphListOffset)
+		numRecords := r.ReadUint32()
+		if r.EOF() || r.Len()/6 < numRecords {
+			return fmt.Errorf("COLR: bad base glyph list")
+		}
+		sfnt.Colr.BaseGlyphPaints = make([]colrBaseGlyphPaint, numRecords)
+		for i := range sfnt.Colr.BaseGlyphPaints {
+			sfnt.Colr.BaseGlyphPaints[i].GlyphID = r.ReadUint16()
+			sfnt.Colr.BaseGlyphPaints[i].Offset = baseGlyphListOffset + r.ReadUint32()
+			if 0 < i && sfnt.Colr.BaseGlyphPaints[i].GlyphID <= sfnt.Colr.BaseGlyphPaints[i-1].GlyphID {
+				return fmt.Errorf("COLR: base glyph list not sorted")
+			}
+		}
+	}
+	if layerListOffset != 0 {
+		r.Seek(layerListOffset)
+		numLayers := r.ReadUint32()
+		if r.EOF() || r.Len()/4 < numLayers {
+			return fmt.Errorf("COLR: bad layer list")
+		}
+		sfnt.Colr.LayerPaints = make([]uint32, numLayers)
+		for i := range sfnt.Colr.LayerPaints {
+			sfnt.Colr.LayerPaints[i] = layerListOffset + r.ReadUint32()
+		}
+	}
+	if clipListOffset != 0 {
+		r.Seek(clipListOffset)
+		format := r.ReadUint8()
+		numClips := r.ReadUint32()
+		if r.EOF() || format != 1 || r.Len()/7 < numClips {
+			return fmt.Errorf("COLR: bad clip list")
+		}
+		sfnt.Colr.Clips = make([]colrClip, 0, numClips)
+		for i := 0; i < int(numClips); i++ {
+			clip := colrClip{}
+			clip.StartGlyphID = r.ReadUint16()
+			clip.EndGlyphID = r.ReadUint16()
+			clipBoxOffset := readUint24(r)
+
+			s := NewBinaryReader(b)
+			s.Seek(clipListOffset + clipBoxOffset)
+			clipBoxFormat := s.ReadUint8()
+			clip.XMin = s.ReadInt16()
+			clip.YMin = s.ReadInt16()
+			clip.XMax = s.ReadInt16()
+			clip.YMax = s.ReadInt16()
+			if s.EOF() || clipBoxFormat != 1 && clipBoxFormat != 2 || clip.EndGlyphID < clip.StartGlyphID {
+				return fmt.Errorf("COLR: bad clip box %d", i)
+			} else if 0 < i && clip.StartGlyphID <= sfnt.Colr.Clips[i-1].EndGlyphID {
+				return fmt.Errorf("COLR: clip list not sorted")
+			}
+			sfnt.Colr.Clips = append(sfnt.Colr.Clips, clip)
+		}
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////
+
+type sbixStrike struct {
+	PPEM    uint16
+	offsets []uint32 // glyph data offsets from the start of the table
+}
+
+type sbixTable struct {
+	data    []byte
+	Strikes []sbixStrike // sorted by PPEM
+}
+
+func (sbix *sbixTable) has(glyphID uint16) bool {
+	for _, strike := range sbix.Strikes {
+		if int(glyphID)+1 < len(strike.offsets) && strike.offsets[glyphID] < strike.offsets[glyphID+1] {
+			return true
+		}
+	}
+	return false
+}
+
+// Get returns the bitmap of the smallest strike with at least the given PPEM, or of the largest strike.
+func (sbix *sbixTable) Get(glyphID, ppem uint16) *GlyphBitmap {
+	i := sort.Search(len(sbix.Strikes), func(i int) bool { return ppem <= sbix.Strikes[i].PPEM })
+	order := make([]int, 0, len(sbix.Strikes))
+	for j := i; j < len(sbix.Strikes); j++ {
+		order = append(order, j)
+	}
+	for j := i - 1; 0 <= j; j-- {
+		order = append(order, j)
+	}
+
+	for _, j := range order {
+		strike := sbix.Strikes[j]
+		id := glyphID
+		for k := 0; k < 8; k++ { // follow a limited number of duplicates
+			if len(strike.offsets) <= int(id)+1 || strike.offsets[id+1] <= strike.offsets[id] {
+				break
+			}
+			b := sbix.data[strike.offsets[id]:strike.offsets[id+1]]
+			if len(b) < 8 {
+				break
+			}
+			r := NewBinaryReader(b)
+			originOffsetX := r.ReadInt16()
+			originOffsetY := r.ReadInt16()
+			graphicType := r.ReadString(4)
+			data := b[8:]
+			if graphicType == "dupe" {
+				if len(data) < 2 {
+					break
+				}
+				id = uint16(data[0])<<8 | uint16(data[1])
+				continue
+			}
+
+			format := ""
+			switch graphicType {
+			case "png ":
+				format = "png"
+			case "jpg ":
+				format = "jpg"
+			case "tiff":
+				format = "tiff"
+			}
+			if format == "" {
+				break
+			}
+			return &GlyphBitmap{
+				Format: format,
+				Data:   data,
+				PPEM:   strike.PPEM,
+				Left:   float64(originOffsetX),
+				Bottom: float64(originOffsetY),
+			}
+		}
+	}
+	return nil
+}
+
+func (sfnt *SFNT) parseSbix() error {
+	b, ok := sfnt.Tables["sbix"]
+	if !ok {
+		return fmt.Errorf("sbix: missing table")
+	} else if len(b) < 8 {
+		return fmt.Errorf("sbix: bad table")
+	}
+
+	r := NewBinaryReader(b)
+	version := r.ReadUint16()
+	if version != 1 {
+		return fmt.Errorf("sbix: bad version %d", version)
+	}
+	_ = r.ReadUint16() // flags
+	numStrikes := r.ReadUint32()
+	if r.Len()/4 < numStrikes {
+		return fmt.Errorf("sbix: bad table")
+	}
+
+	numGlyphs := uint32(sfnt.Maxp.NumGlyphs)
+	sfnt.Sbix = &sbixTable{
+		data:    b,
+		Strikes: make([]sbixStrike, numStrikes),
+	}
+	for i := range sfnt.Sbix.Strikes {
+		strikeOffset := r.ReadUint32()
+		s := NewBinaryReader(b)
+		s.Seek(strikeOffset)
+		strike := sbixStrike{}
+		strike.PPEM = s.ReadUint16()
+		_ = s.ReadUint16() // ppi
+		if s.EOF() || s.Len()/4 < numGlyphs+1 {
+			return fmt.Errorf("sbix: bad strike %d", i)
+		}
+		strike.offsets = make([]uint32, numGlyphs+1)
+		for j := range strike.offsets {
+			strike.offsets[j] = strikeOffset + s.ReadUint32()
+			if uint32(len(b)) < strike.offsets[j] || 0 < j && strike.offsets[j] < strike.offsets[j-1] {
+				return fmt.Errorf("sbix: bad glyph data offset in strike %d", i)
+			}
+		}
+		sfnt.Sbix.Strikes[i] = strike
+	}
+	sort.SliceStable(sfnt.Sbix.Strikes, func(i, j int) bool {
+		return sfnt.Sbix.Strikes[i].PPEM < sfnt.Sbix.Strikes[j].PPEM
+	})
+	return nil
+}
+
+////////////////////////////////////////////////////////////////
+
+type cblcIndexSubtable struct {
+	FirstGlyphID, LastGlyphID uint16
+	offset                    uint32 // from the start of the table
+}
+
+type cblcStrike struct {
+	PPEM       uint16
+	Subtables  []cblcIndexSubtable
+	start, end uint16
+}
+
+type cblcTable struct {
+	data    []byte
+	Strikes []cblcStrike // sorted by PPEM
+}
+
+func (cblc *cblcTable) has(glyphID uint16) bool {
+	for _, strike := range cblc.Strikes {
+		if strike.start <= glyphID && glyphID <= strike.end {
+			for _, subtable := range strike.Subtables {
+				if subtable.FirstGlyphID <= glyphID && glyphID <= subtable.LastGlyphID {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Get returns the bitmap from the CBDT table of the smallest strike with at least the given PPEM, or of the largest strike. Only PNG bitmaps (image formats 17, 18, and 19) are supported.
+func (cblc *cblcTable) Get(cbdt []byte, glyphID, ppem uint16) *GlyphBitmap {
+	i := sort.Search(len(cblc.Strikes), func(i int) bool { return ppem <= cblc.Strikes[i].PPEM })
+	order := make([]int, 0, len(cblc.Strikes))
+	for j := i; j < len(cblc.Strikes); j++ {
+		order = append(order, j)
+	}
+	for j := i - 1; 0 <= j; j-- {
+		order = append(order, j)
+	}
+
+	for _, j := range order {
+		strike := cblc.Strikes[j]
+		for _, subtable := range strike.Subtables {
+			if glyphID < subtable.FirstGlyphID || subtable.LastGlyphID < glyphID {
+				continue
+			}
+			if bitmap := cblc.get(cbdt, subtable, glyphID); bitmap != nil {
+				bitmap.PPEM = strike.PPEM
+				return bitmap
+			}
+		}
+	}
+	return nil
+}
+
+func (cblc *cblcTable) get(cbdt []byte, subtable cblcIndexSubtable, glyphID uint16) *GlyphBitmap {
+	r := NewBinaryReader(cblc.data)
+	r.Seek(subtable.offset)
+	indexFormat := r.ReadUint16()
+	imageFormat := r.ReadUint16()
+	imageDataOffset := r.ReadUint32()
+	index := uint32(glyphID - subtable.FirstGlyphID)
+
+	var offset, length uint32
+	var bigMetrics []byte
+	switch indexFormat {
+	case 1, 3:
+		size := uint32(4)
+		if indexFormat == 3 {
+			size = 2
+		}
+		r.Seek(r.Pos() + index*size)
+		var start, end uint32
+		if indexFormat == 1 {
+			start, end = r.ReadUint32(), r.ReadUint32()
+		} else {
+			start, end = uint32(r.ReadUint16()), uint32(r.ReadUint16())
+		}
+		if end < start {
+			return nil
+		}
+		offset, length = imageDataOffset+start, end-start
+	case 2:
+		imageSize := r.ReadUint32()
+		bigMetrics = r.ReadBytes(8)
+		offset, length = imageDataOffset+index*imageSize, imageSize
+	case 4:
+		numGlyphs := r.ReadUint32()
+		found := false
+		for k := uint32(0); k < numGlyphs && !r.EOF(); k++ {
+			id := r.ReadUint16()
+			start := uint32(r.ReadUint16())
+			if id == glyphID {
+				_ = r.ReadUint16()
+				end := uint32(r.ReadUint16())
+				if end < start {
+					return nil
+				}
+				offset, length = imageDataOffset+start, end-start
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil
+		}
+	case 5:
+		imageSize := r.ReadUint32()
+		bigMetrics = r.ReadBytes(8)
+		numGlyphs := r.ReadUint32()
+		found := false
+		for k := uint32(0); k < numGlyphs && !r.EOF(); k++ {
+			if r.ReadUint16() == glyphID {
+				offset, length = imageDataOffset+k*imageSize, imageSize
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil
+		}
+	default:
+		return nil
+	}
+	if r.EOF() || uint32(len(cbdt)) < offset || uint32(len(cbdt))-offset < length {
+		return nil
+	}
+
+	s := NewBinaryReader(cbdt[offset : offset+length])
+	var height, bearingX, bearingY float64
+	switch imageFormat {
+	case 17: // smallGlyphMetrics
+		height = float64(s.ReadUint8())
+		_ = s.ReadUint8() // width
+		bearingX = float64(s.ReadInt8())
+		bearingY = float64(s.ReadInt8())
+		_ = s.ReadUint8() // advance
+	case 18: // bigGlyphMetrics
+		bigMetrics = s.ReadBytes(8)
+		fallthrough
+	case 19: // metrics are in the index subtable
+		if len(bigMetrics) != 8 {
+			return nil
+		}
+		height = float64(bigMetrics[0])
+		bearingX = float64(int8(bigMetrics[2]))
+		bearingY = float64(int8(bigMetrics[3]))
+	default:
+		return nil
+	}
+	dataLen := s.ReadUint32()
+	data := s.ReadBytes(dataLen)
+	if s.EOF() {
+		return nil
+	}
+	return &GlyphBitmap{
+		Format: "png",
+		Data:   data,
+		Left:   bearingX,
+		Bottom: bearingY - height,
+	}
+}
+
+func (sfnt *SFNT) parseCBLC() error {
+	b, ok := sfnt.Tables["CBLC"]
+	if !ok {
+		return fmt.Errorf("CBLC: missing table")
+	} else if _, ok := sfnt.Tables["CBDT"]; !ok {
+		return fmt.Errorf("CBDT: missing table")
+	} else if len(b) < 8 {
+		return fmt.Errorf("CBLC: bad table")
+	}
+
+	r := NewBinaryReader(b)
+	majorVersion := r.ReadUint16()
+	_ = r.ReadUint16() // minorVersion
+	if majorVersion != 2 && majorVersion != 3 {
+		return fmt.Errorf("CBLC: bad version %d", majorVersion)
+	}
+	numSizes := r.ReadUint32()
+	if r.Len()/48 < numSizes {
+		return fmt.Errorf("CBLC: bad table")
+	}
+
+	sfnt.Cblc = &cblcTable{
+		data:    b,
+		Strikes: make([]cblcStrike, numSizes),
+	}
+	for i := range sfnt.Cblc.Strikes {
+		indexSubTableArrayOffset := r.ReadUint32()
+		_ = r.ReadUint32() // indexTablesSize
+		numberOfIndexSubTables := r.ReadUint32()
+		_ = r.ReadUint32()  // colorRef
+		_ = r.ReadBytes(24) // hori and vert sbitLineMetrics
+		strike := cblcStrike{}
+		strike.start = r.ReadUint16()
+		strike.end = r.ReadUint16()
+		strike.PPEM = uint16(r.ReadUint8())
+		_ = r.ReadUint8() // ppemY
+		_ = r.ReadUint8() // bitDepth
+		_ = r.ReadInt8()  // flags
+
+		s := NewBinaryReader(b)
+		s.Seek(indexSubTableArrayOffset)
+		if s.EOF() || s.Len()/8 < numberOfIndexSubTables {
+			return fmt.Errorf("CBLC: bad index subtable array %d", i)
+		}
+		strike.Subtables = make([]cblcIndexSubtable, numberOfIndexSubTables)
+		for j := range strike.Subtables {
+			strike.Subtables[j].FirstGlyphID = s.ReadUint16()
+			strike.Subtables[j].LastGlyphID = s.ReadUint16()
+			strike.Subtables[j].offset = indexSubTableArrayOffset + s.ReadUint32()
+		}
+		sfnt.Cblc.Strikes[i] = strike
+	}
+	sort.SliceStable(sfnt.Cblc.Strikes, func(i, j int) bool {
+		return sfnt.Cblc.Strikes[i].PPEM < sfnt.Cblc.Strikes[j].PPEM
+	})
+	return nil
+}
+
+////////////////////////////////////////////////////////////////
+
+type svgDocument struct {
+	StartGlyphID, EndGlyphID uint16
+	offset, length           uint32 // from the start of the table
+}
+
+type svgTable struct {
+	data      []byte
+	Documents []svgDocument
+}
+
+func (svg *svgTable) index(glyphID uint16) int {
+	i := sort.Search(len(svg.Documents), func(i int) bool { return glyphID <= svg.Documents[i].EndGlyphID })
+	if i < len(svg.Documents) && svg.Documents[i].StartGlyphID <= glyphID {
+		return i
+	}
+	return -1
+}
+
+func (svg *svgTable) has(glyphID uint16) bool {
+	return svg.index(glyphID) != -1
+}
+
+// Get returns the SVG document that contains the glyph, decompressing it if it is gzip encoded.
+func (svg *svgTable) Get(glyphID uint16) ([]byte, error) {
+	i := svg.index(glyphID)
+	if i == -1 {
+		return nil, nil
+	}
+	doc := svg.Documents[i]
+	b := svg.data[doc.offset : doc.offset+doc.length]
+	if 2 <= len(b) && b[0] == 0x1F && b[1] == 0x8B {
+		r, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, fmt.Errorf("SVG: %w", err)
+		}
+		if b, err = io.ReadAll(io.LimitReader(r, int64(MaxMemory)+1)); err != nil {
+			return nil, fmt.Errorf("SVG: %w", err)
+		} else if uint32(len(b)) > MaxMemory {
+			return nil, ErrExceedsMemory
+		}
+	}
+	return b, nil
+}
+
+func (sfnt *SFNT) parseSVG() error {
+	b, ok := sfnt.Tables["SVG "]
+	if !ok {
+		return fmt.Errorf("SVG: missing table")
+	} else if len(b) < 10 {
+		return fmt.Errorf("SVG: bad table")
+	}
+
+	r := NewBinaryReader(b)
+	version := r.ReadUint16()
+	if version != 0 {
+		return fmt.Errorf("SVG: bad version %d", version)
+	}
+	svgDocumentListOffset := r.ReadUint32()
+	r.Seek(svgDocumentListOffset)
+	numEntries := r.ReadUint16()
+	if r.EOF() || r.Len()/12 < uint32(numEntries) {
+		return fmt.Errorf("SVG: bad document list")
+	}
+
+	sfnt.Svg = &svgTable{
+		data:      b,
+		Documents: make([]svgDocument, numEntries),
+	}
+	for i := range sfnt.Svg.Documents {
+		doc := svgDocument{}
+		doc.StartGlyphID = r.ReadUint16()
+		doc.EndGlyphID = r.ReadUint16()
+		doc.offset = svgDocumentListOffset + r.ReadUint32()
+		doc.length = r.ReadUint32()
+		if doc.EndGlyphID < doc.StartGlyphID || uint32(len(b)) < doc.offset || uint32(len(b))-doc.offset < doc.length {
+			return fmt.Errorf("SVG: bad document record %d", i)
+		} else if 0 < i && doc.StartGlyphID <= sfnt.Svg.Documents[i-1].EndGlyphID {
+			return fmt.Errorf("SVG: document records not sorted")
+		}
+		sfnt.Svg.Documents[i] = doc
+	}
+	return nil
+}
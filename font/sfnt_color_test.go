@@ -0,0 +1,251 @@
+package font
+
+import (
+	"bytes"
+	"compress/gzip"
+	"image"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/tdewolff/test"
+)
+
+func writeUint24(w *BinaryWriter, v uint32) {
+	w.WriteUint8(uint8(v >> 16))
+	w.WriteUint16(uint16(v))
+}
+
+func colorTestFont(t *testing.T) *SFNT {
+	b, err := ioutil.ReadFile("../resources/DejaVuSerif.ttf")
+	test.Error(t, err)
+	sfnt, err := ParseSFNT(b, 0)
+	test.Error(t, err)
+	A, B, C := sfnt.GlyphIndex('A'), sfnt.GlyphIndex('B'), sfnt.GlyphIndex('C')
+	D, E, O := sfnt.GlyphIndex('D'), sfnt.GlyphIndex('E'), sfnt.GlyphIndex('O')
+	F, G, H := sfnt.GlyphIndex('F'), sfnt.GlyphIndex('G'), sfnt.GlyphIndex('H')
+
+	// two palettes with two colors each
+	w := NewBinaryWriter([]byte{})
+	w.WriteUint16(0)  // version
+	w.WriteUint16(2)  // numPaletteEntries
+	w.WriteUint16(2)  // numPalettes
+	w.WriteUint16(4)  // numColorRecords
+	w.WriteUint32(16) // colorRecordsArrayOffset
+	w.WriteUint16(0)  // colorRecordIndices
+	w.WriteUint16(2)
+	w.WriteBytes([]byte{0, 0, 255, 255, 255, 0, 0, 128, 0, 255, 0, 255, 0, 255, 255, 255}) // BGRA
+	sfnt.Tables["CPAL"] = w.Bytes()
+
+	// A has layers B and C, D is a glyph with a linear gradient, and E is a composite of a glyph and the rotated color glyph A
+	w = NewBinaryWriter([]byte{})
+	w.WriteUint16(1)   // version
+	w.WriteUint16(1)   // numBaseGlyphRecords
+	w.WriteUint32(34)  // baseGlyphRecordsOffset
+	w.WriteUint32(40)  // layerRecordsOffset
+	w.WriteUint16(2)   // numLayerRecords
+	w.WriteUint32(48)  // baseGlyphListOffset
+	w.WriteUint32(0)   // layerListOffset
+	w.WriteUint32(133) // clipListOffset
+	w.WriteUint32(0)   // varIndexMapOffset
+	w.WriteUint32(0)   // itemVariationStoreOffset
+	w.WriteUint16(A)   // base glyph record
+	w.WriteUint16(0)
+	w.WriteUint16(2)
+	w.WriteUint16(B) // layer records
+	w.WriteUint16(0)
+	w.WriteUint16(C)
+	w.WriteUint16(1)
+	w.WriteUint32(2) // base glyph list
+	w.WriteUint16(D)
+	w.WriteUint32(16)
+	w.WriteUint16(E)
+	w.WriteUint32(53)
+	w.WriteUint8(10) // 64: PaintGlyph
+	writeUint24(w, 6)
+	w.WriteUint16(O)
+	w.WriteUint8(4) // 70: PaintLinearGradient
+	writeUint24(w, 16)
+	for _, v := range []int16{0, 0, 1000, 0, 0, 1000} {
+		w.WriteInt16(v)
+	}
+	w.WriteUint8(uint8(ExtendReflect)) // 86: ColorLine
+	w.WriteUint16(2)
+	w.WriteInt16(1 << 14) // out of order
+	w.WriteUint16(1)
+	w.WriteInt16(1 << 14)
+	w.WriteInt16(0)
+	w.WriteUint16(0)
+	w.WriteInt16(1 << 13)
+	w.WriteUint8(32) // 101: PaintComposite
+	writeUint24(w, 8)
+	w.WriteUint8(uint8(CompositeMultiply))
+	writeUint24(w, 19)
+	w.WriteUint8(10) // 109: PaintGlyph
+	writeUint24(w, 6)
+	w.WriteUint16(O)
+	w.WriteUint8(2) // 115: PaintSolid
+	w.WriteUint16(ForegroundPaletteIndex)
+	w.WriteInt16(1 << 14)
+	w.WriteUint8(26) // 120: PaintRotateAroundCenter
+	writeUint24(w, 10)
+	w.WriteInt16(1 << 13) // 90 degrees
+	w.WriteInt16(100)
+	w.WriteInt16(200)
+	w.WriteUint8(11) // 130: PaintColrGlyph
+	w.WriteUint16(A)
+	w.WriteUint8(1) // 133: ClipList
+	w.WriteUint32(1)
+	w.WriteUint16(D)
+	w.WriteUint16(E)
+	writeUint24(w, 12)
+	w.WriteUint8(1) // 145: ClipBox
+	for _, v := range []int16{-100, -200, 1100, 1500} {
+		w.WriteInt16(v)
+	}
+	test.T(t, w.Len(), uint32(154))
+	sfnt.Tables["COLR"] = w.Bytes()
+
+	// F has a PNG bitmap in a strike of 20 pixels per em
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.NRGBA{255, 0, 0, 255})
+	pngBuf := &bytes.Buffer{}
+	test.Error(t, png.Encode(pngBuf, img))
+
+	numGlyphs := uint32(sfnt.Maxp.NumGlyphs)
+	w = NewBinaryWriter([]byte{})
+	w.WriteUint16(1)  // version
+	w.WriteUint16(1)  // flags
+	w.WriteUint32(1)  // numStrikes
+	w.WriteUint32(12) // strikeOffset
+	w.WriteUint16(20) // ppem
+	w.WriteUint16(72) // ppi
+	dataOffset := 4 + 4*(numGlyphs+1)
+	for id := uint32(0); id <= numGlyphs; id++ {
+		if uint32(F) < id {
+			w.WriteUint32(dataOffset + 8 + uint32(pngBuf.Len()))
+		} else {
+			w.WriteUint32(dataOffset)
+		}
+	}
+	w.WriteInt16(-2) // originOffsetX
+	w.WriteInt16(-3) // originOffsetY
+	w.WriteString("png ")
+	w.WriteBytes(pngBuf.Bytes())
+	sfnt.Tables["sbix"] = w.Bytes()
+
+	// G has an SVG document and H a compressed SVG document
+	doc := []byte(`<svg xmlns="http://www.w3.org/2000/svg"><rect id="glyph` + strconv.Itoa(int(G)) + `" width="100" height="100" fill="red"/></svg>`)
+	gzBuf := &bytes.Buffer{}
+	gz := gzip.NewWriter(gzBuf)
+	gz.Write(doc)
+	gz.Close()
+
+	w = NewBinaryWriter([]byte{})
+	w.WriteUint16(0)  // version
+	w.WriteUint32(10) // svgDocumentListOffset
+	w.WriteUint32(0)  // reserved
+	w.WriteUint16(2)  // numEntries
+	w.WriteUint16(G)
+	w.WriteUint16(G)
+	w.WriteUint32(26)
+	w.WriteUint32(uint32(len(doc)))
+	w.WriteUint16(H)
+	w.WriteUint16(H)
+	w.WriteUint32(26 + uint32(len(doc)))
+	w.WriteUint32(uint32(gzBuf.Len()))
+	w.WriteBytes(doc)
+	w.WriteBytes(gzBuf.Bytes())
+	sfnt.Tables["SVG "] = w.Bytes()
+
+	sfnt, err = ParseSFNT(sfnt.Write(), 0)
+	test.Error(t, err)
+	return sfnt
+}
+
+func TestSFNTColorPalettes(t *testing.T) {
+	sfnt := colorTestFont(t)
+	test.T(t, sfnt.NumPalettes(), 2)
+	test.T(t, sfnt.Palette(0), []color.NRGBA{{255, 0, 0, 255}, {0, 0, 255, 128}})
+	test.T(t, sfnt.Palette(1), []color.NRGBA{{0, 255, 0, 255}, {255, 255, 0, 255}})
+	test.T(t, sfnt.Palette(2), []color.NRGBA(nil))
+}
+
+func TestSFNTColorCOLR(t *testing.T) {
+	sfnt := colorTestFont(t)
+	A, B, C := sfnt.GlyphIndex('A'), sfnt.GlyphIndex('B'), sfnt.GlyphIndex('C')
+	D, E, O := sfnt.GlyphIndex('D'), sfnt.GlyphIndex('E'), sfnt.GlyphIndex('O')
+
+	test.That(t, sfnt.IsColorGlyph(A))
+	test.That(t, sfnt.IsColorGlyph(D))
+	test.That(t, !sfnt.IsColorGlyph(B))
+	test.T(t, sfnt.GlyphPaint(B), nil)
+
+	// version 0
+	test.T(t, sfnt.GlyphPaint(A), PaintLayers{[]Paint{
+		PaintGlyph{B, PaintSolid{0, 1.0}},
+		PaintGlyph{C, PaintSolid{1, 1.0}},
+	}})
+
+	// version 1 with sorted color stops
+	test.T(t, sfnt.GlyphPaint(D), PaintGlyph{O, PaintLinearGradient{
+		ColorLine{ExtendReflect, []ColorStop{{0.0, 0, 0.5}, {1.0, 1, 1.0}}},
+		0.0, 0.0, 1000.0, 0.0, 0.0, 1000.0,
+	}})
+
+	composite, ok := sfnt.GlyphPaint(E).(PaintComposite)
+	test.That(t, ok)
+	test.T(t, composite.Mode, CompositeMultiply)
+	test.T(t, composite.Source, PaintGlyph{O, PaintSolid{ForegroundPaletteIndex, 1.0}})
+	rotate, ok := composite.Backdrop.(PaintTransform)
+	test.That(t, ok)
+	test.T(t, rotate.Paint, PaintColrGlyph{A})
+	for i, v := range []float64{rotate.XX, rotate.YX, rotate.XY, rotate.YY, rotate.DX, rotate.DY} {
+		test.Float(t, math.Round(v), []float64{0.0, 1.0, -1.0, 0.0, 300.0, 100.0}[i])
+	}
+
+	// clip boxes
+	xmin, ymin, xmax, ymax, ok := sfnt.GlyphClipBox(E)
+	test.That(t, ok)
+	test.T(t, []int16{xmin, ymin, xmax, ymax}, []int16{-100, -200, 1100, 1500})
+	_, _, _, _, ok = sfnt.GlyphClipBox(A)
+	test.That(t, !ok)
+}
+
+func TestSFNTColorBitmap(t *testing.T) {
+	sfnt := colorTestFont(t)
+	F := sfnt.GlyphIndex('F')
+	test.That(t, sfnt.IsColorGlyph(F))
+	test.T(t, sfnt.GlyphBitmap(sfnt.GlyphIndex('E'), 20), (*GlyphBitmap)(nil))
+
+	bitmap := sfnt.GlyphBitmap(F, 100)
+	test.That(t, bitmap != nil)
+	test.T(t, bitmap.Format, "png")
+	test.T(t, bitmap.PPEM, uint16(20))
+	test.T(t, bitmap.Left, -2.0)
+	test.T(t, bitmap.Bottom, -3.0)
+	img, err := png.Decode(bytes.NewReader(bitmap.Data))
+	test.Error(t, err)
+	test.T(t, img.Bounds().Dx(), 2)
+}
+
+func TestSFNTColorSVG(t *testing.T) {
+	sfnt := colorTestFont(t)
+	G, H := sfnt.GlyphIndex('G'), sfnt.GlyphIndex('H')
+	test.That(t, sfnt.IsColorGlyph(G))
+	test.That(t, sfnt.IsColorGlyph(H))
+
+	doc, err := sfnt.GlyphSVG(G)
+	test.Error(t, err)
+	docH, err := sfnt.GlyphSVG(H)
+	test.Error(t, err)
+	test.Bytes(t, docH, doc)
+	test.That(t, bytes.Contains(doc, []byte(`id="glyph`+strconv.Itoa(int(G))+`"`)))
+
+	doc, err = sfnt.GlyphSVG(sfnt.GlyphIndex('A'))
+	test.Error(t, err)
+	test.T(t, doc, []byte(nil))
+}
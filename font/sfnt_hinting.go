@@ -0,0 +1,1610 @@
+package font
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+// TrueType hinting, see https://learn.microsoft.com/en-us/typography/opentype/spec/tt_instructions
+// The interpreter follows the behaviour of FreeType's v35 interpreter, which grid-fits in both directions. Coordinates are in 26.6 fixed-point pixels and vectors are in 2.14 fixed-point.
+
+const (
+	hintMaxSteps     = 1000000 // maximum number of instructions executed per program
+	hintMaxCallDepth = 64
+	hintMaxStack     = 65536
+)
+
+const (
+	hintTouchedX = 0x01
+	hintTouchedY = 0x02
+)
+
+// hintError aborts the execution of hinting instructions.
+type hintError string
+
+func (err hintError) Error() string {
+	return "hinting: " + string(err)
+}
+
+type hintPoint struct {
+	X, Y int32
+}
+
+// hintZone is either the twilight zone or the glyph zone, the latter includes four phantom points at the end. The glyph zone also keeps the unscaled points, which give more precise original distances.
+type hintZone struct {
+	orig, cur []hintPoint
+	touched   []uint8
+	onCurve   []bool
+	endPoints []uint16
+
+	orus      []hintPoint // unscaled points, nil for the twilight zone
+	orusScale int32       // 16.16 scale from orus to 26.6
+}
+
+func newHintZone(n int) *hintZone {
+	return &hintZone{
+		orig:    make([]hintPoint, n),
+		cur:     make([]hintPoint, n),
+		touched: make([]uint8, n),
+		onCurve: make([]bool, n),
+	}
+}
+
+func (zone *hintZone) copy() *hintZone {
+	return &hintZone{
+		orig:      append([]hintPoint{}, zone.orig...),
+		cur:       append([]hintPoint{}, zone.cur...),
+		touched:   append([]uint8{}, zone.touched...),
+		onCurve:   append([]bool{}, zone.onCurve...),
+		endPoints: zone.endPoints,
+	}
+}
+
+func (zone *hintZone) check(i int32) {
+	if i < 0 || len(zone.cur) <= int(i) {
+		panic(hintError(fmt.Sprintf("bad point %v", i)))
+	}
+}
+
+type hintRoundState int
+
+const (
+	hintRoundToHalfGrid hintRoundState = iota
+	hintRoundToGrid
+	hintRoundToDoubleGrid
+	hintRoundDownToGrid
+	hintRoundUpToGrid
+	hintRoundOff
+	hintRoundSuper
+	hintRoundSuper45
+)
+
+type hintGraphicsState struct {
+	pv, fv, dv        [2]int32 // projection, freedom, and dual projection vectors
+	rp                [3]int32 // reference points
+	zp                [3]int32 // zone pointers
+	loop              int32
+	minDist           int32
+	roundState        hintRoundState
+	period, phase     int32 // super rounding
+	threshold         int32
+	autoFlip          bool
+	cvtCutIn          int32
+	singleWidthCutIn  int32
+	singleWidth       int32
+	deltaBase         int32
+	deltaShift        int32
+	instructControl   int32
+	scanControl, scan int32
+}
+
+var defaultHintGraphicsState = hintGraphicsState{
+	pv:         [2]int32{0x4000, 0},
+	fv:         [2]int32{0x4000, 0},
+	dv:         [2]int32{0x4000, 0},
+	zp:         [3]int32{1, 1, 1},
+	loop:       1,
+	minDist:    64,
+	roundState: hintRoundToGrid,
+	autoFlip:   true,
+	cvtCutIn:   68, // 17/16 pixel
+	deltaBase:  9,
+	deltaShift: 3,
+}
+
+// hintSize is the state after running the font program and the control value program at a given ppem, which is the starting point for each glyph program.
+type hintSize struct {
+	functions map[int32][]byte
+	instrDefs map[uint8][]byte
+	cvt       []int32
+	storage   []int32
+	twilight  *hintZone
+	gs        hintGraphicsState
+	err       error
+}
+
+// ttHinter grid-fits TrueType glyphs by executing the font program (fpgm), the control value program (prep), and the glyph programs. It caches the state per ppem and is safe for concurrent use.
+type ttHinter struct {
+	sync.Mutex
+	sfnt  *SFNT
+	sizes map[uint16]*hintSize
+}
+
+func newTTHinter(sfnt *SFNT) *ttHinter {
+	return &ttHinter{
+		sfnt:  sfnt,
+		sizes: map[uint16]*hintSize{},
+	}
+}
+
+// glyfHintedContour is a hinted glyph outline in (fractional) font units.
+type glyfHintedContour struct {
+	EndPoints    []uint16
+	OnCurve      []bool
+	XCoordinates []float64
+	YCoordinates []float64
+}
+
+// Hint returns the glyph's outline grid-fitted at the given ppem.
+func (h *ttHinter) Hint(glyphID, ppem uint16) (*glyfHintedContour, error) {
+	h.Lock()
+	defer h.Unlock()
+
+	size, ok := h.sizes[ppem]
+	if !ok {
+		size = h.newSize(ppem)
+		h.sizes[ppem] = size
+	}
+	if size.err != nil {
+		return nil, size.err
+	}
+
+	ctx := h.newContext(ppem, size)
+	zone, err := ctx.loadGlyph(glyphID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// keep the origin, which is the first phantom point, at its unhinted position
+	n := len(zone.cur) - 4
+	dx := zone.orig[n].X - zone.cur[n].X
+	contour := &glyfHintedContour{
+		EndPoints:    zone.endPoints,
+		OnCurve:      zone.onCurve[:n],
+		XCoordinates: make([]float64, n),
+		YCoordinates: make([]float64, n),
+	}
+	for i := 0; i < n; i++ {
+		contour.XCoordinates[i] = float64(zone.cur[i].X+dx) / ctx.scale
+		contour.YCoordinates[i] = float64(zone.cur[i].Y) / ctx.scale
+	}
+	return contour, nil
+}
+
+func (h *ttHinter) newSize(ppem uint16) *hintSize {
+	size := &hintSize{
+		functions: map[int32][]byte{},
+		instrDefs: map[uint8][]byte{},
+		storage:   make([]int32, h.sfnt.Maxp.MaxStorage),
+		twilight:  newHintZone(int(h.sfnt.Maxp.MaxTwilightPoints)),
+		gs:        defaultHintGraphicsState,
+	}
+
+	ctx := h.newContext(ppem, size)
+	if b, ok := h.sfnt.Tables["cvt "]; ok {
+		r := NewBinaryReader(b)
+		ctx.cvt = make([]int32, len(b)/2)
+		for i := range ctx.cvt {
+			ctx.cvt[i] = ctx.scaleFUnits(int32(r.ReadInt16()))
+		}
+	}
+
+	if fpgm, ok := h.sfnt.Tables["fpgm"]; ok {
+		if size.err = ctx.run(fpgm); size.err != nil {
+			return size
+		}
+	}
+
+	// the control value program starts from the default graphics state, and its changes become the default for the glyph programs
+	ctx.gs = defaultHintGraphicsState
+	if prep, ok := h.sfnt.Tables["prep"]; ok {
+		if size.err = ctx.run(prep); size.err != nil {
+			return size
+		}
+	}
+	size.cvt = ctx.cvt
+	size.storage = ctx.storage
+	size.twilight = ctx.zones[0]
+	if ctx.gs.instructControl&0x02 == 0 {
+		size.gs = ctx.gs
+	}
+	size.gs.instructControl = ctx.gs.instructControl
+	return size
+}
+
+// hintContext is the execution context of hinting instructions.
+type hintContext struct {
+	sfnt       *SFNT
+	ppem       uint16
+	scale      float64 // from font units to 26.6
+	fixedScale int32   // same in 16.16
+	functions  map[int32][]byte
+	instrDefs  map[uint8][]byte
+	cvt        []int32
+	storage    []int32
+	initGS     hintGraphicsState // graphics state at the start of each glyph program
+	gs         hintGraphicsState
+	zones      [2]*hintZone
+	stack      []int32
+	steps      int
+}
+
+func (h *ttHinter) newContext(ppem uint16, size *hintSize) *hintContext {
+	upem := int64(h.sfnt.Head.UnitsPerEm)
+	fixedScale := int32((int64(ppem)<<22 + upem/2) / upem)
+	return &hintContext{
+		sfnt:       h.sfnt,
+		ppem:       ppem,
+		scale:      float64(fixedScale) / (1 << 16),
+		fixedScale: fixedScale,
+		functions:  size.functions,
+		instrDefs:  size.instrDefs,
+		cvt:        append([]int32{}, size.cvt...),
+		storage:    append([]int32{}, size.storage...),
+		initGS:     size.gs,
+		gs:         size.gs,
+		zones:      [2]*hintZone{size.twilight.copy(), newHintZone(0)},
+	}
+}
+
+func (ctx *hintContext) scaleFUnits(v int32) int32 {
+	return hintMulFix(v, ctx.fixedScale)
+}
+
+// loadGlyph returns the hinted glyph zone, including its phantom points, where orig holds the unhinted and cur the hinted positions.
+func (ctx *hintContext) loadGlyph(glyphID uint16, level int) (*hintZone, error) {
+	glyf := ctx.sfnt.Glyf
+	b := glyf.Get(glyphID)
+	if b == nil {
+		return nil, fmt.Errorf("glyf: bad glyphID %v", glyphID)
+	} else if 0 < len(b) && len(b) < 10 {
+		return nil, fmt.Errorf("glyf: bad table for glyphID %v", glyphID)
+	}
+
+	var zone *hintZone
+	var instructions []byte
+	contour, err := glyf.Contour(glyphID, level)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 || 0 <= int16(uint16(b[0])<<8|uint16(b[1])) {
+		// simple glyph
+		n := len(contour.XCoordinates)
+		zone = newHintZone(n + 4)
+		zone.endPoints = contour.EndPoints
+		copy(zone.onCurve, contour.OnCurve)
+		zone.orus = make([]hintPoint, n+4)
+		zone.orusScale = ctx.fixedScale
+		for i := 0; i < n; i++ {
+			zone.orus[i] = hintPoint{int32(contour.XCoordinates[i]), int32(contour.YCoordinates[i])}
+		}
+		ctx.setPhantomPoints(zone, glyphID, contour.XMin, contour.YMax)
+		for i, p := range zone.orus {
+			zone.orig[i] = hintPoint{ctx.scaleFUnits(p.X), ctx.scaleFUnits(p.Y)}
+		}
+		copy(zone.cur, zone.orig)
+		instructions = contour.Instructions
+	} else {
+		// composite glyph, each component is hinted before the composite's own instructions run
+		var hasPhantom bool
+		if zone, hasPhantom, err = ctx.loadComposite(glyphID, b, level); err != nil {
+			return nil, err
+		}
+		if !hasPhantom {
+			ctx.setPhantomPoints(zone, glyphID, contour.XMin, contour.YMax)
+			n := len(zone.cur) - 4
+			for i := n; i < n+4; i++ {
+				zone.cur[i] = hintPoint{ctx.scaleFUnits(zone.orus[i].X), ctx.scaleFUnits(zone.orus[i].Y)}
+			}
+		}
+		instructions = contour.Instructions
+
+		// the instructions of a composite glyph refer to the hinted components
+		copy(zone.orig, zone.cur)
+		copy(zone.orus, zone.cur)
+		zone.orusScale = 1 << 16
+	}
+
+	// round phantom points
+	n := len(zone.cur) - 4
+	zone.cur[n].X = hintRound(zone.cur[n].X)
+	zone.cur[n+1].X = hintRound(zone.cur[n+1].X)
+	zone.cur[n+2].Y = hintRound(zone.cur[n+2].Y)
+	zone.cur[n+3].Y = hintRound(zone.cur[n+3].Y)
+
+	ctx.gs = ctx.initGS
+	if 0 < len(instructions) && ctx.gs.instructControl&0x01 == 0 {
+		ctx.zones[1] = zone
+		if err := ctx.run(instructions); err != nil {
+			return nil, err
+		}
+	}
+	return zone, nil
+}
+
+// setPhantomPoints sets the last four unscaled points of the zone to the horizontal origin and advance, and the vertical origin and advance.
+func (ctx *hintContext) setPhantomPoints(zone *hintZone, glyphID uint16, xMin, yMax int16) {
+	n := len(zone.orus) - 4
+	lsb := int32(ctx.sfnt.Hmtx.LeftSideBearing(glyphID))
+	advance := int32(ctx.sfnt.GlyphAdvance(glyphID))
+	x := int32(xMin) - lsb
+
+	var top, bottom int32
+	if ctx.sfnt.Vmtx != nil {
+		top = int32(yMax) + int32(ctx.sfnt.Vmtx.TopSideBearing(glyphID))
+		bottom = top - int32(ctx.sfnt.Vmtx.Advance(glyphID))
+	} else if ctx.sfnt.OS2 != nil {
+		top, bottom = int32(ctx.sfnt.OS2.STypoAscender), int32(ctx.sfnt.OS2.STypoDescender)
+	} else {
+		top, bottom = int32(ctx.sfnt.Hhea.Ascender), int32(ctx.sfnt.Hhea.Descender)
+	}
+	zone.orus[n] = hintPoint{x, 0}
+	zone.orus[n+1] = hintPoint{x + advance, 0}
+	zone.orus[n+2] = hintPoint{0, top}
+	zone.orus[n+3] = hintPoint{0, bottom}
+}
+
+// loadComposite combines the hinted components into one zone. The phantom points are set only when a component has USE_MY_METRICS.
+func (ctx *hintContext) loadComposite(glyphID uint16, b []byte, level int) (*hintZone, bool, error) {
+	if 7 < level {
+		return nil, false, fmt.Errorf("glyf: compound glyphs too deeply nested")
+	}
+	glyf := ctx.sfnt.Glyf
+
+	var dxs, dys []float64
+	if glyf.gvar != nil && glyf.coords != nil {
+		n, err := glyf.numPoints(glyphID)
+		if err != nil {
+			return nil, false, err
+		}
+		if dxs, dys, err = glyf.gvar.Deltas(glyphID, glyf.coords, n+4, nil, nil, nil); err != nil {
+			return nil, false, err
+		}
+	}
+
+	var points []hintPoint
+	var onCurve []bool
+	var endPoints []uint16
+	var phantom []hintPoint
+	r := NewBinaryReader(b[10:])
+	for component := 0; ; component++ {
+		flags := r.ReadUint16()
+		subGlyphID := r.ReadUint16()
+		if flags&0x0002 == 0 { // ARGS_ARE_XY_VALUES
+			return nil, false, fmt.Errorf("glyf: composite glyph not supported")
+		}
+		var dx, dy int32
+		if flags&0x0001 != 0 { // ARG_1_AND_2_ARE_WORDS
+			dx = int32(r.ReadInt16())
+			dy = int32(r.ReadInt16())
+		} else {
+			dx = int32(r.ReadInt8())
+			dy = int32(r.ReadInt8())
+		}
+		if dxs != nil {
+			dx += int32(math.Round(dxs[component]))
+			dy += int32(math.Round(dys[component]))
+		}
+		var txx, txy, tyx, tyy int32
+		if flags&0x0008 != 0 { // WE_HAVE_A_SCALE
+			txx = int32(r.ReadInt16())
+			tyy = txx
+		} else if flags&0x0040 != 0 { // WE_HAVE_AN_X_AND_Y_SCALE
+			txx = int32(r.ReadInt16())
+			tyy = int32(r.ReadInt16())
+		} else if flags&0x0080 != 0 { // WE_HAVE_A_TWO_BY_TWO
+			txx = int32(r.ReadInt16())
+			txy = int32(r.ReadInt16())
+			tyx = int32(r.ReadInt16())
+			tyy = int32(r.ReadInt16())
+		}
+		if r.EOF() {
+			return nil, false, fmt.Errorf("glyf: bad table for glyphID %v", glyphID)
+		}
+
+		sub, err := ctx.loadGlyph(subGlyphID, level+1)
+		if err != nil {
+			return nil, false, err
+		}
+		n := len(sub.cur) - 4
+
+		ox, oy := ctx.scaleFUnits(dx), ctx.scaleFUnits(dy)
+		if flags&0x0004 != 0 { // ROUND_XY_TO_GRID
+			ox, oy = hintRound(ox), hintRound(oy)
+		}
+		offset := uint16(len(points))
+		for _, endPoint := range sub.endPoints {
+			endPoints = append(endPoints, offset+endPoint)
+		}
+		onCurve = append(onCurve, sub.onCurve[:n]...)
+		for i := 0; i < n; i++ {
+			p := sub.cur[i]
+			if flags&0x00C8 != 0 { // has transformation
+				p = hintPoint{
+					X: hintMul14(p.X, txx) + hintMul14(p.Y, tyx),
+					Y: hintMul14(p.X, txy) + hintMul14(p.Y, tyy),
+				}
+			}
+			points = append(points, hintPoint{p.X + ox, p.Y + oy})
+		}
+		if flags&0x0200 != 0 { // USE_MY_METRICS
+			phantom = sub.cur[n:]
+		}
+		if flags&0x0020 == 0 { // MORE_COMPONENTS
+			break
+		}
+	}
+
+	zone := newHintZone(len(points) + 4)
+	zone.orus = make([]hintPoint, len(points)+4)
+	zone.endPoints = endPoints
+	copy(zone.onCurve, onCurve)
+	copy(zone.cur, points)
+	if phantom != nil {
+		copy(zone.cur[len(points):], phantom)
+	}
+	return zone, phantom != nil, nil
+}
+
+// run executes a program, where some of the graphics state is reset beforehand.
+func (ctx *hintContext) run(program []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if hintErr, ok := r.(hintError); ok {
+				err = hintErr
+				return
+			}
+			panic(r)
+		}
+	}()
+
+	ctx.gs.zp = [3]int32{1, 1, 1}
+	ctx.gs.pv = [2]int32{0x4000, 0}
+	ctx.gs.fv = ctx.gs.pv
+	ctx.gs.dv = ctx.gs.pv
+	ctx.gs.roundState = hintRoundToGrid
+	ctx.gs.loop = 1
+	ctx.gs.rp = [3]int32{0, 0, 0}
+	ctx.stack = ctx.stack[:0]
+	ctx.steps = 0
+	ctx.exec(program, 0)
+	return nil
+}
+
+func (ctx *hintContext) push(v int32) {
+	if hintMaxStack <= len(ctx.stack) {
+		panic(hintError("stack overflow"))
+	}
+	ctx.stack = append(ctx.stack, v)
+}
+
+func (ctx *hintContext) pop() int32 {
+	if len(ctx.stack) == 0 {
+		panic(hintError("stack underflow"))
+	}
+	v := ctx.stack[len(ctx.stack)-1]
+	ctx.stack = ctx.stack[:len(ctx.stack)-1]
+	return v
+}
+
+func (ctx *hintContext) zone(i int) *hintZone {
+	if zp := ctx.gs.zp[i]; zp == 0 || zp == 1 {
+		return ctx.zones[zp]
+	}
+	panic(hintError("bad zone"))
+}
+
+// point pops a point index for the given zone pointer.
+func (ctx *hintContext) point(zp int) (*hintZone, int32) {
+	zone := ctx.zone(zp)
+	i := ctx.pop()
+	zone.check(i)
+	return zone, i
+}
+
+// refPoint returns the reference point for the given zone pointer.
+func (ctx *hintContext) refPoint(rp, zp int) (*hintZone, int32) {
+	zone := ctx.zone(zp)
+	i := ctx.gs.rp[rp]
+	zone.check(i)
+	return zone, i
+}
+
+func (ctx *hintContext) cvtValue(i int32) int32 {
+	if i < 0 || len(ctx.cvt) <= int(i) {
+		return 0
+	}
+	return ctx.cvt[i]
+}
+
+// hintRound rounds a 26.6 value to the grid.
+func hintRound(v int32) int32 {
+	return (v + 32) &^ 63
+}
+
+// hintMulFix multiplies by a 16.16 value, rounding half away from zero.
+func hintMulFix(a, b int32) int32 {
+	v := int64(a) * int64(b)
+	if v < 0 {
+		return -int32((-v + 0x8000) >> 16)
+	}
+	return int32((v + 0x8000) >> 16)
+}
+
+// hintMul14 multiplies by a 2.14 value.
+func hintMul14(a, b int32) int32 {
+	return int32((int64(a)*int64(b) + 0x2000) >> 14)
+}
+
+// hintMulDiv returns a*b/c rounded to nearest.
+func hintMulDiv(a, b, c int32) int32 {
+	if c == 0 {
+		panic(hintError("division by zero"))
+	}
+	n := int64(a) * int64(b)
+	d := int64(c)
+	if (n < 0) != (d < 0) {
+		return int32((n - d/2) / d)
+	}
+	return int32((n + d/2) / d)
+}
+
+func hintNormalize(x, y int32) [2]int32 {
+	if x == 0 && y == 0 {
+		return [2]int32{0x4000, 0}
+	}
+	length := math.Hypot(float64(x), float64(y))
+	return [2]int32{int32(math.Round(float64(x) * 0x4000 / length)), int32(math.Round(float64(y) * 0x4000 / length))}
+}
+
+func (ctx *hintContext) project(p hintPoint) int32 {
+	return int32((int64(p.X)*int64(ctx.gs.pv[0]) + int64(p.Y)*int64(ctx.gs.pv[1]) + 0x2000) >> 14)
+}
+
+func (ctx *hintContext) dualProject(p hintPoint) int32 {
+	return int32((int64(p.X)*int64(ctx.gs.dv[0]) + int64(p.Y)*int64(ctx.gs.dv[1]) + 0x2000) >> 14)
+}
+
+func hintSub(a, b hintPoint) hintPoint {
+	return hintPoint{a.X - b.X, a.Y - b.Y}
+}
+
+// origDistance returns the original distance from p2 to p1 along the dual projection vector, using the unscaled points when neither is in the twilight zone.
+func (ctx *hintContext) origDistance(zone1 *hintZone, p1 int32, zone2 *hintZone, p2 int32) int32 {
+	if zone1.orus == nil || zone2.orus == nil {
+		return ctx.dualProject(hintSub(zone1.orig[p1], zone2.orig[p2]))
+	}
+	return hintMulFix(ctx.dualProject(hintSub(zone1.orus[p1], zone2.orus[p2])), zone1.orusScale)
+}
+
+// freedomDotProjection returns the dot product of the freedom and projection vectors, which is the rate at which moves along the freedom vector change the projected distance.
+func (ctx *hintContext) freedomDotProjection() int32 {
+	dot := int32((int64(ctx.gs.fv[0])*int64(ctx.gs.pv[0]) + int64(ctx.gs.fv[1])*int64(ctx.gs.pv[1])) >> 14)
+	if -0x400 < dot && dot < 0x400 {
+		dot = 0x4000
+	}
+	return dot
+}
+
+// move moves a point along the freedom vector so that its projection changes by d, and marks it as touched.
+func (ctx *hintContext) move(zone *hintZone, i, d int32, touch bool) {
+	dot := ctx.freedomDotProjection()
+	if ctx.gs.fv[0] != 0 {
+		zone.cur[i].X += hintMulDiv(d, ctx.gs.fv[0], dot)
+		if touch {
+			zone.touched[i] |= hintTouchedX
+		}
+	}
+	if ctx.gs.fv[1] != 0 {
+		zone.cur[i].Y += hintMulDiv(d, ctx.gs.fv[1], dot)
+		if touch {
+			zone.touched[i] |= hintTouchedY
+		}
+	}
+}
+
+// moveOrig moves the original position of a point, which is used for points in the twilight zone.
+func (ctx *hintContext) moveOrig(zone *hintZone, i, d int32) {
+	dot := ctx.freedomDotProjection()
+	if ctx.gs.fv[0] != 0 {
+		zone.orig[i].X += hintMulDiv(d, ctx.gs.fv[0], dot)
+	}
+	if ctx.gs.fv[1] != 0 {
+		zone.orig[i].Y += hintMulDiv(d, ctx.gs.fv[1], dot)
+	}
+}
+
+// shift moves a point by the given vector.
+func (ctx *hintContext) shift(zone *hintZone, i int32, dx, dy int32, touch bool) {
+	if ctx.gs.fv[0] != 0 {
+		zone.cur[i].X += dx
+		if touch {
+			zone.touched[i] |= hintTouchedX
+		}
+	}
+	if ctx.gs.fv[1] != 0 {
+		zone.cur[i].Y += dy
+		if touch {
+			zone.touched[i] |= hintTouchedY
+		}
+	}
+}
+
+func (ctx *hintContext) round(v int32) int32 {
+	var period, phase, threshold int32
+	switch ctx.gs.roundState {
+	case hintRoundOff:
+		return v
+	case hintRoundToHalfGrid:
+		period, phase, threshold = 64, 32, 32
+	case hintRoundToGrid:
+		period, phase, threshold = 64, 0, 32
+	case hintRoundToDoubleGrid:
+		period, phase, threshold = 32, 0, 16
+	case hintRoundDownToGrid:
+		period, phase, threshold = 64, 0, 0
+	case hintRoundUpToGrid:
+		period, phase, threshold = 64, 0, 63
+	default:
+		period, phase, threshold = ctx.gs.period, ctx.gs.phase, ctx.gs.threshold
+	}
+	if period == 0 {
+		return v
+	}
+
+	if 0 <= v {
+		r := (v-phase+threshold)/period*period + phase
+		if r < 0 {
+			r = phase
+		}
+		return r
+	}
+	r := -((phase-v+threshold)/period*period + phase)
+	if 0 < r {
+		r = -phase
+	}
+	return r
+}
+
+func (ctx *hintContext) setSuperRound(n int32, gridPeriod float64) {
+	var period float64
+	switch n & 0xC0 {
+	case 0x00:
+		period = gridPeriod / 2.0
+	case 0x40:
+		period = gridPeriod
+	case 0x80:
+		period = gridPeriod * 2.0
+	default:
+		period = gridPeriod
+	}
+	phase := period * float64((n&0x30)>>4) / 4.0
+	threshold := period - 1.0
+	if n&0x0F != 0 {
+		threshold = period * float64(n&0x0F-4) / 8.0
+	}
+	ctx.gs.period = int32(math.Round(period))
+	ctx.gs.phase = int32(math.Round(phase))
+	ctx.gs.threshold = int32(math.Round(threshold))
+}
+
+// hintInstructionLength returns the length of the instruction at pc, including inline data.
+func hintInstructionLength(program []byte, pc int) int {
+	switch op := program[pc]; {
+	case op == 0x40: // NPUSHB
+		if pc+1 < len(program) {
+			return 2 + int(program[pc+1])
+		}
+	case op == 0x41: // NPUSHW
+		if pc+1 < len(program) {
+			return 2 + 2*int(program[pc+1])
+		}
+	case 0xB0 <= op && op <= 0xB7: // PUSHB
+		return 1 + int(op-0xAF)
+	case 0xB8 <= op && op <= 0xBF: // PUSHW
+		return 1 + 2*int(op-0xB7)
+	}
+	return 1
+}
+
+// skipBranch returns the position of the ELSE (if allowed) or EIF that matches the IF or ELSE at pc.
+func hintSkipBranch(program []byte, pc int, toElse bool) int {
+	level := 0
+	for pc += hintInstructionLength(program, pc); pc < len(program); pc += hintInstructionLength(program, pc) {
+		switch program[pc] {
+		case 0x58: // IF
+			level++
+		case 0x1B: // ELSE
+			if level == 0 && toElse {
+				return pc
+			}
+		case 0x59: // EIF
+			if level == 0 {
+				return pc
+			}
+			level--
+		}
+	}
+	panic(hintError("missing EIF"))
+}
+
+// hintDefinition returns the body of the FDEF or IDEF at pc and the position of its ENDF.
+func hintDefinition(program []byte, pc int) ([]byte, int) {
+	start := pc + 1
+	for pc = start; pc < len(program); pc += hintInstructionLength(program, pc) {
+		switch program[pc] {
+		case 0x2C, 0x89: // FDEF, IDEF
+			panic(hintError("nested definition"))
+		case 0x2D: // ENDF
+			return program[start:pc], pc
+		}
+	}
+	panic(hintError("missing ENDF"))
+}
+
+func hintBool(b bool) int32 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// exec executes a program or function body.
+func (ctx *hintContext) exec(program []byte, depth int) {
+	if hintMaxCallDepth < depth {
+		panic(hintError("call depth exceeded"))
+	}
+
+	gs := &ctx.gs
+	for pc := 0; pc < len(program); pc++ {
+		ctx.steps++
+		if hintMaxSteps < ctx.steps {
+			panic(hintError("too many instructions"))
+		}
+
+		op := program[pc]
+		switch {
+		case op <= 0x01: // SVTCA
+			gs.pv = [2]int32{0, 0x4000}
+			if op == 0x01 {
+				gs.pv = [2]int32{0x4000, 0}
+			}
+			gs.fv, gs.dv = gs.pv, gs.pv
+		case op <= 0x03: // SPVTCA
+			gs.pv = [2]int32{0, 0x4000}
+			if op == 0x03 {
+				gs.pv = [2]int32{0x4000, 0}
+			}
+			gs.dv = gs.pv
+		case op <= 0x05: // SFVTCA
+			gs.fv = [2]int32{0, 0x4000}
+			if op == 0x05 {
+				gs.fv = [2]int32{0x4000, 0}
+			}
+		case op <= 0x09: // SPVTL, SFVTL
+			zone2, p2 := ctx.point(2)
+			zone1, p1 := ctx.point(1)
+			v := hintSub(zone1.cur[p1], zone2.cur[p2])
+			if op&0x01 != 0 {
+				v = hintPoint{-v.Y, v.X}
+			}
+			if op <= 0x07 {
+				gs.pv = hintNormalize(v.X, v.Y)
+				gs.dv = gs.pv
+			} else {
+				gs.fv = hintNormalize(v.X, v.Y)
+			}
+		case op == 0x0A || op == 0x0B: // SPVFS, SFVFS
+			y := int32(int16(ctx.pop()))
+			x := int32(int16(ctx.pop()))
+			if op == 0x0A {
+				gs.pv = hintNormalize(x, y)
+				gs.dv = gs.pv
+			} else {
+				gs.fv = hintNormalize(x, y)
+			}
+		case op == 0x0C: // GPV
+			ctx.push(gs.pv[0])
+			ctx.push(gs.pv[1])
+		case op == 0x0D: // GFV
+			ctx.push(gs.fv[0])
+			ctx.push(gs.fv[1])
+		case op == 0x0E: // SFVTPV
+			gs.fv = gs.pv
+		case op == 0x0F: // ISECT
+			zb, b1 := ctx.point(0)
+			_, b0 := ctx.point(0)
+			za, a1 := ctx.point(1)
+			_, a0 := ctx.point(1)
+			zone, i := ctx.point(2)
+			ctx.isect(zone, i, za.cur[a0], za.cur[a1], zb.cur[b0], zb.cur[b1])
+		case op <= 0x12: // SRP0, SRP1, SRP2
+			gs.rp[op-0x10] = ctx.pop()
+		case op <= 0x15: // SZP0, SZP1, SZP2
+			zp := ctx.pop()
+			if zp != 0 && zp != 1 {
+				panic(hintError("bad zone"))
+			}
+			gs.zp[op-0x13] = zp
+		case op == 0x16: // SZPS
+			zp := ctx.pop()
+			if zp != 0 && zp != 1 {
+				panic(hintError("bad zone"))
+			}
+			gs.zp = [3]int32{zp, zp, zp}
+		case op == 0x17: // SLOOP
+			gs.loop = ctx.pop()
+			if gs.loop <= 0 {
+				panic(hintError("bad loop"))
+			}
+		case op == 0x18: // RTG
+			gs.roundState = hintRoundToGrid
+		case op == 0x19: // RTHG
+			gs.roundState = hintRoundToHalfGrid
+		case op == 0x1A: // SMD
+			gs.minDist = ctx.pop()
+		case op == 0x1B: // ELSE
+			pc = hintSkipBranch(program, pc, false)
+		case op == 0x1C: // JMPR
+			pc += int(ctx.pop()) - 1
+			if pc < -1 || len(program) < pc {
+				panic(hintError("bad jump"))
+			}
+		case op == 0x1D: // SCVTCI
+			gs.cvtCutIn = ctx.pop()
+		case op == 0x1E: // SSWCI
+			gs.singleWidthCutIn = ctx.pop()
+		case op == 0x1F: // SSW
+			gs.singleWidth = ctx.scaleFUnits(ctx.pop())
+		case op == 0x20: // DUP
+			v := ctx.pop()
+			ctx.push(v)
+			ctx.push(v)
+		case op == 0x21: // POP
+			ctx.pop()
+		case op == 0x22: // CLEAR
+			ctx.stack = ctx.stack[:0]
+		case op == 0x23: // SWAP
+			a := ctx.pop()
+			b := ctx.pop()
+			ctx.push(a)
+			ctx.push(b)
+		case op == 0x24: // DEPTH
+			ctx.push(int32(len(ctx.stack)))
+		case op == 0x25 || op == 0x26: // CINDEX, MINDEX
+			k := int(ctx.pop())
+			if k <= 0 || len(ctx.stack) < k {
+				panic(hintError("bad index"))
+			}
+			i := len(ctx.stack) - k
+			v := ctx.stack[i]
+			if op == 0x26 {
+				copy(ctx.stack[i:], ctx.stack[i+1:])
+				ctx.stack = ctx.stack[:len(ctx.stack)-1]
+			}
+			ctx.push(v)
+		case op == 0x27: // ALIGNPTS
+			zone2, p2 := ctx.point(0)
+			zone1, p1 := ctx.point(1)
+			d := ctx.project(hintSub(zone2.cur[p2], zone1.cur[p1])) / 2
+			ctx.move(zone1, p1, d, true)
+			ctx.move(zone2, p2, -d, true)
+		case op == 0x29: // UTP
+			zone, i := ctx.point(0)
+			if gs.fv[0] != 0 {
+				zone.touched[i] &^= hintTouchedX
+			}
+			if gs.fv[1] != 0 {
+				zone.touched[i] &^= hintTouchedY
+			}
+		case op == 0x2A: // LOOPCALL
+			f := ctx.pop()
+			count := ctx.pop()
+			body, ok := ctx.functions[f]
+			if !ok {
+				panic(hintError("undefined function"))
+			}
+			for ; 0 < count; count-- {
+				ctx.exec(body, depth+1)
+			}
+		case op == 0x2B: // CALL
+			f := ctx.pop()
+			body, ok := ctx.functions[f]
+			if !ok {
+				panic(hintError("undefined function"))
+			}
+			ctx.exec(body, depth+1)
+		case op == 0x2C: // FDEF
+			f := ctx.pop()
+			ctx.functions[f], pc = hintDefinition(program, pc)
+		case op == 0x2D: // ENDF
+			return
+		case op == 0x2E || op == 0x2F: // MDAP
+			zone, i := ctx.point(0)
+			var d int32
+			if op == 0x2F {
+				v := ctx.project(zone.cur[i])
+				d = ctx.round(v) - v
+			}
+			ctx.move(zone, i, d, true)
+			gs.rp[0], gs.rp[1] = i, i
+		case op == 0x30 || op == 0x31: // IUP
+			ctx.iup(op == 0x31)
+		case op == 0x32 || op == 0x33: // SHP
+			dx, dy := ctx.displacement(op == 0x33)
+			for ; 0 < gs.loop; gs.loop-- {
+				zone, i := ctx.point(2)
+				ctx.shift(zone, i, dx, dy, true)
+			}
+			gs.loop = 1
+		case op == 0x34 || op == 0x35: // SHC
+			refZone, ref := ctx.shiftReference(op == 0x35)
+			dx, dy := ctx.displacement(op == 0x35)
+			zone := ctx.zone(2)
+			c := ctx.pop()
+			if c < 0 || len(zone.endPoints) <= int(c) {
+				panic(hintError("bad contour"))
+			}
+			start := int32(0)
+			if 0 < c {
+				start = int32(zone.endPoints[c-1]) + 1
+			}
+			for i := start; i <= int32(zone.endPoints[c]); i++ {
+				if zone != refZone || i != ref {
+					ctx.shift(zone, i, dx, dy, true)
+				}
+			}
+		case op == 0x36 || op == 0x37: // SHZ
+			refZone, ref := ctx.shiftReference(op == 0x37)
+			dx, dy := ctx.displacement(op == 0x37)
+			zp := ctx.pop()
+			if zp != 0 && zp != 1 {
+				panic(hintError("bad zone"))
+			}
+			zone := ctx.zones[zp]
+			n := int32(len(zone.cur))
+			if zp == 1 {
+				n -= 4 // exclude phantom points
+			}
+			for i := int32(0); i < n; i++ {
+				if zone != refZone || i != ref {
+					ctx.shift(zone, i, dx, dy, false)
+				}
+			}
+		case op == 0x38: // SHPIX
+			d := ctx.pop()
+			dx, dy := hintMul14(d, gs.fv[0]), hintMul14(d, gs.fv[1])
+			for ; 0 < gs.loop; gs.loop-- {
+				zone, i := ctx.point(2)
+				ctx.shift(zone, i, dx, dy, true)
+			}
+			gs.loop = 1
+		case op == 0x39: // IP
+			ctx.interpolate()
+		case op == 0x3A || op == 0x3B: // MSIRP
+			d := ctx.pop()
+			zone, i := ctx.point(1)
+			refZone, ref := ctx.refPoint(0, 0)
+			if gs.zp[1] == 0 {
+				zone.orig[i] = refZone.orig[ref]
+				ctx.moveOrig(zone, i, d)
+				zone.cur[i] = zone.orig[i]
+			}
+			dist := ctx.project(hintSub(zone.cur[i], refZone.cur[ref]))
+			ctx.move(zone, i, d-dist, true)
+			gs.rp[1], gs.rp[2] = gs.rp[0], i
+			if op == 0x3B {
+				gs.rp[0] = i
+			}
+		case op == 0x3C: // ALIGNRP
+			refZone, ref := ctx.refPoint(0, 0)
+			for ; 0 < gs.loop; gs.loop-- {
+				zone, i := ctx.point(1)
+				d := ctx.project(hintSub(zone.cur[i], refZone.cur[ref]))
+				ctx.move(zone, i, -d, true)
+			}
+			gs.loop = 1
+		case op == 0x3D: // RTDG
+			gs.roundState = hintRoundToDoubleGrid
+		case op == 0x3E || op == 0x3F: // MIAP
+			d := ctx.cvtValue(ctx.pop())
+			zone, i := ctx.point(0)
+			if gs.zp[0] == 0 {
+				zone.orig[i] = hintPoint{hintMul14(d, gs.fv[0]), hintMul14(d, gs.fv[1])}
+				zone.cur[i] = zone.orig[i]
+			}
+			dist := ctx.project(zone.cur[i])
+			if op == 0x3F {
+				if diff := d - dist; gs.cvtCutIn < diff || diff < -gs.cvtCutIn {
+					d = dist
+				}
+				d = ctx.round(d)
+			}
+			ctx.move(zone, i, d-dist, true)
+			gs.rp[0], gs.rp[1] = i, i
+		case op == 0x40 || op == 0x41: // NPUSHB, NPUSHW
+			if len(program) <= pc+1 {
+				panic(hintError("bad push"))
+			}
+			n := int(program[pc+1])
+			pc = ctx.pushData(program, pc+2, n, op == 0x41) - 1
+		case op == 0x42: // WS
+			v := ctx.pop()
+			i := ctx.pop()
+			if 0 <= i && int(i) < len(ctx.storage) {
+				ctx.storage[i] = v
+			}
+		case op == 0x43: // RS
+			i := ctx.pop()
+			if 0 <= i && int(i) < len(ctx.storage) {
+				ctx.push(ctx.storage[i])
+			} else {
+				ctx.push(0)
+			}
+		case op == 0x44 || op == 0x70: // WCVTP, WCVTF
+			v := ctx.pop()
+			i := ctx.pop()
+			if op == 0x70 {
+				v = ctx.scaleFUnits(v)
+			}
+			if 0 <= i && int(i) < len(ctx.cvt) {
+				ctx.cvt[i] = v
+			}
+		case op == 0x45: // RCVT
+			ctx.push(ctx.cvtValue(ctx.pop()))
+		case op == 0x46 || op == 0x47: // GC
+			zone, i := ctx.point(2)
+			if op == 0x46 {
+				ctx.push(ctx.project(zone.cur[i]))
+			} else {
+				ctx.push(ctx.dualProject(zone.orig[i]))
+			}
+		case op == 0x48: // SCFS
+			v := ctx.pop()
+			zone, i := ctx.point(2)
+			ctx.move(zone, i, v-ctx.project(zone.cur[i]), true)
+			if gs.zp[2] == 0 {
+				zone.orig[i] = zone.cur[i]
+			}
+		case op == 0x49 || op == 0x4A: // MD
+			zone1, p1 := ctx.point(1)
+			zone0, p0 := ctx.point(0)
+			if op == 0x49 {
+				ctx.push(ctx.project(hintSub(zone0.cur[p0], zone1.cur[p1])))
+			} else {
+				ctx.push(ctx.origDistance(zone0, p0, zone1, p1))
+			}
+		case op == 0x4B || op == 0x4C: // MPPEM, MPS
+			ctx.push(int32(ctx.ppem))
+		case op == 0x4D: // FLIPON
+			gs.autoFlip = true
+		case op == 0x4E: // FLIPOFF
+			gs.autoFlip = false
+		case op == 0x4F: // DEBUG
+			ctx.pop()
+		case 0x50 <= op && op <= 0x55: // LT, LTEQ, GT, GTEQ, EQ, NEQ
+			b := ctx.pop()
+			a := ctx.pop()
+			var v bool
+			switch op {
+			case 0x50:
+				v = a < b
+			case 0x51:
+				v = a <= b
+			case 0x52:
+				v = a > b
+			case 0x53:
+				v = a >= b
+			case 0x54:
+				v = a == b
+			case 0x55:
+				v = a != b
+			}
+			ctx.push(hintBool(v))
+		case op == 0x56: // ODD
+			ctx.push(hintBool(ctx.round(ctx.pop())&127 == 64))
+		case op == 0x57: // EVEN
+			ctx.push(hintBool(ctx.round(ctx.pop())&127 == 0))
+		case op == 0x58: // IF
+			if ctx.pop() == 0 {
+				pc = hintSkipBranch(program, pc, true)
+			}
+		case op == 0x59: // EIF
+		case op == 0x5A: // AND
+			b := ctx.pop()
+			a := ctx.pop()
+			ctx.push(hintBool(a != 0 && b != 0))
+		case op == 0x5B: // OR
+			b := ctx.pop()
+			a := ctx.pop()
+			ctx.push(hintBool(a != 0 || b != 0))
+		case op == 0x5C: // NOT
+			ctx.push(hintBool(ctx.pop() == 0))
+		case op == 0x5D || op == 0x71 || op == 0x72: // DELTAP1, DELTAP2, DELTAP3
+			base := gs.deltaBase
+			if op == 0x71 {
+				base += 16
+			} else if op == 0x72 {
+				base += 32
+			}
+			for n := ctx.pop(); 0 < n; n-- {
+				zone, i := ctx.point(0)
+				if d, ok := ctx.delta(ctx.pop(), base); ok {
+					ctx.move(zone, i, d, true)
+				}
+			}
+		case op == 0x5E: // SDB
+			gs.deltaBase = ctx.pop()
+		case op == 0x5F: // SDS
+			gs.deltaShift = ctx.pop()
+			if gs.deltaShift < 0 || 6 < gs.deltaShift {
+				panic(hintError("bad delta shift"))
+			}
+		case op == 0x60: // ADD
+			b := ctx.pop()
+			ctx.push(ctx.pop() + b)
+		case op == 0x61: // SUB
+			b := ctx.pop()
+			ctx.push(ctx.pop() - b)
+		case op == 0x62: // DIV
+			b := ctx.pop()
+			if b == 0 {
+				panic(hintError("division by zero"))
+			}
+			ctx.push(int32(int64(ctx.pop()) * 64 / int64(b)))
+		case op == 0x63: // MUL
+			b := ctx.pop()
+			ctx.push(hintMulDiv(ctx.pop(), b, 64))
+		case op == 0x64: // ABS
+			if v := ctx.pop(); v < 0 {
+				ctx.push(-v)
+			} else {
+				ctx.push(v)
+			}
+		case op == 0x65: // NEG
+			ctx.push(-ctx.pop())
+		case op == 0x66: // FLOOR
+			ctx.push(ctx.pop() &^ 63)
+		case op == 0x67: // CEILING
+			ctx.push((ctx.pop() + 63) &^ 63)
+		case 0x68 <= op && op <= 0x6B: // ROUND
+			ctx.push(ctx.round(ctx.pop()))
+		case 0x6C <= op && op <= 0x6F: // NROUND
+		case op == 0x73 || op == 0x74 || op == 0x75: // DELTAC1, DELTAC2, DELTAC3
+			base := gs.deltaBase + 16*int32(op-0x73)
+			for n := ctx.pop(); 0 < n; n-- {
+				i := ctx.pop()
+				if d, ok := ctx.delta(ctx.pop(), base); ok && 0 <= i && int(i) < len(ctx.cvt) {
+					ctx.cvt[i] += d
+				}
+			}
+		case op == 0x76 || op == 0x77: // SROUND, S45ROUND
+			gs.roundState = hintRoundSuper
+			gridPeriod := 64.0
+			if op == 0x77 {
+				gs.roundState = hintRoundSuper45
+				gridPeriod = 64.0 * math.Sqrt2 / 2.0
+			}
+			ctx.setSuperRound(ctx.pop(), gridPeriod)
+		case op == 0x78 || op == 0x79: // JROT, JROF
+			b := ctx.pop()
+			offset := int(ctx.pop())
+			if (b != 0) == (op == 0x78) {
+				pc += offset - 1
+				if pc < -1 || len(program) < pc {
+					panic(hintError("bad jump"))
+				}
+			}
+		case op == 0x7A: // ROFF
+			gs.roundState = hintRoundOff
+		case op == 0x7C: // RUTG
+			gs.roundState = hintRoundUpToGrid
+		case op == 0x7D: // RDTG
+			gs.roundState = hintRoundDownToGrid
+		case op == 0x7E || op == 0x7F: // SANGW, AA
+			ctx.pop()
+		case op == 0x80: // FLIPPT
+			for ; 0 < gs.loop; gs.loop-- {
+				zone, i := ctx.point(0)
+				zone.onCurve[i] = !zone.onCurve[i]
+			}
+			gs.loop = 1
+		case op == 0x81 || op == 0x82: // FLIPRGON, FLIPRGOFF
+			zone, hi := ctx.point(0)
+			lo := ctx.pop()
+			zone.check(lo)
+			for i := lo; i <= hi; i++ {
+				zone.onCurve[i] = op == 0x81
+			}
+		case op == 0x85: // SCANCTRL
+			gs.scanControl = ctx.pop()
+		case op == 0x86 || op == 0x87: // SDPVTL
+			zone2, p2 := ctx.point(2)
+			zone1, p1 := ctx.point(1)
+			v := hintSub(zone1.orig[p1], zone2.orig[p2])
+			w := hintSub(zone1.cur[p1], zone2.cur[p2])
+			if op == 0x87 {
+				v = hintPoint{-v.Y, v.X}
+				w = hintPoint{-w.Y, w.X}
+			}
+			gs.dv = hintNormalize(v.X, v.Y)
+			gs.pv = hintNormalize(w.X, w.Y)
+		case op == 0x88: // GETINFO
+			selector := ctx.pop()
+			var v int32
+			if selector&0x01 != 0 {
+				v = 35 // interpreter version
+			}
+			if selector&0x08 != 0 && ctx.sfnt.IsVariable() {
+				v |= 0x0400
+			}
+			if selector&0x20 != 0 {
+				v |= 0x1000 // grayscale rendering
+			}
+			ctx.push(v)
+		case op == 0x89: // IDEF
+			i := ctx.pop()
+			ctx.instrDefs[uint8(i)], pc = hintDefinition(program, pc)
+		case op == 0x8A: // ROLL
+			c := ctx.pop()
+			b := ctx.pop()
+			a := ctx.pop()
+			ctx.push(b)
+			ctx.push(c)
+			ctx.push(a)
+		case op == 0x8B: // MAX
+			b := ctx.pop()
+			if a := ctx.pop(); a < b {
+				ctx.push(b)
+			} else {
+				ctx.push(a)
+			}
+		case op == 0x8C: // MIN
+			b := ctx.pop()
+			if a := ctx.pop(); b < a {
+				ctx.push(b)
+			} else {
+				ctx.push(a)
+			}
+		case op == 0x8D: // SCANTYPE
+			gs.scan = ctx.pop()
+		case op == 0x8E: // INSTCTRL
+			selector := ctx.pop()
+			v := ctx.pop()
+			if 1 <= selector && selector <= 3 {
+				mask := int32(1) << (selector - 1)
+				if v != 0 {
+					gs.instructControl |= mask
+				} else {
+					gs.instructControl &^= mask
+				}
+			}
+		case op == 0x91: // GETVARIATION
+			if !ctx.sfnt.IsVariable() {
+				panic(hintError("GETVARIATION in non-variable font"))
+			}
+			for i := range ctx.sfnt.Fvar.Axes {
+				var v int32
+				if ctx.sfnt.coords != nil {
+					v = int32(math.Round(ctx.sfnt.coords[i] * (1 << 14)))
+				}
+				ctx.push(v)
+			}
+		case op == 0x92: // GETDATA
+			ctx.push(17)
+		case 0xB0 <= op && op <= 0xB7: // PUSHB
+			pc = ctx.pushData(program, pc+1, int(op-0xAF), false) - 1
+		case 0xB8 <= op && op <= 0xBF: // PUSHW
+			pc = ctx.pushData(program, pc+1, int(op-0xB7), true) - 1
+		case 0xC0 <= op && op <= 0xDF: // MDRP
+			ctx.mdrp(op)
+		case 0xE0 <= op: // MIRP
+			ctx.mirp(op)
+		default:
+			body, ok := ctx.instrDefs[op]
+			if !ok {
+				panic(hintError(fmt.Sprintf("unknown instruction 0x%02X", op)))
+			}
+			ctx.exec(body, depth+1)
+		}
+	}
+}
+
+// pushData pushes n bytes or words from the program and returns the position after the data.
+func (ctx *hintContext) pushData(program []byte, pc, n int, words bool) int {
+	size := 1
+	if words {
+		size = 2
+	}
+	if len(program) < pc+n*size {
+		panic(hintError("bad push"))
+	}
+	for i := 0; i < n; i++ {
+		if words {
+			ctx.push(int32(int16(uint16(program[pc])<<8 | uint16(program[pc+1]))))
+		} else {
+			ctx.push(int32(program[pc]))
+		}
+		pc += size
+	}
+	return pc
+}
+
+// delta returns the exception distance encoded in arg when it applies to the current ppem.
+func (ctx *hintContext) delta(arg, base int32) (int32, bool) {
+	if base+(arg>>4&0x0F) != int32(ctx.ppem) {
+		return 0, false
+	}
+	steps := arg&0x0F - 8
+	if 0 <= steps {
+		steps++
+	}
+	return steps * 64 / (1 << ctx.gs.deltaShift), true
+}
+
+// shiftReference returns the reference point used by SHP, SHC, and SHZ, which is either rp1 in zp0 or rp2 in zp1.
+func (ctx *hintContext) shiftReference(useRP1 bool) (*hintZone, int32) {
+	if useRP1 {
+		return ctx.refPoint(1, 0)
+	}
+	return ctx.refPoint(2, 1)
+}
+
+// displacement returns the movement of the reference point used by SHP, SHC, and SHZ.
+func (ctx *hintContext) displacement(useRP1 bool) (int32, int32) {
+	zone, ref := ctx.shiftReference(useRP1)
+	d := ctx.project(hintSub(zone.cur[ref], zone.orig[ref]))
+	dot := ctx.freedomDotProjection()
+	return hintMulDiv(d, ctx.gs.fv[0], dot), hintMulDiv(d, ctx.gs.fv[1], dot)
+}
+
+func (ctx *hintContext) isect(zone *hintZone, i int32, a0, a1, b0, b1 hintPoint) {
+	dbx, dby := b1.X-b0.X, b1.Y-b0.Y
+	dax, day := a1.X-a0.X, a1.Y-a0.Y
+	dx, dy := b0.X-a0.X, b0.Y-a0.Y
+	discriminant := int64(dax)*int64(-dby) + int64(day)*int64(dbx)
+	dotProduct := int64(dax)*int64(dbx) + int64(day)*int64(dby)
+	abs := func(v int64) int64 {
+		if v < 0 {
+			return -v
+		}
+		return v
+	}
+	if 19*abs(discriminant) > abs(dotProduct) {
+		v := int64(dx)*int64(-dby) + int64(dy)*int64(dbx)
+		zone.cur[i] = hintPoint{
+			X: a0.X + int32(float64(v)*float64(dax)/float64(discriminant)),
+			Y: a0.Y + int32(float64(v)*float64(day)/float64(discriminant)),
+		}
+	} else {
+		// parallel lines
+		zone.cur[i] = hintPoint{
+			X: (a0.X + a1.X + b0.X + b1.X) / 4,
+			Y: (a0.Y + a1.Y + b0.Y + b1.Y) / 4,
+		}
+	}
+	zone.touched[i] |= hintTouchedX | hintTouchedY
+}
+
+// interpolate moves points so that their relative position between rp1 and rp2 is as in the original outline.
+func (ctx *hintContext) interpolate() {
+	gs := &ctx.gs
+	zone1, rp1 := ctx.refPoint(1, 0)
+	zone2, rp2 := ctx.refPoint(2, 1)
+	zone := ctx.zone(2)
+	twilight := zone1.orus == nil || zone2.orus == nil || zone.orus == nil
+	orig := func(zone *hintZone, i int32) hintPoint {
+		if twilight {
+			return zone.orig[i]
+		}
+		return zone.orus[i]
+	}
+
+	origBase := ctx.dualProject(orig(zone1, rp1))
+	curBase := ctx.project(zone1.cur[rp1])
+	origRange := ctx.dualProject(orig(zone2, rp2)) - origBase
+	curRange := ctx.project(zone2.cur[rp2]) - curBase
+	for ; 0 < gs.loop; gs.loop-- {
+		zone, i := ctx.point(2)
+		origDist := ctx.dualProject(orig(zone, i)) - origBase
+		curDist := ctx.project(zone.cur[i]) - curBase
+		var newDist int32
+		if origDist != 0 {
+			if origRange != 0 {
+				newDist = hintMulDiv(origDist, curRange, origRange)
+			} else if twilight {
+				newDist = origDist
+			} else {
+				newDist = hintMulFix(origDist, zone.orusScale)
+			}
+		}
+		ctx.move(zone, i, newDist-curDist, true)
+	}
+	gs.loop = 1
+}
+
+// mdrp moves a point relative to rp0 by its original distance.
+func (ctx *hintContext) mdrp(op byte) {
+	gs := &ctx.gs
+	zone, i := ctx.point(1)
+	refZone, ref := ctx.refPoint(0, 0)
+
+	origDist := ctx.origDistance(zone, i, refZone, ref)
+	if d := origDist - gs.singleWidth; 0 < gs.singleWidthCutIn && -gs.singleWidthCutIn < d && d < gs.singleWidthCutIn {
+		if 0 <= origDist {
+			origDist = gs.singleWidth
+		} else {
+			origDist = -gs.singleWidth
+		}
+	}
+
+	dist := origDist
+	if op&0x04 != 0 {
+		dist = ctx.round(origDist)
+	}
+	if op&0x08 != 0 {
+		dist = ctx.minimumDistance(origDist, dist)
+	}
+	curDist := ctx.project(hintSub(zone.cur[i], refZone.cur[ref]))
+	ctx.move(zone, i, dist-curDist, true)
+
+	gs.rp[1], gs.rp[2] = gs.rp[0], i
+	if op&0x10 != 0 {
+		gs.rp[0] = i
+	}
+}
+
+// mirp moves a point relative to rp0 by a distance from the control value table.
+func (ctx *hintContext) mirp(op byte) {
+	gs := &ctx.gs
+	cvtDist := ctx.cvtValue(ctx.pop())
+	zone, i := ctx.point(1)
+	refZone, ref := ctx.refPoint(0, 0)
+
+	if d := cvtDist - gs.singleWidth; -gs.singleWidthCutIn < d && d < gs.singleWidthCutIn {
+		if 0 <= cvtDist {
+			cvtDist = gs.singleWidth
+		} else {
+			cvtDist = -gs.singleWidth
+		}
+	}
+	if gs.zp[1] == 0 {
+		zone.orig[i] = hintPoint{
+			X: refZone.orig[ref].X + hintMul14(cvtDist, gs.fv[0]),
+			Y: refZone.orig[ref].Y + hintMul14(cvtDist, gs.fv[1]),
+		}
+		zone.cur[i] = zone.orig[i]
+	}
+
+	origDist := ctx.dualProject(hintSub(zone.orig[i], refZone.orig[ref]))
+	curDist := ctx.project(hintSub(zone.cur[i], refZone.cur[ref]))
+	if gs.autoFlip && (origDist^cvtDist) < 0 {
+		cvtDist = -cvtDist
+	}
+
+	dist := cvtDist
+	if op&0x04 != 0 {
+		if d := cvtDist - origDist; gs.zp[0] == gs.zp[1] && (gs.cvtCutIn < d || d < -gs.cvtCutIn) {
+			cvtDist = origDist
+		}
+		dist = ctx.round(cvtDist)
+	}
+	if op&0x08 != 0 {
+		dist = ctx.minimumDistance(origDist, dist)
+	}
+	ctx.move(zone, i, dist-curDist, true)
+
+	gs.rp[1], gs.rp[2] = gs.rp[0], i
+	if op&0x10 != 0 {
+		gs.rp[0] = i
+	}
+}
+
+// minimumDistance keeps the distance at least the minimum distance in the direction of the original distance.
+func (ctx *hintContext) minimumDistance(origDist, dist int32) int32 {
+	if 0 <= origDist {
+		if dist < ctx.gs.minDist {
+			return ctx.gs.minDist
+		}
+	} else if -ctx.gs.minDist < dist {
+		return -ctx.gs.minDist
+	}
+	return dist
+}
+
+// iup interpolates the untouched points of the glyph zone in the x or y direction between the touched points of each contour.
+func (ctx *hintContext) iup(x bool) {
+	zone := ctx.zones[1]
+	flag := uint8(hintTouchedY)
+	coord := func(p *hintPoint) *int32 { return &p.Y }
+	if x {
+		flag = hintTouchedX
+		coord = func(p *hintPoint) *int32 { return &p.X }
+	}
+
+	start := 0
+	for _, endPoint := range zone.endPoints {
+		end := int(endPoint)
+		if len(zone.cur)-4 <= end {
+			panic(hintError("bad contour"))
+		}
+
+		first := -1
+		for i := start; i <= end; i++ {
+			if zone.touched[i]&flag != 0 {
+				first = i
+				break
+			}
+		}
+		if first == -1 {
+			start = end + 1
+			continue
+		}
+
+		prev := first
+		for i := first + 1; i <= end+1; i++ {
+			cur := i
+			if i == end+1 {
+				cur = first
+			} else if zone.touched[i]&flag == 0 {
+				continue
+			}
+			ctx.iupRange(zone, coord, start, end, prev, cur)
+			prev = cur
+			if cur == first {
+				break
+			}
+		}
+		start = end + 1
+	}
+}
+
+// iupRange interpolates the untouched points strictly between the touched points p1 and p2, wrapping around the contour from start to end.
+func (ctx *hintContext) iupRange(zone *hintZone, coord func(*hintPoint) *int32, start, end, p1, p2 int) {
+	next := func(i int) int {
+		if i == end {
+			return start
+		}
+		return i + 1
+	}
+	if next(p1) == p2 && p1 != p2 {
+		return
+	}
+
+	u1, u2 := *coord(&zone.orus[p1]), *coord(&zone.orus[p2])
+	o1, o2 := *coord(&zone.orig[p1]), *coord(&zone.orig[p2])
+	c1, c2 := *coord(&zone.cur[p1]), *coord(&zone.cur[p2])
+	if u2 < u1 {
+		u1, u2 = u2, u1
+		o1, o2 = o2, o1
+		c1, c2 = c2, c1
+	}
+	var scale int32 // 16.16
+	if c1 != c2 && u1 != u2 {
+		scale = int32((int64(c2-c1)<<16 + int64(u2-u1)/2) / int64(u2-u1))
+	}
+	for i := next(p1); i != p2; i = next(i) {
+		o := *coord(&zone.orig[i])
+		v := coord(&zone.cur[i])
+		if o <= o1 {
+			*v = o + c1 - o1
+		} else if o2 <= o {
+			*v = o + c2 - o2
+		} else if scale == 0 {
+			*v = c1
+		} else {
+			*v = c1 + hintMulFix(*coord(&zone.orus[i])-u1, scale)
+		}
+	}
+}
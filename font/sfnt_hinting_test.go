@@ -0,0 +1,112 @@
+package font
+
+import (
+	"io/ioutil"
+	"math"
+	"testing"
+
+	"github.com/tdewolff/test"
+)
+
+func TestHintingInstructions(t *testing.T) {
+	var tts = []struct {
+		program []byte
+		stack   []int32
+	}{
+		{[]byte{0xB1, 5, 3, 0x60}, []int32{8}},                                     // PUSHB[1] ADD
+		{[]byte{0xB1, 5, 3, 0x61}, []int32{2}},                                     // SUB
+		{[]byte{0xB1, 64, 128, 0x62}, []int32{32}},                                 // DIV
+		{[]byte{0xB1, 96, 96, 0x63}, []int32{144}},                                 // MUL
+		{[]byte{0xB0, 80, 0x68}, []int32{64}},                                      // ROUND[00]
+		{[]byte{0xB1, 1, 2, 0x23}, []int32{2, 1}},                                  // SWAP
+		{[]byte{0xB0, 0, 0x58, 0xB0, 1, 0x1B, 0xB0, 2, 0x59}, []int32{2}},          // IF ELSE EIF
+		{[]byte{0xB0, 1, 0x58, 0xB0, 1, 0x1B, 0xB0, 2, 0x59}, []int32{1}},          // IF ELSE EIF
+		{[]byte{0xB0, 0, 0x2C, 0xB0, 7, 0x2D, 0xB0, 0, 0x2B}, []int32{7}},          // FDEF ENDF CALL
+		{[]byte{0xB0, 0, 0x2C, 0xB0, 7, 0x2D, 0xB1, 3, 0, 0x2A}, []int32{7, 7, 7}}, // LOOPCALL
+		{[]byte{0x40, 2, 1, 2}, []int32{1, 2}},                                     // NPUSHB
+	}
+	for _, tt := range tts {
+		ctx := &hintContext{
+			functions: map[int32][]byte{},
+			instrDefs: map[uint8][]byte{},
+			gs:        defaultHintGraphicsState,
+			zones:     [2]*hintZone{newHintZone(0), newHintZone(0)},
+		}
+		test.Error(t, ctx.run(tt.program))
+		test.T(t, ctx.stack, tt.stack, tt.program)
+	}
+
+	ctx := &hintContext{
+		gs:    defaultHintGraphicsState,
+		zones: [2]*hintZone{newHintZone(0), newHintZone(0)},
+	}
+	test.That(t, ctx.run([]byte{0x60}) != nil, "stack underflow")
+	test.That(t, ctx.run([]byte{0xB0, 0, 0x2B}) != nil, "undefined function")
+}
+
+func TestHintingTrueType(t *testing.T) {
+	b, err := ioutil.ReadFile("../resources/DejaVuSerif.ttf")
+	test.Error(t, err)
+	sfnt, err := ParseSFNT(b, 0)
+	test.Error(t, err)
+
+	// reference outline in 26.6 pixels from FreeType at 11 ppem
+	H := sfnt.GlyphIndex('H')
+	ppem := uint16(11)
+	expected := []float64{64, 0, 64, 64, 128, 64, 128, 448, 64, 448, 64, 512, 256, 512, 256, 448, 192, 448, 192, 320, 448, 320, 448, 448, 384, 448, 384, 512, 576, 512, 576, 448, 512, 448, 512, 64, 576, 64, 576, 0, 384, 0, 384, 64, 448, 64, 448, 256, 192, 256, 192, 64, 256, 64, 256, 0}
+
+	contour, err := sfnt.Glyf.hinter.Hint(H, ppem)
+	test.Error(t, err)
+	scale := 64.0 * float64(ppem) / float64(sfnt.Head.UnitsPerEm)
+	coords := []float64{}
+	for i := range contour.XCoordinates {
+		coords = append(coords, math.Round(contour.XCoordinates[i]*scale), math.Round(contour.YCoordinates[i]*scale))
+	}
+	test.T(t, coords, expected)
+
+	// hinting changes the outline only at non-zero ppem
+	p, p2 := &pointsPather{}, &pointsPather{}
+	test.Error(t, sfnt.GlyphPath(p, H, 0, 0.0, 0.0, 1.0, NoHinting))
+	test.Error(t, sfnt.GlyphPath(p2, H, 0, 0.0, 0.0, 1.0, FontHinting))
+	test.T(t, p2.points, p.points)
+	test.Error(t, sfnt.GlyphPath(p2, H, ppem, 0.0, 0.0, 1.0, FontHinting))
+	test.That(t, len(p2.points) == 2*len(p.points))
+}
+
+func TestHintingAuto(t *testing.T) {
+	b, err := ioutil.ReadFile("../resources/EBGaramond12-Regular.otf")
+	test.Error(t, err)
+	sfnt, err := ParseSFNT(b, 0)
+	test.Error(t, err)
+
+	H := sfnt.GlyphIndex('H')
+	zones := sfnt.blueZones(H)
+	test.T(t, zones[0], blueZone{0.0, -22.0}) // baseline
+
+	ppem := uint16(9)
+	px := float64(sfnt.Head.UnitsPerEm) / float64(ppem)
+	r, r2 := &glyphRecorder{}, &glyphRecorder{}
+	test.Error(t, sfnt.GlyphPath(r, H, ppem, 0.0, 0.0, 1.0, NoHinting))
+	test.Error(t, sfnt.GlyphPath(r2, H, ppem, 0.0, 0.0, 1.0, AutoHinting))
+	test.T(t, r2.ops, r.ops)
+	for i, pt := range r2.pts {
+		test.T(t, pt[0], r.pts[i][0]) // horizontal coordinates are unchanged
+	}
+
+	// points on the baseline, the cap height, and the crossbar are on the pixel grid
+	for i, pt := range r.pts {
+		switch pt[1] {
+		case 0.0, 302.0, 350.0, 650.0:
+			v := r2.pts[i][1] / px
+			test.Float(t, v, math.Round(v), pt[1])
+		}
+	}
+
+	fy := map[float64]float64{}
+	for i, pt := range r.pts {
+		fy[pt[1]] = r2.pts[i][1]
+	}
+	test.Float(t, fy[0.0], 0.0)
+	test.That(t, 1.0 <= math.Round(fy[24.0]/px), "serif is at least a pixel high")
+	test.That(t, 1.0 <= math.Round((fy[350.0]-fy[302.0])/px), "crossbar is at least a pixel wide")
+}
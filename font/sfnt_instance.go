@@ -0,0 +1,574 @@
+package font
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// variationTables are the tables that are removed from static instances of variable fonts.
+var variationTables = []string{"avar", "cvar", "fvar", "gvar", "HVAR", "MVAR", "STAT", "VVAR"}
+
+type mvarField struct {
+	table  string
+	offset int
+}
+
+// mvarFields are the positions of the metrics that the MVAR value tags refer to, see SFNT.metric.
+var mvarFields = map[string]mvarField{
+	"hcla": {"OS/2", 74},
+	"hcld": {"OS/2", 76},
+	"hasc": {"OS/2", 68},
+	"hdsc": {"OS/2", 70},
+	"hlgp": {"OS/2", 72},
+	"xhgt": {"OS/2", 86},
+	"cpht": {"OS/2", 88},
+	"sbxs": {"OS/2", 10},
+	"sbys": {"OS/2", 12},
+	"sbxo": {"OS/2", 14},
+	"sbyo": {"OS/2", 16},
+	"spxs": {"OS/2", 18},
+	"spys": {"OS/2", 20},
+	"spxo": {"OS/2", 22},
+	"spyo": {"OS/2", 24},
+	"strs": {"OS/2", 26},
+	"stro": {"OS/2", 28},
+	"hcrs": {"hhea", 18},
+	"hcrn": {"hhea", 20},
+	"hcof": {"hhea", 22},
+	"vasc": {"vhea", 4},
+	"vdsc": {"vhea", 6},
+	"vlgp": {"vhea", 8},
+	"vcrs": {"vhea", 18},
+	"vcrn": {"vhea", 20},
+	"vcof": {"vhea", 22},
+	"unds": {"post", 10},
+	"undo": {"post", 8},
+}
+
+// Instance returns a static font for the given location in the design space (see SetVariations), which has the glyph outlines, advances, and metrics of that location and no variation tables. This is needed to embed variable fonts in formats that do not support font variations, such as PDF. CFF2 fonts are converted to CFF fonts without hints, and the hinting instructions of TrueType fonts are kept but the control values are not varied. It returns the font itself if it is not variable, and the current variations of the font are left unchanged.
+func (sfnt *SFNT) Instance(variations map[string]float64) (*SFNT, error) {
+	if !sfnt.IsVariable() {
+		return sfnt, nil
+	} else if !sfnt.IsTrueType && !sfnt.IsCFF {
+		return nil, fmt.Errorf("only TrueType and CFF are supported")
+	}
+
+	var prev map[string]float64
+	if sfnt.design != nil {
+		prev = sfnt.Variations()
+	}
+	sfnt.SetVariations(variations)
+	defer sfnt.SetVariations(prev)
+
+	tables := make(map[string][]byte, len(sfnt.Tables))
+	for tag, b := range sfnt.Tables {
+		tables[tag] = b
+	}
+	for _, tag := range variationTables {
+		delete(tables, tag)
+	}
+	for _, tag := range []string{"head", "hhea", "OS/2", "post", "vhea"} {
+		if b, ok := tables[tag]; ok {
+			tables[tag] = append([]byte{}, b...) // copy before modifying
+		}
+	}
+
+	// metrics from the MVAR table
+	if sfnt.Mvar != nil {
+		for _, record := range sfnt.Mvar.records {
+			v, set := sfnt.metric(record.Tag)
+			field, ok := mvarFields[record.Tag]
+			if b := tables[field.table]; set != nil && ok && field.offset+2 <= len(b) {
+				binary.BigEndian.PutUint16(b[field.offset:], uint16(v))
+			}
+		}
+	}
+
+	// horizontal metrics and bounding boxes
+	numGlyphs := sfnt.Maxp.NumGlyphs
+	var advanceWidthMax uint16
+	minLeftSideBearing, minRightSideBearing, xMaxExtent := int16(math.MaxInt16), int16(math.MaxInt16), int16(math.MinInt16)
+	xMin, yMin, xMax, yMax := int16(math.MaxInt16), int16(math.MaxInt16), int16(math.MinInt16), int16(math.MinInt16)
+	hmtx := NewBinaryWriter([]byte{})
+	for glyphID := uint16(0); glyphID < numGlyphs; glyphID++ {
+		advance := sfnt.GlyphAdvance(glyphID)
+		lsb := sfnt.Hmtx.LeftSideBearing(glyphID)
+		x0, y0, x1, y1, err := sfnt.GlyphBounds(glyphID)
+		if err != nil {
+			return nil, err
+		} else if x0 < x1 || y0 < y1 {
+			lsb = x0
+			if x0 < minLeftSideBearing {
+				minLeftSideBearing = x0
+			}
+			if rsb := int16(advance) - x1; rsb < minRightSideBearing {
+				minRightSideBearing = rsb
+			}
+			if xMaxExtent < x1 {
+				xMaxExtent = x1
+			}
+			if x0 < xMin {
+				xMin = x0
+			}
+			if y0 < yMin {
+				yMin = y0
+			}
+			if xMax < x1 {
+				xMax = x1
+			}
+			if yMax < y1 {
+				yMax = y1
+			}
+		}
+		if advanceWidthMax < advance {
+			advanceWidthMax = advance
+		}
+		hmtx.WriteUint16(advance)
+		hmtx.WriteInt16(lsb)
+	}
+	if xMax < xMin {
+		xMin, yMin, xMax, yMax = 0, 0, 0, 0
+		minLeftSideBearing, minRightSideBearing, xMaxExtent = 0, 0, 0
+	}
+	tables["hmtx"] = hmtx.Bytes()
+
+	hhea := tables["hhea"]
+	binary.BigEndian.PutUint16(hhea[10:], advanceWidthMax)
+	binary.BigEndian.PutUint16(hhea[12:], uint16(minLeftSideBearing))
+	binary.BigEndian.PutUint16(hhea[14:], uint16(minRightSideBearing))
+	binary.BigEndian.PutUint16(hhea[16:], uint16(xMaxExtent))
+	binary.BigEndian.PutUint16(hhea[34:], numGlyphs) // numberOfHMetrics
+
+	head := tables["head"]
+	binary.BigEndian.PutUint16(head[36:], uint16(xMin))
+	binary.BigEndian.PutUint16(head[38:], uint16(yMin))
+	binary.BigEndian.PutUint16(head[40:], uint16(xMax))
+	binary.BigEndian.PutUint16(head[42:], uint16(yMax))
+
+	// glyph outlines
+	if sfnt.IsTrueType {
+		glyf := NewBinaryWriter([]byte{})
+		loca := NewBinaryWriter([]byte{})
+		for glyphID := uint16(0); glyphID < numGlyphs; glyphID++ {
+			loca.WriteUint32(glyf.Len())
+			b, err := sfnt.Glyf.instance(glyphID)
+			if err != nil {
+				return nil, err
+			}
+			glyf.WriteBytes(b)
+			for glyf.Len()%4 != 0 {
+				glyf.WriteByte(0)
+			}
+		}
+		loca.WriteUint32(glyf.Len())
+		tables["glyf"] = glyf.Bytes()
+		tables["loca"] = loca.Bytes()
+		binary.BigEndian.PutUint16(head[50:], 1) // long indexToLocFormat
+	} else if _, ok := tables["CFF2"]; ok {
+		b, err := sfnt.instanceCFF([4]int16{xMin, yMin, xMax, yMax})
+		if err != nil {
+			return nil, err
+		}
+		delete(tables, "CFF2")
+		tables["CFF "] = b
+
+		// glyph names are in the CFF table
+		post := tables["post"][:32]
+		binary.BigEndian.PutUint32(post, 0x00030000)
+		tables["post"] = post
+	}
+
+	instance := &SFNT{
+		IsTrueType: sfnt.IsTrueType,
+		IsCFF:      sfnt.IsCFF,
+		Tables:     tables,
+	}
+	return ParseSFNT(instance.Write(), 0)
+}
+
+// instance returns the glyph data at the current variations.
+func (glyf *glyfTable) instance(glyphID uint16) ([]byte, error) {
+	b := glyf.Get(glyphID)
+	if b == nil {
+		return nil, fmt.Errorf("glyf: bad glyphID %v", glyphID)
+	} else if len(b) == 0 || glyf.gvar == nil || glyf.coords == nil {
+		return b, nil
+	}
+
+	contour, err := glyf.Contour(glyphID, 0)
+	if err != nil {
+		return nil, err
+	}
+	numberOfContours := int16(binary.BigEndian.Uint16(b))
+
+	w := NewBinaryWriter([]byte{})
+	w.WriteInt16(numberOfContours)
+	w.WriteInt16(contour.XMin)
+	w.WriteInt16(contour.YMin)
+	w.WriteInt16(contour.XMax)
+	w.WriteInt16(contour.YMax)
+	if 0 <= numberOfContours {
+		for _, endPoint := range contour.EndPoints {
+			w.WriteUint16(endPoint)
+		}
+		w.WriteUint16(uint16(len(contour.Instructions)))
+		w.WriteBytes(contour.Instructions)
+
+		// encode the coordinates as relative bytes when possible and repeat equal flags
+		flags := make([]byte, len(contour.XCoordinates))
+		xs, ys := NewBinaryWriter([]byte{}), NewBinaryWriter([]byte{})
+		var x, y int16
+		for i := range flags {
+			if contour.OnCurve[i] {
+				flags[i] |= 0x01 // ON_CURVE_POINT
+			}
+			dx, dy := contour.XCoordinates[i]-x, contour.YCoordinates[i]-y
+			x, y = contour.XCoordinates[i], contour.YCoordinates[i]
+			if dx == 0 {
+				flags[i] |= 0x10 // X_IS_SAME_OR_POSITIVE_X_SHORT_VECTOR
+			} else if -256 < dx && dx < 256 {
+				flags[i] |= 0x02 // X_SHORT_VECTOR
+				if 0 < dx {
+					flags[i] |= 0x10 // X_IS_SAME_OR_POSITIVE_X_SHORT_VECTOR
+				} else {
+					dx = -dx
+				}
+				xs.WriteUint8(uint8(dx))
+			} else {
+				xs.WriteInt16(dx)
+			}
+			if dy == 0 {
+				flags[i] |= 0x20 // Y_IS_SAME_OR_POSITIVE_Y_SHORT_VECTOR
+			} else if -256 < dy && dy < 256 {
+				flags[i] |= 0x04 // Y_SHORT_VECTOR
+				if 0 < dy {
+					flags[i] |= 0x20 // Y_IS_SAME_OR_POSITIVE_Y_SHORT_VECTOR
+				} else {
+					dy = -dy
+				}
+				ys.WriteUint8(uint8(dy))
+			} else {
+				ys.WriteInt16(dy)
+			}
+		}
+		for i := 0; i < len(flags); {
+			repeat := 0
+			for i+repeat+1 < len(flags) && flags[i+repeat+1] == flags[i] && repeat < 255 {
+				repeat++
+			}
+			if 1 < repeat {
+				w.WriteUint8(flags[i] | 0x08) // REPEAT_FLAG
+				w.WriteUint8(uint8(repeat))
+			} else {
+				w.WriteUint8(flags[i])
+				repeat = 0
+			}
+			i += repeat + 1
+		}
+		w.WriteBytes(xs.Bytes())
+		w.WriteBytes(ys.Bytes())
+		return w.Bytes(), nil
+	}
+
+	// composite glyph, the deltas move the component offsets
+	n, err := glyf.numPoints(glyphID)
+	if err != nil {
+		return nil, err
+	}
+	dxs, dys, err := glyf.gvar.Deltas(glyphID, glyf.coords, n+4, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	r := NewBinaryReader(b[10:])
+	for component := 0; ; component++ {
+		flags := r.ReadUint16()
+		subGlyphID := r.ReadUint16()
+		length, more := glyfCompositeLength(flags)
+
+		var dx, dy int16
+		argsAreWords := flags&0x0001 != 0 // ARG_1_AND_2_ARE_WORDS
+		if argsAreWords {
+			dx = r.ReadInt16()
+			dy = r.ReadInt16()
+			length -= 8
+		} else {
+			dx = int16(r.ReadInt8())
+			dy = int16(r.ReadInt8())
+			length -= 6
+		}
+		if flags&0x0002 != 0 { // ARGS_ARE_XY_VALUES
+			dx += int16(math.Round(dxs[component]))
+			dy += int16(math.Round(dys[component]))
+			argsAreWords = dx < math.MinInt8 || math.MaxInt8 < dx || dy < math.MinInt8 || math.MaxInt8 < dy
+		}
+
+		if argsAreWords {
+			w.WriteUint16(flags | 0x0001)
+			w.WriteUint16(subGlyphID)
+			w.WriteInt16(dx)
+			w.WriteInt16(dy)
+		} else {
+			w.WriteUint16(flags &^ 0x0001)
+			w.WriteUint16(subGlyphID)
+			w.WriteInt8(int8(dx))
+			w.WriteInt8(int8(dy))
+		}
+		w.WriteBytes(r.ReadBytes(length)) // transformation
+		if !more {
+			break
+		}
+	}
+	w.WriteBytes(r.ReadBytes(r.Len())) // instructions
+	return w.Bytes(), nil
+}
+
+// cffCharStringPather encodes a glyph path as a Type 2 charstring with rounded coordinates.
+type cffCharStringPather struct {
+	w     *BinaryWriter
+	x, y  int
+	width int
+	empty bool
+}
+
+func (p *cffCharStringPather) writeWidth() {
+	if p.empty {
+		writeCharStringInt(p.w, p.width)
+		p.empty = false
+	}
+}
+
+func (p *cffCharStringPather) rel(x, y float64) (int, int) {
+	ix, iy := int(math.Round(x)), int(math.Round(y))
+	dx, dy := ix-p.x, iy-p.y
+	p.x, p.y = ix, iy
+	return dx, dy
+}
+
+func (p *cffCharStringPather) MoveTo(x, y float64) {
+	p.writeWidth()
+	dx, dy := p.rel(x, y)
+	writeCharStringInt(p.w, dx)
+	writeCharStringInt(p.w, dy)
+	p.w.WriteUint8(21) // rmoveto
+}
+
+func (p *cffCharStringPather) LineTo(x, y float64) {
+	dx, dy := p.rel(x, y)
+	writeCharStringInt(p.w, dx)
+	writeCharStringInt(p.w, dy)
+	p.w.WriteUint8(5) // rlineto
+}
+
+func (p *cffCharStringPather) QuadTo(cpx, cpy, x, y float64) {
+	x0, y0 := float64(p.x), float64(p.y)
+	p.CubeTo(x0+2.0/3.0*(cpx-x0), y0+2.0/3.0*(cpy-y0), x+2.0/3.0*(cpx-x), y+2.0/3.0*(cpy-y), x, y)
+}
+
+func (p *cffCharStringPather) CubeTo(cp1x, cp1y, cp2x, cp2y, x, y float64) {
+	for _, d := range [][2]float64{{cp1x, cp1y}, {cp2x, cp2y}, {x, y}} {
+		dx, dy := p.rel(d[0], d[1])
+		writeCharStringInt(p.w, dx)
+		writeCharStringInt(p.w, dy)
+	}
+	p.w.WriteUint8(8) // rrcurveto
+}
+
+func (p *cffCharStringPather) Close() {
+}
+
+func writeCharStringInt(w *BinaryWriter, v int) {
+	if -107 <= v && v <= 107 {
+		w.WriteUint8(uint8(v + 139))
+	} else if 108 <= v && v <= 1131 {
+		v -= 108
+		w.WriteUint8(uint8(v>>8 + 247))
+		w.WriteUint8(uint8(v))
+	} else if -1131 <= v && v <= -108 {
+		v = -v - 108
+		w.WriteUint8(uint8(v>>8 + 251))
+		w.WriteUint8(uint8(v))
+	} else {
+		w.WriteUint8(28)
+		w.WriteInt16(int16(v))
+	}
+}
+
+func writeDICTInt(w *BinaryWriter, v int) {
+	if -32768 <= v && v <= 32767 {
+		writeCharStringInt(w, v) // same encoding
+	} else {
+		w.WriteUint8(29)
+		w.WriteInt32(int32(v))
+	}
+}
+
+func writeDICTReal(w *BinaryWriter, v float64) {
+	s := strings.ToUpper(strconv.FormatFloat(v, 'g', -1, 64))
+	s = strings.Replace(strings.Replace(s, "E-", "c", 1), "E+", "b", 1)
+	nibbles := []byte{}
+	for _, c := range []byte(s) {
+		switch c {
+		case '.':
+			nibbles = append(nibbles, 0xa)
+		case 'b':
+			nibbles = append(nibbles, 0xb)
+		case 'c':
+			nibbles = append(nibbles, 0xc)
+		case '-':
+			nibbles = append(nibbles, 0xe)
+		default:
+			nibbles = append(nibbles, c-'0')
+		}
+	}
+	nibbles = append(nibbles, 0xf)
+	if len(nibbles)%2 == 1 {
+		nibbles = append(nibbles, 0xf)
+	}
+	w.WriteUint8(30)
+	for i := 0; i < len(nibbles); i += 2 {
+		w.WriteUint8(nibbles[i]<<4 | nibbles[i+1])
+	}
+}
+
+func writeINDEX(w *BinaryWriter, items [][]byte) {
+	w.WriteUint16(uint16(len(items)))
+	if len(items) == 0 {
+		return
+	}
+	n := 1
+	for _, item := range items {
+		n += len(item)
+	}
+	offSize := 4
+	if n <= math.MaxUint8 {
+		offSize = 1
+	} else if n <= math.MaxUint16 {
+		offSize = 2
+	} else if n <= 1<<24-1 {
+		offSize = 3
+	}
+	w.WriteUint8(uint8(offSize))
+	offset := uint32(1)
+	for i := 0; i <= len(items); i++ {
+		switch offSize {
+		case 1:
+			w.WriteUint8(uint8(offset))
+		case 2:
+			w.WriteUint16(uint16(offset))
+		case 3:
+			w.WriteUint8(uint8(offset >> 16))
+			w.WriteUint16(uint16(offset))
+		default:
+			w.WriteUint32(offset)
+		}
+		if i < len(items) {
+			offset += uint32(len(items[i]))
+		}
+	}
+	for _, item := range items {
+		w.WriteBytes(item)
+	}
+}
+
+// instanceCFF returns a CFF table with the glyph outlines of the CFF2 table at the current variations.
+func (sfnt *SFNT) instanceCFF(bbox [4]int16) ([]byte, error) {
+	numGlyphs := sfnt.Maxp.NumGlyphs
+	charStrings := make([][]byte, numGlyphs)
+	strs := make([][]byte, 0, numGlyphs)
+	for glyphID := uint16(0); glyphID < numGlyphs; glyphID++ {
+		p := &cffCharStringPather{
+			w:     NewBinaryWriter([]byte{}),
+			width: int(sfnt.GlyphAdvance(glyphID)), // nominalWidthX is zero
+			empty: true,
+		}
+		if err := sfnt.CFF.ToPath(p, glyphID, 0, 0.0, 0.0, 1.0, NoHinting); err != nil {
+			return nil, err
+		}
+		p.writeWidth()
+		p.w.WriteUint8(14) // endchar
+		charStrings[glyphID] = p.w.Bytes()
+
+		if glyphID != 0 {
+			name := sfnt.GlyphName(glyphID)
+			if name == "" {
+				name = fmt.Sprintf("glyph%d", glyphID)
+			}
+			strs = append(strs, []byte(name))
+		}
+	}
+
+	name := "Instance"
+	if sfnt.Name != nil {
+		if records := sfnt.Name.Get(NamePostScript); 0 < len(records) {
+			name = records[0].String()
+		}
+	}
+
+	// charset with the glyph names from the String INDEX
+	charset := NewBinaryWriter([]byte{})
+	charset.WriteUint8(0) // format
+	for i := range strs {
+		charset.WriteUint16(uint16(len(cffStandardStrings) + i))
+	}
+
+	private := NewBinaryWriter([]byte{})
+	writeDICTInt(private, 0)
+	private.WriteUint8(21) // nominalWidthX
+
+	// the offsets in the Top DICT have a fixed size, so that we can calculate its length beforehand
+	topDICT := func(charsetOffset, charStringsOffset, privateOffset int) []byte {
+		w := NewBinaryWriter([]byte{})
+		if fontMatrix := sfnt.CFF.FontMatrix(); fontMatrix != [6]float64{0.001, 0.0, 0.0, 0.001, 0.0, 0.0} {
+			for _, v := range fontMatrix {
+				writeDICTReal(w, v)
+			}
+			w.WriteUint8(12)
+			w.WriteUint8(7) // FontMatrix
+		}
+		for _, v := range bbox {
+			writeDICTInt(w, int(v))
+		}
+		w.WriteUint8(5) // FontBBox
+		w.WriteUint8(29)
+		w.WriteInt32(int32(charsetOffset))
+		w.WriteUint8(15) // charset
+		w.WriteUint8(29)
+		w.WriteInt32(int32(charStringsOffset))
+		w.WriteUint8(17) // CharStrings
+		w.WriteUint8(29)
+		w.WriteInt32(int32(private.Len()))
+		w.WriteUint8(29)
+		w.WriteInt32(int32(privateOffset))
+		w.WriteUint8(18) // Private
+		return w.Bytes()
+	}
+
+	w := NewBinaryWriter([]byte{})
+	w.WriteUint8(1) // major
+	w.WriteUint8(0) // minor
+	w.WriteUint8(4) // hdrSize
+	w.WriteUint8(4) // offSize
+	writeINDEX(w, [][]byte{[]byte(strings.ReplaceAll(name, " ", ""))})
+	topStart := w.Len()
+	writeINDEX(w, [][]byte{topDICT(0, 0, 0)})
+	topEnd := w.Len()
+	writeINDEX(w, strs)
+	writeINDEX(w, nil) // Global Subr INDEX
+
+	charsetOffset := int(w.Len())
+	w.WriteBytes(charset.Bytes())
+	charStringsOffset := int(w.Len())
+	writeINDEX(w, charStrings)
+	privateOffset := int(w.Len())
+	w.WriteBytes(private.Bytes())
+
+	// rewrite the Top DICT INDEX with the offsets
+	b := w.Bytes()
+	top := NewBinaryWriter([]byte{})
+	writeINDEX(top, [][]byte{topDICT(charsetOffset, charStringsOffset, privateOffset)})
+	copy(b[topStart:topEnd], top.Bytes())
+	return b, nil
+}
@@ -0,0 +1,97 @@
+package font
+
+import (
+	"io/ioutil"
+	"math"
+	"testing"
+
+	"github.com/tdewolff/test"
+)
+
+type pointsPather struct {
+	points [][2]float64
+}
+
+func (p *pointsPather) MoveTo(x, y float64) {
+	p.points = append(p.points, [2]float64{math.Round(x), math.Round(y)})
+}
+
+func (p *pointsPather) LineTo(x, y float64) {
+	p.points = append(p.points, [2]float64{math.Round(x), math.Round(y)})
+}
+
+func (p *pointsPather) QuadTo(cpx, cpy, x, y float64) {
+	p.points = append(p.points, [2]float64{math.Round(cpx), math.Round(cpy)}, [2]float64{math.Round(x), math.Round(y)})
+}
+
+func (p *pointsPather) CubeTo(cp1x, cp1y, cp2x, cp2y, x, y float64) {
+	p.points = append(p.points, [2]float64{math.Round(cp1x), math.Round(cp1y)}, [2]float64{math.Round(cp2x), math.Round(cp2y)}, [2]float64{math.Round(x), math.Round(y)})
+}
+
+func (p *pointsPather) Close() {
+}
+
+func TestSFNTInstanceTrueType(t *testing.T) {
+	sfnt := gvarTestFont(t)
+	l, A := sfnt.GlyphIndex('l'), sfnt.GlyphIndex('Á')
+	advance := sfnt.GlyphAdvance(l)
+
+	instance, err := sfnt.Instance(map[string]float64{"wght": 900.0})
+	test.Error(t, err)
+	test.That(t, !instance.IsVariable())
+	test.T(t, sfnt.VariationCoordinates(), []float64(nil)) // unchanged
+	for _, tag := range []string{"fvar", "gvar"} {
+		_, ok := instance.Tables[tag]
+		test.That(t, !ok, tag)
+	}
+
+	sfnt.SetVariations(map[string]float64{"wght": 900.0})
+	test.T(t, instance.GlyphAdvance(l), advance+100)
+	for _, glyphID := range []uint16{l, A, sfnt.GlyphIndex('a')} {
+		contour, err := sfnt.Glyf.Contour(glyphID, 0)
+		test.Error(t, err)
+		contour2, err := instance.Glyf.Contour(glyphID, 0)
+		test.Error(t, err)
+		test.T(t, contour2.XCoordinates, contour.XCoordinates)
+		test.T(t, contour2.YCoordinates, contour.YCoordinates)
+		test.T(t, contour2.OnCurve, contour.OnCurve)
+		test.T(t, contour2.XMin, contour.XMin)
+		test.T(t, instance.Hmtx.LeftSideBearing(glyphID), contour.XMin)
+	}
+
+	// non-variable fonts are returned as is
+	instance2, err := instance.Instance(nil)
+	test.Error(t, err)
+	test.That(t, instance2 == instance)
+}
+
+func TestSFNTInstanceCFF2(t *testing.T) {
+	b, err := ioutil.ReadFile("../resources/AdobeVFPrototype.otf")
+	test.Error(t, err)
+	sfnt, err := ParseSFNT(b, 0)
+	test.Error(t, err)
+	A := sfnt.GlyphIndex('A')
+
+	instance, err := sfnt.Instance(map[string]float64{"wght": 900.0})
+	test.Error(t, err)
+	test.That(t, !instance.IsVariable())
+	test.T(t, instance.CFF.Version(), 1)
+	test.T(t, sfnt.OS2.SxHeight, int16(474)) // unchanged
+
+	// outlines, advances, and metrics are those of the variable font at that location
+	test.T(t, instance.GlyphIndex('A'), A)
+	test.T(t, instance.GlyphAdvance(A), uint16(680))
+	xmin, ymin, xmax, ymax, err := instance.GlyphBounds(A)
+	test.Error(t, err)
+	test.T(t, []int16{xmin, ymin, xmax, ymax}, []int16{10, 0, 665, 652})
+	test.T(t, instance.Hmtx.LeftSideBearing(A), int16(10))
+	test.T(t, instance.OS2.SxHeight, int16(487))
+
+	sfnt.SetVariations(map[string]float64{"wght": 900.0})
+	for _, glyphID := range []uint16{A, sfnt.GlyphIndex('g'), sfnt.GlyphIndex('$')} {
+		p, p2 := &pointsPather{}, &pointsPather{}
+		test.Error(t, sfnt.GlyphPath(p, glyphID, 0, 0.0, 0.0, 1.0, NoHinting))
+		test.Error(t, instance.GlyphPath(p2, glyphID, 0, 0.0, 0.0, 1.0, NoHinting))
+		test.T(t, p2.points, p.points)
+	}
+}
@@ -0,0 +1,398 @@
+package font
+
+import (
+	"fmt"
+)
+
+// MathConstants are the global constants of the MATH table that are used to lay out mathematical formulas, all distances are in font units except for the percentages.
+type MathConstants struct {
+	ScriptPercentScaleDown                   int16
+	ScriptScriptPercentScaleDown             int16
+	DelimitedSubFormulaMinHeight             uint16
+	DisplayOperatorMinHeight                 uint16
+	MathLeading                              int16
+	AxisHeight                               int16
+	AccentBaseHeight                         int16
+	FlattenedAccentBaseHeight                int16
+	SubscriptShiftDown                       int16
+	SubscriptTopMax                          int16
+	SubscriptBaselineDropMin                 int16
+	SuperscriptShiftUp                       int16
+	SuperscriptShiftUpCramped                int16
+	SuperscriptBottomMin                     int16
+	SuperscriptBaselineDropMax               int16
+	SubSuperscriptGapMin                     int16
+	SuperscriptBottomMaxWithSubscript        int16
+	SpaceAfterScript                         int16
+	UpperLimitGapMin                         int16
+	UpperLimitBaselineRiseMin                int16
+	LowerLimitGapMin                         int16
+	LowerLimitBaselineDropMin                int16
+	StackTopShiftUp                          int16
+	StackTopDisplayStyleShiftUp              int16
+	StackBottomShiftDown                     int16
+	StackBottomDisplayStyleShiftDown         int16
+	StackGapMin                              int16
+	StackDisplayStyleGapMin                  int16
+	StretchStackTopShiftUp                   int16
+	StretchStackBottomShiftDown              int16
+	StretchStackGapAboveMin                  int16
+	StretchStackGapBelowMin                  int16
+	FractionNumeratorShiftUp                 int16
+	FractionNumeratorDisplayStyleShiftUp     int16
+	FractionDenominatorShiftDown             int16
+	FractionDenominatorDisplayStyleShiftDown int16
+	FractionNumeratorGapMin                  int16
+	FractionNumDisplayStyleGapMin            int16
+	FractionRuleThickness                    int16
+	FractionDenominatorGapMin                int16
+	FractionDenomDisplayStyleGapMin          int16
+	SkewedFractionHorizontalGap              int16
+	SkewedFractionVerticalGap                int16
+	OverbarVerticalGap                       int16
+	OverbarRuleThickness                     int16
+	OverbarExtraAscender                     int16
+	UnderbarVerticalGap                      int16
+	UnderbarRuleThickness                    int16
+	UnderbarExtraDescender                   int16
+	RadicalVerticalGap                       int16
+	RadicalDisplayStyleVerticalGap           int16
+	RadicalRuleThickness                     int16
+	RadicalExtraAscender                     int16
+	RadicalKernBeforeDegree                  int16
+	RadicalKernAfterDegree                   int16
+	RadicalDegreeBottomRaisePercent          int16
+}
+
+// MathGlyphVariant is a larger or wider variant of a glyph, where Advance is its height for vertical variants and its width for horizontal variants in font units.
+type MathGlyphVariant struct {
+	GlyphID uint16
+	Advance uint16
+}
+
+// MathGlyphPart is a part of a glyph assembly, where the connector lengths specify how much it may overlap with its neighbours. Extenders may be repeated any number of times, including zero.
+type MathGlyphPart struct {
+	GlyphID              uint16
+	StartConnectorLength uint16
+	EndConnectorLength   uint16
+	FullAdvance          uint16
+	Extender             bool
+}
+
+// MathGlyphAssembly constructs arbitrarily large glyphs from parts, ordered from bottom to top for vertical assemblies and from left to right for horizontal assemblies.
+type MathGlyphAssembly struct {
+	ItalicsCorrection int16
+	Parts             []MathGlyphPart
+}
+
+// MathGlyphConstruction lists the size variants of a glyph ordered by increasing size, and optionally an assembly to build it to any larger size.
+type MathGlyphConstruction struct {
+	Variants []MathGlyphVariant
+	Assembly *MathGlyphAssembly
+}
+
+// HasMath returns true if the font has a MATH table.
+func (sfnt *SFNT) HasMath() bool {
+	return sfnt.Math != nil
+}
+
+////////////////////////////////////////////////////////////////
+
+type mathTable struct {
+	Constants           MathConstants
+	MinConnectorOverlap uint16
+
+	italicsCorrections   map[uint16]int16
+	topAccentAttachments map[uint16]int16
+	extendedShapes       map[uint16]bool
+	vertical             map[uint16]MathGlyphConstruction
+	horizontal           map[uint16]MathGlyphConstruction
+}
+
+// ItalicsCorrection returns the italics correction of a glyph in font units, which is the extra space added after a slanted glyph before an upright glyph or a superscript.
+func (table *mathTable) ItalicsCorrection(glyphID uint16) (int16, bool) {
+	v, ok := table.italicsCorrections[glyphID]
+	return v, ok
+}
+
+// TopAccentAttachment returns the horizontal position in font units at which an accent placed over the glyph should be centered.
+func (table *mathTable) TopAccentAttachment(glyphID uint16) (int16, bool) {
+	v, ok := table.topAccentAttachments[glyphID]
+	return v, ok
+}
+
+// IsExtendedShape returns true if the glyph is an extended shape, such as a large operator or a stretched delimiter, which places scripts relative to its bounding box rather than its baseline.
+func (table *mathTable) IsExtendedShape(glyphID uint16) bool {
+	return table.extendedShapes[glyphID]
+}
+
+// VerticalConstruction returns the size variants and assembly to stretch a glyph vertically, such as parentheses and radicals.
+func (table *mathTable) VerticalConstruction(glyphID uint16) (MathGlyphConstruction, bool) {
+	construction, ok := table.vertical[glyphID]
+	return construction, ok
+}
+
+// HorizontalConstruction returns the size variants and assembly to stretch a glyph horizontally, such as wide accents and arrows.
+func (table *mathTable) HorizontalConstruction(glyphID uint16) (MathGlyphConstruction, bool) {
+	construction, ok := table.horizontal[glyphID]
+	return construction, ok
+}
+
+func (sfnt *SFNT) parseMATH() error {
+	b, ok := sfnt.Tables["MATH"]
+	if !ok {
+		return fmt.Errorf("MATH: missing table")
+	} else if len(b) < 10 {
+		return fmt.Errorf("MATH: bad table")
+	}
+
+	r := NewBinaryReader(b)
+	majorVersion := r.ReadUint16()
+	_ = r.ReadUint16() // minorVersion
+	if majorVersion != 1 {
+		return fmt.Errorf("MATH: bad version %d", majorVersion)
+	}
+	mathConstantsOffset := r.ReadUint16()
+	mathGlyphInfoOffset := r.ReadUint16()
+	mathVariantsOffset := r.ReadUint16()
+
+	sfnt.Math = &mathTable{
+		italicsCorrections:   map[uint16]int16{},
+		topAccentAttachments: map[uint16]int16{},
+		extendedShapes:       map[uint16]bool{},
+		vertical:             map[uint16]MathGlyphConstruction{},
+		horizontal:           map[uint16]MathGlyphConstruction{},
+	}
+	if mathConstantsOffset != 0 {
+		if err := sfnt.Math.parseConstants(b, uint32(mathConstantsOffset)); err != nil {
+			return err
+		}
+	}
+	if mathGlyphInfoOffset != 0 {
+		if err := sfnt.Math.parseGlyphInfo(b, uint32(mathGlyphInfoOffset)); err != nil {
+			return err
+		}
+	}
+	if mathVariantsOffset != 0 {
+		if err := sfnt.Math.parseVariants(b, uint32(mathVariantsOffset)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (table *mathTable) parseConstants(b []byte, offset uint32) error {
+	// 4 fields, 51 math value records and 1 field
+	if uint32(len(b)) < offset || uint32(len(b))-offset < 4*2+51*4+2 {
+		return fmt.Errorf("MATH: bad constants")
+	}
+	r := NewBinaryReader(b[offset:])
+	c := &table.Constants
+	c.ScriptPercentScaleDown = r.ReadInt16()
+	c.ScriptScriptPercentScaleDown = r.ReadInt16()
+	c.DelimitedSubFormulaMinHeight = r.ReadUint16()
+	c.DisplayOperatorMinHeight = r.ReadUint16()
+	for _, v := range []*int16{
+		&c.MathLeading,
+		&c.AxisHeight,
+		&c.AccentBaseHeight,
+		&c.FlattenedAccentBaseHeight,
+		&c.SubscriptShiftDown,
+		&c.SubscriptTopMax,
+		&c.SubscriptBaselineDropMin,
+		&c.SuperscriptShiftUp,
+		&c.SuperscriptShiftUpCramped,
+		&c.SuperscriptBottomMin,
+		&c.SuperscriptBaselineDropMax,
+		&c.SubSuperscriptGapMin,
+		&c.SuperscriptBottomMaxWithSubscript,
+		&c.SpaceAfterScript,
+		&c.UpperLimitGapMin,
+		&c.UpperLimitBaselineRiseMin,
+		&c.LowerLimitGapMin,
+		&c.LowerLimitBaselineDropMin,
+		&c.StackTopShiftUp,
+		&c.StackTopDisplayStyleShiftUp,
+		&c.StackBottomShiftDown,
+		&c.StackBottomDisplayStyleShiftDown,
+		&c.StackGapMin,
+		&c.StackDisplayStyleGapMin,
+		&c.StretchStackTopShiftUp,
+		&c.StretchStackBottomShiftDown,
+		&c.StretchStackGapAboveMin,
+		&c.StretchStackGapBelowMin,
+		&c.FractionNumeratorShiftUp,
+		&c.FractionNumeratorDisplayStyleShiftUp,
+		&c.FractionDenominatorShiftDown,
+		&c.FractionDenominatorDisplayStyleShiftDown,
+		&c.FractionNumeratorGapMin,
+		&c.FractionNumDisplayStyleGapMin,
+		&c.FractionRuleThickness,
+		&c.FractionDenominatorGapMin,
+		&c.FractionDenomDisplayStyleGapMin,
+		&c.SkewedFractionHorizontalGap,
+		&c.SkewedFractionVerticalGap,
+		&c.OverbarVerticalGap,
+		&c.OverbarRuleThickness,
+		&c.OverbarExtraAscender,
+		&c.UnderbarVerticalGap,
+		&c.UnderbarRuleThickness,
+		&c.UnderbarExtraDescender,
+		&c.RadicalVerticalGap,
+		&c.RadicalDisplayStyleVerticalGap,
+		&c.RadicalRuleThickness,
+		&c.RadicalExtraAscender,
+		&c.RadicalKernBeforeDegree,
+		&c.RadicalKernAfterDegree,
+	} {
+		*v = r.ReadInt16()
+		_ = r.ReadUint16() // deviceOffset
+	}
+	c.RadicalDegreeBottomRaisePercent = r.ReadInt16()
+	return nil
+}
+
+// parseMathValues parses a table of math value records for the glyphs in its coverage table, which is used for the italics corrections and top accent attachments.
+func parseMathValues(b []byte, offset uint32, values map[uint16]int16) error {
+	if offset == 0 {
+		return nil
+	} else if uint32(len(b)) < offset || uint32(len(b))-offset < 4 {
+		return fmt.Errorf("bad table")
+	}
+	r := NewBinaryReader(b[offset:])
+	coverageOffset := r.ReadUint16()
+	count := r.ReadUint16()
+	if r.Len() < 4*uint32(count) || uint32(len(b))-offset < uint32(coverageOffset) {
+		return fmt.Errorf("bad table")
+	}
+	glyphIDs, err := parseLayoutCoverage(b[offset+uint32(coverageOffset):])
+	if err != nil {
+		return err
+	} else if len(glyphIDs) < int(count) {
+		return fmt.Errorf("bad coverage table")
+	}
+	for i := 0; i < int(count); i++ {
+		values[glyphIDs[i]] = r.ReadInt16()
+		_ = r.ReadUint16() // deviceOffset
+	}
+	return nil
+}
+
+func (table *mathTable) parseGlyphInfo(b []byte, offset uint32) error {
+	if uint32(len(b)) < offset || uint32(len(b))-offset < 8 {
+		return fmt.Errorf("MATH: bad glyph info")
+	}
+	r := NewBinaryReader(b[offset:])
+	mathItalicsCorrectionInfoOffset := r.ReadUint16()
+	mathTopAccentAttachmentOffset := r.ReadUint16()
+	extendedShapeCoverageOffset := r.ReadUint16()
+	_ = r.ReadUint16() // mathKernInfoOffset, TODO
+
+	if err := parseMathValues(b[offset:], uint32(mathItalicsCorrectionInfoOffset), table.italicsCorrections); err != nil {
+		return fmt.Errorf("MATH: italics correction: %w", err)
+	}
+	if err := parseMathValues(b[offset:], uint32(mathTopAccentAttachmentOffset), table.topAccentAttachments); err != nil {
+		return fmt.Errorf("MATH: top accent attachment: %w", err)
+	}
+	if extendedShapeCoverageOffset != 0 {
+		if uint32(len(b))-offset < uint32(extendedShapeCoverageOffset) {
+			return fmt.Errorf("MATH: bad extended shape coverage")
+		}
+		glyphIDs, err := parseLayoutCoverage(b[offset+uint32(extendedShapeCoverageOffset):])
+		if err != nil {
+			return fmt.Errorf("MATH: extended shape: %w", err)
+		}
+		for _, glyphID := range glyphIDs {
+			table.extendedShapes[glyphID] = true
+		}
+	}
+	return nil
+}
+
+func (table *mathTable) parseVariants(b []byte, offset uint32) error {
+	if uint32(len(b)) < offset || uint32(len(b))-offset < 10 {
+		return fmt.Errorf("MATH: bad variants")
+	}
+	b = b[offset:]
+	r := NewBinaryReader(b)
+	table.MinConnectorOverlap = r.ReadUint16()
+	vertGlyphCoverageOffset := r.ReadUint16()
+	horizGlyphCoverageOffset := r.ReadUint16()
+	vertGlyphCount := r.ReadUint16()
+	horizGlyphCount := r.ReadUint16()
+	if r.Len() < 2*(uint32(vertGlyphCount)+uint32(horizGlyphCount)) {
+		return fmt.Errorf("MATH: bad variants")
+	}
+
+	for _, dir := range []struct {
+		coverageOffset uint16
+		count          uint16
+		constructions  map[uint16]MathGlyphConstruction
+	}{
+		{vertGlyphCoverageOffset, vertGlyphCount, table.vertical},
+		{horizGlyphCoverageOffset, horizGlyphCount, table.horizontal},
+	} {
+		if dir.count == 0 {
+			continue
+		} else if dir.coverageOffset == 0 || uint32(len(b)) < uint32(dir.coverageOffset) {
+			return fmt.Errorf("MATH: bad variants coverage")
+		}
+		glyphIDs, err := parseLayoutCoverage(b[dir.coverageOffset:])
+		if err != nil {
+			return fmt.Errorf("MATH: variants: %w", err)
+		} else if len(glyphIDs) < int(dir.count) {
+			return fmt.Errorf("MATH: bad variants coverage")
+		}
+		for i := 0; i < int(dir.count); i++ {
+			construction, err := parseMathGlyphConstruction(b, uint32(r.ReadUint16()))
+			if err != nil {
+				return fmt.Errorf("MATH: glyph construction %d: %w", glyphIDs[i], err)
+			}
+			dir.constructions[glyphIDs[i]] = construction
+		}
+	}
+	return nil
+}
+
+func parseMathGlyphConstruction(b []byte, offset uint32) (MathGlyphConstruction, error) {
+	construction := MathGlyphConstruction{}
+	if uint32(len(b)) < offset || uint32(len(b))-offset < 4 {
+		return construction, fmt.Errorf("bad table")
+	}
+	r := NewBinaryReader(b[offset:])
+	glyphAssemblyOffset := r.ReadUint16()
+	variantCount := r.ReadUint16()
+	if r.Len() < 4*uint32(variantCount) {
+		return construction, fmt.Errorf("bad variants")
+	}
+	construction.Variants = make([]MathGlyphVariant, variantCount)
+	for i := range construction.Variants {
+		construction.Variants[i].GlyphID = r.ReadUint16()
+		construction.Variants[i].Advance = r.ReadUint16()
+	}
+
+	if glyphAssemblyOffset != 0 {
+		offset += uint32(glyphAssemblyOffset)
+		if uint32(len(b)) < offset || uint32(len(b))-offset < 6 {
+			return construction, fmt.Errorf("bad assembly")
+		}
+		r = NewBinaryReader(b[offset:])
+		assembly := &MathGlyphAssembly{}
+		assembly.ItalicsCorrection = r.ReadInt16()
+		_ = r.ReadUint16() // deviceOffset
+		partCount := r.ReadUint16()
+		if r.Len() < 10*uint32(partCount) {
+			return construction, fmt.Errorf("bad assembly")
+		}
+		assembly.Parts = make([]MathGlyphPart, partCount)
+		for i := range assembly.Parts {
+			assembly.Parts[i].GlyphID = r.ReadUint16()
+			assembly.Parts[i].StartConnectorLength = r.ReadUint16()
+			assembly.Parts[i].EndConnectorLength = r.ReadUint16()
+			assembly.Parts[i].FullAdvance = r.ReadUint16()
+			assembly.Parts[i].Extender = r.ReadUint16()&0x0001 != 0
+		}
+		construction.Assembly = assembly
+	}
+	return construction, nil
+}
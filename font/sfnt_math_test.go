@@ -0,0 +1,49 @@
+package font
+
+import (
+	"testing"
+
+	"github.com/go-fonts/latin-modern/lmmath"
+	"github.com/tdewolff/test"
+)
+
+func TestMath(t *testing.T) {
+	sfnt, err := ParseSFNT(lmmath.TTF, 0)
+	test.Error(t, err)
+	test.That(t, sfnt.HasMath())
+	test.T(t, sfnt.Math.Constants.ScriptPercentScaleDown, int16(70))
+	test.T(t, sfnt.Math.Constants.DelimitedSubFormulaMinHeight, uint16(1300))
+	test.T(t, sfnt.Math.Constants.AxisHeight, int16(250))
+	test.T(t, sfnt.Math.Constants.FractionRuleThickness, int16(40))
+	test.T(t, sfnt.Math.Constants.RadicalKernAfterDegree, int16(-556))
+	test.T(t, sfnt.Math.Constants.RadicalDegreeBottomRaisePercent, int16(60))
+	test.T(t, sfnt.Math.MinConnectorOverlap, uint16(20))
+
+	italic, ok := sfnt.Math.ItalicsCorrection(sfnt.GlyphIndex('𝑓'))
+	test.That(t, ok)
+	test.T(t, italic, int16(90))
+	_, ok = sfnt.Math.ItalicsCorrection(sfnt.GlyphIndex('+'))
+	test.That(t, !ok)
+	accent, ok := sfnt.Math.TopAccentAttachment(sfnt.GlyphIndex('𝑓'))
+	test.That(t, ok)
+	test.T(t, accent, int16(464))
+
+	sum := sfnt.GlyphIndex('∑')
+	construction, ok := sfnt.Math.VerticalConstruction(sum)
+	test.That(t, ok)
+	test.T(t, len(construction.Variants), 2)
+	test.T(t, construction.Variants[0].Advance, uint16(1001))
+	test.That(t, sfnt.Math.IsExtendedShape(construction.Variants[1].GlyphID))
+
+	construction, ok = sfnt.Math.VerticalConstruction(sfnt.GlyphIndex('('))
+	test.That(t, ok)
+	test.T(t, len(construction.Variants), 8)
+	test.T(t, construction.Variants[0].GlyphID, sfnt.GlyphIndex('('))
+	test.That(t, construction.Assembly != nil)
+	test.T(t, len(construction.Assembly.Parts), 3)
+	test.That(t, !construction.Assembly.Parts[0].Extender)
+	test.That(t, construction.Assembly.Parts[1].Extender)
+
+	_, ok = sfnt.Math.VerticalConstruction(sfnt.GlyphIndex('x'))
+	test.That(t, !ok)
+}
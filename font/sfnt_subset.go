@@ -0,0 +1,2013 @@
+package font
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+var errLayoutOverflow = fmt.Errorf("offset overflow")
+
+// layoutObject is a table in the offset graph of a layout table, with the offsets to its child tables.
+type layoutObject struct {
+	data     []byte
+	links    []layoutLink
+	isolated bool // packed after the tables of its parent's graph so that its children remain close
+}
+
+type layoutLink struct {
+	pos    uint32 // position of the offset in the parent
+	wide   bool   // 32-bit offset
+	target *layoutObject
+}
+
+// layoutWriter writes a layout table and keeps track of the offsets to its child tables.
+type layoutWriter struct {
+	BinaryWriter
+	links []layoutLink
+}
+
+func newLayoutWriter() *layoutWriter {
+	return &layoutWriter{
+		BinaryWriter: BinaryWriter{[]byte{}},
+	}
+}
+
+// WriteOffset writes a 16-bit offset to the target, which is null for a nil target.
+func (w *layoutWriter) WriteOffset(target *layoutObject) {
+	if target != nil {
+		w.links = append(w.links, layoutLink{pos: w.Len(), target: target})
+	}
+	w.WriteUint16(0)
+}
+
+// WriteOffset32 writes a 32-bit offset to the target, which is null for a nil target.
+func (w *layoutWriter) WriteOffset32(target *layoutObject) {
+	if target != nil {
+		w.links = append(w.links, layoutLink{pos: w.Len(), wide: true, target: target})
+	}
+	w.WriteUint32(0)
+}
+
+func (w *layoutWriter) Object() *layoutObject {
+	return &layoutObject{
+		data:  w.Bytes(),
+		links: w.links,
+	}
+}
+
+// packLayout serializes the offset graph, placing the child tables breadth-first after their parents. Tables referenced by 32-bit offsets or that are isolated start a new graph that is placed after the current one.
+func packLayout(root *layoutObject) ([]byte, error) {
+	n := uint32(0)
+	order := []*layoutObject{}
+	pos := map[*layoutObject]uint32{}
+	place := func(obj *layoutObject) {
+		pos[obj] = n
+		n += uint32(len(obj.data))
+		order = append(order, obj)
+	}
+
+	roots := []*layoutObject{root}
+	for i := 0; i < len(roots); i++ {
+		if _, ok := pos[roots[i]]; ok {
+			continue
+		}
+		j := len(order)
+		place(roots[i])
+		for ; j < len(order); j++ {
+			for _, link := range order[j].links {
+				if _, ok := pos[link.target]; ok {
+					continue
+				} else if link.wide || link.target.isolated {
+					roots = append(roots, link.target)
+				} else {
+					place(link.target)
+				}
+			}
+		}
+	}
+
+	b := make([]byte, n)
+	for _, obj := range order {
+		start := pos[obj]
+		copy(b[start:], obj.data)
+		for _, link := range obj.links {
+			offset := int64(pos[link.target]) - int64(start)
+			if offset <= 0 || !link.wide && 0xFFFF < offset {
+				return nil, errLayoutOverflow
+			}
+			if link.wide {
+				binary.BigEndian.PutUint32(b[start+link.pos:], uint32(offset))
+			} else {
+				binary.BigEndian.PutUint16(b[start+link.pos:], uint16(offset))
+			}
+		}
+	}
+	return b, nil
+}
+
+////////////////////////////////////////////////////////////////
+
+// layoutAt returns the table at the offset from the start of b, or nil if the offset is null or out of range.
+func layoutAt(b []byte, offset uint32) []byte {
+	if offset == 0 || uint32(len(b)) <= offset {
+		return nil
+	}
+	return b[offset:]
+}
+
+// parseLayoutCoverage returns the glyph IDs of a coverage table in coverage index order.
+func parseLayoutCoverage(b []byte) ([]uint16, error) {
+	r := NewBinaryReader(b)
+	var glyphIDs []uint16
+	coverageFormat := r.ReadUint16()
+	if coverageFormat == 1 {
+		glyphCount := r.ReadUint16()
+		glyphIDs = make([]uint16, glyphCount)
+		for i := 0; i < int(glyphCount); i++ {
+			glyphIDs[i] = r.ReadUint16()
+		}
+	} else if coverageFormat == 2 {
+		rangeCount := r.ReadUint16()
+		for i := 0; i < int(rangeCount); i++ {
+			startGlyphID := r.ReadUint16()
+			endGlyphID := r.ReadUint16()
+			startCoverageIndex := r.ReadUint16()
+			if r.EOF() || endGlyphID < startGlyphID || int(startCoverageIndex) != len(glyphIDs) {
+				return nil, fmt.Errorf("bad coverage table")
+			}
+			for glyphID := uint32(startGlyphID); glyphID <= uint32(endGlyphID); glyphID++ {
+				glyphIDs = append(glyphIDs, uint16(glyphID))
+			}
+		}
+	} else {
+		return nil, fmt.Errorf("bad coverage table format")
+	}
+	if r.EOF() {
+		return nil, fmt.Errorf("bad coverage table")
+	}
+	return glyphIDs, nil
+}
+
+func (sfnt *SFNT) parseLayoutClassDef(b []byte, offset uint16, classCount uint16) (classDefTable, error) {
+	if offset == 0 {
+		return classDefFormat2{}, nil
+	}
+	return sfnt.parseClassDefTable(layoutAt(b, uint32(offset)), classCount)
+}
+
+// layoutCoverageObject returns a coverage table for sorted glyph IDs.
+func layoutCoverageObject(glyphIDs []uint16) *layoutObject {
+	rangeCount := 0
+	for i := range glyphIDs {
+		if i == 0 || glyphIDs[i-1]+1 != glyphIDs[i] {
+			rangeCount++
+		}
+	}
+
+	w := newLayoutWriter()
+	if len(glyphIDs) <= 3*rangeCount {
+		w.WriteUint16(1) // coverageFormat
+		w.WriteUint16(uint16(len(glyphIDs)))
+		for _, glyphID := range glyphIDs {
+			w.WriteUint16(glyphID)
+		}
+	} else {
+		w.WriteUint16(2) // coverageFormat
+		w.WriteUint16(uint16(rangeCount))
+		for i := 0; i < len(glyphIDs); {
+			j := i + 1
+			for j < len(glyphIDs) && glyphIDs[j-1]+1 == glyphIDs[j] {
+				j++
+			}
+			w.WriteUint16(glyphIDs[i])   // startGlyphID
+			w.WriteUint16(glyphIDs[j-1]) // endGlyphID
+			w.WriteUint16(uint16(i))     // startCoverageIndex
+			i = j
+		}
+	}
+	return w.Object()
+}
+
+// layoutClassDefObject returns a class definition table for a map of glyph IDs to non-zero classes.
+func layoutClassDefObject(classes map[uint16]uint16) *layoutObject {
+	glyphIDs := make([]uint16, 0, len(classes))
+	for glyphID := range classes {
+		glyphIDs = append(glyphIDs, glyphID)
+	}
+	sort.Slice(glyphIDs, func(i, j int) bool { return glyphIDs[i] < glyphIDs[j] })
+
+	rangeCount := 0
+	for i, glyphID := range glyphIDs {
+		if i == 0 || glyphIDs[i-1]+1 != glyphID || classes[glyphIDs[i-1]] != classes[glyphID] {
+			rangeCount++
+		}
+	}
+
+	w := newLayoutWriter()
+	if len(glyphIDs) == 0 {
+		w.WriteUint16(2) // classFormat
+		w.WriteUint16(0) // classRangeCount
+	} else if glyphCount := int(glyphIDs[len(glyphIDs)-1]-glyphIDs[0]) + 1; 2+2*glyphCount <= 6*rangeCount {
+		w.WriteUint16(1) // classFormat
+		w.WriteUint16(glyphIDs[0])
+		w.WriteUint16(uint16(glyphCount))
+		for i := 0; i < glyphCount; i++ {
+			w.WriteUint16(classes[glyphIDs[0]+uint16(i)])
+		}
+	} else {
+		w.WriteUint16(2) // classFormat
+		w.WriteUint16(uint16(rangeCount))
+		for i := 0; i < len(glyphIDs); {
+			j := i + 1
+			for j < len(glyphIDs) && glyphIDs[j-1]+1 == glyphIDs[j] && classes[glyphIDs[i]] == classes[glyphIDs[j]] {
+				j++
+			}
+			w.WriteUint16(glyphIDs[i])          // startGlyphID
+			w.WriteUint16(glyphIDs[j-1])        // endGlyphID
+			w.WriteUint16(classes[glyphIDs[i]]) // class
+			i = j
+		}
+	}
+	return w.Object()
+}
+
+// parseLayoutDevice returns a Device table, or nil for a VariationIndex table since item variation stores are not retained.
+func parseLayoutDevice(b []byte) (*layoutObject, error) {
+	r := NewBinaryReader(b)
+	startSize := r.ReadUint16()
+	endSize := r.ReadUint16()
+	deltaFormat := r.ReadUint16()
+	if r.EOF() {
+		return nil, fmt.Errorf("bad device table")
+	} else if deltaFormat == 0x8000 {
+		return nil, nil
+	}
+
+	n := 6
+	if 1 <= deltaFormat && deltaFormat <= 3 && startSize <= endSize {
+		bits := 1 << deltaFormat
+		n += 2 * ((int(endSize-startSize+1)*bits + 15) / 16)
+	}
+	if len(b) < n {
+		return nil, fmt.Errorf("bad device table")
+	}
+	return &layoutObject{data: b[:n]}, nil
+}
+
+// parseLayoutAnchor returns the Anchor table at the offset, where anchors shared within the same parent are parsed only once.
+func parseLayoutAnchor(b []byte, offset uint16, anchors map[uint16]*layoutObject) (*layoutObject, error) {
+	if offset == 0 {
+		return nil, nil
+	} else if anchor, ok := anchors[offset]; ok {
+		return anchor, nil
+	}
+
+	data := layoutAt(b, uint32(offset))
+	r := NewBinaryReader(data)
+	w := newLayoutWriter()
+	anchorFormat := r.ReadUint16()
+	w.WriteUint16(anchorFormat)
+	if anchorFormat == 1 {
+		w.WriteBytes(r.ReadBytes(4))
+	} else if anchorFormat == 2 {
+		w.WriteBytes(r.ReadBytes(6))
+	} else if anchorFormat == 3 {
+		w.WriteBytes(r.ReadBytes(4))
+		for i := 0; i < 2; i++ {
+			var device *layoutObject
+			if deviceOffset := r.ReadUint16(); deviceOffset != 0 {
+				var err error
+				if device, err = parseLayoutDevice(layoutAt(data, uint32(deviceOffset))); err != nil {
+					return nil, err
+				}
+			}
+			w.WriteOffset(device)
+		}
+	} else {
+		return nil, fmt.Errorf("bad anchor table format")
+	}
+	if r.EOF() {
+		return nil, fmt.Errorf("bad anchor table")
+	}
+	anchor := w.Object()
+	anchors[offset] = anchor
+	return anchor, nil
+}
+
+// layoutValue is a ValueRecord, where devices are non-nil for fields that are offsets to Device tables.
+type layoutValue struct {
+	fields  []uint16
+	devices []*layoutObject
+}
+
+// parseLayoutValue reads a ValueRecord, where the Device tables are at offsets from the start of parent.
+func parseLayoutValue(r *BinaryReader, parent []byte, valueFormat uint16) (layoutValue, error) {
+	value := layoutValue{}
+	for i := 0; i < 8; i++ {
+		if valueFormat&(1<<i) == 0 {
+			continue
+		}
+		field := r.ReadUint16()
+		var device *layoutObject
+		if 4 <= i && field != 0 {
+			var err error
+			if device, err = parseLayoutDevice(layoutAt(parent, uint32(field))); err != nil {
+				return value, err
+			}
+			field = 0
+		}
+		value.fields = append(value.fields, field)
+		value.devices = append(value.devices, device)
+	}
+	return value, nil
+}
+
+func (w *layoutWriter) writeValue(value layoutValue) {
+	for i, field := range value.fields {
+		if value.devices[i] != nil {
+			w.WriteOffset(value.devices[i])
+		} else {
+			w.WriteUint16(field)
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////
+
+// layoutSubsetter rewrites layout tables for the glyphs retained in a subset.
+type layoutSubsetter struct {
+	glyphMap map[uint16]uint16 // original to subset glyph ID
+}
+
+func (s *layoutSubsetter) has(glyphIDs ...uint16) bool {
+	for _, glyphID := range glyphIDs {
+		if _, ok := s.glyphMap[glyphID]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *layoutSubsetter) remap(glyphIDs []uint16) []uint16 {
+	subsetGlyphIDs := make([]uint16, len(glyphIDs))
+	for i, glyphID := range glyphIDs {
+		subsetGlyphIDs[i] = s.glyphMap[glyphID]
+	}
+	return subsetGlyphIDs
+}
+
+// coverage returns the coverage indices of the retained glyphs for which keep returns true, and a coverage table of their subset glyph IDs. The indices are sorted by subset glyph ID.
+func (s *layoutSubsetter) coverage(glyphIDs []uint16, keep func(int) bool) ([]int, *layoutObject) {
+	indices := []int{}
+	for i, glyphID := range glyphIDs {
+		if s.has(glyphID) && (keep == nil || keep(i)) {
+			indices = append(indices, i)
+		}
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return s.glyphMap[glyphIDs[indices[i]]] < s.glyphMap[glyphIDs[indices[j]]]
+	})
+
+	subsetGlyphIDs := make([]uint16, 0, len(indices))
+	for k := 0; k < len(indices); k++ {
+		subsetGlyphID := s.glyphMap[glyphIDs[indices[k]]]
+		if 0 < k && subsetGlyphIDs[len(subsetGlyphIDs)-1] == subsetGlyphID {
+			indices = append(indices[:k], indices[k+1:]...) // duplicate glyph
+			k--
+			continue
+		}
+		subsetGlyphIDs = append(subsetGlyphIDs, subsetGlyphID)
+	}
+	return indices, layoutCoverageObject(subsetGlyphIDs)
+}
+
+// classes returns the non-zero classes of the retained glyphs by subset glyph ID.
+func (s *layoutSubsetter) classes(classDef classDefTable) map[uint16]uint16 {
+	classes := map[uint16]uint16{}
+	for glyphID, subsetGlyphID := range s.glyphMap {
+		if class := classDef.Get(glyphID); class != 0 {
+			classes[subsetGlyphID] = class
+		}
+	}
+	return classes
+}
+
+////////////////////////////////////////////////////////////////
+
+type layoutSubtable interface {
+	// subset returns the subtable for the retained glyphs, or nil if it has no effect on them.
+	subset(*layoutSubsetter) *layoutObject
+}
+
+type layoutClosure interface {
+	// closure adds the glyphs that the subtable substitutes for glyphs in the set, and returns true if any were added.
+	closure(map[uint16]bool) bool
+}
+
+func addClosure(glyphs map[uint16]bool, glyphIDs ...uint16) bool {
+	changed := false
+	for _, glyphID := range glyphIDs {
+		if !glyphs[glyphID] {
+			glyphs[glyphID] = true
+			changed = true
+		}
+	}
+	return changed
+}
+
+type gsubSingle struct {
+	coverage    []uint16
+	substitutes []uint16
+}
+
+func (sfnt *SFNT) parseGSUBSingle(b []byte) (layoutSubtable, error) {
+	r := NewBinaryReader(b)
+	substFormat := r.ReadUint16()
+	coverage, err := parseLayoutCoverage(layoutAt(b, uint32(r.ReadUint16())))
+	if err != nil {
+		return nil, err
+	}
+
+	table := &gsubSingle{
+		coverage:    coverage,
+		substitutes: make([]uint16, len(coverage)),
+	}
+	if substFormat == 1 {
+		deltaGlyphID := r.ReadUint16()
+		for i, glyphID := range coverage {
+			table.substitutes[i] = glyphID + deltaGlyphID // modulo 65536
+		}
+	} else if substFormat == 2 {
+		if int(r.ReadUint16()) != len(coverage) {
+			return nil, fmt.Errorf("bad single substitution table")
+		}
+		for i := range coverage {
+			table.substitutes[i] = r.ReadUint16()
+		}
+	} else {
+		return nil, fmt.Errorf("bad single substitution table format")
+	}
+	if r.EOF() {
+		return nil, fmt.Errorf("bad single substitution table")
+	}
+	return table, nil
+}
+
+func (table *gsubSingle) closure(glyphs map[uint16]bool) bool {
+	changed := false
+	for i, glyphID := range table.coverage {
+		if glyphs[glyphID] && addClosure(glyphs, table.substitutes[i]) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+func (table *gsubSingle) subset(s *layoutSubsetter) *layoutObject {
+	indices, coverage := s.coverage(table.coverage, func(i int) bool {
+		return s.has(table.substitutes[i])
+	})
+	if len(indices) == 0 {
+		return nil
+	}
+
+	w := newLayoutWriter()
+	w.WriteUint16(2) // substFormat
+	w.WriteOffset(coverage)
+	w.WriteUint16(uint16(len(indices)))
+	for _, i := range indices {
+		w.WriteUint16(s.glyphMap[table.substitutes[i]])
+	}
+	return w.Object()
+}
+
+// gsubMultiple is a multiple or alternate substitution, which share the same structure.
+type gsubMultiple struct {
+	alternate bool
+	coverage  []uint16
+	sequences [][]uint16
+}
+
+func (sfnt *SFNT) parseGSUBMultiple(b []byte, alternate bool) (layoutSubtable, error) {
+	r := NewBinaryReader(b)
+	if r.ReadUint16() != 1 {
+		return nil, fmt.Errorf("bad multiple or alternate substitution table format")
+	}
+	coverage, err := parseLayoutCoverage(layoutAt(b, uint32(r.ReadUint16())))
+	if err != nil {
+		return nil, err
+	} else if int(r.ReadUint16()) != len(coverage) {
+		return nil, fmt.Errorf("bad multiple or alternate substitution table")
+	}
+
+	table := &gsubMultiple{
+		alternate: alternate,
+		coverage:  coverage,
+		sequences: make([][]uint16, len(coverage)),
+	}
+	for i := range coverage {
+		r2 := NewBinaryReader(layoutAt(b, uint32(r.ReadUint16())))
+		glyphCount := r2.ReadUint16()
+		table.sequences[i] = make([]uint16, glyphCount)
+		for j := 0; j < int(glyphCount); j++ {
+			table.sequences[i][j] = r2.ReadUint16()
+		}
+		if r2.EOF() {
+			return nil, fmt.Errorf("bad multiple or alternate substitution table")
+		}
+	}
+	if r.EOF() {
+		return nil, fmt.Errorf("bad multiple or alternate substitution table")
+	}
+	return table, nil
+}
+
+func (table *gsubMultiple) closure(glyphs map[uint16]bool) bool {
+	changed := false
+	for i, glyphID := range table.coverage {
+		if glyphs[glyphID] && addClosure(glyphs, table.sequences[i]...) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+func (table *gsubMultiple) subset(s *layoutSubsetter) *layoutObject {
+	sequences := make([][]uint16, len(table.sequences))
+	for i, sequence := range table.sequences {
+		if !table.alternate {
+			if s.has(sequence...) {
+				sequences[i] = s.remap(sequence)
+			}
+			continue
+		}
+		for _, glyphID := range sequence {
+			if s.has(glyphID) {
+				sequences[i] = append(sequences[i], s.glyphMap[glyphID])
+			}
+		}
+	}
+
+	indices, coverage := s.coverage(table.coverage, func(i int) bool {
+		return sequences[i] != nil
+	})
+	if len(indices) == 0 {
+		return nil
+	}
+
+	w := newLayoutWriter()
+	w.WriteUint16(1) // substFormat
+	w.WriteOffset(coverage)
+	w.WriteUint16(uint16(len(indices)))
+	for _, i := range indices {
+		w2 := newLayoutWriter()
+		w2.WriteUint16(uint16(len(sequences[i])))
+		for _, glyphID := range sequences[i] {
+			w2.WriteUint16(glyphID)
+		}
+		w.WriteOffset(w2.Object())
+	}
+	return w.Object()
+}
+
+type gsubLigature struct {
+	coverage  []uint16
+	ligatures [][]ligature
+}
+
+func (sfnt *SFNT) parseGSUBLigature(b []byte) (layoutSubtable, error) {
+	r := NewBinaryReader(b)
+	if r.ReadUint16() != 1 {
+		return nil, fmt.Errorf("bad ligature substitution table format")
+	}
+	coverage, err := parseLayoutCoverage(layoutAt(b, uint32(r.ReadUint16())))
+	if err != nil {
+		return nil, err
+	} else if int(r.ReadUint16()) != len(coverage) {
+		return nil, fmt.Errorf("bad ligature substitution table")
+	}
+
+	table := &gsubLigature{
+		coverage:  coverage,
+		ligatures: make([][]ligature, len(coverage)),
+	}
+	for i := range coverage {
+		ligatureSet := layoutAt(b, uint32(r.ReadUint16()))
+		r2 := NewBinaryReader(ligatureSet)
+		ligatureCount := r2.ReadUint16()
+		table.ligatures[i] = make([]ligature, ligatureCount)
+		for j := 0; j < int(ligatureCount); j++ {
+			r3 := NewBinaryReader(layoutAt(ligatureSet, uint32(r2.ReadUint16())))
+			table.ligatures[i][j].ligatureGlyph = r3.ReadUint16()
+			componentCount := r3.ReadUint16()
+			if componentCount == 0 {
+				return nil, fmt.Errorf("bad ligature substitution table")
+			}
+			table.ligatures[i][j].componentGlyphIDs = make([]uint16, componentCount-1)
+			for k := 0; k < int(componentCount-1); k++ {
+				table.ligatures[i][j].componentGlyphIDs[k] = r3.ReadUint16()
+			}
+			if r3.EOF() {
+				return nil, fmt.Errorf("bad ligature substitution table")
+			}
+		}
+		if r2.EOF() {
+			return nil, fmt.Errorf("bad ligature substitution table")
+		}
+	}
+	if r.EOF() {
+		return nil, fmt.Errorf("bad ligature substitution table")
+	}
+	return table, nil
+}
+
+func (table *gsubLigature) closure(glyphs map[uint16]bool) bool {
+	changed := false
+	for i, glyphID := range table.coverage {
+		if !glyphs[glyphID] {
+			continue
+		}
+	LigatureLoop:
+		for _, ligature := range table.ligatures[i] {
+			for _, componentGlyphID := range ligature.componentGlyphIDs {
+				if !glyphs[componentGlyphID] {
+					continue LigatureLoop
+				}
+			}
+			if addClosure(glyphs, ligature.ligatureGlyph) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+func (table *gsubLigature) subset(s *layoutSubsetter) *layoutObject {
+	ligatures := make([][]ligature, len(table.ligatures))
+	for i := range table.ligatures {
+		for _, ligature := range table.ligatures[i] {
+			if s.has(ligature.ligatureGlyph) && s.has(ligature.componentGlyphIDs...) {
+				ligatures[i] = append(ligatures[i], ligature)
+			}
+		}
+	}
+
+	indices, coverage := s.coverage(table.coverage, func(i int) bool {
+		return 0 < len(ligatures[i])
+	})
+	if len(indices) == 0 {
+		return nil
+	}
+
+	w := newLayoutWriter()
+	w.WriteUint16(1) // substFormat
+	w.WriteOffset(coverage)
+	w.WriteUint16(uint16(len(indices)))
+	for _, i := range indices {
+		w2 := newLayoutWriter()
+		w2.WriteUint16(uint16(len(ligatures[i])))
+		for _, ligature := range ligatures[i] {
+			w3 := newLayoutWriter()
+			w3.WriteUint16(s.glyphMap[ligature.ligatureGlyph])
+			w3.WriteUint16(uint16(len(ligature.componentGlyphIDs) + 1))
+			for _, glyphID := range ligature.componentGlyphIDs {
+				w3.WriteUint16(s.glyphMap[glyphID])
+			}
+			w2.WriteOffset(w3.Object())
+		}
+		w.WriteOffset(w2.Object())
+	}
+	return w.Object()
+}
+
+type gsubReverseChain struct {
+	coverage    []uint16
+	backtrack   [][]uint16
+	lookahead   [][]uint16
+	substitutes []uint16
+}
+
+func (sfnt *SFNT) parseGSUBReverseChain(b []byte) (layoutSubtable, error) {
+	r := NewBinaryReader(b)
+	if r.ReadUint16() != 1 {
+		return nil, fmt.Errorf("bad reverse chaining substitution table format")
+	}
+	coverage, err := parseLayoutCoverage(layoutAt(b, uint32(r.ReadUint16())))
+	if err != nil {
+		return nil, err
+	}
+
+	table := &gsubReverseChain{
+		coverage: coverage,
+	}
+	for _, coverages := range []*[][]uint16{&table.backtrack, &table.lookahead} {
+		glyphCount := r.ReadUint16()
+		*coverages = make([][]uint16, glyphCount)
+		for i := 0; i < int(glyphCount); i++ {
+			if (*coverages)[i], err = parseLayoutCoverage(layoutAt(b, uint32(r.ReadUint16()))); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if int(r.ReadUint16()) != len(coverage) {
+		return nil, fmt.Errorf("bad reverse chaining substitution table")
+	}
+	table.substitutes = make([]uint16, len(coverage))
+	for i := range coverage {
+		table.substitutes[i] = r.ReadUint16()
+	}
+	if r.EOF() {
+		return nil, fmt.Errorf("bad reverse chaining substitution table")
+	}
+	return table, nil
+}
+
+func (table *gsubReverseChain) closure(glyphs map[uint16]bool) bool {
+	changed := false
+	for i, glyphID := range table.coverage {
+		if glyphs[glyphID] && addClosure(glyphs, table.substitutes[i]) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+func (table *gsubReverseChain) subset(s *layoutSubsetter) *layoutObject {
+	indices, coverage := s.coverage(table.coverage, func(i int) bool {
+		return s.has(table.substitutes[i])
+	})
+	if len(indices) == 0 {
+		return nil
+	}
+
+	w := newLayoutWriter()
+	w.WriteUint16(1) // substFormat
+	w.WriteOffset(coverage)
+	for _, coverages := range [][][]uint16{table.backtrack, table.lookahead} {
+		w.WriteUint16(uint16(len(coverages)))
+		for _, glyphIDs := range coverages {
+			subIndices, subCoverage := s.coverage(glyphIDs, nil)
+			if len(subIndices) == 0 {
+				return nil // can never match
+			}
+			w.WriteOffset(subCoverage)
+		}
+	}
+	w.WriteUint16(uint16(len(indices)))
+	for _, i := range indices {
+		w.WriteUint16(s.glyphMap[table.substitutes[i]])
+	}
+	return w.Object()
+}
+
+////////////////////////////////////////////////////////////////
+
+type layoutLookupRecord struct {
+	sequenceIndex   uint16
+	lookupListIndex uint16
+}
+
+// layoutRule is a (chained) sequence rule, where the sequences are glyph IDs or classes for the backtrack, the input without its first glyph, and the lookahead.
+type layoutRule struct {
+	sequences [3][]uint16
+	records   []layoutLookupRecord
+}
+
+// layoutContext is a (chained) sequence context subtable of GSUB or GPOS.
+type layoutContext struct {
+	chained bool
+	format  uint16
+
+	coverage  []uint16         // format 1 and 2
+	rules     [][]layoutRule   // by coverage index for format 1, by input class for format 2
+	classDefs [3]classDefTable // format 2
+	coverages [3][][]uint16    // format 3
+	records   []layoutLookupRecord
+}
+
+func parseLayoutRecords(r *BinaryReader, count uint16) []layoutLookupRecord {
+	records := make([]layoutLookupRecord, count)
+	for i := 0; i < int(count); i++ {
+		records[i].sequenceIndex = r.ReadUint16()
+		records[i].lookupListIndex = r.ReadUint16()
+	}
+	return records
+}
+
+func writeLayoutRecords(w *layoutWriter, records []layoutLookupRecord) {
+	for _, record := range records {
+		w.WriteUint16(record.sequenceIndex)
+		w.WriteUint16(record.lookupListIndex)
+	}
+}
+
+func parseLayoutRuleSet(b []byte, chained bool) ([]layoutRule, error) {
+	r := NewBinaryReader(b)
+	ruleCount := r.ReadUint16()
+	rules := make([]layoutRule, ruleCount)
+	for i := 0; i < int(ruleCount); i++ {
+		r2 := NewBinaryReader(layoutAt(b, uint32(r.ReadUint16())))
+		rule := &rules[i]
+		if chained {
+			for k := 0; k < 3; k++ {
+				glyphCount := r2.ReadUint16()
+				if k == 1 {
+					if glyphCount == 0 {
+						return nil, fmt.Errorf("bad sequence rule")
+					}
+					glyphCount--
+				}
+				rule.sequences[k] = make([]uint16, glyphCount)
+				for j := 0; j < int(glyphCount); j++ {
+					rule.sequences[k][j] = r2.ReadUint16()
+				}
+			}
+			rule.records = parseLayoutRecords(r2, r2.ReadUint16())
+		} else {
+			glyphCount := r2.ReadUint16()
+			seqLookupCount := r2.ReadUint16()
+			if glyphCount == 0 {
+				return nil, fmt.Errorf("bad sequence rule")
+			}
+			rule.sequences[1] = make([]uint16, glyphCount-1)
+			for j := 0; j < int(glyphCount-1); j++ {
+				rule.sequences[1][j] = r2.ReadUint16()
+			}
+			rule.records = parseLayoutRecords(r2, seqLookupCount)
+		}
+		if r2.EOF() {
+			return nil, fmt.Errorf("bad sequence rule")
+		}
+	}
+	if r.EOF() {
+		return nil, fmt.Errorf("bad sequence rule set")
+	}
+	return rules, nil
+}
+
+func writeLayoutRuleSet(rules []layoutRule, chained bool) *layoutObject {
+	w := newLayoutWriter()
+	w.WriteUint16(uint16(len(rules)))
+	for _, rule := range rules {
+		w2 := newLayoutWriter()
+		if chained {
+			for k := 0; k < 3; k++ {
+				if k == 1 {
+					w2.WriteUint16(uint16(len(rule.sequences[k]) + 1))
+				} else {
+					w2.WriteUint16(uint16(len(rule.sequences[k])))
+				}
+				for _, v := range rule.sequences[k] {
+					w2.WriteUint16(v)
+				}
+			}
+			w2.WriteUint16(uint16(len(rule.records)))
+		} else {
+			w2.WriteUint16(uint16(len(rule.sequences[1]) + 1))
+			w2.WriteUint16(uint16(len(rule.records)))
+			for _, v := range rule.sequences[1] {
+				w2.WriteUint16(v)
+			}
+		}
+		writeLayoutRecords(w2, rule.records)
+		w.WriteOffset(w2.Object())
+	}
+	return w.Object()
+}
+
+func (sfnt *SFNT) parseLayoutContext(b []byte, chained bool) (layoutSubtable, error) {
+	var err error
+	r := NewBinaryReader(b)
+	table := &layoutContext{
+		chained: chained,
+		format:  r.ReadUint16(),
+	}
+	if table.format == 1 || table.format == 2 {
+		if table.coverage, err = parseLayoutCoverage(layoutAt(b, uint32(r.ReadUint16()))); err != nil {
+			return nil, err
+		}
+		if table.format == 2 {
+			classDefs := table.classDefs[1:2]
+			if chained {
+				classDefs = table.classDefs[:]
+			}
+			for k := range classDefs {
+				if classDefs[k], err = sfnt.parseLayoutClassDef(b, r.ReadUint16(), 0xFFFF); err != nil {
+					return nil, err
+				}
+			}
+			if !chained {
+				table.classDefs[0], table.classDefs[2] = classDefFormat2{}, classDefFormat2{}
+			}
+		}
+		ruleSetCount := r.ReadUint16()
+		if table.format == 1 && int(ruleSetCount) != len(table.coverage) {
+			return nil, fmt.Errorf("bad sequence context table")
+		}
+		table.rules = make([][]layoutRule, ruleSetCount)
+		for i := 0; i < int(ruleSetCount); i++ {
+			if offset := r.ReadUint16(); offset != 0 {
+				if table.rules[i], err = parseLayoutRuleSet(layoutAt(b, uint32(offset)), chained); err != nil {
+					return nil, err
+				}
+			}
+		}
+	} else if table.format == 3 {
+		var glyphCount, seqLookupCount uint16
+		for k := 0; k < 3; k++ {
+			if !chained && k != 1 {
+				continue
+			}
+			glyphCount = r.ReadUint16()
+			if !chained {
+				seqLookupCount = r.ReadUint16()
+			}
+			table.coverages[k] = make([][]uint16, glyphCount)
+			for i := 0; i < int(glyphCount); i++ {
+				if table.coverages[k][i], err = parseLayoutCoverage(layoutAt(b, uint32(r.ReadUint16()))); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if chained {
+			seqLookupCount = r.ReadUint16()
+		}
+		table.records = parseLayoutRecords(r, seqLookupCount)
+	} else {
+		return nil, fmt.Errorf("bad sequence context table format")
+	}
+	if r.EOF() {
+		return nil, fmt.Errorf("bad sequence context table")
+	}
+	return table, nil
+}
+
+func (table *layoutContext) subset(s *layoutSubsetter) *layoutObject {
+	w := newLayoutWriter()
+	w.WriteUint16(table.format)
+	if table.format == 1 {
+		rules := make([][]layoutRule, len(table.rules))
+		for i := range table.rules {
+			for _, rule := range table.rules[i] {
+				if s.has(rule.sequences[0]...) && s.has(rule.sequences[1]...) && s.has(rule.sequences[2]...) {
+					rule.sequences = [3][]uint16{s.remap(rule.sequences[0]), s.remap(rule.sequences[1]), s.remap(rule.sequences[2])}
+					rules[i] = append(rules[i], rule)
+				}
+			}
+		}
+		indices, coverage := s.coverage(table.coverage, func(i int) bool {
+			return 0 < len(rules[i])
+		})
+		if len(indices) == 0 {
+			return nil
+		}
+		w.WriteOffset(coverage)
+		w.WriteUint16(uint16(len(indices)))
+		for _, i := range indices {
+			w.WriteOffset(writeLayoutRuleSet(rules[i], table.chained))
+		}
+	} else if table.format == 2 {
+		indices, coverage := s.coverage(table.coverage, nil)
+		if len(indices) == 0 {
+			return nil
+		}
+		w.WriteOffset(coverage)
+		for k, classDef := range table.classDefs {
+			if table.chained || k == 1 {
+				w.WriteOffset(layoutClassDefObject(s.classes(classDef)))
+			}
+		}
+		w.WriteUint16(uint16(len(table.rules)))
+		for _, rules := range table.rules {
+			if rules == nil {
+				w.WriteOffset(nil)
+			} else {
+				w.WriteOffset(writeLayoutRuleSet(rules, table.chained))
+			}
+		}
+	} else {
+		coverages := [3][]*layoutObject{}
+		for k := 0; k < 3; k++ {
+			for _, glyphIDs := range table.coverages[k] {
+				indices, coverage := s.coverage(glyphIDs, nil)
+				if len(indices) == 0 {
+					return nil // can never match
+				}
+				coverages[k] = append(coverages[k], coverage)
+			}
+		}
+		for k := 0; k < 3; k++ {
+			if !table.chained && k != 1 {
+				continue
+			}
+			w.WriteUint16(uint16(len(coverages[k])))
+			if !table.chained {
+				w.WriteUint16(uint16(len(table.records)))
+			}
+			for _, coverage := range coverages[k] {
+				w.WriteOffset(coverage)
+			}
+		}
+		if table.chained {
+			w.WriteUint16(uint16(len(table.records)))
+		}
+		writeLayoutRecords(w, table.records)
+	}
+	return w.Object()
+}
+
+////////////////////////////////////////////////////////////////
+
+type gposSingle struct {
+	format      uint16
+	coverage    []uint16
+	valueFormat uint16
+	values      []layoutValue // single value for format 1
+}
+
+func (sfnt *SFNT) parseGPOSSingle(b []byte) (layoutSubtable, error) {
+	r := NewBinaryReader(b)
+	table := &gposSingle{
+		format: r.ReadUint16(),
+	}
+	var err error
+	if table.coverage, err = parseLayoutCoverage(layoutAt(b, uint32(r.ReadUint16()))); err != nil {
+		return nil, err
+	}
+	table.valueFormat = r.ReadUint16()
+
+	valueCount := uint16(1)
+	if table.format == 2 {
+		if valueCount = r.ReadUint16(); int(valueCount) != len(table.coverage) {
+			return nil, fmt.Errorf("bad single positioning table")
+		}
+	} else if table.format != 1 {
+		return nil, fmt.Errorf("bad single positioning table format")
+	}
+	table.values = make([]layoutValue, valueCount)
+	for i := range table.values {
+		if table.values[i], err = parseLayoutValue(r, b, table.valueFormat); err != nil {
+			return nil, err
+		}
+	}
+	if r.EOF() {
+		return nil, fmt.Errorf("bad single positioning table")
+	}
+	return table, nil
+}
+
+func (table *gposSingle) subset(s *layoutSubsetter) *layoutObject {
+	indices, coverage := s.coverage(table.coverage, nil)
+	if len(indices) == 0 {
+		return nil
+	}
+
+	w := newLayoutWriter()
+	w.WriteUint16(table.format)
+	w.WriteOffset(coverage)
+	w.WriteUint16(table.valueFormat)
+	if table.format == 1 {
+		w.writeValue(table.values[0])
+	} else {
+		w.WriteUint16(uint16(len(indices)))
+		for _, i := range indices {
+			w.writeValue(table.values[i])
+		}
+	}
+	return w.Object()
+}
+
+type gposPairValue struct {
+	secondGlyph uint16
+	values      [2]layoutValue
+}
+
+type gposPair struct {
+	coverage     []uint16
+	valueFormats [2]uint16
+	pairSets     [][]gposPairValue
+}
+
+type gposPairClass struct {
+	coverage     []uint16
+	valueFormats [2]uint16
+	classDefs    [2]classDefTable
+	class2Count  uint16
+	records      [][][2]layoutValue // by class1 and class2
+}
+
+func (sfnt *SFNT) parseGPOSPair(b []byte) (layoutSubtable, error) {
+	r := NewBinaryReader(b)
+	posFormat := r.ReadUint16()
+	coverage, err := parseLayoutCoverage(layoutAt(b, uint32(r.ReadUint16())))
+	if err != nil {
+		return nil, err
+	}
+	valueFormats := [2]uint16{r.ReadUint16(), r.ReadUint16()}
+
+	if posFormat == 1 {
+		if int(r.ReadUint16()) != len(coverage) {
+			return nil, fmt.Errorf("bad pair positioning table")
+		}
+		table := &gposPair{
+			coverage:     coverage,
+			valueFormats: valueFormats,
+			pairSets:     make([][]gposPairValue, len(coverage)),
+		}
+		for i := range coverage {
+			pairSet := layoutAt(b, uint32(r.ReadUint16()))
+			r2 := NewBinaryReader(pairSet)
+			pairValueCount := r2.ReadUint16()
+			table.pairSets[i] = make([]gposPairValue, pairValueCount)
+			for j := 0; j < int(pairValueCount); j++ {
+				table.pairSets[i][j].secondGlyph = r2.ReadUint16()
+				for k := 0; k < 2; k++ {
+					if table.pairSets[i][j].values[k], err = parseLayoutValue(r2, pairSet, valueFormats[k]); err != nil {
+						return nil, err
+					}
+				}
+			}
+			if r2.EOF() {
+				return nil, fmt.Errorf("bad pair positioning table")
+			}
+		}
+		if r.EOF() {
+			return nil, fmt.Errorf("bad pair positioning table")
+		}
+		return table, nil
+	} else if posFormat != 2 {
+		return nil, fmt.Errorf("bad pair positioning table format")
+	}
+
+	classDef1Offset := r.ReadUint16()
+	classDef2Offset := r.ReadUint16()
+	class1Count := r.ReadUint16()
+	class2Count := r.ReadUint16()
+	table := &gposPairClass{
+		coverage:     coverage,
+		valueFormats: valueFormats,
+		class2Count:  class2Count,
+		records:      make([][][2]layoutValue, class1Count),
+	}
+	if table.classDefs[0], err = sfnt.parseLayoutClassDef(b, classDef1Offset, class1Count); err != nil {
+		return nil, err
+	} else if table.classDefs[1], err = sfnt.parseLayoutClassDef(b, classDef2Offset, class2Count); err != nil {
+		return nil, err
+	}
+	for i := 0; i < int(class1Count); i++ {
+		table.records[i] = make([][2]layoutValue, class2Count)
+		for j := 0; j < int(class2Count); j++ {
+			for k := 0; k < 2; k++ {
+				if table.records[i][j][k], err = parseLayoutValue(r, b, valueFormats[k]); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	if r.EOF() {
+		return nil, fmt.Errorf("bad pair positioning table")
+	}
+	return table, nil
+}
+
+func (table *gposPair) subset(s *layoutSubsetter) *layoutObject {
+	pairSets := make([][]gposPairValue, len(table.pairSets))
+	for i, pairSet := range table.pairSets {
+		for _, pairValue := range pairSet {
+			if s.has(pairValue.secondGlyph) {
+				pairSets[i] = append(pairSets[i], pairValue)
+			}
+		}
+		sort.SliceStable(pairSets[i], func(a, b int) bool {
+			return s.glyphMap[pairSets[i][a].secondGlyph] < s.glyphMap[pairSets[i][b].secondGlyph]
+		})
+	}
+
+	indices, coverage := s.coverage(table.coverage, func(i int) bool {
+		return 0 < len(pairSets[i])
+	})
+	if len(indices) == 0 {
+		return nil
+	}
+
+	w := newLayoutWriter()
+	w.WriteUint16(1) // posFormat
+	w.WriteOffset(coverage)
+	w.WriteUint16(table.valueFormats[0])
+	w.WriteUint16(table.valueFormats[1])
+	w.WriteUint16(uint16(len(indices)))
+	for _, i := range indices {
+		w2 := newLayoutWriter()
+		w2.WriteUint16(uint16(len(pairSets[i])))
+		for _, pairValue := range pairSets[i] {
+			w2.WriteUint16(s.glyphMap[pairValue.secondGlyph])
+			w2.writeValue(pairValue.values[0])
+			w2.writeValue(pairValue.values[1])
+		}
+		w.WriteOffset(w2.Object())
+	}
+	return w.Object()
+}
+
+func (table *gposPairClass) subset(s *layoutSubsetter) *layoutObject {
+	indices, coverage := s.coverage(table.coverage, nil)
+	if len(indices) == 0 {
+		return nil
+	}
+
+	// only keep the classes used by the retained glyphs, class zero is always kept
+	classMaps := [2]map[uint16]uint16{{0: 0}, {0: 0}}
+	classLists := [2][]uint16{{}, {}}
+	classDefs := [2]map[uint16]uint16{{}, s.classes(table.classDefs[1])}
+	for _, i := range indices {
+		glyphID := table.coverage[i]
+		if class := table.classDefs[0].Get(glyphID); class != 0 {
+			classDefs[0][s.glyphMap[glyphID]] = class
+		}
+	}
+	for k := 0; k < 2; k++ {
+		for _, class := range classDefs[k] {
+			classMaps[k][class] = 0
+		}
+		for class := range classMaps[k] {
+			classLists[k] = append(classLists[k], class)
+		}
+		sort.Slice(classLists[k], func(i, j int) bool { return classLists[k][i] < classLists[k][j] })
+		for i, class := range classLists[k] {
+			classMaps[k][class] = uint16(i)
+		}
+		for glyphID, class := range classDefs[k] {
+			classDefs[k][glyphID] = classMaps[k][class]
+		}
+	}
+
+	w := newLayoutWriter()
+	w.WriteUint16(2) // posFormat
+	w.WriteOffset(coverage)
+	w.WriteUint16(table.valueFormats[0])
+	w.WriteUint16(table.valueFormats[1])
+	w.WriteOffset(layoutClassDefObject(classDefs[0]))
+	w.WriteOffset(layoutClassDefObject(classDefs[1]))
+	w.WriteUint16(uint16(len(classLists[0])))
+	w.WriteUint16(uint16(len(classLists[1])))
+	for _, class1 := range classLists[0] {
+		for _, class2 := range classLists[1] {
+			w.writeValue(table.records[class1][class2][0])
+			w.writeValue(table.records[class1][class2][1])
+		}
+	}
+	return w.Object()
+}
+
+type gposCursive struct {
+	coverage []uint16
+	anchors  [][2]*layoutObject // entry and exit
+}
+
+func (sfnt *SFNT) parseGPOSCursive(b []byte) (layoutSubtable, error) {
+	r := NewBinaryReader(b)
+	if r.ReadUint16() != 1 {
+		return nil, fmt.Errorf("bad cursive attachment table format")
+	}
+	coverage, err := parseLayoutCoverage(layoutAt(b, uint32(r.ReadUint16())))
+	if err != nil {
+		return nil, err
+	} else if int(r.ReadUint16()) != len(coverage) {
+		return nil, fmt.Errorf("bad cursive attachment table")
+	}
+
+	table := &gposCursive{
+		coverage: coverage,
+		anchors:  make([][2]*layoutObject, len(coverage)),
+	}
+	anchors := map[uint16]*layoutObject{}
+	for i := range coverage {
+		for k := 0; k < 2; k++ {
+			if table.anchors[i][k], err = parseLayoutAnchor(b, r.ReadUint16(), anchors); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if r.EOF() {
+		return nil, fmt.Errorf("bad cursive attachment table")
+	}
+	return table, nil
+}
+
+func (table *gposCursive) subset(s *layoutSubsetter) *layoutObject {
+	indices, coverage := s.coverage(table.coverage, nil)
+	if len(indices) == 0 {
+		return nil
+	}
+
+	w := newLayoutWriter()
+	w.WriteUint16(1) // posFormat
+	w.WriteOffset(coverage)
+	w.WriteUint16(uint16(len(indices)))
+	for _, i := range indices {
+		w.WriteOffset(table.anchors[i][0])
+		w.WriteOffset(table.anchors[i][1])
+	}
+	return w.Object()
+}
+
+type gposMark struct {
+	class  uint16
+	anchor *layoutObject
+}
+
+// gposMarkAttach is a mark-to-base, mark-to-ligature, or mark-to-mark attachment, where the anchors are by base, ligature component, and mark class. Bases have a single component.
+type gposMarkAttach struct {
+	ligature     bool
+	markCoverage []uint16
+	baseCoverage []uint16
+	classCount   uint16
+	marks        []gposMark
+	bases        [][][]*layoutObject
+}
+
+// parseGPOSAnchorMatrix parses the anchor offsets of a BaseArray, LigatureAttach, or Mark2Array table.
+func parseGPOSAnchorMatrix(b []byte, classCount uint16) ([][]*layoutObject, error) {
+	r := NewBinaryReader(b)
+	rowCount := r.ReadUint16()
+	anchors := map[uint16]*layoutObject{}
+	matrix := make([][]*layoutObject, rowCount)
+	for i := range matrix {
+		matrix[i] = make([]*layoutObject, classCount)
+		for j := range matrix[i] {
+			var err error
+			if matrix[i][j], err = parseLayoutAnchor(b, r.ReadUint16(), anchors); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if r.EOF() {
+		return nil, fmt.Errorf("bad anchor array")
+	}
+	return matrix, nil
+}
+
+func writeGPOSAnchorMatrix(matrix [][]*layoutObject) *layoutObject {
+	w := newLayoutWriter()
+	w.WriteUint16(uint16(len(matrix)))
+	for _, anchors := range matrix {
+		for _, anchor := range anchors {
+			w.WriteOffset(anchor)
+		}
+	}
+	return w.Object()
+}
+
+func (sfnt *SFNT) parseGPOSMarkAttach(b []byte, ligature bool) (layoutSubtable, error) {
+	r := NewBinaryReader(b)
+	if r.ReadUint16() != 1 {
+		return nil, fmt.Errorf("bad mark attachment table format")
+	}
+	markCoverage, err := parseLayoutCoverage(layoutAt(b, uint32(r.ReadUint16())))
+	if err != nil {
+		return nil, err
+	}
+	baseCoverage, err := parseLayoutCoverage(layoutAt(b, uint32(r.ReadUint16())))
+	if err != nil {
+		return nil, err
+	}
+	table := &gposMarkAttach{
+		ligature:     ligature,
+		markCoverage: markCoverage,
+		baseCoverage: baseCoverage,
+		classCount:   r.ReadUint16(),
+	}
+
+	markArray := layoutAt(b, uint32(r.ReadUint16()))
+	r2 := NewBinaryReader(markArray)
+	if int(r2.ReadUint16()) != len(markCoverage) {
+		return nil, fmt.Errorf("bad mark array")
+	}
+	table.marks = make([]gposMark, len(markCoverage))
+	anchors := map[uint16]*layoutObject{}
+	for i := range table.marks {
+		if table.marks[i].class = r2.ReadUint16(); table.classCount <= table.marks[i].class {
+			return nil, fmt.Errorf("bad mark class")
+		} else if table.marks[i].anchor, err = parseLayoutAnchor(markArray, r2.ReadUint16(), anchors); err != nil {
+			return nil, err
+		}
+	}
+	if r2.EOF() {
+		return nil, fmt.Errorf("bad mark array")
+	}
+
+	baseArray := layoutAt(b, uint32(r.ReadUint16()))
+	r2 = NewBinaryReader(baseArray)
+	if ligature {
+		if int(r2.ReadUint16()) != len(baseCoverage) {
+			return nil, fmt.Errorf("bad ligature array")
+		}
+		table.bases = make([][][]*layoutObject, len(baseCoverage))
+		for i := range table.bases {
+			ligatureAttach := layoutAt(baseArray, uint32(r2.ReadUint16()))
+			if table.bases[i], err = parseGPOSAnchorMatrix(ligatureAttach, table.classCount); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		matrix, err := parseGPOSAnchorMatrix(baseArray, table.classCount)
+		if err != nil {
+			return nil, err
+		} else if len(matrix) != len(baseCoverage) {
+			return nil, fmt.Errorf("bad base array")
+		}
+		table.bases = make([][][]*layoutObject, len(baseCoverage))
+		for i := range table.bases {
+			table.bases[i] = matrix[i : i+1]
+		}
+	}
+	if r.EOF() || r2.EOF() {
+		return nil, fmt.Errorf("bad mark attachment table")
+	}
+	return table, nil
+}
+
+func (table *gposMarkAttach) subset(s *layoutSubsetter) *layoutObject {
+	markIndices, markCoverage := s.coverage(table.markCoverage, nil)
+	baseIndices, baseCoverage := s.coverage(table.baseCoverage, nil)
+	if len(markIndices) == 0 || len(baseIndices) == 0 {
+		return nil
+	}
+
+	w2 := newLayoutWriter()
+	w2.WriteUint16(uint16(len(markIndices)))
+	for _, i := range markIndices {
+		w2.WriteUint16(table.marks[i].class)
+		w2.WriteOffset(table.marks[i].anchor)
+	}
+
+	var baseArray *layoutObject
+	if table.ligature {
+		w3 := newLayoutWriter()
+		w3.WriteUint16(uint16(len(baseIndices)))
+		for _, i := range baseIndices {
+			w3.WriteOffset(writeGPOSAnchorMatrix(table.bases[i]))
+		}
+		baseArray = w3.Object()
+	} else {
+		matrix := make([][]*layoutObject, len(baseIndices))
+		for j, i := range baseIndices {
+			matrix[j] = table.bases[i][0]
+		}
+		baseArray = writeGPOSAnchorMatrix(matrix)
+	}
+
+	w := newLayoutWriter()
+	w.WriteUint16(1) // posFormat
+	w.WriteOffset(markCoverage)
+	w.WriteOffset(baseCoverage)
+	w.WriteUint16(table.classCount)
+	w.WriteOffset(w2.Object())
+	w.WriteOffset(baseArray)
+	return w.Object()
+}
+
+////////////////////////////////////////////////////////////////
+
+type layoutLookup struct {
+	lookupType       uint16 // extension lookups are resolved
+	lookupFlag       uint16
+	subtables        []layoutSubtable
+	markFilteringSet uint16
+}
+
+// layoutTable is a GSUB or GPOS table, where the script and feature lists do not depend on glyphs and are kept as is.
+type layoutTable struct {
+	gpos        bool
+	scriptList  *layoutObject
+	featureList *layoutObject
+	lookups     []layoutLookup
+}
+
+func parseLayoutScriptList(b []byte) (*layoutObject, error) {
+	if b == nil {
+		return nil, nil
+	}
+
+	langSysObject := func(b []byte) (*layoutObject, error) {
+		r := NewBinaryReader(b)
+		_ = r.ReadUint16() // lookupOrderOffset
+		_ = r.ReadUint16() // requiredFeatureIndex
+		n := 6 + 2*int(r.ReadUint16())
+		if r.EOF() || len(b) < n {
+			return nil, fmt.Errorf("bad language system table")
+		}
+		return &layoutObject{data: b[:n]}, nil
+	}
+
+	r := NewBinaryReader(b)
+	w := newLayoutWriter()
+	scriptCount := r.ReadUint16()
+	w.WriteUint16(scriptCount)
+	for i := 0; i < int(scriptCount); i++ {
+		w.WriteBytes(r.ReadBytes(4)) // scriptTag
+		script := layoutAt(b, uint32(r.ReadUint16()))
+		r2 := NewBinaryReader(script)
+		w2 := newLayoutWriter()
+		if offset := r2.ReadUint16(); offset != 0 {
+			langSys, err := langSysObject(layoutAt(script, uint32(offset)))
+			if err != nil {
+				return nil, err
+			}
+			w2.WriteOffset(langSys)
+		} else {
+			w2.WriteOffset(nil)
+		}
+		langSysCount := r2.ReadUint16()
+		w2.WriteUint16(langSysCount)
+		for j := 0; j < int(langSysCount); j++ {
+			w2.WriteBytes(r2.ReadBytes(4)) // langSysTag
+			langSys, err := langSysObject(layoutAt(script, uint32(r2.ReadUint16())))
+			if err != nil {
+				return nil, err
+			}
+			w2.WriteOffset(langSys)
+		}
+		if r2.EOF() {
+			return nil, fmt.Errorf("bad script table")
+		}
+		w.WriteOffset(w2.Object())
+	}
+	if r.EOF() {
+		return nil, fmt.Errorf("bad script list")
+	}
+	return w.Object(), nil
+}
+
+func parseLayoutFeatureList(b []byte) (*layoutObject, error) {
+	if b == nil {
+		return nil, nil
+	}
+
+	r := NewBinaryReader(b)
+	w := newLayoutWriter()
+	featureCount := r.ReadUint16()
+	w.WriteUint16(featureCount)
+	for i := 0; i < int(featureCount); i++ {
+		featureTag := r.ReadBytes(4)
+		w.WriteBytes(featureTag)
+		feature := layoutAt(b, uint32(r.ReadUint16()))
+		r2 := NewBinaryReader(feature)
+
+		// feature parameters of stylistic sets, character variants, and optical size
+		var featureParams *layoutObject
+		if params := layoutAt(feature, uint32(r2.ReadUint16())); params != nil && featureTag != nil {
+			n := 0
+			if featureTag[0] == 's' && featureTag[1] == 's' {
+				n = 4
+			} else if featureTag[0] == 'c' && featureTag[1] == 'v' && 14 <= len(params) {
+				n = 14 + 3*int(binary.BigEndian.Uint16(params[12:]))
+			} else if string(featureTag) == "size" {
+				n = 10
+			}
+			if 0 < n && n <= len(params) {
+				data := params[:n]
+				if n%2 == 1 {
+					data = append(append([]byte{}, data...), 0)
+				}
+				featureParams = &layoutObject{data: data}
+			}
+		}
+		w2 := newLayoutWriter()
+		w2.WriteOffset(featureParams)
+		lookupIndexCount := r2.ReadUint16()
+		w2.WriteUint16(lookupIndexCount)
+		w2.WriteBytes(r2.ReadBytes(2 * uint32(lookupIndexCount)))
+		if r2.EOF() {
+			return nil, fmt.Errorf("bad feature table")
+		}
+		w.WriteOffset(w2.Object())
+	}
+	if r.EOF() {
+		return nil, fmt.Errorf("bad feature list")
+	}
+	return w.Object(), nil
+}
+
+func (sfnt *SFNT) parseLayoutSubtable(b []byte, gpos bool, lookupType uint16) (layoutSubtable, error) {
+	if gpos {
+		switch lookupType {
+		case 1:
+			return sfnt.parseGPOSSingle(b)
+		case 2:
+			return sfnt.parseGPOSPair(b)
+		case 3:
+			return sfnt.parseGPOSCursive(b)
+		case 4, 6:
+			return sfnt.parseGPOSMarkAttach(b, false)
+		case 5:
+			return sfnt.parseGPOSMarkAttach(b, true)
+		case 7:
+			return sfnt.parseLayoutContext(b, false)
+		case 8:
+			return sfnt.parseLayoutContext(b, true)
+		}
+	} else {
+		switch lookupType {
+		case 1:
+			return sfnt.parseGSUBSingle(b)
+		case 2:
+			return sfnt.parseGSUBMultiple(b, false)
+		case 3:
+			return sfnt.parseGSUBMultiple(b, true)
+		case 4:
+			return sfnt.parseGSUBLigature(b)
+		case 5:
+			return sfnt.parseLayoutContext(b, false)
+		case 6:
+			return sfnt.parseLayoutContext(b, true)
+		case 8:
+			return sfnt.parseGSUBReverseChain(b)
+		}
+	}
+	return nil, fmt.Errorf("bad lookup type %d", lookupType)
+}
+
+// parseLayoutTable parses a GSUB or GPOS table in full for subsetting.
+func (sfnt *SFNT) parseLayoutTable(tag string) (*layoutTable, error) {
+	b, ok := sfnt.Tables[tag]
+	if !ok {
+		return nil, fmt.Errorf("%s: missing table", tag)
+	}
+
+	table := &layoutTable{
+		gpos: tag == "GPOS",
+	}
+	extensionType := uint16(7)
+	if table.gpos {
+		extensionType = 9
+	}
+
+	var err error
+	r := NewBinaryReader(b)
+	if majorVersion := r.ReadUint16(); majorVersion != 1 {
+		return nil, fmt.Errorf("%s: bad version", tag)
+	}
+	_ = r.ReadUint16() // minorVersion, feature variations are not retained
+	if table.scriptList, err = parseLayoutScriptList(layoutAt(b, uint32(r.ReadUint16()))); err != nil {
+		return nil, fmt.Errorf("%s: %w", tag, err)
+	} else if table.featureList, err = parseLayoutFeatureList(layoutAt(b, uint32(r.ReadUint16()))); err != nil {
+		return nil, fmt.Errorf("%s: %w", tag, err)
+	}
+
+	lookupList := layoutAt(b, uint32(r.ReadUint16()))
+	r2 := NewBinaryReader(lookupList)
+	lookupCount := r2.ReadUint16()
+	table.lookups = make([]layoutLookup, lookupCount)
+	for i := range table.lookups {
+		data := layoutAt(lookupList, uint32(r2.ReadUint16()))
+		r3 := NewBinaryReader(data)
+		lookup := &table.lookups[i]
+		lookup.lookupType = r3.ReadUint16()
+		lookup.lookupFlag = r3.ReadUint16()
+		subtableCount := r3.ReadUint16()
+		lookup.subtables = make([]layoutSubtable, subtableCount)
+		extension := lookup.lookupType == extensionType
+		for j := range lookup.subtables {
+			subtable := layoutAt(data, uint32(r3.ReadUint16()))
+			if extension {
+				r4 := NewBinaryReader(subtable)
+				_ = r4.ReadUint16() // format
+				lookupType := r4.ReadUint16()
+				subtable = layoutAt(subtable, r4.ReadUint32())
+				if r4.EOF() || lookupType == extensionType || j != 0 && lookupType != lookup.lookupType {
+					return nil, fmt.Errorf("%s: bad extension table", tag)
+				}
+				if j == 0 {
+					lookup.lookupType = lookupType
+				}
+			}
+			if lookup.subtables[j], err = sfnt.parseLayoutSubtable(subtable, table.gpos, lookup.lookupType); err != nil {
+				return nil, fmt.Errorf("%s: lookup %d: %w", tag, i, err)
+			}
+		}
+		if lookup.lookupFlag&0x0010 != 0 {
+			lookup.markFilteringSet = r3.ReadUint16()
+		}
+		if r3.EOF() {
+			return nil, fmt.Errorf("%s: bad lookup table", tag)
+		}
+	}
+	if r.EOF() || r2.EOF() {
+		return nil, fmt.Errorf("%s: bad table", tag)
+	}
+	return table, nil
+}
+
+func (table *layoutTable) subset(s *layoutSubsetter, extension bool) *layoutObject {
+	extensionType := uint16(7)
+	if table.gpos {
+		extensionType = 9
+	}
+
+	wLookupList := newLayoutWriter()
+	wLookupList.WriteUint16(uint16(len(table.lookups)))
+	for _, lookup := range table.lookups {
+		subtables := []*layoutObject{}
+		for _, subtable := range lookup.subtables {
+			if obj := subtable.subset(s); obj != nil {
+				obj.isolated = true
+				subtables = append(subtables, obj)
+			}
+		}
+
+		// lookups are kept even if empty so that the lookup indices remain valid
+		w := newLayoutWriter()
+		if extension {
+			w.WriteUint16(extensionType)
+		} else {
+			w.WriteUint16(lookup.lookupType)
+		}
+		w.WriteUint16(lookup.lookupFlag)
+		w.WriteUint16(uint16(len(subtables)))
+		for _, subtable := range subtables {
+			if extension {
+				w2 := newLayoutWriter()
+				w2.WriteUint16(1) // format
+				w2.WriteUint16(lookup.lookupType)
+				w2.WriteOffset32(subtable)
+				w.WriteOffset(w2.Object())
+			} else {
+				w.WriteOffset(subtable)
+			}
+		}
+		if lookup.lookupFlag&0x0010 != 0 {
+			w.WriteUint16(lookup.markFilteringSet)
+		}
+		wLookupList.WriteOffset(w.Object())
+	}
+
+	w := newLayoutWriter()
+	w.WriteUint16(1) // majorVersion
+	w.WriteUint16(0) // minorVersion
+	w.WriteOffset(table.scriptList)
+	w.WriteOffset(table.featureList)
+	w.WriteOffset(wLookupList.Object())
+	return w.Object()
+}
+
+////////////////////////////////////////////////////////////////
+
+type gdefTable struct {
+	minorVersion       uint16
+	glyphClassDef      classDefTable
+	attachCoverage     []uint16
+	attachPoints       []*layoutObject
+	ligCaretCoverage   []uint16
+	ligGlyphs          []*layoutObject
+	markAttachClassDef classDefTable
+	markGlyphSets      [][]uint16
+}
+
+func (sfnt *SFNT) parseGDEFTable() (*gdefTable, error) {
+	b, ok := sfnt.Tables["GDEF"]
+	if !ok {
+		return nil, fmt.Errorf("GDEF: missing table")
+	}
+
+	var err error
+	r := NewBinaryReader(b)
+	table := &gdefTable{}
+	if majorVersion := r.ReadUint16(); majorVersion != 1 {
+		return nil, fmt.Errorf("GDEF: bad version")
+	}
+	table.minorVersion = r.ReadUint16()
+	if glyphClassDefOffset := r.ReadUint16(); glyphClassDefOffset != 0 {
+		if table.glyphClassDef, err = sfnt.parseLayoutClassDef(b, glyphClassDefOffset, 0xFFFF); err != nil {
+			return nil, fmt.Errorf("GDEF: %w", err)
+		}
+	}
+
+	if attachList := layoutAt(b, uint32(r.ReadUint16())); attachList != nil {
+		r2 := NewBinaryReader(attachList)
+		if table.attachCoverage, err = parseLayoutCoverage(layoutAt(attachList, uint32(r2.ReadUint16()))); err != nil {
+			return nil, fmt.Errorf("GDEF: %w", err)
+		} else if int(r2.ReadUint16()) != len(table.attachCoverage) {
+			return nil, fmt.Errorf("GDEF: bad attachment point list")
+		}
+		table.attachPoints = make([]*layoutObject, len(table.attachCoverage))
+		for i := range table.attachPoints {
+			attachPoint := layoutAt(attachList, uint32(r2.ReadUint16()))
+			n := 2
+			if 2 <= len(attachPoint) {
+				n += 2 * int(binary.BigEndian.Uint16(attachPoint))
+			}
+			if len(attachPoint) < n {
+				return nil, fmt.Errorf("GDEF: bad attachment point table")
+			}
+			table.attachPoints[i] = &layoutObject{data: attachPoint[:n]}
+		}
+		if r2.EOF() {
+			return nil, fmt.Errorf("GDEF: bad attachment point list")
+		}
+	}
+
+	if ligCaretList := layoutAt(b, uint32(r.ReadUint16())); ligCaretList != nil {
+		r2 := NewBinaryReader(ligCaretList)
+		if table.ligCaretCoverage, err = parseLayoutCoverage(layoutAt(ligCaretList, uint32(r2.ReadUint16()))); err != nil {
+			return nil, fmt.Errorf("GDEF: %w", err)
+		} else if int(r2.ReadUint16()) != len(table.ligCaretCoverage) {
+			return nil, fmt.Errorf("GDEF: bad ligature caret list")
+		}
+		table.ligGlyphs = make([]*layoutObject, len(table.ligCaretCoverage))
+		for i := range table.ligGlyphs {
+			ligGlyph := layoutAt(ligCaretList, uint32(r2.ReadUint16()))
+			r3 := NewBinaryReader(ligGlyph)
+			w := newLayoutWriter()
+			caretCount := r3.ReadUint16()
+			w.WriteUint16(caretCount)
+			for j := 0; j < int(caretCount); j++ {
+				caretValue := layoutAt(ligGlyph, uint32(r3.ReadUint16()))
+				r4 := NewBinaryReader(caretValue)
+				w2 := newLayoutWriter()
+				caretValueFormat := r4.ReadUint16()
+				w2.WriteUint16(caretValueFormat)
+				w2.WriteUint16(r4.ReadUint16()) // coordinate or caretValuePointIndex
+				if caretValueFormat == 3 {
+					device, err := parseLayoutDevice(layoutAt(caretValue, uint32(r4.ReadUint16())))
+					if err != nil {
+						return nil, fmt.Errorf("GDEF: %w", err)
+					}
+					w2.WriteOffset(device)
+				} else if caretValueFormat != 1 && caretValueFormat != 2 {
+					return nil, fmt.Errorf("GDEF: bad caret value format")
+				}
+				if r4.EOF() {
+					return nil, fmt.Errorf("GDEF: bad caret value table")
+				}
+				w.WriteOffset(w2.Object())
+			}
+			if r3.EOF() {
+				return nil, fmt.Errorf("GDEF: bad ligature glyph table")
+			}
+			table.ligGlyphs[i] = w.Object()
+		}
+		if r2.EOF() {
+			return nil, fmt.Errorf("GDEF: bad ligature caret list")
+		}
+	}
+
+	if markAttachClassDefOffset := r.ReadUint16(); markAttachClassDefOffset != 0 {
+		if table.markAttachClassDef, err = sfnt.parseLayoutClassDef(b, markAttachClassDefOffset, 0xFFFF); err != nil {
+			return nil, fmt.Errorf("GDEF: %w", err)
+		}
+	}
+
+	if 2 <= table.minorVersion {
+		if markGlyphSetsDef := layoutAt(b, uint32(r.ReadUint16())); markGlyphSetsDef != nil {
+			r2 := NewBinaryReader(markGlyphSetsDef)
+			if r2.ReadUint16() != 1 {
+				return nil, fmt.Errorf("GDEF: bad mark glyph sets format")
+			}
+			markGlyphSetCount := r2.ReadUint16()
+			table.markGlyphSets = make([][]uint16, markGlyphSetCount)
+			for i := range table.markGlyphSets {
+				if table.markGlyphSets[i], err = parseLayoutCoverage(layoutAt(markGlyphSetsDef, r2.ReadUint32())); err != nil {
+					return nil, fmt.Errorf("GDEF: %w", err)
+				}
+			}
+		}
+	}
+	if r.EOF() {
+		return nil, fmt.Errorf("GDEF: bad table")
+	}
+	return table, nil
+}
+
+// subset returns the GDEF table for the retained glyphs, where the item variation store is not retained.
+func (table *gdefTable) subset(s *layoutSubsetter) *layoutObject {
+	minorVersion := table.minorVersion
+	if 2 < minorVersion {
+		minorVersion = 2
+	}
+
+	w := newLayoutWriter()
+	w.WriteUint16(1) // majorVersion
+	w.WriteUint16(minorVersion)
+	if table.glyphClassDef != nil {
+		w.WriteOffset(layoutClassDefObject(s.classes(table.glyphClassDef)))
+	} else {
+		w.WriteOffset(nil)
+	}
+
+	var attachList *layoutObject
+	if indices, coverage := s.coverage(table.attachCoverage, nil); 0 < len(indices) {
+		w2 := newLayoutWriter()
+		w2.WriteOffset(coverage)
+		w2.WriteUint16(uint16(len(indices)))
+		for _, i := range indices {
+			w2.WriteOffset(table.attachPoints[i])
+		}
+		attachList = w2.Object()
+	}
+	w.WriteOffset(attachList)
+
+	var ligCaretList *layoutObject
+	if indices, coverage := s.coverage(table.ligCaretCoverage, nil); 0 < len(indices) {
+		w2 := newLayoutWriter()
+		w2.WriteOffset(coverage)
+		w2.WriteUint16(uint16(len(indices)))
+		for _, i := range indices {
+			w2.WriteOffset(table.ligGlyphs[i])
+		}
+		ligCaretList = w2.Object()
+	}
+	w.WriteOffset(ligCaretList)
+
+	if table.markAttachClassDef != nil {
+		w.WriteOffset(layoutClassDefObject(s.classes(table.markAttachClassDef)))
+	} else {
+		w.WriteOffset(nil)
+	}
+
+	if minorVersion == 2 {
+		// mark glyph sets are kept even if empty so that their indices remain valid
+		var markGlyphSetsDef *layoutObject
+		if table.markGlyphSets != nil {
+			w2 := newLayoutWriter()
+			w2.WriteUint16(1) // format
+			w2.WriteUint16(uint16(len(table.markGlyphSets)))
+			for _, glyphIDs := range table.markGlyphSets {
+				_, coverage := s.coverage(glyphIDs, nil)
+				w2.WriteOffset32(coverage)
+			}
+			markGlyphSetsDef = w2.Object()
+		}
+		w.WriteOffset(markGlyphSetsDef)
+	}
+	return w.Object()
+}
+
+////////////////////////////////////////////////////////////////
+
+// gsubClosure returns the glyphs that are not in glyphIDs but that may be substituted for them by any of the GSUB lookups, in increasing order. Lookups referenced by contextual substitutions are applied to all glyphs, which may retain more glyphs than necessary.
+func (sfnt *SFNT) gsubClosure(glyphIDs []uint16) ([]uint16, error) {
+	if _, ok := sfnt.Tables["GSUB"]; !ok {
+		return nil, nil
+	}
+	table, err := sfnt.parseLayoutTable("GSUB")
+	if err != nil {
+		return nil, err
+	}
+
+	glyphs := make(map[uint16]bool, len(glyphIDs))
+	for _, glyphID := range glyphIDs {
+		glyphs[glyphID] = true
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, lookup := range table.lookups {
+			for _, subtable := range lookup.subtables {
+				if closure, ok := subtable.(layoutClosure); ok && closure.closure(glyphs) {
+					changed = true
+				}
+			}
+		}
+	}
+	for _, glyphID := range glyphIDs {
+		delete(glyphs, glyphID)
+	}
+
+	closure := make([]uint16, 0, len(glyphs))
+	for glyphID := range glyphs {
+		if glyphID < sfnt.Maxp.NumGlyphs {
+			closure = append(closure, glyphID)
+		}
+	}
+	sort.Slice(closure, func(i, j int) bool { return closure[i] < closure[j] })
+	return closure, nil
+}
+
+// subsetLayoutTable returns the GDEF, GSUB, or GPOS table for a subset, where glyphMap maps the original to the subset glyph IDs.
+func (sfnt *SFNT) subsetLayoutTable(tag string, glyphMap map[uint16]uint16) ([]byte, error) {
+	s := &layoutSubsetter{
+		glyphMap: glyphMap,
+	}
+	if tag == "GDEF" {
+		table, err := sfnt.parseGDEFTable()
+		if err != nil {
+			return nil, err
+		}
+		return packLayout(table.subset(s))
+	}
+
+	table, err := sfnt.parseLayoutTable(tag)
+	if err != nil {
+		return nil, err
+	}
+	b, err := packLayout(table.subset(s, false))
+	if err == errLayoutOverflow {
+		// use extension lookups with 32-bit offsets to the subtables
+		b, err = packLayout(table.subset(s, true))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", tag, err)
+	}
+	return b, nil
+}
@@ -0,0 +1,173 @@
+package font
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/tdewolff/test"
+)
+
+// layoutLigatures returns the ligature glyphs of a GSUB table by their component glyphs.
+func layoutLigatures(table *layoutTable) map[[3]uint16]uint16 {
+	ligatures := map[[3]uint16]uint16{}
+	for _, lookup := range table.lookups {
+		for _, subtable := range lookup.subtables {
+			if ligatureSubst, ok := subtable.(*gsubLigature); ok {
+				for i, glyphID := range ligatureSubst.coverage {
+					for _, ligature := range ligatureSubst.ligatures[i] {
+						key := [3]uint16{glyphID}
+						copy(key[1:], ligature.componentGlyphIDs)
+						ligatures[key] = ligature.ligatureGlyph
+					}
+				}
+			}
+		}
+	}
+	return ligatures
+}
+
+// layoutPairAdjustment returns the first x advance adjustment of a glyph pair in a GPOS table.
+func layoutPairAdjustment(table *layoutTable, glyphID1, glyphID2 uint16) int16 {
+	xAdvance := func(valueFormat uint16, value layoutValue) int16 {
+		if valueFormat&0x0004 == 0 {
+			return 0
+		}
+		i := 0
+		for _, bit := range []uint16{0x0001, 0x0002} {
+			if valueFormat&bit != 0 {
+				i++
+			}
+		}
+		return int16(value.fields[i])
+	}
+	for _, lookup := range table.lookups {
+		for _, subtable := range lookup.subtables {
+			switch pairPos := subtable.(type) {
+			case *gposPair:
+				for i, glyphID := range pairPos.coverage {
+					if glyphID == glyphID1 {
+						for _, pairValue := range pairPos.pairSets[i] {
+							if pairValue.secondGlyph == glyphID2 {
+								return xAdvance(pairPos.valueFormats[0], pairValue.values[0])
+							}
+						}
+					}
+				}
+			case *gposPairClass:
+				for _, glyphID := range pairPos.coverage {
+					if glyphID == glyphID1 {
+						class1 := pairPos.classDefs[0].Get(glyphID1)
+						class2 := pairPos.classDefs[1].Get(glyphID2)
+						return xAdvance(pairPos.valueFormats[0], pairPos.records[class1][class2][0])
+					}
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestSFNTSubsetLayout(t *testing.T) {
+	b, err := ioutil.ReadFile("../resources/DejaVuSerif.ttf")
+	test.Error(t, err)
+	sfnt, err := ParseSFNT(b, 0)
+	test.Error(t, err)
+
+	f, i, fi, ffi := sfnt.GlyphIndex('f'), sfnt.GlyphIndex('i'), sfnt.GlyphIndex('ﬁ'), sfnt.GlyphIndex('ﬃ')
+	subset, glyphIDs := sfnt.Subset([]uint16{0, f, i}, WriteMinTables)
+	test.T(t, glyphIDs, []uint16{0, f, i, 243, 3314, fi, ffi}) // dotlessi, ff, fi, ffi
+
+	sfntSubset, err := ParseSFNT(subset, 0)
+	test.Error(t, err)
+	for _, tag := range []string{"GDEF", "GPOS", "GSUB"} {
+		_, ok := sfntSubset.Tables[tag]
+		test.That(t, ok, tag, "table must be retained")
+	}
+
+	// ligatures are rewritten for the subset glyph IDs
+	table, err := sfntSubset.parseLayoutTable("GSUB")
+	test.Error(t, err)
+	ligatures := layoutLigatures(table)
+	test.T(t, ligatures[[3]uint16{1, 2}], uint16(5))    // fi
+	test.T(t, ligatures[[3]uint16{1, 1, 2}], uint16(6)) // ffi
+
+	// all runes of the retained glyphs are mapped
+	test.T(t, sfntSubset.GlyphIndex('f'), uint16(1))
+	test.T(t, sfntSubset.GlyphIndex('i'), uint16(2))
+	test.T(t, sfntSubset.GlyphIndex('ﬁ'), uint16(5))
+	test.T(t, sfntSubset.GlyphIndex('x'), uint16(0))
+}
+
+func TestSFNTSubsetKerning(t *testing.T) {
+	b, err := ioutil.ReadFile("../resources/DejaVuSerif.ttf")
+	test.Error(t, err)
+	sfnt, err := ParseSFNT(b, 0)
+	test.Error(t, err)
+
+	table, err := sfnt.parseLayoutTable("GPOS")
+	test.Error(t, err)
+	T, o := sfnt.GlyphIndex('T'), sfnt.GlyphIndex('o')
+	kern := layoutPairAdjustment(table, T, o)
+	test.That(t, kern != 0, "must kern T and o")
+
+	// glyphs in reverse order
+	subset, _ := sfnt.Subset([]uint16{0, o, T}, WriteMinTables)
+	sfntSubset, err := ParseSFNT(subset, 0)
+	test.Error(t, err)
+	table, err = sfntSubset.parseLayoutTable("GPOS")
+	test.Error(t, err)
+	test.T(t, layoutPairAdjustment(table, 2, 1), kern)
+	test.T(t, layoutPairAdjustment(table, 1, 2), layoutPairAdjustment(table, o, T))
+}
+
+func TestLayoutExtension(t *testing.T) {
+	b, err := ioutil.ReadFile("../resources/DejaVuSerif.ttf")
+	test.Error(t, err)
+	sfnt, err := ParseSFNT(b, 0)
+	test.Error(t, err)
+
+	s := &layoutSubsetter{glyphMap: map[uint16]uint16{}}
+	for glyphID := uint16(0); glyphID < sfnt.NumGlyphs(); glyphID++ {
+		s.glyphMap[glyphID] = glyphID
+	}
+	for _, tag := range []string{"GSUB", "GPOS"} {
+		table, err := sfnt.parseLayoutTable(tag)
+		test.Error(t, err)
+		b, err := packLayout(table.subset(s, false))
+		test.Error(t, err)
+		extension, err := packLayout(table.subset(s, true))
+		test.Error(t, err)
+		test.That(t, len(b) < len(extension), tag, "extension lookups must be larger")
+
+		// reading the extension lookups gives the same table
+		sfnt.Tables[tag] = extension
+		table, err = sfnt.parseLayoutTable(tag)
+		test.Error(t, err)
+		b2, err := packLayout(table.subset(s, false))
+		test.Error(t, err)
+		test.Bytes(t, b2, b, tag)
+	}
+}
+
+func TestLayoutCoverage(t *testing.T) {
+	var tts = []struct {
+		glyphIDs []uint16
+		format   uint16
+	}{
+		{[]uint16{}, 1},
+		{[]uint16{5}, 1},
+		{[]uint16{1, 2, 3}, 1},
+		{[]uint16{1, 2, 3, 4}, 2},
+		{[]uint16{1, 2, 3, 4, 10, 20, 21, 22, 23, 24, 25}, 2},
+		{[]uint16{1, 3, 5, 7}, 1},
+	}
+	for _, tt := range tts {
+		t.Run("", func(t *testing.T) {
+			obj := layoutCoverageObject(tt.glyphIDs)
+			test.T(t, uint16(obj.data[1]), tt.format)
+			glyphIDs, err := parseLayoutCoverage(obj.data)
+			test.Error(t, err)
+			test.T(t, glyphIDs, tt.glyphIDs)
+		})
+	}
+}
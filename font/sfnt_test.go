@@ -2,6 +2,7 @@ package font
 
 import (
 	"io/ioutil"
+	"sort"
 	"testing"
 
 	"github.com/tdewolff/test"
@@ -68,3 +69,61 @@ func TestSFNTSubset(t *testing.T) {
 
 	//ioutil.WriteFile("out.otf", subset, 0644)
 }
+
+func TestSFNTSubsetMinTables(t *testing.T) {
+	b, err := ioutil.ReadFile("../resources/DejaVuSerif.ttf")
+	test.Error(t, err)
+
+	sfnt, err := ParseSFNT(b, 0)
+	test.Error(t, err)
+	_, hasFpgm := sfnt.Tables["fpgm"]
+	test.That(t, hasFpgm, "font must have hinting tables")
+
+	// only the minimal table set is written, the hinting tables are dropped
+	subset, _ := sfnt.Subset([]uint16{0, 36, 37}, WriteMinTables)
+	sfntSubset, err := ParseSFNT(subset, 0)
+	test.Error(t, err)
+	tags := []string{}
+	for tag := range sfntSubset.Tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	test.T(t, tags, []string{"GDEF", "GPOS", "GSUB", "OS/2", "cmap", "glyf", "head", "hhea", "hmtx", "loca", "maxp", "name", "post"})
+	test.T(t, sfntSubset.GlyphIndex('A'), uint16(1))
+}
+
+func TestParseCFF(t *testing.T) {
+	b, err := ioutil.ReadFile("../resources/EBGaramond12-Regular.otf")
+	test.Error(t, err)
+
+	otf, err := ParseSFNT(b, 0)
+	test.Error(t, err)
+
+	sfnt, err := ParseCFF(otf.Tables["CFF "])
+	test.Error(t, err)
+	test.T(t, sfnt.NumGlyphs(), otf.NumGlyphs())
+	test.T(t, sfnt.CFF.FontMatrix(), [6]float64{0.001, 0.0, 0.0, 0.001, 0.0, 0.0})
+
+	id := otf.GlyphIndex('A')
+	test.T(t, sfnt.CFF.GlyphName(id), "A")
+	glyphID, ok := sfnt.CFF.GlyphIndexByName("A")
+	test.That(t, ok, "must find glyph by name")
+	test.T(t, glyphID, id)
+	_, ok = sfnt.CFF.GlyphIndexByName("doesnotexist")
+	test.That(t, !ok, "must not find unknown glyph name")
+}
+
+func TestParseDICT(t *testing.T) {
+	// BlueValues 1 2 and OtherBlues 3 4, operands are encoded as b0-139
+	b := []byte{139 + 1, 139 + 2, 6, 139 + 3, 139 + 4, 7}
+	operands := map[int][]int{}
+	err := parseDICT(b, false, nil, func(b0 int, is []int, fs []float64) bool {
+		operands[b0] = is
+		return true
+	})
+	test.Error(t, err)
+
+	// operands kept by the callback are not overwritten by those of later operators
+	test.T(t, operands[6], []int{1, 2})
+	test.T(t, operands[7], []int{3, 4})
+}
@@ -3,6 +3,7 @@ package font
 import (
 	"encoding/binary"
 	"fmt"
+	"math"
 	"strings"
 )
 
@@ -61,6 +62,11 @@ func (contour *glyfContour) String() string {
 type glyfTable struct {
 	data []byte
 	loca *locaTable
+
+	gvar   *gvarTable
+	coords []float64 // normalized variation coordinates, nil for the default instance
+
+	hinter *ttHinter // executes the hinting instructions
 }
 
 func (glyf *glyfTable) Get(glyphID uint16) []byte {
@@ -118,6 +124,68 @@ func (glyf *glyfTable) Dependencies(glyphID uint16, level int) ([]uint16, error)
 	return deps, nil
 }
 
+// numPoints returns the number of points of a simple glyph, or the number of components of a composite glyph, excluding the phantom points.
+func (glyf *glyfTable) numPoints(glyphID uint16) (int, error) {
+	b := glyf.Get(glyphID)
+	if b == nil {
+		return 0, fmt.Errorf("glyf: bad glyphID %v", glyphID)
+	} else if len(b) == 0 {
+		return 0, nil
+	}
+	r := NewBinaryReader(b)
+	if r.Len() < 10 {
+		return 0, fmt.Errorf("glyf: bad table for glyphID %v", glyphID)
+	}
+	numberOfContours := r.ReadInt16()
+	_ = r.ReadBytes(8)
+	if 0 <= numberOfContours {
+		if numberOfContours == 0 {
+			return 0, nil
+		}
+		_ = r.ReadBytes(2 * uint32(numberOfContours-1))
+		endPoint := r.ReadUint16()
+		if r.EOF() {
+			return 0, fmt.Errorf("glyf: bad table for glyphID %v", glyphID)
+		}
+		return int(endPoint) + 1, nil
+	}
+
+	n := 0
+	for {
+		if r.Len() < 4 {
+			return 0, fmt.Errorf("glyf: bad table for glyphID %v", glyphID)
+		}
+		flags := r.ReadUint16()
+		_ = r.ReadUint16() // glyphIndex
+		length, more := glyfCompositeLength(flags)
+		if r.Len() < length-4 {
+			return 0, fmt.Errorf("glyf: bad table for glyphID %v", glyphID)
+		}
+		_ = r.ReadBytes(length - 4)
+		n++
+		if !more {
+			break
+		}
+	}
+	return n, nil
+}
+
+// AdvanceDelta returns the advance width delta of the glyph at the current variations, which is given by the horizontal phantom points in the gvar table.
+func (glyf *glyfTable) AdvanceDelta(glyphID uint16) float64 {
+	if glyf.gvar == nil || glyf.coords == nil {
+		return 0.0
+	}
+	n, err := glyf.numPoints(glyphID)
+	if err != nil {
+		return 0.0
+	}
+	dxs, _, err := glyf.gvar.Deltas(glyphID, glyf.coords, n+4, nil, nil, nil)
+	if err != nil {
+		return 0.0
+	}
+	return dxs[n+1] - dxs[n]
+}
+
 func glyfCompositeLength(flags uint16) (length uint32, more bool) {
 	length = 4 + 2
 	if flags&0x0001 != 0 { // ARG_1_AND_2_ARE_WORDS
@@ -234,14 +302,38 @@ func (glyf *glyfTable) Contour(glyphID uint16, level int) (*glyfContour, error)
 			}
 			contour.YCoordinates[i] = y
 		}
+
+		if glyf.gvar != nil && glyf.coords != nil {
+			dxs, dys, err := glyf.gvar.Deltas(glyphID, glyf.coords, numPoints+4, contour.XCoordinates, contour.YCoordinates, contour.EndPoints)
+			if err != nil {
+				return nil, err
+			}
+			for i := 0; i < numPoints; i++ {
+				contour.XCoordinates[i] += int16(math.Round(dxs[i]))
+				contour.YCoordinates[i] += int16(math.Round(dys[i]))
+			}
+			contour.updateBounds()
+		}
 	} else {
 		if 7 < level {
 			return nil, fmt.Errorf("glyf: compound glyphs too deeply nested")
 		}
 
 		// composite glyph
+		var dxs, dys []float64
+		if glyf.gvar != nil && glyf.coords != nil {
+			// the deltas of composite glyphs move the component offsets
+			n, err := glyf.numPoints(glyphID)
+			if err != nil {
+				return nil, err
+			}
+			if dxs, dys, err = glyf.gvar.Deltas(glyphID, glyf.coords, n+4, nil, nil, nil); err != nil {
+				return nil, err
+			}
+		}
+
 		hasInstructions := false
-		for {
+		for component := 0; ; component++ {
 			if r.Len() < 4 {
 				return nil, fmt.Errorf("glyf: bad table for glyphID %v", glyphID)
 			}
@@ -265,6 +357,10 @@ func (glyf *glyfTable) Contour(glyphID uint16, level int) (*glyfContour, error)
 				dx = int16(r.ReadInt8())
 				dy = int16(r.ReadInt8())
 			}
+			if dxs != nil {
+				dx += int16(math.Round(dxs[component]))
+				dy += int16(math.Round(dys[component]))
+			}
 			var txx, txy, tyx, tyy int16
 			if flags&0x0008 != 0 { // WE_HAVE_A_SCALE
 				if r.Len() < 2 {
@@ -327,18 +423,62 @@ func (glyf *glyfTable) Contour(glyphID uint16, level int) (*glyfContour, error)
 			}
 			contour.Instructions = r.ReadBytes(uint32(instructionLength))
 		}
+		if dxs != nil {
+			contour.updateBounds()
+		}
 	}
 	return contour, nil
 }
 
+// updateBounds sets the bounding box to that of the points, which is needed after applying variations.
+func (contour *glyfContour) updateBounds() {
+	if len(contour.XCoordinates) == 0 {
+		return
+	}
+	contour.XMin, contour.XMax = contour.XCoordinates[0], contour.XCoordinates[0]
+	contour.YMin, contour.YMax = contour.YCoordinates[0], contour.YCoordinates[0]
+	for i := 1; i < len(contour.XCoordinates); i++ {
+		if contour.XCoordinates[i] < contour.XMin {
+			contour.XMin = contour.XCoordinates[i]
+		} else if contour.XMax < contour.XCoordinates[i] {
+			contour.XMax = contour.XCoordinates[i]
+		}
+		if contour.YCoordinates[i] < contour.YMin {
+			contour.YMin = contour.YCoordinates[i]
+		} else if contour.YMax < contour.YCoordinates[i] {
+			contour.YMax = contour.YCoordinates[i]
+		}
+	}
+}
+
+// ToPath draws the glyph's contour as a path to the pather. With FontHinting and a non-zero ppem, the glyph is grid-fitted by its TrueType instructions, falling back to the unhinted outline when the instructions fail.
 func (glyf *glyfTable) ToPath(p Pather, glyphID, ppem uint16, x, y, f float64, hinting Hinting) error {
+	if hinting == FontHinting && ppem != 0 && glyf.hinter != nil {
+		if outline, err := glyf.hinter.Hint(glyphID, ppem); err == nil {
+			glyfPath(p, outline.EndPoints, outline.OnCurve, outline.XCoordinates, outline.YCoordinates, x, y, f)
+			return nil
+		}
+	}
+
 	contour, err := glyf.Contour(glyphID, 0)
 	if err != nil {
 		return err
 	}
 
+	xs := make([]float64, len(contour.XCoordinates))
+	ys := make([]float64, len(contour.YCoordinates))
+	for i := range contour.XCoordinates {
+		xs[i] = float64(contour.XCoordinates[i])
+		ys[i] = float64(contour.YCoordinates[i])
+	}
+	glyfPath(p, contour.EndPoints, contour.OnCurve, xs, ys, x, y, f)
+	return nil
+}
+
+// glyfPath draws the quadratic contours given by their end points, on-curve flags, and coordinates in font units.
+func glyfPath(p Pather, endPoints []uint16, onCurve []bool, xs, ys []float64, x, y, f float64) {
 	var i uint16
-	for _, endPoint := range contour.EndPoints {
+	for _, endPoint := range endPoints {
 		j := i
 		first := true
 		firstOff := false
@@ -346,9 +486,9 @@ func (glyf *glyfTable) ToPath(p Pather, glyphID, ppem uint16, x, y, f float64, h
 		startX, startY := 0.0, 0.0
 		for ; i <= endPoint; i++ {
 			if first {
-				if contour.OnCurve[i] {
-					startX = float64(contour.XCoordinates[i])
-					startY = float64(contour.YCoordinates[i])
+				if onCurve[i] {
+					startX = xs[i]
+					startY = ys[i]
 					p.MoveTo(x+f*startX, y+f*startY)
 					first = false
 				} else if !prevOff {
@@ -357,43 +497,42 @@ func (glyf *glyfTable) ToPath(p Pather, glyphID, ppem uint16, x, y, f float64, h
 					prevOff = true
 				} else {
 					// first and second point are off
-					startX = float64(contour.XCoordinates[i-1]+contour.XCoordinates[i]) / 2.0
-					startY = float64(contour.YCoordinates[i-1]+contour.YCoordinates[i]) / 2.0
+					startX = (xs[i-1] + xs[i]) / 2.0
+					startY = (ys[i-1] + ys[i]) / 2.0
 					p.MoveTo(x+f*startX, y+f*startY)
 					first = false
 				}
 			} else if !prevOff {
-				if contour.OnCurve[i] {
-					p.LineTo(x+f*float64(contour.XCoordinates[i]), y+f*float64(contour.YCoordinates[i]))
+				if onCurve[i] {
+					p.LineTo(x+f*xs[i], y+f*ys[i])
 				} else {
 					prevOff = true
 				}
 			} else {
-				if contour.OnCurve[i] {
-					p.QuadTo(x+f*float64(contour.XCoordinates[i-1]), y+f*float64(contour.YCoordinates[i-1]), x+f*float64(contour.XCoordinates[i]), y+f*float64(contour.YCoordinates[i]))
+				if onCurve[i] {
+					p.QuadTo(x+f*xs[i-1], y+f*ys[i-1], x+f*xs[i], y+f*ys[i])
 					prevOff = false
 				} else {
-					midX := float64(contour.XCoordinates[i-1]+contour.XCoordinates[i]) / 2.0
-					midY := float64(contour.YCoordinates[i-1]+contour.YCoordinates[i]) / 2.0
-					p.QuadTo(x+f*float64(contour.XCoordinates[i-1]), y+f*float64(contour.YCoordinates[i-1]), x+f*midX, y+f*midY)
+					midX := (xs[i-1] + xs[i]) / 2.0
+					midY := (ys[i-1] + ys[i]) / 2.0
+					p.QuadTo(x+f*xs[i-1], y+f*ys[i-1], x+f*midX, y+f*midY)
 				}
 			}
 		}
 		if firstOff {
 			if prevOff {
-				midX := float64(contour.XCoordinates[i-1]+contour.XCoordinates[j]) / 2.0
-				midY := float64(contour.YCoordinates[i-1]+contour.YCoordinates[j]) / 2.0
-				p.QuadTo(x+f*float64(contour.XCoordinates[i-1]), y+f*float64(contour.YCoordinates[i-1]), x+f*midX, y+f*midY)
-				p.QuadTo(x+f*float64(contour.XCoordinates[j]), y+f*float64(contour.YCoordinates[j]), x+f*startX, y+f*startY)
+				midX := (xs[i-1] + xs[j]) / 2.0
+				midY := (ys[i-1] + ys[j]) / 2.0
+				p.QuadTo(x+f*xs[i-1], y+f*ys[i-1], x+f*midX, y+f*midY)
+				p.QuadTo(x+f*xs[j], y+f*ys[j], x+f*startX, y+f*startY)
 			} else {
-				p.QuadTo(x+f*float64(contour.XCoordinates[j]), y+f*float64(contour.YCoordinates[j]), x+f*startX, y+f*startY)
+				p.QuadTo(x+f*xs[j], y+f*ys[j], x+f*startX, y+f*startY)
 			}
 		} else if prevOff {
-			p.QuadTo(x+f*float64(contour.XCoordinates[i-1]), y+f*float64(contour.YCoordinates[i-1]), x+f*startX, y+f*startY)
+			p.QuadTo(x+f*xs[i-1], y+f*ys[i-1], x+f*startX, y+f*startY)
 		}
 		p.Close()
 	}
-	return nil
 }
 
 func (sfnt *SFNT) parseGlyf() error {
@@ -0,0 +1,992 @@
+package font
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// VariationAxis is a design axis of a variable font from the fvar table, such as the weight axis 'wght'. Values are in design units, such as 100 to 900 for the weight axis.
+type VariationAxis struct {
+	Tag     string
+	Min     float64
+	Default float64
+	Max     float64
+	Hidden  bool
+	NameID  NameID
+}
+
+// NamedInstance is a predefined location in the design space of a variable font from the fvar table, such as "Bold". The coordinates are in design units and in the order of the variation axes.
+type NamedInstance struct {
+	SubfamilyNameID  NameID
+	PostScriptNameID NameID // 0xFFFF if not set
+	Coordinates      []float64
+}
+
+// IsVariable returns true if the font has variation axes.
+func (sfnt *SFNT) IsVariable() bool {
+	return sfnt.Fvar != nil && 0 < len(sfnt.Fvar.Axes)
+}
+
+// VariationAxes returns the variation axes of a variable font.
+func (sfnt *SFNT) VariationAxes() []VariationAxis {
+	if sfnt.Fvar == nil {
+		return nil
+	}
+	return sfnt.Fvar.Axes
+}
+
+// NamedInstances returns the named instances of a variable font.
+func (sfnt *SFNT) NamedInstances() []NamedInstance {
+	if sfnt.Fvar == nil {
+		return nil
+	}
+	return sfnt.Fvar.Instances
+}
+
+// Variations returns the current location in the design space by axis tag. It returns the default values when no variations have been set.
+func (sfnt *SFNT) Variations() map[string]float64 {
+	if sfnt.Fvar == nil {
+		return nil
+	}
+	variations := make(map[string]float64, len(sfnt.Fvar.Axes))
+	for i, axis := range sfnt.Fvar.Axes {
+		if sfnt.design != nil {
+			variations[axis.Tag] = sfnt.design[i]
+		} else {
+			variations[axis.Tag] = axis.Default
+		}
+	}
+	return variations
+}
+
+// SetVariations sets the location in the design space by axis tag, which applies to glyph outlines, advances, and font metrics. Axes that are not given use their default value, values are clamped to the axis range, and unknown axes are ignored. Passing nil resets the font to its default instance.
+func (sfnt *SFNT) SetVariations(variations map[string]float64) {
+	if sfnt.Fvar == nil {
+		return
+	}
+
+	var design, coords []float64
+	if variations != nil {
+		design = make([]float64, len(sfnt.Fvar.Axes))
+		coords = make([]float64, len(sfnt.Fvar.Axes))
+		isDefault := true
+		for i, axis := range sfnt.Fvar.Axes {
+			v, ok := variations[axis.Tag]
+			if !ok {
+				v = axis.Default
+			}
+			design[i] = math.Max(axis.Min, math.Min(v, axis.Max))
+			coords[i] = axis.normalize(design[i])
+			if sfnt.Avar != nil && i < len(sfnt.Avar.Segments) {
+				coords[i] = sfnt.Avar.Segments[i].Map(coords[i])
+			}
+			coords[i] = math.Round(coords[i]*(1<<14)) / (1 << 14) // F2Dot14 precision
+			if coords[i] != 0.0 {
+				isDefault = false
+			}
+		}
+		if isDefault {
+			coords = nil
+		}
+	}
+	sfnt.design = design
+	sfnt.coords = coords
+
+	if sfnt.Glyf != nil {
+		sfnt.Glyf.gvar = sfnt.Gvar
+		sfnt.Glyf.coords = coords
+	}
+	if sfnt.CFF != nil {
+		sfnt.CFF.coords = coords
+	}
+	if sfnt.Mvar != nil {
+		sfnt.Mvar.apply(sfnt, coords)
+	}
+}
+
+// SetNamedInstance sets the location in the design space to that of the named instance with the given index.
+func (sfnt *SFNT) SetNamedInstance(index int) error {
+	if sfnt.Fvar == nil || index < 0 || len(sfnt.Fvar.Instances) <= index {
+		return fmt.Errorf("fvar: bad named instance %d", index)
+	}
+	variations := map[string]float64{}
+	for i, axis := range sfnt.Fvar.Axes {
+		variations[axis.Tag] = sfnt.Fvar.Instances[index].Coordinates[i]
+	}
+	sfnt.SetVariations(variations)
+	return nil
+}
+
+// VariationCoordinates returns the current location in the design space as normalized coordinates in [-1,1] in the order of the variation axes, after applying the avar table. It returns nil for the default instance.
+func (sfnt *SFNT) VariationCoordinates() []float64 {
+	return sfnt.coords
+}
+
+// ParseVariations parses a comma-separated list of axis values in the format used by HarfBuzz and CSS, such as "wght=700,wdth=75". The tag and value may be separated by an equal sign or whitespace.
+func ParseVariations(s string) (map[string]float64, error) {
+	variations := map[string]float64{}
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		i := strings.IndexAny(item, "= \t")
+		if i == -1 {
+			return nil, fmt.Errorf("bad variation '%s'", item)
+		}
+		tag := strings.Trim(item[:i], `"'`)
+		value := strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(item[i:]), "="))
+		if len(tag) == 0 || 4 < len(tag) {
+			return nil, fmt.Errorf("bad variation tag '%s'", tag)
+		}
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad variation value '%s'", value)
+		}
+		variations[tag+strings.Repeat(" ", 4-len(tag))] = v
+	}
+	return variations, nil
+}
+
+// FormatVariations formats axis values as a comma-separated list sorted by axis tag, which is parsed by ParseVariations.
+func FormatVariations(variations map[string]float64) string {
+	tags := make([]string, 0, len(variations))
+	for tag := range variations {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	items := make([]string, len(tags))
+	for i, tag := range tags {
+		items[i] = strings.TrimSpace(tag) + "=" + strconv.FormatFloat(variations[tag], 'g', -1, 64)
+	}
+	return strings.Join(items, ",")
+}
+
+////////////////////////////////////////////////////////////////
+
+type fvarTable struct {
+	Axes      []VariationAxis
+	Instances []NamedInstance
+}
+
+// normalize maps a design value to [-1,1] with the default value at zero.
+func (axis VariationAxis) normalize(v float64) float64 {
+	if v < axis.Default && axis.Min < axis.Default {
+		return (v - axis.Default) / (axis.Default - axis.Min)
+	} else if axis.Default < v && axis.Default < axis.Max {
+		return (v - axis.Default) / (axis.Max - axis.Default)
+	}
+	return 0.0
+}
+
+func (sfnt *SFNT) parseFvar() error {
+	b, ok := sfnt.Tables["fvar"]
+	if !ok {
+		return fmt.Errorf("fvar: missing table")
+	} else if len(b) < 16 {
+		return fmt.Errorf("fvar: bad table")
+	}
+
+	r := NewBinaryReader(b)
+	majorVersion := r.ReadUint16()
+	minorVersion := r.ReadUint16()
+	if majorVersion != 1 || minorVersion != 0 {
+		return fmt.Errorf("fvar: bad version")
+	}
+	axesArrayOffset := uint32(r.ReadUint16())
+	_ = r.ReadUint16() // reserved
+	axisCount := uint32(r.ReadUint16())
+	axisSize := uint32(r.ReadUint16())
+	instanceCount := uint32(r.ReadUint16())
+	instanceSize := uint32(r.ReadUint16())
+	if axisSize != 20 || instanceSize != 4*axisCount+4 && instanceSize != 4*axisCount+6 {
+		return fmt.Errorf("fvar: bad axis or instance size")
+	} else if uint32(len(b)) < axesArrayOffset || uint32(len(b))-axesArrayOffset < axisCount*axisSize+instanceCount*instanceSize {
+		return fmt.Errorf("fvar: bad table")
+	}
+
+	sfnt.Fvar = &fvarTable{
+		Axes:      make([]VariationAxis, axisCount),
+		Instances: make([]NamedInstance, instanceCount),
+	}
+	r.Seek(axesArrayOffset)
+	for i := range sfnt.Fvar.Axes {
+		axis := &sfnt.Fvar.Axes[i]
+		axis.Tag = r.ReadString(4)
+		axis.Min = readFixed(r)
+		axis.Default = readFixed(r)
+		axis.Max = readFixed(r)
+		axis.Hidden = r.ReadUint16()&0x0001 != 0 // HIDDEN_AXIS
+		axis.NameID = NameID(r.ReadUint16())
+		if axis.Default < axis.Min || axis.Max < axis.Default {
+			return fmt.Errorf("fvar: bad axis range for '%s'", axis.Tag)
+		}
+	}
+	for i := range sfnt.Fvar.Instances {
+		instance := &sfnt.Fvar.Instances[i]
+		instance.SubfamilyNameID = NameID(r.ReadUint16())
+		_ = r.ReadUint16() // flags
+		instance.Coordinates = make([]float64, axisCount)
+		for j := range instance.Coordinates {
+			instance.Coordinates[j] = readFixed(r)
+		}
+		instance.PostScriptNameID = 0xFFFF
+		if instanceSize == 4*axisCount+6 {
+			instance.PostScriptNameID = NameID(r.ReadUint16())
+		}
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////
+
+type avarSegmentMap [][2]float64
+
+// Map maps a normalized coordinate piecewise linearly using the segment map.
+func (segments avarSegmentMap) Map(v float64) float64 {
+	if len(segments) == 0 {
+		return v
+	}
+	for i, segment := range segments {
+		if v <= segment[0] {
+			if i == 0 {
+				return segment[1] + v - segment[0]
+			}
+			prev := segments[i-1]
+			return prev[1] + (segment[1]-prev[1])*(v-prev[0])/(segment[0]-prev[0])
+		}
+	}
+	last := segments[len(segments)-1]
+	return last[1] + v - last[0]
+}
+
+type avarTable struct {
+	Segments []avarSegmentMap
+}
+
+func (sfnt *SFNT) parseAvar() error {
+	b, ok := sfnt.Tables["avar"]
+	if !ok {
+		return fmt.Errorf("avar: missing table")
+	} else if len(b) < 8 {
+		return fmt.Errorf("avar: bad table")
+	}
+
+	r := NewBinaryReader(b)
+	majorVersion := r.ReadUint16()
+	_ = r.ReadUint16() // minorVersion
+	if majorVersion != 1 {
+		return fmt.Errorf("avar: bad version")
+	}
+	_ = r.ReadUint16() // reserved
+	axisCount := r.ReadUint16()
+
+	sfnt.Avar = &avarTable{
+		Segments: make([]avarSegmentMap, axisCount),
+	}
+	for i := range sfnt.Avar.Segments {
+		positionMapCount := r.ReadUint16()
+		if r.Len() < 4*uint32(positionMapCount) {
+			return fmt.Errorf("avar: bad table")
+		}
+		segments := make(avarSegmentMap, positionMapCount)
+		for j := range segments {
+			segments[j][0] = readF2Dot14(r)
+			segments[j][1] = readF2Dot14(r)
+			if 0 < j && segments[j][0] < segments[j-1][0] {
+				return fmt.Errorf("avar: unsorted segment map")
+			}
+		}
+		sfnt.Avar.Segments[i] = segments
+	}
+	if r.EOF() {
+		return fmt.Errorf("avar: bad table")
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////
+
+// tupleScalar returns the scalar of a region for the given normalized coordinates, where each axis has a start, peak, and end value.
+func tupleScalar(coords []float64, start, peak, end []float64) float64 {
+	scalar := 1.0
+	for i := range peak {
+		if peak[i] == 0.0 || end[i] < start[i] || peak[i] < start[i] || end[i] < peak[i] || start[i] < 0.0 && 0.0 < end[i] {
+			continue
+		}
+		var v float64
+		if i < len(coords) {
+			v = coords[i]
+		}
+		if v == peak[i] {
+			continue
+		} else if v <= start[i] || end[i] <= v {
+			return 0.0
+		} else if v < peak[i] {
+			scalar *= (v - start[i]) / (peak[i] - start[i])
+		} else {
+			scalar *= (end[i] - v) / (end[i] - peak[i])
+		}
+	}
+	return scalar
+}
+
+type itemVariationData struct {
+	regionIndices []uint16
+	deltas        [][]int32
+}
+
+type itemVariationStore struct {
+	regions [][3][]float64 // start, peak, and end per axis for each region
+	data    []itemVariationData
+}
+
+func parseItemVariationStore(b []byte) (*itemVariationStore, error) {
+	r := NewBinaryReader(b)
+	format := r.ReadUint16()
+	if format != 1 {
+		return nil, fmt.Errorf("bad item variation store format")
+	}
+	regionListOffset := r.ReadUint32()
+	dataCount := r.ReadUint16()
+	if r.Len() < 4*uint32(dataCount) {
+		return nil, fmt.Errorf("bad item variation store")
+	}
+	dataOffsets := make([]uint32, dataCount)
+	for i := range dataOffsets {
+		dataOffsets[i] = r.ReadUint32()
+	}
+
+	store := &itemVariationStore{}
+	r.Seek(regionListOffset)
+	axisCount := r.ReadUint16()
+	regionCount := r.ReadUint16()
+	if r.EOF() || r.Len() < 6*uint32(axisCount)*uint32(regionCount) {
+		return nil, fmt.Errorf("bad variation region list")
+	}
+	store.regions = make([][3][]float64, regionCount)
+	for i := range store.regions {
+		for k := 0; k < 3; k++ {
+			store.regions[i][k] = make([]float64, axisCount)
+		}
+		for j := 0; j < int(axisCount); j++ {
+			store.regions[i][0][j] = readF2Dot14(r)
+			store.regions[i][1][j] = readF2Dot14(r)
+			store.regions[i][2][j] = readF2Dot14(r)
+		}
+	}
+
+	store.data = make([]itemVariationData, dataCount)
+	for i, offset := range dataOffsets {
+		r.Seek(offset)
+		itemCount := r.ReadUint16()
+		wordDeltaCount := r.ReadUint16()
+		regionIndexCount := r.ReadUint16()
+		longWords := wordDeltaCount&0x8000 != 0
+		wordCount := wordDeltaCount & 0x7FFF
+		if regionIndexCount < wordCount || r.Len() < 2*uint32(regionIndexCount) {
+			return nil, fmt.Errorf("bad item variation data")
+		}
+
+		data := itemVariationData{
+			regionIndices: make([]uint16, regionIndexCount),
+			deltas:        make([][]int32, itemCount),
+		}
+		for j := range data.regionIndices {
+			data.regionIndices[j] = r.ReadUint16()
+			if regionCount <= data.regionIndices[j] {
+				return nil, fmt.Errorf("bad region index")
+			}
+		}
+		rowSize := uint32(wordCount)*2 + uint32(regionIndexCount-wordCount)
+		if longWords {
+			rowSize *= 2
+		}
+		if r.Len() < uint32(itemCount)*rowSize {
+			return nil, fmt.Errorf("bad item variation data")
+		}
+		for j := range data.deltas {
+			deltas := make([]int32, regionIndexCount)
+			for k := range deltas {
+				if k < int(wordCount) {
+					if longWords {
+						deltas[k] = r.ReadInt32()
+					} else {
+						deltas[k] = int32(r.ReadInt16())
+					}
+				} else if longWords {
+					deltas[k] = int32(r.ReadInt16())
+				} else {
+					deltas[k] = int32(r.ReadInt8())
+				}
+			}
+			data.deltas[j] = deltas
+		}
+		store.data[i] = data
+	}
+	return store, nil
+}
+
+// Scalars returns the scalars of the regions that are referenced by the item variation data with the given index.
+func (store *itemVariationStore) Scalars(outer uint16, coords []float64) []float64 {
+	if len(store.data) <= int(outer) {
+		return nil
+	}
+	scalars := make([]float64, len(store.data[outer].regionIndices))
+	if coords != nil {
+		for i, index := range store.data[outer].regionIndices {
+			region := store.regions[index]
+			scalars[i] = tupleScalar(coords, region[0], region[1], region[2])
+		}
+	}
+	return scalars
+}
+
+// Delta returns the interpolated delta for the given delta-set indices.
+func (store *itemVariationStore) Delta(outer, inner uint16, coords []float64) float64 {
+	if coords == nil || len(store.data) <= int(outer) || len(store.data[outer].deltas) <= int(inner) {
+		return 0.0
+	}
+	delta := 0.0
+	data := store.data[outer]
+	for i, index := range data.regionIndices {
+		if d := data.deltas[inner][i]; d != 0 {
+			region := store.regions[index]
+			delta += float64(d) * tupleScalar(coords, region[0], region[1], region[2])
+		}
+	}
+	return delta
+}
+
+type deltaSetIndexMap struct {
+	outer, inner []uint16
+}
+
+// Get returns the outer and inner delta-set indices for the given index, the last mapping is used for indices beyond the map.
+func (m *deltaSetIndexMap) Get(i uint32) (uint16, uint16) {
+	if len(m.outer) == 0 {
+		return 0, 0
+	} else if uint32(len(m.outer)) <= i {
+		i = uint32(len(m.outer)) - 1
+	}
+	return m.outer[i], m.inner[i]
+}
+
+func parseDeltaSetIndexMap(b []byte) (*deltaSetIndexMap, error) {
+	r := NewBinaryReader(b)
+	format := r.ReadUint8()
+	entryFormat := r.ReadUint8()
+	var mapCount uint32
+	if format == 0 {
+		mapCount = uint32(r.ReadUint16())
+	} else if format == 1 {
+		mapCount = r.ReadUint32()
+	} else {
+		return nil, fmt.Errorf("bad delta-set index map format")
+	}
+	entrySize := uint32((entryFormat&0x30)>>4) + 1
+	innerBits := uint32(entryFormat&0x0F) + 1
+	if r.EOF() || r.Len()/entrySize < mapCount {
+		return nil, fmt.Errorf("bad delta-set index map")
+	}
+
+	m := &deltaSetIndexMap{
+		outer: make([]uint16, mapCount),
+		inner: make([]uint16, mapCount),
+	}
+	for i := uint32(0); i < mapCount; i++ {
+		var entry uint32
+		for j := uint32(0); j < entrySize; j++ {
+			entry = entry<<8 | uint32(r.ReadUint8())
+		}
+		m.outer[i] = uint16(entry >> innerBits)
+		m.inner[i] = uint16(entry & (1<<innerBits - 1))
+	}
+	return m, nil
+}
+
+////////////////////////////////////////////////////////////////
+
+type hvarTable struct {
+	store      *itemVariationStore
+	advanceMap *deltaSetIndexMap
+}
+
+// Advance returns the advance width delta of the glyph.
+func (hvar *hvarTable) Advance(glyphID uint16, coords []float64) float64 {
+	outer, inner := uint16(0), glyphID
+	if hvar.advanceMap != nil {
+		outer, inner = hvar.advanceMap.Get(uint32(glyphID))
+	}
+	return hvar.store.Delta(outer, inner, coords)
+}
+
+func (sfnt *SFNT) parseHVAR() error {
+	b, ok := sfnt.Tables["HVAR"]
+	if !ok {
+		return fmt.Errorf("HVAR: missing table")
+	} else if len(b) < 20 {
+		return fmt.Errorf("HVAR: bad table")
+	}
+
+	r := NewBinaryReader(b)
+	majorVersion := r.ReadUint16()
+	_ = r.ReadUint16() // minorVersion
+	if majorVersion != 1 {
+		return fmt.Errorf("HVAR: bad version")
+	}
+	storeOffset := r.ReadUint32()
+	advanceMapOffset := r.ReadUint32()
+	_ = r.ReadUint32() // lsbMappingOffset
+	_ = r.ReadUint32() // rsbMappingOffset
+	if uint32(len(b)) <= storeOffset || uint32(len(b)) <= advanceMapOffset {
+		return fmt.Errorf("HVAR: bad offset")
+	}
+
+	var err error
+	sfnt.Hvar = &hvarTable{}
+	if sfnt.Hvar.store, err = parseItemVariationStore(b[storeOffset:]); err != nil {
+		return fmt.Errorf("HVAR: %w", err)
+	}
+	if advanceMapOffset != 0 {
+		if sfnt.Hvar.advanceMap, err = parseDeltaSetIndexMap(b[advanceMapOffset:]); err != nil {
+			return fmt.Errorf("HVAR: %w", err)
+		}
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////
+
+type mvarRecord struct {
+	Tag          string
+	Outer, Inner uint16
+}
+
+type mvarTable struct {
+	store    *itemVariationStore
+	records  []mvarRecord
+	defaults []int // values of the default instance, set on first use
+}
+
+// metric returns the value of the metric in the OS/2, hhea, vhea, or post table that the MVAR value tag refers to, and a function to set it. It returns nil if the tag is not supported or its table is missing.
+func (sfnt *SFNT) metric(tag string) (int, func(int)) {
+	var v *int16
+	switch tag {
+	case "hcla", "hcld":
+		if sfnt.OS2 == nil {
+			return 0, nil
+		}
+		u := &sfnt.OS2.UsWinAscent
+		if tag == "hcld" {
+			u = &sfnt.OS2.UsWinDescent
+		}
+		return int(*u), func(i int) { *u = uint16(math.Max(0.0, math.Min(float64(i), math.MaxUint16))) }
+	case "hasc", "hdsc", "hlgp", "xhgt", "cpht", "sbxs", "sbys", "sbxo", "sbyo", "spxs", "spys", "spxo", "spyo", "strs", "stro":
+		if sfnt.OS2 == nil {
+			return 0, nil
+		}
+		v = map[string]*int16{
+			"hasc": &sfnt.OS2.STypoAscender,
+			"hdsc": &sfnt.OS2.STypoDescender,
+			"hlgp": &sfnt.OS2.STypoLineGap,
+			"xhgt": &sfnt.OS2.SxHeight,
+			"cpht": &sfnt.OS2.SCapHeight,
+			"sbxs": &sfnt.OS2.YSubscriptXSize,
+			"sbys": &sfnt.OS2.YSubscriptYSize,
+			"sbxo": &sfnt.OS2.YSubscriptXOffset,
+			"sbyo": &sfnt.OS2.YSubscriptYOffset,
+			"spxs": &sfnt.OS2.YSuperscriptXSize,
+			"spys": &sfnt.OS2.YSuperscriptYSize,
+			"spxo": &sfnt.OS2.YSuperscriptXOffset,
+			"spyo": &sfnt.OS2.YSuperscriptYOffset,
+			"strs": &sfnt.OS2.YStrikeoutSize,
+			"stro": &sfnt.OS2.YStrikeoutPosition,
+		}[tag]
+	case "hcrs", "hcrn", "hcof":
+		if sfnt.Hhea == nil {
+			return 0, nil
+		}
+		v = map[string]*int16{
+			"hcrs": &sfnt.Hhea.CaretSlopeRise,
+			"hcrn": &sfnt.Hhea.CaretSlopeRun,
+			"hcof": &sfnt.Hhea.CaretOffset,
+		}[tag]
+	case "vasc", "vdsc", "vlgp", "vcrs", "vcrn", "vcof":
+		if sfnt.Vhea == nil {
+			return 0, nil
+		}
+		v = map[string]*int16{
+			"vasc": &sfnt.Vhea.Ascender,
+			"vdsc": &sfnt.Vhea.Descender,
+			"vlgp": &sfnt.Vhea.LineGap,
+			"vcrs": &sfnt.Vhea.CaretSlopeRise,
+			"vcrn": &sfnt.Vhea.CaretSlopeRun,
+			"vcof": &sfnt.Vhea.CaretOffset,
+		}[tag]
+	case "unds", "undo":
+		if sfnt.Post == nil {
+			return 0, nil
+		}
+		v = &sfnt.Post.UnderlineThickness
+		if tag == "undo" {
+			v = &sfnt.Post.UnderlinePosition
+		}
+	default:
+		return 0, nil
+	}
+	return int(*v), func(i int) { *v = int16(math.Max(math.MinInt16, math.Min(float64(i), math.MaxInt16))) }
+}
+
+// apply sets the metrics of the OS/2, hhea, vhea, and post tables to the values at the given coordinates.
+func (mvar *mvarTable) apply(sfnt *SFNT, coords []float64) {
+	if mvar.defaults == nil {
+		mvar.defaults = make([]int, len(mvar.records))
+		for i, record := range mvar.records {
+			mvar.defaults[i], _ = sfnt.metric(record.Tag)
+		}
+	}
+	for i, record := range mvar.records {
+		if _, set := sfnt.metric(record.Tag); set != nil {
+			delta := mvar.store.Delta(record.Outer, record.Inner, coords)
+			set(mvar.defaults[i] + int(math.Round(delta)))
+		}
+	}
+}
+
+func (sfnt *SFNT) parseMVAR() error {
+	b, ok := sfnt.Tables["MVAR"]
+	if !ok {
+		return fmt.Errorf("MVAR: missing table")
+	} else if len(b) < 12 {
+		return fmt.Errorf("MVAR: bad table")
+	}
+
+	r := NewBinaryReader(b)
+	majorVersion := r.ReadUint16()
+	_ = r.ReadUint16() // minorVersion
+	if majorVersion != 1 {
+		return fmt.Errorf("MVAR: bad version")
+	}
+	_ = r.ReadUint16() // reserved
+	valueRecordSize := uint32(r.ReadUint16())
+	valueRecordCount := uint32(r.ReadUint16())
+	storeOffset := uint32(r.ReadUint16())
+	if valueRecordCount == 0 {
+		return nil
+	} else if valueRecordSize < 8 || r.Len() < valueRecordCount*valueRecordSize || uint32(len(b)) <= storeOffset {
+		return fmt.Errorf("MVAR: bad table")
+	}
+
+	store, err := parseItemVariationStore(b[storeOffset:])
+	if err != nil {
+		return fmt.Errorf("MVAR: %w", err)
+	}
+	sfnt.Mvar = &mvarTable{
+		store:   store,
+		records: make([]mvarRecord, valueRecordCount),
+	}
+	for i := range sfnt.Mvar.records {
+		sfnt.Mvar.records[i].Tag = r.ReadString(4)
+		sfnt.Mvar.records[i].Outer = r.ReadUint16()
+		sfnt.Mvar.records[i].Inner = r.ReadUint16()
+		_ = r.ReadBytes(valueRecordSize - 8)
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////
+
+type gvarTable struct {
+	axisCount    int
+	sharedTuples [][]float64
+	data         [][]byte // glyph variation data for each glyph
+}
+
+func (sfnt *SFNT) parseGvar() error {
+	b, ok := sfnt.Tables["gvar"]
+	if !ok {
+		return fmt.Errorf("gvar: missing table")
+	} else if len(b) < 20 {
+		return fmt.Errorf("gvar: bad table")
+	}
+
+	r := NewBinaryReader(b)
+	majorVersion := r.ReadUint16()
+	_ = r.ReadUint16() // minorVersion
+	if majorVersion != 1 {
+		return fmt.Errorf("gvar: bad version")
+	}
+	axisCount := uint32(r.ReadUint16())
+	sharedTupleCount := uint32(r.ReadUint16())
+	sharedTuplesOffset := r.ReadUint32()
+	glyphCount := uint32(r.ReadUint16())
+	flags := r.ReadUint16()
+	dataArrayOffset := r.ReadUint32()
+	if glyphCount != uint32(sfnt.Maxp.NumGlyphs) {
+		return fmt.Errorf("gvar: bad glyph count")
+	}
+
+	offsets := make([]uint32, glyphCount+1)
+	for i := range offsets {
+		if flags&0x0001 != 0 {
+			offsets[i] = r.ReadUint32()
+		} else {
+			offsets[i] = 2 * uint32(r.ReadUint16())
+		}
+	}
+	if r.EOF() {
+		return fmt.Errorf("gvar: bad table")
+	}
+
+	sfnt.Gvar = &gvarTable{
+		axisCount:    int(axisCount),
+		sharedTuples: make([][]float64, sharedTupleCount),
+		data:         make([][]byte, glyphCount),
+	}
+	r.Seek(sharedTuplesOffset)
+	if r.EOF() || r.Len() < 2*axisCount*sharedTupleCount {
+		return fmt.Errorf("gvar: bad shared tuples")
+	}
+	for i := range sfnt.Gvar.sharedTuples {
+		tuple := make([]float64, axisCount)
+		for j := range tuple {
+			tuple[j] = readF2Dot14(r)
+		}
+		sfnt.Gvar.sharedTuples[i] = tuple
+	}
+	for i := range sfnt.Gvar.data {
+		start, end := dataArrayOffset+offsets[i], dataArrayOffset+offsets[i+1]
+		if end < start || uint32(len(b)) < end {
+			return fmt.Errorf("gvar: bad glyph variation data offset")
+		}
+		sfnt.Gvar.data[i] = b[start:end]
+	}
+	return nil
+}
+
+// parsePackedPoints returns the point numbers, or nil if all points are used.
+func parsePackedPoints(r *BinaryReader) ([]uint16, error) {
+	count := uint32(r.ReadUint8())
+	if count == 0 {
+		return nil, nil
+	} else if count&0x80 != 0 {
+		count = (count&0x7F)<<8 | uint32(r.ReadUint8())
+	}
+
+	points := make([]uint16, 0, count)
+	point := uint16(0)
+	for uint32(len(points)) < count {
+		control := r.ReadUint8()
+		n := int(control&0x7F) + 1
+		for i := 0; i < n; i++ {
+			if control&0x80 != 0 { // POINTS_ARE_WORDS
+				point += r.ReadUint16()
+			} else {
+				point += uint16(r.ReadUint8())
+			}
+			points = append(points, point)
+		}
+		if r.EOF() {
+			return nil, fmt.Errorf("bad packed point numbers")
+		}
+	}
+	return points, nil
+}
+
+// parsePackedDeltas returns n deltas.
+func parsePackedDeltas(r *BinaryReader, n int) ([]int32, error) {
+	deltas := make([]int32, 0, n)
+	for len(deltas) < n {
+		control := r.ReadUint8()
+		count := int(control&0x3F) + 1
+		for i := 0; i < count; i++ {
+			if control&0x80 != 0 { // DELTAS_ARE_ZERO
+				deltas = append(deltas, 0)
+			} else if control&0x40 != 0 { // DELTAS_ARE_WORDS
+				deltas = append(deltas, int32(r.ReadInt16()))
+			} else {
+				deltas = append(deltas, int32(r.ReadInt8()))
+			}
+		}
+		if r.EOF() {
+			return nil, fmt.Errorf("bad packed deltas")
+		}
+	}
+	return deltas[:n], nil
+}
+
+// Deltas returns the interpolated x and y deltas for the points of a glyph at the given normalized coordinates. The number of points includes the four phantom points. Deltas of points that are not referenced are inferred from the original coordinates of the points and the end points of the contours, which are nil for composite glyphs.
+func (gvar *gvarTable) Deltas(glyphID uint16, coords []float64, numPoints int, xs, ys []int16, endPoints []uint16) ([]float64, []float64, error) {
+	dxs, dys := make([]float64, numPoints), make([]float64, numPoints)
+	if coords == nil || len(gvar.data) <= int(glyphID) || len(gvar.data[glyphID]) == 0 {
+		return dxs, dys, nil
+	}
+
+	b := gvar.data[glyphID]
+	r := NewBinaryReader(b)
+	tupleVariationCount := r.ReadUint16()
+	dataOffset := uint32(r.ReadUint16())
+	if uint32(len(b)) < dataOffset {
+		return nil, nil, fmt.Errorf("gvar: bad data for glyphID %v", glyphID)
+	}
+
+	data := NewBinaryReader(b[dataOffset:])
+	var sharedPoints []uint16
+	if tupleVariationCount&0x8000 != 0 { // SHARED_POINT_NUMBERS
+		var err error
+		if sharedPoints, err = parsePackedPoints(data); err != nil {
+			return nil, nil, fmt.Errorf("gvar: %w for glyphID %v", err, glyphID)
+		}
+	}
+	offset := dataOffset + data.Pos()
+
+	axisCount := gvar.axisCount
+	peak, start, end := make([]float64, axisCount), make([]float64, axisCount), make([]float64, axisCount)
+	tdxs, tdys := make([]float64, numPoints), make([]float64, numPoints)
+	touched := make([]bool, numPoints)
+	for k := 0; k < int(tupleVariationCount&0x0FFF); k++ {
+		size := uint32(r.ReadUint16())
+		tupleIndex := r.ReadUint16()
+		if tupleIndex&0x8000 != 0 { // EMBEDDED_PEAK_TUPLE
+			for i := range peak {
+				peak[i] = readF2Dot14(r)
+			}
+		} else if int(tupleIndex&0x0FFF) < len(gvar.sharedTuples) {
+			copy(peak, gvar.sharedTuples[tupleIndex&0x0FFF])
+		} else {
+			return nil, nil, fmt.Errorf("gvar: bad shared tuple index for glyphID %v", glyphID)
+		}
+		if tupleIndex&0x4000 != 0 { // INTERMEDIATE_REGION
+			for i := range start {
+				start[i] = readF2Dot14(r)
+			}
+			for i := range end {
+				end[i] = readF2Dot14(r)
+			}
+		} else {
+			for i := range peak {
+				start[i] = math.Min(0.0, peak[i])
+				end[i] = math.Max(0.0, peak[i])
+			}
+		}
+		if r.EOF() || uint32(len(b)) < offset || uint32(len(b))-offset < size {
+			return nil, nil, fmt.Errorf("gvar: bad data for glyphID %v", glyphID)
+		}
+		tuple := NewBinaryReader(b[offset : offset+size])
+		offset += size
+
+		scalar := tupleScalar(coords, start, peak, end)
+		if scalar == 0.0 {
+			continue
+		}
+
+		points := sharedPoints
+		if tupleIndex&0x2000 != 0 { // PRIVATE_POINT_NUMBERS
+			var err error
+			if points, err = parsePackedPoints(tuple); err != nil {
+				return nil, nil, fmt.Errorf("gvar: %w for glyphID %v", err, glyphID)
+			}
+		}
+		n := len(points)
+		if points == nil {
+			n = numPoints
+		}
+		xDeltas, err := parsePackedDeltas(tuple, n)
+		if err != nil {
+			return nil, nil, fmt.Errorf("gvar: %w for glyphID %v", err, glyphID)
+		}
+		yDeltas, err := parsePackedDeltas(tuple, n)
+		if err != nil {
+			return nil, nil, fmt.Errorf("gvar: %w for glyphID %v", err, glyphID)
+		}
+
+		if points == nil {
+			for i := 0; i < numPoints; i++ {
+				dxs[i] += scalar * float64(xDeltas[i])
+				dys[i] += scalar * float64(yDeltas[i])
+			}
+			continue
+		}
+
+		for i := range touched {
+			tdxs[i], tdys[i], touched[i] = 0.0, 0.0, false
+		}
+		for i, point := range points {
+			if int(point) < numPoints {
+				tdxs[point] = float64(xDeltas[i])
+				tdys[point] = float64(yDeltas[i])
+				touched[point] = true
+			}
+		}
+		if endPoints != nil {
+			iupDeltas(tdxs, xs, touched, endPoints)
+			iupDeltas(tdys, ys, touched, endPoints)
+		}
+		for i := 0; i < numPoints; i++ {
+			dxs[i] += scalar * tdxs[i]
+			dys[i] += scalar * tdys[i]
+		}
+	}
+	return dxs, dys, nil
+}
+
+// iupDeltas infers the deltas of untouched points by interpolating between the deltas of the surrounding touched points in the same contour.
+func iupDeltas(deltas []float64, coords []int16, touched []bool, endPoints []uint16) {
+	start := 0
+	for _, endPoint := range endPoints {
+		end := int(endPoint)
+		if len(coords) <= end || len(deltas) <= end {
+			return
+		}
+
+		refs := []int{}
+		for i := start; i <= end; i++ {
+			if touched[i] {
+				refs = append(refs, i)
+			}
+		}
+		if len(refs) == 1 {
+			for i := start; i <= end; i++ {
+				deltas[i] = deltas[refs[0]]
+			}
+		} else if 1 < len(refs) {
+			for k, ref1 := range refs {
+				ref2 := refs[(k+1)%len(refs)]
+				c1, c2 := coords[ref1], coords[ref2]
+				d1, d2 := deltas[ref1], deltas[ref2]
+				if c2 < c1 {
+					c1, c2 = c2, c1
+					d1, d2 = d2, d1
+				}
+				for i := ref1 + 1; ; i++ {
+					if end < i {
+						i = start
+					}
+					if i == ref2 {
+						break
+					}
+					c := coords[i]
+					if c1 == c2 {
+						if d1 == d2 {
+							deltas[i] = d1
+						} else {
+							deltas[i] = 0.0
+						}
+					} else if c <= c1 {
+						deltas[i] = d1
+					} else if c2 <= c {
+						deltas[i] = d2
+					} else {
+						deltas[i] = d1 + (d2-d1)*float64(c-c1)/float64(c2-c1)
+					}
+				}
+			}
+		}
+		start = end + 1
+	}
+}
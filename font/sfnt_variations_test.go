@@ -0,0 +1,256 @@
+package font
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/tdewolff/test"
+)
+
+func writePackedDeltas(w *BinaryWriter, deltas []int16) {
+	for i := 0; i < len(deltas); i += 64 {
+		j := i + 64
+		if len(deltas) < j {
+			j = len(deltas)
+		}
+		w.WriteUint8(0x40 | uint8(j-i-1)) // DELTAS_ARE_WORDS
+		for _, delta := range deltas[i:j] {
+			w.WriteInt16(delta)
+		}
+	}
+}
+
+// gvarTestFont returns DejaVu Serif with a weight axis from 100 to 900, where at the maximum the first contour of l moves right and its advance increases, at the minimum all points of l move down, and at the maximum the first component of Á moves right.
+func gvarTestFont(t *testing.T) *SFNT {
+	b, err := ioutil.ReadFile("../resources/DejaVuSerif.ttf")
+	test.Error(t, err)
+	sfnt, err := ParseSFNT(b, 0)
+	test.Error(t, err)
+	l, A := sfnt.GlyphIndex('l'), sfnt.GlyphIndex('Á')
+	nl, err := sfnt.Glyf.numPoints(l)
+	test.Error(t, err)
+	nA, err := sfnt.Glyf.numPoints(A)
+	test.Error(t, err)
+	test.T(t, nA, 2) // composite of A and acute
+
+	w := NewBinaryWriter([]byte{})
+	w.WriteUint16(1)  // majorVersion
+	w.WriteUint16(0)  // minorVersion
+	w.WriteUint16(16) // axesArrayOffset
+	w.WriteUint16(2)  // reserved
+	w.WriteUint16(1)  // axisCount
+	w.WriteUint16(20) // axisSize
+	w.WriteUint16(1)  // instanceCount
+	w.WriteUint16(8)  // instanceSize
+	w.WriteString("wght")
+	w.WriteUint32(100 << 16)
+	w.WriteUint32(400 << 16)
+	w.WriteUint32(900 << 16)
+	w.WriteUint16(0)
+	w.WriteUint16(256)
+	w.WriteUint16(2) // subfamilyNameID
+	w.WriteUint16(0)
+	w.WriteUint32(650 << 16)
+	sfnt.Tables["fvar"] = w.Bytes()
+
+	// glyph variation data of l
+	wl := NewBinaryWriter([]byte{})
+	wl.WriteUint16(2)  // tupleVariationCount
+	wl.WriteUint16(16) // dataOffset
+	wl.WriteUint16(12) // variationDataSize
+	wl.WriteUint16(0x8000 | 0x2000)
+	wl.WriteInt16(1 << 14) // peak
+	ys := make([]int16, nl+4)
+	for i := range ys {
+		ys[i] = -5
+	}
+	wl.WriteUint16(uint16(1 + 2*(2*len(ys)+(len(ys)+63)/64)))
+	wl.WriteUint16(0x8000 | 0x2000)
+	wl.WriteInt16(-1 << 14) // peak
+	wl.WriteUint8(2)        // point count
+	wl.WriteUint8(0x81)     // POINTS_ARE_WORDS
+	wl.WriteUint16(0)
+	wl.WriteUint16(uint16(nl + 1)) // second phantom point
+	wl.WriteUint8(0x41)            // DELTAS_ARE_WORDS
+	wl.WriteInt16(10)
+	wl.WriteInt16(100)
+	wl.WriteUint8(0x81) // DELTAS_ARE_ZERO
+	wl.WriteUint8(0)    // all points
+	writePackedDeltas(wl, make([]int16, len(ys)))
+	writePackedDeltas(wl, ys)
+
+	// glyph variation data of Á
+	wA := NewBinaryWriter([]byte{})
+	wA.WriteUint16(1)  // tupleVariationCount
+	wA.WriteUint16(10) // dataOffset
+	wA.WriteUint16(uint16(1 + 2*(1+2*(nA+4))))
+	wA.WriteUint16(0x8000 | 0x2000)
+	wA.WriteInt16(1 << 14) // peak
+	wA.WriteUint8(0)       // all points
+	xs := make([]int16, nA+4)
+	xs[0] = 20
+	writePackedDeltas(wA, xs)
+	writePackedDeltas(wA, make([]int16, nA+4))
+
+	w = NewBinaryWriter([]byte{})
+	w.WriteUint16(1) // majorVersion
+	w.WriteUint16(0) // minorVersion
+	w.WriteUint16(1) // axisCount
+	w.WriteUint16(0) // sharedTupleCount
+	w.WriteUint32(0) // sharedTuplesOffset
+	w.WriteUint16(sfnt.Maxp.NumGlyphs)
+	w.WriteUint16(1)                                      // flags
+	w.WriteUint32(20 + 4*(uint32(sfnt.Maxp.NumGlyphs)+1)) // glyphVariationDataArrayOffset
+	offset := uint32(0)
+	for id := uint16(0); id <= sfnt.Maxp.NumGlyphs; id++ {
+		w.WriteUint32(offset)
+		if id == l {
+			offset += wl.Len()
+		} else if id == A {
+			offset += wA.Len()
+		}
+	}
+	if l < A {
+		w.WriteBytes(wl.Bytes())
+		w.WriteBytes(wA.Bytes())
+	} else {
+		w.WriteBytes(wA.Bytes())
+		w.WriteBytes(wl.Bytes())
+	}
+	sfnt.Tables["gvar"] = w.Bytes()
+
+	sfnt, err = ParseSFNT(sfnt.Write(), 0)
+	test.Error(t, err)
+	return sfnt
+}
+
+func TestSFNTVariationsGvar(t *testing.T) {
+	sfnt := gvarTestFont(t)
+	l, A := sfnt.GlyphIndex('l'), sfnt.GlyphIndex('Á')
+	test.That(t, sfnt.IsVariable())
+	test.T(t, sfnt.NamedInstances(), []NamedInstance{{2, 0xFFFF, []float64{650.0}}})
+
+	contour, err := sfnt.Glyf.Contour(l, 0)
+	test.Error(t, err)
+	compound, err := sfnt.Glyf.Contour(A, 0)
+	test.Error(t, err)
+	advance := sfnt.GlyphAdvance(l)
+
+	// deltas of the first point are inferred for the other points of the contour, and the phantom point increases the advance
+	sfnt.SetVariations(map[string]float64{"wght": 900.0})
+	test.T(t, sfnt.VariationCoordinates(), []float64{1.0})
+	contour2, err := sfnt.Glyf.Contour(l, 0)
+	test.Error(t, err)
+	for i := range contour.XCoordinates {
+		test.T(t, contour2.XCoordinates[i], contour.XCoordinates[i]+10)
+		test.T(t, contour2.YCoordinates[i], contour.YCoordinates[i])
+	}
+	test.T(t, contour2.XMin, contour.XMin+10)
+	test.T(t, sfnt.GlyphAdvance(l), advance+100)
+
+	// the offset of the first component moves
+	compound2, err := sfnt.Glyf.Contour(A, 0)
+	test.Error(t, err)
+	n := int(compound.EndPoints[1]) + 1 // points of the first component
+	test.T(t, compound2.XCoordinates[0], compound.XCoordinates[0]+20)
+	test.T(t, compound2.XCoordinates[n], compound.XCoordinates[n])
+
+	// halfway to the minimum
+	sfnt.SetVariations(map[string]float64{"wght": 250.0})
+	test.T(t, sfnt.VariationCoordinates(), []float64{-0.5})
+	contour2, err = sfnt.Glyf.Contour(l, 0)
+	test.Error(t, err)
+	test.T(t, contour2.XCoordinates[0], contour.XCoordinates[0])
+	test.T(t, contour2.YCoordinates[0], contour.YCoordinates[0]-3) // rounded from -2.5
+	test.T(t, sfnt.GlyphAdvance(l), advance)
+
+	// named instance and default
+	test.Error(t, sfnt.SetNamedInstance(0))
+	test.T(t, sfnt.Variations(), map[string]float64{"wght": 650.0})
+	test.T(t, sfnt.VariationCoordinates(), []float64{0.5})
+	test.That(t, sfnt.SetNamedInstance(1) != nil)
+	sfnt.SetVariations(nil)
+	test.T(t, sfnt.VariationCoordinates(), []float64(nil))
+	test.T(t, sfnt.Variations(), map[string]float64{"wght": 400.0})
+	test.T(t, sfnt.GlyphAdvance(l), advance)
+}
+
+func TestSFNTVariationsCFF2(t *testing.T) {
+	b, err := ioutil.ReadFile("../resources/AdobeVFPrototype.otf")
+	test.Error(t, err)
+	sfnt, err := ParseSFNT(b, 0)
+	test.Error(t, err)
+	A := sfnt.GlyphIndex('A')
+
+	axes := sfnt.VariationAxes()
+	test.T(t, len(axes), 2)
+	test.T(t, axes[0].Tag, "wght")
+	test.T(t, axes[0].Min, 200.0)
+	test.T(t, axes[0].Max, 900.0)
+	test.T(t, axes[1].Tag, "CNTR")
+	test.T(t, len(sfnt.NamedInstances()), 8)
+
+	test.T(t, sfnt.GlyphAdvance(A), uint16(663))
+	xmin, ymin, xmax, ymax, err := sfnt.GlyphBounds(A)
+	test.Error(t, err)
+	test.T(t, []int16{xmin, ymin, xmax, ymax}, []int16{5, 0, 653, 675})
+	test.T(t, sfnt.OS2.SxHeight, int16(474))
+
+	// blend in charstrings, HVAR, and MVAR
+	test.Error(t, sfnt.SetNamedInstance(5)) // Black
+	test.T(t, sfnt.VariationCoordinates(), []float64{1.0, 0.0})
+	test.T(t, sfnt.GlyphAdvance(A), uint16(680))
+	xmin, ymin, xmax, ymax, err = sfnt.GlyphBounds(A)
+	test.Error(t, err)
+	test.T(t, []int16{xmin, ymin, xmax, ymax}, []int16{10, 0, 665, 652})
+	test.T(t, sfnt.OS2.SxHeight, int16(487))
+
+	// avar
+	sfnt.SetVariations(map[string]float64{"wght": 500.0, "CNTR": 100.0})
+	test.T(t, sfnt.VariationCoordinates(), []float64{0.2041015625, 1.0})
+
+	sfnt.SetVariations(nil)
+	test.T(t, sfnt.GlyphAdvance(A), uint16(663))
+	test.T(t, sfnt.OS2.SxHeight, int16(474))
+}
+
+func TestParseVariations(t *testing.T) {
+	var tts = []struct {
+		s          string
+		variations map[string]float64
+	}{
+		{"", map[string]float64{}},
+		{"wght=700", map[string]float64{"wght": 700.0}},
+		{" wght = 700 , wdth=75.5", map[string]float64{"wght": 700.0, "wdth": 75.5}},
+		{`"opsz" 12`, map[string]float64{"opsz": 12.0}},
+		{"ab=1", map[string]float64{"ab  ": 1.0}},
+	}
+	for _, tt := range tts {
+		t.Run(tt.s, func(t *testing.T) {
+			variations, err := ParseVariations(tt.s)
+			test.Error(t, err)
+			test.T(t, variations, tt.variations)
+		})
+	}
+
+	_, err := ParseVariations("wght")
+	test.That(t, err != nil)
+	_, err = ParseVariations("wght=bold")
+	test.That(t, err != nil)
+}
+
+func TestFormatVariations(t *testing.T) {
+	test.T(t, FormatVariations(nil), "")
+	test.T(t, FormatVariations(map[string]float64{"wght": 700.0, "ab  ": 1.5}), "ab=1.5,wght=700")
+
+	variations, err := ParseVariations(FormatVariations(map[string]float64{"wdth": 75.25, "ab  ": 1.0}))
+	test.Error(t, err)
+	test.T(t, variations, map[string]float64{"wdth": 75.25, "ab  ": 1.0})
+}
+
+func TestIUPDeltas(t *testing.T) {
+	deltas := []float64{10.0, 0.0, 30.0, 0.0, 5.0, 0.0}
+	touched := []bool{true, false, true, false, true, false}
+	iupDeltas(deltas, []int16{0, 50, 100, 150, 0, 0}, touched, []uint16{3, 5})
+	test.T(t, deltas, []float64{10.0, 20.0, 30.0, 30.0, 5.0, 5.0})
+}
@@ -80,7 +80,7 @@ func (sfnt *SFNT) Write() []byte {
 	return buf
 }
 
-// Subset regenerates a font file containing only the passed glyphIDs, thereby resulting in a significant size reduction. The glyphIDs will apear in the specified order in the file, and their dependencies are added to the end. It returns the compressed font file and the glyphIDs in the order in which they appear.
+// Subset regenerates a font file containing only the passed glyphIDs, thereby resulting in a significant size reduction. The glyphIDs will apear in the specified order in the file, and their dependencies and the glyphs that GSUB may substitute for them are added to the end. The GDEF, GSUB, and GPOS tables are rewritten for the subset so that it shapes like the original font. It returns the compressed font file and the glyphIDs in the order in which they appear.
 func (sfnt *SFNT) Subset(glyphIDs []uint16, writeTables WriteTables) ([]byte, []uint16) {
 	if sfnt.IsCFF {
 		// TODO: support CFF
@@ -96,6 +96,18 @@ func (sfnt *SFNT) Subset(glyphIDs []uint16, writeTables WriteTables) ([]byte, []
 		glyphMap[glyphID] = uint16(subsetGlyphID)
 	}
 
+	// add glyphs that may be substituted by GSUB so that the subset shapes like the original
+	if writeTables != WritePDFTables {
+		if closure, err := sfnt.gsubClosure(glyphIDs); err == nil {
+			for _, glyphID := range closure {
+				if _, ok := glyphMap[glyphID]; !ok {
+					glyphMap[glyphID] = uint16(len(glyphIDs))
+					glyphIDs = append(glyphIDs, glyphID)
+				}
+			}
+		}
+	}
+
 	// add dependencies for composite glyphs
 	origLen := len(glyphIDs)
 	for i := 0; i < origLen; i++ {
@@ -115,7 +127,12 @@ func (sfnt *SFNT) Subset(glyphIDs []uint16, writeTables WriteTables) ([]byte, []
 	// specify tables to include
 	var tags []string
 	if writeTables == WriteMinTables {
-		tags := []string{"cmap", "head", "hhea", "hmtx", "maxp", "name", "OS/2", "post"}
+		tags = []string{"cmap", "head", "hhea", "hmtx", "maxp", "name", "OS/2", "post"}
+		for _, tag := range []string{"GDEF", "GPOS", "GSUB"} {
+			if _, ok := sfnt.Tables[tag]; ok {
+				tags = append(tags, tag)
+			}
+		}
 		if sfnt.IsTrueType {
 			tags = append(tags, "glyf", "loca")
 		} else if sfnt.IsCFF {
@@ -172,6 +189,20 @@ func (sfnt *SFNT) Subset(glyphIDs []uint16, writeTables WriteTables) ([]byte, []
 			tags = append(tags, "kern")
 		}
 	}
+
+	// rewrite layout tables for the subset glyph IDs, or remove them if they cannot be parsed
+	layoutTables := map[string][]byte{}
+	for i := 0; i < len(tags); i++ {
+		if tag := tags[i]; tag == "GDEF" || tag == "GPOS" || tag == "GSUB" {
+			b, err := sfnt.subsetLayoutTable(tag, glyphMap)
+			if err != nil {
+				tags = append(tags[:i], tags[i+1:]...)
+				i--
+				continue
+			}
+			layoutTables[tag] = b
+		}
+	}
 	sort.Strings(tags)
 
 	// write header
@@ -195,6 +226,7 @@ func (sfnt *SFNT) Subset(glyphIDs []uint16, writeTables WriteTables) ([]byte, []
 	// write tables
 	var checksumAdjustmentPos uint32
 	locaShortFormat := false
+	numberOfHMetrics := uint16(len(glyphIDs))
 	offsets, lengths := make([]uint32, numTables), make([]uint32, numTables)
 	for i, tag := range tags {
 		offsets[i] = w.Len()
@@ -284,19 +316,18 @@ func (sfnt *SFNT) Subset(glyphIDs []uint16, writeTables WriteTables) ([]byte, []
 			w.WriteUint16(uint16(len(glyphIDs))) // numGlyphs
 			w.WriteBytes(maxp[6:])
 		case "hhea":
-			numberOfHMetrics := uint16(0)
-			for _, glyphID := range glyphIDs {
-				if sfnt.Hhea.NumberOfHMetrics <= glyphID {
-					break
-				}
-				numberOfHMetrics++
+			// trailing glyphs with the same advance only need their left side bearing
+			numberOfHMetrics = uint16(len(glyphIDs))
+			for 1 < numberOfHMetrics && sfnt.Hmtx.Advance(glyphIDs[numberOfHMetrics-1]) == sfnt.Hmtx.Advance(glyphIDs[numberOfHMetrics-2]) {
+				numberOfHMetrics--
 			}
 			hhea := sfnt.Tables["hhea"]
 			w.WriteBytes(hhea[:34])
 			w.WriteUint16(numberOfHMetrics) // numberOfHMetrics
 		case "hmtx":
-			for _, glyphID := range glyphIDs {
-				if glyphID < sfnt.Hhea.NumberOfHMetrics {
+			// hhea comes before hmtx
+			for i, glyphID := range glyphIDs {
+				if i < int(numberOfHMetrics) {
 					w.WriteUint16(sfnt.Hmtx.Advance(glyphID))
 				}
 				w.WriteInt16(sfnt.Hmtx.LeftSideBearing(glyphID))
@@ -323,60 +354,108 @@ func (sfnt *SFNT) Subset(glyphIDs []uint16, writeTables WriteTables) ([]byte, []
 				w.WriteBytes(b)
 			}
 		case "cmap":
-			w.WriteUint16(0)  // version
-			w.WriteUint16(1)  // numTables
-			w.WriteUint16(0)  // platformID
-			w.WriteUint16(4)  // encodingID
-			w.WriteUint32(12) // subtableOffset
-
-			// format 12
-			start := w.Len()
-			w.WriteUint16(12) // format
-			w.WriteUint16(0)  // reserved
-			w.WriteUint32(0)  // length
-			w.WriteUint32(0)  // language
-			w.WriteUint32(0)  // numGroups
-
-			rs := make([]rune, 0, len(glyphIDs))
-			runeMap := make(map[rune]uint16, len(glyphIDs))
-			for subsetGlyphID, glyphID := range glyphIDs {
-				if r := sfnt.Cmap.ToUnicode(glyphID); r != 0 {
+			// map all runes of the retained glyphs
+			rs := []rune{}
+			runeMap := map[rune]uint16{}
+			for r, glyphID := range sfnt.Cmap.Runes() {
+				if subsetGlyphID, ok := glyphMap[glyphID]; ok {
 					rs = append(rs, r)
-					runeMap[r] = uint16(subsetGlyphID)
+					runeMap[r] = subsetGlyphID
 				}
 			}
+			sort.Slice(rs, func(i, j int) bool { return rs[i] < rs[j] })
 
-			if 0 < len(rs) {
-				sort.Slice(rs, func(i, j int) bool { return rs[i] < rs[j] })
-
-				numGroups := uint32(1)
-				startCharCode := uint32(rs[0])
-				startGlyphID := uint32(runeMap[rs[0]])
-				n := uint32(1)
-				for i := 1; i < len(rs); i++ {
-					r := rs[i]
-					subsetGlyphID := runeMap[r]
-					if r == rs[i-1] {
-						continue
-					} else if uint32(r) == startCharCode+n && uint32(subsetGlyphID) == startGlyphID+n {
-						n++
+			// segments and groups of consecutive runes and glyph IDs, format 4 only for the BMP
+			startCodes, endCodes := []uint16{}, []uint16{}
+			startCharCodes, endCharCodes := []uint32{}, []uint32{}
+			for i, r := range rs {
+				consecutive := 0 < i && r == rs[i-1]+1 && runeMap[r] == runeMap[rs[i-1]]+1
+				if r < 0xFFFF {
+					if consecutive {
+						endCodes[len(endCodes)-1] = uint16(r)
 					} else {
-						w.WriteUint32(uint32(startCharCode))         // startCharCode
-						w.WriteUint32(uint32(startCharCode + n - 1)) // endCharCode
-						w.WriteUint32(uint32(startGlyphID))          // startGlyphID
-						numGroups++
-						startCharCode = uint32(r)
-						startGlyphID = uint32(subsetGlyphID)
-						n = 1
+						startCodes = append(startCodes, uint16(r))
+						endCodes = append(endCodes, uint16(r))
 					}
 				}
-				w.WriteUint32(uint32(startCharCode))         // startCharCode
-				w.WriteUint32(uint32(startCharCode + n - 1)) // endCharCode
-				w.WriteUint32(uint32(startGlyphID))          // startGlyphID
+				if consecutive && 0 < len(startCharCodes) {
+					endCharCodes[len(endCharCodes)-1] = uint32(r)
+				} else {
+					startCharCodes = append(startCharCodes, uint32(r))
+					endCharCodes = append(endCharCodes, uint32(r))
+				}
+			}
+			startCodes = append(startCodes, 0xFFFF) // final segment
+			endCodes = append(endCodes, 0xFFFF)
+
+			segCount := uint16(len(startCodes))
+			format4Length := 16 + 8*uint32(segCount)
+			numTables := uint16(3)
+			if math.MaxUint16 < format4Length {
+				numTables = 2 // too many segments, only use format 12
+			}
+			format12Offset := 4 + 8*uint32(numTables)
+			if numTables == 3 {
+				format12Offset += format4Length
+			}
+
+			w.WriteUint16(0)              // version
+			w.WriteUint16(numTables)      // numTables
+			w.WriteUint16(0)              // platformID
+			w.WriteUint16(4)              // encodingID
+			w.WriteUint32(format12Offset) // subtableOffset
+			if numTables == 3 {
+				w.WriteUint16(3)                       // platformID
+				w.WriteUint16(1)                       // encodingID
+				w.WriteUint32(4 + 8*uint32(numTables)) // subtableOffset
+			}
+			w.WriteUint16(3)              // platformID
+			w.WriteUint16(10)             // encodingID
+			w.WriteUint32(format12Offset) // subtableOffset
+
+			if numTables == 3 {
+				// format 4
+				entrySelector := uint16(math.Log2(float64(segCount)))
+				searchRange := uint16(2 << entrySelector)
+				w.WriteUint16(4)                        // format
+				w.WriteUint16(uint16(format4Length))    // length
+				w.WriteUint16(0)                        // language
+				w.WriteUint16(2 * segCount)             // segCountX2
+				w.WriteUint16(searchRange)              // searchRange
+				w.WriteUint16(entrySelector)            // entrySelector
+				w.WriteUint16(2*segCount - searchRange) // rangeShift
+				for _, endCode := range endCodes {
+					w.WriteUint16(endCode)
+				}
+				w.WriteUint16(0) // reservedPad
+				for _, startCode := range startCodes {
+					w.WriteUint16(startCode)
+				}
+				for i, startCode := range startCodes {
+					if i+1 == len(startCodes) {
+						w.WriteUint16(1) // maps 0xFFFF to .notdef
+					} else {
+						w.WriteUint16(runeMap[rune(startCode)] - startCode) // idDelta modulo 65536
+					}
+				}
+				for range startCodes {
+					w.WriteUint16(0) // idRangeOffset
+				}
+			}
 
-				binary.BigEndian.PutUint32(w.buf[start+4:], w.Len()-start) // set length
-				binary.BigEndian.PutUint32(w.buf[start+12:], numGroups)    // set numGroups
+			// format 12
+			w.WriteUint16(12)                                  // format
+			w.WriteUint16(0)                                   // reserved
+			w.WriteUint32(16 + 12*uint32(len(startCharCodes))) // length
+			w.WriteUint32(0)                                   // language
+			w.WriteUint32(uint32(len(startCharCodes)))         // numGroups
+			for i, startCharCode := range startCharCodes {
+				w.WriteUint32(startCharCode)                        // startCharCode
+				w.WriteUint32(endCharCodes[i])                      // endCharCode
+				w.WriteUint32(uint32(runeMap[rune(startCharCode)])) // startGlyphID
 			}
+		case "GDEF", "GPOS", "GSUB":
+			w.WriteBytes(layoutTables[tag])
 		case "kern":
 			w.WriteUint16(0)                          // version
 			w.WriteUint16(uint16(len(kernSubtables))) // nTables
@@ -400,7 +479,6 @@ func (sfnt *SFNT) Subset(glyphIDs []uint16, writeTables WriteTables) ([]byte, []
 			}
 		default:
 			// TODO: compress name table
-			// TODO: GDEF, GSUB, GPOS tables for ligatures
 			w.WriteBytes(sfnt.Tables[tag])
 		}
 		lengths[i] = w.Len() - offsets[i]
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"sort"
 )
 
 type woffTable struct {
@@ -223,3 +224,133 @@ func ParseWOFF(b []byte) ([]byte, error) {
 	binary.BigEndian.PutUint32(buf[checksumAdjustmentPos:], checksumAdjustment)
 	return buf, nil
 }
+
+// readSFNTTables returns the sfntVersion and the tables of an SFNT font file (TTF or OTF), where the table data excludes padding.
+func readSFNTTables(b []byte) (uint32, map[string][]byte, error) {
+	if len(b) < 12 || uint(math.MaxUint32) < uint(len(b)) {
+		return 0, nil, ErrInvalidFontData
+	}
+
+	r := NewBinaryReader(b)
+	flavor := r.ReadUint32()
+	if uint32ToString(flavor) == "ttcf" {
+		return 0, nil, fmt.Errorf("collections are unsupported")
+	} else if uint32ToString(flavor) != "OTTO" && uint32ToString(flavor) != "true" && flavor != 0x00010000 {
+		return 0, nil, fmt.Errorf("bad SFNT version")
+	}
+	numTables := r.ReadUint16()
+	_ = r.ReadUint16()                  // searchRange
+	_ = r.ReadUint16()                  // entrySelector
+	_ = r.ReadUint16()                  // rangeShift
+	if r.Len() < 16*uint32(numTables) { // can never exceed uint32 as numTables is uint16
+		return 0, nil, ErrInvalidFontData
+	} else if numTables == 0 {
+		return 0, nil, fmt.Errorf("numTables must not be zero")
+	}
+
+	tables := make(map[string][]byte, numTables)
+	for i := 0; i < int(numTables); i++ {
+		tag := r.ReadString(4)
+		_ = r.ReadUint32() // checksum
+		offset := r.ReadUint32()
+		length := r.ReadUint32()
+		if uint32(len(b)) < offset || uint32(len(b))-offset < length {
+			return 0, nil, ErrInvalidFontData
+		} else if _, ok := tables[tag]; ok {
+			return 0, nil, fmt.Errorf("%s: table defined more than once", tag)
+		}
+		tables[tag] = b[offset : offset+length : offset+length]
+	}
+	if head, ok := tables["head"]; !ok || len(head) < 54 {
+		return 0, nil, fmt.Errorf("head: must be present")
+	}
+	return flavor, tables, nil
+}
+
+// WriteWOFF converts the SFNT font format (TTF or OTF) to the WOFF font format, where each table is compressed using zlib when that reduces its size. See https://www.w3.org/TR/WOFF/
+func WriteWOFF(b []byte) ([]byte, error) {
+	flavor, tables, err := readSFNTTables(b)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := []string{}
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	numTables := uint16(len(tags))
+	frontSize := 44 + 20*uint32(numTables) // can never exceed uint32 as numTables is uint16
+	totalSfntSize := 12 + 16*uint32(numTables)
+
+	// compress tables
+	datas := make([][]byte, numTables)
+	checksums := make([]uint32, numTables)
+	for i, tag := range tags {
+		data := tables[tag]
+		origLength := uint32(len(data))
+		padding := (4 - origLength&3) & 3
+		totalSfntSize += origLength + padding
+
+		// the checksum of the head table is calculated with checkSumAdjustment set to zero
+		padded := make([]byte, origLength+padding)
+		copy(padded, data)
+		if tag == "head" {
+			binary.BigEndian.PutUint32(padded[8:], 0x00000000)
+		}
+		checksums[i] = calcChecksum(padded)
+
+		var buf bytes.Buffer
+		w, _ := zlib.NewWriterLevel(&buf, zlib.BestCompression) // err is always nil
+		if _, err := w.Write(data); err != nil {
+			return nil, fmt.Errorf("%s: %v", tag, err)
+		} else if err := w.Close(); err != nil {
+			return nil, fmt.Errorf("%s: %v", tag, err)
+		}
+		if buf.Len() < len(data) {
+			data = buf.Bytes()
+		}
+		datas[i] = data
+	}
+
+	// write header
+	w := NewBinaryWriter(make([]byte, 0, frontSize))
+	w.WriteString("wOFF")
+	w.WriteUint32(flavor)
+	w.WriteUint32(0) // length, set at the end
+	w.WriteUint16(numTables)
+	w.WriteUint16(0) // reserved
+	w.WriteUint32(totalSfntSize)
+	w.WriteUint16(1) // majorVersion
+	w.WriteUint16(0) // minorVersion
+	w.WriteUint32(0) // metaOffset
+	w.WriteUint32(0) // metaLength
+	w.WriteUint32(0) // metaOrigLength
+	w.WriteUint32(0) // privOffset
+	w.WriteUint32(0) // privLength
+
+	// write table directory
+	offset := frontSize
+	for i, tag := range tags {
+		compLength := uint32(len(datas[i]))
+		w.WriteString(tag)
+		w.WriteUint32(offset)
+		w.WriteUint32(compLength)
+		w.WriteUint32(uint32(len(tables[tag])))
+		w.WriteUint32(checksums[i])
+		offset += (compLength + 3) & 0xFFFFFFFC // add padding
+	}
+
+	// write tables
+	for _, data := range datas {
+		w.WriteBytes(data)
+		for w.Len()%4 != 0 {
+			w.WriteByte(0x00)
+		}
+	}
+
+	buf := w.Bytes()
+	binary.BigEndian.PutUint32(buf[8:], uint32(len(buf)))
+	return buf, nil
+}
@@ -8,6 +8,7 @@ import (
 	"math"
 	"sort"
 
+	brotliEnc "github.com/andybalholm/brotli"
 	"github.com/dsnet/compress/brotli"
 )
 
@@ -769,3 +770,396 @@ func read255Uint16(r *BinaryReader) uint16 {
 		return uint16(code)
 	}
 }
+
+// WriteWOFF2 converts the SFNT font format (TTF or OTF) to the WOFF2 font format, where the glyf and loca tables are transformed and all tables are compressed using Brotli. See https://www.w3.org/TR/WOFF2/
+func WriteWOFF2(b []byte) ([]byte, error) {
+	flavor, tables, err := readSFNTTables(b)
+	if err != nil {
+		return nil, err
+	}
+	delete(tables, "DSIG") // invalidated by the transformations
+
+	// set bit 11 in the flags of the head table to indicate that the font has been transformed
+	head := append([]byte{}, tables["head"]...)
+	binary.BigEndian.PutUint16(head[16:], binary.BigEndian.Uint16(head[16:])|0x0800)
+	tables["head"] = head
+
+	glyf, hasGlyf := tables["glyf"]
+	loca, hasLoca := tables["loca"]
+	var origLocaLength uint32
+	if hasGlyf != hasLoca {
+		return nil, fmt.Errorf("glyf and loca tables must be both present")
+	} else if hasGlyf {
+		maxp, ok := tables["maxp"]
+		if !ok || len(maxp) < 6 {
+			return nil, fmt.Errorf("maxp: must be present")
+		}
+		numGlyphs := binary.BigEndian.Uint16(maxp[4:])
+		indexFormat := binary.BigEndian.Uint16(head[50:])
+		if tables["glyf"], indexFormat, err = transformGlyf(glyf, loca, numGlyphs, indexFormat); err != nil {
+			return nil, err
+		}
+		binary.BigEndian.PutUint16(head[50:], indexFormat)
+
+		// loca is reconstructed by the decoder and has no data
+		origLocaLength = (uint32(numGlyphs) + 1) * 2
+		if indexFormat != 0 {
+			origLocaLength *= 2
+		}
+		tables["loca"] = []byte{}
+	}
+
+	tags := []string{}
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags) // loca comes after glyf
+	numTables := uint16(len(tags))
+
+	// write table directory and concatenate the table data
+	dir := NewBinaryWriter([]byte{})
+	var data bytes.Buffer
+	totalSfntSize := 12 + 16*uint32(numTables) // can never exceed uint32 as numTables is uint16
+	for _, tag := range tags {
+		tagIndex := 63
+		for i, knownTag := range woff2TableTags {
+			if tag == knownTag {
+				tagIndex = i
+				break
+			}
+		}
+
+		origLength := uint32(len(tables[tag]))
+		if tag == "glyf" {
+			origLength = uint32(len(glyf))
+		} else if tag == "loca" {
+			origLength = origLocaLength
+		}
+		totalSfntSize += (origLength + 3) & 0xFFFFFFFC // add padding
+
+		// transformVersion zero is the transformation for glyf and loca, and the null transformation for all other tables
+		dir.WriteByte(byte(tagIndex))
+		if tagIndex == 63 {
+			dir.WriteString(tag)
+		}
+		writeUintBase128(dir, origLength)
+		if tag == "glyf" || tag == "loca" {
+			writeUintBase128(dir, uint32(len(tables[tag])))
+		}
+		data.Write(tables[tag])
+	}
+
+	// compress font data using Brotli
+	var compData bytes.Buffer
+	wBrotli := brotliEnc.NewWriterLevel(&compData, brotliEnc.BestCompression)
+	if _, err := wBrotli.Write(data.Bytes()); err != nil {
+		return nil, err
+	} else if err := wBrotli.Close(); err != nil {
+		return nil, err
+	}
+
+	// write header
+	w := NewBinaryWriter(make([]byte, 0, 48+dir.Len()+uint32(compData.Len())+3))
+	w.WriteString("wOF2")
+	w.WriteUint32(flavor)
+	w.WriteUint32(0) // length, set at the end
+	w.WriteUint16(numTables)
+	w.WriteUint16(0) // reserved
+	w.WriteUint32(totalSfntSize)
+	w.WriteUint32(uint32(compData.Len()))
+	w.WriteUint16(1) // majorVersion
+	w.WriteUint16(0) // minorVersion
+	w.WriteUint32(0) // metaOffset
+	w.WriteUint32(0) // metaLength
+	w.WriteUint32(0) // metaOrigLength
+	w.WriteUint32(0) // privOffset
+	w.WriteUint32(0) // privLength
+	w.WriteBytes(dir.Bytes())
+	w.WriteBytes(compData.Bytes())
+	for w.Len()%4 != 0 {
+		w.WriteByte(0x00)
+	}
+
+	buf := w.Bytes()
+	binary.BigEndian.PutUint32(buf[8:], uint32(len(buf)))
+	return buf, nil
+}
+
+// transformGlyf returns the transformed glyf table, which splits the glyph data into streams that compress better, and the indexFormat of the reconstructed loca table. See https://www.w3.org/TR/WOFF2/#glyf_table_format
+func transformGlyf(glyf, loca []byte, numGlyphs, indexFormat uint16) ([]byte, uint16, error) {
+	locaLength := (uint32(numGlyphs) + 1) * 2
+	if indexFormat != 0 {
+		locaLength *= 2
+	}
+	if uint32(len(loca)) < locaLength {
+		return nil, 0, fmt.Errorf("loca: must have numGlyphs+1 entries")
+	}
+
+	nContourStream := NewBinaryWriter([]byte{})
+	nPointsStream := NewBinaryWriter([]byte{})
+	flagStream := NewBinaryWriter([]byte{})
+	glyphStream := NewBinaryWriter([]byte{})
+	compositeStream := NewBinaryWriter([]byte{})
+	bboxBitmap := make([]byte, ((uint32(numGlyphs)+31)>>5)<<2)
+	bboxStream := NewBinaryWriter([]byte{})
+	instructionStream := NewBinaryWriter([]byte{})
+
+	rLoca := NewBinaryReader(loca)
+	readOffset := func() uint32 {
+		if indexFormat == 0 {
+			return uint32(rLoca.ReadUint16()) << 1
+		}
+		return rLoca.ReadUint32()
+	}
+
+	var reconstructedLength uint32 // length of the glyf table as reconstructed by the decoder
+	offset := readOffset()
+	for iGlyph := uint16(0); iGlyph < numGlyphs; iGlyph++ {
+		offsetNext := readOffset()
+		if offsetNext < offset || uint32(len(glyf)) < offsetNext {
+			return nil, 0, fmt.Errorf("glyf: bad offset for glyph %d", iGlyph)
+		} else if offset == offsetNext {
+			nContourStream.WriteInt16(0) // empty glyph
+			continue
+		}
+
+		glyph := glyf[offset:offsetNext]
+		r := NewBinaryReader(glyph)
+		offset = offsetNext
+		nContours := r.ReadInt16()
+		xMin := r.ReadInt16()
+		yMin := r.ReadInt16()
+		xMax := r.ReadInt16()
+		yMax := r.ReadInt16()
+		if r.EOF() {
+			return nil, 0, fmt.Errorf("glyf: bad data for glyph %d", iGlyph)
+		} else if nContours == 0 {
+			nContourStream.WriteInt16(0) // empty glyph
+			continue
+		}
+		nContourStream.WriteInt16(nContours)
+
+		var length uint32
+		if 0 < nContours { // simple glyph
+			var nPoints uint16
+			for iContour := int16(0); iContour < nContours; iContour++ {
+				endPoint := r.ReadUint16()
+				if endPoint < nPoints || iContour == 0 && endPoint == math.MaxUint16 {
+					return nil, 0, fmt.Errorf("glyf: bad contour for glyph %d", iGlyph)
+				}
+				write255Uint16(nPointsStream, endPoint+1-nPoints)
+				nPoints = endPoint + 1
+			}
+			instructionLength := r.ReadUint16()
+			instructions := r.ReadBytes(uint32(instructionLength))
+
+			flags := make([]byte, 0, nPoints)
+			for len(flags) < int(nPoints) {
+				flag := r.ReadByte()
+				flags = append(flags, flag)
+				if flag&0x08 != 0 { // REPEAT_FLAG
+					for n := r.ReadByte(); 0 < n && len(flags) < int(nPoints); n-- {
+						flags = append(flags, flag)
+					}
+				}
+			}
+			readCoordinates := func(short, same byte) []int16 {
+				deltas := make([]int16, nPoints)
+				for i, flag := range flags {
+					if flag&short != 0 {
+						deltas[i] = int16(r.ReadByte())
+						if flag&same == 0 {
+							deltas[i] = -deltas[i]
+						}
+					} else if flag&same == 0 {
+						deltas[i] = r.ReadInt16()
+					}
+				}
+				return deltas
+			}
+			dxs := readCoordinates(0x02, 0x10)
+			dys := readCoordinates(0x04, 0x20)
+			if r.EOF() {
+				return nil, 0, fmt.Errorf("glyf: bad data for glyph %d", iGlyph)
+			}
+
+			var x, y int16
+			bboxXMin, bboxYMin, bboxXMax, bboxYMax := int16(0), int16(0), int16(0), int16(0)
+			for i, flag := range flags {
+				writeTriplet(flagStream, glyphStream, flag&0x01 != 0, dxs[i], dys[i])
+				x += dxs[i]
+				y += dys[i]
+				if i == 0 {
+					bboxXMin, bboxXMax = x, x
+					bboxYMin, bboxYMax = y, y
+				} else {
+					if x < bboxXMin {
+						bboxXMin = x
+					} else if bboxXMax < x {
+						bboxXMax = x
+					}
+					if y < bboxYMin {
+						bboxYMin = y
+					} else if bboxYMax < y {
+						bboxYMax = y
+					}
+				}
+			}
+			if bboxXMin != xMin || bboxYMin != yMin || bboxXMax != xMax || bboxYMax != yMax {
+				bboxBitmap[iGlyph>>3] |= 0x80 >> (iGlyph & 7)
+				bboxStream.WriteInt16(xMin)
+				bboxStream.WriteInt16(yMin)
+				bboxStream.WriteInt16(xMax)
+				bboxStream.WriteInt16(yMax)
+			}
+			write255Uint16(glyphStream, instructionLength)
+			instructionStream.WriteBytes(instructions)
+			length = 12 + 2*uint32(nContours) + uint32(instructionLength) + 5*uint32(nPoints)
+		} else { // composite glyph
+			bboxBitmap[iGlyph>>3] |= 0x80 >> (iGlyph & 7)
+			bboxStream.WriteInt16(xMin)
+			bboxStream.WriteInt16(yMin)
+			bboxStream.WriteInt16(xMax)
+			bboxStream.WriteInt16(yMax)
+
+			start := r.Pos()
+			hasInstructions := false
+			for {
+				flags := r.ReadUint16()
+				n := uint32(4) // glyphIndex and XY bytes
+				if flags&0x0001 != 0 {
+					n += 2 // ARG_1_AND_2_ARE_WORDS
+				}
+				if flags&0x0008 != 0 {
+					n += 2 // WE_HAVE_A_SCALE
+				} else if flags&0x0040 != 0 {
+					n += 4 // WE_HAVE_AN_X_AND_Y_SCALE
+				} else if flags&0x0080 != 0 {
+					n += 8 // WE_HAVE_A_TWO_BY_TWO
+				}
+				_ = r.ReadBytes(n)
+				if r.EOF() {
+					return nil, 0, fmt.Errorf("glyf: bad data for glyph %d", iGlyph)
+				}
+				if flags&0x0100 != 0 {
+					hasInstructions = true
+				}
+				if flags&0x0020 == 0 {
+					break
+				}
+			}
+			compositeStream.WriteBytes(glyph[start:r.Pos()])
+			length = 10 + r.Pos() - start
+			if hasInstructions {
+				instructionLength := r.ReadUint16()
+				instructions := r.ReadBytes(uint32(instructionLength))
+				if r.EOF() {
+					return nil, 0, fmt.Errorf("glyf: bad data for glyph %d", iGlyph)
+				}
+				write255Uint16(glyphStream, instructionLength)
+				instructionStream.WriteBytes(instructions)
+				length += 2 + uint32(instructionLength)
+			}
+		}
+		reconstructedLength += (length + 3) & 0xFFFFFFFC // add padding
+	}
+
+	// the reconstructed glyphs may be larger than the original, use long offsets when needed
+	if indexFormat == 0 && 0x1FFFE < reconstructedLength {
+		indexFormat = 1
+	}
+
+	w := NewBinaryWriter([]byte{})
+	w.WriteUint32(0) // version
+	w.WriteUint16(numGlyphs)
+	w.WriteUint16(indexFormat)
+	w.WriteUint32(nContourStream.Len())
+	w.WriteUint32(nPointsStream.Len())
+	w.WriteUint32(flagStream.Len())
+	w.WriteUint32(glyphStream.Len())
+	w.WriteUint32(compositeStream.Len())
+	w.WriteUint32(uint32(len(bboxBitmap)) + bboxStream.Len())
+	w.WriteUint32(instructionStream.Len())
+	w.WriteBytes(nContourStream.Bytes())
+	w.WriteBytes(nPointsStream.Bytes())
+	w.WriteBytes(flagStream.Bytes())
+	w.WriteBytes(glyphStream.Bytes())
+	w.WriteBytes(compositeStream.Bytes())
+	w.WriteBytes(bboxBitmap)
+	w.WriteBytes(bboxStream.Bytes())
+	w.WriteBytes(instructionStream.Bytes())
+	return w.Bytes(), indexFormat, nil
+}
+
+// writeTriplet writes a point delta using the triplet encoding, with the flag and the coordinate bytes in separate streams. See https://www.w3.org/TR/WOFF2/#triplet_decoding
+func writeTriplet(flagStream, glyphStream *BinaryWriter, onCurve bool, dx, dy int16) {
+	var flag byte
+	if !onCurve {
+		flag = 0x80
+	}
+	absX, absY := int(dx), int(dy)
+	if absX < 0 {
+		absX = -absX
+	}
+	if absY < 0 {
+		absY = -absY
+	}
+	var signs byte
+	if 0 < dx {
+		signs |= 0x01
+	}
+	if 0 < dy {
+		signs |= 0x02
+	}
+
+	if dx == 0 && absY < 1280 {
+		flagStream.WriteByte(flag + byte((absY&0xF00)>>7) + signs>>1)
+		glyphStream.WriteByte(byte(absY))
+	} else if dy == 0 && absX < 1280 {
+		flagStream.WriteByte(flag + 10 + byte((absX&0xF00)>>7) + signs&0x01)
+		glyphStream.WriteByte(byte(absX))
+	} else if absX < 65 && absY < 65 {
+		flagStream.WriteByte(flag + 20 + byte((absX-1)&0x30) + byte(((absY-1)&0x30)>>2) + signs)
+		glyphStream.WriteByte(byte((absX-1)&0x0F)<<4 | byte((absY-1)&0x0F))
+	} else if absX < 769 && absY < 769 {
+		flagStream.WriteByte(flag + 84 + 12*byte(((absX-1)&0x300)>>8) + byte(((absY-1)&0x300)>>6) + signs)
+		glyphStream.WriteByte(byte(absX - 1))
+		glyphStream.WriteByte(byte(absY - 1))
+	} else if absX < 4096 && absY < 4096 {
+		flagStream.WriteByte(flag + 120 + signs)
+		glyphStream.WriteByte(byte(absX >> 4))
+		glyphStream.WriteByte(byte(absX&0x0F)<<4 | byte(absY>>8))
+		glyphStream.WriteByte(byte(absY))
+	} else {
+		flagStream.WriteByte(flag + 124 + signs)
+		glyphStream.WriteUint16(uint16(absX))
+		glyphStream.WriteUint16(uint16(absY))
+	}
+}
+
+func writeUintBase128(w *BinaryWriter, v uint32) {
+	// see https://www.w3.org/TR/WOFF2/#DataTypes
+	n := 1
+	for ; n < 5 && v>>(7*n) != 0; n++ {
+	}
+	for i := n - 1; 0 < i; i-- {
+		w.WriteByte(byte(v>>(7*i)) | 0x80)
+	}
+	w.WriteByte(byte(v & 0x7F))
+}
+
+func write255Uint16(w *BinaryWriter, v uint16) {
+	// see https://www.w3.org/TR/WOFF2/#DataTypes
+	if v < 253 {
+		w.WriteByte(byte(v))
+	} else if v < 506 {
+		w.WriteByte(255)
+		w.WriteByte(byte(v - 253))
+	} else if v < 759 {
+		w.WriteByte(254)
+		w.WriteByte(byte(v - 506))
+	} else {
+		w.WriteByte(253)
+		w.WriteUint16(v)
+	}
+}
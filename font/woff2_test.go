@@ -483,3 +483,69 @@ func TestWOFF2ValidationUserAgent(t *testing.T) {
 		})
 	}
 }
+
+func TestWOFF2Write(t *testing.T) {
+	for _, filename := range []string{"DejaVuSerif.ttf", "EBGaramond12-Regular.otf"} {
+		t.Run(filename, func(t *testing.T) {
+			b, err := ioutil.ReadFile("../resources/" + filename)
+			test.Error(t, err)
+			sfnt, err := ParseSFNT(b, 0)
+			test.Error(t, err)
+
+			woff2, err := WriteWOFF2(b)
+			test.Error(t, err)
+			woff, err := WriteWOFF(b)
+			test.Error(t, err)
+			test.That(t, len(woff2) < len(woff), "WOFF2 must be smaller than WOFF")
+			mediatype, _ := MediaType(woff2)
+			test.T(t, mediatype, "font/woff2")
+
+			b2, err := ParseWOFF2(woff2)
+			test.Error(t, err)
+			sfnt2, err := ParseSFNT(b2, 0)
+			test.Error(t, err)
+			test.T(t, sfnt2.NumGlyphs(), sfnt.NumGlyphs())
+			for tag, table := range sfnt.Tables {
+				if tag != "head" && tag != "glyf" && tag != "loca" {
+					test.Bytes(t, sfnt2.Tables[tag], table, tag)
+				}
+			}
+
+			// glyphs are transformed but have the same outlines
+			for glyphID := uint16(0); glyphID < sfnt.NumGlyphs(); glyphID++ {
+				p, p2 := &pointsPather{}, &pointsPather{}
+				test.Error(t, sfnt.GlyphPath(p, glyphID, 0, 0.0, 0.0, 1.0, NoHinting))
+				test.Error(t, sfnt2.GlyphPath(p2, glyphID, 0, 0.0, 0.0, 1.0, NoHinting))
+				test.T(t, p2.points, p.points, glyphID)
+				if sfnt.IsTrueType {
+					contour, err := sfnt.Glyf.Contour(glyphID, 0)
+					test.Error(t, err)
+					contour2, err := sfnt2.Glyf.Contour(glyphID, 0)
+					test.Error(t, err)
+					test.T(t, contour2.Instructions, contour.Instructions, glyphID)
+					test.T(t, [4]int16{contour2.XMin, contour2.YMin, contour2.XMax, contour2.YMax}, [4]int16{contour.XMin, contour.YMin, contour.XMax, contour.YMax}, glyphID)
+				}
+			}
+		})
+	}
+}
+
+func TestWOFF2WriteSubset(t *testing.T) {
+	b, err := ioutil.ReadFile("../resources/DejaVuSerif.ttf")
+	test.Error(t, err)
+	sfnt, err := ParseSFNT(b, 0)
+	test.Error(t, err)
+
+	glyphIDs := []uint16{0}
+	for _, r := range "Helo, Wrd!" {
+		glyphIDs = append(glyphIDs, sfnt.GlyphIndex(r))
+	}
+	subset, glyphIDs := sfnt.Subset(glyphIDs, WriteMinTables)
+	woff2, err := WriteWOFF2(subset)
+	test.Error(t, err)
+	test.That(t, len(woff2) < len(subset)/2, "WOFF2 must be smaller than half the subset")
+
+	sfnt2, err := ParseFont(woff2, 0)
+	test.Error(t, err)
+	test.T(t, sfnt2.NumGlyphs(), uint16(len(glyphIDs)))
+}
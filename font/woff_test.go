@@ -97,3 +97,34 @@ func TestWOFFValidationFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestWOFFWrite(t *testing.T) {
+	for _, filename := range []string{"DejaVuSerif.ttf", "EBGaramond12-Regular.otf"} {
+		t.Run(filename, func(t *testing.T) {
+			b, err := ioutil.ReadFile("../resources/" + filename)
+			test.Error(t, err)
+
+			woff, err := WriteWOFF(b)
+			test.Error(t, err)
+			test.That(t, len(woff) < len(b), "WOFF must be smaller than SFNT")
+			mediatype, _ := MediaType(woff)
+			test.T(t, mediatype, "font/woff")
+
+			b2, err := ParseWOFF(woff)
+			test.Error(t, err)
+			_, tables, err := readSFNTTables(b)
+			test.Error(t, err)
+			_, tables2, err := readSFNTTables(b2)
+			test.Error(t, err)
+			test.T(t, len(tables2), len(tables))
+			for tag, table := range tables {
+				if tag == "head" {
+					test.Bytes(t, tables2[tag][:8], table[:8])
+					test.Bytes(t, tables2[tag][12:], table[12:])
+				} else {
+					test.Bytes(t, tables2[tag], table, tag)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,504 @@
+package canvas
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/LaminoidStudio/Canvas/font"
+	canvasText "github.com/LaminoidStudio/Canvas/text"
+	"golang.org/x/image/tiff"
+)
+
+// sweepGradientStep is the angle in degrees of the wedges that approximate a sweep gradient.
+const sweepGradientStep = 2.0
+
+// colorGlyphKey identifies a rendered color glyph of a font.
+type colorGlyphKey struct {
+	glyphID uint16
+	size    float64
+	italic  float64
+	color   color.RGBA
+}
+
+// SetPalette sets the color palette of the CPAL table that is used for color glyphs, by default the first palette is used.
+func (f *Font) SetPalette(palette int) {
+	f.colorGlyphsMu.Lock()
+	defer f.colorGlyphsMu.Unlock()
+	f.palette = palette
+	f.colorGlyphs = nil
+}
+
+// Palette returns the color palette of the CPAL table that is used for color glyphs.
+func (f *Font) Palette() int {
+	return f.palette
+}
+
+// colorGlyph returns the color glyph from the COLR, SVG, sbix, or CBDT table as a canvas in millimeters with the origin at the glyph's origin, or nil if the glyph has no color representation. The COLR table takes precedence over the SVG table, which takes precedence over bitmaps.
+func (face *FontFace) colorGlyph(glyphID uint16) *Canvas {
+	f := face.Font
+	if !f.IsColorGlyph(glyphID) {
+		return nil
+	}
+
+	key := colorGlyphKey{glyphID, face.Size, face.FauxItalic, face.Color}
+	f.colorGlyphsMu.Lock()
+	c, ok := f.colorGlyphs[key]
+	palette := f.palette
+	f.colorGlyphsMu.Unlock()
+	if ok {
+		return c
+	}
+
+	c = face.renderColorGlyph(glyphID, palette)
+	f.colorGlyphsMu.Lock()
+	if f.colorGlyphs == nil {
+		f.colorGlyphs = map[colorGlyphKey]*Canvas{}
+	}
+	f.colorGlyphs[key] = c
+	f.colorGlyphsMu.Unlock()
+	return c
+}
+
+func (face *FontFace) renderColorGlyph(glyphID uint16, palette int) *Canvas {
+	sfnt := face.Font.SFNT
+	w := face.mmPerEm * float64(sfnt.GlyphAdvance(glyphID))
+	c := New(w, face.Metrics().Ascent)
+
+	m := Identity
+	if face.FauxItalic != 0.0 {
+		m = m.Shear(face.FauxItalic, 0.0)
+	}
+
+	if paint := sfnt.GlyphPaint(glyphID); paint != nil {
+		painter := &colrPainter{
+			face:    face,
+			c:       c,
+			palette: sfnt.Palette(palette),
+		}
+		if painter.palette == nil {
+			painter.palette = sfnt.Palette(0)
+		}
+		painter.renderGlyph(glyphID, paint, m.Scale(face.mmPerEm, face.mmPerEm), 0)
+		return c
+	}
+
+	if doc, err := sfnt.GlyphSVG(glyphID); err == nil && doc != nil {
+		em := float64(sfnt.Head.UnitsPerEm)
+		if ok, err := renderSVGGlyph(c, doc, glyphID, em, face.Color, m.Scale(face.mmPerEm, -face.mmPerEm)); err == nil && ok {
+			return c
+		}
+	}
+
+	// use the largest bitmap, as the resolution of the output is unknown
+	if bitmap := sfnt.GlyphBitmap(glyphID, math.MaxUint16); bitmap != nil && bitmap.PPEM != 0 {
+		var decode func(io.Reader) (image.Image, error)
+		switch bitmap.Format {
+		case "png":
+			decode = png.Decode
+		case "jpg":
+			decode = jpeg.Decode
+		case "tiff":
+			decode = tiff.Decode
+		default:
+			return nil
+		}
+		img, err := decode(bytes.NewReader(bitmap.Data))
+		if err != nil {
+			return nil
+		}
+		if bitmap.Format == "png" {
+			img = Image{Image: img, Mimetype: "image/png", Bytes: bitmap.Data}
+		} else if bitmap.Format == "jpg" {
+			img = Image{Image: img, Mimetype: "image/jpeg", Bytes: bitmap.Data}
+		}
+		scale := face.Size / float64(bitmap.PPEM)
+		c.RenderImage(img, m.Scale(scale, scale).Translate(bitmap.Left, bitmap.Bottom))
+		return c
+	}
+	return nil
+}
+
+// colorGlyphSpans splits a text span into spans of consecutive regular glyphs and object spans for each color glyph, and returns the offsets of the spans relative to the origin of the span. It returns nil if the span has no color glyphs.
+func (span TextSpan) colorGlyphSpans() ([]TextSpan, []Point) {
+	if !span.IsText() || !span.Face.Font.hasColorGlyphs() {
+		return nil, nil
+	}
+
+	face := span.Face
+	spans, offsets := []TextSpan(nil), []Point(nil)
+	add := func(i, j int, x, y int32, c *Canvas) {
+		glyphs := span.Glyphs[i:j:j]
+		text := ""
+		for _, glyph := range glyphs {
+			text += glyph.Text
+		}
+		sub := TextSpan{
+			x:         span.x,
+			Width:     face.textWidth(glyphs),
+			Face:      face,
+			Text:      text,
+			Glyphs:    glyphs,
+			Direction: span.Direction,
+			Rotation:  span.Rotation,
+		}
+		if c != nil {
+			if span.Rotation != canvasText.NoRotation {
+				rotated := New(c.W, c.H)
+				c.RenderTo(RendererViewer{rotated, Identity.Rotate(float64(span.Rotation))})
+				c = rotated
+			}
+			sub.Objects = []TextSpanObject{{
+				Canvas: c,
+				X:      face.mmPerEm * float64(glyphs[0].XOffset),
+				Y:      face.mmPerEm * float64(glyphs[0].YOffset),
+				Width:  sub.Width,
+				Height: c.H,
+				VAlign: Baseline,
+			}}
+		}
+		spans = append(spans, sub)
+		offsets = append(offsets, Identity.Rotate(float64(span.Rotation)).Dot(Point{face.mmPerEm * float64(x), face.mmPerEm * float64(y)}))
+	}
+
+	i := 0
+	var x, y, x0, y0 int32
+	for j, glyph := range span.Glyphs {
+		if c := face.colorGlyph(glyph.ID); c != nil {
+			if i < j {
+				add(i, j, x0, y0, nil)
+			}
+			add(j, j+1, x, y, c)
+			i = j + 1
+			x0, y0 = x+glyph.XAdvance, y+glyph.YAdvance
+		}
+		x += glyph.XAdvance
+		y += glyph.YAdvance
+	}
+	if i == 0 {
+		return nil, nil
+	} else if i < len(span.Glyphs) {
+		add(i, len(span.Glyphs), x0, y0, nil)
+	}
+	return spans, offsets
+}
+
+// IsColorGlyph returns true if the span is a color glyph that is rendered as an object, see Text.WalkSpans.
+func (span *TextSpan) IsColorGlyph() bool {
+	return len(span.Objects) == 1 && len(span.Glyphs) == 1 && span.Face.Font.IsColorGlyph(span.Glyphs[0].ID)
+}
+
+// hasColorGlyphs returns true if the font has a COLR, SVG, sbix, or CBDT table.
+func (f *Font) hasColorGlyphs() bool {
+	return f.Colr != nil || f.Svg != nil || f.Sbix != nil || f.Cblc != nil
+}
+
+////////////////////////////////////////////////////////////////
+
+// colrPainter renders the paint graph of a COLR color glyph onto a canvas. Paths and gradients are in font units and the transformation matrices convert them to millimeters. If alphaOnly is set, all colors are replaced by black with the same alpha, which is used to render inverted masks.
+type colrPainter struct {
+	face      *FontFace
+	c         *Canvas
+	palette   []color.NRGBA
+	alphaOnly bool
+	bounds    *Path // area to fill for unbounded paints, in the coordinates of the canvas
+}
+
+// renderGlyph renders a color glyph, clipped by its clip box if it has one.
+func (p *colrPainter) renderGlyph(glyphID uint16, paint font.Paint, m Matrix, depth int) {
+	sfnt := p.face.Font.SFNT
+	if xmin, ymin, xmax, ymax, ok := sfnt.GlyphClipBox(glyphID); ok {
+		clip := Rectangle(float64(xmax-xmin), float64(ymax-ymin)).Translate(float64(xmin), float64(ymin))
+		p.c.PushClip(clip, NonZero, m)
+		defer p.c.PopClip()
+		if p.bounds == nil {
+			p.bounds = clip.Transform(m)
+		}
+	} else if p.bounds == nil {
+		xmin, ymin := float64(sfnt.Head.XMin), float64(sfnt.Head.YMin)
+		xmax, ymax := float64(sfnt.Head.XMax), float64(sfnt.Head.YMax)
+		p.bounds = Rectangle(xmax-xmin, ymax-ymin).Translate(xmin, ymin).Transform(m)
+	}
+	p.render(paint, m, nil, depth)
+}
+
+// color returns the palette color multiplied by alpha.
+func (p *colrPainter) color(index uint16, alpha float64) color.RGBA {
+	col := Transparent
+	if index == font.ForegroundPaletteIndex {
+		col = p.face.Color
+	} else if int(index) < len(p.palette) {
+		col = rgbaColor(p.palette[index])
+	}
+	if p.alphaOnly {
+		col = color.RGBA{0, 0, 0, col.A}
+	}
+	alpha = math.Max(0.0, math.Min(1.0, alpha))
+	return color.RGBA{
+		uint8(float64(col.R)*alpha + 0.5),
+		uint8(float64(col.G)*alpha + 0.5),
+		uint8(float64(col.B)*alpha + 0.5),
+		uint8(float64(col.A)*alpha + 0.5),
+	}
+}
+
+// colorLine returns the color stops of a color line normalized to [0,1], and the offsets of the first and last stop. It returns false if the color line has no stops.
+func (p *colrPainter) colorLine(line font.ColorLine) (Stops, float64, float64, bool) {
+	if len(line.Stops) == 0 {
+		return nil, 0.0, 0.0, false
+	}
+	t0, t1 := math.Inf(1), math.Inf(-1)
+	for _, stop := range line.Stops {
+		t0 = math.Min(t0, stop.Offset)
+		t1 = math.Max(t1, stop.Offset)
+	}
+	stops := Stops{}
+	for _, stop := range line.Stops {
+		t := 0.0
+		if t0 < t1 {
+			t = (stop.Offset - t0) / (t1 - t0)
+		}
+		stops.Add(t, p.color(stop.PaletteIndex, stop.Alpha))
+	}
+	return stops, t0, t1, true
+}
+
+func colrSpread(extend font.Extend) GradientSpread {
+	switch extend {
+	case font.ExtendRepeat:
+		return RepeatSpread
+	case font.ExtendReflect:
+		return ReflectSpread
+	}
+	return PadSpread
+}
+
+// fill fills the area, or the bounds of the glyph if area is nil, using the style.
+func (p *colrPainter) fill(area *Path, style Style, m Matrix) {
+	if area == nil {
+		if Equal(m.Det(), 0.0) {
+			return
+		}
+		area = p.bounds.Transform(m.Inv())
+	}
+	p.c.RenderPath(area, style, m)
+}
+
+// render renders a paint, where m transforms font units to the canvas and area is the region to be painted in font units, or nil if it is not bounded.
+func (p *colrPainter) render(paint font.Paint, m Matrix, area *Path, depth int) {
+	if 64 < depth {
+		return
+	}
+
+	style := DefaultStyle
+	switch paint := paint.(type) {
+	case font.PaintLayers:
+		for _, layer := range paint.Layers {
+			p.render(layer, m, area, depth+1)
+		}
+	case font.PaintSolid:
+		style.FillColor = p.color(paint.PaletteIndex, paint.Alpha)
+		p.fill(area, style, m)
+	case font.PaintLinearGradient:
+		stops, t0, t1, ok := p.colorLine(paint.ColorLine)
+		if !ok {
+			return
+		} else if t0 == t1 {
+			style.FillColor = stops[0].Color
+			p.fill(area, style, m)
+			return
+		}
+
+		// the gradient runs from p0 to p3, which is p1 projected onto the perpendicular of p0p2
+		p0, p1, p2 := Point{paint.X0, paint.Y0}, Point{paint.X1, paint.Y1}, Point{paint.X2, paint.Y2}
+		p3 := p1
+		if n := p2.Sub(p0).Rot90CCW(); !n.IsZero() {
+			p3 = p0.Add(n.Mul(p1.Sub(p0).Dot(n) / n.Dot(n)))
+		}
+		d := p3.Sub(p0)
+		gradient := NewLinearGradient(p0.Add(d.Mul(t0)), p0.Add(d.Mul(t1)))
+		gradient.Stops = stops
+		gradient.Spread = colrSpread(paint.Extend)
+		style.FillGradient = gradient
+		p.fill(area, style, m)
+	case font.PaintRadialGradient:
+		stops, t0, t1, ok := p.colorLine(paint.ColorLine)
+		if !ok {
+			return
+		} else if t0 == t1 {
+			style.FillColor = stops[0].Color
+			p.fill(area, style, m)
+			return
+		}
+
+		c0, c1 := Point{paint.X0, paint.Y0}, Point{paint.X1, paint.Y1}
+		r0 := paint.R0 + (paint.R1-paint.R0)*t0
+		r1 := paint.R0 + (paint.R1-paint.R0)*t1
+		gradient := NewRadialGradient(c0.Interpolate(c1, t0), math.Max(0.0, r0), c0.Interpolate(c1, t1), math.Max(0.0, r1))
+		gradient.Stops = stops
+		gradient.Spread = colrSpread(paint.Extend)
+		style.FillGradient = gradient
+		p.fill(area, style, m)
+	case font.PaintSweepGradient:
+		stops, t0, t1, ok := p.colorLine(paint.ColorLine)
+		if !ok || Equal(paint.StartAngle, paint.EndAngle) {
+			return
+		}
+		if area == nil {
+			if Equal(m.Det(), 0.0) {
+				return
+			}
+			area = p.bounds.Transform(m.Inv())
+		}
+
+		// approximate by wedges around the center that cover the area
+		center := Point{paint.CenterX, paint.CenterY}
+		bounds := area.Bounds()
+		r := 0.0
+		for _, corner := range []Point{{bounds.X, bounds.Y}, {bounds.X + bounds.W, bounds.Y}, {bounds.X, bounds.Y + bounds.H}, {bounds.X + bounds.W, bounds.Y + bounds.H}} {
+			r = math.Max(r, corner.Sub(center).Length())
+		}
+		r *= 1.1 // the wedge's outer edge is a chord
+
+		spread := colrSpread(paint.Extend)
+		p.c.PushClip(area, NonZero, m)
+		for a := 0.0; a < 360.0; a += sweepGradientStep {
+			t := (a + sweepGradientStep/2.0 - paint.StartAngle) / (paint.EndAngle - paint.StartAngle)
+			if t0 < t1 {
+				t = (t - t0) / (t1 - t0)
+			}
+			style.FillColor = stops.At(spread.apply(t))
+
+			// overlap wedges slightly to prevent seams
+			a0, a1 := (a-0.1)*math.Pi/180.0, (a+sweepGradientStep+0.1)*math.Pi/180.0
+			wedge := &Path{}
+			wedge.MoveTo(center.X, center.Y)
+			wedge.LineTo(center.X+r*math.Cos(a0), center.Y+r*math.Sin(a0))
+			wedge.LineTo(center.X+r*math.Cos(a1), center.Y+r*math.Sin(a1))
+			wedge.Close()
+			p.c.RenderPath(wedge, style, m)
+		}
+		p.c.PopClip()
+	case font.PaintGlyph:
+		glyph := &Path{}
+		if err := p.face.Font.GlyphPath(glyph, paint.GlyphID, 0, 0.0, 0.0, 1.0, font.NoHinting); err != nil || glyph.Empty() {
+			return
+		}
+		if area != nil {
+			p.c.PushClip(area, NonZero, m)
+			defer p.c.PopClip()
+		}
+		p.render(paint.Paint, m, glyph, depth+1)
+	case font.PaintColrGlyph:
+		if glyph := p.face.Font.GlyphPaint(paint.GlyphID); glyph != nil {
+			if area != nil {
+				p.c.PushClip(area, NonZero, m)
+				defer p.c.PopClip()
+			}
+			p.renderGlyph(paint.GlyphID, glyph, m, depth+1)
+		}
+	case font.PaintTransform:
+		t := Matrix{{paint.XX, paint.XY, paint.DX}, {paint.YX, paint.YY, paint.DY}}
+		if Equal(t.Det(), 0.0) {
+			return
+		} else if area != nil {
+			area = area.Transform(t.Inv())
+		}
+		p.render(paint.Paint, m.Mul(t), area, depth+1)
+	case font.PaintComposite:
+		if area != nil {
+			p.c.PushClip(area, NonZero, m)
+			defer p.c.PopClip()
+		}
+		p.composite(paint, m, depth+1)
+	}
+}
+
+var colrBlendModes = map[font.CompositeMode]BlendMode{
+	font.CompositeScreen:     ScreenBlend,
+	font.CompositeOverlay:    OverlayBlend,
+	font.CompositeDarken:     DarkenBlend,
+	font.CompositeLighten:    LightenBlend,
+	font.CompositeColorDodge: ColorDodgeBlend,
+	font.CompositeColorBurn:  ColorBurnBlend,
+	font.CompositeHardLight:  HardLightBlend,
+	font.CompositeSoftLight:  SoftLightBlend,
+	font.CompositeDifference: DifferenceBlend,
+	font.CompositeExclusion:  ExclusionBlend,
+	font.CompositeMultiply:   MultiplyBlend,
+	font.CompositeHue:        HueBlend,
+	font.CompositeSaturation: SaturationBlend,
+	font.CompositeColor:      ColorBlend,
+	font.CompositeLuminosity: LuminosityBlend,
+}
+
+// composite renders a composite paint. Porter-Duff operators are implemented using soft masks and blend modes using transparency groups, while the plus operator is approximated by source-over.
+func (p *colrPainter) composite(paint font.PaintComposite, m Matrix, depth int) {
+	switch paint.Mode {
+	case font.CompositeClear:
+	case font.CompositeSrc:
+		p.render(paint.Source, m, nil, depth)
+	case font.CompositeDest:
+		p.render(paint.Backdrop, m, nil, depth)
+	case font.CompositeDestOver:
+		p.render(paint.Source, m, nil, depth)
+		p.render(paint.Backdrop, m, nil, depth)
+	case font.CompositeSrcIn:
+		p.masked(paint.Source, paint.Backdrop, false, m, depth)
+	case font.CompositeDestIn:
+		p.masked(paint.Backdrop, paint.Source, false, m, depth)
+	case font.CompositeSrcOut:
+		p.masked(paint.Source, paint.Backdrop, true, m, depth)
+	case font.CompositeDestOut:
+		p.masked(paint.Backdrop, paint.Source, true, m, depth)
+	case font.CompositeSrcAtop:
+		p.render(paint.Backdrop, m, nil, depth)
+		p.masked(paint.Source, paint.Backdrop, false, m, depth)
+	case font.CompositeDestAtop:
+		p.render(paint.Source, m, nil, depth)
+		p.masked(paint.Backdrop, paint.Source, false, m, depth)
+	case font.CompositeXor:
+		p.masked(paint.Source, paint.Backdrop, true, m, depth)
+		p.masked(paint.Backdrop, paint.Source, true, m, depth)
+	case font.CompositeScreen, font.CompositeOverlay, font.CompositeDarken, font.CompositeLighten, font.CompositeColorDodge, font.CompositeColorBurn, font.CompositeHardLight, font.CompositeSoftLight, font.CompositeDifference, font.CompositeExclusion, font.CompositeMultiply, font.CompositeHue, font.CompositeSaturation, font.CompositeColor, font.CompositeLuminosity:
+		blendMode := colrBlendModes[paint.Mode]
+		p.c.PushGroup(Group{Opacity: 1.0}, Identity)
+		p.render(paint.Backdrop, m, nil, depth)
+		p.c.PushGroup(Group{Opacity: 1.0, BlendMode: blendMode}, Identity)
+		p.render(paint.Source, m, nil, depth)
+		p.c.PopGroup()
+		p.c.PopGroup()
+	default: // source-over and plus
+		p.render(paint.Backdrop, m, nil, depth)
+		p.render(paint.Source, m, nil, depth)
+	}
+}
+
+// masked renders a paint masked by the alpha of another paint, or by its inverted alpha.
+func (p *colrPainter) masked(paint, mask font.Paint, invert bool, m Matrix, depth int) {
+	maskCanvas := New(p.c.W, p.c.H)
+	maskPainter := &colrPainter{
+		face:      p.face,
+		c:         maskCanvas,
+		palette:   p.palette,
+		alphaOnly: invert,
+		bounds:    p.bounds,
+	}
+	maskType := AlphaMask
+	if invert {
+		// the luminosity of black content with the mask's alpha on a white background is the inverted alpha
+		style := DefaultStyle
+		style.FillColor = White
+		maskCanvas.RenderPath(p.bounds, style, Identity)
+		maskType = LuminosityMask
+	}
+	maskPainter.render(mask, m, nil, depth)
+
+	p.c.PushGroup(Group{Opacity: 1.0, Mask: maskCanvas, MaskType: maskType}, Identity)
+	p.render(paint, m, nil, depth)
+	p.c.PopGroup()
+}
@@ -0,0 +1,141 @@
+package canvas
+
+import (
+	"image/color"
+	"io/ioutil"
+	"strconv"
+	"testing"
+
+	"github.com/LaminoidStudio/Canvas/font"
+	"github.com/tdewolff/test"
+)
+
+// recordedFills returns the fill colors of the paths of a recorder.
+func recordedFills(r *Recorder) []color.RGBA {
+	fills := []color.RGBA{}
+	for _, op := range r.Ops {
+		if op.Op == "path" {
+			fills = append(fills, op.Style.FillColor)
+		}
+	}
+	return fills
+}
+
+// colorTestFont returns DejaVu Serif where A has the layers B and C in the first or second palette color, and G is an SVG glyph.
+func colorTestFont(t *testing.T) *Font {
+	b, err := ioutil.ReadFile("resources/DejaVuSerif.ttf")
+	test.Error(t, err)
+	sfnt, err := font.ParseSFNT(b, 0)
+	test.Error(t, err)
+	A, B, C, G := sfnt.GlyphIndex('A'), sfnt.GlyphIndex('B'), sfnt.GlyphIndex('C'), sfnt.GlyphIndex('G')
+
+	w := font.NewBinaryWriter([]byte{})
+	w.WriteUint16(0)  // version
+	w.WriteUint16(2)  // numPaletteEntries
+	w.WriteUint16(2)  // numPalettes
+	w.WriteUint16(4)  // numColorRecords
+	w.WriteUint32(16) // colorRecordsArrayOffset
+	w.WriteUint16(0)  // colorRecordIndices
+	w.WriteUint16(2)
+	w.WriteBytes([]byte{0, 0, 255, 255, 255, 0, 0, 255, 0, 255, 0, 255, 0, 255, 255, 255}) // BGRA
+	sfnt.Tables["CPAL"] = w.Bytes()
+
+	w = font.NewBinaryWriter([]byte{})
+	w.WriteUint16(0)  // version
+	w.WriteUint16(1)  // numBaseGlyphRecords
+	w.WriteUint32(14) // baseGlyphRecordsOffset
+	w.WriteUint32(20) // layerRecordsOffset
+	w.WriteUint16(2)  // numLayerRecords
+	w.WriteUint16(A)
+	w.WriteUint16(0)
+	w.WriteUint16(2)
+	w.WriteUint16(B)
+	w.WriteUint16(0)
+	w.WriteUint16(C)
+	w.WriteUint16(1)
+	sfnt.Tables["COLR"] = w.Bytes()
+
+	doc := []byte(`<svg xmlns="http://www.w3.org/2000/svg"><g fill="currentColor"><rect id="glyph` + strconv.Itoa(int(G)) + `" y="-1000" width="1000" height="1000"/></g></svg>`)
+	w = font.NewBinaryWriter([]byte{})
+	w.WriteUint16(0)  // version
+	w.WriteUint32(10) // svgDocumentListOffset
+	w.WriteUint32(0)  // reserved
+	w.WriteUint16(1)  // numEntries
+	w.WriteUint16(G)
+	w.WriteUint16(G)
+	w.WriteUint32(14)
+	w.WriteUint32(uint32(len(doc)))
+	w.WriteBytes(doc)
+	sfnt.Tables["SVG "] = w.Bytes()
+
+	f, err := LoadFont(sfnt.Write(), 0, FontRegular)
+	test.Error(t, err)
+	return f
+}
+
+func TestColorGlyphSpans(t *testing.T) {
+	f := colorTestFont(t)
+	face := f.Face(12.0, Black)
+
+	xs, texts := []float64{}, []string{}
+	objects := 0
+	text := NewTextLine(face, "xAxx", Left)
+	text.WalkSpans(func(x, y float64, span TextSpan) {
+		xs = append(xs, x)
+		texts = append(texts, span.Text)
+		if span.IsColorGlyph() {
+			objects++
+			test.T(t, span.Objects[0].Canvas, face.colorGlyph(f.GlyphIndex('A')))
+		}
+	})
+	test.T(t, texts, []string{"x", "A", "xx"})
+	test.T(t, objects, 1)
+	test.Float(t, xs[0], 0.0)
+	test.Float(t, xs[1], face.TextWidth("x"))
+	test.Float(t, xs[2], face.TextWidth("xA"))
+
+	// regular glyphs are not split
+	texts = texts[:0]
+	NewTextLine(face, "xyz", Left).WalkSpans(func(x, y float64, span TextSpan) {
+		texts = append(texts, span.Text)
+	})
+	test.T(t, texts, []string{"xyz"})
+}
+
+func TestColorGlyphCOLR(t *testing.T) {
+	f := colorTestFont(t)
+	face := f.Face(12.0, Black)
+
+	r := &Recorder{}
+	face.colorGlyph(f.GlyphIndex('A')).RenderTo(r)
+	test.T(t, recordedFills(r), []color.RGBA{{255, 0, 0, 255}, {0, 0, 255, 255}})
+
+	f.SetPalette(1)
+	r = &Recorder{}
+	face.colorGlyph(f.GlyphIndex('A')).RenderTo(r)
+	test.T(t, recordedFills(r), []color.RGBA{{0, 255, 0, 255}, {255, 255, 0, 255}})
+	test.T(t, face.colorGlyph(f.GlyphIndex('B')), (*Canvas)(nil))
+
+	// the text is rendered with the color glyph's layers
+	r = &Recorder{}
+	NewTextLine(face, "xA", Left).RenderAsPath(r, Identity, DefaultResolution)
+	test.T(t, recordedFills(r), []color.RGBA{Black, {0, 255, 0, 255}, {255, 255, 0, 255}})
+}
+
+func TestColorGlyphSVG(t *testing.T) {
+	f := colorTestFont(t)
+	face := f.Face(12.0, Red)
+
+	c := face.colorGlyph(f.GlyphIndex('G'))
+	test.That(t, c != nil)
+	r := &Recorder{}
+	c.RenderTo(r)
+	test.T(t, recordedFills(r), []color.RGBA{Red})
+
+	// the glyph covers the em square above the baseline, as the y-axis of SVG glyphs points down
+	bounds := r.Ops[0].Path.Bounds().Transform(r.Ops[0].Matrix)
+	test.Float(t, bounds.X, 0.0)
+	test.Float(t, bounds.Y, 0.0)
+	test.Float(t, bounds.W, face.mmPerEm*1000.0)
+	test.Float(t, bounds.H, face.mmPerEm*1000.0)
+}
@@ -3,6 +3,7 @@ package canvas
 import (
 	"testing"
 
+	"github.com/LaminoidStudio/Canvas/font"
 	"github.com/tdewolff/test"
 )
 
@@ -54,6 +55,48 @@ func TestFontFace(t *testing.T) {
 	//test.Float(t, width, 18.515625)
 }
 
+func TestFontFacePPEM(t *testing.T) {
+	family := NewFontFamily("dejavu-serif")
+	if err := family.LoadFontFile("resources/DejaVuSerif.ttf", FontRegular); err != nil {
+		test.Error(t, err)
+	}
+	face := family.Face(12.0, Black, FontRegular, FontNormal)
+	test.T(t, face.PPEM(DPI(96.0)), uint16(16))
+	test.T(t, face.PPEM(DPI(72.0)), uint16(12))
+	test.T(t, face.PPEM(DPI(300.0)), uint16(50))
+
+	// the layout of unhinted text does not depend on the resolution
+	test.T(t, face.shapingPPEM(), uint16(0))
+	face.Hinting = font.FontHinting
+	test.T(t, face.shapingPPEM(), uint16(16))
+}
+
+func TestFontVariations(t *testing.T) {
+	f, err := LoadFontFile("resources/AdobeVFPrototype.otf", FontRegular)
+	test.Error(t, err)
+	pt := ptPerMm * float64(f.Head.UnitsPerEm)
+	face := f.Face(pt, Black)
+	test.Float(t, face.TextWidth("A"), 663)
+	p, _, err := face.ToPath("A")
+	test.Error(t, err)
+	test.Float(t, p.Bounds().Y+p.Bounds().H, 675)
+
+	// shaping and outlines use the variations
+	f.SetVariations("wght=900")
+	test.Float(t, face.TextWidth("A"), 680)
+	p, _, err = face.ToPath("A")
+	test.Error(t, err)
+	test.Float(t, p.Bounds().Y+p.Bounds().H, 652)
+
+	test.Error(t, f.SetNamedInstance(0)) // ExtraLight
+	test.T(t, f.variations, "wght=200,CNTR=0")
+	test.Float(t, face.TextWidth("A"), float64(f.GlyphAdvance(f.GlyphIndex('A'))))
+	test.That(t, f.SetNamedInstance(8) != nil)
+
+	f.SetVariations("")
+	test.Float(t, face.TextWidth("A"), 663)
+}
+
 func TestFontDecoration(t *testing.T) {
 	family := NewFontFamily("dejavu-serif")
 	if err := family.LoadFontFile("resources/DejaVuSerif.ttf", FontRegular); err != nil {
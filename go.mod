@@ -3,15 +3,14 @@ module github.com/LaminoidStudio/Canvas
 go 1.20
 
 require (
-	github.com/ByteArena/poly2tri-go v0.0.0-20170716161910-d102ad91854f
 	github.com/adrg/sysfont v0.1.2
+	github.com/andybalholm/brotli v1.0.5
 	github.com/benoitkugler/textlayout v0.3.0
 	github.com/benoitkugler/textprocessing v0.0.2
 	github.com/dsnet/compress v0.0.1
 	github.com/go-fonts/latin-modern v0.2.0
 	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0
 	github.com/tdewolff/argp v0.0.0-20221007181215-ebef9ed8a560
-	github.com/tdewolff/minify v2.3.6+incompatible
 	github.com/tdewolff/minify/v2 v2.12.4
 	github.com/tdewolff/parse/v2 v2.6.5
 	github.com/tdewolff/test v1.0.7
@@ -30,5 +29,4 @@ require (
 	github.com/go-fonts/liberation v0.2.0 // indirect
 	github.com/go-latex/latex v0.0.0-20210823091927-c0d11ff05a81 // indirect
 	github.com/go-pdf/fpdf v0.6.0 // indirect
-	github.com/tdewolff/parse v2.3.4+incompatible // indirect
 )
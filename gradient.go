@@ -0,0 +1,337 @@
+package canvas
+
+import (
+	"image/color"
+	"math"
+	"sort"
+)
+
+// GradientSpread defines how a gradient continues outside of its defined range of [0,1], see https://www.w3.org/TR/SVG11/pservers.html#LinearGradientElementSpreadMethodAttribute.
+type GradientSpread int
+
+// see GradientSpread
+const (
+	PadSpread GradientSpread = iota
+	ReflectSpread
+	RepeatSpread
+)
+
+func (spread GradientSpread) String() string {
+	switch spread {
+	case ReflectSpread:
+		return "Reflect"
+	case RepeatSpread:
+		return "Repeat"
+	}
+	return "Pad"
+}
+
+// apply maps the gradient parameter t to [0,1].
+func (spread GradientSpread) apply(t float64) float64 {
+	switch spread {
+	case ReflectSpread:
+		t = math.Mod(math.Abs(t), 2.0)
+		if 1.0 < t {
+			t = 2.0 - t
+		}
+	case RepeatSpread:
+		t -= math.Floor(t)
+	default:
+		t = math.Max(0.0, math.Min(1.0, t))
+	}
+	return t
+}
+
+// Stop is a color stop of a gradient at an offset between 0 and 1.
+type Stop struct {
+	Offset float64
+	Color  color.RGBA
+}
+
+// Stops is a list of color stops sorted by offset.
+type Stops []Stop
+
+// Add adds a color stop at the given offset, which is clipped to [0,1]. Stops at equal offsets are kept in the order they were added, which allows for sharp color transitions.
+func (stops *Stops) Add(offset float64, col color.Color) {
+	offset = math.Max(0.0, math.Min(1.0, offset))
+	stop := Stop{offset, rgbaColor(col)}
+	i := sort.Search(len(*stops), func(i int) bool {
+		return offset < (*stops)[i].Offset
+	})
+	*stops = append(*stops, Stop{})
+	copy((*stops)[i+1:], (*stops)[i:])
+	(*stops)[i] = stop
+}
+
+// At returns the interpolated color at offset t.
+func (stops Stops) At(t float64) color.RGBA {
+	if len(stops) == 0 {
+		return Transparent
+	} else if t <= stops[0].Offset {
+		return stops[0].Color
+	} else if stops[len(stops)-1].Offset <= t {
+		return stops[len(stops)-1].Color
+	}
+	for i, stop := range stops[1:] {
+		if t < stop.Offset {
+			t = (t - stops[i].Offset) / (stop.Offset - stops[i].Offset)
+			return colorLerp(stops[i].Color, stop.Color, t)
+		}
+	}
+	return stops[len(stops)-1].Color
+}
+
+// IsOpaque returns true if all color stops are opaque.
+func (stops Stops) IsOpaque() bool {
+	for _, stop := range stops {
+		if stop.Color.A != 255 {
+			return false
+		}
+	}
+	return true
+}
+
+// Average returns the average color of the stops, which is useful for renderers that do not support gradients.
+func (stops Stops) Average() color.RGBA {
+	if len(stops) == 0 {
+		return Transparent
+	} else if len(stops) == 1 {
+		return stops[0].Color
+	}
+	var r, g, b, a float64
+	for i, stop := range stops[1:] {
+		w := stop.Offset - stops[i].Offset
+		r += w * (float64(stops[i].Color.R) + float64(stop.Color.R)) / 2.0
+		g += w * (float64(stops[i].Color.G) + float64(stop.Color.G)) / 2.0
+		b += w * (float64(stops[i].Color.B) + float64(stop.Color.B)) / 2.0
+		a += w * (float64(stops[i].Color.A) + float64(stop.Color.A)) / 2.0
+	}
+	w := stops[len(stops)-1].Offset - stops[0].Offset
+	if w == 0.0 {
+		return stops[0].Color
+	}
+	return color.RGBA{uint8(r/w + 0.5), uint8(g/w + 0.5), uint8(b/w + 0.5), uint8(a/w + 0.5)}
+}
+
+// spread returns the stops for the range [n0,n1] of gradient parameters, where n0 and n1 are integers, by repeating or reflecting the stops. The returned offsets are normalized to [0,1].
+func (stops Stops) spread(spread GradientSpread, n0, n1 int) Stops {
+	if spread == PadSpread || n1-n0 <= 1 && n0 == 0 {
+		return stops
+	}
+	n := float64(n1 - n0)
+	spreaded := make(Stops, 0, (n1-n0)*len(stops))
+	for k := n0; k < n1; k++ {
+		if spread == ReflectSpread && (k%2+2)%2 == 1 {
+			for i := len(stops) - 1; 0 <= i; i-- {
+				spreaded = append(spreaded, Stop{(float64(k-n0) + 1.0 - stops[i].Offset) / n, stops[i].Color})
+			}
+		} else {
+			for _, stop := range stops {
+				spreaded = append(spreaded, Stop{(float64(k-n0) + stop.Offset) / n, stop.Color})
+			}
+		}
+	}
+	return spreaded
+}
+
+// Gradient is a paint that varies its color over the plane. Gradients are defined in the same coordinate system as the path they paint, and thus transform along with the path.
+type Gradient interface {
+	// At returns the color at position (x,y).
+	At(float64, float64) color.RGBA
+
+	// GradientStops returns the color stops of the gradient.
+	GradientStops() Stops
+
+	// Padded returns an equivalent gradient using PadSpread that covers the given rectangle. This is used by renderers that do not support the reflect and repeat spread methods natively.
+	Padded(Rect) Gradient
+}
+
+// LinearGradient is a gradient that varies its color along the line from Start to End. The color is constant on lines perpendicular to it.
+type LinearGradient struct {
+	Start, End Point
+	Stops
+	Spread GradientSpread
+}
+
+// NewLinearGradient returns a new linear gradient from start to end. Add color stops using Add.
+func NewLinearGradient(start, end Point) *LinearGradient {
+	return &LinearGradient{
+		Start: start,
+		End:   end,
+	}
+}
+
+// GradientStops returns the color stops of the gradient.
+func (g *LinearGradient) GradientStops() Stops {
+	return g.Stops
+}
+
+func (g *LinearGradient) t(p Point) float64 {
+	d := g.End.Sub(g.Start)
+	if Equal(d.X, 0.0) && Equal(d.Y, 0.0) {
+		return 1.0
+	}
+	return p.Sub(g.Start).Dot(d) / d.Dot(d)
+}
+
+// At returns the color at position (x,y).
+func (g *LinearGradient) At(x, y float64) color.RGBA {
+	return g.Stops.At(g.Spread.apply(g.t(Point{x, y})))
+}
+
+// Padded returns an equivalent gradient using PadSpread that covers the given rectangle.
+func (g *LinearGradient) Padded(rect Rect) Gradient {
+	if g.Spread == PadSpread {
+		return g
+	}
+	t0, t1 := math.Inf(1), math.Inf(-1)
+	for _, p := range rectCorners(rect) {
+		t := g.t(p)
+		t0 = math.Min(t0, t)
+		t1 = math.Max(t1, t)
+	}
+	n0, n1 := int(math.Floor(math.Min(t0, 0.0))), int(math.Ceil(math.Max(t1, 1.0)))
+	d := g.End.Sub(g.Start)
+	return &LinearGradient{
+		Start:  g.Start.Add(d.Mul(float64(n0))),
+		End:    g.Start.Add(d.Mul(float64(n1))),
+		Stops:  g.Stops.spread(g.Spread, n0, n1),
+		Spread: PadSpread,
+	}
+}
+
+// RadialGradient is a two-point conical gradient that varies its color between the start circle (C0,R0) and the end circle (C1,R1), see https://www.w3.org/TR/2dcontext/#dom-context-2d-createradialgradient. When C0 equals C1 and R0 is zero this is the usual circular gradient.
+type RadialGradient struct {
+	C0 Point
+	R0 float64
+	C1 Point
+	R1 float64
+	Stops
+	Spread GradientSpread
+}
+
+// NewRadialGradient returns a new radial gradient between the circles at c0 with radius r0 and at c1 with radius r1. Add color stops using Add.
+func NewRadialGradient(c0 Point, r0 float64, c1 Point, r1 float64) *RadialGradient {
+	return &RadialGradient{
+		C0: c0,
+		R0: r0,
+		C1: c1,
+		R1: r1,
+	}
+}
+
+// GradientStops returns the color stops of the gradient.
+func (g *RadialGradient) GradientStops() Stops {
+	return g.Stops
+}
+
+// t returns the largest t for which p lies on the circle interpolated between the start and end circles, and whether such a circle with a non-negative radius exists.
+func (g *RadialGradient) t(p Point) (float64, bool) {
+	cd := g.C1.Sub(g.C0)
+	pd := p.Sub(g.C0)
+	dr := g.R1 - g.R0
+	a := cd.Dot(cd) - dr*dr
+	b := pd.Dot(cd) + g.R0*dr
+	c := pd.Dot(pd) - g.R0*g.R0
+	if Equal(a, 0.0) {
+		if Equal(b, 0.0) {
+			return 0.0, false
+		}
+		t := c / (2.0 * b)
+		return t, 0.0 <= g.R0+t*dr
+	}
+	discriminant := b*b - a*c
+	if discriminant < 0.0 {
+		return 0.0, false
+	}
+	discriminant = math.Sqrt(discriminant)
+	t0, t1 := (b+discriminant)/a, (b-discriminant)/a
+	if t0 < t1 {
+		t0, t1 = t1, t0
+	}
+	if 0.0 <= g.R0+t0*dr {
+		return t0, true
+	} else if 0.0 <= g.R0+t1*dr {
+		return t1, true
+	}
+	return 0.0, false
+}
+
+// At returns the color at position (x,y).
+func (g *RadialGradient) At(x, y float64) color.RGBA {
+	t, ok := g.t(Point{x, y})
+	if !ok {
+		return Transparent
+	}
+	return g.Stops.At(g.Spread.apply(t))
+}
+
+// Padded returns an equivalent gradient using PadSpread that covers the given rectangle.
+func (g *RadialGradient) Padded(rect Rect) Gradient {
+	if g.Spread == PadSpread {
+		return g
+	}
+	dr := g.R1 - g.R0
+	t0, t1 := 0.0, 1.0
+	for _, p := range rectCorners(rect) {
+		if t, ok := g.t(p); ok {
+			t0 = math.Min(t0, t)
+			t1 = math.Max(t1, t)
+		}
+	}
+	n0, n1 := int(math.Floor(t0)), int(math.Ceil(t1))
+	if 0.0 < dr {
+		// circles grow with t, the interior is covered down to the circle of zero radius
+		n0 = -int(math.Floor(g.R0 / dr))
+	} else if dr < 0.0 {
+		// circles shrink with t, the interior is covered up to the circle of zero radius
+		n1 = int(math.Floor(g.R0 / -dr))
+	}
+	if n1 <= n0 {
+		n1 = n0 + 1
+	}
+	cd := g.C1.Sub(g.C0)
+	return &RadialGradient{
+		C0:     g.C0.Add(cd.Mul(float64(n0))),
+		R0:     g.R0 + dr*float64(n0),
+		C1:     g.C0.Add(cd.Mul(float64(n1))),
+		R1:     g.R0 + dr*float64(n1),
+		Stops:  g.Stops.spread(g.Spread, n0, n1),
+		Spread: PadSpread,
+	}
+}
+
+func rectCorners(rect Rect) []Point {
+	return []Point{
+		{rect.X, rect.Y},
+		{rect.X + rect.W, rect.Y},
+		{rect.X + rect.W, rect.Y + rect.H},
+		{rect.X, rect.Y + rect.H},
+	}
+}
+
+// colorLerp linearly interpolates between two alpha-premultiplied colors.
+func colorLerp(a, b color.RGBA, t float64) color.RGBA {
+	return color.RGBA{
+		uint8(float64(a.R) + t*(float64(b.R)-float64(a.R)) + 0.5),
+		uint8(float64(a.G) + t*(float64(b.G)-float64(a.G)) + 0.5),
+		uint8(float64(a.B) + t*(float64(b.B)-float64(a.B)) + 0.5),
+		uint8(float64(a.A) + t*(float64(b.A)-float64(a.A)) + 0.5),
+	}
+}
+
+// rgbaColor converts any color to an alpha-premultiplied color.RGBA where the color components do not exceed alpha.
+func rgbaColor(col color.Color) color.RGBA {
+	r, g, b, a := col.RGBA()
+	// RGBA returns an alpha-premultiplied color so that c <= a. We silently correct the color by clipping r,g,b to a
+	if a < r {
+		r = a
+	}
+	if a < g {
+		g = a
+	}
+	if a < b {
+		b = a
+	}
+	return color.RGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), uint8(a >> 8)}
+}
@@ -0,0 +1,101 @@
+package canvas
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/tdewolff/test"
+)
+
+func TestGradientStops(t *testing.T) {
+	stops := Stops{}
+	stops.Add(1.0, White)
+	stops.Add(0.0, Black)
+	stops.Add(0.5, Red)
+	test.T(t, len(stops), 3)
+	test.T(t, stops[0].Color, Black)
+	test.T(t, stops[1].Color, Red)
+	test.T(t, stops[2].Color, White)
+
+	var tts = []struct {
+		t   float64
+		col color.RGBA
+	}{
+		{-1.0, Black},
+		{0.0, Black},
+		{0.25, color.RGBA{128, 0, 0, 255}},
+		{0.5, Red},
+		{0.75, color.RGBA{255, 128, 128, 255}},
+		{2.0, White},
+	}
+	for _, tt := range tts {
+		t.Run(num(tt.t).String(), func(t *testing.T) {
+			test.T(t, stops.At(tt.t), tt.col)
+		})
+	}
+}
+
+func TestGradientSpread(t *testing.T) {
+	var tts = []struct {
+		spread GradientSpread
+		t      float64
+		r      float64
+	}{
+		{PadSpread, -0.5, 0.0},
+		{PadSpread, 1.5, 1.0},
+		{RepeatSpread, 1.25, 0.25},
+		{RepeatSpread, -0.25, 0.75},
+		{ReflectSpread, 1.25, 0.75},
+		{ReflectSpread, -0.25, 0.25},
+		{ReflectSpread, 2.25, 0.25},
+	}
+	for _, tt := range tts {
+		t.Run(tt.spread.String(), func(t *testing.T) {
+			test.Float(t, tt.spread.apply(tt.t), tt.r)
+		})
+	}
+}
+
+func TestLinearGradient(t *testing.T) {
+	g := NewLinearGradient(Point{0.0, 0.0}, Point{10.0, 0.0})
+	g.Add(0.0, Black)
+	g.Add(1.0, White)
+	test.T(t, g.At(-5.0, 3.0), Black)
+	test.T(t, g.At(5.0, 3.0), color.RGBA{128, 128, 128, 255})
+	test.T(t, g.At(15.0, 3.0), White)
+
+	g.Spread = RepeatSpread
+	test.T(t, g.At(15.0, 3.0), color.RGBA{128, 128, 128, 255})
+
+	padded := g.Padded(Rect{-5.0, 0.0, 30.0, 10.0}).(*LinearGradient)
+	test.T(t, padded.Spread, PadSpread)
+	test.T(t, padded.Start, Point{-10.0, 0.0})
+	test.T(t, padded.End, Point{30.0, 0.0})
+	test.T(t, len(padded.Stops), 8)
+	test.T(t, padded.At(15.0, 3.0), g.At(15.0, 3.0))
+	test.T(t, padded.At(-2.5, 3.0), g.At(-2.5, 3.0))
+}
+
+func TestRadialGradient(t *testing.T) {
+	g := NewRadialGradient(Point{0.0, 0.0}, 0.0, Point{0.0, 0.0}, 10.0)
+	g.Add(0.0, Black)
+	g.Add(1.0, White)
+	test.T(t, g.At(0.0, 0.0), Black)
+	test.T(t, g.At(0.0, 5.0), color.RGBA{128, 128, 128, 255})
+	test.T(t, g.At(-20.0, 0.0), White)
+
+	g.Spread = ReflectSpread
+	test.T(t, g.At(-12.5, 0.0), color.RGBA{191, 191, 191, 255})
+
+	padded := g.Padded(Rect{-20.0, -20.0, 40.0, 40.0}).(*RadialGradient)
+	test.T(t, padded.R0, 0.0)
+	test.T(t, padded.R1, 30.0)
+	test.T(t, padded.At(-12.5, 0.0), g.At(-12.5, 0.0))
+
+	// focal point outside of the end circle
+	g = NewRadialGradient(Point{20.0, 0.0}, 0.0, Point{0.0, 0.0}, 10.0)
+	g.Add(0.0, Black)
+	g.Add(1.0, White)
+	test.T(t, g.At(0.0, 20.0), Transparent)
+	test.T(t, g.At(-10.0, 0.0), White)
+}
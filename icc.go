@@ -0,0 +1,484 @@
+package canvas
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// ICCProfile is an ICC color profile that converts colors in its color space to sRGB. Display profiles with matrices and tone reproduction curves, and profiles with lookup tables (lut8, lut16, and lutAtoB) are supported, which covers most RGB, gray, and CMYK profiles.
+type ICCProfile struct {
+	Data       []byte // original profile, which is embedded in documents
+	ColorSpace string // data color space signature, such as "RGB ", "GRAY", or "CMYK"
+	Components int
+
+	pcsLab bool
+	trc    []iccCurve // tone reproduction curves of matrix/TRC profiles
+	matrix [3][3]float64
+	lut    *iccLUT
+}
+
+// ParseICCProfile parses an ICC color profile.
+func ParseICCProfile(b []byte) (*ICCProfile, error) {
+	if len(b) < 132 || string(b[36:40]) != "acsp" {
+		return nil, fmt.Errorf("bad ICC profile")
+	}
+
+	p := &ICCProfile{
+		Data:       b,
+		ColorSpace: string(b[16:20]),
+	}
+	switch p.ColorSpace {
+	case "GRAY":
+		p.Components = 1
+	case "RGB ", "Lab ", "XYZ ", "YCbr", "Yxy ", "HSV ", "HLS ", "CMY ":
+		p.Components = 3
+	case "CMYK":
+		p.Components = 4
+	default:
+		if '2' <= b[16] && b[16] <= '9' && string(b[17:20]) == "CLR" {
+			p.Components = int(b[16] - '0')
+		} else if 'A' <= b[16] && b[16] <= 'F' && string(b[17:20]) == "CLR" {
+			p.Components = int(b[16]-'A') + 10
+		} else {
+			return nil, fmt.Errorf("unsupported ICC color space %q", p.ColorSpace)
+		}
+	}
+	switch string(b[20:24]) {
+	case "XYZ ":
+	case "Lab ":
+		p.pcsLab = true
+	default:
+		return nil, fmt.Errorf("bad ICC profile connection space")
+	}
+
+	tags := map[string][]byte{}
+	n := int(binary.BigEndian.Uint32(b[128:]))
+	if len(b) < 132+12*n {
+		return nil, fmt.Errorf("bad ICC tag table")
+	}
+	for i := 0; i < n; i++ {
+		entry := b[132+12*i:]
+		offset, size := binary.BigEndian.Uint32(entry[4:]), binary.BigEndian.Uint32(entry[8:])
+		if uint32(len(b)) < offset || uint32(len(b))-offset < size || size < 8 {
+			return nil, fmt.Errorf("bad ICC tag")
+		}
+		tags[string(entry[:4])] = b[offset : offset+size]
+	}
+
+	if data, ok := tags["A2B0"]; ok {
+		lut, err := parseICCLUT(data)
+		if err != nil {
+			return nil, err
+		} else if lut.inputs != p.Components || lut.outputs != 3 {
+			return nil, fmt.Errorf("bad ICC lookup table channels")
+		}
+		lut.legacyLab = p.pcsLab && string(data[:4]) == "mft2"
+		p.lut = lut
+		return p, nil
+	}
+
+	if p.ColorSpace == "GRAY" {
+		curve, err := parseICCCurve(tags["kTRC"])
+		if err != nil {
+			return nil, err
+		}
+		p.trc = []iccCurve{curve}
+		return p, nil
+	} else if p.ColorSpace == "RGB " && !p.pcsLab {
+		for i, c := range []string{"r", "g", "b"} {
+			curve, err := parseICCCurve(tags[c+"TRC"])
+			if err != nil {
+				return nil, err
+			}
+			p.trc = append(p.trc, curve)
+
+			xyz := tags[c+"XYZ"]
+			if len(xyz) < 20 || string(xyz[:4]) != "XYZ " {
+				return nil, fmt.Errorf("bad ICC colorant tag")
+			}
+			for j := 0; j < 3; j++ {
+				p.matrix[j][i] = iccFixed(xyz[8+4*j:])
+			}
+		}
+		return p, nil
+	}
+	return nil, fmt.Errorf("unsupported ICC profile: no lookup table or tone reproduction curves")
+}
+
+// ToXYZ converts color components between 0 and 1 to the CIE XYZ color space relative to the D50 illuminant.
+func (p *ICCProfile) ToXYZ(c []float64) (float64, float64, float64) {
+	in := make([]float64, p.Components)
+	for i := range in {
+		if i < len(c) {
+			in[i] = math.Max(0.0, math.Min(1.0, c[i]))
+		}
+	}
+
+	if p.lut != nil {
+		out := p.lut.Eval(in)
+		if p.pcsLab {
+			f := 1.0
+			if p.lut.legacyLab {
+				f = 65535.0 / 65280.0
+			}
+			return labToXYZ(out[0]*f*100.0, out[1]*f*255.0-128.0, out[2]*f*255.0-128.0)
+		}
+		f := 65535.0 / 32768.0
+		return out[0] * f, out[1] * f, out[2] * f
+	} else if len(p.trc) == 1 {
+		y := p.trc[0].Eval(in[0])
+		return y * iccD50[0], y, y * iccD50[2]
+	}
+
+	var xyz [3]float64
+	for i := 0; i < 3; i++ {
+		v := p.trc[i].Eval(in[i])
+		for j := 0; j < 3; j++ {
+			xyz[j] += p.matrix[j][i] * v
+		}
+	}
+	return xyz[0], xyz[1], xyz[2]
+}
+
+// ToRGB converts color components between 0 and 1 to sRGB using the relative colorimetric intent.
+func (p *ICCProfile) ToRGB(c []float64) (float64, float64, float64) {
+	x, y, z := p.ToXYZ(c)
+	return xyzToSRGB(x, y, z)
+}
+
+// iccD50 is the white point of the profile connection space.
+var iccD50 = [3]float64{0.9642, 1.0, 0.8249}
+
+// xyzToSRGB converts XYZ relative to D50 to sRGB, using the Bradford chromatic adaptation to D65.
+func xyzToSRGB(x, y, z float64) (float64, float64, float64) {
+	r := 3.1338561*x - 1.6168667*y - 0.4906146*z
+	g := -0.9787684*x + 1.9161415*y + 0.0334540*z
+	b := 0.0719453*x - 0.2289914*y + 1.4052427*z
+	gamma := func(v float64) float64 {
+		v = math.Max(0.0, math.Min(1.0, v))
+		if v <= 0.0031308 {
+			return 12.92 * v
+		}
+		return 1.055*math.Pow(v, 1.0/2.4) - 0.055
+	}
+	return gamma(r), gamma(g), gamma(b)
+}
+
+// labToXYZ converts CIE L*a*b* to XYZ relative to D50.
+func labToXYZ(l, a, b float64) (float64, float64, float64) {
+	fy := (l + 16.0) / 116.0
+	fx := fy + a/500.0
+	fz := fy - b/200.0
+	f := func(t float64) float64 {
+		if 6.0/29.0 < t {
+			return t * t * t
+		}
+		return 3.0 * (6.0 / 29.0) * (6.0 / 29.0) * (t - 4.0/29.0)
+	}
+	return iccD50[0] * f(fx), iccD50[1] * f(fy), iccD50[2] * f(fz)
+}
+
+func iccFixed(b []byte) float64 {
+	return float64(int32(binary.BigEndian.Uint32(b))) / 65536.0
+}
+
+// iccCurve is a one-dimensional transfer function, either sampled, a gamma value, or a parametric function.
+type iccCurve struct {
+	table  []float64
+	params []float64 // gamma, a, b, c, d, e, f
+	size   int       // size of the tag including padding, as curves are stored consecutively in lutAtoB tags
+}
+
+func parseICCCurve(b []byte) (iccCurve, error) {
+	if len(b) < 12 {
+		return iccCurve{}, fmt.Errorf("bad ICC curve")
+	}
+	switch string(b[:4]) {
+	case "curv":
+		n := int(binary.BigEndian.Uint32(b[8:]))
+		if len(b) < 12+2*n {
+			return iccCurve{}, fmt.Errorf("bad ICC curve")
+		}
+		size := (12 + 2*n + 3) &^ 3
+		if n == 0 {
+			return iccCurve{params: []float64{1.0}, size: size}, nil
+		} else if n == 1 {
+			return iccCurve{params: []float64{float64(binary.BigEndian.Uint16(b[12:])) / 256.0}, size: size}, nil
+		}
+		table := make([]float64, n)
+		for i := range table {
+			table[i] = float64(binary.BigEndian.Uint16(b[12+2*i:])) / 65535.0
+		}
+		return iccCurve{table: table, size: size}, nil
+	case "para":
+		counts := []int{1, 3, 4, 5, 7}
+		typ := int(binary.BigEndian.Uint16(b[8:]))
+		if len(counts) <= typ || len(b) < 12+4*counts[typ] {
+			return iccCurve{}, fmt.Errorf("bad ICC parametric curve")
+		}
+		params := make([]float64, counts[typ])
+		for i := range params {
+			params[i] = iccFixed(b[12+4*i:])
+		}
+		return iccCurve{params: params, size: 12 + 4*len(params)}, nil
+	}
+	return iccCurve{}, fmt.Errorf("bad ICC curve type")
+}
+
+// Eval evaluates the curve for a value between 0 and 1.
+func (c iccCurve) Eval(x float64) float64 {
+	if c.table != nil {
+		return interpolateTable(c.table, x)
+	}
+
+	p := c.params
+	var y float64
+	switch len(p) {
+	case 1:
+		y = math.Pow(x, p[0])
+	case 3:
+		if -p[2]/p[1] <= x {
+			y = math.Pow(p[1]*x+p[2], p[0])
+		}
+	case 4:
+		y = p[3]
+		if -p[2]/p[1] <= x {
+			y = math.Pow(p[1]*x+p[2], p[0]) + p[3]
+		}
+	case 5:
+		y = p[3] * x
+		if p[4] <= x {
+			y = math.Pow(p[1]*x+p[2], p[0])
+		}
+	case 7:
+		y = p[3]*x + p[6]
+		if p[4] <= x {
+			y = math.Pow(p[1]*x+p[2], p[0]) + p[5]
+		}
+	}
+	return math.Max(0.0, math.Min(1.0, y))
+}
+
+func interpolateTable(table []float64, x float64) float64 {
+	x = math.Max(0.0, math.Min(1.0, x)) * float64(len(table)-1)
+	i := int(x)
+	if len(table)-1 <= i {
+		return table[len(table)-1]
+	}
+	t := x - float64(i)
+	return table[i]*(1.0-t) + table[i+1]*t
+}
+
+// iccLUT is a multi-dimensional lookup table that converts device colors to the profile connection space. It applies the input curves, the color lookup table, and the output curves, where lutAtoB tags may additionally have a matrix with curves.
+type iccLUT struct {
+	inputs, outputs int
+	legacyLab       bool // 16-bit Lab encoding of ICC version 2
+
+	aCurves []iccCurve
+	grid    []int
+	clut    []float64
+	mCurves []iccCurve
+	matrix  []float64 // 3x3 matrix and offset
+	bCurves []iccCurve
+}
+
+func parseICCLUT(b []byte) (*iccLUT, error) {
+	if len(b) < 12 {
+		return nil, fmt.Errorf("bad ICC lookup table")
+	}
+	lut := &iccLUT{
+		inputs:  int(b[8]),
+		outputs: int(b[9]),
+	}
+	if lut.inputs < 1 || 15 < lut.inputs || lut.outputs < 1 || 15 < lut.outputs {
+		return nil, fmt.Errorf("bad ICC lookup table channels")
+	}
+
+	switch string(b[:4]) {
+	case "mft1", "mft2":
+		// the matrix is only used for XYZ input and is ignored
+		if len(b) < 52 {
+			return nil, fmt.Errorf("bad ICC lookup table")
+		}
+		gridPoints := int(b[10])
+		inEntries, outEntries, size, pos := 256, 256, 1, 48
+		if string(b[:4]) == "mft2" {
+			inEntries, outEntries, size, pos = int(binary.BigEndian.Uint16(b[48:])), int(binary.BigEndian.Uint16(b[50:])), 2, 52
+		}
+		if gridPoints < 2 || inEntries < 2 || outEntries < 2 {
+			return nil, fmt.Errorf("bad ICC lookup table")
+		}
+		clutSize := lut.outputs
+		for i := 0; i < lut.inputs; i++ {
+			lut.grid = append(lut.grid, gridPoints)
+			clutSize *= gridPoints
+		}
+		if len(b) < pos+size*(inEntries*lut.inputs+clutSize+outEntries*lut.outputs) {
+			return nil, fmt.Errorf("bad ICC lookup table")
+		}
+		read := func(n int) []float64 {
+			vals := make([]float64, n)
+			for i := range vals {
+				if size == 1 {
+					vals[i] = float64(b[pos]) / 255.0
+				} else {
+					vals[i] = float64(binary.BigEndian.Uint16(b[pos:])) / 65535.0
+				}
+				pos += size
+			}
+			return vals
+		}
+		for i := 0; i < lut.inputs; i++ {
+			lut.aCurves = append(lut.aCurves, iccCurve{table: read(inEntries)})
+		}
+		lut.clut = read(clutSize)
+		for i := 0; i < lut.outputs; i++ {
+			lut.bCurves = append(lut.bCurves, iccCurve{table: read(outEntries)})
+		}
+	case "mAB ":
+		if len(b) < 32 {
+			return nil, fmt.Errorf("bad ICC lookup table")
+		}
+		offsets := make([]int, 5) // B, matrix, M, CLUT, A
+		for i := range offsets {
+			offsets[i] = int(binary.BigEndian.Uint32(b[12+4*i:]))
+			if len(b) < offsets[i] {
+				return nil, fmt.Errorf("bad ICC lookup table")
+			}
+		}
+		curves := func(offset, n int) ([]iccCurve, error) {
+			if offset == 0 {
+				return nil, nil
+			}
+			var cs []iccCurve
+			for i := 0; i < n; i++ {
+				c, err := parseICCCurve(b[offset:])
+				if err != nil {
+					return nil, err
+				}
+				cs = append(cs, c)
+				offset += c.size
+			}
+			return cs, nil
+		}
+
+		var err error
+		if lut.bCurves, err = curves(offsets[0], lut.outputs); err != nil {
+			return nil, err
+		} else if lut.mCurves, err = curves(offsets[2], lut.outputs); err != nil {
+			return nil, err
+		} else if lut.aCurves, err = curves(offsets[4], lut.inputs); err != nil {
+			return nil, err
+		}
+		if offsets[1] != 0 && lut.outputs == 3 {
+			if len(b) < offsets[1]+48 {
+				return nil, fmt.Errorf("bad ICC lookup table")
+			}
+			for i := 0; i < 12; i++ {
+				lut.matrix = append(lut.matrix, iccFixed(b[offsets[1]+4*i:]))
+			}
+		}
+		if offset := offsets[3]; offset != 0 {
+			if len(b) < offset+20 {
+				return nil, fmt.Errorf("bad ICC lookup table")
+			}
+			clutSize := lut.outputs
+			for i := 0; i < lut.inputs; i++ {
+				gridPoints := int(b[offset+i])
+				if gridPoints < 2 {
+					return nil, fmt.Errorf("bad ICC lookup table")
+				}
+				lut.grid = append(lut.grid, gridPoints)
+				clutSize *= gridPoints
+			}
+			size := int(b[offset+16])
+			if size != 1 && size != 2 || len(b) < offset+20+size*clutSize {
+				return nil, fmt.Errorf("bad ICC lookup table")
+			}
+			lut.clut = make([]float64, clutSize)
+			for i := range lut.clut {
+				if size == 1 {
+					lut.clut[i] = float64(b[offset+20+i]) / 255.0
+				} else {
+					lut.clut[i] = float64(binary.BigEndian.Uint16(b[offset+20+2*i:])) / 65535.0
+				}
+			}
+		} else if lut.inputs != lut.outputs {
+			return nil, fmt.Errorf("bad ICC lookup table")
+		}
+	default:
+		return nil, fmt.Errorf("bad ICC lookup table type")
+	}
+	return lut, nil
+}
+
+// Eval converts the input values between 0 and 1 to output values between 0 and 1.
+func (lut *iccLUT) Eval(in []float64) []float64 {
+	vals := make([]float64, len(in))
+	copy(vals, in)
+	apply := func(curves []iccCurve) {
+		for i := range curves {
+			if i < len(vals) {
+				vals[i] = curves[i].Eval(vals[i])
+			}
+		}
+	}
+
+	apply(lut.aCurves)
+	if lut.clut != nil {
+		vals = lut.interpolate(vals)
+	}
+	apply(lut.mCurves)
+	if lut.matrix != nil {
+		m := lut.matrix
+		x, y, z := vals[0], vals[1], vals[2]
+		for i := 0; i < 3; i++ {
+			vals[i] = math.Max(0.0, math.Min(1.0, m[3*i]*x+m[3*i+1]*y+m[3*i+2]*z+m[9+i]))
+		}
+	}
+	apply(lut.bCurves)
+	return vals
+}
+
+// interpolate does a multilinear interpolation in the color lookup table, where the first input varies slowest.
+func (lut *iccLUT) interpolate(in []float64) []float64 {
+	n := len(lut.grid)
+	base := make([]int, n)
+	frac := make([]float64, n)
+	strides := make([]int, n)
+	stride := lut.outputs
+	for i := n - 1; 0 <= i; i-- {
+		strides[i] = stride
+		stride *= lut.grid[i]
+
+		x := math.Max(0.0, math.Min(1.0, in[i])) * float64(lut.grid[i]-1)
+		base[i] = int(x)
+		if lut.grid[i]-1 <= base[i] {
+			base[i] = lut.grid[i] - 2
+		}
+		frac[i] = x - float64(base[i])
+	}
+
+	out := make([]float64, lut.outputs)
+	for corner := 0; corner < 1<<uint(n); corner++ {
+		weight := 1.0
+		offset := 0
+		for i := 0; i < n; i++ {
+			if corner&(1<<uint(i)) != 0 {
+				weight *= frac[i]
+				offset += (base[i] + 1) * strides[i]
+			} else {
+				weight *= 1.0 - frac[i]
+				offset += base[i] * strides[i]
+			}
+		}
+		if weight == 0.0 {
+			continue
+		}
+		for j := range out {
+			out[j] += weight * lut.clut[offset+j]
+		}
+	}
+	return out
+}
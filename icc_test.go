@@ -0,0 +1,136 @@
+package canvas
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/tdewolff/test"
+)
+
+// buildICCProfile builds an ICC profile from the data color space, the profile connection space, and its tags.
+func buildICCProfile(colorSpace, pcs string, tags map[string][]byte) []byte {
+	sigs := []string{}
+	for sig := range tags {
+		sigs = append(sigs, sig)
+	}
+
+	offset := 128 + 4 + 12*len(tags)
+	table := binary.BigEndian.AppendUint32(nil, uint32(len(tags)))
+	data := []byte{}
+	for _, sig := range sigs {
+		table = append(table, sig...)
+		table = binary.BigEndian.AppendUint32(table, uint32(offset+len(data)))
+		table = binary.BigEndian.AppendUint32(table, uint32(len(tags[sig])))
+		data = append(data, tags[sig]...)
+		for len(data)%4 != 0 {
+			data = append(data, 0)
+		}
+	}
+
+	header := make([]byte, 128)
+	binary.BigEndian.PutUint32(header, uint32(offset+len(data)))
+	binary.BigEndian.PutUint32(header[8:], 0x02100000)
+	copy(header[12:], "mntr"+colorSpace+pcs)
+	copy(header[36:], "acsp")
+	return append(append(header, table...), data...)
+}
+
+func iccXYZ(x, y, z float64) []byte {
+	b := []byte("XYZ \x00\x00\x00\x00")
+	for _, v := range []float64{x, y, z} {
+		b = binary.BigEndian.AppendUint32(b, uint32(int32(math.Round(v*65536.0))))
+	}
+	return b
+}
+
+func TestICCProfileMatrixTRC(t *testing.T) {
+	// parametric sRGB curve
+	trc := []byte("para\x00\x00\x00\x00\x00\x03\x00\x00")
+	for _, v := range []float64{2.4, 1.0 / 1.055, 0.055 / 1.055, 1.0 / 12.92, 0.04045} {
+		trc = binary.BigEndian.AppendUint32(trc, uint32(int32(math.Round(v*65536.0))))
+	}
+	profile, err := ParseICCProfile(buildICCProfile("RGB ", "XYZ ", map[string][]byte{
+		"rXYZ": iccXYZ(0.4361, 0.2225, 0.0139),
+		"gXYZ": iccXYZ(0.3851, 0.7169, 0.0971),
+		"bXYZ": iccXYZ(0.1431, 0.0606, 0.7141),
+		"rTRC": trc,
+		"gTRC": trc,
+		"bTRC": trc,
+	}))
+	test.Error(t, err)
+	test.T(t, profile.ColorSpace, "RGB ")
+	test.T(t, profile.Components, 3)
+
+	// an sRGB profile must be the identity transform
+	for _, c := range [][]float64{{0.0, 0.0, 0.0}, {1.0, 1.0, 1.0}, {0.2, 0.5, 0.8}, {1.0, 0.0, 0.0}} {
+		r, g, b := profile.ToRGB(c)
+		test.That(t, math.Abs(r-c[0]) < 0.01 && math.Abs(g-c[1]) < 0.01 && math.Abs(b-c[2]) < 0.01, "bad conversion of", c, "to", r, g, b)
+	}
+
+	// gray profile with a gamma curve
+	profile, err = ParseICCProfile(buildICCProfile("GRAY", "XYZ ", map[string][]byte{
+		"kTRC": []byte("curv\x00\x00\x00\x00\x00\x00\x00\x01\x01\x00"),
+	}))
+	test.Error(t, err)
+	x, y, z := profile.ToXYZ([]float64{0.5})
+	test.Float(t, y, 0.5)
+	test.Float(t, x, 0.5*0.9642)
+	test.Float(t, z, 0.5*0.8249)
+}
+
+func TestICCProfileLUT(t *testing.T) {
+	// CMYK to Lab lookup table with two grid points, where the lightness depends only on black and cyan adds a negative a*
+	lut := []byte("mft2\x00\x00\x00\x00\x04\x03\x02\x00")
+	for i := 0; i < 9; i++ {
+		v := uint32(0)
+		if i%4 == 0 {
+			v = 0x10000
+		}
+		lut = binary.BigEndian.AppendUint32(lut, v)
+	}
+	lut = binary.BigEndian.AppendUint16(lut, 2)
+	lut = binary.BigEndian.AppendUint16(lut, 2)
+	for i := 0; i < 4; i++ {
+		lut = binary.BigEndian.AppendUint16(lut, 0)
+		lut = binary.BigEndian.AppendUint16(lut, 0xFFFF)
+	}
+	for c := 0; c < 2; c++ {
+		for m := 0; m < 2; m++ {
+			for y := 0; y < 2; y++ {
+				for k := 0; k < 2; k++ {
+					l, a := uint16(0xFF00), uint16(0x8000)
+					if k == 1 {
+						l = 0
+					}
+					if c == 1 {
+						a = 0x4000
+					}
+					lut = binary.BigEndian.AppendUint16(lut, l)
+					lut = binary.BigEndian.AppendUint16(lut, a)
+					lut = binary.BigEndian.AppendUint16(lut, 0x8000)
+				}
+			}
+		}
+	}
+	for i := 0; i < 3; i++ {
+		lut = binary.BigEndian.AppendUint16(lut, 0)
+		lut = binary.BigEndian.AppendUint16(lut, 0xFFFF)
+	}
+
+	profile, err := ParseICCProfile(buildICCProfile("CMYK", "Lab ", map[string][]byte{
+		"A2B0": lut,
+	}))
+	test.Error(t, err)
+	test.T(t, profile.Components, 4)
+
+	r, g, b := profile.ToRGB([]float64{0.0, 0.0, 0.0, 0.0})
+	test.That(t, 0.99 < r && 0.99 < g && 0.99 < b, "white expected, got", r, g, b)
+	r, g, b = profile.ToRGB([]float64{0.0, 0.0, 0.0, 1.0})
+	test.That(t, r < 0.01 && g < 0.01 && b < 0.01, "black expected, got", r, g, b)
+	r, g, b = profile.ToRGB([]float64{1.0, 0.0, 0.0, 0.0})
+	test.That(t, r < g && r < b, "cyan expected, got", r, g, b)
+
+	_, err = ParseICCProfile([]byte("not a profile"))
+	test.That(t, err != nil, "must fail on bad profiles")
+}
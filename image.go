@@ -22,6 +22,7 @@ type Image struct {
 	image.Image
 	Mimetype string
 	Bytes    []byte
+	Alt      string // alternate description for accessibility, such as in tagged PDFs
 }
 
 // NewJPEGImage parses a JPEG image.
@@ -0,0 +1,32 @@
+// Package pdfedit lists and replaces the strings shown in a PDF document for cmd/pdftext. The implementation is part of the PDF reader in renderers/pdf, which sets Open when it is imported, so that the editing API is not exported by the renderer.
+package pdfedit
+
+import (
+	"fmt"
+	"io"
+)
+
+// TextString is a string shown by a text-showing operator (Tj, TJ, ', or ") in the content stream of a page or of one of its form XObjects.
+type TextString struct {
+	Object string // empty for the page, otherwise the form XObject numbered by its position in the sorted resource names, such as "1" or "1/2" for nested forms
+	Index  int    // index of the string in the content stream
+	Font   string // resource name of the font
+	Text   string // characters without a Unicode mapping are replaced by U+FFFD
+}
+
+// Document is a PDF document whose text can be replaced.
+type Document interface {
+	// TextStrings returns the strings shown in the content stream of the page with the given zero-based index and in its form XObjects.
+	TextStrings(page int) ([]TextString, error)
+
+	// ReplaceText replaces a string, see TextStrings, by a TJ operator that shows the text in the same font. The string is moved by xOffset and its characters are separated by spacing, both in thousandths of a text space unit.
+	ReplaceText(page int, object string, index int, text string, xOffset, spacing float64) error
+
+	// Write writes the document with the replaced text.
+	Write(w io.Writer) error
+}
+
+// Open reads a PDF document for editing, it is set by renderers/pdf. The password is used for encrypted documents and may be empty.
+var Open = func(r io.Reader, password string) (Document, error) {
+	return nil, fmt.Errorf("pdfedit: renderers/pdf is not imported")
+}
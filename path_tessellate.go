@@ -0,0 +1,1200 @@
+package canvas
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Mesh is an indexed triangle mesh. Triangles index into Vertices and are counter clockwise. Curves are Loop-Blinn curve triangles for quadratic Béziers along the boundary and are only set by TessellateCurves, they index into Vertices as well.
+type Mesh struct {
+	Vertices  []Point
+	Triangles [][3]int
+	Curves    []CurveTriangle
+
+	index map[Point]int
+}
+
+// CurveTriangle is a Loop-Blinn curve triangle of a quadratic Bézier with the start, control, and end points as indices into the mesh vertices. The curve triangle lies outside the triangulated interior for convex curves and inside the triangulated interior (where it is not covered by triangles) for concave curves.
+type CurveTriangle struct {
+	Indices [3]int
+	Convex  bool
+}
+
+// Coords returns the Loop-Blinn texture coordinates (u,v) of the start, control, and end points.
+func (t CurveTriangle) Coords() [3]Point {
+	return [3]Point{{0.0, 0.0}, {0.5, 0.0}, {1.0, 1.0}}
+}
+
+// Filled returns true if the interpolated texture coordinate (u,v) is filled, which is when u^2-v < 0 for convex curves and u^2-v > 0 for concave curves.
+func (t CurveTriangle) Filled(u, v float64) bool {
+	if t.Convex {
+		return u*u-v < 0.0
+	}
+	return 0.0 < u*u-v
+}
+
+func (m *Mesh) add(p Point) int {
+	if i, ok := m.index[p]; ok {
+		return i
+	}
+	if m.index == nil {
+		m.index = map[Point]int{}
+	}
+	m.index[p] = len(m.Vertices)
+	m.Vertices = append(m.Vertices, p)
+	return len(m.Vertices) - 1
+}
+
+// Empty returns true if the mesh has no triangles.
+func (m *Mesh) Empty() bool {
+	return len(m.Triangles) == 0 && len(m.Curves) == 0
+}
+
+// Area returns the area of the triangles of the mesh, curve triangles are not included.
+func (m *Mesh) Area() float64 {
+	area := 0.0
+	for _, t := range m.Triangles {
+		p0, p1, p2 := m.Vertices[t[0]], m.Vertices[t[1]], m.Vertices[t[2]]
+		area += p1.Sub(p0).PerpDot(p2.Sub(p0)) / 2.0
+	}
+	return area
+}
+
+// Tessellate tessellates the path into a triangle mesh that fills the path for the given fill rule. Curves are flattened with the given tolerance, or with Tolerance if it is zero. Subpaths are implicitly closed, and self-intersecting and overlapping subpaths are settled by splitting them at their intersections and keeping the regions that are filled according to the fill rule. Each filled region and its holes is triangulated by a constrained Delaunay triangulation.
+func (p *Path) Tessellate(fillRule FillRule, tolerance float64) (*Mesh, error) {
+	return p.tessellate(fillRule, tolerance, false)
+}
+
+// TessellateCurves tessellates the path into a triangle mesh that fills the path for the given fill rule, similar to Tessellate, but keeps curves as quadratic Béziers that are returned as Loop-Blinn curve triangles. Cubic Béziers and arcs are approximated by quadratic Béziers with the given tolerance, or with Tolerance if it is zero. Curve triangles that overlap are subdivided, and curves are flattened when they cannot be kept, which is always the case for subpaths that intersect.
+func (p *Path) TessellateCurves(fillRule FillRule, tolerance float64) (*Mesh, error) {
+	return p.tessellate(fillRule, tolerance, true)
+}
+
+// Triangulate tessellates the path using the NonZero fill rule and returns the triangles that fill the path and the quadratic Bézier curve triangles that fill the area between the triangles and the curves. The curves are returned as cubic Béziers of the start, first and second control, and end point, with the fifth point being (1,1) for convex curves and (-1,-1) for concave curves. Use TessellateCurves to obtain an indexed mesh.
+func (p *Path) Triangulate() ([][3]Point, [][5]Point) {
+	mesh, err := p.TessellateCurves(NonZero, 0.0)
+	if err != nil {
+		return nil, nil
+	}
+
+	triangles := make([][3]Point, 0, len(mesh.Triangles))
+	for _, t := range mesh.Triangles {
+		triangles = append(triangles, [3]Point{mesh.Vertices[t[0]], mesh.Vertices[t[1]], mesh.Vertices[t[2]]})
+	}
+	beziers := make([][5]Point, 0, len(mesh.Curves))
+	for _, t := range mesh.Curves {
+		start, cp, end := mesh.Vertices[t.Indices[0]], mesh.Vertices[t.Indices[1]], mesh.Vertices[t.Indices[2]]
+		cp1, cp2 := quadraticToCubicBezier(start, cp, end)
+		sign := Point{1.0, 1.0}
+		if !t.Convex {
+			sign = Point{-1.0, -1.0}
+		}
+		beziers = append(beziers, [5]Point{start, cp1, cp2, end, sign})
+	}
+	return triangles, beziers
+}
+
+func (p *Path) tessellate(fillRule FillRule, tolerance float64, curves bool) (*Mesh, error) {
+	if 0.0 < tolerance {
+		oldTolerance := Tolerance
+		Tolerance = tolerance
+		defer func() {
+			Tolerance = oldTolerance
+		}()
+	}
+
+	var contours [][]tessCurve
+	if curves {
+		contours = tessContours(p.replace(nil, nil, cubicToQuads, arcToQuadTolerance))
+	} else {
+		contours = tessContours(p.Flatten())
+	}
+
+	polys := make([][]Point, len(contours))
+	for i, contour := range contours {
+		polys[i] = tessFlatten(contour)
+	}
+	arr := newTessArrangement(polys)
+	faces := arr.faces()
+
+	mesh := &Mesh{}
+	if !curves || !arr.simple {
+		for _, face := range faces {
+			if !fillRule.fills(tessWinding(polys, face.interiorPoint(arr.vs))) {
+				continue
+			}
+			if err := mesh.triangulate(face.points(arr.vs)); err != nil {
+				return nil, err
+			}
+		}
+		return mesh, nil
+	}
+
+	// the arrangement corresponds to the contours, replace each cycle of a filled face by its contour
+	// oriented such that the interior of the face is on the left
+	filled := [][][]tessCurve{}
+	for _, face := range faces {
+		if !fillRule.fills(tessWinding(polys, face.interiorPoint(arr.vs))) {
+			continue
+		}
+		cycles := append([][]int{face.outer}, face.holes...)
+		region := make([][]tessCurve, len(cycles))
+		for i, cycle := range cycles {
+			k := arr.contour[cycle[0]]
+			region[i] = contours[k]
+			if (0.0 < polygonSignedArea(polys[k])) != (0.0 < arr.area(cycle)) {
+				region[i] = tessReverse(contours[k])
+			}
+		}
+		filled = append(filled, region)
+	}
+	tessResolveOverlaps(filled)
+
+	for _, region := range filled {
+		rings := make([][]Point, 0, len(region))
+		for _, contour := range region {
+			ring := []Point{}
+			for _, c := range contour {
+				if c.quad {
+					i0, i1, i2 := mesh.add(c.start), mesh.add(c.cp), mesh.add(c.end)
+					convex := c.end.Sub(c.start).PerpDot(c.cp.Sub(c.start)) < 0.0
+					mesh.Curves = append(mesh.Curves, CurveTriangle{[3]int{i0, i1, i2}, convex})
+					ring = append(ring, c.start)
+					if !convex {
+						ring = append(ring, c.cp)
+					}
+				} else {
+					ring = append(ring, c.start)
+				}
+			}
+			rings = append(rings, ring)
+		}
+		if err := mesh.triangulate(rings); err != nil {
+			return nil, err
+		}
+	}
+	return mesh, nil
+}
+
+func (fillRule FillRule) fills(winding int) bool {
+	return fillRule == NonZero && winding != 0 || winding%2 != 0
+}
+
+// triangulate adds the triangles of the polygon given by its outer ring and hole rings, using ear clipping followed by edge flips to obtain a constrained Delaunay triangulation.
+func (m *Mesh) triangulate(rings [][]Point) error {
+	ps := []Point{}
+	var outer *earNode
+	holes := []*earNode{}
+	constraints := [][2]int{}
+	for i, ring := range rings {
+		ring = tessSimplify(ring)
+		if len(ring) < 3 {
+			if i == 0 {
+				return nil
+			}
+			continue
+		}
+		list := earLinkedList(ps, ring, i == 0)
+		for j := range ring {
+			constraints = append(constraints, [2]int{len(ps) + j, len(ps) + (j+1)%len(ring)})
+		}
+		ps = append(ps, ring...)
+		if i == 0 {
+			outer = list
+		} else {
+			holes = append(holes, list)
+		}
+	}
+	if outer == nil || outer.next == outer.prev {
+		return nil
+	}
+	outer = earEliminateHoles(holes, outer)
+
+	triangles := [][3]int{}
+	if !earcutLinked(outer, &triangles, 0) {
+		return fmt.Errorf("could not triangulate polygon")
+	}
+
+	// merge coincident vertices and remove degenerate triangles
+	index := make([]int, len(ps))
+	for i, p := range ps {
+		index[i] = m.add(p)
+	}
+	constrained := map[[2]int]bool{}
+	for _, e := range constraints {
+		constrained[tessEdge(index[e[0]], index[e[1]])] = true
+	}
+	ts := make([][3]int, 0, len(triangles))
+	for _, t := range triangles {
+		t = [3]int{index[t[0]], index[t[1]], index[t[2]]}
+		p0, p1, p2 := m.Vertices[t[0]], m.Vertices[t[1]], m.Vertices[t[2]]
+		if area := p1.Sub(p0).PerpDot(p2.Sub(p0)); area < 0.0 {
+			t[1], t[2] = t[2], t[1]
+		} else if area == 0.0 {
+			continue
+		}
+		ts = append(ts, t)
+	}
+	m.Triangles = append(m.Triangles, m.delaunay(ts, constrained)...)
+	return nil
+}
+
+func tessEdge(a, b int) [2]int {
+	if b < a {
+		return [2]int{b, a}
+	}
+	return [2]int{a, b}
+}
+
+// delaunay flips edges that are not constrained until all triangles satisfy the Delaunay condition.
+func (m *Mesh) delaunay(ts [][3]int, constrained map[[2]int]bool) [][3]int {
+	edges := map[[2]int][]int{}
+	for i, t := range ts {
+		for j := 0; j < 3; j++ {
+			e := tessEdge(t[j], t[(j+1)%3])
+			edges[e] = append(edges[e], i)
+		}
+	}
+	stack := [][2]int{}
+	for e, tris := range edges {
+		if len(tris) == 2 && !constrained[e] {
+			stack = append(stack, e)
+		}
+	}
+
+	// opposite returns the vertex of triangle t opposite to edge e, and whether the edge is in counter clockwise order
+	opposite := func(t [3]int, e [2]int) (int, bool) {
+		for j := 0; j < 3; j++ {
+			if t[j] != e[0] && t[j] != e[1] {
+				return t[j], t[(j+1)%3] == e[0]
+			}
+		}
+		return -1, false
+	}
+	replace := func(e [2]int, from, to int) {
+		for k, i := range edges[e] {
+			if i == from {
+				edges[e][k] = to
+			}
+		}
+	}
+
+	for n := 0; 0 < len(stack) && n < 16*len(ts)+64; n++ {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		tris := edges[e]
+		if len(tris) != 2 || constrained[e] {
+			continue
+		}
+		i1, i2 := tris[0], tris[1]
+		c, ccw := opposite(ts[i1], e)
+		d, _ := opposite(ts[i2], e)
+		a, b := e[0], e[1]
+		if !ccw {
+			a, b = b, a
+		}
+		// triangles are (a,b,c) and (b,a,d)
+		pa, pb, pc, pd := m.Vertices[a], m.Vertices[b], m.Vertices[c], m.Vertices[d]
+		if !tessInCircle(pa, pb, pc, pd) || pa.Sub(pc).PerpDot(pd.Sub(pc)) <= 0.0 || pd.Sub(pc).PerpDot(pb.Sub(pc)) <= 0.0 {
+			continue
+		}
+		ts[i1] = [3]int{c, a, d}
+		ts[i2] = [3]int{d, b, c}
+		delete(edges, e)
+		replace(tessEdge(a, d), i2, i1)
+		replace(tessEdge(b, c), i1, i2)
+		edges[tessEdge(c, d)] = []int{i1, i2}
+		stack = append(stack, tessEdge(a, d), tessEdge(d, b), tessEdge(b, c), tessEdge(c, a))
+	}
+	return ts
+}
+
+// tessInCircle returns true if d lies strictly inside the circumcircle of the counter clockwise triangle (a,b,c).
+func tessInCircle(a, b, c, d Point) bool {
+	ad, bd, cd := a.Sub(d), b.Sub(d), c.Sub(d)
+	det := (ad.X*ad.X+ad.Y*ad.Y)*bd.PerpDot(cd) - (bd.X*bd.X+bd.Y*bd.Y)*ad.PerpDot(cd) + (cd.X*cd.X+cd.Y*cd.Y)*ad.PerpDot(bd)
+	scale := ad.Dot(ad) + bd.Dot(bd) + cd.Dot(cd)
+	return Epsilon*scale*scale < det
+}
+
+////////////////////////////////////////////////////////////////
+
+// earNode is a vertex in a circular doubly linked list of polygon vertices used for ear clipping, see https://github.com/mapbox/earcut
+type earNode struct {
+	i int
+	Point
+	prev, next *earNode
+	steiner    bool
+}
+
+// earArea returns twice the signed area of the triangle (p,q,r), which is negative for counter clockwise triangles.
+func earArea(p, q, r *earNode) float64 {
+	return (q.Y-p.Y)*(r.X-q.X) - (q.X-p.X)*(r.Y-q.Y)
+}
+
+func earInsert(i int, p Point, last *earNode) *earNode {
+	n := &earNode{i: i, Point: p}
+	if last == nil {
+		n.prev, n.next = n, n
+	} else {
+		n.next, n.prev = last.next, last
+		last.next.prev = n
+		last.next = n
+	}
+	return n
+}
+
+func earRemove(n *earNode) {
+	n.next.prev = n.prev
+	n.prev.next = n.next
+}
+
+// earLinkedList returns the ring as a linked list that is counter clockwise for the outer ring and clockwise for holes, offset is the index of the first point.
+func earLinkedList(ps []Point, ring []Point, outer bool) *earNode {
+	offset := len(ps)
+	var last *earNode
+	if outer == (0.0 < polygonSignedArea(ring)) {
+		for i, p := range ring {
+			last = earInsert(offset+i, p, last)
+		}
+	} else {
+		for i := len(ring) - 1; 0 <= i; i-- {
+			last = earInsert(offset+i, ring[i], last)
+		}
+	}
+	if last != nil && last.Point == last.next.Point {
+		earRemove(last)
+		last = last.next
+	}
+	return last
+}
+
+// earFilter removes duplicate and collinear points.
+func earFilter(start, end *earNode) *earNode {
+	if start == nil {
+		return start
+	}
+	if end == nil {
+		end = start
+	}
+	p := start
+	for {
+		again := false
+		if !p.steiner && (p.Point == p.next.Point || earArea(p.prev, p, p.next) == 0.0) {
+			earRemove(p)
+			p = p.prev
+			end = p
+			if p == p.next {
+				break
+			}
+			again = true
+		} else {
+			p = p.next
+		}
+		if !again && p == end {
+			break
+		}
+	}
+	return end
+}
+
+// earcutLinked clips ears from the polygon and returns false if it did not succeed.
+func earcutLinked(ear *earNode, triangles *[][3]int, pass int) bool {
+	if ear == nil {
+		return true
+	}
+	stop := ear
+	for ear.prev != ear.next {
+		prev, next := ear.prev, ear.next
+		if earIsEar(ear) {
+			*triangles = append(*triangles, [3]int{prev.i, ear.i, next.i})
+			earRemove(ear)
+			ear = next.next
+			stop = next.next
+			continue
+		}
+		ear = next
+		if ear == stop {
+			// no ears found, try filtering points, curing self-intersections, or splitting the polygon
+			if pass == 0 {
+				return earcutLinked(earFilter(ear, nil), triangles, 1)
+			} else if pass == 1 {
+				ear = earCureLocalIntersections(earFilter(ear, nil), triangles)
+				return earcutLinked(ear, triangles, 2)
+			}
+			return earSplit(ear, triangles)
+		}
+	}
+	return true
+}
+
+func earIsEar(ear *earNode) bool {
+	a, b, c := ear.prev, ear, ear.next
+	if 0.0 <= earArea(a, b, c) {
+		return false // reflex
+	}
+	xmin, xmax := math.Min(a.X, math.Min(b.X, c.X)), math.Max(a.X, math.Max(b.X, c.X))
+	ymin, ymax := math.Min(a.Y, math.Min(b.Y, c.Y)), math.Max(a.Y, math.Max(b.Y, c.Y))
+	for p := c.next; p != a; p = p.next {
+		if xmin <= p.X && p.X <= xmax && ymin <= p.Y && p.Y <= ymax && earInTriangle(a.Point, b.Point, c.Point, p.Point) && 0.0 <= earArea(p.prev, p, p.next) {
+			return false
+		}
+	}
+	return true
+}
+
+func earCureLocalIntersections(start *earNode, triangles *[][3]int) *earNode {
+	p := start
+	for {
+		a, b := p.prev, p.next.next
+		if a.Point != b.Point && earIntersects(a, p, p.next, b) && earLocallyInside(a, b) && earLocallyInside(b, a) {
+			*triangles = append(*triangles, [3]int{a.i, p.i, b.i})
+			earRemove(p)
+			earRemove(p.next)
+			p, start = b, b
+		}
+		p = p.next
+		if p == start {
+			break
+		}
+	}
+	return earFilter(p, nil)
+}
+
+func earSplit(start *earNode, triangles *[][3]int) bool {
+	a := start
+	for {
+		for b := a.next.next; b != a.prev; b = b.next {
+			if a.i != b.i && earIsValidDiagonal(a, b) {
+				c := earSplitPolygon(a, b)
+				a = earFilter(a, a.next)
+				c = earFilter(c, c.next)
+				ok := earcutLinked(a, triangles, 0)
+				return earcutLinked(c, triangles, 0) && ok
+			}
+		}
+		a = a.next
+		if a == start {
+			return false
+		}
+	}
+}
+
+func earEliminateHoles(holes []*earNode, outer *earNode) *earNode {
+	queue := make([]*earNode, 0, len(holes))
+	for _, list := range holes {
+		if list == list.next {
+			list.steiner = true
+		}
+		leftmost := list
+		for p := list.next; p != list; p = p.next {
+			if p.X < leftmost.X || p.X == leftmost.X && p.Y < leftmost.Y {
+				leftmost = p
+			}
+		}
+		queue = append(queue, leftmost)
+	}
+	sort.SliceStable(queue, func(i, j int) bool {
+		return queue[i].X < queue[j].X
+	})
+	for _, hole := range queue {
+		if bridge := earFindHoleBridge(hole, outer); bridge != nil {
+			bridgeReverse := earSplitPolygon(bridge, hole)
+			earFilter(bridgeReverse, bridgeReverse.next)
+			outer = earFilter(bridge, bridge.next)
+		}
+	}
+	return outer
+}
+
+// earFindHoleBridge finds a vertex on the outer ring that is visible from the leftmost vertex of the hole, using David Eberly's algorithm.
+func earFindHoleBridge(hole, outer *earNode) *earNode {
+	var m *earNode
+	h, qx := hole.Point, math.Inf(-1)
+	for p := outer; ; {
+		if h.Y <= p.Y && p.next.Y <= h.Y && p.next.Y != p.Y {
+			x := p.X + (h.Y-p.Y)*(p.next.X-p.X)/(p.next.Y-p.Y)
+			if x <= h.X && qx < x {
+				qx = x
+				m = p
+				if p.next.X < p.X {
+					m = p.next
+				}
+				if x == h.X {
+					return m // hole touches outer segment
+				}
+			}
+		}
+		if p = p.next; p == outer {
+			break
+		}
+	}
+	if m == nil {
+		return nil
+	}
+
+	// look for points inside the triangle of the hole point, segment intersection, and end point, and choose the one with the smallest angle with the ray
+	stop, mp, tanMin := m, m.Point, math.Inf(1)
+	a, c := Point{qx, h.Y}, h
+	if h.Y < mp.Y {
+		a, c = h, Point{qx, h.Y}
+	}
+	for p := m; ; {
+		if p.X <= h.X && mp.X <= p.X && h.X != p.X && earInTriangle(a, mp, c, p.Point) {
+			tan := math.Abs(h.Y-p.Y) / (h.X - p.X)
+			if earLocallyInside(p, hole) && (tan < tanMin || tan == tanMin && (m.X < p.X || p.X == m.X && earArea(m.prev, m, p.prev) < 0.0 && earArea(p.next, m, m.next) < 0.0)) {
+				m = p
+				tanMin = tan
+			}
+		}
+		if p = p.next; p == stop {
+			break
+		}
+	}
+	return m
+}
+
+func earInTriangle(a, b, c, p Point) bool {
+	return (c.X-p.X)*(a.Y-p.Y) >= (a.X-p.X)*(c.Y-p.Y) && (a.X-p.X)*(b.Y-p.Y) >= (b.X-p.X)*(a.Y-p.Y) && (b.X-p.X)*(c.Y-p.Y) >= (c.X-p.X)*(b.Y-p.Y)
+}
+
+func earIsValidDiagonal(a, b *earNode) bool {
+	return a.next.i != b.i && a.prev.i != b.i && !earIntersectsPolygon(a, b) &&
+		(earLocallyInside(a, b) && earLocallyInside(b, a) && earMiddleInside(a, b) && (earArea(a.prev, a, b.prev) != 0.0 || earArea(a, b.prev, b) != 0.0) ||
+			a.Point == b.Point && 0.0 < earArea(a.prev, a, a.next) && 0.0 < earArea(b.prev, b, b.next))
+}
+
+func earSign(f float64) int {
+	if 0.0 < f {
+		return 1
+	} else if f < 0.0 {
+		return -1
+	}
+	return 0
+}
+
+func earOnSegment(p, q, r *earNode) bool {
+	return q.X <= math.Max(p.X, r.X) && math.Min(p.X, r.X) <= q.X && q.Y <= math.Max(p.Y, r.Y) && math.Min(p.Y, r.Y) <= q.Y
+}
+
+func earIntersects(p1, q1, p2, q2 *earNode) bool {
+	o1, o2 := earSign(earArea(p1, q1, p2)), earSign(earArea(p1, q1, q2))
+	o3, o4 := earSign(earArea(p2, q2, p1)), earSign(earArea(p2, q2, q1))
+	return o1 != o2 && o3 != o4 ||
+		o1 == 0 && earOnSegment(p1, p2, q1) ||
+		o2 == 0 && earOnSegment(p1, q2, q1) ||
+		o3 == 0 && earOnSegment(p2, p1, q2) ||
+		o4 == 0 && earOnSegment(p2, q1, q2)
+}
+
+func earIntersectsPolygon(a, b *earNode) bool {
+	for p := a; ; {
+		if p.i != a.i && p.next.i != a.i && p.i != b.i && p.next.i != b.i && earIntersects(p, p.next, a, b) {
+			return true
+		}
+		if p = p.next; p == a {
+			return false
+		}
+	}
+}
+
+func earLocallyInside(a, b *earNode) bool {
+	if earArea(a.prev, a, a.next) < 0.0 {
+		return 0.0 <= earArea(a, b, a.next) && 0.0 <= earArea(a, a.prev, b)
+	}
+	return earArea(a, b, a.prev) < 0.0 || earArea(a, a.next, b) < 0.0
+}
+
+func earMiddleInside(a, b *earNode) bool {
+	inside := false
+	m := a.Point.Interpolate(b.Point, 0.5)
+	for p := a; ; {
+		if (m.Y < p.Y) != (m.Y < p.next.Y) && p.next.Y != p.Y && m.X < (p.next.X-p.X)*(m.Y-p.Y)/(p.next.Y-p.Y)+p.X {
+			inside = !inside
+		}
+		if p = p.next; p == a {
+			return inside
+		}
+	}
+}
+
+// earSplitPolygon links a to b with a bridge, splitting the polygon in two, and returns the start of the second polygon.
+func earSplitPolygon(a, b *earNode) *earNode {
+	a2 := &earNode{i: a.i, Point: a.Point}
+	b2 := &earNode{i: b.i, Point: b.Point}
+	an, bp := a.next, b.prev
+	a.next, b.prev = b, a
+	a2.next, an.prev = an, a2
+	b2.next, a2.prev = a2, b2
+	bp.next, b2.prev = b2, bp
+	return b2
+}
+
+////////////////////////////////////////////////////////////////
+
+// tessCurve is a line or quadratic Bézier segment of a contour.
+type tessCurve struct {
+	start, cp, end Point
+	quad           bool
+}
+
+func cubicToQuads(p0, p1, p2, p3 Point) *Path {
+	p := &Path{}
+	p.MoveTo(p0.X, p0.Y)
+	for _, quad := range cubicToQuadraticBeziers(p0, p1, p2, p3) {
+		p.QuadTo(quad[1].X, quad[1].Y, quad[2].X, quad[2].Y)
+	}
+	return p
+}
+
+// tessContours returns the implicitly closed contours of a path of lines and quadratic Béziers.
+func tessContours(p *Path) [][]tessCurve {
+	contours := [][]tessCurve{}
+	for _, pi := range p.Split() {
+		contour := []tessCurve{}
+		var start, end Point
+		for i := 0; i < len(pi.d); {
+			cmd := pi.d[i]
+			switch cmd {
+			case MoveToCmd:
+				end = Point{pi.d[i+1], pi.d[i+2]}
+			case LineToCmd, CloseCmd:
+				end = Point{pi.d[i+1], pi.d[i+2]}
+				contour = append(contour, tessCurve{start: start, end: end})
+			case QuadToCmd:
+				cp := Point{pi.d[i+1], pi.d[i+2]}
+				end = Point{pi.d[i+3], pi.d[i+4]}
+				contour = append(contour, tessCurve{start, cp, end, true})
+			default:
+				panic("path must contain only lines and quadratic Béziers")
+			}
+			i += cmdLen(cmd)
+			start = end
+		}
+		if 0 < len(contour) && !contour[0].start.Equals(end) {
+			contour = append(contour, tessCurve{start: end, end: contour[0].start})
+		}
+
+		// remove zero-length segments and treat flat quadratic Béziers as lines
+		n := 0
+		for _, c := range contour {
+			if c.start.Equals(c.end) {
+				continue
+			} else if c.quad && Equal(c.end.Sub(c.start).PerpDot(c.cp.Sub(c.start)), 0.0) {
+				c.quad = false
+			}
+			contour[n] = c
+			n++
+		}
+		if 2 <= n {
+			contours = append(contours, contour[:n])
+		}
+	}
+	return contours
+}
+
+// tessFlatten returns the polygon of the contour, with quadratic Béziers flattened.
+func tessFlatten(contour []tessCurve) []Point {
+	poly := []Point{}
+	for _, c := range contour {
+		poly = append(poly, c.start)
+		if c.quad {
+			coords := flattenQuadraticBezier(c.start, c.cp, c.end).Coords()
+			poly = append(poly, coords[1:len(coords)-1]...)
+		}
+	}
+	return poly
+}
+
+func tessReverse(contour []tessCurve) []tessCurve {
+	r := make([]tessCurve, len(contour))
+	for i, c := range contour {
+		r[len(contour)-1-i] = tessCurve{c.end, c.cp, c.start, c.quad}
+	}
+	return r
+}
+
+// tessSimplify removes consecutive duplicate points from a closed polygon.
+func tessSimplify(poly []Point) []Point {
+	r := make([]Point, 0, len(poly))
+	for _, p := range poly {
+		if len(r) == 0 || r[len(r)-1] != p {
+			r = append(r, p)
+		}
+	}
+	for 1 < len(r) && r[0] == r[len(r)-1] {
+		r = r[:len(r)-1]
+	}
+	return r
+}
+
+func polygonSignedArea(poly []Point) float64 {
+	area := 0.0
+	for i := range poly {
+		area += poly[i].PerpDot(poly[(i+1)%len(poly)])
+	}
+	return area / 2.0
+}
+
+// polygonContains returns true if q is inside the polygon using the even-odd rule.
+func polygonContains(poly []Point, q Point) bool {
+	inside := false
+	for i := range poly {
+		a, b := poly[i], poly[(i+1)%len(poly)]
+		if (a.Y <= q.Y) != (b.Y <= q.Y) && q.X < a.X+(q.Y-a.Y)/(b.Y-a.Y)*(b.X-a.X) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// tessWinding returns the winding number of the polygons at q, which must not be on the boundary.
+func tessWinding(polys [][]Point, q Point) int {
+	n := 0
+	for _, poly := range polys {
+		for i := range poly {
+			a, b := poly[i], poly[(i+1)%len(poly)]
+			if a.Y <= q.Y && q.Y < b.Y && 0.0 < b.Sub(a).PerpDot(q.Sub(a)) {
+				n++
+			} else if b.Y <= q.Y && q.Y < a.Y && b.Sub(a).PerpDot(q.Sub(a)) < 0.0 {
+				n--
+			}
+		}
+	}
+	return n
+}
+
+////////////////////////////////////////////////////////////////
+
+// tessArrangement is the planar graph of polygons that are split at their intersections, where vertices that are within Epsilon are merged.
+type tessArrangement struct {
+	vs      []Point
+	adj     [][]int // neighbours sorted counter clockwise by angle
+	contour []int   // polygon index of the vertex, or -1 for intersections
+	simple  bool    // polygons do not intersect or touch
+
+	index map[Point]int
+}
+
+type tessSegment struct {
+	a, b   int
+	splits []tessSplit
+}
+
+type tessSplit struct {
+	t float64
+	v int
+}
+
+func newTessArrangement(polys [][]Point) *tessArrangement {
+	arr := &tessArrangement{
+		simple: true,
+		index:  map[Point]int{},
+	}
+	segs := []tessSegment{}
+	for k, poly := range polys {
+		first := len(segs)
+		for i := range poly {
+			a := arr.add(poly[i], k)
+			b := arr.add(poly[(i+1)%len(poly)], k)
+			if a != b {
+				segs = append(segs, tessSegment{a: a, b: b})
+			}
+		}
+		if len(segs)-first < 3 {
+			arr.simple = false
+		}
+	}
+
+	// find intersections and touching vertices between all segments using a sweep along x
+	eps := 1e3 * Epsilon
+	sort.Slice(segs, func(i, j int) bool {
+		return math.Min(arr.vs[segs[i].a].X, arr.vs[segs[i].b].X) < math.Min(arr.vs[segs[j].a].X, arr.vs[segs[j].b].X)
+	})
+	for i := range segs {
+		a, b := arr.vs[segs[i].a], arr.vs[segs[i].b]
+		xmax := math.Max(a.X, b.X) + eps
+		for j := i + 1; j < len(segs); j++ {
+			c, d := arr.vs[segs[j].a], arr.vs[segs[j].b]
+			if xmax < math.Min(c.X, d.X) {
+				break
+			} else if math.Max(a.Y, b.Y)+eps < math.Min(c.Y, d.Y) || math.Max(c.Y, d.Y)+eps < math.Min(a.Y, b.Y) {
+				continue
+			}
+			arr.intersect(&segs[i], &segs[j], eps)
+		}
+	}
+
+	// split segments and remove duplicate edges
+	edges := map[[2]int]bool{}
+	for _, seg := range segs {
+		sort.Slice(seg.splits, func(i, j int) bool {
+			return seg.splits[i].t < seg.splits[j].t
+		})
+		vs := []int{seg.a}
+		for _, split := range seg.splits {
+			vs = append(vs, split.v)
+		}
+		vs = append(vs, seg.b)
+		for i := 1; i < len(vs); i++ {
+			u, v := vs[i-1], vs[i]
+			if u == v {
+				continue
+			} else if v < u {
+				u, v = v, u
+			}
+			if edges[[2]int{u, v}] {
+				arr.simple = false
+			}
+			edges[[2]int{u, v}] = true
+		}
+	}
+
+	arr.adj = make([][]int, len(arr.vs))
+	for e := range edges {
+		arr.adj[e[0]] = append(arr.adj[e[0]], e[1])
+		arr.adj[e[1]] = append(arr.adj[e[1]], e[0])
+	}
+
+	// prune dangling edges
+	queue := []int{}
+	for v := range arr.adj {
+		if len(arr.adj[v]) == 1 {
+			queue = append(queue, v)
+		}
+	}
+	for 0 < len(queue) {
+		v := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		if len(arr.adj[v]) != 1 {
+			continue
+		}
+		arr.simple = false
+		u := arr.adj[v][0]
+		arr.adj[v] = nil
+		for i, w := range arr.adj[u] {
+			if w == v {
+				arr.adj[u] = append(arr.adj[u][:i], arr.adj[u][i+1:]...)
+				break
+			}
+		}
+		if len(arr.adj[u]) == 1 {
+			queue = append(queue, u)
+		}
+	}
+
+	for v := range arr.adj {
+		if len(arr.adj[v]) != 0 && len(arr.adj[v]) != 2 {
+			arr.simple = false
+		}
+		p := arr.vs[v]
+		sort.Slice(arr.adj[v], func(i, j int) bool {
+			return arr.vs[arr.adj[v][i]].Sub(p).Angle() < arr.vs[arr.adj[v][j]].Sub(p).Angle()
+		})
+	}
+	return arr
+}
+
+func (arr *tessArrangement) add(p Point, contour int) int {
+	key := Point{math.Round(p.X/Epsilon) * Epsilon, math.Round(p.Y/Epsilon) * Epsilon}
+	if i, ok := arr.index[key]; ok {
+		if arr.contour[i] != contour {
+			arr.simple = false
+		}
+		return i
+	}
+	arr.index[key] = len(arr.vs)
+	arr.vs = append(arr.vs, p)
+	arr.contour = append(arr.contour, contour)
+	return len(arr.vs) - 1
+}
+
+func (arr *tessArrangement) intersect(s, t *tessSegment, eps float64) {
+	a, b, c, d := arr.vs[s.a], arr.vs[s.b], arr.vs[t.a], arr.vs[t.b]
+	ab, cd := b.Sub(a), d.Sub(c)
+	lab, lcd := ab.Length(), cd.Length()
+
+	// signed distances of the end points to the other segment
+	dc, dd := ab.PerpDot(c.Sub(a))/lab, ab.PerpDot(d.Sub(a))/lab
+	da, db := cd.PerpDot(a.Sub(c))/lcd, cd.PerpDot(b.Sub(c))/lcd
+
+	// end points touching the other segment
+	touch := func(seg *tessSegment, p0, d0 Point, l float64, v int, dist float64) {
+		if v == seg.a || v == seg.b || eps < math.Abs(dist) {
+			return
+		}
+		t := arr.vs[v].Sub(p0).Dot(d0) / (l * l)
+		if eps/l < t && t < 1.0-eps/l {
+			seg.splits = append(seg.splits, tessSplit{t, v})
+			arr.simple = false
+		}
+	}
+	touch(s, a, ab, lab, t.a, dc)
+	touch(s, a, ab, lab, t.b, dd)
+	touch(t, c, cd, lcd, s.a, da)
+	touch(t, c, cd, lcd, s.b, db)
+
+	// proper crossing
+	if (eps < dc && dd < -eps || dc < -eps && eps < dd) && (eps < da && db < -eps || da < -eps && eps < db) {
+		ta, tc := da/(da-db), dc/(dc-dd)
+		v := arr.add(a.Interpolate(b, ta), -1)
+		s.splits = append(s.splits, tessSplit{ta, v})
+		t.splits = append(t.splits, tessSplit{tc, v})
+		arr.simple = false
+	}
+}
+
+func (arr *tessArrangement) area(cycle []int) float64 {
+	area := 0.0
+	for i := range cycle {
+		area += arr.vs[cycle[i]].PerpDot(arr.vs[cycle[(i+1)%len(cycle)]])
+	}
+	return area / 2.0
+}
+
+// tessFace is a bounded face of the arrangement with a counter clockwise outer cycle and clockwise hole cycles.
+type tessFace struct {
+	outer []int
+	holes [][]int
+	area  float64
+}
+
+// faces returns the bounded faces of the arrangement.
+func (arr *tessArrangement) faces() []*tessFace {
+	// connected components
+	comp := make([]int, len(arr.vs))
+	for v := range comp {
+		comp[v] = v
+	}
+	var find func(int) int
+	find = func(v int) int {
+		if comp[v] != v {
+			comp[v] = find(comp[v])
+		}
+		return comp[v]
+	}
+	for v := range arr.adj {
+		for _, w := range arr.adj[v] {
+			comp[find(v)] = find(w)
+		}
+	}
+
+	// trace cycles keeping the face on the left, bounded faces are counter clockwise and the outer boundaries of components are clockwise
+	faces := []*tessFace{}
+	outers := [][]int{}
+	visited := map[[2]int]bool{}
+	for u0 := range arr.adj {
+		for _, v0 := range arr.adj[u0] {
+			if visited[[2]int{u0, v0}] {
+				continue
+			}
+			cycle := []int{}
+			u, v := u0, v0
+			for !visited[[2]int{u, v}] {
+				visited[[2]int{u, v}] = true
+				cycle = append(cycle, u)
+				adj := arr.adj[v]
+				i := 0
+				for adj[i] != u {
+					i++
+				}
+				u, v = v, adj[(i+len(adj)-1)%len(adj)]
+			}
+			if area := arr.area(cycle); 0.0 < area {
+				faces = append(faces, &tessFace{outer: cycle, area: area})
+			} else if area < 0.0 {
+				outers = append(outers, cycle)
+			}
+		}
+	}
+
+	// assign the outer boundaries of components as holes to the smallest face of another component that contains it
+	rings := make([][]Point, len(faces))
+	for i, face := range faces {
+		rings[i] = face.points(arr.vs)[0]
+	}
+	for _, cycle := range outers {
+		var hole *tessFace
+		q := arr.vs[cycle[0]]
+		for i, face := range faces {
+			if find(face.outer[0]) != find(cycle[0]) && (hole == nil || face.area < hole.area) && polygonContains(rings[i], q) {
+				hole = face
+			}
+		}
+		if hole != nil {
+			hole.holes = append(hole.holes, cycle)
+		}
+	}
+	return faces
+}
+
+func (face *tessFace) points(vs []Point) [][]Point {
+	rings := make([][]Point, 0, 1+len(face.holes))
+	for _, cycle := range append([][]int{face.outer}, face.holes...) {
+		ring := make([]Point, len(cycle))
+		for i, v := range cycle {
+			ring[i] = vs[v]
+		}
+		rings = append(rings, ring)
+	}
+	return rings
+}
+
+// interiorPoint returns a point strictly inside the face and outside its holes. It takes the middle of the widest interior span along horizontal lines halfway the largest vertical gaps between vertices.
+func (face *tessFace) interiorPoint(vs []Point) Point {
+	rings := face.points(vs)
+	ys := []float64{}
+	for _, ring := range rings {
+		for _, p := range ring {
+			ys = append(ys, p.Y)
+		}
+	}
+	sort.Float64s(ys)
+
+	gaps := []int{}
+	for i := 1; i < len(ys); i++ {
+		if ys[i-1] < ys[i] {
+			gaps = append(gaps, i)
+		}
+	}
+	sort.SliceStable(gaps, func(i, j int) bool {
+		return ys[gaps[i]]-ys[gaps[i]-1] > ys[gaps[j]]-ys[gaps[j]-1]
+	})
+	if 4 < len(gaps) {
+		gaps = gaps[:4]
+	}
+
+	best, bestSize := Point{}, -1.0
+	for _, i := range gaps {
+		y := (ys[i-1] + ys[i]) / 2.0
+		xs := []float64{}
+		for _, ring := range rings {
+			for j := range ring {
+				a, b := ring[j], ring[(j+1)%len(ring)]
+				if (a.Y <= y) != (b.Y <= y) {
+					xs = append(xs, a.X+(y-a.Y)/(b.Y-a.Y)*(b.X-a.X))
+				}
+			}
+		}
+		sort.Float64s(xs)
+		for j := 1; j < len(xs); j += 2 {
+			if size := (xs[j] - xs[j-1]) * (ys[i] - ys[i-1]); bestSize < size {
+				best, bestSize = Point{(xs[j-1] + xs[j]) / 2.0, y}, size
+			}
+		}
+	}
+	return best
+}
+
+////////////////////////////////////////////////////////////////
+
+// tessResolveOverlaps subdivides quadratic Béziers whose curve triangles overlap other segments or curve triangles, and flattens them when that does not resolve the overlap.
+func tessResolveOverlaps(regions [][][]tessCurve) {
+	type ref struct{ i, j int }
+	contours := []*[]tessCurve{}
+	for _, region := range regions {
+		for i := range region {
+			contours = append(contours, &region[i])
+		}
+	}
+
+	overlaps := func() map[ref]bool {
+		items := []ref{}
+		for i, contour := range contours {
+			for j := range *contour {
+				items = append(items, ref{i, j})
+			}
+		}
+		get := func(r ref) tessCurve {
+			return (*contours[r.i])[r.j]
+		}
+		bounds := func(c tessCurve) Rect {
+			r := Rect{c.start.X, c.start.Y, 0.0, 0.0}.AddPoint(c.end)
+			if c.quad {
+				r = r.AddPoint(c.cp)
+			}
+			return r
+		}
+
+		overlap := map[ref]bool{}
+		for x, rx := range items {
+			cx := get(rx)
+			if !cx.quad {
+				continue
+			}
+			bx := bounds(cx)
+			for y, ry := range items {
+				cy := get(ry)
+				if x == y || cy.quad && y < x {
+					continue
+				}
+				by := bounds(cy)
+				if bx.X+bx.W < by.X || by.X+by.W < bx.X || bx.Y+bx.H < by.Y || by.Y+by.H < bx.Y {
+					continue
+				}
+				if cy.quad {
+					if tessTrianglesOverlap(cx, cy) {
+						overlap[rx], overlap[ry] = true, true
+					}
+				} else if tessSegmentOverlapsTriangle(cy.start, cy.end, cx) {
+					overlap[rx] = true
+				}
+			}
+		}
+		return overlap
+	}
+
+	for iter := 0; ; iter++ {
+		overlap := overlaps()
+		if len(overlap) == 0 {
+			return
+		}
+		for i, contour := range contours {
+			r := []tessCurve{}
+			for j, c := range *contour {
+				if !overlap[ref{i, j}] {
+					r = append(r, c)
+				} else if iter < 8 {
+					q0, q1, q2, r0, r1, r2 := quadraticBezierSplit(c.start, c.cp, c.end, 0.5)
+					r = append(r, tessCurve{q0, q1, q2, true}, tessCurve{r0, r1, r2, true})
+				} else {
+					coords := flattenQuadraticBezier(c.start, c.cp, c.end).Coords()
+					for k := 1; k < len(coords); k++ {
+						r = append(r, tessCurve{start: coords[k-1], end: coords[k]})
+					}
+				}
+			}
+			*contour = r
+		}
+		if 8 <= iter {
+			return
+		}
+	}
+}
+
+// tessSegmentsCross returns true if segments AB and CD cross at a point that is not an end point of either.
+func tessSegmentsCross(a, b, c, d Point) bool {
+	d1, d2 := b.Sub(a).PerpDot(c.Sub(a)), b.Sub(a).PerpDot(d.Sub(a))
+	d3, d4 := d.Sub(c).PerpDot(a.Sub(c)), d.Sub(c).PerpDot(b.Sub(c))
+	return (0.0 < d1 && d2 < 0.0 || d1 < 0.0 && 0.0 < d2) && (0.0 < d3 && d4 < 0.0 || d3 < 0.0 && 0.0 < d4)
+}
+
+// tessTriangleContains returns true if q is strictly inside the triangle of the quadratic Bézier.
+func tessTriangleContains(c tessCurve, q Point) bool {
+	d1 := c.cp.Sub(c.start).PerpDot(q.Sub(c.start))
+	d2 := c.end.Sub(c.cp).PerpDot(q.Sub(c.cp))
+	d3 := c.start.Sub(c.end).PerpDot(q.Sub(c.end))
+	return 0.0 < d1 && 0.0 < d2 && 0.0 < d3 || d1 < 0.0 && d2 < 0.0 && d3 < 0.0
+}
+
+func tessSegmentOverlapsTriangle(a, b Point, c tessCurve) bool {
+	if tessTriangleContains(c, a) || tessTriangleContains(c, b) {
+		return true
+	}
+	return tessSegmentsCross(a, b, c.start, c.cp) || tessSegmentsCross(a, b, c.cp, c.end) || tessSegmentsCross(a, b, c.end, c.start)
+}
+
+func tessTrianglesOverlap(c, d tessCurve) bool {
+	return tessSegmentOverlapsTriangle(d.start, d.cp, c) || tessSegmentOverlapsTriangle(d.cp, d.end, c) || tessSegmentOverlapsTriangle(d.end, d.start, c) || tessTriangleContains(d, c.cp)
+}
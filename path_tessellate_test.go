@@ -0,0 +1,157 @@
+package canvas
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tdewolff/test"
+)
+
+// meshArea returns the filled area of the mesh including the curve triangles, where the parabolic segment of a quadratic Bézier covers two thirds of its triangle.
+func meshArea(m *Mesh) float64 {
+	area := m.Area()
+	for _, t := range m.Curves {
+		p0, p1, p2 := m.Vertices[t.Indices[0]], m.Vertices[t.Indices[1]], m.Vertices[t.Indices[2]]
+		tri := math.Abs(p1.Sub(p0).PerpDot(p2.Sub(p0))) / 2.0
+		if t.Convex {
+			area += 2.0 / 3.0 * tri
+		} else {
+			area += 1.0 / 3.0 * tri
+		}
+	}
+	return area
+}
+
+// isDelaunay returns true if all triangles are counter clockwise and no vertex lies inside the circumcircle of an adjacent triangle, except across boundary edges.
+func isDelaunay(m *Mesh) bool {
+	edges := map[[2]int][][3]int{}
+	for _, t := range m.Triangles {
+		p0, p1, p2 := m.Vertices[t[0]], m.Vertices[t[1]], m.Vertices[t[2]]
+		if p1.Sub(p0).PerpDot(p2.Sub(p0)) <= 0.0 {
+			return false
+		}
+		for j := 0; j < 3; j++ {
+			e := tessEdge(t[j], t[(j+1)%3])
+			edges[e] = append(edges[e], t)
+		}
+	}
+	for _, ts := range edges {
+		if len(ts) != 2 {
+			continue
+		}
+		for _, v := range ts[1] {
+			if v != ts[0][0] && v != ts[0][1] && v != ts[0][2] && tessInCircle(m.Vertices[ts[0][0]], m.Vertices[ts[0][1]], m.Vertices[ts[0][2]], m.Vertices[v]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestPathTessellate(t *testing.T) {
+	var tts = []struct {
+		p        string
+		fillRule FillRule
+		area     float64
+	}{
+		{"", NonZero, 0.0},
+		{"M0 0H2V1H0z", NonZero, 2.0},
+		{"M0 0H2V1H0", NonZero, 2.0},
+		{"M0 0H1H2V1H1H0z", NonZero, 2.0},
+		{"M0 0H4V4H0zM1 1V3H3V1z", NonZero, 12.0},
+		{"M0 0H4V4H0zM1 1H3V3H1z", NonZero, 16.0},
+		{"M0 0H4V4H0zM1 1H3V3H1z", EvenOdd, 12.0},
+		{"M0 0H6V6H0zM1 1V5H5V1zM2 2H4V4H2z", NonZero, 24.0},
+		{"M0 0H2V2H0zM1 1H3V3H1z", NonZero, 7.0},
+		{"M0 0H2V2H0zM1 1H3V3H1z", EvenOdd, 6.0},
+		{"M0 0H1V1H0zM1 0H2V1H1z", NonZero, 2.0},
+		{"M0 0H1V1H0zM1 1H2V2H1z", NonZero, 2.0},
+		{"M0 0H2V2H0zM0 0L1 1L0 2z", NonZero, 4.0},
+		{"M0 0H4L2 2zM2 2L4 4H0z", NonZero, 8.0},
+		{"M0 0L2 2H0L2 0z", NonZero, 2.0},
+		{"M0 0L2 2H0L2 0z", EvenOdd, 2.0},
+		{"M0 4L1 0L2 4L0 1H2z", NonZero, 137.0 / 44.0},
+		{"M0 4L1 0L2 4L0 1H2z", EvenOdd, 98.0 / 44.0},
+	}
+	for _, tt := range tts {
+		t.Run(tt.p, func(t *testing.T) {
+			mesh, err := MustParseSVG(tt.p).Tessellate(tt.fillRule, 0.0)
+			test.Error(t, err)
+			test.Float(t, mesh.Area(), tt.area)
+			test.That(t, isDelaunay(mesh))
+		})
+	}
+
+	mesh, err := MustParseSVG("M0 0H2V1H0z").Tessellate(NonZero, 0.0)
+	test.Error(t, err)
+	test.T(t, len(mesh.Vertices), 4)
+	test.T(t, len(mesh.Triangles), 2)
+	test.T(t, len(mesh.Curves), 0)
+
+	mesh, err = Ellipse(2.0, 1.0).Tessellate(NonZero, 0.001)
+	test.Error(t, err)
+	test.That(t, math.Abs(mesh.Area()-2.0*math.Pi) < 0.02)
+	test.That(t, isDelaunay(mesh))
+}
+
+func TestPathTessellateCurves(t *testing.T) {
+	var tts = []struct {
+		p        *Path
+		fillRule FillRule
+		area     float64
+	}{
+		{Circle(1.0), NonZero, math.Pi},
+		{Circle(2.0).Append(Circle(1.0).Reverse()), NonZero, 3.0 * math.Pi},
+		{Circle(2.0).Append(Circle(1.0)), NonZero, 4.0 * math.Pi},
+		{Circle(2.0).Append(Circle(1.0)), EvenOdd, 3.0 * math.Pi},
+		{MustParseSVG("M0 0H2V2Q1 1 0 2z"), NonZero, 4.0 - 2.0/3.0},
+		{MustParseSVG("M0 0H2V2Q1 3 0 2z"), NonZero, 4.0 + 2.0/3.0},
+		{MustParseSVG("M0 0C0 2 2 2 2 0z"), NonZero, 2.4},
+	}
+	for _, tt := range tts {
+		t.Run(tt.p.String(), func(t *testing.T) {
+			mesh, err := tt.p.TessellateCurves(tt.fillRule, 0.0001)
+			test.Error(t, err)
+			test.That(t, math.Abs(meshArea(mesh)-tt.area) < 0.001, meshArea(mesh), "!=", tt.area)
+			test.That(t, 0 < len(mesh.Curves))
+			test.That(t, isDelaunay(mesh))
+		})
+	}
+
+	// concave curve
+	mesh, err := MustParseSVG("M0 0H2V2Q1 1 0 2z").TessellateCurves(NonZero, 0.0)
+	test.Error(t, err)
+	test.T(t, len(mesh.Curves), 1)
+	test.That(t, !mesh.Curves[0].Convex)
+	test.Float(t, mesh.Area(), 3.0)
+
+	// intersecting subpaths are flattened
+	mesh, err = Circle(1.0).Append(Circle(1.0).Translate(1.0, 0.0)).TessellateCurves(NonZero, 0.001)
+	test.Error(t, err)
+	test.T(t, len(mesh.Curves), 0)
+
+	// overlapping curve triangles are subdivided
+	mesh, err = MustParseSVG("M0 0L4 0Q2 4 0 0zM1.5 1L2.5 1L2 1.5z").TessellateCurves(NonZero, 0.0)
+	test.Error(t, err)
+	test.That(t, 1 < len(mesh.Curves))
+	test.That(t, isDelaunay(mesh))
+}
+
+func TestCurveTriangle(t *testing.T) {
+	convex := CurveTriangle{Convex: true}
+	test.That(t, convex.Filled(0.5, 0.5))
+	test.That(t, !convex.Filled(0.5, 0.0))
+	concave := CurveTriangle{Convex: false}
+	test.That(t, !concave.Filled(0.5, 0.5))
+	test.That(t, concave.Filled(0.5, 0.0))
+	test.T(t, convex.Coords(), [3]Point{{0.0, 0.0}, {0.5, 0.0}, {1.0, 1.0}})
+}
+
+func TestPathTriangulate(t *testing.T) {
+	triangles, beziers := MustParseSVG("M0 0H2V2Q1 1 0 2z").Triangulate()
+	test.T(t, len(triangles), 3)
+	test.T(t, len(beziers), 1)
+	for i, p := range []Point{{2.0, 2.0}, {4.0 / 3.0, 4.0 / 3.0}, {2.0 / 3.0, 4.0 / 3.0}, {0.0, 2.0}, {-1.0, -1.0}} {
+		test.That(t, beziers[0][i].Equals(p), beziers[0][i], "!=", p)
+	}
+}
@@ -2,25 +2,63 @@ package canvas
 
 import (
 	"math"
-
-	"github.com/ByteArena/poly2tri-go"
 )
 
+// Tiler is a wallpaper group used for tiling a motif over the plane. A and B are the lattice vectors that span the unit cell, Ms are the symmetry transformations that map the fundamental domain onto the rest of the unit cell, and Domain is the fundamental domain itself. A motif that is drawn inside the fundamental domain will produce a seamless tiling without overlap.
 type Tiler struct {
-	A, B Point
-	Ms   []Matrix
+	A, B   Point
+	Ms     []Matrix
+	Domain *Path
+}
+
+func polygon(ps ...Point) *Path {
+	p := &Path{}
+	p.MoveTo(ps[0].X, ps[0].Y)
+	for _, q := range ps[1:] {
+		p.LineTo(q.X, q.Y)
+	}
+	p.Close()
+	return p
 }
 
+// hexagonal returns the lattice vectors of a hexagonal lattice with spacing d and the 3-fold rotation centres of the triangle spanned by them and of the triangle below it.
+func hexagonal(d float64) (Point, Point, Point, Point) {
+	a := Point{d, 0.0}
+	b := Point{d / 2.0, d * math.Sqrt(3.0) / 2.0}
+	return a, b, a.Add(b).Mul(1.0 / 3.0), a.Mul(2.0).Sub(b).Mul(1.0 / 3.0)
+}
+
+// P1 returns the wallpaper group p1 with only translations, with lattice vectors of length x and y with an angle of rot in degrees between them. The fundamental domain is the unit cell.
 func P1(x, y, rot float64) Tiler {
+	a := Point{x, 0.0}
+	b := Point{y, 0.0}.Rot(rot*math.Pi/180.0, Point{0.0, 0.0})
 	return Tiler{
-		Point{x, 0.0},
-		Point{y, 0.0}.Rot(rot*math.Pi/180.0, Point{0.0, 0.0}),
+		a,
+		b,
 		[]Matrix{
 			Identity,
 		},
+		polygon(Point{}, a, a.Add(b), b),
 	}
 }
 
+// P2 returns the wallpaper group p2 with 2-fold rotations, with lattice vectors of length x and y with an angle of rot in degrees between them. The fundamental domain is the lower half of the unit cell.
+func P2(x, y, rot float64) Tiler {
+	a := Point{x, 0.0}
+	b := Point{y, 0.0}.Rot(rot*math.Pi/180.0, Point{0.0, 0.0})
+	c := a.Add(b).Mul(0.5)
+	return Tiler{
+		a,
+		b,
+		[]Matrix{
+			Identity,
+			Identity.RotateAbout(180.0, c.X, c.Y),
+		},
+		polygon(Point{}, a, a.Add(b.Mul(0.5)), b.Mul(0.5)),
+	}
+}
+
+// Pm returns the wallpaper group pm with vertical mirror lines, with a rectangular unit cell of x by y. The fundamental domain is the rectangle (0,0)-(x/2,y).
 func Pm(x, y float64) Tiler {
 	return Tiler{
 		Point{x, 0.0},
@@ -29,9 +67,11 @@ func Pm(x, y float64) Tiler {
 			Identity,
 			Identity.ReflectXAbout(x / 2.0),
 		},
+		Rectangle(x/2.0, y),
 	}
 }
 
+// Pg returns the wallpaper group pg with horizontal glide reflections, with a rectangular unit cell of x by y. The fundamental domain is the rectangle (0,0)-(x/2,y).
 func Pg(x, y float64) Tiler {
 	return Tiler{
 		Point{x, 0.0},
@@ -40,193 +80,216 @@ func Pg(x, y float64) Tiler {
 			Identity,
 			Identity.Translate(x/2.0, 0.0).ReflectYAbout(y / 2.0),
 		},
+		Rectangle(x/2.0, y),
+	}
+}
+
+// Cm returns the wallpaper group cm with vertical mirror lines and a centred rectangular lattice, with a rectangular unit cell of x by y. The fundamental domain is the rectangle (0,0)-(x/2,y/2).
+func Cm(x, y float64) Tiler {
+	return Tiler{
+		Point{x, 0.0},
+		Point{0.0, y},
+		[]Matrix{
+			Identity,
+			Identity.ReflectXAbout(x / 2.0),
+			Identity.Translate(x/2.0, y/2.0),
+			Identity.Translate(0.0, y/2.0).ReflectXAbout(x / 4.0),
+		},
+		Rectangle(x/2.0, y/2.0),
+	}
+}
+
+// Pmm returns the wallpaper group pmm with horizontal and vertical mirror lines, with a rectangular unit cell of x by y. The fundamental domain is the rectangle (0,0)-(x/2,y/2).
+func Pmm(x, y float64) Tiler {
+	return Tiler{
+		Point{x, 0.0},
+		Point{0.0, y},
+		[]Matrix{
+			Identity,
+			Identity.ReflectXAbout(x / 2.0),
+			Identity.ReflectYAbout(y / 2.0),
+			Identity.RotateAbout(180.0, x/2.0, y/2.0),
+		},
+		Rectangle(x/2.0, y/2.0),
+	}
+}
+
+// Pmg returns the wallpaper group pmg with vertical mirror lines, horizontal glide reflections, and 2-fold rotations, with a rectangular unit cell of x by y. The fundamental domain is the rectangle (0,0)-(x/2,y/2).
+func Pmg(x, y float64) Tiler {
+	return Tiler{
+		Point{x, 0.0},
+		Point{0.0, y},
+		[]Matrix{
+			Identity,
+			Identity.ReflectXAbout(x / 2.0),
+			Identity.RotateAbout(180.0, x/4.0, y/2.0),
+			Identity.Translate(x/2.0, 0.0).ReflectYAbout(y / 2.0),
+		},
+		Rectangle(x/2.0, y/2.0),
+	}
+}
+
+// Pgg returns the wallpaper group pgg with horizontal and vertical glide reflections and 2-fold rotations, with a rectangular unit cell of x by y. The fundamental domain is the rectangle (0,0)-(x/2,y/2).
+func Pgg(x, y float64) Tiler {
+	return Tiler{
+		Point{x, 0.0},
+		Point{0.0, y},
+		[]Matrix{
+			Identity,
+			Identity.Translate(x/2.0, 0.0).ReflectYAbout(y / 4.0),
+			Identity.Translate(0.0, y/2.0).ReflectXAbout(x / 4.0),
+			Identity.RotateAbout(180.0, x/2.0, y/2.0),
+		},
+		Rectangle(x/2.0, y/2.0),
+	}
+}
+
+// Cmm returns the wallpaper group cmm with horizontal and vertical mirror lines and a centred rectangular lattice, with a rectangular unit cell of x by y. The fundamental domain is the rectangle (0,0)-(x/4,y/2).
+func Cmm(x, y float64) Tiler {
+	return Tiler{
+		Point{x, 0.0},
+		Point{0.0, y},
+		[]Matrix{
+			Identity,
+			Identity.ReflectXAbout(x / 2.0),
+			Identity.ReflectYAbout(y / 2.0),
+			Identity.RotateAbout(180.0, x/2.0, y/2.0),
+			Identity.Translate(x/2.0, y/2.0),
+			Identity.Translate(x/2.0, y/2.0).ReflectX(),
+			Identity.Translate(x/2.0, 0.0).ReflectYAbout(y / 4.0),
+			Identity.RotateAbout(180.0, x/4.0, y/4.0),
+		},
+		Rectangle(x/4.0, y/2.0),
 	}
 }
 
-//func Cm(x, y float64) Tiler {
-//	return Tiler{
-//		Point{x, 0.0},
-//		Point{0.0, 2.0 * y},
-//		[]Matrix{
-//			Identity,
-//			Identity.Translate(x/2.0, 0.0).ReflectYAbout(y / 2.0),
-//			Identity.Translate(x/2.0, y),
-//			Identity.ReflectYAbout(y),
-//		},
-//	}
-//}
+// P4 returns the wallpaper group p4 with 4-fold rotations, with a square unit cell of x by x. The fundamental domain is the square (0,0)-(x/2,x/2).
+func P4(x float64) Tiler {
+	d := x / 2.0
+	return Tiler{
+		Point{x, 0.0},
+		Point{0.0, x},
+		[]Matrix{
+			Identity,
+			Identity.RotateAbout(90.0, d, d),
+			Identity.RotateAbout(180.0, d, d),
+			Identity.RotateAbout(270.0, d, d),
+		},
+		Rectangle(d, d),
+	}
+}
 
-//func Pm(x, y float64) Tiler {
-//	a := Point{x, 0.0}
-//	b := Point{0.0, y}
-//	return func(i, j int) []Matrix {
-//		pos := a.Mul(float64(i)).Add(b.Mul(float64(j)))
-//		return []Matrix{
-//			Identity.Translate(pos.X, pos.Y),
-//			Identity.Translate(pos.X+x, pos.Y).ReflectX(),
-//		}
-//	}
-//}
-//
-//func Cm(x, y float64) Tiler {
-//	a := Point{x, 0.0}
-//	b := Point{0.0, y}
-//	return func(i, j int) []Matrix {
-//		pos := a.Mul(float64(i)).Add(b.Mul(float64(j)))
-//		return []Matrix{
-//			Identity.Translate(pos.X, pos.Y),
-//			Identity.Translate(pos.X+x/2.0, pos.Y).ReflectX(),
-//			Identity.Translate(pos.X+x/2.0, pos.Y+y/2.0),
-//			Identity.Translate(pos.X+x, pos.Y+y/2.0).ReflectX(),
-//		}
-//	}
-//}
-//
-//func P2(x, y, rot float64) Tiler {
-//	a := Point{x, 0.0}
-//	b := Point{y, 0.0}.Rot(rot*math.Pi/180.0, Point{0.0, 0.0})
-//	return func(i, j int) []Matrix {
-//		pos := a.Mul(float64(i)).Add(b.Mul(float64(j)))
-//		return []Matrix{
-//			Identity.Translate(pos.X, pos.Y),
-//			Identity.Translate(pos.X+x, pos.Y+y).Rotate(180),
-//		}
-//	}
-//}
-//
-//func Pgg(x, y float64) Tiler {
-//	a := Point{x, 0.0}
-//	b := Point{0.0, y}
-//	return func(i, j int) []Matrix {
-//		pos := a.Mul(float64(i)).Add(b.Mul(float64(j)))
-//		return []Matrix{
-//			Identity.Translate(pos.X, pos.Y),
-//			Identity.Translate(pos.X+x/2.0, pos.Y+y/2.0).Rotate(180),
-//			Identity.Translate(pos.X+x/2.0, pos.Y+y).ReflectY(),
-//			Identity.Translate(pos.X+x, pos.Y+y/2.0).ReflectX(),
-//		}
-//	}
-//}
-//
-//func Pmm(x, y float64) Tiler {
-//	a := Point{x, 0.0}
-//	b := Point{0.0, y}
-//	return func(i, j int) []Matrix {
-//		pos := a.Mul(float64(i)).Add(b.Mul(float64(j)))
-//		return []Matrix{
-//			Identity.Translate(pos.X, pos.Y),
-//			Identity.Translate(pos.X+x, pos.Y).ReflectX(),
-//			Identity.Translate(pos.X, pos.Y+y).ReflectY(),
-//			Identity.Translate(pos.X+x, pos.Y+y).Rotate(180),
-//		}
-//	}
-//}
-//
-//func Cmm(x, y float64) Tiler {
-//	a := Point{x, 0.0}
-//	b := Point{0.0, y}
-//	return func(i, j int) []Matrix {
-//		pos := a.Mul(float64(i)).Add(b.Mul(float64(j)))
-//		return []Matrix{
-//			Identity.Translate(pos.X, pos.Y),
-//			Identity.Translate(pos.X+x, pos.Y).ReflectX(),
-//			Identity.Translate(pos.X, pos.Y+y).ReflectY(),
-//			Identity.Translate(pos.X+x, pos.Y+y).Rotate(180),
-//			Identity.Translate(pos.X+x/2.0, pos.Y+y/2.0).Rotate(180),
-//			Identity.Translate(pos.X+x/2.0, pos.Y+y/2.0).ReflectY(),
-//			Identity.Translate(pos.X+x/2.0, pos.Y+y/2.0).ReflectX(),
-//			Identity.Translate(pos.X+x/2.0, pos.Y+y/2.0),
-//		}
-//	}
-//}
-//
-//func Pmg(x, y float64) Tiler {
-//	a := Point{x, 0.0}
-//	b := Point{0.0, y}
-//	return func(i, j int) []Matrix {
-//		pos := a.Mul(float64(i)).Add(b.Mul(float64(j)))
-//		return []Matrix{
-//			Identity.Translate(pos.X, pos.Y),
-//			Identity.Translate(pos.X+x/2.0, pos.Y).ReflectX(),
-//			Identity.Translate(pos.X+x/2.0, pos.Y+y).ReflectY(),
-//			Identity.Translate(pos.X+x, pos.Y+y).Rotate(180),
-//		}
-//	}
-//}
-//
-//func P4(x float64) Tiler {
-//	a := Point{x, 0.0}
-//	b := Point{0.0, x}
-//	d := x / 2.0
-//	return func(i, j int) []Matrix {
-//		pos := a.Mul(float64(i)).Add(b.Mul(float64(j)))
-//		return []Matrix{
-//			Identity.Translate(pos.X, pos.Y),
-//			Identity.Translate(pos.X, pos.Y).RotateAbout(90, d, d),
-//			Identity.Translate(pos.X, pos.Y).RotateAbout(180, d, d),
-//			Identity.Translate(pos.X, pos.Y).RotateAbout(270, d, d),
-//		}
-//	}
-//}
-//
-//func P4m(x float64) Tiler {
-//	a := Point{x, 0.0}
-//	b := Point{0.0, x}
-//	d := x / 2.0
-//	return func(i, j int) []Matrix {
-//		pos := a.Mul(float64(i)).Add(b.Mul(float64(j)))
-//		return []Matrix{
-//			Identity.Translate(pos.X, pos.Y),
-//			Identity.Translate(pos.X, pos.Y).RotateAbout(90, d, d),
-//			Identity.Translate(pos.X, pos.Y).RotateAbout(180, d, d),
-//			Identity.Translate(pos.X, pos.Y).RotateAbout(270, d, d),
-//			Identity.Translate(pos.X, pos.Y).ReflectXAbout(d).RotateAbout(90, d, d),
-//			Identity.Translate(pos.X, pos.Y).ReflectXAbout(d),
-//			Identity.Translate(pos.X, pos.Y).ReflectYAbout(d),
-//			Identity.Translate(pos.X, pos.Y).ReflectYAbout(d).RotateAbout(90, d, d),
-//		}
-//	}
-//}
-//
-//func P4g(x float64) Tiler {
-//	a := Point{x, 0.0}
-//	b := Point{0.0, x}
-//	d := x / 2.0
-//	return func(i, j int) []Matrix {
-//		pos := a.Mul(float64(i)).Add(b.Mul(float64(j)))
-//		return []Matrix{
-//			Identity.Translate(pos.X, pos.Y),
-//			Identity.Translate(pos.X, pos.Y).ReflectXAbout(x / 4.0),
-//			Identity.Translate(pos.X, pos.Y).ReflectYAbout(x / 4.0),
-//			Identity.Translate(pos.X, pos.Y).RotateAbout(180, x/4.0, x/4.0),
-//			Identity.Translate(pos.X, pos.Y).RotateAbout(90, d, d),
-//			Identity.Translate(pos.X, pos.Y).RotateAbout(90, d, d).ReflectXAbout(x / 4.0),
-//			Identity.Translate(pos.X, pos.Y).RotateAbout(90, d, d).ReflectYAbout(x / 4.0),
-//			Identity.Translate(pos.X, pos.Y).RotateAbout(90, d, d).RotateAbout(180, x/4.0, x/4.0),
-//			Identity.Translate(pos.X, pos.Y).RotateAbout(180, d, d),
-//			Identity.Translate(pos.X, pos.Y).RotateAbout(180, d, d).ReflectXAbout(x / 4.0),
-//			Identity.Translate(pos.X, pos.Y).RotateAbout(180, d, d).ReflectYAbout(x / 4.0),
-//			Identity.Translate(pos.X, pos.Y).RotateAbout(180, d, d).RotateAbout(180, x/4.0, x/4.0),
-//			Identity.Translate(pos.X, pos.Y).RotateAbout(270, d, d),
-//			Identity.Translate(pos.X, pos.Y).RotateAbout(270, d, d).ReflectXAbout(x / 4.0),
-//			Identity.Translate(pos.X, pos.Y).RotateAbout(270, d, d).ReflectYAbout(x / 4.0),
-//			Identity.Translate(pos.X, pos.Y).RotateAbout(270, d, d).RotateAbout(180, x/4.0, x/4.0),
-//		}
-//	}
-//}
+// P4m returns the wallpaper group p4m with 4-fold rotations and mirror lines along the axes and diagonals, with a square unit cell of x by x. The fundamental domain is the triangle (0,0), (x/2,0), (x/2,x/2).
+func P4m(x float64) Tiler {
+	d := x / 2.0
+	ms := make([]Matrix, 0, 8)
+	for _, rot := range []float64{0.0, 90.0, 180.0, 270.0} {
+		ms = append(ms, Identity.RotateAbout(rot, d, d))
+		ms = append(ms, Identity.RotateAbout(rot, d, d).Rotate(90.0).ReflectY())
+	}
+	return Tiler{
+		Point{x, 0.0},
+		Point{0.0, x},
+		ms,
+		polygon(Point{}, Point{d, 0.0}, Point{d, d}),
+	}
+}
 
-//func P3(d float64) Tiler {
-//	a := Point{d, 0.0}
-//	b := Point{d, 0.0}.Rot(60.0*math.Pi/180.0, Point{0.0, 0.0})
-//	return func() (Point, Point, []Matrix) {
-//		return a, b, []Matrix{
-//			Identity,
-//			Identity.Rotate(120.0),
-//			Identity.Rotate(240.0),
-//		}
-//	}
-//}
+// P4g returns the wallpaper group p4g with 4-fold rotations and diagonal mirror lines that do not pass through the rotation centres, with a square unit cell of x by x. The fundamental domain is the triangle (0,0), (x/2,0), (0,x/2).
+func P4g(x float64) Tiler {
+	d := x / 2.0
+	ms := make([]Matrix, 0, 8)
+	for _, rot := range []float64{0.0, 90.0, 180.0, 270.0} {
+		ms = append(ms, Identity.RotateAbout(rot, d, d))
+		ms = append(ms, Identity.RotateAbout(rot, d, d).Translate(d, d).Rotate(90.0).ReflectX())
+	}
+	return Tiler{
+		Point{x, 0.0},
+		Point{0.0, x},
+		ms,
+		polygon(Point{}, Point{d, 0.0}, Point{0.0, d}),
+	}
+}
 
+// P3 returns the wallpaper group p3 with 3-fold rotations, with a hexagonal lattice of spacing d. The fundamental domain is the rhombus (0,0), (d/2,-d/sqrt(12)), (d,0), (d/2,d/sqrt(12)) of which the second and fourth vertex are rotation centres.
+func P3(d float64) Tiler {
+	a, b, c0, c1 := hexagonal(d)
+	return Tiler{
+		a,
+		b,
+		[]Matrix{
+			Identity,
+			Identity.Rotate(120.0),
+			Identity.Rotate(240.0),
+		},
+		polygon(Point{}, c1, a, c0),
+	}
+}
+
+// P3m1 returns the wallpaper group p3m1 with 3-fold rotations whose centres all lie on mirror lines, with a hexagonal lattice of spacing d. The fundamental domain is the equilateral triangle (0,0), (d/2,-d/sqrt(12)), (d/2,d/sqrt(12)).
+func P3m1(d float64) Tiler {
+	a, b, c0, c1 := hexagonal(d)
+	ms := make([]Matrix, 0, 6)
+	for _, rot := range []float64{0.0, 120.0, 240.0} {
+		ms = append(ms, Identity.Rotate(rot))
+		ms = append(ms, Identity.Rotate(rot).ReflectXAbout(d/2.0))
+	}
+	return Tiler{
+		a,
+		b,
+		ms,
+		polygon(Point{}, c1, c0),
+	}
+}
+
+// P31m returns the wallpaper group p31m with 3-fold rotations of which some centres do not lie on mirror lines, with a hexagonal lattice of spacing d. The fundamental domain is the triangle (0,0), (d,0), (d/2,d/sqrt(12)).
+func P31m(d float64) Tiler {
+	a, b, c0, _ := hexagonal(d)
+	ms := make([]Matrix, 0, 6)
+	for _, rot := range []float64{0.0, 120.0, 240.0} {
+		ms = append(ms, Identity.RotateAbout(rot, c0.X, c0.Y))
+		ms = append(ms, Identity.ReflectY().RotateAbout(rot, c0.X, c0.Y))
+	}
+	return Tiler{
+		a,
+		b,
+		ms,
+		polygon(Point{}, a, c0),
+	}
+}
+
+// P6 returns the wallpaper group p6 with 6-fold rotations, with a hexagonal lattice of spacing d. The fundamental domain is the equilateral triangle (0,0), (d/2,-d/sqrt(12)), (d/2,d/sqrt(12)).
+func P6(d float64) Tiler {
+	a, b, c0, c1 := hexagonal(d)
+	ms := make([]Matrix, 0, 6)
+	for _, rot := range []float64{0.0, 60.0, 120.0, 180.0, 240.0, 300.0} {
+		ms = append(ms, Identity.Rotate(rot))
+	}
+	return Tiler{
+		a,
+		b,
+		ms,
+		polygon(Point{}, c1, c0),
+	}
+}
+
+// P6m returns the wallpaper group p6m with 6-fold rotations and mirror lines, with a hexagonal lattice of spacing d. The fundamental domain is the triangle (0,0), (d/2,0), (d/2,d/sqrt(12)).
+func P6m(d float64) Tiler {
+	a, b, c0, _ := hexagonal(d)
+	ms := make([]Matrix, 0, 12)
+	for _, rot := range []float64{0.0, 60.0, 120.0, 180.0, 240.0, 300.0} {
+		ms = append(ms, Identity.Rotate(rot))
+		ms = append(ms, Identity.Rotate(rot).ReflectY())
+	}
+	return Tiler{
+		a,
+		b,
+		ms,
+		polygon(Point{}, Point{d / 2.0, 0.0}, c0),
+	}
+}
+
+// Tile tiles the path, which is the motif in the fundamental domain of the tiler, over n by m unit cells.
 func (p *Path) Tile(n, m int, tiler Tiler) *Path {
 	a, b, ms := tiler.A, tiler.B, tiler.Ms
 	pm := &Path{}
@@ -244,47 +307,54 @@ func (p *Path) Tile(n, m int, tiler Tiler) *Path {
 	return pt
 }
 
-// Triangulate tessellates the path and returns the triangles that fill the path. WIP
-func (p *Path) Triangulate() ([][3]Point, [][5]Point) {
-	p = p.ReplaceArcs()
+// TileIn tiles the path, which is the motif in the fundamental domain of the tiler, so that it fills the boundary path. Each copy of the motif is clipped by the boundary separately, so that adjacent copies are not merged.
+func (p *Path) TileIn(boundary *Path, tiler Tiler) *Path {
+	a, b := tiler.A, tiler.B
+	det := a.PerpDot(b)
+	if p.Empty() || boundary.Empty() || Equal(det, 0.0) {
+		return &Path{}
+	}
 
-	beziers := [][5]Point{}
-	contour := []*poly2tri.Point{}
-	var start, end Point
-	for i := 0; i < len(p.d); {
-		cmd := p.d[i]
-		switch cmd {
-		case MoveToCmd, LineToCmd:
-			end = Point{p.d[i+1], p.d[i+2]}
-			contour = append(contour, poly2tri.NewPoint(end.X, end.Y))
-		case QuadToCmd:
-			cp := Point{p.d[i+1], p.d[i+2]}
-			end = Point{p.d[i+3], p.d[i+4]}
-			cp1, cp2 := quadraticToCubicBezier(start, cp, end)
-			contour = append(contour, poly2tri.NewPoint(end.X, end.Y))
-			beziers = append(beziers, [5]Point{start, cp1, cp2, end, {1.0, 1.0}})
-		case CubeToCmd:
-			cp1 := Point{p.d[i+1], p.d[i+2]}
-			cp2 := Point{p.d[i+3], p.d[i+4]}
-			end = Point{p.d[i+5], p.d[i+6]}
-			contour = append(contour, poly2tri.NewPoint(end.X, end.Y))
-			beziers = append(beziers, [5]Point{start, cp1, cp2, end, {1.0, 1.0}})
-		case ArcToCmd:
-			panic("arcs should have been replaced")
+	pms := make([]*Path, len(tiler.Ms))
+	rm := Rect{}
+	for k, m := range tiler.Ms {
+		pms[k] = p.Transform(m)
+		if k == 0 {
+			rm = pms[k].Bounds()
+		} else {
+			rm = rm.Add(pms[k].Bounds())
 		}
-		i += cmdLen(cmd)
-		start = end
 	}
 
-	swctx := poly2tri.NewSweepContext(contour, false)
-	swctx.Triangulate()
+	// find the range of lattice positions for which the motif's bounds overlap the boundary's bounds
+	rb := boundary.Bounds()
+	imin, imax := math.Inf(1), math.Inf(-1)
+	jmin, jmax := math.Inf(1), math.Inf(-1)
+	for _, pos := range []Point{
+		{rb.X - rm.X - rm.W, rb.Y - rm.Y - rm.H},
+		{rb.X + rb.W - rm.X, rb.Y - rm.Y - rm.H},
+		{rb.X + rb.W - rm.X, rb.Y + rb.H - rm.Y},
+		{rb.X - rm.X - rm.W, rb.Y + rb.H - rm.Y},
+	} {
+		// solve pos = i*a + j*b
+		i := pos.PerpDot(b) / det
+		j := a.PerpDot(pos) / det
+		imin, imax = math.Min(imin, i), math.Max(imax, i)
+		jmin, jmax = math.Min(jmin, j), math.Max(jmax, j)
+	}
 
-	triangles := [][3]Point{}
-	for _, tr := range swctx.GetTriangles() {
-		p0 := Point{tr.Points[0].X, tr.Points[0].Y}
-		p1 := Point{tr.Points[1].X, tr.Points[1].Y}
-		p2 := Point{tr.Points[2].X, tr.Points[2].Y}
-		triangles = append(triangles, [3]Point{p0, p1, p2})
+	pt := &Path{}
+	for j := int(math.Floor(jmin)); j <= int(math.Ceil(jmax)); j++ {
+		for i := int(math.Floor(imin)); i <= int(math.Ceil(imax)); i++ {
+			pos := a.Mul(float64(i)).Add(b.Mul(float64(j)))
+			for _, pm := range pms {
+				r := pm.Bounds().Move(pos)
+				if r.X+r.W < rb.X || rb.X+rb.W < r.X || r.Y+r.H < rb.Y || rb.Y+rb.H < r.Y {
+					continue
+				}
+				pt = pt.Append(pm.Translate(pos.X, pos.Y).And(boundary))
+			}
+		}
 	}
-	return triangles, beziers
+	return pt
 }
@@ -0,0 +1,119 @@
+package canvas
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/tdewolff/test"
+)
+
+func polygonArea(p *Path) float64 {
+	area := 0.0
+	for _, ps := range p.Flatten().Split() {
+		a := 0.0
+		coords := ps.Coords()
+		for i := range coords {
+			j := (i + 1) % len(coords)
+			a += coords[i].PerpDot(coords[j])
+		}
+		area += a / 2.0
+	}
+	return area
+}
+
+func TestTilers(t *testing.T) {
+	var tests = []struct {
+		name  string
+		tiler Tiler
+	}{
+		{"p1", P1(3.0, 2.0, 70.0)},
+		{"p2", P2(3.0, 2.0, 70.0)},
+		{"pm", Pm(3.0, 2.0)},
+		{"pg", Pg(3.0, 2.0)},
+		{"cm", Cm(3.0, 2.0)},
+		{"pmm", Pmm(3.0, 2.0)},
+		{"pmg", Pmg(3.0, 2.0)},
+		{"pgg", Pgg(3.0, 2.0)},
+		{"cmm", Cmm(3.0, 2.0)},
+		{"p4", P4(3.0)},
+		{"p4m", P4m(3.0)},
+		{"p4g", P4g(3.0)},
+		{"p3", P3(3.0)},
+		{"p3m1", P3m1(3.0)},
+		{"p31m", P31m(3.0)},
+		{"p6", P6(3.0)},
+		{"p6m", P6m(3.0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b, ms := tt.tiler.A, tt.tiler.B, tt.tiler.Ms
+			det := math.Abs(a.PerpDot(b))
+
+			// symmetry transformations form a group modulo lattice translations
+			for _, m0 := range ms {
+				for _, m1 := range ms {
+					m := m0.Mul(m1)
+					found := false
+					for _, m2 := range ms {
+						d := Point{m[0][2] - m2[0][2], m[1][2] - m2[1][2]}
+						i, j := d.PerpDot(b)/a.PerpDot(b), a.PerpDot(d)/a.PerpDot(b)
+						m2 = Identity.Translate(d.X, d.Y).Mul(m2)
+						if m.Equals(m2) && Equal(i, math.Round(i)) && Equal(j, math.Round(j)) {
+							found = true
+							break
+						}
+					}
+					test.That(t, found, fmt.Sprintf("%v·%v not in group", m0, m1))
+				}
+			}
+
+			// images of the fundamental domain fill the plane without overlap
+			domain := tt.tiler.Domain
+			test.Float(t, math.Abs(polygonArea(domain))*float64(len(ms)), det)
+
+			tiles := domain.Tile(3, 3, tt.tiler)
+			sum := 0.0
+			for _, tile := range tiles.Split() {
+				sum += math.Abs(polygonArea(tile))
+			}
+			test.Float(t, sum, 9.0*det)
+
+			// every point in the centre cell is covered exactly once
+			ps := tiles.Split()
+			for y := 0.0531; y < 1.0; y += 0.0913 {
+				for x := 0.0317; x < 1.0; x += 0.0871 {
+					pos := a.Mul(1.0 + x).Add(b.Mul(1.0 + y))
+					n := 0
+					for _, tile := range ps {
+						if interior, boundary := tile.Interior(pos.X, pos.Y, NonZero); interior && !boundary {
+							n++
+						}
+					}
+					test.T(t, n, 1, pos)
+				}
+			}
+		})
+	}
+}
+
+func TestPathTileIn(t *testing.T) {
+	boundary := MustParseSVG("M0 -5L5 0L0 5L-5 0z")
+	tiler := Pmm(2.0, 2.0)
+	tiles := tiler.Domain.TileIn(boundary, tiler)
+	test.T(t, tiles.Bounds(), Rect{-5.0, -5.0, 10.0, 10.0})
+	sum := 0.0
+	for _, tile := range tiles.Split() {
+		sum += polygonArea(tile)
+	}
+	test.Float(t, sum, 50.0)
+
+	// copies are clipped separately and are not merged
+	test.T(t, len(tiles.Split()), 2*(10+8+6+4+2))
+
+	test.That(t, Rectangle(1.0, 1.0).TileIn(&Path{}, tiler).Empty())
+
+	tiles = Rectangle(1.0, 1.0).TileIn(Rectangle(1.0, 1.0).Translate(0.25, 0.25), tiler)
+	test.T(t, len(tiles.Split()), 4)
+	test.T(t, tiles.Bounds(), Rect{0.25, 0.25, 1.0, 1.0})
+}
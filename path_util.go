@@ -144,7 +144,18 @@ func ellipseSplit(rx, ry, phi, cx, cy, theta0, theta1, theta float64) (Point, bo
 func arcToQuad(start Point, rx, ry, phi float64, large, sweep bool, end Point) *Path {
 	p := &Path{}
 	p.MoveTo(start.X, start.Y)
-	for _, bezier := range ellipseToQuadraticBeziers(start, rx, ry, phi, large, sweep, end) {
+	for _, bezier := range ellipseToQuadraticBeziers(start, rx, ry, phi, large, sweep, end, math.Pi/2.0) {
+		p.QuadTo(bezier[1].X, bezier[1].Y, bezier[2].X, bezier[2].Y)
+	}
+	return p
+}
+
+// arcToQuadTolerance replaces the arc by quadratic Béziers that deviate at most Tolerance from the arc. For a circular arc of angle 2a, the middle of the quadratic Bézier deviates r*(1-cos(a))^2/(2*cos(a)) ≈ r*a^4/8 from the arc.
+func arcToQuadTolerance(start Point, rx, ry, phi float64, large, sweep bool, end Point) *Path {
+	dtheta := math.Min(math.Pi/2.0, 2.0*math.Pow(8.0*Tolerance/math.Max(rx, ry), 0.25))
+	p := &Path{}
+	p.MoveTo(start.X, start.Y)
+	for _, bezier := range ellipseToQuadraticBeziers(start, rx, ry, phi, large, sweep, end, dtheta) {
 		p.QuadTo(bezier[1].X, bezier[1].Y, bezier[2].X, bezier[2].Y)
 	}
 	return p
@@ -188,10 +199,10 @@ func arcToCube(start Point, rx, ry, phi float64, large, sweep bool, end Point) *
 //}
 
 // see Drawing and elliptical arc using polylines, quadratic or cubic Bézier curves (2003), L. Maisonobe, https://spaceroots.org/documents/ellipse/elliptical-arc.pdf
-func ellipseToQuadraticBeziers(start Point, rx, ry, phi float64, large, sweep bool, end Point) [][3]Point {
+// ellipseToQuadraticBeziers approximates the arc by quadratic Béziers that each span at most dtheta radians.
+func ellipseToQuadraticBeziers(start Point, rx, ry, phi float64, large, sweep bool, end Point, dtheta float64) [][3]Point {
 	cx, cy, theta0, theta1 := ellipseToCenter(start.X, start.Y, rx, ry, phi, large, sweep, end.X, end.Y)
 
-	dtheta := math.Pi / 2.0 // TODO: use error measure to determine dtheta?
 	n := int(math.Ceil(math.Abs(theta1-theta0) / dtheta))
 	dtheta = math.Abs(theta1-theta0) / float64(n) // evenly spread the n points, dalpha will get smaller
 	kappa := math.Tan(dtheta / 2.0)
@@ -0,0 +1,63 @@
+package canvas
+
+import "math"
+
+// Pattern is a paint that repeats a canvas over the plane, such as the hatching of cut surfaces in engineering drawings. The pattern cell spans from the origin to (W+Spacing.X, H+Spacing.Y) in the coordinate system of the canvas, so that Spacing is the empty space between neighbouring tiles, and content outside the cell is clipped. Matrix is the tile matrix that transforms the pattern's coordinate system to the coordinate system of the path it paints, and thus patterns transform along with the path like gradients.
+type Pattern struct {
+	Canvas  *Canvas
+	Matrix  Matrix
+	Spacing Point
+}
+
+// NewPattern returns a new pattern that repeats the canvas with spacing (sx,sy) between the tiles, where m is the tile matrix that for example rotates or scales the pattern.
+func NewPattern(c *Canvas, m Matrix, sx, sy float64) *Pattern {
+	return &Pattern{
+		Canvas:  c,
+		Matrix:  m,
+		Spacing: Point{sx, sy},
+	}
+}
+
+// Cell returns the rectangle of a single tile in the pattern's coordinate system, its width and height are the distances between neighbouring tiles.
+func (p *Pattern) Cell() Rect {
+	return Rect{0.0, 0.0, p.Canvas.W + p.Spacing.X, p.Canvas.H + p.Spacing.Y}
+}
+
+// Empty returns true if the pattern paints nothing, which is when the canvas is empty or the cell has no area.
+func (p *Pattern) Empty() bool {
+	cell := p.Cell()
+	return p.Canvas.Empty() || cell.W <= 0.0 || cell.H <= 0.0 || p.Matrix.Det() == 0.0
+}
+
+// Tiles returns the transformations from the pattern's coordinate system to the path's coordinate system of all tiles that overlap with the given rectangle, which is in the path's coordinate system.
+func (p *Pattern) Tiles(rect Rect) []Matrix {
+	if p.Empty() {
+		return nil
+	}
+	cell := p.Cell()
+	bounds := rect.Transform(p.Matrix.Inv())
+	i0, i1 := int(math.Floor(bounds.X/cell.W)), int(math.Ceil((bounds.X+bounds.W)/cell.W))
+	j0, j1 := int(math.Floor(bounds.Y/cell.H)), int(math.Ceil((bounds.Y+bounds.H)/cell.H))
+	ms := make([]Matrix, 0, (i1-i0)*(j1-j0))
+	for j := j0; j < j1; j++ {
+		for i := i0; i < i1; i++ {
+			ms = append(ms, p.Matrix.Translate(float64(i)*cell.W, float64(j)*cell.H))
+		}
+	}
+	return ms
+}
+
+// RenderFill fills the path with the pattern by clipping to the path and rendering all tiles that cover it, where m transforms the path and the pattern. This is used by renderers that do not support patterns natively.
+func (p *Pattern) RenderFill(r Renderer, path *Path, fillRule FillRule, m Matrix) {
+	if path.Empty() || p.Empty() {
+		return
+	}
+	cell := p.Cell()
+	r.PushClip(path, fillRule, m)
+	for _, tile := range p.Tiles(path.Bounds()) {
+		r.PushClip(Rectangle(cell.W, cell.H), NonZero, m.Mul(tile))
+		p.Canvas.RenderTo(RendererViewer{r, m.Mul(tile)})
+		r.PopClip()
+	}
+	r.PopClip()
+}
@@ -0,0 +1,72 @@
+package canvas
+
+import (
+	"testing"
+
+	"github.com/tdewolff/test"
+)
+
+func TestPattern(t *testing.T) {
+	cell := New(2.0, 1.0)
+	NewContext(cell).DrawPath(0.0, 0.0, Rectangle(1.0, 1.0))
+
+	pattern := NewPattern(cell, Identity.Translate(0.5, 0.0), 1.0, 1.0)
+	test.T(t, pattern.Cell(), Rect{0.0, 0.0, 3.0, 2.0})
+	test.That(t, !pattern.Empty())
+	test.That(t, NewPattern(New(2.0, 1.0), Identity, 1.0, 1.0).Empty())
+	test.That(t, NewPattern(cell, Identity, -2.0, 1.0).Empty())
+	test.That(t, NewPattern(cell, Identity.Scale(0.0, 1.0), 1.0, 1.0).Empty())
+
+	tiles := pattern.Tiles(Rect{0.0, 0.0, 6.0, 2.0})
+	test.T(t, len(tiles), 3)
+	test.T(t, tiles[0], Identity.Translate(-2.5, 0.0))
+	test.T(t, tiles[1], Identity.Translate(0.5, 0.0))
+	test.T(t, tiles[2], Identity.Translate(3.5, 0.0))
+	test.T(t, len(pattern.Tiles(Rect{0.0, 0.0, 6.0, 4.5})), 9)
+
+	r := &Recorder{}
+	pattern.RenderFill(r, Rectangle(6.0, 2.0), NonZero, Identity.Translate(10.0, 10.0))
+	test.T(t, recordedOps(r), []string{"push (10,10)", "push (7.5,10)", "path", "pop", "push (10.5,10)", "path", "pop", "push (13.5,10)", "path", "pop", "pop"})
+}
+
+func TestContextPattern(t *testing.T) {
+	pattern := NewPattern(New(1.0, 1.0), Identity, 0.0, 0.0)
+	ctx := NewContext(New(100, 100))
+	ctx.SetFillPattern(pattern)
+	ctx.SetStrokePattern(pattern)
+	test.T(t, ctx.Style.FillPattern, pattern)
+	test.T(t, ctx.Style.StrokePattern, pattern)
+
+	ctx.SetFillColor(Red)
+	ctx.SetStrokeGradient(NewLinearGradient(Point{0.0, 0.0}, Point{1.0, 0.0}))
+	test.T(t, ctx.Style.FillPattern, (*Pattern)(nil))
+	test.T(t, ctx.Style.StrokePattern, (*Pattern)(nil))
+}
+
+func TestContextPatternFillStroke(t *testing.T) {
+	pattern := NewPattern(New(1.0, 1.0), Identity, 0.0, 0.0)
+	c := New(100, 100)
+	ctx := NewContext(c)
+	ctx.SetFillPattern(pattern)
+	ctx.SetStrokePattern(pattern)
+	ctx.SetStrokeWidth(1.0)
+
+	ctx.MoveTo(0.0, 0.0)
+	ctx.LineTo(10.0, 0.0)
+	ctx.LineTo(10.0, 10.0)
+	ctx.Fill()
+	ctx.MoveTo(0.0, 0.0)
+	ctx.LineTo(10.0, 0.0)
+	ctx.LineTo(10.0, 10.0)
+	ctx.Stroke()
+	ctx.SetDashes(0.0, 0.0) // no stroke
+	ctx.DrawPath(0.0, 0.0, Rectangle(10.0, 10.0))
+
+	layers := c.layers[0]
+	test.T(t, len(layers), 3)
+	test.That(t, layers[0].style.HasFill() && !layers[0].style.HasStroke(), "Fill must only fill")
+	test.That(t, !layers[1].style.HasFill() && layers[1].style.HasStroke(), "Stroke must only stroke")
+	test.That(t, layers[2].style.HasFill() && !layers[2].style.HasStroke(), "invalid dashes must not stroke")
+	test.T(t, ctx.Style.FillPattern, pattern)
+	test.T(t, ctx.Style.StrokePattern, pattern)
+}
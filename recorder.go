@@ -0,0 +1,74 @@
+package canvas
+
+import "image"
+
+// RecordedOp is a drawing operation received by a Recorder. Op is the name of the operation, which is "path", "text", "image", "clip", "pop", "group", "end", "link", or "dest", and the other fields are the arguments of the operation.
+type RecordedOp struct {
+	Op       string
+	Path     *Path // path of "path" and "clip"
+	Style    Style
+	FillRule FillRule
+	Text     *Text
+	Image    image.Image
+	Group    Group
+	Name     string // URI of "link" and name of "dest"
+	Rect     Rect
+	Pos      Point
+	Matrix   Matrix
+}
+
+// Recorder is a renderer that records the drawing operations it receives in order, which allows to inspect how a canvas is rendered, for example in tests. It also records links and destinations.
+type Recorder struct {
+	W, H float64
+	Ops  []RecordedOp
+}
+
+// Size returns the size of the recorder.
+func (r *Recorder) Size() (float64, float64) {
+	return r.W, r.H
+}
+
+// RenderPath records a path.
+func (r *Recorder) RenderPath(path *Path, style Style, m Matrix) {
+	r.Ops = append(r.Ops, RecordedOp{Op: "path", Path: path, Style: style, Matrix: m})
+}
+
+// RenderText records a text.
+func (r *Recorder) RenderText(text *Text, m Matrix) {
+	r.Ops = append(r.Ops, RecordedOp{Op: "text", Text: text, Matrix: m})
+}
+
+// RenderImage records an image.
+func (r *Recorder) RenderImage(img image.Image, m Matrix) {
+	r.Ops = append(r.Ops, RecordedOp{Op: "image", Image: img, Matrix: m})
+}
+
+// PushClip records a clipping path.
+func (r *Recorder) PushClip(path *Path, fillRule FillRule, m Matrix) {
+	r.Ops = append(r.Ops, RecordedOp{Op: "clip", Path: path, FillRule: fillRule, Matrix: m})
+}
+
+// PopClip records the removal of the last clipping path.
+func (r *Recorder) PopClip() {
+	r.Ops = append(r.Ops, RecordedOp{Op: "pop"})
+}
+
+// PushGroup records the start of a group.
+func (r *Recorder) PushGroup(group Group, m Matrix) {
+	r.Ops = append(r.Ops, RecordedOp{Op: "group", Group: group, Matrix: m})
+}
+
+// PopGroup records the end of the last group.
+func (r *Recorder) PopGroup() {
+	r.Ops = append(r.Ops, RecordedOp{Op: "end"})
+}
+
+// RenderLink records a link.
+func (r *Recorder) RenderLink(uri string, rect Rect, m Matrix) {
+	r.Ops = append(r.Ops, RecordedOp{Op: "link", Name: uri, Rect: rect, Matrix: m})
+}
+
+// RenderDestination records a named destination.
+func (r *Recorder) RenderDestination(name string, pos Point, m Matrix) {
+	r.Ops = append(r.Ops, RecordedOp{Op: "dest", Name: name, Pos: pos, Matrix: m})
+}
@@ -0,0 +1,1069 @@
+package pdf
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/LaminoidStudio/Canvas"
+)
+
+// pdfColorSpace converts color components of a PDF color space to RGB.
+type pdfColorSpace interface {
+	// Components returns the number of color components.
+	Components() int
+
+	// RGB returns the opaque color for the given components.
+	RGB([]float64) color.RGBA
+
+	// Initial returns the initial color components when the color space is set.
+	Initial() []float64
+}
+
+type pdfDeviceGray struct{}
+
+func (pdfDeviceGray) Components() int    { return 1 }
+func (pdfDeviceGray) Initial() []float64 { return []float64{0.0} }
+func (pdfDeviceGray) RGB(c []float64) color.RGBA {
+	v := toByte(component(c, 0))
+	return color.RGBA{v, v, v, 255}
+}
+
+type pdfDeviceRGB struct{}
+
+func (pdfDeviceRGB) Components() int    { return 3 }
+func (pdfDeviceRGB) Initial() []float64 { return []float64{0.0, 0.0, 0.0} }
+func (pdfDeviceRGB) RGB(c []float64) color.RGBA {
+	return color.RGBA{toByte(component(c, 0)), toByte(component(c, 1)), toByte(component(c, 2)), 255}
+}
+
+type pdfDeviceCMYK struct{}
+
+func (pdfDeviceCMYK) Components() int    { return 4 }
+func (pdfDeviceCMYK) Initial() []float64 { return []float64{0.0, 0.0, 0.0, 1.0} }
+func (pdfDeviceCMYK) RGB(c []float64) color.RGBA {
+	k := component(c, 3)
+	return color.RGBA{toByte((1.0 - component(c, 0)) * (1.0 - k)), toByte((1.0 - component(c, 1)) * (1.0 - k)), toByte((1.0 - component(c, 2)) * (1.0 - k)), 255}
+}
+
+// pdfICCBased is a color space defined by an ICC profile, which is converted to sRGB.
+type pdfICCBased struct {
+	profile *canvas.ICCProfile
+}
+
+func (cs pdfICCBased) Components() int { return cs.profile.Components }
+
+func (cs pdfICCBased) Initial() []float64 {
+	return make([]float64, cs.profile.Components)
+}
+
+func (cs pdfICCBased) RGB(c []float64) color.RGBA {
+	r, g, b := cs.profile.ToRGB(c)
+	return color.RGBA{toByte(r), toByte(g), toByte(b), 255}
+}
+
+// pdfLab is the CIE L*a*b* color space, which is converted to sRGB.
+type pdfLab struct {
+	whitePoint [3]float64
+	rng        [4]float64
+}
+
+func (pdfLab) Components() int { return 3 }
+
+func (cs pdfLab) Initial() []float64 {
+	return []float64{0.0, math.Max(cs.rng[0], math.Min(cs.rng[1], 0.0)), math.Max(cs.rng[2], math.Min(cs.rng[3], 0.0))}
+}
+
+func (cs pdfLab) RGB(c []float64) color.RGBA {
+	L, a, b := component100(c, 0), 0.0, 0.0
+	if 1 < len(c) {
+		a = c[1]
+	}
+	if 2 < len(c) {
+		b = c[2]
+	}
+	g := func(x float64) float64 {
+		if 6.0/29.0 <= x {
+			return x * x * x
+		}
+		return 108.0 / 841.0 * (x - 4.0/29.0)
+	}
+	M := (L + 16.0) / 116.0
+	X := cs.whitePoint[0] * g(M+a/500.0)
+	Y := cs.whitePoint[1] * g(M)
+	Z := cs.whitePoint[2] * g(M-b/200.0)
+
+	// XYZ to linear sRGB with D65 white point and sRGB gamma
+	gamma := func(v float64) float64 {
+		v = math.Max(0.0, math.Min(1.0, v))
+		if v <= 0.0031308 {
+			return 12.92 * v
+		}
+		return 1.055*math.Pow(v, 1.0/2.4) - 0.055
+	}
+	R := 3.2406*X - 1.5372*Y - 0.4986*Z
+	G := -0.9689*X + 1.8758*Y + 0.0415*Z
+	B := 0.0557*X - 0.2040*Y + 1.0570*Z
+	return color.RGBA{toByte(gamma(R)), toByte(gamma(G)), toByte(gamma(B)), 255}
+}
+
+// pdfIndexed is a color space where each component is an index into a color table of the base color space.
+type pdfIndexed struct {
+	base   pdfColorSpace
+	hival  int
+	lookup []byte
+}
+
+func (pdfIndexed) Components() int    { return 1 }
+func (pdfIndexed) Initial() []float64 { return []float64{0.0} }
+func (cs pdfIndexed) RGB(c []float64) color.RGBA {
+	index := 0
+	if 0 < len(c) {
+		index = int(math.Round(clip(c[0], 0.0, float64(cs.hival))))
+	}
+	n := cs.base.Components()
+	vals := make([]float64, n)
+	for i := range vals {
+		if j := index*n + i; j < len(cs.lookup) {
+			vals[i] = float64(cs.lookup[j]) / 255.0
+		}
+	}
+	if _, ok := cs.base.(pdfLab); ok {
+		// Lab components are stored over their range
+		lab := cs.base.(pdfLab)
+		vals[0] *= 100.0
+		vals[1] = lab.rng[0] + vals[1]*(lab.rng[1]-lab.rng[0])
+		vals[2] = lab.rng[2] + vals[2]*(lab.rng[3]-lab.rng[2])
+	}
+	return cs.base.RGB(vals)
+}
+
+// pdfSeparation is a Separation or DeviceN color space that converts its tints to the alternate color space using a tint transform function.
+type pdfSeparation struct {
+	n     int
+	none  bool // the None colorant is never painted
+	alt   pdfColorSpace
+	tint  pdfFunction
+	cache map[float64]color.RGBA
+}
+
+func (cs pdfSeparation) Components() int { return cs.n }
+
+func (cs pdfSeparation) Initial() []float64 {
+	c := make([]float64, cs.n)
+	for i := range c {
+		c[i] = 1.0
+	}
+	return c
+}
+
+func (cs pdfSeparation) RGB(c []float64) color.RGBA {
+	if cs.none {
+		return color.RGBA{}
+	}
+	if cs.n == 1 && cs.cache != nil {
+		if col, ok := cs.cache[component(c, 0)]; ok {
+			return col
+		}
+	}
+	col := cs.alt.RGB(cs.tint.Call(c))
+	if cs.n == 1 && cs.cache != nil && len(cs.cache) < 1024 {
+		cs.cache[component(c, 0)] = col
+	}
+	return col
+}
+
+// pdfPatternSpace is the Pattern color space. For uncolored tiling patterns, the base color space specifies the pattern's color.
+type pdfPatternSpace struct {
+	base pdfColorSpace
+}
+
+func (cs pdfPatternSpace) Components() int {
+	if cs.base == nil {
+		return 0
+	}
+	return cs.base.Components()
+}
+
+func (cs pdfPatternSpace) Initial() []float64 {
+	if cs.base == nil {
+		return nil
+	}
+	return cs.base.Initial()
+}
+
+func (cs pdfPatternSpace) RGB(c []float64) color.RGBA {
+	if cs.base == nil {
+		return canvas.Black
+	}
+	return cs.base.RGB(c)
+}
+
+func component(c []float64, i int) float64 {
+	if i < len(c) {
+		return math.Max(0.0, math.Min(1.0, c[i]))
+	}
+	return 0.0
+}
+
+func component100(c []float64, i int) float64 {
+	if i < len(c) {
+		return math.Max(0.0, math.Min(100.0, c[i]))
+	}
+	return 0.0
+}
+
+func toByte(v float64) uint8 {
+	return uint8(math.Max(0.0, math.Min(255.0, v*255.0+0.5)))
+}
+
+// deviceColorSpace returns the color space with the given number of components.
+func deviceColorSpace(n int) pdfColorSpace {
+	switch n {
+	case 1:
+		return pdfDeviceGray{}
+	case 4:
+		return pdfDeviceCMYK{}
+	}
+	return pdfDeviceRGB{}
+}
+
+// GetColorSpace returns the color space for a name or array, looking up names in the ColorSpace resources.
+func (r *pdfReader) GetColorSpace(val interface{}, resources pdfDict) (pdfColorSpace, error) {
+	return r.getColorSpace(val, resources, 0)
+}
+
+func (r *pdfReader) getColorSpace(val interface{}, resources pdfDict, depth int) (pdfColorSpace, error) {
+	if 8 < depth {
+		return nil, fmt.Errorf("color space nested too deeply")
+	}
+
+	val, err := r.get(val)
+	if err != nil {
+		return nil, err
+	}
+	if name, ok := val.(pdfName); ok {
+		switch name {
+		case "DeviceGray", "G", "CalGray":
+			return pdfDeviceGray{}, nil
+		case "DeviceRGB", "RGB", "CalRGB":
+			return pdfDeviceRGB{}, nil
+		case "DeviceCMYK", "CMYK":
+			return pdfDeviceCMYK{}, nil
+		case "Pattern":
+			return pdfPatternSpace{}, nil
+		}
+		colorSpaces, _ := r.GetDict(resources["ColorSpace"])
+		if cs, ok := colorSpaces[name]; ok {
+			return r.getColorSpace(cs, resources, depth+1)
+		}
+		return nil, fmt.Errorf("unknown color space %v", name)
+	}
+
+	array, ok := val.(pdfArray)
+	if !ok || len(array) == 0 {
+		return nil, fmt.Errorf("bad color space")
+	}
+	family, err := r.GetName(array[0])
+	if err != nil {
+		return nil, fmt.Errorf("bad color space")
+	}
+	switch family {
+	case "DeviceGray", "G", "CalGray", "DeviceRGB", "RGB", "CalRGB", "DeviceCMYK", "CMYK":
+		return r.getColorSpace(family, resources, depth+1)
+	case "Lab":
+		cs := pdfLab{whitePoint: [3]float64{0.9505, 1.0, 1.089}, rng: [4]float64{-100.0, 100.0, -100.0, 100.0}}
+		if 1 < len(array) {
+			dict, _ := r.GetDict(array[1])
+			if wp, err := r.GetFloats(dict["WhitePoint"]); err == nil && len(wp) == 3 {
+				copy(cs.whitePoint[:], wp)
+			}
+			if rng, err := r.GetFloats(dict["Range"]); err == nil && len(rng) == 4 {
+				copy(cs.rng[:], rng)
+			}
+		}
+		return cs, nil
+	case "ICCBased":
+		if len(array) < 2 {
+			return nil, fmt.Errorf("bad ICCBased color space")
+		}
+		stream, err := r.GetStream(array[1])
+		if err != nil {
+			return nil, fmt.Errorf("bad ICCBased color space: %w", err)
+		}
+		dict := stream.dict
+		n, _ := r.GetInt(dict["N"])
+		if profile, err := canvas.ParseICCProfile(stream.stream); err == nil && profile.Components == n {
+			return pdfICCBased{profile}, nil
+		}
+		if alt, ok := dict["Alternate"]; ok {
+			if cs, err := r.getColorSpace(alt, resources, depth+1); err == nil {
+				return cs, nil
+			}
+		}
+		return deviceColorSpace(n), nil
+	case "Indexed", "I":
+		if len(array) != 4 {
+			return nil, fmt.Errorf("bad Indexed color space")
+		}
+		base, err := r.getColorSpace(array[1], resources, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		hival, err := r.GetInt(array[2])
+		if err != nil || hival < 0 || 255 < hival {
+			return nil, fmt.Errorf("bad Indexed color space")
+		}
+		lookup, err := r.GetString(array[3])
+		if err != nil {
+			stream, err := r.GetStream(array[3])
+			if err != nil {
+				return nil, fmt.Errorf("bad Indexed color space")
+			}
+			lookup = stream.stream
+		}
+		return pdfIndexed{base, hival, lookup}, nil
+	case "Separation", "DeviceN":
+		if len(array) < 4 {
+			return nil, fmt.Errorf("bad %v color space", family)
+		}
+		cs := pdfSeparation{n: 1, cache: map[float64]color.RGBA{}}
+		if family == "Separation" {
+			name, _ := r.GetName(array[1])
+			cs.none = name == "None"
+		} else {
+			names, err := r.GetArray(array[1])
+			if err != nil || len(names) == 0 {
+				return nil, fmt.Errorf("bad DeviceN color space")
+			}
+			cs.n = len(names)
+			cs.none = true
+			for _, item := range names {
+				if name, _ := r.GetName(item); name != "None" {
+					cs.none = false
+				}
+			}
+		}
+		if cs.alt, err = r.getColorSpace(array[2], resources, depth+1); err != nil {
+			return nil, err
+		} else if cs.tint, err = r.GetFunction(array[3]); err != nil {
+			return nil, err
+		}
+		return cs, nil
+	case "Pattern":
+		if len(array) < 2 {
+			return pdfPatternSpace{}, nil
+		}
+		base, err := r.getColorSpace(array[1], resources, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		return pdfPatternSpace{base}, nil
+	}
+	return nil, fmt.Errorf("unsupported color space %v", family)
+}
+
+////////////////////////////////////////////////////////////////
+
+// pdfFunction is a PDF function that maps input values to output values.
+type pdfFunction interface {
+	Call([]float64) []float64
+}
+
+func clip(v, min, max float64) float64 {
+	return math.Max(min, math.Min(max, v))
+}
+
+func interpolate(x, xmin, xmax, ymin, ymax float64) float64 {
+	if xmax == xmin {
+		return ymin
+	}
+	return ymin + (x-xmin)*(ymax-ymin)/(xmax-xmin)
+}
+
+// clipRange clips the values to the range given by pairs of minimum and maximum values.
+func clipRange(vs, rng []float64) []float64 {
+	for i := range vs {
+		if 2*i+1 < len(rng) {
+			vs[i] = clip(vs[i], rng[2*i], rng[2*i+1])
+		}
+	}
+	return vs
+}
+
+// pdfFunctions is an array of functions with one output value each.
+type pdfFunctions []pdfFunction
+
+func (fs pdfFunctions) Call(in []float64) []float64 {
+	out := make([]float64, 0, len(fs))
+	for _, f := range fs {
+		out = append(out, f.Call(in)...)
+	}
+	return out
+}
+
+// pdfSampledFunction is a function of type 0, which interpolates between sample values.
+type pdfSampledFunction struct {
+	domain, rng    []float64
+	encode, decode []float64
+	size           []int
+	bps            int
+	samples        []byte
+}
+
+func (f pdfSampledFunction) sample(index, j int) float64 {
+	n := len(f.rng) / 2
+	pos := (index*n + j) * f.bps
+	v := uint32(0)
+	for k := 0; k < f.bps; k++ {
+		byteIndex := (pos + k) / 8
+		if len(f.samples) <= byteIndex {
+			break
+		}
+		v = v<<1 | uint32(f.samples[byteIndex]>>(7-uint((pos+k)%8))&1)
+	}
+	max := math.Pow(2.0, float64(f.bps)) - 1.0
+	return interpolate(float64(v), 0.0, max, f.decode[2*j], f.decode[2*j+1])
+}
+
+func (f pdfSampledFunction) Call(in []float64) []float64 {
+	// use multilinear interpolation for the first input and nearest samples for the others
+	index, stride := 0, 1
+	var frac float64
+	var next int
+	for i := range f.size {
+		x := 0.0
+		if i < len(in) {
+			x = in[i]
+		}
+		x = clip(x, f.domain[2*i], f.domain[2*i+1])
+		e := interpolate(x, f.domain[2*i], f.domain[2*i+1], f.encode[2*i], f.encode[2*i+1])
+		e = clip(e, 0.0, float64(f.size[i]-1))
+		if i == 0 {
+			e0 := math.Floor(e)
+			frac = e - e0
+			index += int(e0)
+			if int(e0)+1 < f.size[0] {
+				next = 1
+			}
+		} else {
+			index += int(math.Round(e)) * stride
+		}
+		stride *= f.size[i]
+	}
+
+	out := make([]float64, len(f.rng)/2)
+	for j := range out {
+		v := f.sample(index, j)
+		if next != 0 && frac != 0.0 {
+			v += frac * (f.sample(index+next, j) - v)
+		}
+		out[j] = v
+	}
+	return clipRange(out, f.rng)
+}
+
+// pdfExponentialFunction is a function of type 2, which interpolates exponentially between C0 and C1.
+type pdfExponentialFunction struct {
+	domain, rng []float64
+	c0, c1      []float64
+	n           float64
+}
+
+func (f pdfExponentialFunction) Call(in []float64) []float64 {
+	x := 0.0
+	if 0 < len(in) {
+		x = in[0]
+	}
+	x = clip(x, f.domain[0], f.domain[1])
+	xn := math.Pow(x, f.n)
+	out := make([]float64, len(f.c0))
+	for i := range out {
+		out[i] = f.c0[i] + xn*(f.c1[i]-f.c0[i])
+	}
+	return clipRange(out, f.rng)
+}
+
+// pdfStitchingFunction is a function of type 3, which combines one-input functions over subdomains.
+type pdfStitchingFunction struct {
+	domain, rng    []float64
+	functions      []pdfFunction
+	bounds, encode []float64
+}
+
+func (f pdfStitchingFunction) Call(in []float64) []float64 {
+	x := 0.0
+	if 0 < len(in) {
+		x = in[0]
+	}
+	x = clip(x, f.domain[0], f.domain[1])
+	k := sort.Search(len(f.bounds), func(i int) bool { return x < f.bounds[i] })
+	lo, hi := f.domain[0], f.domain[1]
+	if 0 < k {
+		lo = f.bounds[k-1]
+	}
+	if k < len(f.bounds) {
+		hi = f.bounds[k]
+	}
+	e := interpolate(x, lo, hi, f.encode[2*k], f.encode[2*k+1])
+	return clipRange(f.functions[k].Call([]float64{e}), f.rng)
+}
+
+// pdfPostScriptFunction is a function of type 4, which is a program in a subset of the PostScript language.
+type pdfPostScriptFunction struct {
+	domain, rng []float64
+	program     []interface{} // float64, bool, string operators, and []interface{} procedures
+}
+
+func (f pdfPostScriptFunction) Call(in []float64) []float64 {
+	stack := make([]interface{}, 0, 16)
+	for i := 0; i < len(f.domain)/2; i++ {
+		x := 0.0
+		if i < len(in) {
+			x = in[i]
+		}
+		stack = append(stack, clip(x, f.domain[2*i], f.domain[2*i+1]))
+	}
+	stack, _ = runPostScript(f.program, stack, 0)
+
+	n := len(f.rng) / 2
+	out := make([]float64, n)
+	for i := range out {
+		if j := len(stack) - n + i; 0 <= j {
+			out[i], _ = stack[j].(float64)
+		}
+	}
+	return clipRange(out, f.rng)
+}
+
+var errPostScript = fmt.Errorf("bad PostScript function")
+
+func runPostScript(program []interface{}, stack []interface{}, depth int) ([]interface{}, error) {
+	if 32 < depth {
+		return stack, errPostScript
+	}
+	pop := func() (interface{}, error) {
+		if len(stack) == 0 {
+			return nil, errPostScript
+		}
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		return v, nil
+	}
+	popNum := func() (float64, error) {
+		v, err := pop()
+		if err != nil {
+			return 0.0, err
+		}
+		f, ok := v.(float64)
+		if !ok {
+			return 0.0, errPostScript
+		}
+		return f, nil
+	}
+
+	for pc := 0; pc < len(program); pc++ {
+		switch op := program[pc].(type) {
+		case float64, bool:
+			stack = append(stack, op)
+		case []interface{}:
+			// procedure operand of if or ifelse
+			if pc+1 < len(program) && program[pc+1] == "if" {
+				cond, err := pop()
+				if b, ok := cond.(bool); err != nil || !ok {
+					return stack, errPostScript
+				} else if b {
+					if stack, err = runPostScript(op, stack, depth+1); err != nil {
+						return stack, err
+					}
+				}
+				pc++
+			} else if pc+2 < len(program) && program[pc+2] == "ifelse" {
+				proc2, ok := program[pc+1].([]interface{})
+				cond, err := pop()
+				b, okBool := cond.(bool)
+				if !ok || err != nil || !okBool {
+					return stack, errPostScript
+				}
+				if !b {
+					op = proc2
+				}
+				if stack, err = runPostScript(op, stack, depth+1); err != nil {
+					return stack, err
+				}
+				pc += 2
+			} else {
+				return stack, errPostScript
+			}
+		case string:
+			switch op {
+			case "abs", "neg", "ceiling", "floor", "round", "truncate", "sqrt", "sin", "cos", "ln", "log", "exp", "cvi", "cvr":
+				var a, b float64
+				var err error
+				if op == "exp" {
+					if b, err = popNum(); err != nil {
+						return stack, err
+					}
+				}
+				if a, err = popNum(); err != nil {
+					return stack, err
+				}
+				switch op {
+				case "abs":
+					a = math.Abs(a)
+				case "neg":
+					a = -a
+				case "ceiling":
+					a = math.Ceil(a)
+				case "floor":
+					a = math.Floor(a)
+				case "round":
+					a = math.Floor(a + 0.5)
+				case "truncate", "cvi":
+					a = math.Trunc(a)
+				case "sqrt":
+					a = math.Sqrt(a)
+				case "sin":
+					a = math.Sin(a * math.Pi / 180.0)
+				case "cos":
+					a = math.Cos(a * math.Pi / 180.0)
+				case "ln":
+					a = math.Log(a)
+				case "log":
+					a = math.Log10(a)
+				case "exp":
+					a = math.Pow(a, b)
+				}
+				stack = append(stack, a)
+			case "add", "sub", "mul", "div", "idiv", "mod", "atan", "bitshift":
+				b, err1 := popNum()
+				a, err2 := popNum()
+				if err1 != nil || err2 != nil {
+					return stack, errPostScript
+				}
+				switch op {
+				case "add":
+					a += b
+				case "sub":
+					a -= b
+				case "mul":
+					a *= b
+				case "div":
+					if b == 0.0 {
+						return stack, errPostScript
+					}
+					a /= b
+				case "idiv", "mod":
+					if int(b) == 0 {
+						return stack, errPostScript
+					} else if op == "idiv" {
+						a = float64(int(a) / int(b))
+					} else {
+						a = float64(int(a) % int(b))
+					}
+				case "atan":
+					a = math.Atan2(a, b) * 180.0 / math.Pi
+					if a < 0.0 {
+						a += 360.0
+					}
+				case "bitshift":
+					if 0 <= b {
+						a = float64(int(a) << uint(b))
+					} else {
+						a = float64(int(a) >> uint(-b))
+					}
+				}
+				stack = append(stack, a)
+			case "eq", "ne", "gt", "ge", "lt", "le":
+				vb, err1 := pop()
+				va, err2 := pop()
+				if err1 != nil || err2 != nil {
+					return stack, errPostScript
+				}
+				if op == "eq" || op == "ne" {
+					stack = append(stack, (va == vb) == (op == "eq"))
+					break
+				}
+				a, ok1 := va.(float64)
+				b, ok2 := vb.(float64)
+				if !ok1 || !ok2 {
+					return stack, errPostScript
+				}
+				switch op {
+				case "gt":
+					stack = append(stack, a > b)
+				case "ge":
+					stack = append(stack, a >= b)
+				case "lt":
+					stack = append(stack, a < b)
+				case "le":
+					stack = append(stack, a <= b)
+				}
+			case "and", "or", "xor":
+				vb, err1 := pop()
+				va, err2 := pop()
+				if err1 != nil || err2 != nil {
+					return stack, errPostScript
+				}
+				if a, ok := va.(bool); ok {
+					b, _ := vb.(bool)
+					switch op {
+					case "and":
+						stack = append(stack, a && b)
+					case "or":
+						stack = append(stack, a || b)
+					case "xor":
+						stack = append(stack, a != b)
+					}
+				} else {
+					a, _ := va.(float64)
+					b, _ := vb.(float64)
+					switch op {
+					case "and":
+						stack = append(stack, float64(int(a)&int(b)))
+					case "or":
+						stack = append(stack, float64(int(a)|int(b)))
+					case "xor":
+						stack = append(stack, float64(int(a)^int(b)))
+					}
+				}
+			case "not":
+				v, err := pop()
+				if err != nil {
+					return stack, err
+				} else if b, ok := v.(bool); ok {
+					stack = append(stack, !b)
+				} else {
+					a, _ := v.(float64)
+					stack = append(stack, float64(^int(a)))
+				}
+			case "true", "false":
+				stack = append(stack, op == "true")
+			case "pop":
+				if _, err := pop(); err != nil {
+					return stack, err
+				}
+			case "dup":
+				if len(stack) == 0 {
+					return stack, errPostScript
+				}
+				stack = append(stack, stack[len(stack)-1])
+			case "exch":
+				if len(stack) < 2 {
+					return stack, errPostScript
+				}
+				stack[len(stack)-1], stack[len(stack)-2] = stack[len(stack)-2], stack[len(stack)-1]
+			case "copy":
+				n, err := popNum()
+				if err != nil || n < 0 || len(stack) < int(n) {
+					return stack, errPostScript
+				}
+				stack = append(stack, stack[len(stack)-int(n):]...)
+			case "index":
+				n, err := popNum()
+				if err != nil || n < 0 || len(stack) <= int(n) {
+					return stack, errPostScript
+				}
+				stack = append(stack, stack[len(stack)-1-int(n)])
+			case "roll":
+				j, err1 := popNum()
+				n, err2 := popNum()
+				if err1 != nil || err2 != nil || n < 0 || len(stack) < int(n) {
+					return stack, errPostScript
+				} else if 0 < int(n) {
+					items := append([]interface{}{}, stack[len(stack)-int(n):]...)
+					shift := ((int(j) % int(n)) + int(n)) % int(n)
+					for i := range items {
+						stack[len(stack)-int(n)+(i+shift)%int(n)] = items[i]
+					}
+				}
+			default:
+				return stack, errPostScript
+			}
+		}
+	}
+	return stack, nil
+}
+
+// parsePostScript parses the program of a PostScript calculator function enclosed in braces.
+func parsePostScript(b []byte) ([]interface{}, error) {
+	i := moveWhiteSpace(b, 0)
+	if len(b) <= i || b[i] != '{' {
+		return nil, errPostScript
+	}
+	program, _, err := parsePostScriptProc(b, i+1, 0)
+	return program, err
+}
+
+func parsePostScriptProc(b []byte, i, depth int) ([]interface{}, int, error) {
+	if 32 < depth {
+		return nil, 0, errPostScript
+	}
+	proc := []interface{}{}
+	for {
+		i = moveWhiteSpace(b, i)
+		if len(b) <= i {
+			return nil, 0, errPostScript
+		} else if b[i] == '}' {
+			return proc, i + 1, nil
+		} else if b[i] == '{' {
+			sub, n, err := parsePostScriptProc(b, i+1, depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			proc = append(proc, sub)
+			i = n
+		} else if val, n, err := pdfReadContentVal(b[i:]); err == nil {
+			switch v := val.(type) {
+			case int:
+				proc = append(proc, float64(v))
+			case float64:
+				proc = append(proc, v)
+			case bool:
+				proc = append(proc, v)
+			default:
+				return nil, 0, errPostScript
+			}
+			i += n
+		} else {
+			j := i
+			for j < len(b) && isRegular(b[j]) {
+				j++
+			}
+			if j == i {
+				return nil, 0, errPostScript
+			}
+			proc = append(proc, string(b[i:j]))
+			i = j
+		}
+	}
+}
+
+// GetFunction returns the function for a function dictionary or stream, or an array of functions.
+func (r *pdfReader) GetFunction(val interface{}) (pdfFunction, error) {
+	return r.getFunction(val, 0)
+}
+
+func (r *pdfReader) getFunction(val interface{}, depth int) (pdfFunction, error) {
+	if 8 < depth {
+		return nil, fmt.Errorf("function nested too deeply")
+	}
+	val, err := r.get(val)
+	if err != nil {
+		return nil, err
+	}
+	if array, ok := val.(pdfArray); ok {
+		fs := pdfFunctions{}
+		for _, item := range array {
+			f, err := r.getFunction(item, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			fs = append(fs, f)
+		}
+		return fs, nil
+	}
+
+	var dict pdfDict
+	var data []byte
+	if stream, ok := val.(pdfStream); ok {
+		dict, data = stream.dict, stream.stream
+	} else if dict, ok = val.(pdfDict); !ok {
+		return nil, fmt.Errorf("bad function")
+	}
+
+	functionType, err := r.GetInt(dict["FunctionType"])
+	if err != nil {
+		return nil, fmt.Errorf("bad function type")
+	}
+	domain, err := r.GetFloats(dict["Domain"])
+	if err != nil || len(domain) < 2 || len(domain)%2 != 0 {
+		return nil, fmt.Errorf("bad function domain")
+	}
+	rng, _ := r.GetFloats(dict["Range"])
+	if len(rng)%2 != 0 {
+		return nil, fmt.Errorf("bad function range")
+	}
+
+	switch functionType {
+	case 0:
+		f := pdfSampledFunction{domain: domain, rng: rng, samples: data}
+		if f.bps, err = r.GetInt(dict["BitsPerSample"]); err != nil || f.bps < 1 || 32 < f.bps {
+			return nil, fmt.Errorf("bad sampled function")
+		}
+		sizes, err := r.GetFloats(dict["Size"])
+		if err != nil || len(sizes) != len(domain)/2 || len(rng) == 0 {
+			return nil, fmt.Errorf("bad sampled function")
+		}
+		for _, size := range sizes {
+			if size < 1.0 {
+				return nil, fmt.Errorf("bad sampled function")
+			}
+			f.size = append(f.size, int(size))
+		}
+		if f.encode, err = r.GetFloats(dict["Encode"]); err != nil || len(f.encode) != len(domain) {
+			f.encode = make([]float64, len(domain))
+			for i, size := range f.size {
+				f.encode[2*i+1] = float64(size - 1)
+			}
+		}
+		if f.decode, err = r.GetFloats(dict["Decode"]); err != nil || len(f.decode) != len(rng) {
+			f.decode = rng
+		}
+		return f, nil
+	case 2:
+		f := pdfExponentialFunction{domain: domain, rng: rng, c0: []float64{0.0}, c1: []float64{1.0}}
+		if c0, err := r.GetFloats(dict["C0"]); err == nil {
+			f.c0 = c0
+		}
+		if c1, err := r.GetFloats(dict["C1"]); err == nil {
+			f.c1 = c1
+		}
+		if f.n, err = r.GetFloat(dict["N"]); err != nil || len(f.c0) != len(f.c1) {
+			return nil, fmt.Errorf("bad exponential function")
+		}
+		return f, nil
+	case 3:
+		f := pdfStitchingFunction{domain: domain, rng: rng}
+		functions, err := r.GetArray(dict["Functions"])
+		if err != nil || len(functions) == 0 {
+			return nil, fmt.Errorf("bad stitching function")
+		}
+		for _, item := range functions {
+			sub, err := r.getFunction(item, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			f.functions = append(f.functions, sub)
+		}
+		f.bounds, _ = r.GetFloats(dict["Bounds"])
+		f.encode, _ = r.GetFloats(dict["Encode"])
+		if len(f.bounds) != len(f.functions)-1 || len(f.encode) != 2*len(f.functions) {
+			return nil, fmt.Errorf("bad stitching function")
+		}
+		return f, nil
+	case 4:
+		if len(rng) == 0 {
+			return nil, fmt.Errorf("bad PostScript function")
+		}
+		program, err := parsePostScript(data)
+		if err != nil {
+			return nil, err
+		}
+		return pdfPostScriptFunction{domain: domain, rng: rng, program: program}, nil
+	}
+	return nil, fmt.Errorf("unsupported function type %v", functionType)
+}
+
+// functionBreakpoints returns the input values in [t0,t1] at which the function is not differentiable, and whether the function is linear between them. Only exponential functions with an exponent of one and stitching functions thereof are linear.
+func functionBreakpoints(f pdfFunction, t0, t1 float64) ([]float64, bool) {
+	ts := []float64{t0, t1}
+	switch f := f.(type) {
+	case pdfExponentialFunction:
+		return ts, f.n == 1.0
+	case pdfStitchingFunction:
+		linear := true
+		for i, sub := range f.functions {
+			if exp, ok := sub.(pdfExponentialFunction); !ok || exp.n != 1.0 {
+				linear = false
+			} else if f.encode[2*i] != exp.domain[0] && f.encode[2*i] != exp.domain[1] || f.encode[2*i+1] != exp.domain[0] && f.encode[2*i+1] != exp.domain[1] {
+				linear = false
+			}
+		}
+		for _, bound := range f.bounds {
+			if math.Min(t0, t1) < bound && bound < math.Max(t0, t1) {
+				ts = append(ts, bound)
+			}
+		}
+		return ts, linear
+	case pdfFunctions:
+		linear := true
+		for _, sub := range f {
+			subTs, subLinear := functionBreakpoints(sub, t0, t1)
+			ts = append(ts, subTs[2:]...)
+			linear = linear && subLinear
+		}
+		return ts, linear
+	}
+	return ts, false
+}
+
+////////////////////////////////////////////////////////////////
+
+// pdfShading is an axial or radial shading converted to a gradient in the shading's coordinate space.
+type pdfShading struct {
+	gradient   canvas.Gradient
+	background *color.RGBA
+	bbox       *canvas.Rect
+}
+
+// GetShading returns the gradient for an axial (type 2) or radial (type 3) shading. Other shading types are not supported.
+func (r *pdfReader) GetShading(val interface{}, resources pdfDict) (pdfShading, error) {
+	dict, err := r.GetDict(val)
+	if err != nil {
+		return pdfShading{}, fmt.Errorf("bad shading: %w", err)
+	}
+	cs, err := r.GetColorSpace(dict["ColorSpace"], resources)
+	if err != nil {
+		return pdfShading{}, err
+	}
+
+	shading := pdfShading{}
+	if bg, err := r.GetFloats(dict["Background"]); err == nil {
+		col := cs.RGB(bg)
+		shading.background = &col
+	}
+	if bbox, err := r.GetFloats(dict["BBox"]); err == nil && len(bbox) == 4 {
+		rect := canvas.Rect{X: math.Min(bbox[0], bbox[2]), Y: math.Min(bbox[1], bbox[3]), W: math.Abs(bbox[2] - bbox[0]), H: math.Abs(bbox[3] - bbox[1])}
+		shading.bbox = &rect
+	}
+
+	shadingType, _ := r.GetInt(dict["ShadingType"])
+	if shadingType != 2 && shadingType != 3 {
+		return pdfShading{}, fmt.Errorf("unsupported shading type %v", shadingType)
+	}
+	coords, err := r.GetFloats(dict["Coords"])
+	if err != nil || shadingType == 2 && len(coords) != 4 || shadingType == 3 && len(coords) != 6 {
+		return pdfShading{}, fmt.Errorf("bad shading coordinates")
+	}
+	f, err := r.GetFunction(dict["Function"])
+	if err != nil {
+		return pdfShading{}, err
+	}
+	t0, t1 := 0.0, 1.0
+	if domain, err := r.GetFloats(dict["Domain"]); err == nil && len(domain) == 2 {
+		t0, t1 = domain[0], domain[1]
+	}
+
+	// sample the function at its breakpoints, or at regular intervals if it is not linear
+	ts, linear := functionBreakpoints(f, t0, t1)
+	if _, ok := cs.(pdfSeparation); ok || !linear {
+		for i := 1; i < 32; i++ {
+			ts = append(ts, t0+(t1-t0)*float64(i)/32.0)
+		}
+	}
+	offsets := make([]float64, len(ts))
+	for i, t := range ts {
+		offsets[i] = interpolate(t, t0, t1, 0.0, 1.0)
+	}
+	sort.Float64s(offsets)
+	stops := canvas.Stops{}
+	for i, offset := range offsets {
+		if 0 < i && offset == offsets[i-1] {
+			continue
+		}
+		stops.Add(offset, cs.RGB(f.Call([]float64{t0 + offset*(t1-t0)})))
+	}
+
+	if shadingType == 2 {
+		gradient := canvas.NewLinearGradient(canvas.Point{X: coords[0], Y: coords[1]}, canvas.Point{X: coords[2], Y: coords[3]})
+		gradient.Stops = stops
+		shading.gradient = gradient
+	} else {
+		gradient := canvas.NewRadialGradient(canvas.Point{X: coords[0], Y: coords[1]}, coords[2], canvas.Point{X: coords[3], Y: coords[4]}, coords[5])
+		gradient.Stops = stops
+		shading.gradient = gradient
+	}
+	return shading, nil
+}
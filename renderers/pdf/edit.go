@@ -0,0 +1,535 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/LaminoidStudio/Canvas/internal/pdfedit"
+)
+
+// Info is the document information of a PDF document.
+type Info struct {
+	Title        string
+	Author       string
+	Subject      string
+	Keywords     string
+	Creator      string
+	Producer     string
+	CreationDate time.Time
+	ModDate      time.Time
+}
+
+var infoDateFormat = "2006-01-02 15:04:05 UTC-0700"
+
+func (info Info) String() string {
+	sb := strings.Builder{}
+	if info.Title != "" {
+		sb.WriteString("Title: " + info.Title + "\n")
+	}
+	if info.Author != "" {
+		sb.WriteString("Author: " + info.Author + "\n")
+	}
+	if info.Subject != "" {
+		sb.WriteString("Subject: " + info.Subject + "\n")
+	}
+	if info.Keywords != "" {
+		sb.WriteString("Keywords: " + info.Keywords + "\n")
+	}
+	if info.Creator != "" {
+		sb.WriteString("Creator: " + info.Creator + "\n")
+	}
+	if info.Producer != "" {
+		sb.WriteString("Producer: " + info.Producer + "\n")
+	}
+	if !info.CreationDate.IsZero() {
+		sb.WriteString("CreationDate: " + info.CreationDate.Format(infoDateFormat) + "\n")
+	}
+	if !info.ModDate.IsZero() {
+		sb.WriteString("ModDate: " + info.ModDate.Format(infoDateFormat) + "\n")
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// Info returns the document information dictionary.
+func (r *Reader) Info() Info {
+	info := Info{}
+	dict, err := r.r.GetDict(r.r.trailer["Info"])
+	if err != nil {
+		return info
+	}
+	text := func(key pdfName) string {
+		b, _ := r.r.GetString(dict[key])
+		return parseTextString(b)
+	}
+	date := func(key pdfName) time.Time {
+		b, _ := r.r.GetString(dict[key])
+		return parseDate(b)
+	}
+	info.Title = text("Title")
+	info.Author = text("Author")
+	info.Subject = text("Subject")
+	info.Keywords = text("Keywords")
+	info.Creator = text("Creator")
+	info.Producer = text("Producer")
+	info.CreationDate = date("CreationDate")
+	info.ModDate = date("ModDate")
+	return info
+}
+
+// Encrypted returns true if the document is encrypted.
+func (r *Reader) Encrypted() bool {
+	return r.r.encrypt.isEncrypted
+}
+
+// parseTextString decodes a text string, which is encoded in UTF-16BE or UTF-8 with a byte order mark, or in PDFDocEncoding, see PDF reference 7.9.2.2.
+func parseTextString(b []byte) string {
+	if 2 <= len(b) && b[0] == 0xFE && b[1] == 0xFF {
+		return string(utf16BE(b[2:]))
+	} else if 3 <= len(b) && b[0] == 0xEF && b[1] == 0xBB && b[2] == 0xBF {
+		return string(b[3:])
+	}
+	rs := make([]rune, len(b))
+	for i, c := range b {
+		if r, ok := glyphNameToRune(pdfEncodings["PDFDocEncoding"][c]); ok {
+			rs[i] = r
+		} else {
+			rs[i] = rune(c)
+		}
+	}
+	return string(rs)
+}
+
+// parseDate parses a date string, see PDF reference 7.9.4.
+func parseDate(b []byte) time.Time {
+	layout := "D:20060102150405Z07'00'"
+	if len(layout) < len(b) {
+		b = b[:len(layout)]
+	}
+	t, _ := time.Parse(layout[:len(b)], string(b))
+	return t
+}
+
+////////////////////////////////////////////////////////////////
+
+func init() {
+	pdfedit.Open = func(r io.Reader, password string) (pdfedit.Document, error) {
+		reader, err := NewReader(r, password)
+		if err != nil {
+			return nil, err
+		}
+		return pdfDocument{reader}, nil
+	}
+}
+
+// pdfDocument implements pdfedit.Document, which keeps the text editing out of the exported API of the renderer.
+type pdfDocument struct {
+	r *Reader
+}
+
+func (doc pdfDocument) TextStrings(page int) ([]pdfedit.TextString, error) {
+	return doc.r.textStrings(page)
+}
+
+func (doc pdfDocument) ReplaceText(page int, object string, index int, text string, xOffset, spacing float64) error {
+	return doc.r.replaceText(page, object, index, text, xOffset, spacing)
+}
+
+func (doc pdfDocument) Write(w io.Writer) error {
+	return doc.r.writeDocument(w)
+}
+
+// textStrings returns the strings shown in the content stream of the page with the given zero-based index and in its form XObjects.
+func (r *Reader) textStrings(page int) ([]pdfedit.TextString, error) {
+	contents, err := r.contents(page)
+	if err != nil {
+		return nil, err
+	}
+	strs := []pdfedit.TextString{}
+	for _, content := range contents {
+		index := 0
+		err := r.walkText(content, func(start, end int, name pdfName, font *pdfFont, op string, vals []interface{}) error {
+			text := ""
+			for _, s := range textOperands(op, vals) {
+				if font != nil {
+					text += font.Text(s)
+				} else {
+					text += parseTextString(s)
+				}
+			}
+			strs = append(strs, pdfedit.TextString{
+				Object: content.name,
+				Index:  index,
+				Font:   string(name),
+				Text:   text,
+			})
+			index++
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("bad page %d: %w", page, err)
+		}
+	}
+	return strs, nil
+}
+
+// replaceText replaces a string, see textStrings, by a TJ operator that shows the text in the same font. The string is moved by xOffset and its characters are separated by spacing, both in thousandths of a text space unit. The document with the replaced text is written by writeDocument.
+func (r *Reader) replaceText(page int, object string, index int, text string, xOffset, spacing float64) error {
+	contents, err := r.contents(page)
+	if err != nil {
+		return err
+	}
+	for _, content := range contents {
+		if content.name != object {
+			continue
+		}
+
+		i := 0
+		replaced := false
+		err := r.walkText(content, func(start, end int, name pdfName, font *pdfFont, op string, vals []interface{}) error {
+			if i != index {
+				i++
+				return nil
+			} else if font == nil {
+				return fmt.Errorf("unknown font %s", name)
+			}
+			s, err := font.Encode(text)
+			if err != nil {
+				return err
+			}
+
+			b := &bytes.Buffer{}
+			w := &pdfWriter{w: b}
+			if op == "'" {
+				w.write("T* ")
+			} else if op == "\"" && len(vals) == 3 {
+				w.writeVal(vals[0])
+				w.write(" Tw ")
+				w.writeVal(vals[1])
+				w.write(" Tc T* ")
+			}
+			array := pdfArray{}
+			if xOffset != 0.0 {
+				array = append(array, -xOffset)
+			}
+			if spacing != 0.0 {
+				k := 0
+				for j, c := range font.Chars(s) {
+					if j != 0 {
+						array = append(array, -spacing)
+					}
+					array = append(array, s[k:k+c.n])
+					k += c.n
+				}
+			} else {
+				array = append(array, s)
+			}
+			w.writeVal(array)
+			w.write(" TJ")
+
+			// content streams of a page are concatenated, replace in the stream that contains the string
+			k := sort.SearchInts(content.ends, start+1)
+			if k == len(content.ends) || content.ends[k] < end {
+				return fmt.Errorf("string %d spans several content streams", index)
+			}
+			begin := 0
+			if 0 < k {
+				begin = content.ends[k-1] + 1
+			}
+			data := append([]byte{}, content.data[begin:start]...)
+			data = append(data, b.Bytes()...)
+			data = append(data, content.data[end:content.ends[k]]...)
+			r.edits[content.refs[k]] = data
+			replaced = true
+			return io.EOF
+		})
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("bad page %d: %w", page, err)
+		} else if !replaced {
+			return fmt.Errorf("unknown string %d", index)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown object %q", object)
+}
+
+// pdfContent is the content stream of a page, which may be split over several streams, or of a form XObject.
+type pdfContent struct {
+	name      string
+	data      []byte
+	refs      []pdfIndirectRef
+	ends      []int // end positions of the streams in data
+	resources pdfDict
+}
+
+// contents returns the content streams of a page and its form XObjects.
+func (r *Reader) contents(index int) ([]pdfContent, error) {
+	if index < 0 || len(r.r.kids) <= index {
+		return nil, fmt.Errorf("unknown page %d", index)
+	}
+	page, err := r.r.GetDict(r.r.kids[index])
+	if err != nil {
+		return nil, fmt.Errorf("bad page %d: %w", index, err)
+	}
+
+	content := pdfContent{}
+	content.resources, _ = r.r.GetDict(r.r.GetInherited(page, "Resources"))
+	vals := pdfArray{page["Contents"]}
+	if array, err := r.r.GetArray(page["Contents"]); err == nil {
+		vals = array
+	}
+	for _, val := range vals {
+		ref, ok := val.(pdfIndirectRef)
+		if !ok {
+			continue
+		}
+		data, err := r.streamData(ref)
+		if err != nil {
+			return nil, fmt.Errorf("bad page %d: %w", index, err)
+		}
+		content.data = append(content.data, data...)
+		content.data = append(content.data, '\n') // content streams may be split at any token boundary
+		content.refs = append(content.refs, ref)
+		content.ends = append(content.ends, len(content.data)-1)
+	}
+	return r.addForms([]pdfContent{content}, "", content.resources, 1), nil
+}
+
+// addForms appends the form XObjects of the resources and of their resources recursively.
+func (r *Reader) addForms(contents []pdfContent, prefix string, resources pdfDict, depth int) []pdfContent {
+	xobjects, err := r.r.GetDict(resources["XObject"])
+	if err != nil || maxFormDepth < depth {
+		return contents
+	}
+	names := make([]string, 0, len(xobjects))
+	for name := range xobjects {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		ref, ok := xobjects[pdfName(name)].(pdfIndirectRef)
+		if !ok {
+			continue
+		}
+		stream, err := r.r.GetStream(ref)
+		if err != nil {
+			continue
+		} else if subtype, _ := r.r.GetName(stream.dict["Subtype"]); subtype != "Form" {
+			continue
+		}
+		data, _ := r.streamData(ref)
+		content := pdfContent{
+			name:      fmt.Sprintf("%s%d", prefix, i+1),
+			data:      data,
+			refs:      []pdfIndirectRef{ref},
+			ends:      []int{len(data)},
+			resources: resources,
+		}
+		formResources, err := r.r.GetDict(stream.dict["Resources"])
+		if err == nil {
+			content.resources = formResources
+		}
+		contents = append(contents, content)
+		if err == nil {
+			contents = r.addForms(contents, content.name+"/", formResources, depth+1)
+		}
+	}
+	return contents
+}
+
+// streamData returns the decoded data of a stream, or its replacement.
+func (r *Reader) streamData(ref pdfIndirectRef) ([]byte, error) {
+	if data, ok := r.edits[ref]; ok {
+		return data, nil
+	}
+	stream, err := r.r.GetStream(ref)
+	if err != nil {
+		return nil, err
+	}
+	return stream.stream, nil
+}
+
+// walkText calls f for each text-showing operator of a content stream with the positions of the operator and its operands, and the current font.
+func (r *Reader) walkText(content pdfContent, f func(int, int, pdfName, *pdfFont, string, []interface{}) error) error {
+	fonts, _ := r.r.GetDict(content.resources["Font"])
+	var name pdfName
+	var names []pdfName // saved by q and restored by Q
+	stream := newPDFStreamReader(content.data)
+	for {
+		start := moveWhiteSpace(content.data, stream.Pos())
+		op, vals, err := stream.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		switch op {
+		case "q":
+			names = append(names, name)
+		case "Q":
+			if 0 < len(names) {
+				name = names[len(names)-1]
+				names = names[:len(names)-1]
+			}
+		case "Tf":
+			if len(vals) == 2 {
+				name, _ = vals[0].(pdfName)
+			}
+		case "Tj", "TJ", "'", "\"":
+			if err := f(start, stream.Pos(), name, r.getFont(fonts[name]), op, vals); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// textOperands returns the strings shown by a text-showing operator.
+func textOperands(op string, vals []interface{}) [][]byte {
+	var strs [][]byte
+	if op == "TJ" && len(vals) == 1 {
+		array, _ := vals[0].(pdfArray)
+		for _, item := range array {
+			if s, ok := item.([]byte); ok {
+				strs = append(strs, s)
+			}
+		}
+	} else if (op == "Tj" || op == "'") && len(vals) == 1 {
+		if s, ok := vals[0].([]byte); ok {
+			strs = append(strs, s)
+		}
+	} else if op == "\"" && len(vals) == 3 {
+		if s, ok := vals[2].([]byte); ok {
+			strs = append(strs, s)
+		}
+	}
+	return strs
+}
+
+////////////////////////////////////////////////////////////////
+
+// writeDocument writes the document with the replaced text. Unchanged objects are copied, objects in object streams are written as regular objects, and a new cross-reference table and trailer are written.
+func (r *Reader) writeDocument(writer io.Writer) error {
+	pdf := r.r
+	size := uint32(1)
+	refs := make([]pdfIndirectRef, 0, len(pdf.objects))
+	for ref, obj := range pdf.objects {
+		if !obj.free {
+			refs = append(refs, ref)
+		}
+		if size <= ref[0] {
+			size = ref[0] + 1
+		}
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i][0] < refs[j][0] || refs[i][0] == refs[j][0] && refs[i][1] < refs[j][1]
+	})
+
+	w := &pdfWriter{w: writer}
+	w.write("%s\n%%\xE2\xE3\xCF\xD3\n", pdf.data[:8])
+	xrefs := make([]pdfIndirectRef, size) // offsets and generations of used objects
+	for _, ref := range refs {
+		obj := pdf.objects[ref]
+		var val interface{}
+		var end int
+		var err error
+		if obj.compressed {
+			val, err = pdf.readObject(ref)
+		} else {
+			val, end, err = pdf.readObjectAt(ref, obj.offset)
+		}
+		if err != nil {
+			return err
+		} else if stream, ok := val.(pdfStream); ok {
+			if typ, _ := pdf.GetName(stream.dict["Type"]); typ == "ObjStm" || typ == "XRef" {
+				continue // objects are written as regular objects and cross references as a table
+			}
+		}
+
+		xrefs[ref[0]] = pdfIndirectRef{uint32(w.pos), ref[1]}
+		if data, ok := r.edits[ref]; ok {
+			stream, _ := val.(pdfStream)
+			dict := pdfDict{}
+			for key, val := range stream.dict {
+				if key != "Filter" && key != "DecodeParms" && key != "DL" && key != "Length" {
+					dict[key] = val
+				}
+			}
+			dict["Filter"] = pdfName(pdfFilterFlate)
+
+			b := &bytes.Buffer{}
+			zw := zlib.NewWriter(b)
+			zw.Write(data)
+			zw.Close()
+			data = b.Bytes()
+			if pdf.encrypt.isEncrypted {
+				data = pdf.encrypt.Encrypt(ref, data)
+			}
+
+			w.write("%d %d obj\n", ref[0], ref[1])
+			w.writeVal(pdfStream{pdf.encryptStrings(ref, dict).(pdfDict), data})
+			w.write("\nendobj\n")
+		} else if obj.compressed {
+			w.write("%d %d obj\n", ref[0], ref[1])
+			w.writeVal(pdf.encryptStrings(ref, val))
+			w.write("\nendobj\n")
+		} else {
+			end = moveWhiteSpace(pdf.data, end)
+			if bytes.HasPrefix(pdf.data[end:], []byte("endobj")) {
+				end += 6
+			}
+			w.writeBytes(pdf.data[obj.offset:end])
+			w.write("\n")
+		}
+	}
+
+	startxref := w.pos
+	w.write("xref\n0 %d\n", size)
+	for i, xref := range xrefs {
+		if i == 0 {
+			w.write("%010d %05d f \n", 0, 65535)
+		} else if xref[0] == 0 {
+			w.write("%010d %05d f \n", 0, 0)
+		} else {
+			w.write("%010d %05d n \n", xref[0], xref[1])
+		}
+	}
+	trailer := pdfDict{"Size": int(size)}
+	for _, key := range []pdfName{"Root", "Info", "ID", "Encrypt"} {
+		if val, ok := pdf.trailer[key]; ok {
+			trailer[key] = val
+		}
+	}
+	w.write("trailer\n")
+	w.writeVal(trailer)
+	w.write("\nstartxref\n%d\n%%%%EOF\n", startxref)
+	return w.err
+}
+
+// encryptStrings returns a copy of a value with its strings encrypted for the given object if the document is encrypted.
+func (r *pdfReader) encryptStrings(ref pdfIndirectRef, val interface{}) interface{} {
+	if !r.encrypt.isEncrypted {
+		return val
+	}
+	switch v := val.(type) {
+	case []byte:
+		return r.encrypt.Encrypt(ref, v)
+	case pdfArray:
+		array := make(pdfArray, len(v))
+		for i, item := range v {
+			array[i] = r.encryptStrings(ref, item)
+		}
+		return array
+	case pdfDict:
+		dict := make(pdfDict, len(v))
+		for key, item := range v {
+			dict[key] = r.encryptStrings(ref, item)
+		}
+		return dict
+	}
+	return val
+}
@@ -0,0 +1,76 @@
+package pdf
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/LaminoidStudio/Canvas"
+	"github.com/tdewolff/test"
+)
+
+func testTextDocument(t *testing.T) []byte {
+	family := canvas.NewFontFamily("dejavu-serif")
+	test.Error(t, family.LoadFontFile(fontDir+"DejaVuSerif.ttf", canvas.FontRegular))
+	face := family.Face(12.0, canvas.Black, canvas.FontRegular, canvas.FontNormal)
+
+	buf := &bytes.Buffer{}
+	pdf := New(buf, 100.0, 50.0, nil)
+	pdf.SetInfo("Title", "", "", "Author", "")
+	pdf.RenderText(canvas.NewTextLine(face, "Hello", canvas.Left), canvas.Identity.Translate(10.0, 20.0))
+	test.Error(t, pdf.Close())
+	return buf.Bytes()
+}
+
+func TestReaderInfo(t *testing.T) {
+	reader, err := NewReader(bytes.NewReader(testTextDocument(t)), "")
+	test.Error(t, err)
+	info := reader.Info()
+	test.T(t, info.Title, "Title")
+	test.T(t, info.Author, "Author")
+	test.That(t, !info.CreationDate.IsZero(), "creation date must be set")
+	test.That(t, !reader.Encrypted(), "document must not be encrypted")
+
+	test.T(t, parseTextString([]byte("\xFE\xFF\x00H\x00\xE9")), "Hé")
+	test.T(t, parseTextString([]byte("a\x84b")), "a—b") // PDFDocEncoding
+	test.T(t, parseDate([]byte("D:20230102")).Format("2006-01-02"), "2023-01-02")
+}
+
+func TestReaderTextStrings(t *testing.T) {
+	reader, err := NewReader(bytes.NewReader(testTextDocument(t)), "")
+	test.Error(t, err)
+	strs, err := reader.textStrings(0)
+	test.Error(t, err)
+	test.T(t, len(strs), 1)
+	test.T(t, strs[0].Object, "")
+	test.T(t, strs[0].Index, 0)
+	test.T(t, strs[0].Text, "Hello")
+
+	_, err = reader.textStrings(1)
+	test.That(t, err != nil, "must fail for unknown page")
+}
+
+func TestReaderReplaceText(t *testing.T) {
+	reader, err := NewReader(bytes.NewReader(testTextDocument(t)), "")
+	test.Error(t, err)
+	test.That(t, reader.replaceText(0, "", 0, "Hexo", 0.0, 0.0) != nil, "must fail for characters not in the subset font")
+	test.That(t, reader.replaceText(0, "", 1, "Hello", 0.0, 0.0) != nil, "must fail for unknown string")
+	test.That(t, reader.replaceText(0, "1", 0, "Hello", 0.0, 0.0) != nil, "must fail for unknown object")
+	test.Error(t, reader.replaceText(0, "", 0, "Hole", 100.0, 50.0))
+
+	strs, err := reader.textStrings(0)
+	test.Error(t, err)
+	test.T(t, strs[0].Text, "Hole")
+
+	buf := &bytes.Buffer{}
+	test.Error(t, reader.writeDocument(buf))
+
+	reader, err = NewReader(bytes.NewReader(buf.Bytes()), "")
+	test.Error(t, err)
+	test.T(t, reader.Info().Title, "Title")
+	strs, err = reader.textStrings(0)
+	test.Error(t, err)
+	test.T(t, len(strs), 1)
+	test.T(t, strs[0].Text, "Hole")
+	_, err = reader.Page(0)
+	test.Error(t, err)
+}
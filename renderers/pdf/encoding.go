@@ -0,0 +1,313 @@
+package pdf
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// pdfEncodings holds the glyph names for each character code of the standard Latin-text encodings, see PDF reference Appendix D.
+var pdfEncodings = map[pdfName]*[256]string{}
+
+func init() {
+	standard, mac, win, pdfDoc := [256]string{}, [256]string{}, [256]string{}, [256]string{}
+	for _, entry := range charset {
+		standard[entry.std] = entry.name
+		mac[entry.mac] = entry.name
+		win[entry.win] = entry.name
+		pdfDoc[entry.pdf] = entry.name
+	}
+	standard[0], mac[0], win[0], pdfDoc[0] = "", "", "", ""
+
+	// WinAnsiEncoding additionally maps the space and hyphen to the non-breaking space and soft hyphen
+	win[0240] = "space"
+	win[0255] = "hyphen"
+	pdfEncodings["StandardEncoding"] = &standard
+	pdfEncodings["MacRomanEncoding"] = &mac
+	pdfEncodings["WinAnsiEncoding"] = &win
+	pdfEncodings["PDFDocEncoding"] = &pdfDoc
+}
+
+// glyphNameToRune returns the first Unicode character for a glyph name, see glyphNameToText.
+func glyphNameToRune(name string) (rune, bool) {
+	if text, ok := glyphNameToText(name); ok {
+		r, _ := utf8.DecodeRuneInString(text)
+		return r, true
+	}
+	return 0, false
+}
+
+// glyphNameToText returns the Unicode characters for a glyph name following the Adobe Glyph List specification, where the name may be a ligature of components separated by underscores that are each in the Adobe Glyph List or use the uniXXXX[YYYY...] or uXXXX[XX] naming conventions.
+func glyphNameToText(name string) (string, bool) {
+	if i := strings.IndexByte(name, '.'); 0 <= i {
+		name = name[:i] // strip suffix such as in a.sc
+	}
+	sb := strings.Builder{}
+	for _, component := range strings.Split(name, "_") {
+		if text, ok := aglGlyphNames[component]; ok {
+			sb.WriteString(text)
+		} else if strings.HasPrefix(component, "uni") && 3 < len(component) && (len(component)-3)%4 == 0 {
+			rs := []rune{}
+			for i := 3; i < len(component); i += 4 {
+				r, ok := parseGlyphNameCode(component[i : i+4])
+				if !ok {
+					rs = nil
+					break
+				}
+				rs = append(rs, r)
+			}
+			sb.WriteString(string(rs))
+		} else if strings.HasPrefix(component, "u") && 5 <= len(component) && len(component) <= 7 {
+			if r, ok := parseGlyphNameCode(component[1:]); ok {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	return sb.String(), 0 < sb.Len()
+}
+
+// parseGlyphNameCode parses the hexadecimal Unicode character of a glyph name, which must be a valid character outside the surrogate range.
+func parseGlyphNameCode(s string) (rune, bool) {
+	if r, err := strconv.ParseUint(s, 16, 32); err == nil && utf8.ValidRune(rune(r)) {
+		return rune(r), true
+	}
+	return 0, false
+}
+
+// charsetEntry is the glyph name and the octal character codes in the standard, MacRoman, WinAnsi, and PDFDoc encodings of a character, where zero means it is not encoded.
+type charsetEntry struct {
+	name               string
+	std, mac, win, pdf int
+}
+
+var charset = map[rune]charsetEntry{
+	'A':  {"A", 0101, 0101, 0101, 0101},
+	'Æ':  {"AE", 0341, 0256, 0306, 0306},
+	'Á':  {"Aacute", 0, 0347, 0301, 0301},
+	'Â':  {"Acircumflex", 0, 0345, 0302, 0302},
+	'Ä':  {"Adieresis", 0, 0200, 0304, 0304},
+	'À':  {"Agrave", 0, 0313, 0300, 0300},
+	'Å':  {"Aring", 0, 0201, 0305, 0305},
+	'Ã':  {"Atilde", 0, 0314, 0303, 0303},
+	'B':  {"B", 0102, 0102, 0102, 0102},
+	'C':  {"C", 0103, 0103, 0103, 0103},
+	'Ç':  {"Ccedilla", 0, 0202, 0307, 0307},
+	'D':  {"D", 0104, 0104, 0104, 0104},
+	'E':  {"E", 0105, 0105, 0105, 0105},
+	'É':  {"Eacute", 0, 0203, 0311, 0311},
+	'Ê':  {"Ecircumflex", 0, 0346, 0312, 0312},
+	'Ë':  {"Edieresis", 0, 0350, 0313, 0313},
+	'È':  {"Egrave", 0, 0351, 0310, 0310},
+	'Ð':  {"Eth", 0, 0, 0320, 0320},
+	'€':  {"Euro", 0, 0, 0200, 0240},
+	'F':  {"F", 0106, 0106, 0106, 0106},
+	'G':  {"G", 0107, 0107, 0107, 0107},
+	'H':  {"H", 0110, 0110, 0110, 0110},
+	'I':  {"I", 0111, 0111, 0111, 0111},
+	'Í':  {"Iacute", 0, 0352, 0315, 0315},
+	'Î':  {"Icircumflex", 0, 0353, 0316, 0316},
+	'Ï':  {"Idieresis", 0, 0354, 0317, 0317},
+	'Ì':  {"Igrave", 0, 0355, 0314, 0314},
+	'J':  {"J", 0112, 0112, 0112, 0112},
+	'K':  {"K", 0113, 0113, 0113, 0113},
+	'L':  {"L", 0114, 0114, 0114, 0114},
+	'Ł':  {"Lslash", 0350, 0, 0, 0225},
+	'M':  {"M", 0115, 0115, 0115, 0115},
+	'N':  {"N", 0116, 0116, 0116, 0116},
+	'Ñ':  {"Ntilde", 0, 0204, 0321, 0321},
+	'O':  {"O", 0117, 0117, 0117, 0117},
+	'Œ':  {"OE", 0352, 0316, 0214, 0226},
+	'Ó':  {"Oacute", 0, 0356, 0323, 0323},
+	'Ô':  {"Ocircumflex", 0, 0357, 0324, 0324},
+	'Ö':  {"Odieresis", 0, 0205, 0326, 0326},
+	'Ò':  {"Ograve", 0, 0361, 0322, 0322},
+	'Ø':  {"Oslash", 0351, 0257, 0330, 0330},
+	'Õ':  {"Otilde", 0, 0315, 0325, 0325},
+	'P':  {"P", 0120, 0120, 0120, 0120},
+	'Q':  {"Q", 0121, 0121, 0121, 0121},
+	'R':  {"R", 0122, 0122, 0122, 0122},
+	'S':  {"S", 0123, 0123, 0123, 0123},
+	'Š':  {"Scaron", 0, 0, 0212, 0227},
+	'T':  {"T", 0124, 0124, 0124, 0124},
+	'Þ':  {"Thorn", 0, 0, 0336, 0336},
+	'U':  {"U", 0125, 0125, 0125, 0125},
+	'Ú':  {"Uacute", 0, 0362, 0332, 0332},
+	'Û':  {"Ucircumflex", 0, 0363, 0333, 0333},
+	'Ü':  {"Udieresis", 0, 0206, 0334, 0334},
+	'Ù':  {"Ugrave", 0, 0364, 0331, 0331},
+	'V':  {"V", 0126, 0126, 0126, 0126},
+	'W':  {"W", 0127, 0127, 0127, 0127},
+	'X':  {"X", 0130, 0130, 0130, 0130},
+	'Y':  {"Y", 0131, 0131, 0131, 0131},
+	'Ý':  {"Yacute", 0, 0, 0335, 0335},
+	'Ÿ':  {"Ydieresis", 0, 0331, 0237, 0230},
+	'Z':  {"Z", 0132, 0132, 0132, 0132},
+	'Ž':  {"Zcaron", 0, 0, 0216, 0231},
+	'a':  {"a", 0141, 0141, 0141, 0141},
+	'á':  {"aacute", 0, 0207, 0341, 0341},
+	'â':  {"acircumflex", 0, 0211, 0342, 0342},
+	'´':  {"acute", 0302, 0253, 0264, 0264},
+	'ä':  {"adieresis", 0, 0212, 0344, 0344},
+	'æ':  {"ae", 0361, 0276, 0346, 0346},
+	'à':  {"agrave", 0, 0210, 0340, 0340},
+	'&':  {"ampersand", 0046, 0046, 0046, 0046},
+	'å':  {"aring", 0, 0214, 0345, 0345},
+	'^':  {"asciicircum", 0136, 0136, 0136, 0136},
+	'~':  {"asciitilde", 0176, 0176, 0176, 0176},
+	'*':  {"asterisk", 0052, 0052, 0052, 0052},
+	'@':  {"at", 0100, 0100, 0100, 0100},
+	'ã':  {"atilde", 0, 0213, 0343, 0343},
+	'b':  {"b", 0142, 0142, 0142, 0142},
+	'\\': {"backslash", 0134, 0134, 0134, 0134},
+	'|':  {"bar", 0174, 0174, 0174, 0174},
+	'{':  {"braceleft", 0173, 0173, 0173, 0173},
+	'}':  {"braceright", 0175, 0175, 0175, 0175},
+	'[':  {"bracketleft", 0133, 0133, 0133, 0133},
+	']':  {"bracketright", 0135, 0135, 0135, 0135},
+	'˘':  {"breve", 0306, 0371, 0, 0030},
+	'¦':  {"brokenbar", 0, 0, 0246, 0246},
+	'•':  {"bullet", 0267, 0245, 0225, 0200},
+	'c':  {"c", 0143, 0143, 0143, 0143},
+	'ˇ':  {"caron", 0317, 0377, 0, 0031},
+	'ç':  {"ccedilla", 0, 0215, 0347, 0347},
+	'¸':  {"cedilla", 0313, 0374, 0270, 0270},
+	'¢':  {"cent", 0242, 0242, 0242, 0242},
+	'ˆ':  {"circumflex", 0303, 0366, 0210, 0032},
+	':':  {"colon", 0072, 0072, 0072, 0072},
+	',':  {"comma", 0054, 0054, 0054, 0054},
+	'©':  {"copyright", 0, 0251, 0251, 0251},
+	'¤':  {"currency", 0250, 0333, 0244, 0244},
+	'd':  {"d", 0144, 0144, 0144, 0144},
+	'†':  {"dagger", 0262, 0240, 0206, 0201},
+	'‡':  {"daggerdbl", 0263, 0340, 0207, 0202},
+	'°':  {"degree", 0, 0241, 0260, 0260},
+	'¨':  {"dieresis", 0310, 0254, 0250, 0250},
+	'÷':  {"divide", 0, 0326, 0367, 0367},
+	'$':  {"dollar", 0044, 0044, 0044, 0044},
+	'˙':  {"dotaccent", 0307, 0372, 0, 0033},
+	'ı':  {"dotlessi", 0365, 0365, 0, 0232},
+	'e':  {"e", 0145, 0145, 0145, 0145},
+	'é':  {"eacute", 0, 0216, 0351, 0351},
+	'ê':  {"ecircumflex", 0, 0220, 0352, 0352},
+	'ë':  {"edieresis", 0, 0221, 0353, 0353},
+	'è':  {"egrave", 0, 0217, 0350, 0350},
+	'8':  {"eight", 0070, 0070, 0070, 0070},
+	'…':  {"ellipsis", 0274, 0311, 0205, 0203},
+	'—':  {"emdash", 0320, 0321, 0227, 0204},
+	'–':  {"endash", 0261, 0320, 0226, 0205},
+	'=':  {"equal", 0075, 0075, 0075, 0075},
+	'ð':  {"eth", 0, 0, 0360, 0360},
+	'!':  {"exclam", 0041, 0041, 0041, 0041},
+	'¡':  {"exclamdown", 0241, 0301, 0241, 0241},
+	'f':  {"f", 0146, 0146, 0146, 0146},
+	'ﬁ':  {"fi", 0256, 0336, 0, 0223},
+	'5':  {"five", 0065, 0065, 0065, 0065},
+	'ﬂ':  {"fl", 0257, 0337, 0, 0224},
+	'ƒ':  {"florin", 0246, 0304, 0203, 0206},
+	'4':  {"four", 0064, 0064, 0064, 0064},
+	'⁄':  {"fraction", 0244, 0332, 0, 0207},
+	'g':  {"g", 0147, 0147, 0147, 0147},
+	'ß':  {"germandbls", 0373, 0247, 0337, 0337},
+	'`':  {"grave", 0301, 0140, 0140, 0140},
+	'>':  {"greater", 0076, 0076, 0076, 0076},
+	'«':  {"guillemotleft", 0253, 0307, 0253, 0253},
+	'»':  {"guillemotright", 0273, 0310, 0273, 0273},
+	'‹':  {"guilsinglleft", 0254, 0334, 0213, 0210},
+	'›':  {"guilsinglright", 0255, 0335, 0233, 0211},
+	'h':  {"h", 0150, 0150, 0150, 0150},
+	'˝':  {"hungarumlaut", 0315, 0375, 0, 0034},
+	'-':  {"hyphen", 0055, 0055, 0055, 0055},
+	'i':  {"i", 0151, 0151, 0151, 0151},
+	'í':  {"iacute", 0, 0222, 0355, 0355},
+	'î':  {"icircumflex", 0, 0224, 0356, 0356},
+	'ï':  {"idieresis", 0, 0225, 0357, 0357},
+	'ì':  {"igrave", 0, 0223, 0354, 0354},
+	'j':  {"j", 0152, 0152, 0152, 0152},
+	'k':  {"k", 0153, 0153, 0153, 0153},
+	'l':  {"l", 0154, 0154, 0154, 0154},
+	'<':  {"less", 0074, 0074, 0074, 0074},
+	'¬':  {"logicalnot", 0, 0302, 0254, 0254},
+	'ł':  {"lslash", 0370, 0, 0, 0233},
+	'm':  {"m", 0155, 0155, 0155, 0155},
+	'¯':  {"macron", 0305, 0370, 0257, 0257},
+	'−':  {"minus", 0, 0, 0, 0212},
+	'µ':  {"mu", 0, 0265, 0265, 0265},
+	'×':  {"multiply", 0, 0, 0327, 0327},
+	'n':  {"n", 0156, 0156, 0156, 0156},
+	'9':  {"nine", 0071, 0071, 0071, 0071},
+	'ñ':  {"ntilde", 0, 0226, 0361, 0361},
+	'#':  {"numbersign", 0043, 0043, 0043, 0043},
+	'o':  {"o", 0157, 0157, 0157, 0157},
+	'ó':  {"oacute", 0, 0227, 0363, 0363},
+	'ô':  {"ocircumflex", 0, 0231, 0364, 0364},
+	'ö':  {"odieresis", 0, 0232, 0366, 0366},
+	'œ':  {"oe", 0372, 0317, 0234, 0234},
+	'˛':  {"ogonek", 0316, 0376, 0, 0035},
+	'ò':  {"ograve", 0, 0230, 0362, 0362},
+	'1':  {"one", 0061, 0061, 0061, 0061},
+	'½':  {"onehalf", 0, 0, 0275, 0275},
+	'¼':  {"onequarter", 0, 0, 0274, 0274},
+	'¹':  {"onesuperior", 0, 0, 0271, 0271},
+	'ª':  {"ordfeminine", 0343, 0273, 0252, 0252},
+	'º':  {"ordmasculine", 0353, 0274, 0272, 0272},
+	'ø':  {"oslash", 0371, 0277, 0370, 0370},
+	'õ':  {"otilde", 0, 0233, 0365, 0365},
+	'p':  {"p", 0160, 0160, 0160, 0160},
+	'¶':  {"paragraph", 0266, 0246, 0266, 0266},
+	'(':  {"parenleft", 0050, 0050, 0050, 0050},
+	')':  {"parenright", 0051, 0051, 0051, 0051},
+	'%':  {"percent", 0045, 0045, 0045, 0045},
+	'.':  {"period", 0056, 0056, 0056, 0056},
+	'·':  {"periodcentered", 0264, 0341, 0267, 0267},
+	'‰':  {"perthousand", 0275, 0344, 0211, 0213},
+	'+':  {"plus", 0053, 0053, 0053, 0053},
+	'±':  {"plusminus", 0, 0261, 0261, 0261},
+	'q':  {"q", 0161, 0161, 0161, 0161},
+	'?':  {"question", 0077, 0077, 0077, 0077},
+	'¿':  {"questiondown", 0277, 0300, 0277, 0277},
+	'"':  {"quotedbl", 0042, 0042, 0042, 0042},
+	'„':  {"quotedblbase", 0271, 0343, 0204, 0214},
+	'“':  {"quotedblleft", 0252, 0322, 0223, 0215},
+	'”':  {"quotedblright", 0272, 0323, 0224, 0216},
+	'‘':  {"quoteleft", 0140, 0324, 0221, 0217},
+	'’':  {"quoteright", 0047, 0325, 0222, 0220},
+	'‚':  {"quotesinglbase", 0270, 0342, 0202, 0221},
+	'\'': {"quotesingle", 0251, 0047, 0047, 0047},
+	'r':  {"r", 0162, 0162, 0162, 0162},
+	'®':  {"registered", 0, 0250, 0256, 0256},
+	'˚':  {"ring", 0312, 0373, 0, 0036},
+	's':  {"s", 0163, 0163, 0163, 0163},
+	'š':  {"scaron", 0, 0, 0232, 0235},
+	'§':  {"section", 0247, 0244, 0247, 0247},
+	';':  {"semicolon", 0073, 0073, 0073, 0073},
+	'7':  {"seven", 0067, 0067, 0067, 0067},
+	'6':  {"six", 0066, 0066, 0066, 0066},
+	'/':  {"slash", 0057, 0057, 0057, 0057},
+	' ':  {"space", 0040, 0040, 0040, 0040},
+	'£':  {"sterling", 0243, 0243, 0243, 0243},
+	't':  {"t", 0164, 0164, 0164, 0164},
+	'þ':  {"thorn", 0, 0, 0376, 0376},
+	'3':  {"three", 0063, 0063, 0063, 0063},
+	'¾':  {"threequarters", 0, 0, 0276, 0276},
+	'³':  {"threesuperior", 0, 0, 0263, 0263},
+	'˜':  {"tilde", 0304, 0367, 0230, 0037},
+	'™':  {"trademark", 0, 0252, 0231, 0222},
+	'2':  {"two", 0062, 0062, 0062, 0062},
+	'²':  {"twosuperior", 0, 0, 0262, 0262},
+	'u':  {"u", 0165, 0165, 0165, 0165},
+	'ú':  {"uacute", 0, 0234, 0372, 0372},
+	'û':  {"ucircumflex", 0, 0236, 0373, 0373},
+	'ü':  {"udieresis", 0, 0237, 0374, 0374},
+	'ù':  {"ugrave", 0, 0235, 0371, 0371},
+	'_':  {"underscore", 0137, 0137, 0137, 0137},
+	'v':  {"v", 0166, 0166, 0166, 0166},
+	'w':  {"w", 0167, 0167, 0167, 0167},
+	'x':  {"x", 0170, 0170, 0170, 0170},
+	'y':  {"y", 0171, 0171, 0171, 0171},
+	'ý':  {"yacute", 0, 0, 0375, 0375},
+	'ÿ':  {"ydieresis", 0, 0330, 0377, 0377},
+	'¥':  {"yen", 0245, 0264, 0245, 0245},
+	'z':  {"z", 0172, 0172, 0172, 0172},
+	'ž':  {"zcaron", 0, 0, 0236, 0236},
+	'0':  {"zero", 0060, 0060, 0060, 0060},
+}
@@ -0,0 +1,47 @@
+package pdf
+
+import (
+	"testing"
+
+	"github.com/tdewolff/test"
+)
+
+func TestGlyphNameToText(t *testing.T) {
+	var tts = []struct {
+		name string
+		text string
+	}{
+		{"A", "A"},
+		{"alpha", "α"},
+		{"arrowright", "→"},
+		{"afii10017", "А"},
+		{"dalethatafpatah", "דֲ"}, // multiple characters in the Adobe Glyph List
+		{"a.sc", "a"},
+		{"uni0041", "A"},
+		{"uni00410042", "AB"},
+		{"u1F600", "😀"},
+		{"f_f_i", "ffi"},
+		{"uni20AC_u0041.alt", "€A"},
+		{"uniD800", ""}, // surrogate
+		{"uni004", ""},  // incomplete
+		{"u110000", ""}, // out of range
+		{"unknown", ""},
+		{".notdef", ""},
+	}
+	for _, tt := range tts {
+		t.Run(tt.name, func(t *testing.T) {
+			text, ok := glyphNameToText(tt.name)
+			test.T(t, ok, tt.text != "")
+			test.T(t, text, tt.text)
+		})
+	}
+
+	r, ok := glyphNameToRune("dalethatafpatah")
+	test.T(t, ok, true)
+	test.T(t, r, 'ד')
+
+	// all glyph names of the standard encodings are in the Adobe Glyph List
+	for r, entry := range charset {
+		test.T(t, aglGlyphNames[entry.name], string(r), entry.name)
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package pdf
 
 import (
 	"bytes"
@@ -10,7 +10,8 @@ import (
 
 var passwordPadding []byte = []byte("\x28\xBF\x4E\x5E\x4E\x75\x8A\x41\x64\x00\x4E\x56\xFF\xFA\x01\x08\x2E\x2E\x00\xB6\xD0\x68\x3E\x80\x2F\x0C\xA9\xFE\x64\x53\x69\x7A")
 
-var BadPassword error = fmt.Errorf("bad password")
+// ErrBadPassword is returned when opening an encrypted PDF file with the wrong password.
+var ErrBadPassword = fmt.Errorf("bad password")
 
 type pdfEncrypt struct {
 	isEncrypted bool
@@ -48,7 +49,7 @@ func (r *pdfReader) readEncrypt(password []byte) error {
 	V, _ := encrypt["V"].(int)
 	if V != 1 && V != 2 && V != 4 {
 		return fmt.Errorf("bad encryption algorithm")
-	} else if V != 2 {
+	} else if V == 4 {
 		return fmt.Errorf("unsupported encryption algorithm")
 	}
 	length, ok := encrypt["Length"].(int)
@@ -78,7 +79,7 @@ func (r *pdfReader) readEncrypt(password []byte) error {
 	}
 	encryptMetadata := true
 	if val, ok := encrypt["EncryptMetadata"]; ok {
-		encryptMetadata = val.(bool)
+		encryptMetadata, _ = val.(bool)
 	}
 
 	// pad or clip password to 32 bytes, password may be empty (default password)
@@ -127,7 +128,7 @@ func (r *pdfReader) readEncrypt(password []byte) error {
 	// authenticate
 	isOwner := r.encrypt.authenticateOwner(password)
 	if !isOwner && !r.encrypt.authenticateUser(password) {
-		return BadPassword
+		return ErrBadPassword
 	}
 	r.encrypt.owner = isOwner
 	return nil
@@ -193,7 +194,7 @@ func (encrypt pdfEncrypt) authenticateOwner(password []byte) bool {
 	return encrypt.authenticateUser(dst)
 }
 
-func (encrypt pdfEncrypt) Encrypt(ref pdfRef, data []byte) []byte {
+func (encrypt pdfEncrypt) Encrypt(ref pdfIndirectRef, data []byte) []byte {
 	key := append(encrypt.key[:encrypt.n], []byte("\x00\x00\x00\x00\x00")...)
 	key[len(encrypt.key)+0] = byte(ref[0])
 	key[len(encrypt.key)+1] = byte(ref[0] >> 8)
@@ -216,6 +217,6 @@ func (encrypt pdfEncrypt) Encrypt(ref pdfRef, data []byte) []byte {
 	return dst
 }
 
-func (encrypt pdfEncrypt) Decrypt(ref pdfRef, data []byte) []byte {
+func (encrypt pdfEncrypt) Decrypt(ref pdfIndirectRef, data []byte) []byte {
 	return encrypt.Encrypt(ref, data)
 }
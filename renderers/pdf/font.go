@@ -0,0 +1,633 @@
+package pdf
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/LaminoidStudio/Canvas"
+	"github.com/LaminoidStudio/Canvas/font"
+)
+
+// pdfCMap maps character codes to CIDs or to Unicode text, see PDF reference 9.7.5 and 9.10.3.
+type pdfCMap struct {
+	codespaces []pdfCodespace
+	cids       []pdfCIDRange
+	unicode    map[uint32][]rune
+}
+
+type pdfCodespace struct {
+	n      int // number of bytes
+	lo, hi uint32
+}
+
+type pdfCIDRange struct {
+	lo, hi, cid uint32
+}
+
+// parseCMap parses an embedded CMap stream. Parsing stops at the first error while keeping the mappings read so far, and CMaps referenced by usecmap are ignored.
+func parseCMap(b []byte) *pdfCMap {
+	cmap := &pdfCMap{unicode: map[uint32][]rune{}}
+	r := newPDFStreamReader(b)
+	for {
+		op, vals, err := r.Next()
+		if err != nil {
+			break
+		}
+		switch op {
+		case "endcodespacerange":
+			for i := 0; i+1 < len(vals); i += 2 {
+				lo, ok0 := vals[i].([]byte)
+				hi, ok1 := vals[i+1].([]byte)
+				if ok0 && ok1 && len(lo) == len(hi) && 0 < len(lo) && len(lo) <= 4 {
+					cmap.codespaces = append(cmap.codespaces, pdfCodespace{len(lo), readNumberBE(lo, len(lo)), readNumberBE(hi, len(hi))})
+				}
+			}
+		case "endcidrange":
+			for i := 0; i+2 < len(vals); i += 3 {
+				lo, ok0 := vals[i].([]byte)
+				hi, ok1 := vals[i+1].([]byte)
+				cid, ok2 := vals[i+2].(int)
+				if ok0 && ok1 && ok2 && 0 <= cid && len(lo) <= 4 && len(hi) <= 4 {
+					cmap.cids = append(cmap.cids, pdfCIDRange{readNumberBE(lo, len(lo)), readNumberBE(hi, len(hi)), uint32(cid)})
+				}
+			}
+		case "endcidchar":
+			for i := 0; i+1 < len(vals); i += 2 {
+				code, ok0 := vals[i].([]byte)
+				cid, ok1 := vals[i+1].(int)
+				if ok0 && ok1 && 0 <= cid && len(code) <= 4 {
+					c := readNumberBE(code, len(code))
+					cmap.cids = append(cmap.cids, pdfCIDRange{c, c, uint32(cid)})
+				}
+			}
+		case "endbfchar":
+			for i := 0; i+1 < len(vals); i += 2 {
+				code, ok0 := vals[i].([]byte)
+				dst, ok1 := vals[i+1].([]byte)
+				if ok0 && ok1 && len(code) <= 4 {
+					cmap.unicode[readNumberBE(code, len(code))] = utf16BE(dst)
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(vals); i += 3 {
+				lo, ok0 := vals[i].([]byte)
+				hi, ok1 := vals[i+1].([]byte)
+				if !ok0 || !ok1 || 4 < len(lo) || 4 < len(hi) {
+					continue
+				}
+				c0, c1 := readNumberBE(lo, len(lo)), readNumberBE(hi, len(hi))
+				if c1 < c0 || 0xFFFF < c1-c0 {
+					continue
+				}
+				if dst, ok := vals[i+2].([]byte); ok {
+					rs := utf16BE(dst)
+					for c := c0; c <= c1 && 0 < len(rs); c++ {
+						text := append([]rune{}, rs...)
+						text[len(text)-1] += rune(c - c0)
+						cmap.unicode[c] = text
+					}
+				} else if array, ok := vals[i+2].(pdfArray); ok {
+					for j, item := range array {
+						if dst, ok := item.([]byte); ok && c0+uint32(j) <= c1 {
+							cmap.unicode[c0+uint32(j)] = utf16BE(dst)
+						}
+					}
+				}
+			}
+		}
+	}
+	return cmap
+}
+
+// utf16BE decodes big-endian UTF-16 text.
+func utf16BE(b []byte) []rune {
+	s := make([]uint16, len(b)/2)
+	for i := range s {
+		s[i] = uint16(b[2*i])<<8 | uint16(b[2*i+1])
+	}
+	if len(b)%2 == 1 {
+		s = append(s, uint16(b[len(b)-1]))
+	}
+	return utf16.Decode(s)
+}
+
+// Next returns the next character code of a string and its length in bytes.
+func (cmap *pdfCMap) Next(s []byte) (uint32, int) {
+	if cmap == nil || len(cmap.codespaces) == 0 {
+		// Identity-H and Identity-V
+		if len(s) < 2 {
+			return uint32(s[0]), 1
+		}
+		return uint32(s[0])<<8 | uint32(s[1]), 2
+	}
+	for n := 1; n <= 4 && n <= len(s); n++ {
+		code := readNumberBE(s, n)
+		for _, codespace := range cmap.codespaces {
+			if codespace.n == n && codespace.lo <= code && code <= codespace.hi {
+				return code, n
+			}
+		}
+	}
+
+	// invalid code, use the length of the shortest codespace with a matching first byte
+	n := 1
+	for _, codespace := range cmap.codespaces {
+		if codespace.n <= len(s) && byte(codespace.lo>>(8*uint(codespace.n-1))) <= s[0] && s[0] <= byte(codespace.hi>>(8*uint(codespace.n-1))) {
+			n = codespace.n
+			break
+		}
+	}
+	return readNumberBE(s, n), n
+}
+
+// CID returns the CID for a character code.
+func (cmap *pdfCMap) CID(code uint32) uint32 {
+	if cmap == nil || len(cmap.codespaces) == 0 {
+		return code
+	}
+	for _, rng := range cmap.cids {
+		if rng.lo <= code && code <= rng.hi {
+			return rng.cid + code - rng.lo
+		}
+	}
+	return 0
+}
+
+////////////////////////////////////////////////////////////////
+
+// pdfFont is a font resource used to draw text, see PDF reference 9.5.
+type pdfFont struct {
+	subtype  pdfName
+	type0    bool
+	cmap     *pdfCMap // nil for Identity-H and Identity-V
+	vertical bool
+
+	toUnicode *pdfCMap
+	encoding  [256]string // glyph names for simple fonts, empty when not specified
+	symbolic  bool
+
+	widths       map[uint32]float64 // by character code for simple fonts and by CID for composite fonts
+	defaultWidth float64
+	hasWidths    bool
+	vertAdvance  float64 // vertical displacement for vertical writing
+	vertOrigin   float64 // vertical position vector for vertical writing
+
+	sfnt       *font.SFNT // embedded font
+	glyphScale float64
+	cidToGID   []uint16 // nil for Identity
+	fallback   *font.SFNT
+	fbScale    float64
+
+	// Type3 fonts
+	charProcs  pdfDict
+	fontMatrix canvas.Matrix
+	resources  pdfDict
+
+	glyphs map[uint32]*canvas.Path
+}
+
+// pdfChar is a character of a string shown with a font.
+type pdfChar struct {
+	code uint32
+	cid  uint32
+	n    int // number of bytes
+}
+
+// GetFont loads a font dictionary and its embedded font program. Fonts that cannot be embedded, such as the standard 14 fonts and Type1 fonts, use the fallback font family if given.
+func (r *pdfReader) GetFont(val interface{}, fallback *canvas.FontFamily) (*pdfFont, error) {
+	dict, err := r.GetDict(val)
+	if err != nil {
+		return nil, fmt.Errorf("bad font: %w", err)
+	}
+	f := &pdfFont{
+		defaultWidth: 0.0,
+		vertAdvance:  -1.0,
+		vertOrigin:   0.88,
+		fontMatrix:   canvas.Identity.Scale(0.001, 0.001),
+		glyphs:       map[uint32]*canvas.Path{},
+	}
+	f.subtype, _ = r.GetName(dict["Subtype"])
+	if stream, err := r.GetStream(dict["ToUnicode"]); err == nil {
+		f.toUnicode = parseCMap(stream.stream)
+	}
+
+	descendant := dict
+	if f.subtype == "Type0" {
+		f.type0 = true
+		f.defaultWidth = 1.0
+		if name, err := r.GetName(dict["Encoding"]); err == nil {
+			f.vertical = strings.HasSuffix(string(name), "-V")
+		} else if stream, err := r.GetStream(dict["Encoding"]); err == nil {
+			f.cmap = parseCMap(stream.stream)
+			wmode, _ := r.GetInt(stream.dict["WMode"])
+			f.vertical = wmode == 1
+		}
+
+		descendants, err := r.GetArray(dict["DescendantFonts"])
+		if err != nil || len(descendants) != 1 {
+			return nil, fmt.Errorf("bad DescendantFonts")
+		} else if descendant, err = r.GetDict(descendants[0]); err != nil {
+			return nil, fmt.Errorf("bad DescendantFonts: %w", err)
+		}
+		if dw, err := r.GetFloat(descendant["DW"]); err == nil {
+			f.defaultWidth = dw / 1000.0
+		}
+		if dw2, err := r.GetFloats(descendant["DW2"]); err == nil && len(dw2) == 2 {
+			f.vertOrigin, f.vertAdvance = dw2[0]/1000.0, dw2[1]/1000.0
+		}
+		f.widths = map[uint32]float64{}
+		if w, err := r.GetArray(descendant["W"]); err == nil {
+			f.hasWidths = true
+			for i := 0; i+1 < len(w); {
+				first, err := r.GetInt(w[i])
+				if err != nil || first < 0 {
+					break
+				}
+				if array, err := r.GetArray(w[i+1]); err == nil {
+					for j := range array {
+						width, _ := r.GetFloat(array[j])
+						f.widths[uint32(first+j)] = width / 1000.0
+					}
+					i += 2
+				} else if i+2 < len(w) {
+					last, _ := r.GetInt(w[i+1])
+					width, _ := r.GetFloat(w[i+2])
+					for cid := first; cid <= last && cid-first < 0x10000; cid++ {
+						f.widths[uint32(cid)] = width / 1000.0
+					}
+					i += 3
+				} else {
+					break
+				}
+			}
+		}
+		if stream, err := r.GetStream(descendant["CIDToGIDMap"]); err == nil {
+			f.cidToGID = make([]uint16, len(stream.stream)/2)
+			for i := range f.cidToGID {
+				f.cidToGID[i] = uint16(readNumberBE(stream.stream[2*i:], 2))
+			}
+		}
+	} else {
+		// simple font
+		if f.subtype == "Type3" {
+			if f.charProcs, err = r.GetDict(dict["CharProcs"]); err != nil {
+				return nil, fmt.Errorf("bad CharProcs")
+			}
+			if m, err := r.GetFloats(dict["FontMatrix"]); err == nil && len(m) == 6 {
+				f.fontMatrix = canvas.Matrix{{m[0], m[2], m[4]}, {m[1], m[3], m[5]}}
+			}
+			f.resources, _ = r.GetDict(dict["Resources"])
+		}
+		if err := r.readEncoding(f, dict["Encoding"]); err != nil {
+			return nil, err
+		}
+
+		f.widths = map[uint32]float64{}
+		if widths, err := r.GetFloats(dict["Widths"]); err == nil {
+			f.hasWidths = true
+			firstChar, _ := r.GetInt(dict["FirstChar"])
+			for i, width := range widths {
+				if f.subtype == "Type3" {
+					f.widths[uint32(firstChar+i)] = width * f.fontMatrix[0][0]
+				} else {
+					f.widths[uint32(firstChar+i)] = width / 1000.0
+				}
+			}
+		}
+	}
+
+	descriptor, _ := r.GetDict(descendant["FontDescriptor"])
+	if missingWidth, err := r.GetFloat(descriptor["MissingWidth"]); err == nil && !f.type0 {
+		f.defaultWidth = missingWidth / 1000.0
+	}
+	flags, _ := r.GetInt(descriptor["Flags"])
+	f.symbolic = flags&0x04 != 0
+
+	// load embedded font program
+	if stream, err := r.GetStream(descriptor["FontFile2"]); err == nil {
+		f.sfnt, _ = font.ParseEmbeddedSFNT(stream.stream, 0)
+	} else if stream, err := r.GetStream(descriptor["FontFile3"]); err == nil {
+		if subtype, _ := r.GetName(stream.dict["Subtype"]); subtype == "OpenType" {
+			f.sfnt, _ = font.ParseEmbeddedSFNT(stream.stream, 0)
+		} else {
+			f.sfnt, _ = font.ParseCFF(stream.stream)
+		}
+	}
+	if f.sfnt != nil {
+		if f.sfnt.Head != nil && f.sfnt.Head.UnitsPerEm != 0 {
+			f.glyphScale = 1.0 / float64(f.sfnt.Head.UnitsPerEm)
+		} else if f.sfnt.IsCFF {
+			f.glyphScale = f.sfnt.CFF.FontMatrix()[0]
+		}
+	}
+
+	if f.subtype != "Type3" && fallback != nil {
+		name, _ := r.GetName(descendant["BaseFont"])
+		weight, _ := r.GetFloat(descriptor["FontWeight"])
+		italicAngle, _ := r.GetFloat(descriptor["ItalicAngle"])
+		style := canvas.FontRegular
+		if 600.0 <= weight || flags&0x40000 != 0 || strings.Contains(string(name), "Bold") {
+			style = canvas.FontBold
+		}
+		if italicAngle != 0.0 || flags&0x40 != 0 || strings.Contains(string(name), "Italic") || strings.Contains(string(name), "Oblique") {
+			style |= canvas.FontItalic
+		}
+		face := fallback.Face(12.0, canvas.Black, style, canvas.FontNormal)
+		f.fallback = face.Font.SFNT
+		f.fbScale = 1.0 / float64(f.fallback.Head.UnitsPerEm)
+	}
+	return f, nil
+}
+
+// readEncoding reads the encoding of a simple font, which is a predefined encoding name or a dictionary with differences to a base encoding.
+func (r *pdfReader) readEncoding(f *pdfFont, val interface{}) error {
+	val, err := r.get(val)
+	if err != nil {
+		return err
+	}
+	if name, ok := val.(pdfName); ok {
+		if encoding, ok := pdfEncodings[name]; ok {
+			f.encoding = *encoding
+		}
+		return nil
+	} else if val == nil {
+		return nil
+	}
+
+	dict, ok := val.(pdfDict)
+	if !ok {
+		return fmt.Errorf("bad font encoding")
+	}
+	if name, err := r.GetName(dict["BaseEncoding"]); err == nil {
+		if encoding, ok := pdfEncodings[name]; ok {
+			f.encoding = *encoding
+		}
+	}
+	if differences, err := r.GetArray(dict["Differences"]); err == nil {
+		code := 0
+		for _, item := range differences {
+			if i, ok := item.(int); ok {
+				code = i
+			} else if name, ok := item.(pdfName); ok && 0 <= code && code < 256 {
+				f.encoding[code] = string(name)
+				code++
+			}
+		}
+	}
+	return nil
+}
+
+// Chars splits a string into its character codes.
+func (f *pdfFont) Chars(s []byte) []pdfChar {
+	chars := []pdfChar{}
+	for 0 < len(s) {
+		if !f.type0 {
+			chars = append(chars, pdfChar{uint32(s[0]), uint32(s[0]), 1})
+			s = s[1:]
+			continue
+		}
+		code, n := f.cmap.Next(s)
+		chars = append(chars, pdfChar{code, f.cmap.CID(code), n})
+		s = s[n:]
+	}
+	return chars
+}
+
+// Width returns the horizontal displacement of a character in text space units.
+func (f *pdfFont) Width(c pdfChar) float64 {
+	if width, ok := f.widths[c.cid]; ok {
+		return width
+	} else if !f.hasWidths && f.fallback != nil && f.sfnt == nil {
+		// standard 14 fonts have no widths
+		if r, ok := f.Unicode(c); ok {
+			if glyphID := f.fallback.GlyphIndex(r); glyphID != 0 {
+				return float64(f.fallback.GlyphAdvance(glyphID)) * f.fbScale
+			}
+		}
+	} else if !f.hasWidths && f.sfnt != nil && f.sfnt.Hmtx != nil {
+		if sfnt, glyphID, scale, ok := f.glyph(c); ok && sfnt == f.sfnt {
+			return float64(sfnt.GlyphAdvance(glyphID)) * scale
+		}
+	}
+	return f.defaultWidth
+}
+
+// Unicode returns the first Unicode character represented by a character code.
+func (f *pdfFont) Unicode(c pdfChar) (rune, bool) {
+	if text, ok := f.unicode(c); ok {
+		r, _ := utf8.DecodeRuneInString(text)
+		return r, true
+	}
+	return 0, false
+}
+
+// unicode returns the Unicode characters represented by a character code, using the ToUnicode mapping or the glyph name of simple fonts.
+func (f *pdfFont) unicode(c pdfChar) (string, bool) {
+	if f.toUnicode != nil {
+		if rs, ok := f.toUnicode.unicode[c.code]; ok && 0 < len(rs) {
+			return string(rs), true
+		}
+	}
+	if !f.type0 {
+		if name := f.encoding[c.code]; name != "" {
+			return glyphNameToText(name)
+		} else if f.sfnt != nil && f.sfnt.IsCFF && f.sfnt.CFF != nil {
+			if glyphID, ok := f.sfnt.CFF.Encoding()[byte(c.code)]; ok {
+				return glyphNameToText(f.sfnt.CFF.GlyphName(glyphID))
+			}
+		}
+		if name := pdfEncodings["StandardEncoding"][c.code]; name != "" && !f.symbolic {
+			return glyphNameToText(name)
+		}
+	}
+	return "", false
+}
+
+// Text returns the Unicode text of a string, characters without a Unicode mapping are replaced by U+FFFD.
+func (f *pdfFont) Text(s []byte) string {
+	sb := strings.Builder{}
+	for _, c := range f.Chars(s) {
+		if text, ok := f.unicode(c); ok {
+			sb.WriteString(text)
+		} else {
+			sb.WriteRune(utf8.RuneError)
+		}
+	}
+	return sb.String()
+}
+
+// Encode returns the string of character codes that shows the given text, using the ToUnicode mapping and the encoding of simple fonts. Multi-character mappings are matched greedily and the lowest character code is used when several map to the same text.
+func (f *pdfFont) Encode(text string) ([]byte, error) {
+	codes := map[string]uint32{}
+	maxLen := 1
+	if f.toUnicode != nil {
+		for code, rs := range f.toUnicode.unicode {
+			if 0 < len(rs) {
+				if prev, ok := codes[string(rs)]; !ok || code < prev {
+					codes[string(rs)] = code
+				}
+				if maxLen < len(rs) {
+					maxLen = len(rs)
+				}
+			}
+		}
+	}
+	if !f.type0 {
+		for code := uint32(0); code < 256; code++ {
+			if f.toUnicode != nil && f.toUnicode.unicode[code] != nil {
+				continue
+			} else if unicode, ok := f.unicode(pdfChar{code, code, 1}); ok {
+				if _, ok := codes[unicode]; !ok {
+					codes[unicode] = code
+				}
+				if n := utf8.RuneCountInString(unicode); maxLen < n {
+					maxLen = n
+				}
+			}
+		}
+	}
+
+	b := []byte{}
+	rs := []rune(text)
+	for i := 0; i < len(rs); {
+		n := maxLen
+		if len(rs)-i < n {
+			n = len(rs) - i
+		}
+		for ; 0 < n; n-- {
+			if code, ok := codes[string(rs[i:i+n])]; ok {
+				b = append(b, f.codeBytes(code)...)
+				break
+			}
+		}
+		if n == 0 {
+			return nil, fmt.Errorf("character %q cannot be encoded in the font", rs[i])
+		}
+		i += n
+	}
+	return b, nil
+}
+
+// codeBytes returns the bytes of a character code, which are as many as given by the codespace of composite fonts.
+func (f *pdfFont) codeBytes(code uint32) []byte {
+	n := 1
+	if f.type0 {
+		n = 2
+		if f.cmap != nil {
+			for _, codespace := range f.cmap.codespaces {
+				if codespace.lo <= code && code <= codespace.hi {
+					n = codespace.n
+					break
+				}
+			}
+		}
+	}
+	b := make([]byte, n)
+	for i := n - 1; 0 <= i; i-- {
+		b[i] = byte(code)
+		code >>= 8
+	}
+	return b
+}
+
+// glyph returns the font and glyph ID used to draw a character and the scale from glyph space to text space.
+func (f *pdfFont) glyph(c pdfChar) (*font.SFNT, uint16, float64, bool) {
+	if sfnt := f.sfnt; sfnt != nil {
+		if f.type0 {
+			if f.cidToGID != nil {
+				if int(c.cid) < len(f.cidToGID) {
+					return sfnt, f.cidToGID[c.cid], f.glyphScale, true
+				}
+			} else if sfnt.IsCFF && sfnt.CFF != nil {
+				if glyphID, ok := sfnt.CFF.GlyphIndexByCID(uint16(c.cid)); ok {
+					return sfnt, glyphID, f.glyphScale, true
+				}
+			} else if int(c.cid) < int(sfnt.NumGlyphs()) {
+				return sfnt, uint16(c.cid), f.glyphScale, true
+			}
+		} else {
+			name := f.encoding[c.code]
+			if sfnt.IsCFF && sfnt.CFF != nil {
+				if name != "" {
+					if glyphID, ok := sfnt.CFF.GlyphIndexByName(name); ok {
+						return sfnt, glyphID, f.glyphScale, true
+					}
+				}
+				if glyphID, ok := sfnt.CFF.Encoding()[byte(c.code)]; ok {
+					return sfnt, glyphID, f.glyphScale, true
+				}
+				if name = pdfEncodings["StandardEncoding"][c.code]; name != "" {
+					if glyphID, ok := sfnt.CFF.GlyphIndexByName(name); ok {
+						return sfnt, glyphID, f.glyphScale, true
+					}
+				}
+			}
+			if sfnt.Cmap != nil {
+				if r, ok := glyphNameToRune(name); ok && name != "" {
+					if glyphID := sfnt.GlyphIndex(r); glyphID != 0 {
+						return sfnt, glyphID, f.glyphScale, true
+					}
+				}
+				if glyphID := sfnt.GlyphIndex(0xF000 + rune(c.code)); glyphID != 0 {
+					return sfnt, glyphID, f.glyphScale, true
+				} else if glyphID := sfnt.GlyphIndex(rune(c.code)); glyphID != 0 {
+					return sfnt, glyphID, f.glyphScale, true
+				}
+			} else if sfnt.IsTrueType && int(c.code) < int(sfnt.NumGlyphs()) {
+				return sfnt, uint16(c.code), f.glyphScale, true
+			}
+		}
+	}
+	if f.fallback != nil {
+		if r, ok := f.Unicode(c); ok {
+			if glyphID := f.fallback.GlyphIndex(r); glyphID != 0 {
+				return f.fallback, glyphID, f.fbScale, true
+			}
+		}
+	}
+	return nil, 0, 0.0, false
+}
+
+// GlyphPath returns the outline of a character in text space, or nil if the glyph is not available.
+func (f *pdfFont) GlyphPath(c pdfChar) *canvas.Path {
+	if p, ok := f.glyphs[c.code]; ok {
+		return p
+	}
+
+	var p *canvas.Path
+	if sfnt, glyphID, scale, ok := f.glyph(c); ok {
+		p = &canvas.Path{}
+		if err := sfnt.GlyphPath(p, glyphID, 0, 0.0, 0.0, scale, font.NoHinting); err != nil {
+			p = nil
+		} else if sfnt == f.fallback && f.sfnt == nil && f.hasWidths {
+			// stretch the fallback glyph to the width of the original glyph
+			if advance := float64(sfnt.GlyphAdvance(glyphID)) * scale; 0.0 < advance {
+				if width := f.Width(c); 0.0 < width {
+					p = p.Transform(canvas.Identity.Scale(width/advance, 1.0))
+				}
+			}
+		}
+	}
+	if p != nil && f.vertical {
+		p = p.Translate(-f.Width(c)/2.0, -f.vertOrigin)
+	}
+	if len(f.glyphs) < 4096 {
+		f.glyphs[c.code] = p
+	}
+	return p
+}
+
+// Type3Glyph returns the content stream of a glyph of a Type3 font.
+func (r *pdfReader) Type3Glyph(f *pdfFont, c pdfChar) ([]byte, error) {
+	name := f.encoding[c.code]
+	if name == "" {
+		return nil, fmt.Errorf("unknown glyph for character code %v", c.code)
+	}
+	stream, err := r.GetStream(f.charProcs[pdfName(name)])
+	if err != nil {
+		return nil, err
+	}
+	return stream.stream, nil
+}
@@ -0,0 +1,63 @@
+//go:build exclude
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// generates glyphlist.go from the Adobe Glyph List in agl.txt, run with go run glyphlist_gen.go
+func main() {
+	r, err := os.Open("agl.txt")
+	if err != nil {
+		panic(err)
+	}
+	defer r.Close()
+
+	w, err := os.Create("glyphlist.go")
+	if err != nil {
+		panic(err)
+	}
+	defer w.Close()
+
+	w.WriteString("// Code generated by glyphlist_gen.go from agl.txt; DO NOT EDIT.\n\n")
+	w.WriteString("package pdf\n\n")
+	w.WriteString("// aglGlyphNames maps the glyph names of the Adobe Glyph List to their Unicode characters, see agl.txt for its license.\n")
+	w.WriteString("var aglGlyphNames = map[string]string{\n")
+	scanner := bufio.NewScanner(r)
+	i := 0
+	for scanner.Scan() {
+		i++
+		line := scanner.Text()
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		cols := strings.Split(line, ";")
+		if len(cols) != 2 || (len(cols[1])+1)%5 != 0 {
+			panic(fmt.Sprintf("bad format at line %d", i))
+		}
+
+		codes := []uint16{}
+		for _, field := range strings.Fields(cols[1]) {
+			code, err := strconv.ParseUint(field, 16, 16)
+			if err != nil {
+				panic(fmt.Sprintf("bad code at line %d: %v", i, err))
+			}
+			codes = append(codes, uint16(code))
+		}
+		s := ""
+		for _, r := range utf16.Decode(codes) {
+			s += fmt.Sprintf("\\u%04x", r)
+		}
+		fmt.Fprintf(w, "\t%q: \"%s\",\n", cols[0], s)
+	}
+	w.WriteString("}\n")
+	if err := scanner.Err(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,92 @@
+package pdf
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// sRGBProfile returns an ICC version 2 display profile of the sRGB IEC61966-2.1 color space. The primaries are adapted to the D50 illuminant of the profile connection space and the tone reproduction curves are sampled from the sRGB transfer function.
+func sRGBProfile() []byte {
+	type tag struct {
+		sig  string
+		data []byte
+	}
+
+	text := func(s string) []byte {
+		b := append([]byte("text\x00\x00\x00\x00"), s...)
+		return append(b, 0)
+	}
+	desc := func(s string) []byte {
+		b := []byte("desc\x00\x00\x00\x00")
+		b = binary.BigEndian.AppendUint32(b, uint32(len(s)+1))
+		b = append(b, s...)
+		b = append(b, 0)
+		b = append(b, make([]byte, 4+4+2+1+67)...) // empty Unicode and ScriptCode descriptions
+		return b
+	}
+	xyz := func(x, y, z float64) []byte {
+		b := []byte("XYZ \x00\x00\x00\x00")
+		for _, v := range []float64{x, y, z} {
+			b = binary.BigEndian.AppendUint32(b, uint32(int32(math.Round(v*65536.0))))
+		}
+		return b
+	}
+
+	const n = 1024
+	trc := []byte("curv\x00\x00\x00\x00")
+	trc = binary.BigEndian.AppendUint32(trc, n)
+	for i := 0; i < n; i++ {
+		v := float64(i) / (n - 1)
+		if v <= 0.04045 {
+			v /= 12.92
+		} else {
+			v = math.Pow((v+0.055)/1.055, 2.4)
+		}
+		trc = binary.BigEndian.AppendUint16(trc, uint16(math.Round(v*65535.0)))
+	}
+
+	tags := []tag{
+		{"desc", desc("sRGB IEC61966-2.1")},
+		{"cprt", text("No copyright, use freely")},
+		{"wtpt", xyz(0.9642, 1.0, 0.8249)},
+		{"rXYZ", xyz(0.4361, 0.2225, 0.0139)},
+		{"gXYZ", xyz(0.3851, 0.7169, 0.0971)},
+		{"bXYZ", xyz(0.1431, 0.0606, 0.7141)},
+		{"rTRC", trc},
+		{"gTRC", trc},
+		{"bTRC", trc},
+	}
+
+	// the tag data follows the header and the tag table, the tone reproduction curves share their data
+	offset := 128 + 4 + 12*len(tags)
+	table := binary.BigEndian.AppendUint32(nil, uint32(len(tags)))
+	data := []byte{}
+	offsets := map[*byte]int{}
+	for _, t := range tags {
+		pos, ok := offsets[&t.data[0]]
+		if !ok {
+			pos = offset + len(data)
+			offsets[&t.data[0]] = pos
+			data = append(data, t.data...)
+			for len(data)%4 != 0 {
+				data = append(data, 0)
+			}
+		}
+		table = append(table, t.sig...)
+		table = binary.BigEndian.AppendUint32(table, uint32(pos))
+		table = binary.BigEndian.AppendUint32(table, uint32(len(t.data)))
+	}
+
+	header := make([]byte, 128)
+	binary.BigEndian.PutUint32(header[0:], uint32(offset+len(data)))
+	binary.BigEndian.PutUint32(header[8:], 0x02100000) // version 2.1
+	copy(header[12:], "mntrRGB XYZ ")
+	binary.BigEndian.PutUint16(header[24:], 2000) // creation date
+	binary.BigEndian.PutUint16(header[26:], 1)
+	binary.BigEndian.PutUint16(header[28:], 1)
+	copy(header[36:], "acsp")
+	copy(header[68:], xyz(0.9642, 1.0, 0.8249)[8:]) // illuminant D50
+
+	b := append(header, table...)
+	return append(b, data...)
+}
@@ -0,0 +1,222 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"math"
+)
+
+// maxImagePixels is the maximum number of pixels of images that are decoded.
+const maxImagePixels = 1 << 26
+
+// GetImage decodes an image XObject or inline image. Stencil masks are painted with the fill color. Images compressed with DCTDecode are supported, while JPXDecode, CCITTFaxDecode, and JBIG2Decode are not.
+func (r *pdfReader) GetImage(stream pdfStream, resources pdfDict, fill color.RGBA) (image.Image, error) {
+	return r.getImage(stream, resources, fill, false)
+}
+
+func (r *pdfReader) getImage(stream pdfStream, resources pdfDict, fill color.RGBA, isMask bool) (*image.NRGBA, error) {
+	dict := stream.dict
+	getInt := func(key pdfName) int {
+		v, _ := r.GetInt(dict[key])
+		return v
+	}
+	getVal := func(key pdfName) interface{} {
+		v, _ := r.get(dict[key])
+		return v
+	}
+
+	width, height := getInt("Width"), getInt("Height")
+	if width <= 0 || height <= 0 || maxImagePixels < width*height {
+		return nil, fmt.Errorf("bad image size")
+	}
+	imageMask, _ := getVal("ImageMask").(bool)
+
+	var decode []float64
+	if array, ok := getVal("Decode").(pdfArray); ok {
+		for _, item := range array {
+			switch v := item.(type) {
+			case int:
+				decode = append(decode, float64(v))
+			case float64:
+				decode = append(decode, v)
+			}
+		}
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	filter, _ := dict["Filter"].(pdfName)
+	if filter == "DCTDecode" || filter == "DCT" {
+		src, err := jpeg.Decode(bytes.NewReader(stream.stream))
+		if err != nil {
+			return nil, err
+		}
+		draw.Draw(img, img.Bounds(), src, src.Bounds().Min, draw.Src)
+		if len(decode) == 8 && decode[0] == 1.0 && decode[1] == 0.0 {
+			// inverted CMYK
+			for i := 0; i < len(img.Pix); i += 4 {
+				img.Pix[i+0] = 255 - img.Pix[i+0]
+				img.Pix[i+1] = 255 - img.Pix[i+1]
+				img.Pix[i+2] = 255 - img.Pix[i+2]
+			}
+		}
+	} else if filter != "" {
+		return nil, fmt.Errorf("unsupported image filter %v", filter)
+	} else if imageMask || isMask {
+		// stencil mask, samples of zero are painted unless the decode array is inverted
+		paint := byte(0)
+		if 2 <= len(decode) && decode[0] == 1.0 {
+			paint = 1
+		}
+		if isMask {
+			paint = 1 - paint // for the Mask entry, samples of one are masked out
+		}
+		rowSize := (width + 7) / 8
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				j := y*rowSize + x/8
+				if len(stream.stream) <= j {
+					break
+				}
+				if (stream.stream[j]>>(7-uint(x%8)))&1 == paint {
+					i := img.PixOffset(x, y)
+					img.Pix[i+0], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = fill.R, fill.G, fill.B, 255
+				}
+			}
+		}
+		if fill.A != 0 && fill.A != 255 {
+			// stencil masks are painted with the alpha of the fill color
+			for i := 3; i < len(img.Pix); i += 4 {
+				img.Pix[i] = uint8(uint32(img.Pix[i]) * uint32(fill.A) / 255)
+			}
+			for i := 0; i < len(img.Pix); i += 4 {
+				if img.Pix[i+3] != 0 {
+					img.Pix[i+0] = uint8(uint32(fill.R) * 255 / uint32(fill.A))
+					img.Pix[i+1] = uint8(uint32(fill.G) * 255 / uint32(fill.A))
+					img.Pix[i+2] = uint8(uint32(fill.B) * 255 / uint32(fill.A))
+				}
+			}
+		}
+		return img, nil
+	} else {
+		var cs pdfColorSpace = pdfDeviceGray{}
+		if val, ok := dict["ColorSpace"]; ok {
+			var err error
+			if cs, err = r.GetColorSpace(val, resources); err != nil {
+				return nil, err
+			}
+		}
+		bpc := getInt("BitsPerComponent")
+		if bpc != 1 && bpc != 2 && bpc != 4 && bpc != 8 && bpc != 16 {
+			return nil, fmt.Errorf("bad image bits per component")
+		}
+		n := cs.Components()
+		if n == 0 {
+			return nil, fmt.Errorf("bad image color space")
+		}
+		max := math.Pow(2.0, float64(bpc)) - 1.0
+		if len(decode) != 2*n {
+			decode = make([]float64, 2*n)
+			for i := 0; i < n; i++ {
+				decode[2*i+1] = 1.0
+				if _, ok := cs.(pdfIndexed); ok {
+					decode[2*i+1] = max
+				}
+			}
+		}
+
+		// color key masking
+		var colorKey []int
+		if array, ok := getVal("Mask").(pdfArray); ok && len(array) == 2*n {
+			for _, item := range array {
+				v, _ := item.(int)
+				colorKey = append(colorKey, v)
+			}
+		}
+
+		rowSize := (width*n*bpc + 7) / 8
+		samples := make([]int, n)
+		vals := make([]float64, n)
+		cache := map[[4]int]color.RGBA{}
+		for y := 0; y < height; y++ {
+			row := y * rowSize
+			if len(stream.stream) < row+rowSize {
+				break
+			}
+			for x := 0; x < width; x++ {
+				pos := x * n * bpc
+				for k := 0; k < n; k++ {
+					samples[k] = readSample(stream.stream[row:row+rowSize], pos+k*bpc, bpc)
+				}
+
+				var key [4]int
+				copy(key[:], samples)
+				col, ok := cache[key]
+				if !ok || 4 < n {
+					for k := 0; k < n; k++ {
+						vals[k] = interpolate(float64(samples[k]), 0.0, max, decode[2*k], decode[2*k+1])
+					}
+					col = cs.RGB(vals)
+					if len(cache) < 4096 {
+						cache[key] = col
+					}
+				}
+
+				masked := colorKey != nil
+				for k := 0; k < n && masked; k++ {
+					masked = colorKey[2*k] <= samples[k] && samples[k] <= colorKey[2*k+1]
+				}
+				if !masked {
+					i := img.PixOffset(x, y)
+					img.Pix[i+0], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = col.R, col.G, col.B, 255
+				}
+			}
+		}
+	}
+
+	if isMask {
+		return img, nil
+	}
+
+	// soft mask or stencil mask
+	var alpha *image.NRGBA
+	if smask, err := r.GetStream(dict["SMask"]); err == nil {
+		if alpha, err = r.getImage(smask, resources, color.RGBA{255, 255, 255, 255}, false); err != nil {
+			return nil, err
+		}
+	} else if mask, err := r.GetStream(dict["Mask"]); err == nil {
+		if alpha, err = r.getImage(mask, resources, color.RGBA{255, 255, 255, 255}, true); err != nil {
+			return nil, err
+		}
+	}
+	if alpha != nil {
+		// use the gray level or alpha of the mask as the image's alpha
+		mw, mh := alpha.Bounds().Dx(), alpha.Bounds().Dy()
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				j := alpha.PixOffset(x*mw/width, y*mh/height)
+				a := alpha.Pix[j]
+				if alpha.Pix[j+3] != 255 {
+					a = alpha.Pix[j+3]
+				}
+				i := img.PixOffset(x, y)
+				img.Pix[i+3] = uint8(uint32(img.Pix[i+3]) * uint32(a) / 255)
+			}
+		}
+	}
+	return img, nil
+}
+
+// readSample reads a sample of the given bit size at the bit position of a row of image data.
+func readSample(b []byte, pos, bpc int) int {
+	switch bpc {
+	case 8:
+		return int(b[pos/8])
+	case 16:
+		return int(b[pos/8])<<8 | int(b[pos/8+1])
+	}
+	return int(b[pos/8]>>(8-uint(pos%8)-uint(bpc))) & (1<<uint(bpc) - 1)
+}
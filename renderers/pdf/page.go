@@ -0,0 +1,941 @@
+package pdf
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+
+	"github.com/LaminoidStudio/Canvas"
+)
+
+// maxFormDepth is the maximum nesting depth of form XObjects, soft masks, patterns, and Type3 glyphs.
+const maxFormDepth = 16
+
+// maxPatternTiles is the maximum number of tiles drawn to fill a path with a tiling pattern.
+const maxPatternTiles = 4096
+
+// Reader reads a PDF document and imports its pages as vector content into a canvas. Paths, clipping paths, images, axial and radial shadings, tiling patterns, transparency groups, soft masks, and text are supported. Text is converted to paths using the embedded fonts, fonts that are not embedded (such as the standard 14 fonts) or Type1 fonts use the Fallback font family. Free-form, lattice, and mesh shadings, and images compressed with JPXDecode, CCITTFaxDecode, or JBIG2Decode are skipped.
+type Reader struct {
+	r     *pdfReader
+	fonts map[pdfIndirectRef]*pdfFont
+	edits map[pdfIndirectRef][]byte // replaced content streams
+
+	// Fallback is the font family used for fonts that are not embedded or cannot be read, it must contain at least the regular font style. Text with such fonts is skipped when nil.
+	Fallback *canvas.FontFamily
+}
+
+// NewReader returns a reader for a PDF document. The password is used for encrypted documents and may be empty.
+func NewReader(r io.Reader, password string) (*Reader, error) {
+	pdf, err := newPDFReader(r, password)
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{
+		r:     pdf,
+		fonts: map[pdfIndirectRef]*pdfFont{},
+		edits: map[pdfIndirectRef][]byte{},
+	}, nil
+}
+
+// NumPages returns the number of pages in the document.
+func (r *Reader) NumPages() int {
+	return len(r.r.kids)
+}
+
+// Page returns the page with the given zero-based index as a canvas. The canvas has the size of the page's crop box and is rotated by the page's Rotate entry.
+func (r *Reader) Page(index int) (*canvas.Canvas, error) {
+	page, contents, err := r.r.GetPage(index)
+	if err != nil {
+		return nil, err
+	}
+
+	box, err := r.r.GetFloats(r.r.GetInherited(page, "MediaBox"))
+	if err != nil || len(box) != 4 {
+		box = []float64{0.0, 0.0, 612.0, 792.0} // US Letter
+	}
+	box = []float64{math.Min(box[0], box[2]), math.Min(box[1], box[3]), math.Max(box[0], box[2]), math.Max(box[1], box[3])}
+	if crop, err := r.r.GetFloats(r.r.GetInherited(page, "CropBox")); err == nil && len(crop) == 4 {
+		box[0] = math.Max(box[0], math.Min(crop[0], crop[2]))
+		box[1] = math.Max(box[1], math.Min(crop[1], crop[3]))
+		box[2] = math.Min(box[2], math.Max(crop[0], crop[2]))
+		box[3] = math.Min(box[3], math.Max(crop[1], crop[3]))
+	}
+	w, h := math.Max(0.0, box[2]-box[0]), math.Max(0.0, box[3]-box[1])
+
+	rotate, _ := r.r.GetInt(r.r.GetInherited(page, "Rotate"))
+	rotation := canvas.Identity
+	switch (rotate%360 + 360) % 360 {
+	case 90:
+		rotation = canvas.Identity.Translate(0.0, w).Rotate(-90.0)
+		w, h = h, w
+	case 180:
+		rotation = canvas.Identity.Translate(w, h).Rotate(180.0)
+	case 270:
+		rotation = canvas.Identity.Translate(h, 0.0).Rotate(90.0)
+		w, h = h, w
+	}
+
+	c := canvas.New(w*mmPerPt, h*mmPerPt)
+	base := canvas.Identity.Scale(mmPerPt, mmPerPt).Mul(rotation).Translate(-box[0], -box[1])
+	resources, _ := r.r.GetDict(r.r.GetInherited(page, "Resources"))
+
+	pageBox := canvas.Rectangle(c.W, c.H)
+	c.PushClip(pageBox, canvas.NonZero, canvas.Identity)
+	interp := &pdfInterpreter{
+		Reader:  r,
+		c:       c,
+		base:    base,
+		pageBox: pageBox,
+	}
+	err = interp.run(contents, resources, newPDFGraphicsState(canvas.Identity), 0)
+	c.PopClip()
+	if err != nil {
+		return nil, fmt.Errorf("bad page %d: %w", index, err)
+	}
+	return c, nil
+}
+
+////////////////////////////////////////////////////////////////
+
+// pdfPaint is the fill or stroke color or pattern.
+type pdfPaint struct {
+	cs      pdfColorSpace
+	comps   []float64
+	pattern interface{}
+}
+
+func (paint pdfPaint) Color() color.RGBA {
+	return paint.cs.RGB(paint.comps)
+}
+
+type pdfGraphicsState struct {
+	ctm         canvas.Matrix
+	patternBase canvas.Matrix // the default coordinate space of the content stream for patterns
+
+	fill, stroke           pdfPaint
+	fillAlpha, strokeAlpha float64
+	blendMode              canvas.BlendMode
+	smask                  int // number of scopes before the group of the soft mask, or -1 if none
+
+	lineWidth  float64
+	lineCap    int
+	lineJoin   int
+	miterLimit float64
+	dashes     []float64
+	dashOffset float64
+
+	font                         *pdfFont
+	fontSize                     float64
+	charSpace, wordSpace, hScale float64
+	leading, rise                float64
+	render                       int
+	textMatrix, textLineMatrix   canvas.Matrix
+
+	scopes int // number of scopes when the state was saved
+}
+
+func newPDFGraphicsState(ctm canvas.Matrix) pdfGraphicsState {
+	return pdfGraphicsState{
+		ctm:         ctm,
+		patternBase: ctm,
+		fill:        pdfPaint{cs: pdfDeviceGray{}, comps: []float64{0.0}},
+		stroke:      pdfPaint{cs: pdfDeviceGray{}, comps: []float64{0.0}},
+		fillAlpha:   1.0,
+		strokeAlpha: 1.0,
+		smask:       -1,
+		lineWidth:   1.0,
+		miterLimit:  10.0,
+		hScale:      1.0,
+		textMatrix:  canvas.Identity,
+	}
+}
+
+// pdfInterpreter draws content streams to a canvas.
+type pdfInterpreter struct {
+	*Reader
+	c       *canvas.Canvas
+	base    canvas.Matrix // from the page's default coordinate space to the canvas
+	pageBox *canvas.Path  // in canvas coordinates
+
+	scopes []bool // pushed clipping paths (false) and groups (true)
+}
+
+func (interp *pdfInterpreter) pushClip(path *canvas.Path, fillRule canvas.FillRule, m canvas.Matrix) {
+	interp.c.PushClip(path, fillRule, m)
+	interp.scopes = append(interp.scopes, false)
+}
+
+func (interp *pdfInterpreter) pushGroup(group canvas.Group, m canvas.Matrix) {
+	interp.c.PushGroup(group, m)
+	interp.scopes = append(interp.scopes, true)
+}
+
+// popScopes pops clipping paths and groups until n remain.
+func (interp *pdfInterpreter) popScopes(n int) {
+	for n < len(interp.scopes) {
+		if interp.scopes[len(interp.scopes)-1] {
+			interp.c.PopGroup()
+		} else {
+			interp.c.PopClip()
+		}
+		interp.scopes = interp.scopes[:len(interp.scopes)-1]
+	}
+}
+
+// run interprets a content stream with the given resources and initial graphics state.
+func (interp *pdfInterpreter) run(b []byte, resources pdfDict, state pdfGraphicsState, depth int) error {
+	if maxFormDepth < depth {
+		return fmt.Errorf("content streams nested too deeply")
+	}
+	r := interp.r
+	scopes := len(interp.scopes)
+	defer interp.popScopes(scopes)
+
+	states := []pdfGraphicsState{}
+	path := &canvas.Path{}
+	var start, cur canvas.Point
+	var clip *canvas.FillRule
+	var textClip *canvas.Path
+
+	stream := newPDFStreamReader(b)
+	for {
+		op, vals, err := stream.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		nums := make([]float64, 0, len(vals))
+		for _, val := range vals {
+			if i, ok := val.(int); ok {
+				nums = append(nums, float64(i))
+			} else if f, ok := val.(float64); ok {
+				nums = append(nums, f)
+			}
+		}
+		var name pdfName
+		if 0 < len(vals) {
+			name, _ = vals[len(vals)-1].(pdfName)
+		}
+
+		switch op {
+		// graphics state
+		case "q":
+			state.scopes = len(interp.scopes)
+			states = append(states, state)
+		case "Q":
+			if 0 < len(states) {
+				interp.popScopes(states[len(states)-1].scopes)
+				state = states[len(states)-1]
+				states = states[:len(states)-1]
+			}
+		case "cm":
+			if len(nums) == 6 {
+				state.ctm = state.ctm.Mul(canvas.Matrix{{nums[0], nums[2], nums[4]}, {nums[1], nums[3], nums[5]}})
+			}
+		case "w":
+			if len(nums) == 1 {
+				state.lineWidth = nums[0]
+			}
+		case "J":
+			if len(nums) == 1 {
+				state.lineCap = int(nums[0])
+			}
+		case "j":
+			if len(nums) == 1 {
+				state.lineJoin = int(nums[0])
+			}
+		case "M":
+			if len(nums) == 1 {
+				state.miterLimit = nums[0]
+			}
+		case "d":
+			if len(vals) == 2 && len(nums) == 1 {
+				state.dashes, _ = r.GetFloats(vals[0])
+				state.dashOffset = nums[len(nums)-1]
+			}
+		case "gs":
+			extGStates, _ := r.GetDict(resources["ExtGState"])
+			if gs, err := r.GetDict(extGStates[name]); err == nil {
+				interp.setExtGState(gs, resources, &state, depth)
+			}
+
+		// path construction
+		case "m":
+			if len(nums) == 2 {
+				start = canvas.Point{X: nums[0], Y: nums[1]}
+				cur = start
+				path.MoveTo(cur.X, cur.Y)
+			}
+		case "l":
+			if len(nums) == 2 {
+				cur = canvas.Point{X: nums[0], Y: nums[1]}
+				path.LineTo(cur.X, cur.Y)
+			}
+		case "c":
+			if len(nums) == 6 {
+				cur = canvas.Point{X: nums[4], Y: nums[5]}
+				path.CubeTo(nums[0], nums[1], nums[2], nums[3], cur.X, cur.Y)
+			}
+		case "v":
+			if len(nums) == 4 {
+				path.CubeTo(cur.X, cur.Y, nums[0], nums[1], nums[2], nums[3])
+				cur = canvas.Point{X: nums[2], Y: nums[3]}
+			}
+		case "y":
+			if len(nums) == 4 {
+				cur = canvas.Point{X: nums[2], Y: nums[3]}
+				path.CubeTo(nums[0], nums[1], cur.X, cur.Y, cur.X, cur.Y)
+			}
+		case "h":
+			path.Close()
+			cur = start
+		case "re":
+			if len(nums) == 4 {
+				path.MoveTo(nums[0], nums[1])
+				path.LineTo(nums[0]+nums[2], nums[1])
+				path.LineTo(nums[0]+nums[2], nums[1]+nums[3])
+				path.LineTo(nums[0], nums[1]+nums[3])
+				path.Close()
+				start = canvas.Point{X: nums[0], Y: nums[1]}
+				cur = start
+			}
+
+		// path painting
+		case "S", "s", "f", "F", "f*", "B", "B*", "b", "b*", "n":
+			if op == "s" || op == "b" || op == "b*" {
+				path.Close()
+			}
+			fillRule := canvas.NonZero
+			if op == "f*" || op == "B*" || op == "b*" {
+				fillRule = canvas.EvenOdd
+			}
+			doFill := op != "S" && op != "s" && op != "n"
+			doStroke := op == "S" || op == "s" || op == "B" || op == "B*" || op == "b" || op == "b*"
+			if !path.Empty() {
+				interp.drawPath(path, fillRule, doFill, doStroke, resources, state, depth)
+				if clip != nil {
+					interp.pushClip(path, *clip, interp.base.Mul(state.ctm))
+				}
+			}
+			clip = nil
+			path = &canvas.Path{}
+		case "W", "W*":
+			fillRule := canvas.NonZero
+			if op == "W*" {
+				fillRule = canvas.EvenOdd
+			}
+			clip = &fillRule
+
+		// color
+		case "CS", "cs":
+			paint := &state.fill
+			if op == "CS" {
+				paint = &state.stroke
+			}
+			if cs, err := r.GetColorSpace(name, resources); err == nil {
+				*paint = pdfPaint{cs: cs, comps: cs.Initial()}
+			}
+		case "SC", "SCN", "sc", "scn":
+			paint := &state.fill
+			if op == "SC" || op == "SCN" {
+				paint = &state.stroke
+			}
+			if name != "" {
+				patterns, _ := r.GetDict(resources["Pattern"])
+				paint.pattern = patterns[name]
+			}
+			if 0 < len(nums) {
+				paint.comps = nums
+			}
+		case "G", "g", "RG", "rg", "K", "k":
+			paint := &state.fill
+			if op == "G" || op == "RG" || op == "K" {
+				paint = &state.stroke
+			}
+			if cs := deviceColorSpace(len(nums)); cs != nil && len(nums) != 2 {
+				*paint = pdfPaint{cs: cs, comps: nums}
+			}
+		case "sh":
+			interp.shade(name, resources, state)
+
+		// text
+		case "BT":
+			state.textMatrix, state.textLineMatrix = canvas.Identity, canvas.Identity
+			textClip = nil
+		case "ET":
+			if textClip != nil {
+				interp.pushClip(textClip, canvas.NonZero, canvas.Identity)
+				textClip = nil
+			}
+		case "Tc":
+			if len(nums) == 1 {
+				state.charSpace = nums[0]
+			}
+		case "Tw":
+			if len(nums) == 1 {
+				state.wordSpace = nums[0]
+			}
+		case "Tz":
+			if len(nums) == 1 {
+				state.hScale = nums[0] / 100.0
+			}
+		case "TL":
+			if len(nums) == 1 {
+				state.leading = nums[0]
+			}
+		case "Tf":
+			if len(vals) == 2 && 0 < len(nums) {
+				fonts, _ := r.GetDict(resources["Font"])
+				name, _ := vals[0].(pdfName)
+				state.font = interp.getFont(fonts[name])
+				state.fontSize = nums[len(nums)-1]
+			}
+		case "Tr":
+			if len(nums) == 1 {
+				state.render = int(nums[0])
+			}
+		case "Ts":
+			if len(nums) == 1 {
+				state.rise = nums[0]
+			}
+		case "Td", "TD":
+			if len(nums) == 2 {
+				if op == "TD" {
+					state.leading = -nums[1]
+				}
+				state.textLineMatrix = state.textLineMatrix.Translate(nums[0], nums[1])
+				state.textMatrix = state.textLineMatrix
+			}
+		case "Tm":
+			if len(nums) == 6 {
+				state.textLineMatrix = canvas.Matrix{{nums[0], nums[2], nums[4]}, {nums[1], nums[3], nums[5]}}
+				state.textMatrix = state.textLineMatrix
+			}
+		case "T*":
+			state.textLineMatrix = state.textLineMatrix.Translate(0.0, -state.leading)
+			state.textMatrix = state.textLineMatrix
+		case "Tj", "TJ", "'", "\"":
+			if op == "'" || op == "\"" {
+				if op == "\"" && len(nums) == 2 {
+					state.wordSpace, state.charSpace = nums[0], nums[1]
+				}
+				state.textLineMatrix = state.textLineMatrix.Translate(0.0, -state.leading)
+				state.textMatrix = state.textLineMatrix
+			}
+			if len(vals) == 0 {
+				break
+			}
+			var items pdfArray
+			if op == "TJ" {
+				items, _ = vals[0].(pdfArray)
+			} else {
+				items = pdfArray{vals[len(vals)-1]}
+			}
+			if textPath := interp.showText(items, resources, &state, depth); textPath != nil {
+				if 4 <= state.render {
+					if textClip == nil {
+						textClip = &canvas.Path{}
+					}
+					textClip = textClip.Append(textPath.Transform(interp.base.Mul(state.ctm)))
+				}
+			}
+
+		// XObjects and inline images
+		case "Do":
+			xobjects, _ := r.GetDict(resources["XObject"])
+			interp.drawXObject(xobjects[name], resources, state, depth)
+		case "BI":
+			if img, ok := vals[0].(pdfStream); ok {
+				interp.drawImage(img, resources, state)
+			}
+		}
+	}
+	return nil
+}
+
+// setExtGState sets the parameters of a graphics state parameter dictionary.
+func (interp *pdfInterpreter) setExtGState(gs pdfDict, resources pdfDict, state *pdfGraphicsState, depth int) {
+	r := interp.r
+	if v, err := r.GetFloat(gs["LW"]); err == nil {
+		state.lineWidth = v
+	}
+	if v, err := r.GetInt(gs["LC"]); err == nil {
+		state.lineCap = v
+	}
+	if v, err := r.GetInt(gs["LJ"]); err == nil {
+		state.lineJoin = v
+	}
+	if v, err := r.GetFloat(gs["ML"]); err == nil {
+		state.miterLimit = v
+	}
+	if v, err := r.GetArray(gs["D"]); err == nil && len(v) == 2 {
+		state.dashes, _ = r.GetFloats(v[0])
+		state.dashOffset, _ = r.GetFloat(v[1])
+	}
+	if v, err := r.GetFloat(gs["CA"]); err == nil {
+		state.strokeAlpha = clip(v, 0.0, 1.0)
+	}
+	if v, err := r.GetFloat(gs["ca"]); err == nil {
+		state.fillAlpha = clip(v, 0.0, 1.0)
+	}
+	if v, err := r.get(gs["BM"]); err == nil && v != nil {
+		if array, ok := v.(pdfArray); ok && 0 < len(array) {
+			v = array[0]
+		}
+		if name, ok := v.(pdfName); ok {
+			state.blendMode = canvas.NormalBlend
+			for mode := canvas.NormalBlend; mode <= canvas.LuminosityBlend; mode++ {
+				if mode.String() == string(name) || name == "Compatible" && mode == canvas.NormalBlend {
+					state.blendMode = mode
+					break
+				}
+			}
+		}
+	}
+	if v, err := r.GetArray(gs["Font"]); err == nil && len(v) == 2 {
+		state.font = interp.getFont(v[0])
+		state.fontSize, _ = r.GetFloat(v[1])
+	}
+	if v, err := r.get(gs["SMask"]); err == nil && v != nil {
+		if 0 <= state.smask && state.smask <= len(interp.scopes) {
+			interp.popScopes(state.smask)
+			state.smask = -1
+		}
+		if smask, ok := v.(pdfDict); ok {
+			if mask, maskType, ok := interp.softMask(smask, resources, *state, depth); ok {
+				state.smask = len(interp.scopes)
+				interp.pushGroup(canvas.Group{Opacity: 1.0, Mask: mask, MaskType: maskType}, canvas.Identity)
+			}
+		}
+	}
+}
+
+// softMask draws the transparency group of a soft mask dictionary to a new canvas.
+func (interp *pdfInterpreter) softMask(smask pdfDict, resources pdfDict, state pdfGraphicsState, depth int) (*canvas.Canvas, canvas.MaskType, bool) {
+	r := interp.r
+	subtype, _ := r.GetName(smask["S"])
+	maskType := canvas.LuminosityMask
+	if subtype == "Alpha" {
+		maskType = canvas.AlphaMask
+	}
+	form, err := r.GetStream(smask["G"])
+	if err != nil {
+		return nil, 0, false
+	}
+
+	mask := canvas.New(interp.c.W, interp.c.H)
+	sub := &pdfInterpreter{
+		Reader:  interp.Reader,
+		c:       mask,
+		base:    interp.base,
+		pageBox: interp.pageBox,
+	}
+	if maskType == canvas.LuminosityMask {
+		if bc, err := r.GetFloats(smask["BC"]); err == nil {
+			// draw the backdrop color
+			group, _ := r.GetDict(form.dict["Group"])
+			var cs pdfColorSpace = deviceColorSpace(len(bc))
+			if groupCS, err := r.GetColorSpace(group["CS"], resources); err == nil && groupCS.Components() == len(bc) {
+				cs = groupCS
+			}
+			if cs != nil {
+				style := canvas.DefaultStyle
+				style.FillColor = cs.RGB(bc)
+				mask.RenderPath(interp.pageBox, style, canvas.Identity)
+			}
+		}
+	}
+	sub.drawForm(form, resources, newPDFGraphicsState(state.ctm), depth+1)
+	return mask, maskType, true
+}
+
+// getFont returns the font of a font dictionary, which is cached for indirect references.
+func (r *Reader) getFont(val interface{}) *pdfFont {
+	ref, isRef := val.(pdfIndirectRef)
+	if isRef {
+		if f, ok := r.fonts[ref]; ok {
+			return f
+		}
+	}
+	f, err := r.r.GetFont(val, r.Fallback)
+	if err != nil {
+		f = nil
+	}
+	if isRef {
+		r.fonts[ref] = f
+	}
+	return f
+}
+
+// style returns the style for painting with the current colors and line parameters. The line width, dashes, and the path are in user space.
+func (interp *pdfInterpreter) style(state pdfGraphicsState) canvas.Style {
+	style := canvas.DefaultStyle
+	style.FillColor = alphaColor(state.fill.Color(), state.fillAlpha)
+	style.StrokeColor = alphaColor(state.stroke.Color(), state.strokeAlpha)
+	style.BlendMode = state.blendMode
+
+	style.StrokeWidth = state.lineWidth
+	if style.StrokeWidth <= 0.0 {
+		// the thinnest line that can be rendered, approximated by 0.1 mm
+		if det := math.Abs(interp.base.Mul(state.ctm).Det()); det != 0.0 {
+			style.StrokeWidth = 0.1 / math.Sqrt(det)
+		}
+	}
+	switch state.lineCap {
+	case 1:
+		style.StrokeCapper = canvas.RoundCap
+	case 2:
+		style.StrokeCapper = canvas.SquareCap
+	default:
+		style.StrokeCapper = canvas.ButtCap
+	}
+	switch state.lineJoin {
+	case 1:
+		style.StrokeJoiner = canvas.RoundJoin
+	case 2:
+		style.StrokeJoiner = canvas.BevelJoin
+	default:
+		style.StrokeJoiner = canvas.MiterJoiner{GapJoiner: canvas.BevelJoin, Limit: state.miterLimit * style.StrokeWidth / 2.0}
+	}
+
+	total := 0.0
+	for _, dash := range state.dashes {
+		if dash < 0.0 {
+			total = 0.0
+			break
+		}
+		total += dash
+	}
+	if 0.0 < total {
+		style.Dashes = state.dashes
+		if len(style.Dashes)%2 == 1 {
+			style.Dashes = append(style.Dashes[:len(style.Dashes):len(style.Dashes)], style.Dashes...)
+		}
+		style.DashOffset = state.dashOffset
+	}
+	return style
+}
+
+// alphaColor returns the premultiplied color with an additional alpha.
+func alphaColor(col color.RGBA, alpha float64) color.RGBA {
+	if alpha == 1.0 {
+		return col
+	}
+	return color.RGBA{
+		uint8(float64(col.R)*alpha + 0.5),
+		uint8(float64(col.G)*alpha + 0.5),
+		uint8(float64(col.B)*alpha + 0.5),
+		uint8(float64(col.A)*alpha + 0.5),
+	}
+}
+
+// drawPath fills and/or strokes a path in user space.
+func (interp *pdfInterpreter) drawPath(path *canvas.Path, fillRule canvas.FillRule, doFill, doStroke bool, resources pdfDict, state pdfGraphicsState, depth int) {
+	m := interp.base.Mul(state.ctm)
+	style := interp.style(state)
+	style.FillRule = fillRule
+	if !doFill {
+		style.FillColor = canvas.Transparent
+	}
+	if !doStroke {
+		style.StrokeColor = canvas.Transparent
+	}
+	if state.fill.pattern == nil && state.stroke.pattern == nil || !doFill && state.stroke.pattern == nil || !doStroke && state.fill.pattern == nil {
+		if style.HasFill() || style.HasStroke() {
+			interp.c.RenderPath(path, style, m)
+		}
+		return
+	}
+
+	if doFill {
+		if state.fill.pattern != nil {
+			interp.fillPattern(path, fillRule, state.fill, state.fillAlpha, resources, state, depth)
+		} else {
+			fillStyle := style
+			fillStyle.StrokeColor = canvas.Transparent
+			interp.c.RenderPath(path, fillStyle, m)
+		}
+	}
+	if doStroke {
+		if state.stroke.pattern != nil {
+			strokePath := path
+			if style.IsDashed() {
+				strokePath = strokePath.Dash(style.DashOffset, style.Dashes...)
+			}
+			strokePath = strokePath.Stroke(style.StrokeWidth, style.StrokeCapper, style.StrokeJoiner)
+			interp.fillPattern(strokePath, canvas.NonZero, state.stroke, state.strokeAlpha, resources, state, depth)
+		} else {
+			strokeStyle := style
+			strokeStyle.FillColor = canvas.Transparent
+			interp.c.RenderPath(path, strokeStyle, m)
+		}
+	}
+}
+
+// fillPattern fills a path in user space with a shading or tiling pattern.
+func (interp *pdfInterpreter) fillPattern(path *canvas.Path, fillRule canvas.FillRule, paint pdfPaint, alpha float64, resources pdfDict, state pdfGraphicsState, depth int) {
+	r := interp.r
+	pattern, err := r.get(paint.pattern)
+	if err != nil {
+		return
+	}
+	var dict pdfDict
+	var stream pdfStream
+	if d, ok := pattern.(pdfDict); ok {
+		dict = d
+	} else if s, ok := pattern.(pdfStream); ok {
+		stream, dict = s, s.dict
+	} else {
+		return
+	}
+
+	patternMatrix := canvas.Identity
+	if m, err := r.GetFloats(dict["Matrix"]); err == nil && len(m) == 6 {
+		patternMatrix = canvas.Matrix{{m[0], m[2], m[4]}, {m[1], m[3], m[5]}}
+	}
+	patternSpace := state.patternBase.Mul(patternMatrix)
+	if patternSpace.Det() == 0.0 {
+		return
+	}
+
+	patternType, _ := r.GetInt(dict["PatternType"])
+	if patternType == 2 {
+		shading, err := r.GetShading(dict["Shading"], resources)
+		if err != nil {
+			return
+		}
+		m := interp.base.Mul(patternSpace)
+		path = path.Transform(patternSpace.Inv().Mul(state.ctm))
+
+		style := canvas.DefaultStyle
+		style.FillRule = fillRule
+		style.BlendMode = state.blendMode
+		if shading.background != nil {
+			style.FillColor = alphaColor(*shading.background, alpha)
+			interp.c.RenderPath(path, style, m)
+		}
+		n := len(interp.scopes)
+		if shading.bbox != nil {
+			interp.pushClip(canvas.Rectangle(shading.bbox.W, shading.bbox.H).Translate(shading.bbox.X, shading.bbox.Y), canvas.NonZero, m)
+		}
+		if alpha != 1.0 {
+			interp.pushGroup(canvas.Group{Opacity: alpha}, canvas.Identity)
+		}
+		style.FillColor = canvas.Transparent
+		style.FillGradient = shading.gradient
+		interp.c.RenderPath(path, style, m)
+		interp.popScopes(n)
+	} else if patternType == 1 && stream.dict != nil {
+		interp.fillTiling(path, fillRule, stream, paint, alpha, patternSpace, state, depth)
+	}
+}
+
+// fillTiling fills a path in user space by repeating the tile of a tiling pattern.
+func (interp *pdfInterpreter) fillTiling(path *canvas.Path, fillRule canvas.FillRule, stream pdfStream, paint pdfPaint, alpha float64, patternSpace canvas.Matrix, state pdfGraphicsState, depth int) {
+	r := interp.r
+	bbox, err := r.GetFloats(stream.dict["BBox"])
+	if err != nil || len(bbox) != 4 {
+		return
+	}
+	xStep, _ := r.GetFloat(stream.dict["XStep"])
+	yStep, _ := r.GetFloat(stream.dict["YStep"])
+	if xStep == 0.0 || yStep == 0.0 {
+		return
+	}
+	xStep, yStep = math.Abs(xStep), math.Abs(yStep)
+	paintType, _ := r.GetInt(stream.dict["PaintType"])
+	resources, _ := r.GetDict(stream.dict["Resources"])
+
+	// find the range of tiles covering the path's bounds in pattern space
+	bounds := path.Transform(patternSpace.Inv().Mul(state.ctm)).Bounds()
+	x0, x1 := math.Min(bbox[0], bbox[2]), math.Max(bbox[0], bbox[2])
+	y0, y1 := math.Min(bbox[1], bbox[3]), math.Max(bbox[1], bbox[3])
+	i0, i1 := math.Floor((bounds.X-x1)/xStep), math.Ceil((bounds.X+bounds.W-x0)/xStep)
+	j0, j1 := math.Floor((bounds.Y-y1)/yStep), math.Ceil((bounds.Y+bounds.H-y0)/yStep)
+	if maxPatternTiles < (i1-i0+1)*(j1-j0+1) {
+		return
+	}
+
+	n := len(interp.scopes)
+	interp.pushClip(path, fillRule, interp.base.Mul(state.ctm))
+	if alpha != 1.0 || state.blendMode != canvas.NormalBlend {
+		interp.pushGroup(canvas.Group{Opacity: alpha, BlendMode: state.blendMode}, canvas.Identity)
+	}
+	tile := canvas.Rectangle(x1-x0, y1-y0).Translate(x0, y0)
+	for j := j0; j <= j1; j++ {
+		for i := i0; i <= i1; i++ {
+			tileState := newPDFGraphicsState(patternSpace.Translate(i*xStep, j*yStep))
+			if paintType == 2 {
+				// uncolored tiling pattern, use the color given with the pattern
+				if cs, ok := paint.cs.(pdfPatternSpace); ok && cs.base != nil {
+					tileState.fill = pdfPaint{cs: cs.base, comps: paint.comps}
+					tileState.stroke = tileState.fill
+				}
+			}
+			m := len(interp.scopes)
+			interp.pushClip(tile, canvas.NonZero, interp.base.Mul(tileState.ctm))
+			interp.run(stream.stream, resources, tileState, depth+1)
+			interp.popScopes(m)
+		}
+	}
+	interp.popScopes(n)
+}
+
+// shade paints a shading over the current clipping region.
+func (interp *pdfInterpreter) shade(name pdfName, resources pdfDict, state pdfGraphicsState) {
+	r := interp.r
+	shadings, _ := r.GetDict(resources["Shading"])
+	shading, err := r.GetShading(shadings[name], resources)
+	if err != nil {
+		return
+	}
+	m := interp.base.Mul(state.ctm)
+	if m.Det() == 0.0 {
+		return
+	}
+
+	n := len(interp.scopes)
+	path := interp.pageBox.Transform(m.Inv())
+	if shading.bbox != nil {
+		path = canvas.Rectangle(shading.bbox.W, shading.bbox.H).Translate(shading.bbox.X, shading.bbox.Y)
+	}
+	if state.fillAlpha != 1.0 {
+		interp.pushGroup(canvas.Group{Opacity: state.fillAlpha}, canvas.Identity)
+	}
+	style := canvas.DefaultStyle
+	style.FillColor = canvas.Transparent
+	style.FillGradient = shading.gradient
+	style.BlendMode = state.blendMode
+	interp.c.RenderPath(path, style, m)
+	interp.popScopes(n)
+}
+
+// showText draws a string or the items of a TJ array and advances the text matrix. It returns the outline of the text in user space.
+func (interp *pdfInterpreter) showText(items pdfArray, resources pdfDict, state *pdfGraphicsState, depth int) *canvas.Path {
+	f := state.font
+	if f == nil {
+		return nil
+	}
+
+	textPath := &canvas.Path{}
+	for _, item := range items {
+		if s, ok := item.([]byte); ok {
+			for _, c := range f.Chars(s) {
+				trm := state.textMatrix.Translate(0.0, state.rise).Scale(state.fontSize*state.hScale, state.fontSize)
+				if f.subtype == "Type3" {
+					interp.drawType3Glyph(f, c, resources, *state, trm, depth)
+				} else if p := f.GlyphPath(c); p != nil && state.render != 3 {
+					textPath = textPath.Append(p.Transform(trm))
+				}
+
+				// advance
+				spacing := state.charSpace
+				if c.n == 1 && c.code == 32 {
+					spacing += state.wordSpace
+				}
+				if f.vertical {
+					state.textMatrix = state.textMatrix.Translate(0.0, f.vertAdvance*state.fontSize+spacing)
+				} else {
+					state.textMatrix = state.textMatrix.Translate((f.Width(c)*state.fontSize+spacing)*state.hScale, 0.0)
+				}
+			}
+		} else if adjust, err := interp.r.GetFloat(item); err == nil {
+			if f.vertical {
+				state.textMatrix = state.textMatrix.Translate(0.0, -adjust/1000.0*state.fontSize)
+			} else {
+				state.textMatrix = state.textMatrix.Translate(-adjust/1000.0*state.fontSize*state.hScale, 0.0)
+			}
+		}
+	}
+	if textPath.Empty() {
+		return nil
+	}
+
+	// paint text according to the text rendering mode
+	doFill := state.render == 0 || state.render == 2 || state.render == 4 || state.render == 6
+	doStroke := state.render == 1 || state.render == 2 || state.render == 5 || state.render == 6
+	if doFill || doStroke {
+		interp.drawPath(textPath, canvas.NonZero, doFill, doStroke, resources, *state, depth)
+	}
+	return textPath
+}
+
+// drawType3Glyph draws a glyph of a Type3 font by running its content stream.
+func (interp *pdfInterpreter) drawType3Glyph(f *pdfFont, c pdfChar, resources pdfDict, state pdfGraphicsState, trm canvas.Matrix, depth int) {
+	if state.render == 3 || state.render == 7 {
+		return
+	}
+	b, err := interp.r.Type3Glyph(f, c)
+	if err != nil {
+		return
+	}
+	if f.resources != nil {
+		resources = f.resources
+	}
+	glyphState := state
+	glyphState.ctm = state.ctm.Mul(trm).Mul(f.fontMatrix)
+	glyphState.font = nil
+	interp.run(b, resources, glyphState, depth+1)
+}
+
+// drawXObject draws an image or form XObject.
+func (interp *pdfInterpreter) drawXObject(val interface{}, resources pdfDict, state pdfGraphicsState, depth int) {
+	stream, err := interp.r.GetStream(val)
+	if err != nil {
+		return
+	}
+	subtype, _ := interp.r.GetName(stream.dict["Subtype"])
+	if subtype == "Image" {
+		interp.drawImage(stream, resources, state)
+	} else if subtype == "Form" {
+		interp.drawForm(stream, resources, state, depth)
+	}
+}
+
+// drawImage draws an image in the unit square of user space.
+func (interp *pdfInterpreter) drawImage(stream pdfStream, resources pdfDict, state pdfGraphicsState) {
+	img, err := interp.r.GetImage(stream, resources, state.fill.Color())
+	if err != nil {
+		return
+	}
+	m := interp.base.Mul(state.ctm)
+	size := img.Bounds().Size()
+	n := len(interp.scopes)
+	if state.fillAlpha != 1.0 || state.blendMode != canvas.NormalBlend {
+		interp.pushGroup(canvas.Group{Opacity: state.fillAlpha, BlendMode: state.blendMode}, canvas.Identity)
+	}
+	interp.c.RenderImage(img, m.Scale(1.0/float64(size.X), 1.0/float64(size.Y)))
+	interp.popScopes(n)
+}
+
+// drawForm draws a form XObject, which is drawn in a transparency group if it has a Group entry.
+func (interp *pdfInterpreter) drawForm(stream pdfStream, resources pdfDict, state pdfGraphicsState, depth int) {
+	r := interp.r
+	if formResources, err := r.GetDict(stream.dict["Resources"]); err == nil {
+		resources = formResources
+	}
+	if m, err := r.GetFloats(stream.dict["Matrix"]); err == nil && len(m) == 6 {
+		state.ctm = state.ctm.Mul(canvas.Matrix{{m[0], m[2], m[4]}, {m[1], m[3], m[5]}})
+	}
+	state.patternBase = state.ctm
+
+	n := len(interp.scopes)
+	if group, err := r.GetDict(stream.dict["Group"]); err == nil {
+		if subtype, _ := r.GetName(group["S"]); subtype == "Transparency" {
+			interp.pushGroup(canvas.Group{Opacity: state.fillAlpha, BlendMode: state.blendMode}, canvas.Identity)
+			state.fillAlpha, state.strokeAlpha = 1.0, 1.0
+			state.blendMode = canvas.NormalBlend
+		}
+	}
+	state.smask = -1
+	if bbox, err := r.GetFloats(stream.dict["BBox"]); err == nil && len(bbox) == 4 {
+		x0, x1 := math.Min(bbox[0], bbox[2]), math.Max(bbox[0], bbox[2])
+		y0, y1 := math.Min(bbox[1], bbox[3]), math.Max(bbox[1], bbox[3])
+		interp.pushClip(canvas.Rectangle(x1-x0, y1-y0).Translate(x0, y0), canvas.NonZero, interp.base.Mul(state.ctm))
+	}
+	interp.run(stream.stream, resources, state, depth+1)
+	interp.popScopes(n)
+}
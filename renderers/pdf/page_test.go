@@ -0,0 +1,273 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/LaminoidStudio/Canvas"
+	"github.com/tdewolff/test"
+)
+
+type pageLayer struct {
+	path  *canvas.Path // in canvas coordinates
+	style canvas.Style
+	img   image.Image
+	m     canvas.Matrix
+}
+
+// pageRecording holds the paths and images of a rendered page, and the names of all its operations.
+type pageRecording struct {
+	layers []pageLayer
+	ops    []string
+}
+
+// recordPage renders a canvas to a recorder and returns its paths and images, and the names of its operations where groups include their opacity.
+func recordPage(c *canvas.Canvas) *pageRecording {
+	rec := &canvas.Recorder{W: 100.0, H: 100.0}
+	c.RenderTo(rec)
+
+	r := &pageRecording{}
+	for _, op := range rec.Ops {
+		switch op.Op {
+		case "path":
+			r.layers = append(r.layers, pageLayer{path: op.Path.Transform(op.Matrix), style: op.Style, m: op.Matrix})
+		case "image":
+			r.layers = append(r.layers, pageLayer{img: op.Image, m: op.Matrix})
+		}
+		if op.Op == "group" {
+			r.ops = append(r.ops, fmt.Sprintf("group %v", op.Group.Opacity))
+		} else {
+			r.ops = append(r.ops, op.Op)
+		}
+	}
+	return r
+}
+
+// testRect compares rectangles within the precision of the numbers written to PDFs.
+func testRect(t *testing.T, a, b canvas.Rect) {
+	t.Helper()
+	if 1e-3 < math.Abs(a.X-b.X) || 1e-3 < math.Abs(a.Y-b.Y) || 1e-3 < math.Abs(a.W-b.W) || 1e-3 < math.Abs(a.H-b.H) {
+		test.T(t, a, b)
+	}
+}
+
+// runContent interprets a content stream on a page of 72x72 points without a document.
+func runContent(t *testing.T, content string, resources pdfDict) *pageRecording {
+	c := canvas.New(72.0*mmPerPt, 72.0*mmPerPt)
+	interp := &pdfInterpreter{
+		Reader:  &Reader{r: &pdfReader{}, fonts: map[pdfIndirectRef]*pdfFont{}},
+		c:       c,
+		base:    canvas.Identity.Scale(mmPerPt, mmPerPt),
+		pageBox: canvas.Rectangle(c.W, c.H),
+	}
+	err := interp.run([]byte(content), resources, newPDFGraphicsState(canvas.Identity), 0)
+	test.Error(t, err)
+
+	return recordPage(c)
+}
+
+func TestReaderContentStream(t *testing.T) {
+	r := runContent(t, `q 2 0 0 2 0 0 cm 1 0 0 rg 0 0 1 RG 3 w 1 J [2] 1 d 1 2 10 5 re B Q
+0.5 g 0 0 m 10 0 l 10 10 l h f*`, nil)
+	test.T(t, len(r.layers), 2)
+
+	test.T(t, r.layers[0].path.Bounds(), canvas.Rect{X: 2.0 * mmPerPt, Y: 4.0 * mmPerPt, W: 20.0 * mmPerPt, H: 10.0 * mmPerPt})
+	test.T(t, r.layers[0].style.FillColor, canvas.Red)
+	test.T(t, r.layers[0].style.StrokeColor, canvas.Blue)
+	test.Float(t, r.layers[0].style.StrokeWidth, 3.0) // in user space
+	test.T(t, r.layers[0].style.StrokeCapper, canvas.RoundCap)
+	test.T(t, r.layers[0].style.Dashes, []float64{2.0, 2.0})
+	test.Float(t, r.layers[0].style.DashOffset, 1.0)
+
+	test.T(t, r.layers[1].style.FillColor, color.RGBA{128, 128, 128, 255})
+	test.T(t, r.layers[1].style.FillRule, canvas.EvenOdd)
+	test.That(t, !r.layers[1].style.HasStroke(), "path must not be stroked")
+}
+
+func TestReaderClipAndGroup(t *testing.T) {
+	resources := pdfDict{
+		"ExtGState": pdfDict{
+			"A": pdfDict{"ca": 0.5, "BM": pdfName("Multiply")},
+		},
+	}
+	r := runContent(t, `q 0 0 10 10 re W n 0 0 20 20 re f /A gs 0 0 20 20 re f Q 0 0 20 20 re f`, resources)
+	test.T(t, r.ops, []string{"clip", "path", "path", "pop", "path"})
+	test.T(t, r.layers[1].style.FillColor, color.RGBA{0, 0, 0, 128})
+	test.T(t, r.layers[1].style.BlendMode, canvas.MultiplyBlend)
+	test.T(t, r.layers[2].style.FillColor, canvas.Black)
+
+	// transparency group of a form XObject
+	resources = pdfDict{
+		"ExtGState": pdfDict{"A": pdfDict{"ca": 0.5}},
+		"XObject": pdfDict{
+			"X": pdfStream{
+				dict: pdfDict{
+					"Subtype": pdfName("Form"),
+					"BBox":    pdfArray{0, 0, 10, 10},
+					"Matrix":  pdfArray{1, 0, 0, 1, 5, 5},
+					"Group":   pdfDict{"S": pdfName("Transparency")},
+				},
+				stream: []byte("0 0 20 20 re f"),
+			},
+		},
+	}
+	r = runContent(t, `/A gs /X Do`, resources)
+	test.T(t, r.ops, []string{"group 0.5", "clip", "path", "pop", "end"})
+	test.T(t, r.layers[0].style.FillColor, canvas.Black)
+	test.T(t, r.layers[0].path.Bounds(), canvas.Rect{X: 5.0 * mmPerPt, Y: 5.0 * mmPerPt, W: 20.0 * mmPerPt, H: 20.0 * mmPerPt})
+}
+
+func TestReaderShading(t *testing.T) {
+	shading := pdfDict{
+		"ShadingType": 2,
+		"ColorSpace":  pdfName("DeviceRGB"),
+		"Coords":      pdfArray{0, 0, 10, 0},
+		"Function":    pdfDict{"FunctionType": 2, "Domain": pdfArray{0, 1}, "C0": pdfArray{1, 0, 0}, "C1": pdfArray{0, 0, 1}, "N": 1},
+	}
+	resources := pdfDict{
+		"Shading": pdfDict{"Sh": shading},
+		"Pattern": pdfDict{"P": pdfDict{"PatternType": 2, "Shading": shading, "Matrix": pdfArray{2, 0, 0, 2, 0, 0}}},
+	}
+	r := runContent(t, `/Sh sh /Pattern cs /P scn 0 0 10 10 re f`, resources)
+	test.T(t, len(r.layers), 2)
+	gradient, ok := r.layers[0].style.FillGradient.(*canvas.LinearGradient)
+	test.That(t, ok, "must be linear gradient")
+	test.T(t, gradient.End, canvas.Point{X: 10.0, Y: 0.0})
+	test.T(t, gradient.Stops[0].Color, canvas.Red)
+	test.T(t, gradient.Stops[len(gradient.Stops)-1].Color, canvas.Blue)
+
+	// pattern fills are drawn in pattern space
+	test.T(t, r.layers[1].m, canvas.Identity.Scale(2.0*mmPerPt, 2.0*mmPerPt))
+	test.T(t, r.layers[1].path.Bounds(), canvas.Rect{X: 0.0, Y: 0.0, W: 10.0 * mmPerPt, H: 10.0 * mmPerPt})
+}
+
+func TestReaderInlineImage(t *testing.T) {
+	r := runContent(t, "q 20 0 0 10 0 0 cm BI /W 2 /H 1 /CS /RGB /BPC 8 ID \xff\x00\x00\x00\x00\xff EI Q", nil)
+	test.T(t, len(r.layers), 1)
+	img := r.layers[0].img
+	test.T(t, img.Bounds().Size(), image.Point{2, 1})
+	test.T(t, color.NRGBAModel.Convert(img.At(0, 0)), color.NRGBA{255, 0, 0, 255})
+	test.T(t, color.NRGBAModel.Convert(img.At(1, 0)), color.NRGBA{0, 0, 255, 255})
+	test.T(t, r.layers[0].m.Dot(canvas.Point{X: 2.0, Y: 1.0}), canvas.Point{X: 20.0 * mmPerPt, Y: 10.0 * mmPerPt})
+}
+
+func TestReaderIndexedImage(t *testing.T) {
+	stream := pdfStream{
+		dict: pdfDict{
+			"Width":            3,
+			"Height":           1,
+			"BitsPerComponent": 2,
+			"ColorSpace":       pdfArray{pdfName("Indexed"), pdfName("DeviceRGB"), 1, []byte("\x00\xff\x00\xff\xff\xff")},
+			"Mask":             pdfArray{1, 1},
+		},
+		stream: []byte{0x10}, // 0, 1, 0
+	}
+	img, err := (&pdfReader{}).GetImage(stream, nil, canvas.Black)
+	test.Error(t, err)
+	test.T(t, color.NRGBAModel.Convert(img.At(0, 0)), color.NRGBA{0, 255, 0, 255})
+	test.T(t, color.NRGBAModel.Convert(img.At(1, 0)), color.NRGBA{0, 0, 0, 0}) // masked by color key
+}
+
+func TestReaderDocument(t *testing.T) {
+	family := canvas.NewFontFamily("dejavu-serif")
+	test.Error(t, family.LoadFontFile(fontDir+"DejaVuSerif.ttf", canvas.FontRegular))
+	face := family.Face(12.0, canvas.Green, canvas.FontRegular, canvas.FontNormal)
+
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.NRGBA{255, 0, 0, 255})
+
+	buf := &bytes.Buffer{}
+	pdf := New(buf, 100.0, 50.0, nil)
+	style := canvas.DefaultStyle
+	style.FillColor = canvas.Red
+	pdf.RenderPath(canvas.Rectangle(10.0, 20.0), style, canvas.Identity.Translate(5.0, 5.0))
+	pdf.RenderText(canvas.NewTextLine(face, "Hi", canvas.Left), canvas.Identity.Translate(20.0, 20.0))
+	pdf.RenderImage(img, canvas.Identity.Translate(50.0, 10.0))
+	test.Error(t, pdf.Close())
+
+	reader, err := NewReader(bytes.NewReader(buf.Bytes()), "")
+	test.Error(t, err)
+	test.T(t, reader.NumPages(), 1)
+	c, err := reader.Page(0)
+	test.Error(t, err)
+	test.That(t, math.Abs(c.W-100.0) < 1e-3 && math.Abs(c.H-50.0) < 1e-3, "page must have the written size")
+
+	r := recordPage(c)
+	test.T(t, len(r.layers), 3)
+	testRect(t, r.layers[0].path.Bounds(), canvas.Rect{X: 5.0, Y: 5.0, W: 10.0, H: 20.0})
+	test.T(t, r.layers[0].style.FillColor, canvas.Red)
+
+	// text is converted to paths using the embedded font
+	textPath, _, err := face.ToPath("Hi")
+	test.Error(t, err)
+	test.T(t, r.layers[1].style.FillColor, canvas.Green)
+	testRect(t, r.layers[1].path.Bounds(), textPath.Translate(20.0, 20.0).Bounds())
+
+	test.T(t, r.layers[2].img.Bounds().Size(), image.Point{2, 2})
+	test.T(t, color.NRGBAModel.Convert(r.layers[2].img.At(0, 0)), color.NRGBA{255, 0, 0, 255})
+	origin := r.layers[2].m.Dot(canvas.Point{X: 0.0, Y: 0.0})
+	testRect(t, canvas.Rect{X: origin.X, Y: origin.Y}, canvas.Rect{X: 50.0, Y: 10.0})
+
+	_, err = reader.Page(1)
+	test.That(t, err != nil, "must fail for unknown page")
+}
+
+func TestParseCMap(t *testing.T) {
+	cmap := parseCMap([]byte(`/CIDInit /ProcSet findresource begin 12 dict begin begincmap
+1 begincodespacerange <00> <7F> endcodespacerange
+1 begincodespacerange <8000> <FFFF> endcodespacerange
+1 begincidrange <8000> <80FF> 100 endcidrange
+2 beginbfchar <41> <0042> <42> <D83DDE00> endbfchar
+1 beginbfrange <61> <63> <0041> endbfrange
+endcmap CMapName currentdict /CMap defineresource pop end end`))
+
+	code, n := cmap.Next([]byte{0x41, 0x80})
+	test.T(t, code, uint32(0x41))
+	test.T(t, n, 1)
+	code, n = cmap.Next([]byte{0x80, 0x05})
+	test.T(t, code, uint32(0x8005))
+	test.T(t, n, 2)
+	test.T(t, cmap.CID(0x8005), uint32(105))
+
+	test.T(t, cmap.unicode[0x41], []rune{'B'})
+	test.T(t, cmap.unicode[0x42], []rune{0x1F600})
+	test.T(t, cmap.unicode[0x63], []rune{'C'})
+}
+
+func TestReaderFallbackFont(t *testing.T) {
+	family := canvas.NewFontFamily("dejavu-serif")
+	test.Error(t, family.LoadFontFile(fontDir+"DejaVuSerif.ttf", canvas.FontRegular))
+
+	c := canvas.New(72.0*mmPerPt, 72.0*mmPerPt)
+	interp := &pdfInterpreter{
+		Reader:  &Reader{r: &pdfReader{}, fonts: map[pdfIndirectRef]*pdfFont{}, Fallback: family},
+		c:       c,
+		base:    canvas.Identity,
+		pageBox: canvas.Rectangle(c.W, c.H),
+	}
+	resources := pdfDict{
+		"Font": pdfDict{
+			"F": pdfDict{"Type": pdfName("Font"), "Subtype": pdfName("Type1"), "BaseFont": pdfName("Helvetica"), "FirstChar": 72, "Widths": pdfArray{1000}},
+		},
+	}
+	err := interp.run([]byte("BT /F 10 Tf 1 0 0 rg 10 20 Td (H) Tj [(H) -500 (H)] TJ ET"), resources, newPDFGraphicsState(canvas.Identity), 0)
+	test.Error(t, err)
+
+	r := recordPage(c)
+	test.T(t, len(r.layers), 2)
+	test.T(t, r.layers[0].style.FillColor, canvas.Red)
+
+	// the fallback glyph is stretched to the width given in the font dictionary
+	face := family.Face(10.0*ptPerMm, canvas.Black, canvas.FontRegular, canvas.FontNormal)
+	glyph, _, err := face.ToPath("H")
+	test.Error(t, err)
+	bounds := r.layers[0].path.Bounds()
+	test.Float(t, bounds.Y, 20.0+glyph.Bounds().Y)
+	test.Float(t, bounds.H, glyph.Bounds().H)
+	test.Float(t, r.layers[1].path.Bounds().X-bounds.X, 10.0)
+	test.Float(t, r.layers[1].path.Bounds().W, 15.0+bounds.W)
+}
@@ -13,6 +13,8 @@ import (
 type Options struct {
 	Compress    bool
 	SubsetFonts bool
+	PDFA        bool // write PDF/A-2b conformant output
+	Tagged      bool // write a tagged PDF where text and images are part of the structure tree
 	canvas.ImageEncoding
 }
 
@@ -27,6 +29,12 @@ type PDF struct {
 	w             *pdfPageWriter
 	width, height float64
 	opts          *Options
+	groups        []pdfGroup
+}
+
+type pdfGroup struct {
+	canvas.Group
+	m canvas.Matrix
 }
 
 // New returns a portable document format (PDF) renderer.
@@ -39,6 +47,8 @@ func New(w io.Writer, width, height float64, opts *Options) *PDF {
 	page := newPDFWriter(w).NewPage(width, height)
 	page.pdf.SetCompression(opts.Compress)
 	page.pdf.SetFontSubsetting(opts.SubsetFonts)
+	page.pdf.SetPDFA(opts.PDFA)
+	page.pdf.SetTagged(opts.Tagged)
 	return &PDF{
 		w:      page,
 		width:  width,
@@ -63,11 +73,17 @@ func (r *PDF) SetInfo(title, subject, keywords, author, creator string) {
 
 // NewPage starts adds a new page where further rendering will be written to.
 func (r *PDF) NewPage(width, height float64) {
+	for range r.groups {
+		r.PopGroup()
+	}
 	r.w = r.w.pdf.NewPage(width, height)
 }
 
 // Close finished and closes the PDF.
 func (r *PDF) Close() error {
+	for range r.groups {
+		r.PopGroup()
+	}
 	return r.w.pdf.Close()
 }
 
@@ -78,7 +94,23 @@ func (r *PDF) Size() (float64, float64) {
 
 // RenderPath renders a path to the canvas using a style and a transformation matrix.
 func (r *PDF) RenderPath(path *canvas.Path, style canvas.Style, m canvas.Matrix) {
+	if r.w.BeginArtifact() {
+		defer r.w.EndArtifact()
+	}
+
+	if style.FillPattern != nil && style.FillPattern.Empty() {
+		style.FillColor, style.FillGradient, style.FillPattern = canvas.Transparent, nil, nil
+	}
+	if style.StrokePattern != nil && style.StrokePattern.Empty() {
+		style.StrokeColor, style.StrokeGradient, style.StrokePattern = canvas.Transparent, nil, nil
+	}
+
 	differentAlpha := style.HasFill() && style.HasStroke() && style.FillColor.A != style.StrokeColor.A
+	fillRect, strokeRect := canvas.Rect{}, canvas.Rect{}
+	if style.FillGradient != nil || style.StrokeGradient != nil {
+		fillRect = path.Bounds()
+		strokeRect = canvas.Rect{X: fillRect.X - style.StrokeWidth, Y: fillRect.Y - style.StrokeWidth, W: fillRect.W + 2.0*style.StrokeWidth, H: fillRect.H + 2.0*style.StrokeWidth}
+	}
 
 	// PDFs don't support the arcs joiner, miter joiner (not clipped), or miter joiner (clipped) with non-bevel fallback
 	strokeUnsupported := false
@@ -118,10 +150,57 @@ func (r *PDF) RenderPath(path *canvas.Path, style canvas.Style, m canvas.Matrix)
 		data = data[:len(data)-2]
 		closed = true
 	}
+	r.w.SetBlendMode(style.BlendMode)
+
+	if style.FillGradient != nil || style.StrokeGradient != nil || style.FillPattern != nil || style.StrokePattern != nil {
+		// gradients and patterns are painted within their own graphics state, so we fill and stroke separately
+		if style.HasFill() {
+			r.fill(data, style.FillColor, style.FillNative, style.FillGradient, style.FillPattern, style.FillRule, m, fillRect)
+		}
+		if style.HasStroke() && !strokeUnsupported {
+			r.w.SetLineWidth(style.StrokeWidth)
+			r.w.SetLineCap(style.StrokeCapper)
+			r.w.SetLineJoin(style.StrokeJoiner)
+			r.w.SetDashes(style.DashOffset, style.Dashes)
+			op := " S"
+			if closed {
+				op = " s"
+			}
+			if style.StrokePattern != nil {
+				pattern := r.getPattern(style.StrokePattern, m)
+				r.w.Write([]byte(" q"))
+				r.w.SetStrokePattern(pattern)
+				r.w.Write([]byte(" "))
+				r.w.Write([]byte(data))
+				r.w.Write([]byte(op))
+				r.w.Write([]byte(" Q"))
+			} else if style.StrokeGradient == nil {
+				r.w.SetStrokeNativeColor(style.StrokeColor, style.StrokeNative)
+				r.w.Write([]byte(" "))
+				r.w.Write([]byte(data))
+				r.w.Write([]byte(op))
+			} else {
+				r.w.Write([]byte(" q"))
+				r.w.SetStrokeGradient(style.StrokeGradient, m, strokeRect)
+				r.w.Write([]byte(" "))
+				r.w.Write([]byte(data))
+				r.w.Write([]byte(op))
+				r.w.Write([]byte(" Q"))
+			}
+		} else if style.HasStroke() {
+			// stroke settings unsupported by PDF, draw stroke explicitly
+			if style.IsDashed() {
+				path = path.Dash(style.DashOffset, style.Dashes...)
+			}
+			path = path.Stroke(style.StrokeWidth, style.StrokeCapper, style.StrokeJoiner)
+			r.fill(path.Transform(m).ToPDF(), style.StrokeColor, style.StrokeNative, style.StrokeGradient, style.StrokePattern, canvas.NonZero, m, strokeRect)
+		}
+		return
+	}
 
 	if !style.HasStroke() || !strokeUnsupported {
 		if style.HasFill() && !style.HasStroke() {
-			r.w.SetFillColor(style.FillColor)
+			r.w.SetFillNativeColor(style.FillColor, style.FillNative)
 			r.w.Write([]byte(" "))
 			r.w.Write([]byte(data))
 			r.w.Write([]byte(" f"))
@@ -129,7 +208,7 @@ func (r *PDF) RenderPath(path *canvas.Path, style canvas.Style, m canvas.Matrix)
 				r.w.Write([]byte("*"))
 			}
 		} else if !style.HasFill() && style.HasStroke() {
-			r.w.SetStrokeColor(style.StrokeColor)
+			r.w.SetStrokeNativeColor(style.StrokeColor, style.StrokeNative)
 			r.w.SetLineWidth(style.StrokeWidth)
 			r.w.SetLineCap(style.StrokeCapper)
 			r.w.SetLineJoin(style.StrokeJoiner)
@@ -146,8 +225,8 @@ func (r *PDF) RenderPath(path *canvas.Path, style canvas.Style, m canvas.Matrix)
 			}
 		} else if style.HasFill() && style.HasStroke() {
 			if !differentAlpha {
-				r.w.SetFillColor(style.FillColor)
-				r.w.SetStrokeColor(style.StrokeColor)
+				r.w.SetFillNativeColor(style.FillColor, style.FillNative)
+				r.w.SetStrokeNativeColor(style.StrokeColor, style.StrokeNative)
 				r.w.SetLineWidth(style.StrokeWidth)
 				r.w.SetLineCap(style.StrokeCapper)
 				r.w.SetLineJoin(style.StrokeJoiner)
@@ -163,7 +242,7 @@ func (r *PDF) RenderPath(path *canvas.Path, style canvas.Style, m canvas.Matrix)
 					r.w.Write([]byte("*"))
 				}
 			} else {
-				r.w.SetFillColor(style.FillColor)
+				r.w.SetFillNativeColor(style.FillColor, style.FillNative)
 				r.w.Write([]byte(" "))
 				r.w.Write([]byte(data))
 				r.w.Write([]byte(" f"))
@@ -171,7 +250,7 @@ func (r *PDF) RenderPath(path *canvas.Path, style canvas.Style, m canvas.Matrix)
 					r.w.Write([]byte("*"))
 				}
 
-				r.w.SetStrokeColor(style.StrokeColor)
+				r.w.SetStrokeNativeColor(style.StrokeColor, style.StrokeNative)
 				r.w.SetLineWidth(style.StrokeWidth)
 				r.w.SetLineCap(style.StrokeCapper)
 				r.w.SetLineJoin(style.StrokeJoiner)
@@ -191,7 +270,7 @@ func (r *PDF) RenderPath(path *canvas.Path, style canvas.Style, m canvas.Matrix)
 	} else {
 		// style.HasStroke() && strokeUnsupported
 		if style.HasFill() {
-			r.w.SetFillColor(style.FillColor)
+			r.w.SetFillNativeColor(style.FillColor, style.FillNative)
 			r.w.Write([]byte(" "))
 			r.w.Write([]byte(data))
 			r.w.Write([]byte(" f"))
@@ -206,13 +285,99 @@ func (r *PDF) RenderPath(path *canvas.Path, style canvas.Style, m canvas.Matrix)
 		}
 		path = path.Stroke(style.StrokeWidth, style.StrokeCapper, style.StrokeJoiner)
 
-		r.w.SetFillColor(style.StrokeColor)
+		r.w.SetFillNativeColor(style.StrokeColor, style.StrokeNative)
 		r.w.Write([]byte(" "))
 		r.w.Write([]byte(path.Transform(m).ToPDF()))
 		r.w.Write([]byte(" f"))
 	}
 }
 
+// fill fills the path data with either a pattern, a gradient, or a color, in its native color space if not nil, where m and rect are the paint's transformation and the area to cover in the gradient's coordinate system.
+func (r *PDF) fill(data string, col color.RGBA, native canvas.NativeColor, gradient canvas.Gradient, pattern *canvas.Pattern, fillRule canvas.FillRule, m canvas.Matrix, rect canvas.Rect) {
+	if pattern != nil {
+		ref := r.getPattern(pattern, m)
+		r.w.Write([]byte(" q"))
+		r.w.SetFillPattern(ref)
+	} else if gradient != nil {
+		r.w.Write([]byte(" q"))
+		r.w.SetFillGradient(gradient, m, rect)
+	} else {
+		r.w.SetFillNativeColor(col, native)
+	}
+	r.w.Write([]byte(" "))
+	r.w.Write([]byte(data))
+	r.w.Write([]byte(" f"))
+	if fillRule == canvas.EvenOdd {
+		r.w.Write([]byte("*"))
+	}
+	if pattern != nil || gradient != nil {
+		r.w.Write([]byte(" Q"))
+	}
+}
+
+// getPattern returns the tiling pattern for the pattern transformed by m. The pattern cell is rendered once for each transformation of the pattern.
+func (r *PDF) getPattern(pattern *canvas.Pattern, m canvas.Matrix) pdfRef {
+	key := pdfPatternKey{pattern, m}
+	if ref, ok := r.w.pdf.patterns[key]; ok {
+		return ref
+	}
+	r.w.PushPattern()
+	pattern.Canvas.RenderTo(r)
+	ref := r.w.PopPattern(pattern.Cell(), m.Mul(pattern.Matrix))
+	r.w.pdf.patterns[key] = ref
+	return ref
+}
+
+// PushClip intersects the clipping region with a path using a fill rule and a transformation matrix.
+func (r *PDF) PushClip(path *canvas.Path, fillRule canvas.FillRule, m canvas.Matrix) {
+	r.w.PushClip(path.Transform(m).ToPDF(), fillRule)
+}
+
+// PopClip removes the last pushed clipping path.
+func (r *PDF) PopClip() {
+	r.w.PopClip()
+}
+
+// PushGroup starts a transparency group, where m is the transformation of the group's mask.
+func (r *PDF) PushGroup(group canvas.Group, m canvas.Matrix) {
+	r.groups = append(r.groups, pdfGroup{group, m})
+	r.w.PushGroup()
+}
+
+// PopGroup ends the last started transparency group and paints it as a form XObject with the group's opacity and soft mask.
+func (r *PDF) PopGroup() {
+	if len(r.groups) == 0 {
+		return
+	}
+	group := r.groups[len(r.groups)-1]
+	r.groups = r.groups[:len(r.groups)-1]
+
+	ref := r.w.PopGroup()
+	mask := pdfRef(0)
+	if group.Mask != nil {
+		r.w.PushMaskGroup()
+		group.Mask.RenderTo(canvas.RendererViewer{Renderer: r, Matrix: group.m})
+		mask = r.w.PopGroup()
+	}
+	r.w.SetBlendMode(group.BlendMode)
+	r.w.DrawGroup(ref, group.Opacity, mask, group.MaskType)
+}
+
+// RenderLink adds a link annotation for the rectangle transformed by a matrix to the current page. URIs that start with # link to the named destination.
+func (r *PDF) RenderLink(uri string, rect canvas.Rect, m canvas.Matrix) {
+	r.w.AddLink(uri, rect.Transform(m))
+}
+
+// RenderDestination defines a named destination at the position transformed by a matrix on the current page.
+func (r *PDF) RenderDestination(name string, pos canvas.Point, m canvas.Matrix) {
+	r.w.pdf.AddDestination(name, m.Dot(pos))
+}
+
+// AddOutline adds an item to the document outline (bookmarks) that points to a position in millimeters on the current page. The level is the nesting depth of the item, where zero is the top level and each item is at most one level deeper than the previous.
+func (r *PDF) AddOutline(title string, level int, pos canvas.Point) {
+	r.w.pdf.AddOutline(title, level, pos)
+}
+
 // RenderText renders a text object to the canvas using a transformation matrix.
 func (r *PDF) RenderText(text *canvas.Text, m canvas.Matrix) {
 	text.WalkDecorations(func(col color.RGBA, p *canvas.Path) {
@@ -221,35 +386,52 @@ func (r *PDF) RenderText(text *canvas.Text, m canvas.Matrix) {
 		r.RenderPath(p, style, m)
 	})
 
-	text.WalkSpans(func(x, y float64, span canvas.TextSpan) {
+	// each text is a paragraph in the structure tree with its spans as marked content
+	elem := r.w.AddStructElem("P", "")
+	renderSpan := func(m canvas.Matrix, span canvas.TextSpan) {
 		if span.IsText() {
-			style := canvas.DefaultStyle
-			style.FillColor = span.Face.Color
-
+			r.w.SetBlendMode(canvas.NormalBlend)
+			r.w.BeginMarkedContent(elem)
 			r.w.StartTextObject()
-			r.w.SetFillColor(span.Face.Color)
+			r.w.SetFillNativeColor(span.Face.Color, span.Face.NativeColor)
 			r.w.SetFont(span.Face.Font, span.Face.Size, span.Direction)
-			r.w.SetTextPosition(m.Translate(x, y).Shear(span.Face.FauxItalic, 0.0))
+			r.w.SetTextPosition(m.Shear(span.Face.FauxItalic, 0.0))
 
 			if 0.0 < span.Face.FauxBold {
 				r.w.SetTextRenderMode(2)
-				r.w.SetStrokeColor(span.Face.Color)
+				r.w.SetStrokeNativeColor(span.Face.Color, span.Face.NativeColor)
 				fmt.Fprintf(r.w, " %v w", dec(span.Face.FauxBold*2.0))
 			} else {
 				r.w.SetTextRenderMode(0)
 			}
 			r.w.WriteText(text.WritingMode, span.Glyphs)
 			r.w.EndTextObject()
+			r.w.EndMarkedContent(elem)
 		} else {
 			for _, obj := range span.Objects {
-				rv := canvas.RendererViewer{r, m.Mul(obj.View(x, y, span.Face))}
+				rv := canvas.RendererViewer{Renderer: r, Matrix: m.Mul(obj.View(0.0, 0.0, span.Face))}
 				obj.Canvas.RenderTo(rv)
 			}
 		}
+	}
+	text.WalkSpans(func(x, y float64, span canvas.TextSpan) {
+		renderSpan(m.Translate(x, y), span)
+	})
+	text.WalkPathSpans(func(mSpan canvas.Matrix, span canvas.TextSpan) {
+		renderSpan(m.Mul(mSpan), span)
 	})
 }
 
 // RenderImage renders an image to the canvas using a transformation matrix.
 func (r *PDF) RenderImage(img image.Image, m canvas.Matrix) {
+	alt := ""
+	if canvasImg, ok := img.(canvas.Image); ok {
+		alt = canvasImg.Alt
+	}
+	elem := r.w.AddStructElem("Figure", alt)
+
+	r.w.SetBlendMode(canvas.NormalBlend)
+	r.w.BeginMarkedContent(elem)
 	r.w.DrawImage(img, r.opts.ImageEncoding, m)
+	r.w.EndMarkedContent(elem)
 }
@@ -2,9 +2,13 @@ package pdf
 
 import (
 	"bytes"
+	"encoding/binary"
 	"image"
 	"io"
+	"math"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -145,3 +149,400 @@ func TestPDFMetadata(t *testing.T) {
 	test.That(t, strings.Contains(out, "/Author (d4)"), `could not find "/Author (d4)" in output`)
 	test.That(t, strings.Contains(out, "/Creator (e5)"), `could not find "/Creator (e5)" in output`)
 }
+
+func TestPDFGradient(t *testing.T) {
+	gradient := canvas.NewLinearGradient(canvas.Point{X: 0.0, Y: 0.0}, canvas.Point{X: 10.0, Y: 0.0})
+	gradient.Add(0.0, canvas.Red)
+	gradient.Add(1.0, canvas.Transparent)
+
+	style := canvas.DefaultStyle
+	style.FillGradient = gradient
+
+	buf := &bytes.Buffer{}
+	pdf := New(buf, 10, 10, &Options{Compress: false})
+	pdf.RenderPath(canvas.Rectangle(10.0, 10.0), style, canvas.Identity)
+	err := pdf.Close()
+	test.Error(t, err)
+	out := buf.String()
+
+	test.That(t, strings.Contains(out, "/PatternType 2 /Shading << /ColorSpace /DeviceRGB /Coords [0 0 10 0] /Extend [true true] /Function << /C0 [1 0 0] /C1 [1 0 0] /Domain [0 1] /FunctionType 2 /N 1 >> /ShadingType 2 >>"), "could not find axial shading in output")
+	test.That(t, strings.Contains(out, "/ColorSpace /DeviceGray /Coords [0 0 10 0] /Extend [true true] /Function << /C0 [1] /C1 [0] /Domain [0 1] /FunctionType 2 /N 1 >> /ShadingType 2"), "could not find soft mask shading in output")
+	test.That(t, strings.Contains(out, "q /Pattern cs /P0 scn /G0 gs 0 0 m 10 0 l 10 10 l 0 10 l f Q"), "could not find pattern fill in output")
+}
+
+func TestPDFGradientTransparentStops(t *testing.T) {
+	stops := canvas.Stops{}
+	stops.Add(0.0, canvas.Red)
+	stops.Add(0.5, canvas.Transparent)
+	stops.Add(1.0, canvas.Blue)
+	test.T(t, transparentStopColors(stops), canvas.Stops{
+		{Offset: 0.0, Color: canvas.Red},
+		{Offset: 0.5, Color: canvas.Red},
+		{Offset: 0.5, Color: canvas.Blue},
+		{Offset: 1.0, Color: canvas.Blue},
+	})
+
+	stops = canvas.Stops{}
+	stops.Add(0.0, canvas.Transparent)
+	stops.Add(0.5, canvas.Transparent)
+	stops.Add(1.0, canvas.Green)
+	test.T(t, transparentStopColors(stops), canvas.Stops{
+		{Offset: 0.5, Color: canvas.Green},
+		{Offset: 1.0, Color: canvas.Green},
+	})
+}
+
+func TestPDFPattern(t *testing.T) {
+	cell := canvas.New(2.0, 2.0)
+	ctx := canvas.NewContext(cell)
+	ctx.SetFillColor(canvas.Red)
+	ctx.DrawPath(0.0, 0.0, canvas.Rectangle(1.0, 1.0))
+
+	style := canvas.DefaultStyle
+	style.FillPattern = canvas.NewPattern(cell, canvas.Identity, 1.0, 0.0)
+
+	buf := &bytes.Buffer{}
+	pdf := New(buf, 10, 10, &Options{Compress: false})
+	pdf.RenderPath(canvas.Rectangle(10.0, 10.0), style, canvas.Identity)
+	pdf.RenderPath(canvas.Rectangle(5.0, 5.0), style, canvas.Identity)
+	err := pdf.Close()
+	test.Error(t, err)
+	out := buf.String()
+
+	test.T(t, strings.Count(out, "/PatternType 1"), 1)
+	test.That(t, strings.Contains(out, "/Type /Pattern /BBox [0 0 3 2]"), "could not find tiling pattern in output")
+	test.That(t, strings.Contains(out, "/Matrix [2.8346457 0 0 2.8346457 0 0] /PaintType 1 /PatternType 1 /Resources << >> /TilingType 1 /XStep 3 /YStep 2"), "could not find tiling pattern steps in output")
+	test.That(t, strings.Contains(out, "1 0 0 rg 0 0 m 1 0 l 1 1 l 0 1 l f\nendstream"), "could not find pattern cell in output")
+	test.That(t, strings.Contains(out, "q /Pattern cs /P0 scn 0 0 m 10 0 l 10 10 l 0 10 l f Q"), "could not find pattern fill in output")
+	test.That(t, strings.Contains(out, "q /Pattern cs /P0 scn 0 0 m 5 0 l 5 5 l 0 5 l f Q"), "could not find reused pattern fill in output")
+}
+
+func TestPDFClip(t *testing.T) {
+	buf := &bytes.Buffer{}
+	pdf := New(buf, 10, 10, &Options{Compress: false})
+	pdf.PushClip(canvas.Rectangle(5.0, 5.0), canvas.EvenOdd, canvas.Identity)
+	pdf.RenderPath(canvas.Rectangle(10.0, 10.0), canvas.DefaultStyle, canvas.Identity)
+	err := pdf.Close()
+	test.Error(t, err)
+	test.That(t, strings.Contains(buf.String(), " q 0 0 m 5 0 l 5 5 l 0 5 l h W* n 0 0 m 10 0 l 10 10 l 0 10 l f Q"), "could not find clipping path in output")
+}
+
+func TestPDFGroup(t *testing.T) {
+	mask := canvas.New(10, 10)
+	mask.RenderPath(canvas.Rectangle(10.0, 5.0), canvas.DefaultStyle, canvas.Identity)
+
+	buf := &bytes.Buffer{}
+	pdf := New(buf, 10, 10, &Options{Compress: false})
+	pdf.PushGroup(canvas.Group{Opacity: 0.5}, canvas.Identity)
+	pdf.RenderPath(canvas.Rectangle(10.0, 10.0), canvas.DefaultStyle, canvas.Identity)
+	pdf.PopGroup()
+	pdf.PushGroup(canvas.Group{Opacity: 1.0, Mask: mask, MaskType: canvas.AlphaMask}, canvas.Identity)
+	pdf.RenderPath(canvas.Rectangle(10.0, 10.0), canvas.DefaultStyle, canvas.Identity)
+	pdf.PopGroup()
+	err := pdf.Close()
+	test.Error(t, err)
+	out := buf.String()
+
+	test.That(t, strings.Contains(out, "/Subtype /Form /BBox [0 0 10 10] /Group << /Type /Group /CS /DeviceRGB /I true /S /Transparency >>"), "could not find transparency group in output")
+	test.That(t, strings.Contains(out, " cm /A0 gs /X0 Do q /G1 gs /X1 Do Q"), "could not find group painting in output")
+	test.That(t, strings.Contains(out, "/G1 << /CA 1 /SMask << /Type /Mask /G 6 0 R /S /Alpha >> /ca 1 >>"), "could not find soft mask in output")
+}
+
+func TestPDFGroupResources(t *testing.T) {
+	style := canvas.DefaultStyle
+	style.FillColor = canvas.RGBA(0.0, 0.0, 0.0, 0.5)
+
+	buf := &bytes.Buffer{}
+	pdf := New(buf, 10, 10, &Options{Compress: false})
+	pdf.RenderPath(canvas.Rectangle(10.0, 10.0), style, canvas.Identity)
+	pdf.PushGroup(canvas.Group{Opacity: 0.5}, canvas.Identity)
+	style.FillColor = canvas.RGBA(0.0, 0.0, 0.0, 0.25)
+	pdf.RenderPath(canvas.Rectangle(10.0, 10.0), style, canvas.Identity)
+	pdf.PopGroup()
+	err := pdf.Close()
+	test.Error(t, err)
+	out := buf.String()
+
+	// the group defines only the graphics state it uses, independently of the page
+	test.That(t, strings.Contains(out, "/Resources << /ExtGState << /A0 << /CA .24705882 /ca .24705882 >> >> >> >> stream\n/A0 gs 0 0 m"), "could not find group resources in output")
+	test.That(t, strings.Contains(out, "/Resources << /ExtGState << /A0 << /CA .49803922 /ca .49803922 >> /A1 << /CA .5 /ca .5 >> >> /XObject << /X0 4 0 R >> >>"), "could not find page resources in output")
+}
+
+func TestPDFBlendMode(t *testing.T) {
+	style := canvas.DefaultStyle
+	style.BlendMode = canvas.MultiplyBlend
+
+	buf := &bytes.Buffer{}
+	pdf := New(buf, 10, 10, &Options{Compress: false})
+	pdf.RenderPath(canvas.Rectangle(10.0, 10.0), style, canvas.Identity)
+	pdf.RenderPath(canvas.Rectangle(10.0, 10.0), style, canvas.Identity)
+	pdf.RenderPath(canvas.Rectangle(10.0, 10.0), canvas.DefaultStyle, canvas.Identity)
+	err := pdf.Close()
+	test.Error(t, err)
+	out := buf.String()
+
+	test.That(t, strings.Contains(out, "/B0 << /BM /Multiply >> /B1 << /BM /Normal >>"), "could not find blend modes in output")
+	test.That(t, strings.Contains(out, "/B0 gs 0 0 m 10 0 l 10 10 l 0 10 l f 0 0 m 10 0 l 10 10 l 0 10 l f /B1 gs 0 0 m"), "could not find blend mode changes in output")
+}
+
+func TestPDFLink(t *testing.T) {
+	buf := &bytes.Buffer{}
+	pdf := New(buf, 10, 10, &Options{Compress: false})
+	pdf.RenderLink("https://example.com", canvas.Rect{X: 0.0, Y: 0.0, W: 5.0, H: 5.0}, canvas.Identity)
+	pdf.RenderLink("#second", canvas.Rect{X: 5.0, Y: 5.0, W: 5.0, H: 5.0}, canvas.Identity)
+	pdf.AddOutline("Chapter", 0, canvas.Point{X: 0.0, Y: 10.0})
+	pdf.NewPage(10, 10)
+	pdf.RenderDestination("second", canvas.Point{X: 0.0, Y: 10.0}, canvas.Identity)
+	pdf.AddOutline("Section", 1, canvas.Point{X: 0.0, Y: 10.0})
+	pdf.AddOutline("Appendix", 0, canvas.Point{X: 0.0, Y: 5.0})
+	err := pdf.Close()
+	test.Error(t, err)
+	out := buf.String()
+
+	test.That(t, strings.Contains(out, "/Type /Annot /Subtype /Link /A << /S /URI /URI (https://example.com) >> /Border [0 0 0] /Rect [0 0 14.173228 14.173228]"), "could not find URI link in output")
+	test.That(t, strings.Contains(out, "/Dest (second)"), "could not find internal link in output")
+	test.That(t, strings.Count(out, "/Annots ") == 1, "expected link annotations on the first page only")
+	test.That(t, strings.Contains(out, "/Names << /Dests "), "could not find named destinations in catalog")
+	test.That(t, strings.Contains(out, "/Names [(second) ["), "could not find named destination in output")
+	test.That(t, strings.Contains(out, "/PageMode /UseOutlines"), "could not find page mode in output")
+	test.That(t, strings.Contains(out, "/Count 3 /First "), "could not find outline root in output")
+	test.That(t, strings.Contains(out, "/Title (Section)"), "could not find outline item in output")
+}
+
+func TestPDFA(t *testing.T) {
+	family := canvas.NewFontFamily("dejavu-serif")
+	test.Error(t, family.LoadFontFile(fontDir+"DejaVuSerif.ttf", canvas.FontRegular))
+	face := family.Face(12.0, canvas.Black, canvas.FontRegular, canvas.FontNormal)
+
+	img := canvas.Image{Image: image.NewNRGBA(image.Rect(0, 0, 2, 2)), Alt: "Empty image"}
+
+	buf := &bytes.Buffer{}
+	pdf := New(buf, 100.0, 50.0, &Options{Compress: true, SubsetFonts: true, PDFA: true, Tagged: true})
+	pdf.SetInfo("Archive – Ω", "", "", "Author", "")
+	pdf.RenderPath(canvas.Rectangle(10.0, 20.0), canvas.DefaultStyle, canvas.Identity)
+	pdf.RenderText(canvas.NewTextLine(face, "Hello", canvas.Left), canvas.Identity.Translate(20.0, 20.0))
+	pdf.RenderImage(img, canvas.Identity.Translate(50.0, 10.0))
+	pdf.RenderLink("https://example.com", canvas.Rect{X: 0.0, Y: 0.0, W: 10.0, H: 10.0}, canvas.Identity)
+	test.Error(t, pdf.Close())
+
+	r, err := newPDFReader(bytes.NewReader(buf.Bytes()), "")
+	test.Error(t, err)
+	test.That(t, r.trailer["Encrypt"] == nil, "must not be encrypted")
+	id, err := r.GetArray(r.trailer["ID"])
+	test.Error(t, err)
+	test.T(t, len(id), 2)
+
+	catalog, err := r.GetDict(r.trailer["Root"])
+	test.Error(t, err)
+
+	// XMP metadata identifying the conformance level, and equivalent to the document information dictionary
+	metadata, err := r.GetStream(catalog["Metadata"])
+	test.Error(t, err)
+	test.T(t, metadata.dict["Subtype"], pdfName("XML"))
+	test.That(t, metadata.dict["Filter"] == nil, "metadata must not be compressed")
+	xmp := string(metadata.stream)
+	test.That(t, strings.Contains(xmp, "<pdfaid:part>2</pdfaid:part><pdfaid:conformance>B</pdfaid:conformance>"), "must identify as PDF/A-2b")
+	test.That(t, strings.Contains(xmp, `<rdf:li xml:lang="x-default">Archive – Ω</rdf:li>`), "must contain the title")
+	info, err := r.GetDict(r.trailer["Info"])
+	test.Error(t, err)
+	title, err := r.GetString(info["Title"])
+	test.Error(t, err)
+	test.String(t, string(title[:2]), "\xFE\xFF")
+
+	// output intent with an embedded ICC profile
+	intents, err := r.GetArray(catalog["OutputIntents"])
+	test.Error(t, err)
+	test.T(t, len(intents), 1)
+	intent, err := r.GetDict(intents[0])
+	test.Error(t, err)
+	test.T(t, intent["S"], pdfName("GTS_PDFA1"))
+	profile, err := r.GetStream(intent["DestOutputProfile"])
+	test.Error(t, err)
+	test.T(t, profile.dict["N"], 3)
+	test.T(t, int(binary.BigEndian.Uint32(profile.stream)), len(profile.stream))
+	test.String(t, string(profile.stream[12:20]), "mntrRGB ")
+	test.String(t, string(profile.stream[36:40]), "acsp")
+
+	// page content
+	page, content, err := r.GetPage(0)
+	test.Error(t, err)
+	test.T(t, page["StructParents"], 0)
+	test.That(t, bytes.Contains(content, []byte("/Artifact BMC")), "paths must be artifacts")
+	test.That(t, bytes.Contains(content, []byte("/P <</MCID 0>> BDC")), "text must be marked content")
+	test.That(t, bytes.Contains(content, []byte("/Figure <</MCID 1>> BDC")), "images must be marked content")
+
+	// fonts are embedded and have a ToUnicode CMap
+	resources, err := r.GetDict(page["Resources"])
+	test.Error(t, err)
+	fonts, err := r.GetDict(resources["Font"])
+	test.Error(t, err)
+	test.T(t, len(fonts), 1)
+	for _, val := range fonts {
+		font, err := r.GetDict(val)
+		test.Error(t, err)
+		toUnicode, err := r.GetStream(font["ToUnicode"])
+		test.Error(t, err)
+		for _, m := range regexp.MustCompile(`(\d+) beginbf(?:char|range)\n([^e]*)endbf`).FindAllSubmatch(toUnicode.stream, -1) {
+			test.String(t, string(m[1]), strconv.Itoa(bytes.Count(m[2], []byte("\n"))), "ToUnicode CMap entry count")
+		}
+		runes := map[rune]bool{}
+		for _, r := range parseCMap(toUnicode.stream).unicode {
+			runes[r[0]] = true
+		}
+		for _, r := range "Helo" {
+			test.That(t, runes[r], "ToUnicode CMap must map", string(r))
+		}
+		descendants, err := r.GetArray(font["DescendantFonts"])
+		test.Error(t, err)
+		descendant, err := r.GetDict(descendants[0])
+		test.Error(t, err)
+		descriptor, err := r.GetDict(descendant["FontDescriptor"])
+		test.Error(t, err)
+		_, err = r.GetStream(descriptor["FontFile3"])
+		test.Error(t, err)
+	}
+
+	// images must not be interpolated
+	xobjects, err := r.GetDict(resources["XObject"])
+	test.Error(t, err)
+	for _, val := range xobjects {
+		image, err := r.GetDict(val)
+		test.Error(t, err)
+		test.T(t, image["Interpolate"], false)
+	}
+
+	// annotations must be printed
+	annots, err := r.GetArray(page["Annots"])
+	test.Error(t, err)
+	annot, err := r.GetDict(annots[0])
+	test.Error(t, err)
+	test.T(t, annot["F"], 4)
+
+	// structure tree
+	markInfo, err := r.GetDict(catalog["MarkInfo"])
+	test.Error(t, err)
+	test.T(t, markInfo["Marked"], true)
+	root, err := r.GetDict(catalog["StructTreeRoot"])
+	test.Error(t, err)
+	document, err := r.GetDict(root["K"])
+	test.Error(t, err)
+	test.T(t, document["S"], pdfName("Document"))
+	kids, err := r.GetArray(document["K"])
+	test.Error(t, err)
+	test.T(t, len(kids), 2)
+	paragraph, err := r.GetDict(kids[0])
+	test.Error(t, err)
+	test.T(t, paragraph["S"], pdfName("P"))
+	test.T(t, paragraph["K"], 0)
+	figure, err := r.GetDict(kids[1])
+	test.Error(t, err)
+	test.T(t, figure["S"], pdfName("Figure"))
+	alt, err := r.GetString(figure["Alt"])
+	test.Error(t, err)
+	test.String(t, string(alt), "Empty image")
+	parentTree, err := r.GetDict(root["ParentTree"])
+	test.Error(t, err)
+	nums, err := r.GetArray(parentTree["Nums"])
+	test.Error(t, err)
+	test.T(t, len(nums), 2)
+	parents, err := r.GetArray(nums[1])
+	test.Error(t, err)
+	test.T(t, parents, pdfArray{kids[0], kids[1]})
+}
+
+func TestPDFNativeColor(t *testing.T) {
+	profile, err := canvas.ParseICCProfile(sRGBProfile())
+	test.Error(t, err)
+
+	style := canvas.DefaultStyle
+	style.FillColor = canvas.Red
+	style.FillNative = canvas.CMYK(0.0, 1.0, 1.0, 0.0)
+	style.StrokeColor = canvas.Black
+	style.StrokeNative = canvas.NewSpotColor("PANTONE 185 C", canvas.CMYK(0.0, 0.9, 0.8, 0.0), 0.5)
+	style.StrokeWidth = 1.0
+
+	buf := &bytes.Buffer{}
+	pdf := New(buf, 10, 10, &Options{Compress: false})
+	pdf.RenderPath(canvas.Rectangle(10.0, 10.0), style, canvas.Identity)
+	style = canvas.DefaultStyle
+	style.FillNative = canvas.NewICCColor(profile, 0.2, 0.4, 0.6)
+	pdf.RenderPath(canvas.Rectangle(10.0, 10.0), style, canvas.Identity)
+	test.Error(t, pdf.Close())
+	out := buf.String()
+
+	test.That(t, strings.Contains(out, "0 1 1 0 k /CS0 CS .5 SCN"), "could not find CMYK fill and spot stroke in output")
+	test.That(t, strings.Contains(out, "/CS0 [/Separation /PANTONE#20185#20C /DeviceCMYK << /C0 [0 0 0 0] /C1 [0 .9 .8 0] /Domain [0 1] /FunctionType 2 /N 1 >>]"), "could not find separation color space in output")
+	test.That(t, strings.Contains(out, "/CS1 cs .2 .4 .6 scn"), "could not find ICC color in output")
+	test.That(t, strings.Contains(out, "/CS1 [/ICCBased "), "could not find ICC color space in output")
+
+	// the reader converts the ICC colors using the embedded profile
+	reader, err := NewReader(bytes.NewReader(buf.Bytes()), "")
+	test.Error(t, err)
+	c, err := reader.Page(0)
+	test.Error(t, err)
+	r := recordPage(c)
+	test.T(t, len(r.layers), 2)
+	test.T(t, r.layers[0].style.FillColor, canvas.Red)
+	col := r.layers[1].style.FillColor
+	test.That(t, math.Abs(float64(col.R)-51.0) <= 2.0 && math.Abs(float64(col.G)-102.0) <= 2.0 && math.Abs(float64(col.B)-153.0) <= 2.0, "bad ICC color conversion", col)
+}
+
+func TestPDFVariableFont(t *testing.T) {
+	font, err := canvas.LoadFontFile("../../resources/AdobeVFPrototype.otf", canvas.FontRegular)
+	test.Error(t, err)
+	face := font.Face(12.0, canvas.Black)
+
+	buf := &bytes.Buffer{}
+	pdf := New(buf, 100, 100, nil)
+	font.SetVariations("wght=900")
+	pdf.RenderText(canvas.NewTextLine(face, "A", canvas.Left), canvas.Identity)
+	font.SetVariations("wght=200")
+	pdf.RenderText(canvas.NewTextLine(face, "A", canvas.Left), canvas.Identity.Translate(0.0, 20.0))
+	test.Error(t, pdf.Close())
+
+	// each set of variations is embedded as a separate static instance
+	reader, err := NewReader(bytes.NewReader(buf.Bytes()), "")
+	test.Error(t, err)
+	page, _, err := reader.r.GetPage(0)
+	test.Error(t, err)
+	resources, _ := reader.r.GetDict(page["Resources"])
+	fonts, _ := reader.r.GetDict(resources["Font"])
+	test.T(t, len(fonts), 2)
+	advances := []float64{}
+	for _, name := range []pdfName{"F0", "F1"} {
+		f, err := reader.r.GetFont(fonts[name], nil)
+		test.Error(t, err)
+		test.That(t, f.sfnt != nil && !f.sfnt.IsVariable(), "embedded font must be a static instance")
+		advances = append(advances, f.widths[uint32(font.SFNT.GlyphIndex('A'))])
+	}
+	test.T(t, advances[0], 0.68)
+	test.That(t, advances[1] < advances[0], "instances must have different advances")
+}
+
+func TestPDFVariableFontError(t *testing.T) {
+	font, err := canvas.LoadFontFile("../../resources/AdobeVFPrototype.otf", canvas.FontRegular)
+	test.Error(t, err)
+	face := font.Face(12.0, canvas.Black)
+
+	// bad variations are reported, but the default instance is embedded so that the document stays valid
+	buf := &bytes.Buffer{}
+	pdf := New(buf, 100, 100, nil)
+	pdf.RenderText(canvas.NewTextLine(face, "A", canvas.Left), canvas.Identity)
+	w := pdf.w.pdf
+	key := newPDFFontKey(font)
+	bad := pdfFontKey{font, "wght=bad"}
+	w.fontsH[bad], w.fontSubset[bad] = w.fontsH[key], w.fontSubset[key]
+	delete(w.fontsH, key)
+	delete(w.fontSubset, key)
+	test.That(t, pdf.Close() != nil, "must report bad variations")
+
+	reader, err := NewReader(bytes.NewReader(buf.Bytes()), "")
+	test.Error(t, err)
+	page, _, err := reader.r.GetPage(0)
+	test.Error(t, err)
+	resources, _ := reader.r.GetDict(page["Resources"])
+	fonts, _ := reader.r.GetDict(resources["Font"])
+	test.T(t, len(fonts), 1)
+	_, err = reader.r.GetFont(fonts["F0"], nil)
+	test.Error(t, err)
+}
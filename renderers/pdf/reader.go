@@ -0,0 +1,1298 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/ascii85"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/tdewolff/parse/v2/strconv"
+	"golang.org/x/image/tiff/lzw"
+)
+
+// pdfIndirectRef is a reference to an object in a PDF file being read, given by its object number and generation.
+type pdfIndirectRef [2]uint32
+
+func (v pdfIndirectRef) String() string {
+	return fmt.Sprintf("%d %d R", v[0], v[1])
+}
+
+var noEncryptRef = pdfIndirectRef{0, 0}
+
+type pdfObject struct {
+	free, compressed bool
+	offset           int
+	object           uint32 // compressed or next free object number
+}
+
+// pdfReader reads objects from a PDF file. Values are returned as int, float64, bool, []byte for strings, pdfName, pdfArray, pdfDict, pdfStream with decoded data, pdfIndirectRef, or nil.
+type pdfReader struct {
+	data    []byte
+	objects map[pdfIndirectRef]pdfObject
+	trailer pdfDict
+	encrypt pdfEncrypt
+	kids    []pdfIndirectRef
+
+	startxref int
+	xrefs     map[int]bool // offsets of cross reference sections that have been read
+	cache     map[pdfIndirectRef]interface{}
+}
+
+func newPDFReader(reader io.Reader, password string) (*pdfReader, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	} else if len(data) < 8 || !bytes.Equal(data[:5], []byte("%PDF-")) || (data[5] != '1' || data[7] < '0' || '7' < data[7]) && !bytes.Equal(data[5:8], []byte("2.0")) {
+		return nil, fmt.Errorf("invalid PDF file: bad version")
+	}
+
+	r := &pdfReader{
+		data:    data,
+		objects: map[pdfIndirectRef]pdfObject{},
+		xrefs:   map[int]bool{},
+		cache:   map[pdfIndirectRef]interface{}{},
+	}
+
+	// get startxref
+	var line []byte
+	lrr := newLineReaderReverse(r.data, len(r.data))
+	for {
+		if line = lrr.Next(); line == nil {
+			return nil, fmt.Errorf("invalid PDF file")
+		} else if bytes.HasPrefix(bytes.TrimSpace(line), []byte("%%EOF")) {
+			break
+		}
+	}
+	num, _ := strconv.ParseUint(bytes.TrimSpace(lrr.Next()))
+	if num == 0 {
+		return nil, fmt.Errorf("invalid PDF file")
+	} else if line = lrr.Next(); !bytes.Equal(bytes.TrimSpace(line), []byte("startxref")) {
+		return nil, fmt.Errorf("invalid PDF file")
+	} else if len(r.data) <= int(num) {
+		return nil, fmt.Errorf("invalid PDF file: bad startxref")
+	}
+
+	if r.trailer, err = r.readTrailer(int(num)); err != nil {
+		return nil, err
+	} else if err := r.readEncrypt([]byte(password)); err != nil {
+		return nil, err
+	} else if err := r.readKids(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *pdfReader) readCrossReferenceTable() (pdfDict, error) {
+	var starttrailer int
+	var line []byte
+
+	lr := newLineReader(r.data, r.startxref)
+	_ = lr.Next() // xref
+	for {
+		starttrailer = lr.Pos()
+		line = lr.Next()
+		if line == nil {
+			return pdfDict{}, fmt.Errorf("invalid cross reference table")
+		} else if bytes.HasPrefix(line, []byte("trailer")) {
+			break
+		} else if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		first, n := strconv.ParseUint(line)
+		if n == 0 {
+			return pdfDict{}, fmt.Errorf("invalid cross reference table")
+		}
+		i := moveWhiteSpace(line, n)
+		entries, n := strconv.ParseUint(line[i:])
+		if n == 0 {
+			return pdfDict{}, fmt.Errorf("invalid cross reference table")
+		}
+
+		for i := uint32(0); i < uint32(entries); i++ {
+			line = bytes.TrimRight(lr.Next(), " ")
+			if len(line) != 18 {
+				return pdfDict{}, fmt.Errorf("invalid cross reference table")
+			}
+			offset, n := strconv.ParseUint(line)
+			if n != 10 {
+				return pdfDict{}, fmt.Errorf("invalid cross reference table")
+			}
+			generation, n := strconv.ParseUint(line[11:])
+			if n != 5 || line[17] != 'f' && line[17] != 'n' {
+				return pdfDict{}, fmt.Errorf("invalid cross reference table")
+			}
+			free := line[17] == 'f'
+			ref := pdfIndirectRef{uint32(first) + i, uint32(generation)}
+			if _, ok := r.objects[ref]; !ok {
+				// add object of previous generations only if not over-written by a new version
+				if free {
+					r.objects[ref] = pdfObject{free: true, object: uint32(offset)}
+				} else {
+					r.objects[ref] = pdfObject{offset: int(offset)}
+				}
+			}
+		}
+	}
+
+	// trailer
+	starttrailer = moveWhiteSpace(r.data, starttrailer+7)
+	itrailer, _, err := pdfReadVal(r, noEncryptRef, r.data[starttrailer:])
+	if err != nil {
+		return pdfDict{}, fmt.Errorf("invalid trailer: %w", err)
+	} else if _, ok := itrailer.(pdfDict); !ok {
+		return pdfDict{}, fmt.Errorf("invalid trailer: must be dictionary")
+	}
+	trailer := itrailer.(pdfDict)
+	return trailer, nil
+}
+
+func (r *pdfReader) readCrossReferenceStream() (pdfDict, error) {
+	istream, _, err := r.readObjectAt(noEncryptRef, r.startxref)
+	if err != nil {
+		return pdfDict{}, fmt.Errorf("invalid cross reference stream: %w", err)
+	}
+	stream, ok := istream.(pdfStream)
+	if !ok {
+		return pdfDict{}, fmt.Errorf("invalid cross reference stream")
+	}
+	size, err := r.GetInt(stream.dict["Size"])
+	if err != nil {
+		return pdfDict{}, fmt.Errorf("invalid cross reference stream")
+	}
+	ws, err := r.GetArray(stream.dict["W"])
+	if err != nil || len(ws) != 3 {
+		return pdfDict{}, fmt.Errorf("invalid cross reference stream")
+	}
+	W := [3]int{}
+	for i, w := range ws {
+		W[i], err = r.GetInt(w)
+		if err != nil || W[i] < 0 || 4 < W[i] {
+			return pdfDict{}, fmt.Errorf("invalid cross reference stream")
+		}
+	}
+	var indices []int
+	if _, ok := stream.dict["Index"]; ok {
+		is, err := r.GetArray(stream.dict["Index"])
+		if err != nil || len(is)%2 != 0 {
+			return pdfDict{}, fmt.Errorf("invalid cross reference stream")
+		}
+		for i := 0; i < len(is); i += 2 {
+			first, err1 := r.GetInt(is[i+0])
+			n, err2 := r.GetInt(is[i+1])
+			if err1 != nil || err2 != nil || first < 0 || n < 0 || size < n {
+				return pdfDict{}, fmt.Errorf("invalid cross reference stream")
+			}
+			for j := 0; j < n; j++ {
+				indices = append(indices, first+j)
+			}
+		}
+	} else {
+		indices = make([]int, size)
+		for i := 0; i < size; i++ {
+			indices[i] = i
+		}
+	}
+	dW := W[0] + W[1] + W[2]
+	if len(stream.stream) < dW*len(indices) || W[1] == 0 {
+		return pdfDict{}, fmt.Errorf("invalid cross reference stream")
+	}
+	for i, index := range indices {
+		d := i * dW
+		t := uint32(1)
+		if W[0] != 0 {
+			t = readNumberBE(stream.stream[d:], W[0])
+		}
+		field2 := readNumberBE(stream.stream[d+W[0]:], W[1])
+		field3 := uint32(0)
+		if W[2] != 0 {
+			field3 = readNumberBE(stream.stream[d+W[0]+W[1]:], W[2])
+		}
+		if t == 0 {
+			// free object
+			ref := pdfIndirectRef{uint32(index), field3}
+			if _, ok := r.objects[ref]; !ok {
+				r.objects[ref] = pdfObject{free: true, object: field2}
+			}
+		} else if t == 1 {
+			// used object
+			ref := pdfIndirectRef{uint32(index), field3}
+			if _, ok := r.objects[ref]; !ok {
+				// add object of previous generations only if not over-written by a new version
+				r.objects[ref] = pdfObject{offset: int(field2)}
+			}
+		} else if t == 2 {
+			// compressed object
+			ref := pdfIndirectRef{uint32(index), 0}
+			if _, ok := r.objects[ref]; !ok {
+				// add object of previous generations only if not over-written by a new version
+				r.objects[ref] = pdfObject{compressed: true, offset: int(field3), object: field2}
+			}
+		} else {
+			// no-op
+		}
+	}
+
+	trailer := stream.dict
+	delete(trailer, "Type")
+	delete(trailer, "Index")
+	delete(trailer, "W")
+	delete(trailer, "Length")
+	return trailer, nil
+}
+
+func (r *pdfReader) readTrailer(startxref int) (pdfDict, error) {
+	if startxref < 0 || len(r.data) <= startxref || r.xrefs[startxref] {
+		return pdfDict{}, fmt.Errorf("invalid cross reference offset")
+	}
+	r.startxref = startxref
+	r.xrefs[startxref] = true
+
+	var trailer pdfDict
+	var err error
+	if bytes.HasPrefix(r.data[startxref:], []byte("xref")) {
+		trailer, err = r.readCrossReferenceTable()
+	} else {
+		trailer, err = r.readCrossReferenceStream()
+	}
+	if err != nil {
+		return pdfDict{}, err
+	}
+	if xrefStm, err := r.GetInt(trailer["XRefStm"]); err == nil {
+		// hybrid-reference file
+		if _, err = r.readTrailer(xrefStm); err != nil {
+			return pdfDict{}, err
+		}
+	}
+	if prev, err := r.GetInt(trailer["Prev"]); err == nil {
+		if _, err = r.readTrailer(prev); err != nil {
+			return pdfDict{}, err
+		}
+	}
+	return trailer, nil
+}
+
+func (r *pdfReader) readKids() error {
+	root, err := r.GetDict(r.trailer["Root"])
+	if err != nil {
+		return fmt.Errorf("bad /Catalog object")
+	}
+	pages, err := r.GetDict(root["Pages"])
+	if err != nil {
+		return fmt.Errorf("bad /Pages object")
+	} else if err = r.addKids(pages, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *pdfReader) addKids(pages pdfDict, depth int) error {
+	if 64 < depth {
+		return fmt.Errorf("page tree too deep")
+	}
+	kids, err := r.GetArray(pages["Kids"])
+	if err != nil {
+		return fmt.Errorf("missing or invalid Kids entry in Pages object")
+	}
+	for _, kid := range kids {
+		obj, err := r.GetDict(kid)
+		if err != nil {
+			return fmt.Errorf("bad Kids entry")
+		} else if _, ok := obj["Kids"]; ok || obj["Type"] == pdfName("Pages") {
+			if err := r.addKids(obj, depth+1); err != nil {
+				return err
+			}
+		} else if ref, ok := kid.(pdfIndirectRef); ok {
+			r.kids = append(r.kids, ref)
+		}
+	}
+	return nil
+}
+
+func (r *pdfReader) get(val interface{}) (interface{}, error) {
+	for i := 0; ; i++ {
+		ref, ok := val.(pdfIndirectRef)
+		if !ok {
+			break
+		} else if 32 < i {
+			return nil, fmt.Errorf("reference loop")
+		}
+		var err error
+		if val, err = r.readObject(ref); err != nil {
+			return nil, err
+		}
+	}
+	return val, nil
+}
+
+// GetName returns the name value, resolving references.
+func (r *pdfReader) GetName(val interface{}) (pdfName, error) {
+	val, err := r.get(val)
+	if err != nil {
+		return "", err
+	}
+	name, ok := val.(pdfName)
+	if !ok {
+		return "", fmt.Errorf("not a name or missing")
+	}
+	return name, nil
+}
+
+// GetInt returns the integer value, resolving references.
+func (r *pdfReader) GetInt(val interface{}) (int, error) {
+	val, err := r.get(val)
+	if err != nil {
+		return 0, err
+	}
+	i, ok := val.(int)
+	if !ok {
+		return 0, fmt.Errorf("not an integer or missing")
+	}
+	return i, nil
+}
+
+// GetFloat returns the integer or real value as a float, resolving references.
+func (r *pdfReader) GetFloat(val interface{}) (float64, error) {
+	val, err := r.get(val)
+	if err != nil {
+		return 0.0, err
+	}
+	switch v := val.(type) {
+	case int:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	}
+	return 0.0, fmt.Errorf("not a number or missing")
+}
+
+// GetFloats returns the array of numbers as floats, resolving references.
+func (r *pdfReader) GetFloats(val interface{}) ([]float64, error) {
+	array, err := r.GetArray(val)
+	if err != nil {
+		return nil, err
+	}
+	fs := make([]float64, len(array))
+	for i, item := range array {
+		if fs[i], err = r.GetFloat(item); err != nil {
+			return nil, err
+		}
+	}
+	return fs, nil
+}
+
+// GetString returns the string value, resolving references.
+func (r *pdfReader) GetString(val interface{}) ([]byte, error) {
+	val, err := r.get(val)
+	if err != nil {
+		return nil, err
+	}
+	i, ok := val.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("not a string or missing")
+	}
+	return i, nil
+}
+
+// GetArray returns the array value, resolving references.
+func (r *pdfReader) GetArray(val interface{}) (pdfArray, error) {
+	val, err := r.get(val)
+	if err != nil {
+		return pdfArray{}, err
+	}
+	array, ok := val.(pdfArray)
+	if !ok {
+		return pdfArray{}, fmt.Errorf("not an array or missing")
+	}
+	return array, nil
+}
+
+// GetDict returns the dictionary value, or the dictionary of a stream, resolving references.
+func (r *pdfReader) GetDict(val interface{}) (pdfDict, error) {
+	val, err := r.get(val)
+	if err != nil {
+		return pdfDict{}, err
+	}
+	if stream, ok := val.(pdfStream); ok {
+		return stream.dict, nil
+	}
+	dict, ok := val.(pdfDict)
+	if !ok {
+		return pdfDict{}, fmt.Errorf("not a dictionary or missing")
+	}
+	return dict, nil
+}
+
+// GetStream returns the stream value, resolving references.
+func (r *pdfReader) GetStream(val interface{}) (pdfStream, error) {
+	val, err := r.get(val)
+	if err != nil {
+		return pdfStream{}, err
+	}
+	stream, ok := val.(pdfStream)
+	if !ok {
+		return pdfStream{}, fmt.Errorf("not a stream or missing")
+	}
+	return stream, nil
+}
+
+// GetPage returns the page dictionary and its concatenated content streams.
+func (r *pdfReader) GetPage(index int) (pdfDict, []byte, error) {
+	if index < 0 || len(r.kids) <= index {
+		return nil, nil, fmt.Errorf("unknown page %d", index)
+	}
+	dict, err := r.GetDict(r.kids[index])
+	if err != nil {
+		return nil, nil, fmt.Errorf("bad page %d: %w", index, err)
+	}
+
+	if dict["Contents"] == nil {
+		return dict, []byte{}, nil
+	} else if array, err := r.GetArray(dict["Contents"]); err == nil {
+		b := []byte{}
+		for _, item := range array {
+			contents, err := r.GetStream(item)
+			if err != nil {
+				return nil, nil, fmt.Errorf("bad page %d: %w", index, err)
+			}
+			b = append(b, contents.stream...)
+			b = append(b, '\n') // content streams may be split at any token boundary
+		}
+		return dict, b, nil
+	}
+
+	contents, err := r.GetStream(dict["Contents"])
+	if err != nil {
+		return nil, nil, fmt.Errorf("bad page %d: %w", index, err)
+	}
+	return dict, contents.stream, nil
+}
+
+// GetInherited returns the value of a page attribute that may be inherited from the ancestors in the page tree.
+func (r *pdfReader) GetInherited(page pdfDict, key pdfName) interface{} {
+	for i := 0; i < 64 && page != nil; i++ {
+		if val, ok := page[key]; ok {
+			return val
+		}
+		parent, err := r.GetDict(page["Parent"])
+		if err != nil {
+			break
+		}
+		page = parent
+	}
+	return nil
+}
+
+func (r *pdfReader) readObject(ref pdfIndirectRef) (interface{}, error) {
+	if val, ok := r.cache[ref]; ok {
+		return val, nil
+	}
+	obj, ok := r.objects[ref]
+	if !ok {
+		return nil, fmt.Errorf("unknown object %v", ref)
+	} else if obj.free {
+		return nil, fmt.Errorf("bad object %v is free", ref)
+	} else if obj.compressed {
+		r.cache[ref] = nil // guard against self-reference
+		iobjectStream, err := r.readObject(pdfIndirectRef{obj.object, 0})
+		if err != nil {
+			return nil, err
+		}
+		objectStream, ok := iobjectStream.(pdfStream)
+		if !ok {
+			return nil, fmt.Errorf("compressed object %v must refer to stream", ref)
+		}
+
+		b, i := objectStream.stream, 0
+		object, offset := 0, 0
+		for index := 0; index <= obj.offset; index++ {
+			val, n, err := pdfReadContentVal(b[i:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid stream: %w", err)
+			}
+			object, _ = val.(int)
+			i = moveWhiteSpace(b, i+n)
+
+			val, n, err = pdfReadContentVal(b[i:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid stream: %w", err)
+			}
+			offset, _ = val.(int)
+			i = moveWhiteSpace(b, i+n)
+		}
+		if uint32(object) != ref[0] {
+			return nil, fmt.Errorf("bad object %v: invalid index in compressed object", ref)
+		}
+
+		first, _ := r.GetInt(objectStream.dict["First"])
+		offset += first
+		if offset < 0 || len(b) <= offset {
+			return nil, fmt.Errorf("bad object %v: invalid offset in compressed object", ref)
+		}
+
+		// objects in object streams are not encrypted individually
+		val, _, err := pdfReadVal(r, noEncryptRef, b[offset:])
+		if err != nil {
+			return nil, fmt.Errorf("bad object %v: %w", ref, err)
+		}
+		r.cache[ref] = val
+		return val, nil
+	}
+	r.cache[ref] = nil // guard against self-reference
+	val, _, err := r.readObjectAt(ref, obj.offset)
+	r.cache[ref] = val
+	return val, err
+}
+
+// readObjectAt reads the object at the given offset and returns its value and the position after the value.
+func (r *pdfReader) readObjectAt(ref pdfIndirectRef, i int) (interface{}, int, error) {
+	b := r.data
+	if i < 0 || len(b) <= i {
+		return nil, 0, fmt.Errorf("bad object %v", ref)
+	}
+	val, n, err := pdfReadContentVal(b[i:])
+	if _, ok := val.(int); !ok || err != nil {
+		return nil, 0, fmt.Errorf("bad object %v", ref)
+	}
+	i = moveWhiteSpace(b, i+n)
+	val, n, err = pdfReadContentVal(b[i:])
+	if _, ok := val.(int); !ok || err != nil {
+		return nil, 0, fmt.Errorf("bad object %v", ref)
+	}
+	i = moveWhiteSpace(b, i+n)
+	if len(b) <= i+3 || !bytes.Equal(b[i:i+3], []byte("obj")) {
+		return nil, 0, fmt.Errorf("bad object %v", ref)
+	}
+	i = moveWhiteSpace(b, i+3)
+
+	if encryptRef, ok := r.trailer["Encrypt"].(pdfIndirectRef); ok && ref == encryptRef {
+		ref = noEncryptRef
+	}
+	val, n, err = pdfReadVal(r, ref, b[i:])
+	if err != nil {
+		return nil, 0, fmt.Errorf("bad object %v: %w", ref, err)
+	}
+	return val, i + n, nil
+}
+
+func pdfReadContentVal(b []byte) (interface{}, int, error) {
+	return pdfReadVal(nil, pdfIndirectRef{}, b)
+}
+
+// pdfReadRef reads the generation number and R keyword following an object number if present, and returns the indirect reference and the new position.
+func pdfReadRef(b []byte, i int, object int) (interface{}, int) {
+	val, n, err := pdfReadContentVal(b[i:])
+	if generation, ok := val.(int); ok && err == nil && 0 <= generation && 0 <= object {
+		j := moveWhiteSpace(b, i+n)
+		if j < len(b) && b[j] == 'R' && (j+1 == len(b) || !isRegular(b[j+1])) {
+			return pdfIndirectRef{uint32(object), uint32(generation)}, moveWhiteSpace(b, j+1)
+		}
+	}
+	return object, i
+}
+
+func pdfReadVal(r *pdfReader, ref pdfIndirectRef, b []byte) (interface{}, int, error) {
+	if len(b) == 0 {
+		return nil, 0, fmt.Errorf("bad value")
+	}
+	if '0' <= b[0] && b[0] <= '9' || b[0] == '+' || b[0] == '-' || b[0] == '.' {
+		isFloat := b[0] == '.'
+		i := 1
+		for i < len(b) && ('0' <= b[i] && b[i] <= '9' || b[i] == '.') {
+			if b[i] == '.' {
+				isFloat = true
+			}
+			i++
+		}
+		if i == 1 && (b[0] == '+' || b[0] == '-' || b[0] == '.') {
+			return nil, 0, fmt.Errorf("bad number")
+		} else if isFloat {
+			num, _ := strconv.ParseFloat(b[:i])
+			return num, i, nil
+		}
+		num, n := strconv.ParseInt(b[:i])
+		if n != i {
+			// integer overflow
+			num, _ := strconv.ParseFloat(b[:i])
+			return num, i, nil
+		}
+		return int(num), i, nil
+	} else if b[0] == '/' {
+		name, n, err := parseName(b[1:])
+		if err != nil {
+			return nil, 0, err
+		}
+		return pdfName(name), n + 1, nil
+	} else if b[0] == '[' {
+		i := moveWhiteSpace(b, 1)
+		array := pdfArray{}
+		for {
+			if len(b) <= i {
+				return nil, 0, fmt.Errorf("bad array")
+			} else if b[i] == ']' {
+				i++
+				break
+			} else if val, n, err := pdfReadVal(r, ref, b[i:]); err != nil {
+				return nil, 0, err
+			} else {
+				i = moveWhiteSpace(b, i+n)
+				if object, ok := val.(int); ok && r != nil {
+					val, i = pdfReadRef(b, i, object)
+				}
+				array = append(array, val)
+			}
+		}
+		return array, i, nil
+	} else if b[0] == '<' && 1 < len(b) && b[1] == '<' {
+		i := moveWhiteSpace(b, 2)
+		dict := pdfDict{}
+		for {
+			if len(b) <= i {
+				return nil, 0, fmt.Errorf("bad dict")
+			} else if i+1 < len(b) && b[i] == '>' && b[i+1] == '>' {
+				i += 2
+				break
+			}
+
+			val, n, err := pdfReadContentVal(b[i:])
+			key, ok := val.(pdfName)
+			if err != nil {
+				return nil, 0, err
+			} else if !ok {
+				return nil, 0, fmt.Errorf("bad dict")
+			}
+			i = moveWhiteSpace(b, i+n)
+
+			val, n, err = pdfReadVal(r, ref, b[i:])
+			if err != nil {
+				return nil, 0, err
+			}
+			i = moveWhiteSpace(b, i+n)
+			if object, ok := val.(int); ok && r != nil {
+				val, i = pdfReadRef(b, i, object)
+			}
+			if val != nil {
+				dict[key] = val
+			}
+		}
+		i = moveWhiteSpace(b, i)
+		if r != nil && i+7 < len(b) && bytes.Equal(b[i:i+6], []byte("stream")) {
+			i += 6
+			if b[i] == '\r' && i+1 < len(b) && b[i+1] == '\n' {
+				i++
+			}
+			if b[i] == '\n' || b[i] == '\r' {
+				i++
+			}
+
+			length, err := r.GetInt(dict["Length"])
+			if err != nil || length < 0 || len(b) < i+length || !bytes.HasPrefix(b[moveWhiteSpace(b, i+length):], []byte("endstream")) {
+				// bad or missing length, search for the end of the stream
+				end := bytes.Index(b[i:], []byte("endstream"))
+				if end == -1 {
+					return nil, 0, fmt.Errorf("bad stream")
+				}
+				length = end
+				for 0 < length && (b[i+length-1] == '\n' || b[i+length-1] == '\r') {
+					length--
+				}
+			}
+
+			data := b[i : i+length]
+			if r.encrypt.isEncrypted && ref != noEncryptRef {
+				data = r.encrypt.Decrypt(ref, data)
+			}
+			stream, err := r.decodeStream(dict, data)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			i = moveWhiteSpace(b, i+length)
+			i = moveWhiteSpace(b, i+9) // endstream
+			return stream, i, nil
+		}
+		return dict, i, nil
+	} else if b[0] == '(' {
+		var s []byte
+		j := 1 // start in b
+		i := 1
+		level := 0
+		for i < len(b) {
+			if b[i] == '(' {
+				level++
+			} else if b[i] == ')' {
+				if level == 0 {
+					break
+				}
+				level--
+			} else if i+1 < len(b) && b[i] == '\\' {
+				s = append(s, b[j:i]...)
+				if b[i+1] == 'n' {
+					s = append(s, '\n')
+					i++
+				} else if b[i+1] == 'r' {
+					s = append(s, '\r')
+					i++
+				} else if b[i+1] == 't' {
+					s = append(s, '\t')
+					i++
+				} else if b[i+1] == 'b' {
+					s = append(s, '\b')
+					i++
+				} else if b[i+1] == 'f' {
+					s = append(s, '\f')
+					i++
+				} else if b[i+1] == '(' || b[i+1] == ')' || b[i+1] == '\\' {
+					s = append(s, b[i+1])
+					i++
+				} else if '0' <= b[i+1] && b[i+1] <= '7' {
+					num := int(b[i+1] - '0')
+					i++
+					k := 1
+					for k < 3 && i+1 < len(b) && '0' <= b[i+1] && b[i+1] <= '7' {
+						num = (num * 8) + int(b[i+1]-'0')
+						i++
+						k++
+					}
+					s = append(s, byte(num))
+				} else if b[i+1] == '\r' || b[i+1] == '\n' {
+					// line continuation
+					i++
+					if b[i] == '\r' && i+1 < len(b) && b[i+1] == '\n' {
+						i++
+					}
+				}
+				j = i + 1 // +1 for backslash
+			}
+			i++
+		}
+		if i == len(b) || b[i] != ')' {
+			return nil, 0, fmt.Errorf("bad string")
+		}
+		s = append(s, b[j:i]...)
+		i++
+		if r != nil && r.encrypt.isEncrypted && ref != noEncryptRef {
+			s = r.encrypt.Decrypt(ref, s)
+		}
+		return s, i, nil
+	} else if b[0] == '<' {
+		i := 1
+		s := []byte{}
+		for i < len(b) && b[i] != '>' {
+			if '0' <= b[i] && b[i] <= '9' || 'a' <= b[i] && b[i] <= 'f' || 'A' <= b[i] && b[i] <= 'F' {
+				s = append(s, b[i])
+			} else if !isWhiteSpace(b[i]) {
+				return nil, 0, fmt.Errorf("bad string")
+			}
+			i++
+		}
+		if i == len(b) {
+			return nil, 0, fmt.Errorf("bad string")
+		}
+		i++
+		if len(s)%2 == 1 {
+			s = append(s, '0')
+		}
+		var err error
+		s, err = hex.DecodeString(string(s))
+		if r != nil && r.encrypt.isEncrypted && ref != noEncryptRef {
+			s = r.encrypt.Decrypt(ref, s)
+		}
+		return s, i, err
+	} else if 3 < len(b) && bytes.Equal(b[:4], []byte("true")) {
+		return true, 4, nil
+	} else if 4 < len(b) && bytes.Equal(b[:5], []byte("false")) {
+		return false, 5, nil
+	} else if 3 < len(b) && bytes.Equal(b[:4], []byte("null")) {
+		return nil, 4, nil
+	}
+	return nil, 0, fmt.Errorf("bad value")
+}
+
+// decodeStream applies the decoding filters of the stream. Image filters such as DCTDecode and JPXDecode are kept as the Filter entry and their data is returned undecoded.
+func (r *pdfReader) decodeStream(dict pdfDict, data []byte) (pdfStream, error) {
+	var filters []pdfName
+	var params []pdfDict
+	if filter, err := r.GetName(dict["Filter"]); err == nil {
+		filters = []pdfName{filter}
+	} else if array, err := r.GetArray(dict["Filter"]); err == nil {
+		for _, item := range array {
+			filter, err := r.GetName(item)
+			if err != nil {
+				return pdfStream{}, fmt.Errorf("bad stream filters")
+			}
+			filters = append(filters, filter)
+		}
+	}
+	if param, err := r.GetDict(dict["DecodeParms"]); err == nil {
+		params = []pdfDict{param}
+	} else if array, err := r.GetArray(dict["DecodeParms"]); err == nil {
+		for _, item := range array {
+			param, _ := r.GetDict(item)
+			params = append(params, param)
+		}
+	}
+
+	stream, err := decodeFilters(r, filters, params, dict, data)
+	if err != nil {
+		return pdfStream{}, err
+	}
+	return stream, nil
+}
+
+func decodeFilters(r *pdfReader, filters []pdfName, params []pdfDict, dict pdfDict, data []byte) (pdfStream, error) {
+	for i, filter := range filters {
+		var param pdfDict
+		if i < len(params) {
+			param = params[i]
+		}
+
+		var err error
+		switch filter {
+		case "ASCIIHexDecode", "AHx":
+			s := make([]byte, 0, len(data))
+			for _, c := range data {
+				if c == '>' {
+					break
+				} else if !isWhiteSpace(c) {
+					s = append(s, c)
+				}
+			}
+			if len(s)%2 == 1 {
+				s = append(s, '0')
+			}
+			if data, err = hex.DecodeString(string(s)); err != nil {
+				return pdfStream{}, err
+			}
+		case "ASCII85Decode", "A85":
+			if j := bytes.Index(data, []byte("~>")); j != -1 {
+				data = data[:j]
+			}
+			data = bytes.TrimPrefix(bytes.TrimSpace(data), []byte("<~"))
+			if data, err = io.ReadAll(ascii85.NewDecoder(bytes.NewReader(data))); err != nil {
+				return pdfStream{}, err
+			}
+		case "FlateDecode", "Fl":
+			zr, err := zlib.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return pdfStream{}, err
+			}
+			data, err = io.ReadAll(zr)
+			if err != nil && err != io.ErrUnexpectedEOF && len(data) == 0 {
+				return pdfStream{}, err
+			}
+			zr.Close()
+			if data, err = decodePredictor(r, param, data); err != nil {
+				return pdfStream{}, err
+			}
+		case "LZWDecode", "LZW":
+			// the early change parameter defaults to one, which is the TIFF variant of LZW
+			data, err = io.ReadAll(lzw.NewReader(bytes.NewReader(data), lzw.MSB, 8))
+			if err != nil && len(data) == 0 {
+				return pdfStream{}, err
+			}
+			if data, err = decodePredictor(r, param, data); err != nil {
+				return pdfStream{}, err
+			}
+		case "RunLengthDecode", "RL":
+			s := []byte{}
+			for j := 0; j < len(data); {
+				n := int(data[j])
+				if n == 128 {
+					break
+				} else if n < 128 {
+					end := j + 1 + n + 1
+					if len(data) < end {
+						end = len(data)
+					}
+					s = append(s, data[j+1:end]...)
+					j = end
+				} else if j+1 < len(data) {
+					for k := 0; k < 257-n; k++ {
+						s = append(s, data[j+1])
+					}
+					j += 2
+				} else {
+					break
+				}
+			}
+			data = s
+		case "DCTDecode", "DCT", "JPXDecode", "CCITTFaxDecode", "CCF", "JBIG2Decode":
+			// keep image filters, subsequent filters are not allowed
+			dict["Filter"] = filter
+			if param != nil {
+				dict["DecodeParms"] = param
+			} else {
+				delete(dict, "DecodeParms")
+			}
+			return pdfStream{dict, data}, nil
+		case "Crypt":
+			// identity crypt filter
+		default:
+			return pdfStream{}, fmt.Errorf("unsupported filter: %v", filter)
+		}
+	}
+	delete(dict, "Filter")
+	delete(dict, "DecodeParms")
+	return pdfStream{dict, data}, nil
+}
+
+// decodePredictor reverses the TIFF or PNG predictor applied before Flate or LZW compression.
+func decodePredictor(r *pdfReader, param pdfDict, b []byte) ([]byte, error) {
+	getInt := func(key pdfName, def int) int {
+		if r == nil {
+			if v, ok := param[key].(int); ok {
+				return v
+			}
+		} else if v, err := r.GetInt(param[key]); err == nil {
+			return v
+		}
+		return def
+	}
+
+	predictor := getInt("Predictor", 1)
+	if predictor == 1 {
+		return b, nil
+	}
+
+	columns := getInt("Columns", 1)
+	colors := getInt("Colors", 1)
+	bpc := getInt("BitsPerComponent", 8)
+	if columns < 1 || colors < 1 || 32 < colors || bpc != 1 && bpc != 2 && bpc != 4 && bpc != 8 && bpc != 16 {
+		return nil, fmt.Errorf("bad predictor parameters")
+	}
+	bpp := (colors*bpc + 7) / 8             // bytes per pixel, rounded up
+	rowSize := (colors*bpc*columns + 7) / 8 // bytes per row
+	if predictor == 2 {
+		// TIFF predictor
+		if bpc != 8 {
+			return nil, fmt.Errorf("unsupported TIFF predictor bits per component: %v", bpc)
+		}
+		for j := 0; j+rowSize <= len(b); j += rowSize {
+			for k := j + bpp; k < j+rowSize; k++ {
+				b[k] += b[k-bpp]
+			}
+		}
+		return b, nil
+	} else if predictor < 10 || 15 < predictor {
+		return nil, fmt.Errorf("unsupported predictor: %v", predictor)
+	}
+
+	// PNG predictors
+	n := len(b) / (rowSize + 1)
+	out := make([]byte, n*rowSize)
+	for j := 0; j < n; j++ {
+		src := b[j*(rowSize+1)+1 : (j+1)*(rowSize+1)]
+		dst := out[j*rowSize : (j+1)*rowSize]
+		var prev []byte
+		if 0 < j {
+			prev = out[(j-1)*rowSize : j*rowSize]
+		}
+		filter := b[j*(rowSize+1)]
+		for k := range dst {
+			A, B, C := 0, 0, 0 // left, above, above-left
+			if bpp <= k {
+				A = int(dst[k-bpp])
+			}
+			if prev != nil {
+				B = int(prev[k])
+				if bpp <= k {
+					C = int(prev[k-bpp])
+				}
+			}
+			switch filter {
+			case 0:
+				dst[k] = src[k]
+			case 1:
+				dst[k] = src[k] + byte(A)
+			case 2:
+				dst[k] = src[k] + byte(B)
+			case 3:
+				dst[k] = src[k] + byte(math.Floor(float64(A+B)/2.0))
+			case 4:
+				// Paeth predictor function
+				p := A + B - C
+				pa, pb, pc := abs(p-A), abs(p-B), abs(p-C)
+				if pa <= pb && pa <= pc {
+					p = A
+				} else if pb <= pc {
+					p = B
+				} else {
+					p = C
+				}
+				dst[k] = src[k] + byte(p)
+			default:
+				return nil, fmt.Errorf("bad PNG predictor filter: %v", filter)
+			}
+		}
+	}
+	return out, nil
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+type pdfStreamReader struct {
+	b []byte
+	i int
+}
+
+func newPDFStreamReader(b []byte) *pdfStreamReader {
+	return &pdfStreamReader{b, 0}
+}
+
+// Pos returns the position in the content stream after the last operator.
+func (r *pdfStreamReader) Pos() int {
+	return r.i
+}
+
+// Next returns the next operator and its operands of a content stream. Inline images are returned as the BI operator with the image as a pdfStream operand.
+func (r *pdfStreamReader) Next() (string, []interface{}, error) {
+	var vals []interface{}
+	r.i = moveWhiteSpace(r.b, r.i)
+	for r.i < len(r.b) {
+		if 'a' <= r.b[r.i] && r.b[r.i] <= 'z' || 'A' <= r.b[r.i] && r.b[r.i] <= 'Z' || r.b[r.i] == '\'' || r.b[r.i] == '"' {
+			name, n, err := parseName(r.b[r.i:])
+			if err != nil {
+				return "", nil, err
+			}
+			r.i += n
+			if keyword := string(name); keyword == "true" || keyword == "false" || keyword == "null" {
+				val, _, _ := pdfReadContentVal(name)
+				vals = append(vals, val)
+				r.i = moveWhiteSpace(r.b, r.i)
+				continue
+			} else if keyword == "BI" {
+				img, err := r.readInlineImage()
+				if err != nil {
+					return "", nil, err
+				}
+				return "BI", []interface{}{img}, nil
+			}
+			return string(name), vals, nil
+		}
+
+		val, n, err := pdfReadContentVal(r.b[r.i:])
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid stream: %w", err)
+		}
+		vals = append(vals, val)
+		r.i = moveWhiteSpace(r.b, r.i+n)
+	}
+	return "", nil, io.EOF
+}
+
+var inlineImageKeys = map[pdfName]pdfName{
+	"BPC": "BitsPerComponent",
+	"CS":  "ColorSpace",
+	"D":   "Decode",
+	"DP":  "DecodeParms",
+	"F":   "Filter",
+	"H":   "Height",
+	"IM":  "ImageMask",
+	"I":   "Interpolate",
+	"W":   "Width",
+	"L":   "Length",
+}
+
+func (r *pdfStreamReader) readInlineImage() (pdfStream, error) {
+	dict := pdfDict{}
+	for {
+		r.i = moveWhiteSpace(r.b, r.i)
+		if len(r.b) <= r.i+1 {
+			return pdfStream{}, fmt.Errorf("bad inline image")
+		} else if r.b[r.i] == 'I' && r.b[r.i+1] == 'D' {
+			r.i += 2
+			if r.i < len(r.b) && isWhiteSpace(r.b[r.i]) {
+				r.i++
+			}
+			break
+		}
+
+		val, n, err := pdfReadContentVal(r.b[r.i:])
+		key, ok := val.(pdfName)
+		if err != nil || !ok {
+			return pdfStream{}, fmt.Errorf("bad inline image")
+		}
+		r.i = moveWhiteSpace(r.b, r.i+n)
+		val, n, err = pdfReadContentVal(r.b[r.i:])
+		if err != nil {
+			return pdfStream{}, fmt.Errorf("bad inline image")
+		}
+		r.i += n
+		if long, ok := inlineImageKeys[key]; ok {
+			key = long
+		}
+		dict[key] = val
+	}
+
+	// find the EI operator that is surrounded by whitespace
+	start := r.i
+	for {
+		j := bytes.Index(r.b[r.i:], []byte("EI"))
+		if j == -1 {
+			return pdfStream{}, fmt.Errorf("bad inline image")
+		}
+		r.i += j + 2
+		if isWhiteSpace(r.b[r.i-3]) && (r.i == len(r.b) || isWhiteSpace(r.b[r.i])) {
+			break
+		}
+	}
+	data := r.b[start : r.i-3]
+
+	var filters []pdfName
+	var params []pdfDict
+	if filter, ok := dict["Filter"].(pdfName); ok {
+		filters = []pdfName{filter}
+	} else if array, ok := dict["Filter"].(pdfArray); ok {
+		for _, item := range array {
+			if filter, ok := item.(pdfName); ok {
+				filters = append(filters, filter)
+			}
+		}
+	}
+	if param, ok := dict["DecodeParms"].(pdfDict); ok {
+		params = []pdfDict{param}
+	} else if array, ok := dict["DecodeParms"].(pdfArray); ok {
+		for _, item := range array {
+			param, _ := item.(pdfDict)
+			params = append(params, param)
+		}
+	}
+	return decodeFilters(nil, filters, params, dict, data)
+}
+
+////////////////////////////////////////////////////////////////
+
+func isWhiteSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\f' || b == 0
+}
+
+func isDelimiter(b byte) bool {
+	return b == '(' || b == ')' || b == '<' || b == '>' || b == '[' || b == ']' || b == '{' || b == '}' || b == '/' || b == '%'
+}
+
+func isRegular(b byte) bool {
+	return !isWhiteSpace(b) && !isDelimiter(b)
+}
+
+func moveWhiteSpace(b []byte, i int) int {
+Again:
+	for i < len(b) && isWhiteSpace(b[i]) {
+		i++
+	}
+	if i < len(b) && b[i] == '%' {
+		for i < len(b) {
+			if b[i] == '\r' || b[i] == '\n' {
+				break
+			}
+			i++
+		}
+		goto Again
+	}
+	return i
+}
+
+func parseName(b []byte) ([]byte, int, error) {
+	var s []byte
+	j := 0 // start in b
+	i := 0 // position
+	for i < len(b) && '!' <= b[i] && b[i] <= '~' {
+		if isDelimiter(b[i]) {
+			break
+		} else if b[i] == '#' {
+			s = append(s, b[j:i]...)
+			if i+2 < len(b) {
+				s = append(s, 0)
+				_, err := hex.Decode(s[len(s)-1:], b[i+1:i+3])
+				if err != nil {
+					return nil, 0, fmt.Errorf("bad name")
+				}
+				i += 2
+				j = i + 1
+			} else {
+				return nil, 0, fmt.Errorf("bad name")
+			}
+		}
+		i++
+	}
+	return append(s, b[j:i]...), i, nil
+}
+
+type lineReader struct {
+	b      []byte
+	offset int
+}
+
+func newLineReader(b []byte, offset int) *lineReader {
+	return &lineReader{b, offset}
+}
+
+func (r *lineReader) Pos() int {
+	return r.offset
+}
+
+func (r *lineReader) Next() []byte {
+	for i := r.offset; i < len(r.b); i++ {
+		if r.b[i] == '\r' || r.b[i] == '\n' {
+			line := r.b[r.offset:i]
+			if i+1 < len(r.b) && r.b[i] == '\r' && r.b[i+1] == '\n' {
+				i++
+			}
+			i++
+			r.offset = i
+			return line
+		}
+	}
+	if r.offset < len(r.b) {
+		line := r.b[r.offset:]
+		r.offset = len(r.b)
+		return line
+	}
+	return nil
+}
+
+type lineReaderReverse struct {
+	b      []byte
+	offset int
+}
+
+func newLineReaderReverse(b []byte, offset int) *lineReaderReverse {
+	return &lineReaderReverse{b, offset}
+}
+
+func (r *lineReaderReverse) Next() []byte {
+	// skip empty lines
+	for 0 < r.offset && (r.b[r.offset-1] == '\r' || r.b[r.offset-1] == '\n') {
+		r.offset--
+	}
+	for i := r.offset - 1; 0 <= i; i-- {
+		if r.b[i] == '\r' || r.b[i] == '\n' {
+			line := r.b[i+1 : r.offset]
+			r.offset = i
+			return line
+		}
+	}
+	if 0 < r.offset {
+		line := r.b[:r.offset]
+		r.offset = 0
+		return line
+	}
+	return nil
+}
+
+func readNumberBE(b []byte, n int) uint32 {
+	num := uint32(0)
+	for i := 0; i < n; i++ {
+		num <<= 8
+		num += uint32(b[i])
+	}
+	return num
+}
@@ -1,9 +1,11 @@
 package pdf
 
 import (
+	"encoding/xml"
 	"fmt"
 	"math"
 	"strings"
+	"unicode/utf16"
 
 	"github.com/LaminoidStudio/Canvas"
 	"github.com/tdewolff/minify/v2"
@@ -26,6 +28,45 @@ func float64sEqual(a, b []float64) bool {
 	return true
 }
 
+// pdfNameEscape escapes the characters of a name that are not regular characters using the #xx notation.
+func pdfNameEscape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < '!' || '~' < c || c == '#' || strings.IndexByte("()<>[]{}/%", c) != -1 {
+			fmt.Fprintf(&b, "#%02X", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// xmlEscape escapes text for use in XML content.
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// pdfTextString encodes text strings that contain non-ASCII characters as UTF-16BE with a byte order mark.
+func pdfTextString(s string) string {
+	for _, r := range s {
+		if 0x80 <= r {
+			b := []byte{0xFE, 0xFF}
+			for _, c := range utf16.Encode([]rune(s)) {
+				b = append(b, byte(c>>8), byte(c))
+			}
+			return string(b)
+		}
+	}
+	return s
+}
+
+func pdfMatrix(m canvas.Matrix) pdfArray {
+	return pdfArray{m[0][0], m[1][0], m[0][1], m[1][1], m[0][2], m[1][2]}
+}
+
 type dec float64
 
 func (f dec) String() string {
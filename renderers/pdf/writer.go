@@ -3,6 +3,7 @@ package pdf
 import (
 	"bytes"
 	"compress/zlib"
+	"crypto/md5"
 	"encoding/ascii85"
 	"encoding/binary"
 	"fmt"
@@ -23,35 +24,87 @@ import (
 // TODO: Invalid Color space, The operator "g" can't be used without Color Profile
 
 type pdfWriter struct {
-	w   io.Writer
-	err error
+	w       io.Writer
+	err     error
+	fontErr error // error of embedding a font, which does not stop writing the document
 
 	pos        int
 	objOffsets []int
 	pages      []pdfRef
 
-	page       *pdfPageWriter
-	fontSubset map[*canvas.Font]*canvas.FontSubsetter
-	fontsH     map[*canvas.Font]pdfRef
-	fontsV     map[*canvas.Font]pdfRef
-	compress   bool
-	subset     bool
-	title      string
-	subject    string
-	keywords   string
-	author     string
-	creator    string
+	page        *pdfPageWriter
+	fontSubset  map[pdfFontKey]*canvas.FontSubsetter
+	fontsH      map[pdfFontKey]pdfRef
+	fontsV      map[pdfFontKey]pdfRef
+	iccProfiles map[*canvas.ICCProfile]pdfRef
+	patterns    map[pdfPatternKey]pdfRef
+	compress    bool
+	subset      bool
+	pdfa        bool
+	tagged      bool
+	title       string
+	subject     string
+	keywords    string
+	author      string
+	creator     string
+
+	annots   []pdfDict // link annotations of the current page
+	dests    []pdfDestination
+	outlines []pdfOutlineItem
+
+	structElems []pdfStructElem
+	mcid        int // next marked-content identifier of the current page
+}
+
+// pdfFontKey identifies an embedded font by the font and its variations, as each location in the design space of a variable font is embedded as a separate static font.
+type pdfFontKey struct {
+	font       *canvas.Font
+	variations string // empty for fonts that are not variable
+}
+
+func newPDFFontKey(font *canvas.Font) pdfFontKey {
+	return pdfFontKey{font, canvasFont.FormatVariations(font.SFNT.Variations())}
+}
+
+// pdfPatternKey identifies a tiling pattern by the pattern and its transformation, so that repeated use of a pattern is written only once.
+type pdfPatternKey struct {
+	pattern *canvas.Pattern
+	m       canvas.Matrix
+}
+
+// pdfDestination is a position on a page.
+type pdfDestination struct {
+	name string
+	page int // index of the page
+	x, y float64
+}
+
+// pdfStructElem is an element of the structure tree of a tagged PDF, its content consists of marked-content sequences on a page.
+type pdfStructElem struct {
+	tag   pdfName
+	alt   string
+	page  int
+	mcids []int
+}
+
+// pdfOutlineItem is an entry of the document outline (bookmarks) that points to a position on a page.
+type pdfOutlineItem struct {
+	title string
+	level int
+	pdfDestination
 }
 
 func newPDFWriter(writer io.Writer) *pdfWriter {
 	w := &pdfWriter{
-		w:          writer,
-		objOffsets: []int{0, 0, 0}, // catalog, metadata, page tree
-		fontSubset: map[*canvas.Font]*canvas.FontSubsetter{},
-		fontsH:     map[*canvas.Font]pdfRef{},
-		fontsV:     map[*canvas.Font]pdfRef{},
-		compress:   true,
-		subset:     true,
+		w:           writer,
+		objOffsets:  []int{0, 0, 0}, // catalog, metadata, page tree
+		fontSubset:  map[pdfFontKey]*canvas.FontSubsetter{},
+		fontsH:      map[pdfFontKey]pdfRef{},
+		fontsV:      map[pdfFontKey]pdfRef{},
+		iccProfiles: map[*canvas.ICCProfile]pdfRef{},
+		patterns:    map[pdfPatternKey]pdfRef{},
+		compress:    true,
+		subset:      true,
 	}
 
 	w.write("%%PDF-1.7\n%%Ŧǟċơ\n")
@@ -68,6 +121,16 @@ func (w *pdfWriter) SetFontSubsetting(subset bool) {
 	w.subset = subset
 }
 
+// SetPDFA enables PDF/A-2b conformant output, which embeds XMP metadata and an sRGB output intent.
+func (w *pdfWriter) SetPDFA(pdfa bool) {
+	w.pdfa = pdfa
+}
+
+// SetTagged enables the structure tree and marked content for text and images.
+func (w *pdfWriter) SetTagged(tagged bool) {
+	w.tagged = tagged
+}
+
 // SetTitle sets the document's title.
 func (w *pdfWriter) SetTitle(title string) {
 	w.title = title
@@ -128,6 +191,8 @@ const (
 
 func (w *pdfWriter) writeVal(i interface{}) {
 	switch v := i.(type) {
+	case nil:
+		w.write("null")
 	case bool:
 		if v {
 			w.write("true")
@@ -142,10 +207,17 @@ func (w *pdfWriter) writeVal(i interface{}) {
 		v = strings.Replace(v, `\`, `\\`, -1)
 		v = strings.Replace(v, `(`, `\(`, -1)
 		v = strings.Replace(v, `)`, `\)`, -1)
+		v = strings.Replace(v, "\r", `\r`, -1)
 		w.write("(%v)", v)
+	case []byte:
+		w.write("<%X>", v)
 	case pdfRef:
 		w.write("%v 0 R", v)
-	case pdfName, pdfFilter:
+	case pdfIndirectRef:
+		w.write("%v", v)
+	case pdfName:
+		w.write("/%v", pdfNameEscape(string(v)))
+	case pdfFilter:
 		w.write("/%v", v)
 	case pdfArray:
 		w.write("[")
@@ -233,38 +305,55 @@ func (w *pdfWriter) writeObject(val interface{}) pdfRef {
 	return pdfRef(len(w.objOffsets))
 }
 
-func (w *pdfWriter) getFont(font *canvas.Font, vertical bool) pdfRef {
+func (w *pdfWriter) getFont(key pdfFontKey, vertical bool) pdfRef {
 	fonts := w.fontsH
 	if vertical {
 		fonts = w.fontsV
 	}
 
-	if ref, ok := fonts[font]; ok {
+	if ref, ok := fonts[key]; ok {
 		return ref
 	}
 	w.objOffsets = append(w.objOffsets, 0)
 	ref := pdfRef(len(w.objOffsets))
-	fonts[font] = ref
+	fonts[key] = ref
 
-	w.fontSubset[font] = canvas.NewFontSubsetter()
+	if _, ok := w.fontSubset[key]; !ok {
+		w.fontSubset[key] = canvas.NewFontSubsetter()
+	}
 	return ref
 }
 
-func (w *pdfWriter) writeFont(ref pdfRef, font *canvas.Font, vertical bool) {
+func (w *pdfWriter) writeFont(ref pdfRef, key pdfFontKey, vertical bool) {
+	// bake the variations into a static font, as PDF does not support variable fonts, and fall back to the default instance on error so that the reserved font object is always written
+	font, sfnt := key.font, key.font.SFNT
+	if sfnt.IsVariable() {
+		variations, err := canvasFont.ParseVariations(key.variations)
+		if err == nil {
+			var instance *canvasFont.SFNT
+			if instance, err = sfnt.Instance(variations); err == nil {
+				sfnt = instance
+			}
+		}
+		if err != nil && w.fontErr == nil {
+			w.fontErr = fmt.Errorf("font %s: %w", font.Name(), err)
+		}
+	}
+
 	// subset the font
-	fontProgram := font.SFNT.Data
-	glyphIDs := w.fontSubset[font].List()
+	fontProgram := sfnt.Data
+	glyphIDs := w.fontSubset[key].List()
 	if w.subset {
 		// TODO: CFF font subsetting doesn't work
 		// TODO: remove all optional tables such as kern, GPOS, GSUB, ...
-		fontProgram, glyphIDs = font.SFNT.Subset(glyphIDs, canvasFont.WritePDFTables)
+		fontProgram, glyphIDs = sfnt.Subset(glyphIDs, canvasFont.WritePDFTables)
 	}
 
 	// calculate the character widths for the W array and shorten it
-	f := 1000.0 / float64(font.SFNT.Head.UnitsPerEm)
+	f := 1000.0 / float64(sfnt.Head.UnitsPerEm)
 	widths := make([]int, len(glyphIDs)+1)
 	for subsetGlyphID, glyphID := range glyphIDs {
-		widths[subsetGlyphID] = int(f*float64(font.SFNT.GlyphAdvance(glyphID)) + 0.5)
+		widths[subsetGlyphID] = int(f*float64(sfnt.GlyphAdvance(glyphID)) + 0.5)
 	}
 	DW := widths[0]
 	W := pdfArray{}
@@ -302,7 +391,7 @@ func (w *pdfWriter) writeFont(ref pdfRef, font *canvas.Font, vertical bool) {
 	startUnicode := uint32('\uFFFD')
 	length := uint16(1)
 	for subsetGlyphID, glyphID := range glyphIDs[1:] {
-		unicode := uint32(font.SFNT.Cmap.ToUnicode(glyphID))
+		unicode := uint32(sfnt.Cmap.ToUnicode(glyphID))
 		if 0x010000 <= unicode && unicode <= 0x10FFFF {
 			// UTF-16 surrogates
 			unicode -= 0x10000
@@ -313,8 +402,10 @@ func (w *pdfWriter) writeFont(ref pdfRef, font *canvas.Font, vertical bool) {
 		} else {
 			if 1 < length {
 				fmt.Fprintf(&bfRange, "<%04X> <%04X> <%04X>\n", startGlyphID, startGlyphID+length-1, startUnicode)
+				bfRangeCount++
 			} else {
 				fmt.Fprintf(&bfChar, "<%04X> <%04X>\n", startGlyphID, startUnicode)
+				bfCharCount++
 			}
 			startGlyphID = uint16(subsetGlyphID + 1)
 			startUnicode = unicode
@@ -323,8 +414,10 @@ func (w *pdfWriter) writeFont(ref pdfRef, font *canvas.Font, vertical bool) {
 	}
 	if 1 < length {
 		fmt.Fprintf(&bfRange, "<%04X> <%04X> <%04X>\n", startGlyphID, startGlyphID+length-1, startUnicode)
+		bfRangeCount++
 	} else {
 		fmt.Fprintf(&bfChar, "<%04X> <%04X>\n", startGlyphID, startUnicode)
+		bfCharCount++
 	}
 
 	toUnicode := fmt.Sprintf(`/CIDInit /ProcSet findresource begin
@@ -368,7 +461,7 @@ end`, bfRangeCount, bfRange.String(), bfCharCount, bfChar.String())
 
 	// get name and CID subtype
 	name := font.Name()
-	if records := font.SFNT.Name.Get(canvasFont.NamePostScript); 0 < len(records) {
+	if records := sfnt.Name.Get(canvasFont.NamePostScript); 0 < len(records) {
 		name = records[0].String()
 	}
 	baseFont := strings.ReplaceAll(name, " ", "")
@@ -382,9 +475,9 @@ end`, bfRangeCount, bfRange.String(), bfCharCount, bfChar.String())
 	}
 
 	cidSubtype := ""
-	if font.SFNT.IsTrueType {
+	if sfnt.IsTrueType {
 		cidSubtype = "CIDFontType2"
-	} else if font.SFNT.IsCFF {
+	} else if sfnt.IsCFF {
 		cidSubtype = "CIDFontType0"
 	}
 
@@ -412,15 +505,15 @@ end`, bfRangeCount, bfRange.String(), bfCharCount, bfChar.String())
 				"FontName": pdfName(baseFont),
 				"Flags":    4, // Symbolic
 				"FontBBox": pdfArray{
-					int(f * float64(font.SFNT.Head.XMin)),
-					int(f * float64(font.SFNT.Head.YMin)),
-					int(f * float64(font.SFNT.Head.XMax)),
-					int(f * float64(font.SFNT.Head.YMax)),
+					int(f * float64(sfnt.Head.XMin)),
+					int(f * float64(sfnt.Head.YMin)),
+					int(f * float64(sfnt.Head.XMax)),
+					int(f * float64(sfnt.Head.YMax)),
 				},
-				"ItalicAngle": float64(font.SFNT.Post.ItalicAngle),
-				"Ascent":      int(f * float64(font.SFNT.Hhea.Ascender)),
-				"Descent":     -int(f * float64(font.SFNT.Hhea.Descender)),
-				"CapHeight":   int(f * float64(font.SFNT.OS2.SCapHeight)),
+				"ItalicAngle": float64(sfnt.Post.ItalicAngle),
+				"Ascent":      int(f * float64(sfnt.Hhea.Ascender)),
+				"Descent":     -int(f * float64(sfnt.Hhea.Descender)),
+				"CapHeight":   int(f * float64(sfnt.OS2.SCapHeight)),
 				"StemV":       80, // taken from Inkscape, should be calculated somehow, maybe use: 10+220*(usWeightClass-50)/900
 				"FontFile3":   fontfileRef,
 			},
@@ -463,42 +556,78 @@ func (w *pdfWriter) Close() error {
 		kids = append(kids, page)
 	}
 
-	for font, ref := range w.fontsH {
-		w.writeFont(ref, font, false)
+	for key, ref := range w.fontsH {
+		w.writeFont(ref, key, false)
 	}
-	for font, ref := range w.fontsV {
-		w.writeFont(ref, font, true)
+	for key, ref := range w.fontsV {
+		w.writeFont(ref, key, true)
 	}
 
-	// document catalog
-	w.objOffsets[0] = w.pos
-	w.write("%v 0 obj\n", 1)
-	w.writeVal(pdfDict{
+	catalog := pdfDict{
 		"Type":  pdfName("Catalog"),
 		"Pages": pdfRef(3),
 		// TODO: add metadata?
-	})
+	}
+	if 0 < len(w.dests) {
+		catalog["Names"] = pdfDict{
+			"Dests": w.writeDestinations(),
+		}
+	}
+	if 0 < len(w.outlines) {
+		catalog["Outlines"] = w.writeOutlines()
+		catalog["PageMode"] = pdfName("UseOutlines")
+	}
+	if w.tagged {
+		catalog["MarkInfo"] = pdfDict{
+			"Marked": true,
+		}
+		catalog["StructTreeRoot"] = w.writeStructTree()
+	}
+
+	creationDate := time.Now()
+	if w.pdfa {
+		creationDate = creationDate.UTC()
+		catalog["Metadata"] = w.writeMetadata(creationDate)
+		catalog["OutputIntents"] = pdfArray{pdfDict{
+			"Type":                      pdfName("OutputIntent"),
+			"S":                         pdfName("GTS_PDFA1"),
+			"OutputConditionIdentifier": "sRGB IEC61966-2.1",
+			"Info":                      "sRGB IEC61966-2.1",
+			"DestOutputProfile": w.writeObject(pdfStream{
+				dict: pdfDict{
+					"N":      3,
+					"Filter": pdfFilterFlate,
+				},
+				stream: sRGBProfile(),
+			}),
+		}}
+	}
+
+	// document catalog
+	w.objOffsets[0] = w.pos
+	w.write("%v 0 obj\n", 1)
+	w.writeVal(catalog)
 	w.write("\nendobj\n")
 
 	// metadata
 	info := pdfDict{
 		"Producer":     "tdewolff/canvas",
-		"CreationDate": time.Now().Format("D:20060102150405Z0700"),
+		"CreationDate": creationDate.Format("D:20060102150405Z0700"),
 	}
 	if w.title != "" {
-		info["Title"] = w.title
+		info["Title"] = pdfTextString(w.title)
 	}
 	if w.subject != "" {
-		info["Subject"] = w.subject
+		info["Subject"] = pdfTextString(w.subject)
 	}
 	if w.keywords != "" {
-		info["Keywords"] = w.keywords
+		info["Keywords"] = pdfTextString(w.keywords)
 	}
 	if w.author != "" {
-		info["Author"] = w.author
+		info["Author"] = pdfTextString(w.author)
 	}
 	if w.creator != "" {
-		info["Creator"] = w.creator
+		info["Creator"] = pdfTextString(w.creator)
 	}
 
 	w.objOffsets[1] = w.pos
@@ -522,14 +651,267 @@ func (w *pdfWriter) Close() error {
 		w.write("%010d 00000 n \n", objOffset)
 	}
 	w.write("trailer\n")
-	w.writeVal(pdfDict{
+	trailer := pdfDict{
 		"Root": pdfRef(1),
 		"Size": len(w.objOffsets) + 1,
 		"Info": pdfRef(2),
-		// TODO: write document ID
-	})
+	}
+	if w.pdfa {
+		// the file identifier is required by PDF/A
+		id := fmt.Sprintf("%x", md5.Sum([]byte(fmt.Sprintf("%v %v %v", creationDate.UnixNano(), w.pos, w.title))))
+		trailer["ID"] = pdfArray{id, id}
+	}
+	w.writeVal(trailer)
 	w.write("\nstartxref\n%v\n%%%%EOF\n", xrefOffset)
-	return w.err
+	if w.err != nil {
+		return w.err
+	}
+	return w.fontErr
+}
+
+// writeMetadata writes the XMP metadata stream that identifies the PDF/A conformance level, its contents must be equivalent to the document information dictionary.
+func (w *pdfWriter) writeMetadata(creationDate time.Time) pdfRef {
+	var b strings.Builder
+	b.WriteString("<?xpacket begin=\"\uFEFF\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n")
+	b.WriteString(`<x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">`)
+	b.WriteString(`<rdf:Description rdf:about="" xmlns:pdfaid="http://www.aiim.org/pdfa/ns/id/" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:xmp="http://ns.adobe.com/xap/1.0/" xmlns:pdf="http://ns.adobe.com/pdf/1.3/">`)
+	b.WriteString(`<pdfaid:part>2</pdfaid:part><pdfaid:conformance>B</pdfaid:conformance>`)
+	fmt.Fprintf(&b, `<xmp:CreateDate>%v</xmp:CreateDate>`, creationDate.Format(time.RFC3339))
+	b.WriteString(`<pdf:Producer>tdewolff/canvas</pdf:Producer>`)
+	if w.title != "" {
+		fmt.Fprintf(&b, `<dc:title><rdf:Alt><rdf:li xml:lang="x-default">%v</rdf:li></rdf:Alt></dc:title>`, xmlEscape(w.title))
+	}
+	if w.subject != "" {
+		fmt.Fprintf(&b, `<dc:description><rdf:Alt><rdf:li xml:lang="x-default">%v</rdf:li></rdf:Alt></dc:description>`, xmlEscape(w.subject))
+	}
+	if w.keywords != "" {
+		fmt.Fprintf(&b, `<pdf:Keywords>%v</pdf:Keywords>`, xmlEscape(w.keywords))
+	}
+	if w.author != "" {
+		fmt.Fprintf(&b, `<dc:creator><rdf:Seq><rdf:li>%v</rdf:li></rdf:Seq></dc:creator>`, xmlEscape(w.author))
+	}
+	if w.creator != "" {
+		fmt.Fprintf(&b, `<xmp:CreatorTool>%v</xmp:CreatorTool>`, xmlEscape(w.creator))
+	}
+	b.WriteString("</rdf:Description></rdf:RDF></x:xmpmeta>\n<?xpacket end=\"w\"?>")
+
+	// the metadata stream must not be compressed so that it can be read by non-PDF tools
+	return w.writeObject(pdfStream{
+		dict: pdfDict{
+			"Type":    pdfName("Metadata"),
+			"Subtype": pdfName("XML"),
+		},
+		stream: []byte(b.String()),
+	})
+}
+
+// writeStructTree writes the structure tree root with a Document element that contains all structure elements, and the parent tree that maps the marked content of each page to its structure element.
+func (w *pdfWriter) writeStructTree() pdfRef {
+	// reserve references for the structure tree root, the document element and the structure elements
+	w.objOffsets = append(w.objOffsets, 0, 0)
+	root := pdfRef(len(w.objOffsets) - 1)
+	document := pdfRef(len(w.objOffsets))
+	refs := make([]pdfRef, len(w.structElems))
+	for i := range w.structElems {
+		w.objOffsets = append(w.objOffsets, 0)
+		refs[i] = pdfRef(len(w.objOffsets))
+	}
+
+	kids := pdfArray{}
+	parents := make([]pdfArray, len(w.pages)) // structure elements by MCID for each page
+	for i, elem := range w.structElems {
+		if elem.page < 0 || len(w.pages) <= elem.page || len(elem.mcids) == 0 {
+			continue
+		}
+		dict := pdfDict{
+			"Type": pdfName("StructElem"),
+			"S":    elem.tag,
+			"P":    document,
+			"Pg":   w.pages[elem.page],
+		}
+		if elem.alt != "" {
+			dict["Alt"] = pdfTextString(elem.alt)
+		}
+		if len(elem.mcids) == 1 {
+			dict["K"] = elem.mcids[0]
+		} else {
+			k := pdfArray{}
+			for _, mcid := range elem.mcids {
+				k = append(k, mcid)
+			}
+			dict["K"] = k
+		}
+		for _, mcid := range elem.mcids {
+			for len(parents[elem.page]) <= mcid {
+				parents[elem.page] = append(parents[elem.page], nil)
+			}
+			parents[elem.page][mcid] = refs[i]
+		}
+		kids = append(kids, refs[i])
+
+		w.objOffsets[refs[i]-1] = w.pos
+		w.write("%v 0 obj\n", refs[i])
+		w.writeVal(dict)
+		w.write("\nendobj\n")
+	}
+
+	w.objOffsets[document-1] = w.pos
+	w.write("%v 0 obj\n", document)
+	w.writeVal(pdfDict{
+		"Type": pdfName("StructElem"),
+		"S":    pdfName("Document"),
+		"P":    root,
+		"K":    kids,
+	})
+	w.write("\nendobj\n")
+
+	nums := pdfArray{}
+	for page, array := range parents {
+		if array != nil {
+			nums = append(nums, page, array)
+		}
+	}
+	parentTree := w.writeObject(pdfDict{
+		"Nums": nums,
+	})
+
+	w.objOffsets[root-1] = w.pos
+	w.write("%v 0 obj\n", root)
+	w.writeVal(pdfDict{
+		"Type":              pdfName("StructTreeRoot"),
+		"K":                 document,
+		"ParentTree":        parentTree,
+		"ParentTreeNextKey": len(w.pages),
+	})
+	w.write("\nendobj\n")
+	return root
+}
+
+// AddDestination defines a named destination at a position in millimeters on the current page.
+func (w *pdfWriter) AddDestination(name string, pos canvas.Point) {
+	for i, dest := range w.dests {
+		if dest.name == name {
+			w.dests = append(w.dests[:i], w.dests[i+1:]...)
+			break
+		}
+	}
+	w.dests = append(w.dests, pdfDestination{name, len(w.pages), pos.X * ptPerMm, pos.Y * ptPerMm})
+}
+
+// AddOutline adds an item to the document outline that points to a position in millimeters on the current page. The level is the nesting depth of the item, where zero is the top level.
+func (w *pdfWriter) AddOutline(title string, level int, pos canvas.Point) {
+	w.outlines = append(w.outlines, pdfOutlineItem{title, level, pdfDestination{"", len(w.pages), pos.X * ptPerMm, pos.Y * ptPerMm}})
+}
+
+func (w *pdfWriter) destination(dest pdfDestination) pdfArray {
+	if dest.page < 0 || len(w.pages) <= dest.page {
+		return nil
+	}
+	return pdfArray{w.pages[dest.page], pdfName("XYZ"), dest.x, dest.y, 0}
+}
+
+// writeDestinations writes the name tree of the named destinations.
+func (w *pdfWriter) writeDestinations() pdfRef {
+	sort.SliceStable(w.dests, func(i, j int) bool {
+		return w.dests[i].name < w.dests[j].name
+	})
+
+	names := pdfArray{}
+	for _, dest := range w.dests {
+		if array := w.destination(dest); array != nil {
+			names = append(names, dest.name, array)
+		}
+	}
+	return w.writeObject(pdfDict{
+		"Names": names,
+	})
+}
+
+// writeOutlines writes the outline items, where the level of each item determines its parent.
+func (w *pdfWriter) writeOutlines() pdfRef {
+	// reserve references for the outline dictionary and its items
+	root := pdfRef(len(w.objOffsets) + 1)
+	refs := make([]pdfRef, len(w.outlines))
+	w.objOffsets = append(w.objOffsets, 0)
+	for i := range w.outlines {
+		w.objOffsets = append(w.objOffsets, 0)
+		refs[i] = pdfRef(len(w.objOffsets))
+	}
+
+	// find the parent and children of each item, the level is at most one deeper than the previous item
+	parents := make([]int, len(w.outlines)) // -1 is the root
+	children := make([][]int, len(w.outlines)+1)
+	stack := []int{}
+	for i, item := range w.outlines {
+		level := item.level
+		if level < 0 {
+			level = 0
+		} else if len(stack) < level {
+			level = len(stack)
+		}
+		stack = append(stack[:level], i)
+		parents[i] = -1
+		if 0 < level {
+			parents[i] = stack[level-1]
+		}
+		children[parents[i]+1] = append(children[parents[i]+1], i)
+	}
+	var count func(int) int
+	count = func(i int) int {
+		n := len(children[i+1])
+		for _, child := range children[i+1] {
+			n += count(child)
+		}
+		return n
+	}
+	links := func(dict pdfDict, i int) {
+		if kids := children[i+1]; 0 < len(kids) {
+			dict["First"] = refs[kids[0]]
+			dict["Last"] = refs[kids[len(kids)-1]]
+			dict["Count"] = count(i)
+		}
+	}
+
+	for i, item := range w.outlines {
+		dict := pdfDict{
+			"Title":  pdfTextString(item.title),
+			"Parent": root,
+		}
+		if parents[i] != -1 {
+			dict["Parent"] = refs[parents[i]]
+		}
+		siblings := children[parents[i]+1]
+		for j, sibling := range siblings {
+			if sibling == i {
+				if 0 < j {
+					dict["Prev"] = refs[siblings[j-1]]
+				}
+				if j+1 < len(siblings) {
+					dict["Next"] = refs[siblings[j+1]]
+				}
+				break
+			}
+		}
+		if dest := w.destination(item.pdfDestination); dest != nil {
+			dict["Dest"] = dest
+		}
+		links(dict, i)
+
+		w.objOffsets[refs[i]-1] = w.pos
+		w.write("%v 0 obj\n", refs[i])
+		w.writeVal(dict)
+		w.write("\nendobj\n")
+	}
+
+	dict := pdfDict{
+		"Type": pdfName("Outlines"),
+	}
+	links(dict, -1)
+	w.objOffsets[root-1] = w.pos
+	w.write("%v 0 obj\n", root)
+	w.writeVal(dict)
+	w.write("\nendobj\n")
+	return root
 }
 
 type pdfPageWriter struct {
@@ -539,21 +921,28 @@ type pdfPageWriter struct {
 	resources     pdfDict
 
 	graphicsStates map[float64]pdfName
+	blendStates    map[canvas.BlendMode]pdfName
 	alpha          float64
-	fillColor      color.RGBA
-	strokeColor    color.RGBA
+	blendMode      canvas.BlendMode
+	fillColor      string // color operator
+	strokeColor    string
+	colorSpaces    map[string]pdfName
 	lineWidth      float64
 	lineCap        int
 	lineJoin       int
 	miterLimit     float64
 	dashes         []float64
 	font           *canvas.Font
+	fontKey        pdfFontKey
 	fontSize       float64
 	fontDirection  canvasText.Direction
 	inTextObject   bool
 	textPosition   canvas.Matrix
 	textCharSpace  float64
 	textRenderMode int
+
+	clips  []pdfPageWriter // graphics states saved by PushClip
+	groups []pdfPageWriter // page writer states saved by PushGroup
 }
 
 // NewPage starts a new page.
@@ -570,9 +959,12 @@ func (w *pdfWriter) NewPage(width, height float64) *pdfPageWriter {
 		height:         height,
 		resources:      pdfDict{},
 		graphicsStates: map[float64]pdfName{},
+		blendStates:    map[canvas.BlendMode]pdfName{},
 		alpha:          1.0,
-		fillColor:      canvas.Black,
-		strokeColor:    canvas.Black,
+		blendMode:      canvas.NormalBlend,
+		colorSpaces:    map[string]pdfName{},
+		fillColor:      "0 g",
+		strokeColor:    "0 G",
 		lineWidth:      1.0,
 		lineCap:        0,
 		lineJoin:       0,
@@ -593,6 +985,10 @@ func (w *pdfWriter) NewPage(width, height float64) *pdfPageWriter {
 }
 
 func (w *pdfPageWriter) writePage(parent pdfRef) pdfRef {
+	for range w.clips {
+		w.PopClip()
+	}
+
 	b := w.Bytes()
 	if 0 < len(b) && b[0] == ' ' {
 		b = b[1:]
@@ -605,7 +1001,7 @@ func (w *pdfPageWriter) writePage(parent pdfRef) pdfRef {
 		stream.dict["Filter"] = pdfFilterFlate
 	}
 	contents := w.pdf.writeObject(stream)
-	return w.pdf.writeObject(pdfDict{
+	page := pdfDict{
 		"Type":      pdfName("Page"),
 		"Parent":    parent,
 		"MediaBox":  pdfArray{0.0, 0.0, w.width * ptPerMm, w.height * ptPerMm},
@@ -617,7 +1013,249 @@ func (w *pdfPageWriter) writePage(parent pdfRef) pdfRef {
 			"CS":   pdfName("DeviceRGB"),
 		},
 		"Contents": contents,
+	}
+	if w.pdf.tagged {
+		page["StructParents"] = len(w.pdf.pages)
+		w.pdf.mcid = 0
+	}
+	if 0 < len(w.pdf.annots) {
+		annots := pdfArray{}
+		for _, annot := range w.pdf.annots {
+			annots = append(annots, w.pdf.writeObject(annot))
+		}
+		page["Annots"] = annots
+		w.pdf.annots = nil
+	}
+	return w.pdf.writeObject(page)
+}
+
+// AddLink adds a link annotation for the rectangle in millimeters to the page. A URI starting with # links to the named destination.
+func (w *pdfPageWriter) AddLink(uri string, rect canvas.Rect) {
+	annot := pdfDict{
+		"Type":    pdfName("Annot"),
+		"Subtype": pdfName("Link"),
+		"Rect":    pdfArray{rect.X * ptPerMm, rect.Y * ptPerMm, (rect.X + rect.W) * ptPerMm, (rect.Y + rect.H) * ptPerMm},
+		"Border":  pdfArray{0, 0, 0},
+	}
+	if w.pdf.pdfa {
+		annot["F"] = 4 // print
+	}
+	if strings.HasPrefix(uri, "#") {
+		annot["Dest"] = uri[1:]
+	} else {
+		annot["A"] = pdfDict{
+			"S":   pdfName("URI"),
+			"URI": uri,
+		}
+	}
+	w.pdf.annots = append(w.pdf.annots, annot)
+}
+
+// AddStructElem adds a structure element with a tag and an optional alternate description for the content of the current page and returns its index. It returns -1 if the document is not tagged or if content is being written to a transparency group, as content of form XObjects is not tagged.
+func (w *pdfPageWriter) AddStructElem(tag pdfName, alt string) int {
+	if !w.pdf.tagged || 0 < len(w.groups) {
+		return -1
+	}
+	w.pdf.structElems = append(w.pdf.structElems, pdfStructElem{
+		tag:  tag,
+		alt:  alt,
+		page: len(w.pdf.pages),
 	})
+	return len(w.pdf.structElems) - 1
+}
+
+// BeginMarkedContent starts a marked-content sequence that belongs to the structure element returned by AddStructElem. It does nothing for negative indices.
+func (w *pdfPageWriter) BeginMarkedContent(elem int) {
+	if elem < 0 {
+		return
+	}
+	w.pdf.structElems[elem].mcids = append(w.pdf.structElems[elem].mcids, w.pdf.mcid)
+	fmt.Fprintf(w, " /%v <</MCID %d>> BDC", w.pdf.structElems[elem].tag, w.pdf.mcid)
+	w.pdf.mcid++
+}
+
+// EndMarkedContent ends the marked-content sequence started by BeginMarkedContent.
+func (w *pdfPageWriter) EndMarkedContent(elem int) {
+	if elem < 0 {
+		return
+	}
+	fmt.Fprintf(w, " EMC")
+}
+
+// BeginArtifact starts a marked-content sequence of content that is not part of the structure tree, such as paths and decorations. It returns false if the document is not tagged.
+func (w *pdfPageWriter) BeginArtifact() bool {
+	if !w.pdf.tagged {
+		return false
+	}
+	fmt.Fprintf(w, " /Artifact BMC")
+	return true
+}
+
+// EndArtifact ends the marked-content sequence started by BeginArtifact.
+func (w *pdfPageWriter) EndArtifact() {
+	fmt.Fprintf(w, " EMC")
+}
+
+// PushClip saves the graphics state and intersects the clipping path with the given path, which must already be in PDF notation.
+func (w *pdfPageWriter) PushClip(data string, fillRule canvas.FillRule) {
+	w.clips = append(w.clips, *w)
+	fmt.Fprintf(w, " q %v W", data)
+	if fillRule == canvas.EvenOdd {
+		fmt.Fprintf(w, "*")
+	}
+	fmt.Fprintf(w, " n")
+}
+
+// PopClip restores the graphics state from before the last call to PushClip.
+func (w *pdfPageWriter) PopClip() {
+	if len(w.clips) == 0 {
+		return
+	}
+	fmt.Fprintf(w, " Q")
+	clips := w.clips[:len(w.clips)-1]
+	*w = w.clips[len(w.clips)-1]
+	w.clips = clips
+}
+
+// PushGroup starts a transparency group, all subsequent content is written to the group's content stream until PopGroup. The group has its own resources, so that the names used by its content are defined in its resource dictionary.
+func (w *pdfPageWriter) PushGroup() {
+	w.groups = append(w.groups, *w)
+	w.Buffer = &bytes.Buffer{}
+	w.resources = pdfDict{}
+	w.graphicsStates = map[float64]pdfName{}
+	w.blendStates = map[canvas.BlendMode]pdfName{}
+	w.colorSpaces = map[string]pdfName{}
+	w.clips = nil
+	w.alpha = 1.0 // the alpha constant and blend mode are reset at the start of a transparency group
+	w.blendMode = canvas.NormalBlend
+	w.font = nil // the font is selected again from the group's resources
+	w.fontKey = pdfFontKey{}
+}
+
+// PushMaskGroup starts a transparency group for a soft mask. Soft masks do not inherit the graphics state of the page, so the state is reset to its defaults explicitly.
+func (w *pdfPageWriter) PushMaskGroup() {
+	w.PushGroup()
+	fmt.Fprintf(w, " 0 g 0 G 1 w 0 J 0 j 10 M [] 0 d 0 Tc 0 Tr")
+	w.fillColor = "0 g"
+	w.strokeColor = "0 G"
+	w.lineWidth = 1.0
+	w.lineCap = 0
+	w.lineJoin = 0
+	w.miterLimit = 10.0
+	w.dashes = []float64{0.0}
+	w.font = nil
+	w.fontKey = pdfFontKey{}
+	w.textCharSpace = 0.0
+	w.textRenderMode = 0
+}
+
+// PopGroup ends the last started transparency group and writes it as a form XObject. The page writer state from before the group is restored.
+func (w *pdfPageWriter) PopGroup() pdfRef {
+	return w.popContent(pdfDict{
+		"Type":    pdfName("XObject"),
+		"Subtype": pdfName("Form"),
+		"BBox":    pdfArray{0.0, 0.0, w.width, w.height},
+		"Group": pdfDict{
+			"Type": pdfName("Group"),
+			"S":    pdfName("Transparency"),
+			"I":    true,
+			"CS":   pdfName("DeviceRGB"),
+		},
+	})
+}
+
+// PushPattern starts the content stream of a pattern cell, all subsequent content is written to the cell until PopPattern. Like soft masks, pattern cells do not inherit the graphics state of the page.
+func (w *pdfPageWriter) PushPattern() {
+	w.PushMaskGroup()
+}
+
+// PopPattern ends the content stream of the last started pattern cell and writes it as a colored tiling pattern, where cell is the rectangle of a single tile and m transforms the pattern to the page. The page writer state from before the pattern is restored.
+func (w *pdfPageWriter) PopPattern(cell canvas.Rect, m canvas.Matrix) pdfRef {
+	return w.popContent(pdfDict{
+		"Type":        pdfName("Pattern"),
+		"PatternType": 1,
+		"PaintType":   1,
+		"TilingType":  1,
+		"BBox":        pdfArray{cell.X, cell.Y, cell.X + cell.W, cell.Y + cell.H},
+		"XStep":       cell.W,
+		"YStep":       cell.H,
+		"Matrix":      pdfMatrix(canvas.Identity.Scale(ptPerMm, ptPerMm).Mul(m)),
+	})
+}
+
+// popContent writes the content stream started by PushGroup with the given stream dictionary and the resources used by the content, and restores the page writer state from before PushGroup.
+func (w *pdfPageWriter) popContent(dict pdfDict) pdfRef {
+	for range w.clips {
+		w.PopClip()
+	}
+
+	b := w.Bytes()
+	if 0 < len(b) && b[0] == ' ' {
+		b = b[1:]
+	}
+	dict["Resources"] = w.resources
+	stream := pdfStream{
+		dict:   dict,
+		stream: b,
+	}
+	if w.pdf.compress {
+		stream.dict["Filter"] = pdfFilterFlate
+	}
+	ref := w.pdf.writeObject(stream)
+
+	groups := w.groups[:len(w.groups)-1]
+	*w = w.groups[len(w.groups)-1]
+	w.groups = groups
+	return ref
+}
+
+// DrawGroup paints a transparency group written by PopGroup with the given opacity. If mask is non-zero, it is a reference to a soft mask group that is applied using the mask type.
+func (w *pdfPageWriter) DrawGroup(group pdfRef, opacity float64, mask pdfRef, maskType canvas.MaskType) {
+	if _, ok := w.resources["XObject"]; !ok {
+		w.resources["XObject"] = pdfDict{}
+	}
+	name := pdfName(fmt.Sprintf("X%d", len(w.resources["XObject"].(pdfDict))))
+	w.resources["XObject"].(pdfDict)[name] = group
+
+	if mask == 0 {
+		w.SetAlpha(opacity)
+		fmt.Fprintf(w, " /%v Do", name)
+		return
+	}
+
+	if _, ok := w.resources["ExtGState"]; !ok {
+		w.resources["ExtGState"] = pdfDict{}
+	}
+	gs := pdfName(fmt.Sprintf("G%d", len(w.resources["ExtGState"].(pdfDict))))
+	w.resources["ExtGState"].(pdfDict)[gs] = pdfDict{
+		"CA": opacity,
+		"ca": opacity,
+		"SMask": pdfDict{
+			"Type": pdfName("Mask"),
+			"S":    pdfName(maskType.String()),
+			"G":    mask,
+		},
+	}
+	fmt.Fprintf(w, " q /%v gs /%v Do Q", gs, name)
+}
+
+// SetBlendMode sets the blend mode.
+func (w *pdfPageWriter) SetBlendMode(mode canvas.BlendMode) {
+	if mode != w.blendMode {
+		name, ok := w.blendStates[mode]
+		if !ok {
+			if _, ok := w.resources["ExtGState"]; !ok {
+				w.resources["ExtGState"] = pdfDict{}
+			}
+			name = pdfName(fmt.Sprintf("B%d", len(w.blendStates)))
+			w.blendStates[mode] = name
+			w.resources["ExtGState"].(pdfDict)[name] = pdfDict{
+				"BM": pdfName(mode.String()),
+			}
+		}
+		fmt.Fprintf(w, " /%v gs", name)
+		w.blendMode = mode
+	}
 }
 
 // SetAlpha sets the transparency value.
@@ -631,30 +1269,123 @@ func (w *pdfPageWriter) SetAlpha(alpha float64) {
 
 // SetFillColor sets the filling color.
 func (w *pdfPageWriter) SetFillColor(fillColor color.RGBA) {
-	a := float64(fillColor.A) / 255.0
-	if fillColor != w.fillColor {
-		if fillColor.R == fillColor.G && fillColor.R == fillColor.B {
-			fmt.Fprintf(w, " %v g", dec(float64(fillColor.R)/255.0/a))
-		} else {
-			fmt.Fprintf(w, " %v %v %v rg", dec(float64(fillColor.R)/255.0/a), dec(float64(fillColor.G)/255.0/a), dec(float64(fillColor.B)/255.0/a))
-		}
-		w.fillColor = fillColor
+	w.SetFillNativeColor(fillColor, nil)
+}
+
+// SetFillNativeColor sets the filling color. If native is not nil, the color is written in its native color space, otherwise fillColor is used.
+func (w *pdfPageWriter) SetFillNativeColor(fillColor color.RGBA, native canvas.NativeColor) {
+	if op := w.colorOperator(fillColor, native, false); op != w.fillColor {
+		fmt.Fprintf(w, " %v", op)
+		w.fillColor = op
 	}
-	w.SetAlpha(a)
+	w.SetAlpha(float64(fillColor.A) / 255.0)
 }
 
 // SetStrokeColor sets the stroking color.
 func (w *pdfPageWriter) SetStrokeColor(strokeColor color.RGBA) {
-	a := float64(strokeColor.A) / 255.0
-	if strokeColor != w.strokeColor {
-		if strokeColor.R == strokeColor.G && strokeColor.R == strokeColor.B {
-			fmt.Fprintf(w, " %v G", dec(float64(strokeColor.R)/255.0/a))
+	w.SetStrokeNativeColor(strokeColor, nil)
+}
+
+// SetStrokeNativeColor sets the stroking color. If native is not nil, the color is written in its native color space, otherwise strokeColor is used.
+func (w *pdfPageWriter) SetStrokeNativeColor(strokeColor color.RGBA, native canvas.NativeColor) {
+	if op := w.colorOperator(strokeColor, native, true); op != w.strokeColor {
+		fmt.Fprintf(w, " %v", op)
+		w.strokeColor = op
+	}
+	w.SetAlpha(float64(strokeColor.A) / 255.0)
+}
+
+// colorOperator returns the operators that set the filling or stroking color. Spot colors and ICC colors are added as color space resources.
+func (w *pdfPageWriter) colorOperator(col color.RGBA, native canvas.NativeColor, stroke bool) string {
+	ops := []string{"g", "rg", "k", "cs", "scn"}
+	if stroke {
+		ops = []string{"G", "RG", "K", "CS", "SCN"}
+	}
+
+	switch c := native.(type) {
+	case canvas.CMYKColor:
+		return fmt.Sprintf("%v %v %v %v %v", dec(c.C), dec(c.M), dec(c.Y), dec(c.K), ops[2])
+	case canvas.SpotColor:
+		name := w.getSeparation(c)
+		return fmt.Sprintf("/%v %v %v %v", name, ops[3], dec(c.Tint), ops[4])
+	case canvas.ICCColor:
+		if c.Profile != nil {
+			name := w.getICCColorSpace(c.Profile)
+			var sb strings.Builder
+			fmt.Fprintf(&sb, "/%v %v", name, ops[3])
+			for i := 0; i < c.Profile.Components; i++ {
+				v := 0.0
+				if i < len(c.Components) {
+					v = c.Components[i]
+				}
+				fmt.Fprintf(&sb, " %v", dec(v))
+			}
+			fmt.Fprintf(&sb, " %v", ops[4])
+			return sb.String()
+		}
+	}
+
+	a := float64(col.A) / 255.0
+	if col.R == col.G && col.R == col.B {
+		return fmt.Sprintf("%v %v", dec(float64(col.R)/255.0/a), ops[0])
+	}
+	return fmt.Sprintf("%v %v %v %v", dec(float64(col.R)/255.0/a), dec(float64(col.G)/255.0/a), dec(float64(col.B)/255.0/a), ops[1])
+}
+
+// getSeparation returns the name of the Separation color space resource of a spot color, where the tint transform interpolates from white to the alternate color in DeviceCMYK or DeviceRGB.
+func (w *pdfPageWriter) getSeparation(spot canvas.SpotColor) pdfName {
+	alternate, white, full := pdfName("DeviceRGB"), pdfArray{1, 1, 1}, pdfArray{}
+	if cmyk, ok := spot.Alternate.(canvas.CMYKColor); ok {
+		alternate, white, full = pdfName("DeviceCMYK"), pdfArray{0, 0, 0, 0}, pdfArray{cmyk.C, cmyk.M, cmyk.Y, cmyk.K}
+	} else {
+		R, G, B, A := canvas.Black.RGBA()
+		if spot.Alternate != nil {
+			R, G, B, A = spot.Alternate.RGBA()
+		}
+		if A != 0 {
+			full = pdfArray{float64(R) / float64(A), float64(G) / float64(A), float64(B) / float64(A)}
 		} else {
-			fmt.Fprintf(w, " %v %v %v RG", dec(float64(strokeColor.R)/255.0/a), dec(float64(strokeColor.G)/255.0/a), dec(float64(strokeColor.B)/255.0/a))
+			full = white
 		}
-		w.strokeColor = strokeColor
 	}
-	w.SetAlpha(a)
+
+	key := fmt.Sprint("Separation ", spot.Name, alternate, full)
+	return w.getColorSpace(key, pdfArray{pdfName("Separation"), pdfName(spot.Name), alternate, pdfDict{
+		"FunctionType": 2,
+		"Domain":       pdfArray{0, 1},
+		"C0":           white,
+		"C1":           full,
+		"N":            1,
+	}})
+}
+
+// getICCColorSpace returns the name of the ICCBased color space resource of an ICC profile, the profile is embedded once per document.
+func (w *pdfPageWriter) getICCColorSpace(profile *canvas.ICCProfile) pdfName {
+	ref, ok := w.pdf.iccProfiles[profile]
+	if !ok {
+		ref = w.pdf.writeObject(pdfStream{
+			dict: pdfDict{
+				"N":      profile.Components,
+				"Filter": pdfFilterFlate,
+			},
+			stream: profile.Data,
+		})
+		w.pdf.iccProfiles[profile] = ref
+	}
+	return w.getColorSpace(fmt.Sprint("ICCBased ", ref), pdfArray{pdfName("ICCBased"), ref})
+}
+
+func (w *pdfPageWriter) getColorSpace(key string, colorSpace pdfArray) pdfName {
+	if name, ok := w.colorSpaces[key]; ok {
+		return name
+	}
+	if _, ok := w.resources["ColorSpace"]; !ok {
+		w.resources["ColorSpace"] = pdfDict{}
+	}
+	name := pdfName(fmt.Sprintf("CS%d", len(w.resources["ColorSpace"].(pdfDict))))
+	w.resources["ColorSpace"].(pdfDict)[name] = colorSpace
+	w.colorSpaces[key] = name
+	return name
 }
 
 // SetLineWidth sets the stroke width.
@@ -749,13 +1480,14 @@ func (w *pdfPageWriter) SetFont(font *canvas.Font, size float64, direction canva
 	if !w.inTextObject {
 		panic("must be in text object")
 	}
-	if font != w.font || w.fontSize != size || w.fontDirection != direction {
+	if key := newPDFFontKey(font); key != w.fontKey || w.fontSize != size || w.fontDirection != direction {
 		w.font = font
+		w.fontKey = key
 		w.fontSize = size
 		w.fontDirection = direction
 
 		vertical := direction == canvasText.TopToBottom || direction == canvasText.BottomToTop
-		ref := w.pdf.getFont(font, vertical)
+		ref := w.pdf.getFont(key, vertical)
 		if _, ok := w.resources["Font"]; !ok {
 			w.resources["Font"] = pdfDict{}
 		} else {
@@ -849,7 +1581,7 @@ func (w *pdfPageWriter) WriteText(mode canvas.WritingMode, TJ ...interface{}) {
 		} else {
 			fmt.Fprintf(w, " (")
 		}
-		subset := w.pdf.fontSubset[w.font]
+		subset := w.pdf.fontSubset[w.fontKey]
 		for _, glyph := range glyphs {
 			glyphID := subset.Get(glyph.ID)
 			for _, c := range []uint8{uint8((glyphID & 0xff00) >> 8), uint8(glyphID & 0x00ff)} {
@@ -984,7 +1716,7 @@ func (w *pdfPageWriter) embedImage(img image.Image, enc canvas.ImageEncoding) pd
 		"Height":           size.Y,
 		"ColorSpace":       pdfName("DeviceRGB"),
 		"BitsPerComponent": 8,
-		"Interpolate":      true,
+		"Interpolate":      !w.pdf.pdfa, // interpolation is not allowed by PDF/A
 		"Filter":           pdfFilterFlate,
 	}
 
@@ -997,7 +1729,7 @@ func (w *pdfPageWriter) embedImage(img image.Image, enc canvas.ImageEncoding) pd
 				"Height":           size.Y,
 				"ColorSpace":       pdfName("DeviceGray"),
 				"BitsPerComponent": 8,
-				"Interpolate":      true,
+				"Interpolate":      !w.pdf.pdfa,
 				"Filter":           pdfFilterFlate,
 			},
 			stream: bMask,
@@ -1018,6 +1750,214 @@ func (w *pdfPageWriter) embedImage(img image.Image, enc canvas.ImageEncoding) pd
 	return name
 }
 
+// SetFillGradient sets the filling paint to a gradient. The gradient is transformed by m, and rect is the area in the gradient's coordinate system that needs to be painted. It must be called within a saved graphics state (q and Q) as it does not update the cached graphics state.
+func (w *pdfPageWriter) SetFillGradient(gradient canvas.Gradient, m canvas.Matrix, rect canvas.Rect) {
+	name := w.getGradientPattern(gradient, m, rect)
+	fmt.Fprintf(w, " /Pattern cs /%v scn", name)
+	w.setGradientAlpha(gradient, m, rect)
+}
+
+// SetStrokeGradient sets the stroking paint to a gradient. The gradient is transformed by m, and rect is the area in the gradient's coordinate system that needs to be painted. It must be called within a saved graphics state (q and Q) as it does not update the cached graphics state.
+func (w *pdfPageWriter) SetStrokeGradient(gradient canvas.Gradient, m canvas.Matrix, rect canvas.Rect) {
+	name := w.getGradientPattern(gradient, m, rect)
+	fmt.Fprintf(w, " /Pattern CS /%v SCN", name)
+	w.setGradientAlpha(gradient, m, rect)
+}
+
+func (w *pdfPageWriter) getGradientPattern(gradient canvas.Gradient, m canvas.Matrix, rect canvas.Rect) pdfName {
+	shading := w.pdf.gradientShading(gradient.Padded(rect), false)
+	ref := w.pdf.writeObject(pdfDict{
+		"Type":        pdfName("Pattern"),
+		"PatternType": 2,
+		"Shading":     shading,
+		"Matrix":      pdfMatrix(canvas.Identity.Scale(ptPerMm, ptPerMm).Mul(m)),
+	})
+	return w.getPattern(ref)
+}
+
+// getPattern returns the name of the pattern resource that refers to the given pattern object.
+func (w *pdfPageWriter) getPattern(ref pdfRef) pdfName {
+	if _, ok := w.resources["Pattern"]; !ok {
+		w.resources["Pattern"] = pdfDict{}
+	}
+	patterns := w.resources["Pattern"].(pdfDict)
+	for name, val := range patterns {
+		if val == ref {
+			return name
+		}
+	}
+	name := pdfName(fmt.Sprintf("P%d", len(patterns)))
+	patterns[name] = ref
+	return name
+}
+
+// SetFillPattern sets the filling paint to a tiling pattern written by PopPattern. It must be called within a saved graphics state (q and Q) as it does not update the cached graphics state.
+func (w *pdfPageWriter) SetFillPattern(pattern pdfRef) {
+	fmt.Fprintf(w, " /Pattern cs /%v scn", w.getPattern(pattern))
+	if w.alpha != 1.0 {
+		fmt.Fprintf(w, " /%v gs", w.getOpacityGS(1.0))
+	}
+}
+
+// SetStrokePattern sets the stroking paint to a tiling pattern written by PopPattern. It must be called within a saved graphics state (q and Q) as it does not update the cached graphics state.
+func (w *pdfPageWriter) SetStrokePattern(pattern pdfRef) {
+	fmt.Fprintf(w, " /Pattern CS /%v SCN", w.getPattern(pattern))
+	if w.alpha != 1.0 {
+		fmt.Fprintf(w, " /%v gs", w.getOpacityGS(1.0))
+	}
+}
+
+// setGradientAlpha sets the opacity for painting a gradient. Since shadings only have color and no alpha, we use a soft mask with the gradient's alpha values as luminosity.
+func (w *pdfPageWriter) setGradientAlpha(gradient canvas.Gradient, m canvas.Matrix, rect canvas.Rect) {
+	if gradient.GradientStops().IsOpaque() {
+		if w.alpha != 1.0 {
+			fmt.Fprintf(w, " /%v gs", w.getOpacityGS(1.0))
+		}
+		return
+	}
+
+	bbox := rect.Transform(m)
+	shading := w.pdf.gradientShading(gradient.Padded(rect), true)
+	mask := w.pdf.writeObject(pdfStream{
+		dict: pdfDict{
+			"Type":    pdfName("XObject"),
+			"Subtype": pdfName("Form"),
+			"BBox":    pdfArray{bbox.X, bbox.Y, bbox.X + bbox.W, bbox.Y + bbox.H},
+			"Group": pdfDict{
+				"Type": pdfName("Group"),
+				"S":    pdfName("Transparency"),
+				"CS":   pdfName("DeviceGray"),
+			},
+			"Resources": pdfDict{
+				"Shading": pdfDict{
+					"Sh0": shading,
+				},
+			},
+		},
+		stream: []byte(fmt.Sprintf("%v %v %v %v %v %v cm /Sh0 sh", dec(m[0][0]), dec(m[1][0]), dec(m[0][1]), dec(m[1][1]), dec(m[0][2]), dec(m[1][2]))),
+	})
+
+	if _, ok := w.resources["ExtGState"]; !ok {
+		w.resources["ExtGState"] = pdfDict{}
+	}
+	name := pdfName(fmt.Sprintf("G%d", len(w.resources["ExtGState"].(pdfDict))))
+	w.resources["ExtGState"].(pdfDict)[name] = pdfDict{
+		"CA": 1.0,
+		"ca": 1.0,
+		"SMask": pdfDict{
+			"Type": pdfName("Mask"),
+			"S":    pdfName("Luminosity"),
+			"G":    mask,
+		},
+	}
+	fmt.Fprintf(w, " /%v gs", name)
+}
+
+// gradientShading returns an axial or radial shading dictionary for the gradient, which must use the pad spread method. If alpha is set, it returns the shading of the alpha values in the DeviceGray color space instead.
+func (w *pdfWriter) gradientShading(gradient canvas.Gradient, alpha bool) pdfDict {
+	colorSpace := pdfName("DeviceRGB")
+	stops := transparentStopColors(gradient.GradientStops())
+	components := func(col color.RGBA) pdfArray {
+		if col.A == 0 {
+			return pdfArray{0.0, 0.0, 0.0}
+		}
+		a := float64(col.A) / 255.0
+		return pdfArray{float64(col.R) / 255.0 / a, float64(col.G) / 255.0 / a, float64(col.B) / 255.0 / a}
+	}
+	if alpha {
+		colorSpace = pdfName("DeviceGray")
+		stops = gradient.GradientStops()
+		components = func(col color.RGBA) pdfArray {
+			return pdfArray{float64(col.A) / 255.0}
+		}
+	}
+
+	shading := pdfDict{
+		"ColorSpace": colorSpace,
+		"Function":   gradientFunction(stops, components),
+		"Extend":     pdfArray{true, true},
+	}
+	switch g := gradient.(type) {
+	case *canvas.LinearGradient:
+		shading["ShadingType"] = 2
+		shading["Coords"] = pdfArray{g.Start.X, g.Start.Y, g.End.X, g.End.Y}
+	case *canvas.RadialGradient:
+		shading["ShadingType"] = 3
+		shading["Coords"] = pdfArray{g.C0.X, g.C0.Y, g.R0, g.C1.X, g.C1.Y, g.R1}
+	default:
+		panic(fmt.Sprintf("PDF: gradient %T not supported", gradient))
+	}
+	return shading
+}
+
+// transparentStopColors replaces fully transparent stops by the colors of their neighbouring stops, which are placed at the same offset when there are neighbours on both sides. The alpha values are painted by a separate soft mask, and like in SVG and CSS, which interpolate in premultiplied form, a gradient to transparent thus doesn't pass through black.
+func transparentStopColors(stops canvas.Stops) canvas.Stops {
+	colors := make(canvas.Stops, 0, len(stops))
+	for i, stop := range stops {
+		if stop.Color.A != 0 {
+			colors = append(colors, stop)
+			continue
+		}
+		if 0 < i && stops[i-1].Color.A != 0 {
+			colors = append(colors, canvas.Stop{Offset: stop.Offset, Color: stops[i-1].Color})
+		}
+		if i+1 < len(stops) && stops[i+1].Color.A != 0 {
+			colors = append(colors, canvas.Stop{Offset: stop.Offset, Color: stops[i+1].Color})
+		}
+	}
+	return colors
+}
+
+// gradientFunction returns an exponential interpolation function for two color stops, or a stitching function of exponential interpolation functions for more color stops.
+func gradientFunction(stops canvas.Stops, components func(color.RGBA) pdfArray) pdfDict {
+	if len(stops) == 0 {
+		stops = canvas.Stops{{Offset: 0.0, Color: canvas.Transparent}}
+	}
+	if 0.0 < stops[0].Offset {
+		stops = append(canvas.Stops{{Offset: 0.0, Color: stops[0].Color}}, stops...)
+	}
+	if stops[len(stops)-1].Offset < 1.0 {
+		stops = append(stops, canvas.Stop{Offset: 1.0, Color: stops[len(stops)-1].Color})
+	}
+	if len(stops) == 1 {
+		stops = append(stops, canvas.Stop{Offset: 1.0, Color: stops[0].Color})
+	}
+
+	if len(stops) == 2 {
+		return pdfDict{
+			"FunctionType": 2,
+			"Domain":       pdfArray{0.0, 1.0},
+			"C0":           components(stops[0].Color),
+			"C1":           components(stops[1].Color),
+			"N":            1.0,
+		}
+	}
+
+	functions := pdfArray{}
+	bounds := pdfArray{}
+	encode := pdfArray{}
+	for i, stop := range stops[1:] {
+		functions = append(functions, pdfDict{
+			"FunctionType": 2,
+			"Domain":       pdfArray{0.0, 1.0},
+			"C0":           components(stops[i].Color),
+			"C1":           components(stop.Color),
+			"N":            1.0,
+		})
+		if i != 0 {
+			bounds = append(bounds, stops[i].Offset)
+		}
+		encode = append(encode, 0.0, 1.0)
+	}
+	return pdfDict{
+		"FunctionType": 3,
+		"Domain":       pdfArray{0.0, 1.0},
+		"Functions":    functions,
+		"Bounds":       bounds,
+		"Encode":       encode,
+	}
+}
+
 func (w *pdfPageWriter) getOpacityGS(a float64) pdfName {
 	if name, ok := w.graphicsStates[a]; ok {
 		return name
@@ -40,13 +40,23 @@ type PS struct {
 	width, height float64
 	opts          *Options
 
-	color      color.NRGBA
+	color      string // color operator
 	lineWidth  float64
 	miterLimit float64
 	lineCap    canvas.Capper
 	lineJoin   canvas.Joiner
 	dashOffset float64
 	dashes     []float64
+
+	clips     []PS // graphics states saved by PushClip
+	patterns  map[psPatternKey]string
+	inPattern bool // writing the paint procedure of a pattern
+}
+
+// psPatternKey identifies a pattern definition by the pattern and its transformation, so that repeated use of a pattern is written only once.
+type psPatternKey struct {
+	pattern *canvas.Pattern
+	m       canvas.Matrix
 }
 
 // New returns an PostScript renderer.
@@ -77,11 +87,16 @@ func New(w io.Writer, width, height float64, opts *Options) *PS {
 		width:      width,
 		height:     height,
 		opts:       opts,
+		color:      "0 setgray",
 		miterLimit: 10.0,
+		patterns:   map[psPatternKey]string{},
 	}
 }
 
 func (r *PS) Close() error {
+	for range r.clips {
+		r.PopClip()
+	}
 	if r.opts.Format == EncapsulatedPostScript {
 		fmt.Fprintf(r.w, "%%%%EOF")
 	}
@@ -89,14 +104,49 @@ func (r *PS) Close() error {
 }
 
 func (r *PS) setColor(col color.RGBA) {
-	color := toNRGBA(col)
-	if color.R != r.color.R || color.G != r.color.G || color.B != r.color.B {
+	r.setNativeColor(col, nil)
+}
+
+// setNativeColor sets the color, where CMYK and spot colors are written natively while other colors use RGB.
+func (r *PS) setNativeColor(col color.RGBA, native canvas.NativeColor) {
+	var op string
+	switch c := native.(type) {
+	case canvas.CMYKColor:
+		op = fmt.Sprintf("%v %v %v %v setcmykcolor", dec(c.C), dec(c.M), dec(c.Y), dec(c.K))
+	case canvas.SpotColor:
+		// the tint transform interpolates each component between white and the alternate color at full tint
+		space, white, full := "/DeviceRGB", []float64{1.0, 1.0, 1.0}, []float64{}
+		if cmyk, ok := c.Alternate.(canvas.CMYKColor); ok {
+			space, white, full = "/DeviceCMYK", []float64{0.0, 0.0, 0.0, 0.0}, []float64{cmyk.C, cmyk.M, cmyk.Y, cmyk.K}
+		} else if c.Alternate != nil {
+			alt := toNRGBA(c.Alternate)
+			full = []float64{float64(alt.R) / 255.0, float64(alt.G) / 255.0, float64(alt.B) / 255.0}
+		} else {
+			full = []float64{0.0, 0.0, 0.0}
+		}
+		var tint strings.Builder
+		for i := range white {
+			if i != 0 {
+				tint.WriteString(" ")
+			}
+			if i+1 < len(white) {
+				fmt.Fprintf(&tint, "dup %v mul %v add exch", dec(full[i]-white[i]), dec(white[i]))
+			} else {
+				fmt.Fprintf(&tint, "%v mul %v add", dec(full[i]-white[i]), dec(white[i]))
+			}
+		}
+		op = fmt.Sprintf("[/Separation (%v) %v {%v}] setcolorspace %v setcolor", escapeString(c.Name), space, tint.String(), dec(c.Tint))
+	default:
+		color := toNRGBA(col)
 		if color.R == color.G && color.R == color.B {
-			fmt.Fprintf(r.w, " %v setgray", dec(float64(color.R)/255.0))
+			op = fmt.Sprintf("%v setgray", dec(float64(color.R)/255.0))
 		} else {
-			fmt.Fprintf(r.w, " %v %v %v setrgbcolor", dec(float64(color.R)/255.0), dec(float64(color.G)/255.0), dec(float64(color.B)/255.0))
+			op = fmt.Sprintf("%v %v %v setrgbcolor", dec(float64(color.R)/255.0), dec(float64(color.G)/255.0), dec(float64(color.B)/255.0))
 		}
-		r.color = color
+	}
+	if op != r.color {
+		fmt.Fprintf(r.w, " %v", op)
+		r.color = op
 	}
 }
 
@@ -195,33 +245,82 @@ func (r *PS) RenderPath(path *canvas.Path, style canvas.Style, m canvas.Matrix)
 		}
 	}
 
+	if style.FillPattern != nil && style.FillPattern.Empty() {
+		style.FillColor, style.FillGradient, style.FillPattern = canvas.Transparent, nil, nil
+	}
+	if style.StrokePattern != nil && style.StrokePattern.Empty() {
+		style.StrokeColor, style.StrokeGradient, style.StrokePattern = canvas.Transparent, nil, nil
+	}
+
+	// patterns are defined before the path is constructed
+	fillPattern, strokePattern := "", ""
+	if style.HasFill() && style.FillPattern != nil {
+		fillPattern = r.definePattern(style.FillPattern, m)
+	}
+	if style.HasStroke() && style.StrokePattern != nil {
+		strokePattern = r.definePattern(style.StrokePattern, m)
+	}
+
+	bounds := canvas.Rect{}
+	if style.FillGradient != nil || style.StrokeGradient != nil {
+		bounds = path.Bounds()
+		bounds = canvas.Rect{X: bounds.X - style.StrokeWidth, Y: bounds.Y - style.StrokeWidth, W: bounds.W + 2.0*style.StrokeWidth, H: bounds.H + 2.0*style.StrokeWidth}
+	}
+
 	if style.HasFill() || style.HasStroke() && !strokeUnsupported {
 		r.w.Write([]byte("\n"))
 		r.w.Write([]byte(path.Transform(m).ToPS()))
 	}
 
 	if style.HasFill() {
-		r.setColor(style.FillColor)
-		if style.HasStroke() && !strokeUnsupported {
+		if fillPattern == "" && style.FillGradient != nil {
 			r.w.Write([]byte(" gsave"))
-		}
-		if style.FillRule == canvas.EvenOdd {
-			r.w.Write([]byte(" eofill"))
-		} else {
-			r.w.Write([]byte(" fill"))
-		}
-		if style.HasStroke() && !strokeUnsupported {
+			if style.FillRule == canvas.EvenOdd {
+				r.w.Write([]byte(" eoclip"))
+			} else {
+				r.w.Write([]byte(" clip"))
+			}
+			r.writeShading(style.FillGradient, m, bounds)
 			r.w.Write([]byte(" grestore"))
+			if !style.HasStroke() || strokeUnsupported {
+				r.w.Write([]byte(" newpath"))
+			}
+		} else {
+			if fillPattern != "" {
+				r.setPattern(fillPattern)
+			} else {
+				r.setNativeColor(style.FillColor, style.FillNative)
+			}
+			if style.HasStroke() && !strokeUnsupported {
+				r.w.Write([]byte(" gsave"))
+			}
+			if style.FillRule == canvas.EvenOdd {
+				r.w.Write([]byte(" eofill"))
+			} else {
+				r.w.Write([]byte(" fill"))
+			}
+			if style.HasStroke() && !strokeUnsupported {
+				r.w.Write([]byte(" grestore"))
+			}
 		}
 	}
 	if style.HasStroke() {
 		if !strokeUnsupported {
-			r.setColor(style.StrokeColor)
 			r.setLineWidth(style.StrokeWidth)
 			r.setLineCap(style.StrokeCapper)
 			r.setLineJoin(style.StrokeJoiner)
 			r.setDashes(style.DashOffset, style.Dashes)
-			r.w.Write([]byte(" stroke"))
+			if strokePattern != "" {
+				r.setPattern(strokePattern)
+				r.w.Write([]byte(" stroke"))
+			} else if style.StrokeGradient != nil {
+				r.w.Write([]byte(" gsave strokepath clip"))
+				r.writeShading(style.StrokeGradient, m, bounds)
+				r.w.Write([]byte(" grestore newpath"))
+			} else {
+				r.setNativeColor(style.StrokeColor, style.StrokeNative)
+				r.w.Write([]byte(" stroke"))
+			}
 		} else {
 			// stroke settings unsupported by PDF, draw stroke explicitly
 			if style.IsDashed() {
@@ -231,12 +330,153 @@ func (r *PS) RenderPath(path *canvas.Path, style canvas.Style, m canvas.Matrix)
 
 			r.w.Write([]byte("\n"))
 			r.w.Write([]byte(path.Transform(m).ToPS()))
-			r.setColor(style.StrokeColor)
-			r.w.Write([]byte(" fill"))
+			if strokePattern != "" {
+				r.setPattern(strokePattern)
+				r.w.Write([]byte(" fill"))
+			} else if style.StrokeGradient != nil {
+				r.w.Write([]byte(" gsave clip"))
+				r.writeShading(style.StrokeGradient, m, bounds)
+				r.w.Write([]byte(" grestore newpath"))
+			} else {
+				r.setNativeColor(style.StrokeColor, style.StrokeNative)
+				r.w.Write([]byte(" fill"))
+			}
 		}
 	}
 }
 
+// writeShading paints the gradient over the current clipping path, where m is the gradient's transformation and rect the area to cover in the gradient's coordinate system. PostScript does not support transparency, so alpha is ignored.
+func (r *PS) writeShading(gradient canvas.Gradient, m canvas.Matrix, rect canvas.Rect) {
+	gradient = gradient.Padded(rect)
+	fmt.Fprintf(r.w, " [%v %v %v %v %v %v] concat", dec(m[0][0]), dec(m[1][0]), dec(m[0][1]), dec(m[1][1]), dec(m[0][2]), dec(m[1][2]))
+	switch g := gradient.(type) {
+	case *canvas.LinearGradient:
+		fmt.Fprintf(r.w, "<</ShadingType 2 /Coords [%v %v %v %v]", dec(g.Start.X), dec(g.Start.Y), dec(g.End.X), dec(g.End.Y))
+	case *canvas.RadialGradient:
+		fmt.Fprintf(r.w, "<</ShadingType 3 /Coords [%v %v %v %v %v %v]", dec(g.C0.X), dec(g.C0.Y), dec(g.R0), dec(g.C1.X), dec(g.C1.Y), dec(g.R1))
+	default:
+		panic(fmt.Sprintf("PS: gradient %T not supported", gradient))
+	}
+	fmt.Fprintf(r.w, " /ColorSpace /DeviceRGB /Extend [true true] /Function ")
+	r.writeGradientFunction(gradient.GradientStops())
+	fmt.Fprintf(r.w, ">>shfill")
+}
+
+// definePattern defines a colored tiling pattern for the pattern transformed by m and returns its name. The pattern cell is rendered into the pattern's paint procedure, and each transformation of a pattern is defined only once.
+func (r *PS) definePattern(pattern *canvas.Pattern, m canvas.Matrix) string {
+	key := psPatternKey{pattern, m}
+	if name, ok := r.patterns[key]; ok {
+		return name
+	}
+	name := fmt.Sprintf("P%d", len(r.patterns))
+	r.patterns[key] = name
+
+	cell := pattern.Cell()
+	fmt.Fprintf(r.w, "\n/%v <</PatternType 1 /PaintType 1 /TilingType 1 /BBox [0 0 %v %v] /XStep %v /YStep %v /PaintProc {pop", name, dec(cell.W), dec(cell.H), dec(cell.W), dec(cell.H))
+
+	// the paint procedure starts with the graphics state at the time the pattern is defined
+	state := *r
+	r.clips = nil
+	r.inPattern = true
+	pattern.Canvas.RenderTo(r)
+	for range r.clips {
+		r.PopClip()
+	}
+	*r = state
+
+	m = m.Mul(pattern.Matrix)
+	fmt.Fprintf(r.w, "}>> [%v %v %v %v %v %v] makepattern def", dec(m[0][0]), dec(m[1][0]), dec(m[0][1]), dec(m[1][1]), dec(m[0][2]), dec(m[1][2]))
+	return name
+}
+
+// setPattern sets a pattern defined by definePattern as the current color.
+func (r *PS) setPattern(name string) {
+	fmt.Fprintf(r.w, " %v setpattern", name)
+	r.color = "" // pattern changes the color space
+}
+
+// writeGradientFunction writes an exponential interpolation function for two color stops, or a stitching function of exponential interpolation functions for more color stops.
+func (r *PS) writeGradientFunction(stops canvas.Stops) {
+	if len(stops) == 0 {
+		stops = canvas.Stops{{Offset: 0.0, Color: canvas.Transparent}}
+	}
+	if 0.0 < stops[0].Offset {
+		stops = append(canvas.Stops{{Offset: 0.0, Color: stops[0].Color}}, stops...)
+	}
+	if stops[len(stops)-1].Offset < 1.0 {
+		stops = append(stops, canvas.Stop{Offset: 1.0, Color: stops[len(stops)-1].Color})
+	}
+	if len(stops) == 1 {
+		stops = append(stops, canvas.Stop{Offset: 1.0, Color: stops[0].Color})
+	}
+
+	exponential := func(c0, c1 color.RGBA) string {
+		n0, n1 := toNRGBA(c0), toNRGBA(c1)
+		return fmt.Sprintf("<</FunctionType 2 /Domain [0 1] /C0 [%v %v %v] /C1 [%v %v %v] /N 1>>",
+			dec(float64(n0.R)/255.0), dec(float64(n0.G)/255.0), dec(float64(n0.B)/255.0),
+			dec(float64(n1.R)/255.0), dec(float64(n1.G)/255.0), dec(float64(n1.B)/255.0))
+	}
+	if len(stops) == 2 {
+		fmt.Fprint(r.w, exponential(stops[0].Color, stops[1].Color))
+		return
+	}
+
+	fmt.Fprintf(r.w, "<</FunctionType 3 /Domain [0 1] /Functions [")
+	for i, stop := range stops[1:] {
+		if i != 0 {
+			fmt.Fprintf(r.w, " ")
+		}
+		fmt.Fprint(r.w, exponential(stops[i].Color, stop.Color))
+	}
+	fmt.Fprintf(r.w, "] /Bounds [")
+	for i, stop := range stops[1 : len(stops)-1] {
+		if i != 0 {
+			fmt.Fprintf(r.w, " ")
+		}
+		fmt.Fprintf(r.w, "%v", dec(stop.Offset))
+	}
+	fmt.Fprintf(r.w, "] /Encode [")
+	for i := range stops[1:] {
+		if i != 0 {
+			fmt.Fprintf(r.w, " ")
+		}
+		fmt.Fprintf(r.w, "0 1")
+	}
+	fmt.Fprintf(r.w, "]>>")
+}
+
+// PushClip intersects the clipping region with a path using a fill rule and a transformation matrix.
+func (r *PS) PushClip(path *canvas.Path, fillRule canvas.FillRule, m canvas.Matrix) {
+	r.clips = append(r.clips, *r)
+	r.w.Write([]byte("\n"))
+	r.w.Write([]byte(path.Transform(m).ToPS()))
+	if fillRule == canvas.EvenOdd {
+		r.w.Write([]byte(" gsave eoclip newpath"))
+	} else {
+		r.w.Write([]byte(" gsave clip newpath"))
+	}
+}
+
+// PopClip removes the last pushed clipping path.
+func (r *PS) PopClip() {
+	if len(r.clips) == 0 {
+		return
+	}
+	fmt.Fprintf(r.w, " grestore")
+	clips := r.clips[:len(r.clips)-1]
+	*r = r.clips[len(r.clips)-1]
+	r.clips = clips
+}
+
+// PushGroup starts a transparency group. PostScript does not support transparency, so the group's content is drawn directly.
+func (r *PS) PushGroup(group canvas.Group, m canvas.Matrix) {
+	// TODO: (EPS) use dither to fake transparency
+}
+
+// PopGroup ends the last started transparency group.
+func (r *PS) PopGroup() {
+}
+
 // RenderText renders a text object to the canvas using a transformation matrix.
 func (r *PS) RenderText(text *canvas.Text, m canvas.Matrix) {
 	// TODO: (EPS) write text natively
@@ -275,14 +515,23 @@ func (r *PS) RenderImage(img image.Image, m canvas.Matrix) {
 	fmt.Fprintf(r.w, "<</ImageType 1 /BitsPerComponent 8 /Decode [0 1 0 1 0 1] /Interpolate true")
 	fmt.Fprintf(r.w, " /Width %d /Height %d", size.X, size.Y)
 	fmt.Fprintf(r.w, " /ImageMatrix [%d %d %d %d %d %d]", size.X, 0, 0, -size.Y, 0, size.Y)
-	fmt.Fprintf(r.w, " /DataSource currentfile /ASCII85Decode filter /FlateDecode filter>>image\n")
+	if r.inPattern {
+		// the data cannot be read from the file within the paint procedure of a pattern, so we write it as a string
+		fmt.Fprintf(r.w, " /DataSource <~")
+	} else {
+		fmt.Fprintf(r.w, " /DataSource currentfile /ASCII85Decode filter /FlateDecode filter>>image\n")
+	}
 
 	wAscii := ascii85.NewEncoder(r.w)
 	wZlib := zlib.NewWriter(wAscii)
 	wZlib.Write(b)
 	wZlib.Close()
 	wAscii.Close()
-	fmt.Fprintf(r.w, "~>\n")
+	if r.inPattern {
+		fmt.Fprintf(r.w, "~> /FlateDecode filter>>image")
+	} else {
+		fmt.Fprintf(r.w, "~>\n")
+	}
 	fmt.Fprintf(r.w, " grestore")
 }
 
@@ -2,9 +2,11 @@ package ps
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/LaminoidStudio/Canvas"
+	"github.com/tdewolff/test"
 )
 
 func TestPS(t *testing.T) {
@@ -13,3 +15,38 @@ func TestPS(t *testing.T) {
 	ps.setColor(canvas.Red)
 	//test.String(t, string(w.Bytes()), "")
 }
+
+func TestPSNativeColor(t *testing.T) {
+	style := canvas.DefaultStyle
+	style.FillNative = canvas.CMYK(0.0, 1.0, 1.0, 0.0)
+	style.StrokeColor = canvas.Black
+	style.StrokeNative = canvas.NewSpotColor("PANTONE (185) C", canvas.CMYK(0.0, 0.9, 0.8, 0.0), 0.5)
+	style.StrokeWidth = 1.0
+
+	w := &bytes.Buffer{}
+	ps := New(w, 100, 80, nil)
+	ps.RenderPath(canvas.Rectangle(10.0, 10.0), style, canvas.Identity)
+	out := w.String()
+	test.That(t, strings.Contains(out, " 0 1 1 0 setcmykcolor"), "could not find CMYK color in output")
+	test.That(t, strings.Contains(out, " [/Separation (PANTONE \\(185\\) C) /DeviceCMYK {dup 0 mul 0 add exch dup .9 mul 0 add exch dup .8 mul 0 add exch 0 mul 0 add}] setcolorspace .5 setcolor"), "could not find spot color in output")
+}
+
+func TestPSPattern(t *testing.T) {
+	cell := canvas.New(2.0, 2.0)
+	ctx := canvas.NewContext(cell)
+	ctx.SetFillColor(canvas.Red)
+	ctx.DrawPath(0.0, 0.0, canvas.Rectangle(1.0, 1.0))
+
+	style := canvas.DefaultStyle
+	style.FillPattern = canvas.NewPattern(cell, canvas.Identity.Rotate(90.0), 1.0, 0.0)
+
+	w := &bytes.Buffer{}
+	ps := New(w, 100, 80, nil)
+	ps.RenderPath(canvas.Rectangle(10.0, 10.0), style, canvas.Identity)
+	ps.RenderPath(canvas.Rectangle(5.0, 5.0), style, canvas.Identity.Translate(10.0, 0.0))
+	out := w.String()
+	test.T(t, strings.Count(out, "makepattern"), 2)
+	test.That(t, strings.Contains(out, "\n/P0 <</PatternType 1 /PaintType 1 /TilingType 1 /BBox [0 0 3 2] /XStep 3 /YStep 2 /PaintProc {pop\n0 0 moveto 1 0 lineto 1 1 lineto 0 1 lineto closepath 1 0 0 setrgbcolor fill}>> [0 1 -1 0 0 0] makepattern def"), "could not find pattern definition in output")
+	test.That(t, strings.Contains(out, " P0 setpattern fill"), "could not find pattern fill in output")
+	test.That(t, strings.Contains(out, "[0 1 -1 0 10 0] makepattern def"), "could not find translated pattern definition in output")
+}
@@ -3,11 +3,11 @@ package rasterizer
 import (
 	"image"
 	"image/color"
+	"math"
 
 	"github.com/LaminoidStudio/Canvas"
 	"golang.org/x/image/draw"
 	"golang.org/x/image/math/f64"
-	"golang.org/x/image/vector"
 )
 
 // Draw draws the canvas on a new image with given resolution (in dots-per-millimeter). Higher resolution will result in larger images.
@@ -24,6 +24,19 @@ type Rasterizer struct {
 	draw.Image
 	resolution canvas.Resolution
 	colorSpace canvas.ColorSpace
+	clips      []*image.Alpha // clipping masks, where each is the intersection with the previous
+	groups     []rasterizerGroup
+
+	scanner scanner
+	mask    image.Alpha // coverage of the current path, its buffer is reused between paths
+}
+
+// rasterizerGroup is a transparency group, which saves the destination image and the number of clipping masks from before the group.
+type rasterizerGroup struct {
+	canvas.Group
+	m     canvas.Matrix
+	dst   draw.Image
+	clips int
 }
 
 // New returns a renderer that draws to a rasterized image. By default the linear color space is used, which assumes input and output colors are in linearRGB. If the sRGB color space is used for drawing with an average of gamma=2.2, the input and output colors are assumed to be in sRGB (a common assumption) and blending happens in linearRGB. Be aware that for text this results in thin stems for black-on-white (but wide stems for white-on-black).
@@ -44,7 +57,15 @@ func FromImage(img draw.Image, resolution canvas.Resolution, colorSpace canvas.C
 	}
 }
 
+// SetAntialiasing sets the anti-aliasing method used for rasterizing paths, clipping paths and text. The default is Analytic.
+func (r *Rasterizer) SetAntialiasing(aa Antialiasing) {
+	r.scanner.aa = aa
+}
+
 func (r *Rasterizer) Close() {
+	for range r.groups {
+		r.PopGroup()
+	}
 	if _, ok := r.colorSpace.(canvas.LinearColorSpace); !ok {
 		// gamma compress
 		changeColorSpace(r.Image, r.Image, r.colorSpace.FromLinear)
@@ -59,7 +80,6 @@ func (r *Rasterizer) Size() (float64, float64) {
 
 // RenderPath renders a path to the canvas using a style and a transformation matrix.
 func (r *Rasterizer) RenderPath(path *canvas.Path, style canvas.Style, m canvas.Matrix) {
-	// TODO: use fill rule (EvenOdd, NonZero) for rasterizer
 	fill := path
 	stroke := path
 	bounds := canvas.Rect{}
@@ -79,22 +99,17 @@ func (r *Rasterizer) RenderPath(path *canvas.Path, style canvas.Style, m canvas.
 	}
 
 	size := r.Bounds().Size()
-	dx, dy := 0, 0
 	dpmm := r.resolution.DPMM()
-	x := int(bounds.X * dpmm)
-	y := int(bounds.Y * dpmm)
-	w := int(bounds.W*dpmm) + 1
-	h := int(bounds.H*dpmm) + 1
-	if (x+w <= 0 || size.X <= x) && (y+h <= 0 || size.Y <= y) {
-		return // outside canvas
-	}
-
+	x := int(math.Floor(bounds.X * dpmm))
+	y := int(math.Floor(bounds.Y * dpmm))
+	w := int(math.Ceil((bounds.X+bounds.W)*dpmm)) - x + 1
+	h := int(math.Ceil((bounds.Y+bounds.H)*dpmm)) - y + 1
 	if x < 0 {
-		dx = -x
+		w += x
 		x = 0
 	}
 	if y < 0 {
-		dy = -y
+		h += y
 		y = 0
 	}
 	if size.X <= x+w {
@@ -104,22 +119,290 @@ func (r *Rasterizer) RenderPath(path *canvas.Path, style canvas.Style, m canvas.
 		h = size.Y - y
 	}
 	if w <= 0 || h <= 0 {
-		return // has no size
+		return // outside canvas or has no size
 	}
 
+	rect := image.Rect(x, size.Y-y-h, x+w, size.Y-y)
 	if style.HasFill() {
-		ras := vector.NewRasterizer(w, h)
-		fill = fill.Translate(-float64(x)/dpmm, -float64(y)/dpmm)
-		fill.ToRasterizer(ras, r.resolution)
-		col := r.colorSpace.ToLinear(style.FillColor)
-		ras.Draw(r.Image, image.Rect(x, size.Y-y, x+w, size.Y-y-h), image.NewUniform(col), image.Point{dx, dy})
+		r.rasterize(fill, style.FillRule, x, y, w, h)
+		if style.FillPattern != nil {
+			r.draw(rect, r.patternImage(style.FillPattern, m), rect.Min, style.BlendMode)
+		} else if style.FillGradient != nil {
+			r.draw(rect, r.gradientImage(style.FillGradient, m), rect.Min, style.BlendMode)
+		} else {
+			col := r.colorSpace.ToLinear(style.FillColor)
+			r.draw(rect, image.NewUniform(col), image.Point{}, style.BlendMode)
+		}
 	}
 	if style.HasStroke() {
-		ras := vector.NewRasterizer(w, h)
-		stroke = stroke.Translate(-float64(x)/dpmm, -float64(y)/dpmm)
-		stroke.ToRasterizer(ras, r.resolution)
-		col := r.colorSpace.ToLinear(style.StrokeColor)
-		ras.Draw(r.Image, image.Rect(x, size.Y-y, x+w, size.Y-y-h), image.NewUniform(col), image.Point{dx, dy})
+		r.rasterize(stroke, canvas.NonZero, x, y, w, h)
+		if style.StrokePattern != nil {
+			r.draw(rect, r.patternImage(style.StrokePattern, m), rect.Min, style.BlendMode)
+		} else if style.StrokeGradient != nil {
+			r.draw(rect, r.gradientImage(style.StrokeGradient, m), rect.Min, style.BlendMode)
+		} else {
+			col := r.colorSpace.ToLinear(style.StrokeColor)
+			r.draw(rect, image.NewUniform(col), image.Point{}, style.BlendMode)
+		}
+	}
+}
+
+// rasterize computes the coverage of the path using the fill rule within the region of w×h pixels whose bottom-left corner is at (x,y) in pixels, and stores it in the reused mask.
+func (r *Rasterizer) rasterize(path *canvas.Path, fillRule canvas.FillRule, x, y, w, h int) {
+	if n := w * h; cap(r.mask.Pix) < n {
+		r.mask.Pix = make([]uint8, n)
+	} else {
+		r.mask.Pix = r.mask.Pix[:n]
+	}
+	r.mask.Stride = w
+	r.mask.Rect = image.Rect(0, 0, w, h)
+
+	r.scanner.Reset(w, h)
+	r.scanner.AddPath(path, r.resolution.DPMM(), float64(x), float64(y))
+	r.scanner.Draw(&r.mask, fillRule)
+}
+
+// draw composites src onto the image within rect using the rasterized path as mask and the blend mode, where sp is the point in src that aligns with rect.Min. The current clipping mask is applied as well.
+func (r *Rasterizer) draw(rect image.Rectangle, src image.Image, sp image.Point, mode canvas.BlendMode) {
+	if 0 < len(r.clips) {
+		clip := r.clips[len(r.clips)-1]
+		for j := 0; j < rect.Dy(); j++ {
+			pix := r.mask.Pix[j*r.mask.Stride : j*r.mask.Stride+rect.Dx()]
+			k := clip.PixOffset(rect.Min.X, rect.Min.Y+j)
+			for i, a := range pix {
+				pix[i] = uint8((uint32(a)*uint32(clip.Pix[k+i]) + 127) / 255)
+			}
+		}
+	}
+	if mode == canvas.NormalBlend {
+		draw.DrawMask(r.Image, rect, src, sp, &r.mask, image.Point{}, draw.Over)
+		return
+	}
+	r.blend(rect, src, sp, &r.mask, image.Point{}, mode)
+}
+
+// blend composites src through mask onto the image within rect using the blend mode, where sp and mp are the points in src and mask that align with rect.Min. Blending takes place in the color space of the output image, which is also where PDF viewers and browsers blend colors, whereas the image is kept in the linear color space.
+func (r *Rasterizer) blend(rect image.Rectangle, src image.Image, sp image.Point, mask *image.Alpha, mp image.Point, mode canvas.BlendMode) {
+	_, linear := r.colorSpace.(canvas.LinearColorSpace)
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			a := uint32(mask.AlphaAt(mp.X+x-rect.Min.X, mp.Y+y-rect.Min.Y).A)
+			if a == 0 {
+				continue
+			}
+			R, G, B, A := src.At(sp.X+x-rect.Min.X, sp.Y+y-rect.Min.Y).RGBA()
+			if A == 0 {
+				continue
+			}
+			a |= a << 8
+			source := color.RGBA{uint8(R * a / 0xffff >> 8), uint8(G * a / 0xffff >> 8), uint8(B * a / 0xffff >> 8), uint8(A * a / 0xffff >> 8)}
+			backdrop := color.RGBAModel.Convert(r.Image.At(x, y)).(color.RGBA)
+			if !linear {
+				source = r.colorSpace.FromLinear(source)
+				if backdrop.A != 0 {
+					backdrop = r.colorSpace.FromLinear(backdrop)
+				}
+			}
+			col := mode.Blend(backdrop, source)
+			if !linear && col.A != 0 {
+				col = r.colorSpace.ToLinear(col)
+			}
+			r.Image.Set(x, y, col)
+		}
+	}
+}
+
+// PushClip intersects the clipping region with a path using a fill rule and a transformation matrix.
+func (r *Rasterizer) PushClip(path *canvas.Path, fillRule canvas.FillRule, m canvas.Matrix) {
+	bounds := r.Bounds()
+	size := bounds.Size()
+	mask := image.NewAlpha(bounds)
+	r.scanner.Reset(size.X, size.Y)
+	r.scanner.AddPath(path.Transform(m), r.resolution.DPMM(), 0.0, 0.0)
+	r.scanner.Draw(mask, fillRule)
+	if 0 < len(r.clips) {
+		prev := r.clips[len(r.clips)-1]
+		for i := range mask.Pix {
+			mask.Pix[i] = uint8((uint32(mask.Pix[i])*uint32(prev.Pix[i]) + 127) / 255)
+		}
+	}
+	r.clips = append(r.clips, mask)
+}
+
+// PopClip removes the last pushed clipping path.
+func (r *Rasterizer) PopClip() {
+	if len(r.clips) == 0 {
+		return
+	}
+	r.clips = r.clips[:len(r.clips)-1]
+}
+
+// PushGroup starts a transparency group, where m is the transformation of the group's mask. Subsequent rendering is drawn to an off-screen image until PopGroup is called.
+func (r *Rasterizer) PushGroup(group canvas.Group, m canvas.Matrix) {
+	r.groups = append(r.groups, rasterizerGroup{
+		Group: group,
+		m:     m,
+		dst:   r.Image,
+		clips: len(r.clips),
+	})
+	r.Image = image.NewRGBA(r.Bounds())
+}
+
+// PopGroup ends the last started transparency group and composites it with its opacity, soft mask and the clipping mask from before the group.
+func (r *Rasterizer) PopGroup() {
+	if len(r.groups) == 0 {
+		return
+	}
+	group := r.groups[len(r.groups)-1]
+	r.groups = r.groups[:len(r.groups)-1]
+	r.clips = r.clips[:group.clips]
+	src := r.Image
+	r.Image = group.dst
+
+	bounds := r.Bounds()
+	mask := image.NewAlpha(bounds)
+	opacity := uint32(math.Max(0.0, math.Min(1.0, group.Opacity))*255.0 + 0.5)
+	for i := range mask.Pix {
+		mask.Pix[i] = uint8(opacity)
+	}
+	if group.Mask != nil {
+		maskImg := image.NewRGBA(bounds)
+		maskRas := FromImage(maskImg, r.resolution, r.colorSpace)
+		group.Mask.RenderTo(canvas.RendererViewer{Renderer: maskRas, Matrix: group.m})
+		_, linear := r.colorSpace.(canvas.LinearColorSpace)
+		for j := bounds.Min.Y; j < bounds.Max.Y; j++ {
+			for i := bounds.Min.X; i < bounds.Max.X; i++ {
+				col := maskImg.RGBAAt(i, j)
+				a := uint32(col.A)
+				if group.MaskType == canvas.LuminosityMask {
+					// luminance of the premultiplied color in the output color space, which is the color composited on black
+					if !linear && col.A != 0 {
+						col = r.colorSpace.FromLinear(col)
+					}
+					a = uint32(0.2125*float64(col.R) + 0.7154*float64(col.G) + 0.0721*float64(col.B) + 0.5)
+				}
+				k := mask.PixOffset(i, j)
+				mask.Pix[k] = uint8((uint32(mask.Pix[k])*a + 127) / 255)
+			}
+		}
+	}
+	if 0 < len(r.clips) {
+		clip := r.clips[len(r.clips)-1]
+		for i := range mask.Pix {
+			mask.Pix[i] = uint8((uint32(mask.Pix[i])*uint32(clip.Pix[i]) + 127) / 255)
+		}
+	}
+	if group.BlendMode == canvas.NormalBlend {
+		draw.DrawMask(r.Image, bounds, src, bounds.Min, mask, bounds.Min, draw.Over)
+	} else {
+		r.blend(bounds, src, bounds.Min, mask, bounds.Min, group.BlendMode)
+	}
+}
+
+// gradientImage returns an image that evaluates the gradient, transformed by m, at the center of each pixel of the destination image.
+func (r *Rasterizer) gradientImage(gradient canvas.Gradient, m canvas.Matrix) image.Image {
+	dpmm := r.resolution.DPMM()
+	h := float64(r.Bounds().Size().Y)
+	pixelToCanvas := canvas.Identity.Translate(0.0, h/dpmm).Scale(1.0/dpmm, -1.0/dpmm).Translate(0.5, 0.5)
+	return gradientImage{
+		gradient:   gradient,
+		m:          m.Inv().Mul(pixelToCanvas),
+		colorSpace: r.colorSpace,
+	}
+}
+
+// gradientImage is an unbounded image of a gradient, where m transforms pixel coordinates to the gradient's coordinate system.
+type gradientImage struct {
+	gradient   canvas.Gradient
+	m          canvas.Matrix
+	colorSpace canvas.ColorSpace
+}
+
+func (img gradientImage) ColorModel() color.Model {
+	return color.RGBAModel
+}
+
+func (img gradientImage) Bounds() image.Rectangle {
+	return image.Rect(math.MinInt32, math.MinInt32, math.MaxInt32, math.MaxInt32)
+}
+
+func (img gradientImage) At(x, y int) color.Color {
+	p := img.m.Dot(canvas.Point{X: float64(x), Y: float64(y)})
+	col := img.gradient.At(p.X, p.Y)
+	if col.A == 0 {
+		return color.RGBA{}
+	}
+	return img.colorSpace.ToLinear(col)
+}
+
+// patternImage returns an image that repeats the pattern, transformed by m, over the destination image. The pattern cell is rasterized once at the resolution of the destination image and is sampled with bilinear interpolation.
+func (r *Rasterizer) patternImage(pattern *canvas.Pattern, m canvas.Matrix) image.Image {
+	if pattern.Empty() {
+		return image.Transparent
+	}
+	m = m.Mul(pattern.Matrix)
+	dpmm := r.resolution.DPMM()
+	cell := pattern.Cell()
+
+	// limit the cell's size to that of the destination image, larger cells are rarely useful and would use a lot of memory
+	size := r.Bounds().Size()
+	w := math.Hypot(m[0][0], m[1][0]) * cell.W * dpmm
+	h := math.Hypot(m[0][1], m[1][1]) * cell.H * dpmm
+	wi := int(math.Max(1.0, math.Min(math.Ceil(w), float64(2*size.X+2))))
+	hi := int(math.Max(1.0, math.Min(math.Ceil(h), float64(2*size.Y+2))))
+
+	// rasterize the cell in the linear color space, where the cell covers the image exactly
+	tile := image.NewRGBA(image.Rect(0, 0, wi, hi))
+	ras := FromImage(tile, canvas.DPMM(1.0), r.colorSpace)
+	ras.scanner.aa = r.scanner.aa
+	pattern.Canvas.RenderTo(canvas.RendererViewer{Renderer: ras, Matrix: canvas.Identity.Scale(float64(wi)/cell.W, float64(hi)/cell.H)})
+	for range ras.groups {
+		ras.PopGroup()
+	}
+
+	H := float64(size.Y)
+	pixelToCanvas := canvas.Identity.Translate(0.0, H/dpmm).Scale(1.0/dpmm, -1.0/dpmm).Translate(0.5, 0.5)
+	return patternImage{
+		tile: tile,
+		m:    canvas.Identity.Scale(float64(wi)/cell.W, -float64(hi)/cell.H).Translate(0.0, -cell.H).Mul(m.Inv()).Mul(pixelToCanvas),
+	}
+}
+
+// patternImage is an unbounded image that repeats a tile, where m transforms pixel coordinates to the tile's pixel coordinates.
+type patternImage struct {
+	tile *image.RGBA
+	m    canvas.Matrix
+}
+
+func (img patternImage) ColorModel() color.Model {
+	return color.RGBAModel
+}
+
+func (img patternImage) Bounds() image.Rectangle {
+	return image.Rect(math.MinInt32, math.MinInt32, math.MaxInt32, math.MaxInt32)
+}
+
+func (img patternImage) At(x, y int) color.Color {
+	size := img.tile.Bounds().Size()
+	p := img.m.Dot(canvas.Point{X: float64(x), Y: float64(y)})
+
+	// bilinear interpolation between the four nearest pixel centers, wrapping around the tile's edges
+	u, v := p.X-0.5, p.Y-0.5
+	i0, j0 := math.Floor(u), math.Floor(v)
+	fu, fv := u-i0, v-j0
+	i := (int(i0)%size.X + size.X) % size.X
+	j := (int(j0)%size.Y + size.Y) % size.Y
+	i1, j1 := (i+1)%size.X, (j+1)%size.Y
+	c00, c10 := img.tile.RGBAAt(i, j), img.tile.RGBAAt(i1, j)
+	c01, c11 := img.tile.RGBAAt(i, j1), img.tile.RGBAAt(i1, j1)
+	lerp := func(a, b, c, d uint8) uint8 {
+		return uint8((float64(a)*(1.0-fu)+float64(b)*fu)*(1.0-fv) + (float64(c)*(1.0-fu)+float64(d)*fu)*fv + 0.5)
+	}
+	return color.RGBA{
+		lerp(c00.R, c10.R, c01.R, c11.R),
+		lerp(c00.G, c10.G, c01.G, c11.G),
+		lerp(c00.B, c10.B, c01.B, c11.B),
+		lerp(c00.A, c10.A, c01.A, c11.A),
 	}
 }
 
@@ -151,7 +434,11 @@ func (r *Rasterizer) RenderImage(img image.Image, m canvas.Matrix) {
 
 	h := float64(r.Bounds().Size().Y)
 	aff3 := f64.Aff3{m[0][0], -m[0][1], origin.X, -m[1][0], m[1][1], h - origin.Y}
-	draw.CatmullRom.Transform(r, aff3, img2, img2.Bounds(), draw.Over, nil)
+	var opts *draw.Options
+	if 0 < len(r.clips) {
+		opts = &draw.Options{DstMask: r.clips[len(r.clips)-1]}
+	}
+	draw.CatmullRom.Transform(r, aff3, img2, img2.Bounds(), draw.Over, opts)
 }
 
 type colorFunc func(color.Color) color.RGBA
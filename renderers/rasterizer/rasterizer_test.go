@@ -0,0 +1,147 @@
+package rasterizer
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/LaminoidStudio/Canvas"
+	"github.com/tdewolff/test"
+)
+
+func TestRasterizerFillRule(t *testing.T) {
+	// two nested squares in the same direction, the inner one is filled only for the NonZero fill rule
+	path := canvas.Rectangle(10.0, 10.0)
+	path = path.Append(canvas.Rectangle(4.0, 4.0).Translate(3.0, 3.0))
+
+	for _, aa := range []Antialiasing{Analytic, NoAntialiasing, Supersample4x, Supersample16x} {
+		for _, fillRule := range []canvas.FillRule{canvas.NonZero, canvas.EvenOdd} {
+			t.Run(aa.String(), func(t *testing.T) {
+				ras := New(10.0, 10.0, canvas.DPMM(1.0), canvas.LinearColorSpace{})
+				ras.SetAntialiasing(aa)
+				style := canvas.DefaultStyle
+				style.FillRule = fillRule
+				ras.RenderPath(path, style, canvas.Identity)
+
+				img := ras.Image.(*image.RGBA)
+				test.T(t, img.RGBAAt(1, 1).A, uint8(255))
+				test.T(t, img.RGBAAt(8, 8).A, uint8(255))
+				if fillRule == canvas.NonZero {
+					test.T(t, img.RGBAAt(5, 5).A, uint8(255))
+				} else {
+					test.T(t, img.RGBAAt(5, 5).A, uint8(0))
+				}
+			})
+		}
+	}
+}
+
+func TestRasterizerAntialiasing(t *testing.T) {
+	// the right edge of the rectangle covers half of the third pixel
+	var tts = []struct {
+		aa    Antialiasing
+		alpha uint8
+	}{
+		{Analytic, 128},
+		{NoAntialiasing, 0},
+		{Supersample4x, 128},
+		{Supersample16x, 128},
+	}
+	for _, tt := range tts {
+		t.Run(tt.aa.String(), func(t *testing.T) {
+			ras := New(5.0, 5.0, canvas.DPMM(1.0), canvas.LinearColorSpace{})
+			ras.SetAntialiasing(tt.aa)
+			ras.RenderPath(canvas.Rectangle(2.5, 5.0), canvas.DefaultStyle, canvas.Identity)
+
+			img := ras.Image.(*image.RGBA)
+			test.T(t, img.RGBAAt(1, 2).A, uint8(255))
+			test.T(t, img.RGBAAt(2, 2).A, tt.alpha)
+			test.T(t, img.RGBAAt(3, 2).A, uint8(0))
+		})
+	}
+}
+
+func TestRasterizerClip(t *testing.T) {
+	// the clipping path is a square with a hole when using the EvenOdd fill rule
+	clip := canvas.Rectangle(10.0, 10.0)
+	clip = clip.Append(canvas.Rectangle(4.0, 4.0).Translate(3.0, 3.0))
+
+	ras := New(10.0, 10.0, canvas.DPMM(1.0), canvas.LinearColorSpace{})
+	ras.PushClip(clip, canvas.EvenOdd, canvas.Identity)
+	ras.RenderPath(canvas.Rectangle(10.0, 10.0), canvas.DefaultStyle, canvas.Identity)
+	ras.PopClip()
+
+	img := ras.Image.(*image.RGBA)
+	test.T(t, img.RGBAAt(1, 1).A, uint8(255))
+	test.T(t, img.RGBAAt(5, 5).A, uint8(0))
+}
+
+func TestScannerAllocations(t *testing.T) {
+	s := scanner{}
+	mask := image.NewAlpha(image.Rect(0, 0, 64, 64))
+	for _, aa := range []Antialiasing{Analytic, Supersample16x} {
+		s.aa = aa
+		allocs := testing.AllocsPerRun(10, func() {
+			s.Reset(64, 64)
+			s.MoveTo(-10.0, 5.0)
+			s.LineTo(50.0, 70.0)
+			s.LineTo(70.0, 10.0)
+			s.ClosePath()
+			s.Draw(mask, canvas.NonZero)
+		})
+		test.T(t, allocs, 0.0, aa.String())
+	}
+}
+
+func TestRasterizerPattern(t *testing.T) {
+	// the cell has a red square in its bottom-left quarter
+	cell := canvas.New(1.0, 1.0)
+	ctx := canvas.NewContext(cell)
+	ctx.SetFillColor(canvas.Red)
+	ctx.DrawPath(0.0, 0.0, canvas.Rectangle(1.0, 1.0))
+
+	var tts = []struct {
+		pattern *canvas.Pattern
+		x, y    float64
+		col     color.RGBA
+	}{
+		{canvas.NewPattern(cell, canvas.Identity, 1.0, 1.0), 0.5, 0.5, canvas.Red},
+		{canvas.NewPattern(cell, canvas.Identity, 1.0, 1.0), 1.5, 0.5, canvas.Transparent},
+		{canvas.NewPattern(cell, canvas.Identity, 1.0, 1.0), 1.5, 1.5, canvas.Transparent},
+		{canvas.NewPattern(cell, canvas.Identity, 1.0, 1.0), 4.5, 6.5, canvas.Red},
+		{canvas.NewPattern(cell, canvas.Identity, 1.0, 1.0), 7.5, 6.5, canvas.Transparent},
+		{canvas.NewPattern(cell, canvas.Identity.Translate(1.0, 0.0), 1.0, 1.0), 0.5, 0.5, canvas.Transparent},
+		{canvas.NewPattern(cell, canvas.Identity.Translate(1.0, 0.0), 1.0, 1.0), 1.5, 0.5, canvas.Red},
+		{canvas.NewPattern(cell, canvas.Identity.Scale(2.0, 2.0), 1.0, 1.0), 1.5, 1.5, canvas.Red},
+		{canvas.NewPattern(cell, canvas.Identity.Scale(2.0, 2.0), 1.0, 1.0), 2.5, 1.5, canvas.Transparent},
+		{canvas.NewPattern(cell, canvas.Identity.Scale(2.0, 2.0), 1.0, 1.0), 4.5, 5.5, canvas.Red},
+	}
+	for _, tt := range tts {
+		ras := New(10.0, 10.0, canvas.DPMM(4.0), canvas.LinearColorSpace{})
+		style := canvas.DefaultStyle
+		style.FillPattern = tt.pattern
+		ras.RenderPath(canvas.Rectangle(10.0, 10.0), style, canvas.Identity)
+
+		img := ras.Image.(*image.RGBA)
+		test.T(t, img.RGBAAt(int(tt.x*4.0), 40-int(tt.y*4.0)-1), tt.col, tt.x, tt.y)
+	}
+}
+
+func TestRasterizerLuminosityMask(t *testing.T) {
+	// the luminance of the mask is taken from its sRGB color and not from the linear color used for blending
+	mask := canvas.New(10.0, 10.0)
+	ctx := canvas.NewContext(mask)
+	ctx.SetFillColor(color.RGBA{128, 128, 128, 255})
+	ctx.DrawPath(0.0, 0.0, canvas.Rectangle(10.0, 10.0))
+
+	for _, colorSpace := range []canvas.ColorSpace{canvas.LinearColorSpace{}, canvas.SRGBColorSpace{}} {
+		ras := New(10.0, 10.0, canvas.DPMM(1.0), colorSpace)
+		ras.PushGroup(canvas.Group{Opacity: 1.0, Mask: mask, MaskType: canvas.LuminosityMask}, canvas.Identity)
+		ras.RenderPath(canvas.Rectangle(10.0, 10.0), canvas.DefaultStyle, canvas.Identity)
+		ras.PopGroup()
+		ras.Close()
+
+		img := ras.Image.(*image.RGBA)
+		test.T(t, img.RGBAAt(5, 5).A, uint8(128))
+	}
+}
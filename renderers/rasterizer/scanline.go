@@ -0,0 +1,328 @@
+package rasterizer
+
+import (
+	"image"
+	"math"
+
+	"github.com/LaminoidStudio/Canvas"
+)
+
+// Antialiasing is the anti-aliasing method used to compute the coverage of pixels by a path.
+type Antialiasing int
+
+// see Antialiasing
+const (
+	Analytic       Antialiasing = iota // exact area coverage of each pixel
+	NoAntialiasing                     // one sample at the center of each pixel
+	Supersample4x                      // 2x2 samples per pixel
+	Supersample16x                     // 4x4 samples per pixel
+)
+
+func (aa Antialiasing) String() string {
+	switch aa {
+	case NoAntialiasing:
+		return "NoAntialiasing"
+	case Supersample4x:
+		return "Supersample4x"
+	case Supersample16x:
+		return "Supersample16x"
+	}
+	return "Analytic"
+}
+
+// scanEdge is a line segment of a path in pixel coordinates, where y0 < y1 and dir is +1 when the original segment goes down and -1 when it goes up.
+type scanEdge struct {
+	x0, y0, x1, y1 float64
+	dir            float64
+}
+
+// scanCrossing is the intersection of an edge with a sample scanline.
+type scanCrossing struct {
+	x   float64
+	dir int
+}
+
+// scanner is a scanline rasterizer that accumulates the coverage of pixels by line segments into a buffer of cells. All buffers are kept between paths so that rasterizing does not allocate once they have grown to the size of the largest path.
+type scanner struct {
+	aa   Antialiasing
+	w, h int
+
+	x0, y0, x, y float64 // start of the subpath and current position
+	edges        []scanEdge
+	cells        []float32 // signed area and cover per pixel for analytic coverage, or sample coverage per pixel for supersampling, with a stride of w+2
+
+	// used for supersampling only
+	heads     []int // first edge starting at each row
+	next      []int // next edge starting at the same row
+	active    []int
+	crossings []scanCrossing
+}
+
+// Reset clears the edges and sets the size of the region to rasterize in pixels.
+func (s *scanner) Reset(w, h int) {
+	s.w, s.h = w, h
+	s.x0, s.y0, s.x, s.y = 0.0, 0.0, 0.0, 0.0
+	s.edges = s.edges[:0]
+	if n := (w + 2) * h; cap(s.cells) < n {
+		s.cells = make([]float32, n) // cells are cleared while drawing, so a new buffer is only needed when growing
+	} else {
+		s.cells = s.cells[:n]
+	}
+}
+
+// MoveTo starts a new subpath at (x,y) in pixel coordinates, implicitly closing the previous subpath.
+func (s *scanner) MoveTo(x, y float64) {
+	s.ClosePath()
+	s.x0, s.y0, s.x, s.y = x, y, x, y
+}
+
+// LineTo adds a line segment to (x,y) in pixel coordinates.
+func (s *scanner) LineTo(x, y float64) {
+	s.addEdge(s.x, s.y, x, y)
+	s.x, s.y = x, y
+}
+
+// ClosePath closes the current subpath.
+func (s *scanner) ClosePath() {
+	s.LineTo(s.x0, s.y0)
+}
+
+// AddPath adds a path with coordinates in millimeters, where (ox,oy) is the bottom-left corner of the rasterized region in pixels. The path is flattened to line segments and the y-axis is flipped.
+func (s *scanner) AddPath(p *canvas.Path, dpmm, ox, oy float64) {
+	oldTolerance := canvas.Tolerance
+	canvas.Tolerance = canvas.RasterizerTolerance / dpmm // tolerance of 1/10 of a pixel
+	p = p.Flatten()
+	canvas.Tolerance = oldTolerance
+
+	h := float64(s.h)
+	for scanner := p.Scanner(); scanner.Scan(); {
+		end := scanner.End()
+		x, y := end.X*dpmm-ox, h-(end.Y*dpmm-oy)
+		switch scanner.Cmd() {
+		case canvas.MoveToCmd:
+			s.MoveTo(x, y)
+		case canvas.LineToCmd:
+			s.LineTo(x, y)
+		case canvas.CloseCmd:
+			s.ClosePath()
+		default:
+			panic("quadratic and cubic Béziers and arcs should have been replaced")
+		}
+	}
+	s.ClosePath()
+}
+
+// addEdge adds a line segment, which is split at the left and right border so that the parts outside the region can be clamped to the border, where they still contribute to the winding of the pixels to their right. Parts above and below the region are dropped.
+func (s *scanner) addEdge(x0, y0, x1, y1 float64) {
+	if y0 == y1 || math.IsNaN(x0) || math.IsNaN(y0) || math.IsNaN(x1) || math.IsNaN(y1) {
+		return
+	}
+	w, h := float64(s.w), float64(s.h)
+	if y0 <= 0.0 && y1 <= 0.0 || h <= y0 && h <= y1 {
+		return
+	}
+	for _, bx := range [2]float64{0.0, w} {
+		if x0 != bx && x1 != bx && (x0 < bx) != (x1 < bx) {
+			ym := y0 + (bx-x0)/(x1-x0)*(y1-y0)
+			s.addEdge(x0, y0, bx, ym)
+			s.addEdge(bx, ym, x1, y1)
+			return
+		}
+	}
+	x0 = math.Max(0.0, math.Min(w, x0))
+	x1 = math.Max(0.0, math.Min(w, x1))
+
+	dir := 1.0
+	if y1 < y0 {
+		dir = -1.0
+		x0, y0, x1, y1 = x1, y1, x0, y0
+	}
+	dxdy := (x1 - x0) / (y1 - y0)
+	if y0 < 0.0 {
+		x0 -= y0 * dxdy
+		y0 = 0.0
+	}
+	if h < y1 {
+		x1 -= (y1 - h) * dxdy
+		y1 = h
+	}
+	s.edges = append(s.edges, scanEdge{x0, y0, x1, y1, dir})
+}
+
+// Draw computes the coverage of the pixels using the fill rule and writes it to mask, which must be of size w×h. The cell buffer is cleared for the next path.
+func (s *scanner) Draw(mask *image.Alpha, fillRule canvas.FillRule) {
+	switch s.aa {
+	case NoAntialiasing:
+		s.supersample(1, 1, fillRule)
+	case Supersample4x:
+		s.supersample(2, 2, fillRule)
+	case Supersample16x:
+		s.supersample(4, 4, fillRule)
+	default:
+		for _, e := range s.edges {
+			s.accumulate(e)
+		}
+	}
+
+	stride := s.w + 2
+	for y := 0; y < s.h; y++ {
+		acc := float32(0.0)
+		row := s.cells[y*stride : (y+1)*stride]
+		pix := mask.Pix[y*mask.Stride : y*mask.Stride+s.w]
+		for x := range pix {
+			v := row[x]
+			row[x] = 0.0
+			if s.aa == Analytic {
+				acc += v
+				v = acc
+			}
+			if v < 0.0 {
+				v = -v
+			}
+			if fillRule == canvas.EvenOdd {
+				if 2.0 <= v {
+					v -= 2.0 * float32(int(v/2.0))
+				}
+				if 1.0 < v {
+					v = 2.0 - v
+				}
+			} else if 1.0 < v {
+				v = 1.0
+			}
+			pix[x] = uint8(v*255.0 + 0.5)
+		}
+		row[s.w] = 0.0
+		row[s.w+1] = 0.0
+	}
+}
+
+// accumulate adds the signed area and cover of an edge to the cells of the rows it crosses, such that the running sum over a row gives the winding-weighted coverage of each pixel.
+func (s *scanner) accumulate(e scanEdge) {
+	stride := s.w + 2
+	w := float64(s.w)
+	dxdy := (e.x1 - e.x0) / (e.y1 - e.y0)
+	x := e.x0
+	for y := int(e.y0); y < s.h && float64(y) < e.y1; y++ {
+		dy := math.Min(float64(y+1), e.y1) - math.Max(float64(y), e.y0)
+		xnext := math.Max(0.0, math.Min(w, x+dxdy*dy))
+		d := dy * e.dir
+		x0, x1 := x, xnext
+		if x1 < x0 {
+			x0, x1 = x1, x0
+		}
+
+		row := s.cells[y*stride : (y+1)*stride]
+		x0floor := math.Floor(x0)
+		x0i := int(x0floor)
+		x1ceil := math.Ceil(x1)
+		x1i := int(x1ceil)
+		if x1i <= x0i+1 {
+			// the edge stays within a single pixel of this row
+			xm := 0.5*(x+xnext) - x0floor
+			row[x0i] += float32(d - d*xm)
+			row[x0i+1] += float32(d * xm)
+		} else {
+			is := 1.0 / (x1 - x0)
+			x0f := x0 - x0floor
+			a0 := 0.5 * is * (1.0 - x0f) * (1.0 - x0f)
+			x1f := x1 - x1ceil + 1.0
+			am := 0.5 * is * x1f * x1f
+			row[x0i] += float32(d * a0)
+			if x1i == x0i+2 {
+				row[x0i+1] += float32(d * (1.0 - a0 - am))
+			} else {
+				a1 := is * (1.5 - x0f)
+				row[x0i+1] += float32(d * (a1 - a0))
+				for xi := x0i + 2; xi < x1i-1; xi++ {
+					row[xi] += float32(d * is)
+				}
+				a2 := a1 + float64(x1i-x0i-3)*is
+				row[x1i-1] += float32(d * (1.0 - a2 - am))
+			}
+			row[x1i] += float32(d * am)
+		}
+		x = xnext
+	}
+}
+
+// supersample computes the coverage of the pixels from n×m samples per pixel, where each sample is inside when the winding number of the crossings to its left satisfies the fill rule. Edges are bucketed by their first row and kept in an active list while they cross the current row.
+func (s *scanner) supersample(n, m int, fillRule canvas.FillRule) {
+	if cap(s.heads) < s.h {
+		s.heads = make([]int, s.h)
+	}
+	s.heads = s.heads[:s.h]
+	for y := range s.heads {
+		s.heads[y] = -1
+	}
+	if cap(s.next) < len(s.edges) {
+		s.next = make([]int, len(s.edges))
+	}
+	s.next = s.next[:len(s.edges)]
+	for i := len(s.edges) - 1; 0 <= i; i-- {
+		if y := int(s.edges[i].y0); y < s.h {
+			s.next[i] = s.heads[y]
+			s.heads[y] = i
+		}
+	}
+
+	stride := s.w + 2
+	inc := float32(1.0 / float64(n*m))
+	wm := s.w * m
+	s.active = s.active[:0]
+	for y := 0; y < s.h; y++ {
+		for i := s.heads[y]; i != -1; i = s.next[i] {
+			s.active = append(s.active, i)
+		}
+		if len(s.active) == 0 {
+			continue
+		}
+
+		row := s.cells[y*stride : (y+1)*stride]
+		for j := 0; j < n; j++ {
+			sy := float64(y) + (float64(j)+0.5)/float64(n)
+			s.crossings = s.crossings[:0]
+			k := 0
+			for _, i := range s.active {
+				e := s.edges[i]
+				if e.y1 <= sy {
+					continue // edge has ended
+				}
+				s.active[k] = i
+				k++
+				if sy < e.y0 {
+					continue // edge starts below this sample scanline
+				}
+
+				// insertion sort, there are usually only a few crossings
+				c := scanCrossing{e.x0 + (sy-e.y0)*(e.x1-e.x0)/(e.y1-e.y0), int(e.dir)}
+				s.crossings = append(s.crossings, c)
+				l := len(s.crossings) - 1
+				for ; 0 < l && c.x < s.crossings[l-1].x; l-- {
+					s.crossings[l] = s.crossings[l-1]
+				}
+				s.crossings[l] = c
+			}
+			s.active = s.active[:k]
+
+			winding := 0
+			for c := 0; c+1 < len(s.crossings); c++ {
+				winding += s.crossings[c].dir
+				if fillRule == canvas.EvenOdd && winding%2 == 0 || winding == 0 {
+					continue
+				}
+				// fill the samples at (k+0.5)/m within [xa,xb)
+				ka := int(math.Ceil(s.crossings[c].x*float64(m) - 0.5))
+				kb := int(math.Ceil(s.crossings[c+1].x*float64(m) - 0.5))
+				if ka < 0 {
+					ka = 0
+				}
+				if wm < kb {
+					kb = wm
+				}
+				for k := ka; k < kb; k++ {
+					row[k/m] += inc
+				}
+			}
+		}
+	}
+}
@@ -12,22 +12,34 @@ import (
 	"image/png"
 	"io"
 	"math"
+	"sort"
 	"strings"
 
 	"github.com/LaminoidStudio/Canvas"
 	canvasFont "github.com/LaminoidStudio/Canvas/font"
 )
 
+// FontFormat is the file format of embedded fonts. Fonts are embedded as TTF or OTF by default, set FontWOFF2 and SubsetFonts in the options for compact output.
+type FontFormat int
+
+// see FontFormat
+const (
+	FontSFNT  FontFormat = iota // TTF or OTF
+	FontWOFF                    // zlib compressed
+	FontWOFF2                   // Brotli compressed with transformed glyphs
+)
+
 type Options struct {
 	Compression int
 	EmbedFonts  bool
 	SubsetFonts bool
+	FontFormat  FontFormat
 	canvas.ImageEncoding
 }
 
 var DefaultOptions = Options{
 	EmbedFonts:    true,
-	SubsetFonts:   false, // TODO: enable when properly handling GPOS and GSUB tables
+	SubsetFonts:   false,
 	ImageEncoding: canvas.Lossless,
 }
 
@@ -35,9 +47,15 @@ var DefaultOptions = Options{
 type SVG struct {
 	w             io.Writer
 	width, height float64
-	fonts         map[*canvas.Font]bool
-	fontSubset    map[*canvas.Font]*canvas.FontSubsetter
+	fonts         map[svgFontKey]string // font family names
+	fontSubset    map[svgFontKey]*canvas.FontSubsetter
 	maskID        int
+	gradientID    int
+	patternID     int
+	patterns      map[svgPatternKey]string
+	clipID        int
+	textPathID    int
+	groups        int // number of open groups for clipping paths and transparency groups
 	classes       []string
 	opts          *Options
 }
@@ -61,9 +79,13 @@ func New(w io.Writer, width, height float64, opts *Options) *SVG {
 		w:          w,
 		width:      width,
 		height:     height,
-		fonts:      map[*canvas.Font]bool{},
-		fontSubset: map[*canvas.Font]*canvas.FontSubsetter{},
+		fonts:      map[svgFontKey]string{},
+		fontSubset: map[svgFontKey]*canvas.FontSubsetter{},
 		maskID:     0,
+		gradientID: 0,
+		patternID:  0,
+		patterns:   map[svgPatternKey]string{},
+		clipID:     0,
 		classes:    []string{},
 		opts:       opts,
 	}
@@ -71,40 +93,123 @@ func New(w io.Writer, width, height float64, opts *Options) *SVG {
 
 // Close finished and closes the SVG.
 func (r *SVG) Close() error {
+	for ; 0 < r.groups; r.groups-- {
+		fmt.Fprintf(r.w, "</g>")
+	}
+	var errFonts error
 	if r.opts.EmbedFonts {
-		r.writeFonts()
+		errFonts = r.writeFonts()
 	}
 	_, err := fmt.Fprintf(r.w, "</svg>")
 	if r.opts.Compression != 0 {
 		r.w.(*gzip.Writer).Close() // does not close underlying writer
 	}
+	if errFonts != nil {
+		return errFonts
+	}
 	return err
 }
 
-func (r *SVG) writeFonts() {
-	if 0 < len(r.fonts) {
-		fmt.Fprintf(r.w, "<style>")
-		for font := range r.fonts {
-			b := font.SFNT.Data
-			if r.opts.SubsetFonts {
-				glyphIDs := r.fontSubset[font].List()
-				b, _ = font.SFNT.Subset(glyphIDs, canvasFont.WriteMinTables)
+// svgFontKey identifies an embedded font by the font and its variations, as each location in the design space of a variable font is embedded as a separate static font.
+type svgFontKey struct {
+	font       *canvas.Font
+	variations string // empty for fonts that are not variable
+}
+
+func newSVGFontKey(font *canvas.Font) svgFontKey {
+	return svgFontKey{font, canvasFont.FormatVariations(font.SFNT.Variations())}
+}
+
+// fontFamily returns the font family name of a font face and registers its font for embedding. Instances of a variable font other than the first are embedded under the font name with a numbered suffix.
+func (r *SVG) fontFamily(face *canvas.FontFace) string {
+	key := newSVGFontKey(face.Font)
+	if name, ok := r.fonts[key]; ok {
+		return name
+	}
+
+	name := face.Name()
+	if r.opts.EmbedFonts {
+		n := 0
+		for k := range r.fonts {
+			if k.font == key.font {
+				n++
 			}
-			fmt.Fprintf(r.w, "\n@font-face{font-family:'%s'", font.Name())
-			if font.Style().Weight() != canvas.FontRegular {
-				fmt.Fprintf(r.w, ";font-weight:%d", font.Style().CSS())
+		}
+		if 0 < n {
+			name = fmt.Sprintf("%s-%d", name, n)
+		}
+	}
+	r.fonts[key] = name
+	r.fontSubset[key] = canvas.NewFontSubsetter()
+	return name
+}
+
+func (r *SVG) writeFonts() error {
+	if len(r.fonts) == 0 {
+		return nil
+	}
+
+	keys := make([]svgFontKey, 0, len(r.fonts))
+	for key := range r.fonts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return r.fonts[keys[i]] < r.fonts[keys[j]] || r.fonts[keys[i]] == r.fonts[keys[j]] && keys[i].font.Style() < keys[j].font.Style()
+	})
+
+	var errInstance error
+	fmt.Fprintf(r.w, "<style>")
+	for _, key := range keys {
+		font := key.font
+		// bake the variations into a static font so that the glyphs match the layout, as not all viewers support variable fonts
+		sfnt := font.SFNT
+		if sfnt.IsVariable() {
+			variations, err := canvasFont.ParseVariations(key.variations)
+			if err == nil {
+				sfnt, err = sfnt.Instance(variations)
 			}
-			if font.Style().Italic() {
-				fmt.Fprintf(r.w, ";font-style:italic")
+			if err != nil {
+				if errInstance == nil {
+					errInstance = fmt.Errorf("font %s: %w", font.Name(), err)
+				}
+				continue
 			}
-			fmt.Fprintf(r.w, ";src:url('data:type/opentype;base64,")
-			encoder := base64.NewEncoder(base64.StdEncoding, r.w)
-			encoder.Write(b)
-			encoder.Close()
-			fmt.Fprintf(r.w, "');}")
 		}
-		fmt.Fprintf(r.w, "\n</style>")
-	}
+
+		b := sfnt.Data
+		if r.opts.SubsetFonts {
+			glyphIDs := r.fontSubset[key].List()
+			b, _ = sfnt.Subset(glyphIDs, canvasFont.WriteMinTables)
+		}
+
+		mediatype, format := "font/truetype", "truetype"
+		if sfnt.IsCFF {
+			mediatype, format = "font/opentype", "opentype"
+		}
+		if r.opts.FontFormat == FontWOFF2 {
+			if woff2, err := canvasFont.WriteWOFF2(b); err == nil {
+				b, mediatype, format = woff2, "font/woff2", "woff2"
+			}
+		} else if r.opts.FontFormat == FontWOFF {
+			if woff, err := canvasFont.WriteWOFF(b); err == nil {
+				b, mediatype, format = woff, "font/woff", "woff"
+			}
+		}
+		fmt.Fprintf(r.w, "\n@font-face{font-family:'%s'", r.fonts[key])
+		if font.Style().Weight() != canvas.FontRegular {
+			fmt.Fprintf(r.w, ";font-weight:%d", font.Style().CSS())
+		}
+		if font.Style().Italic() {
+			fmt.Fprintf(r.w, ";font-style:italic")
+		}
+		fmt.Fprintf(r.w, ";src:url('data:%s;base64,", mediatype)
+		encoder := base64.NewEncoder(base64.StdEncoding, r.w)
+		encoder.Write(b)
+		encoder.Close()
+		fmt.Fprintf(r.w, "') format('%s');}", format)
+	}
+	fmt.Fprintf(r.w, "\n</style>")
+	return errInstance
 }
 
 func (r *SVG) writeClasses(w io.Writer) {
@@ -153,6 +258,29 @@ func (r *SVG) Size() (float64, float64) {
 
 // RenderPath renders a path to the canvas using a style and a transformation matrix.
 func (r *SVG) RenderPath(path *canvas.Path, style canvas.Style, m canvas.Matrix) {
+	if style.FillPattern != nil && style.FillPattern.Empty() {
+		style.FillColor, style.FillGradient, style.FillPattern = canvas.Transparent, nil, nil
+	}
+	if style.StrokePattern != nil && style.StrokePattern.Empty() {
+		style.StrokeColor, style.StrokeGradient, style.StrokePattern = canvas.Transparent, nil, nil
+	}
+
+	fill, strokePaint := "", ""
+	if style.FillPattern != nil {
+		fill = r.writePattern(style.FillPattern, m)
+	} else if style.FillGradient != nil {
+		fill = r.writeGradient(style.FillGradient, m)
+	} else if style.FillColor != canvas.Black {
+		fill = canvas.CSSColor(style.FillColor).String()
+	}
+	if style.StrokePattern != nil && style.HasStroke() {
+		strokePaint = r.writePattern(style.StrokePattern, m)
+	} else if style.StrokeGradient != nil && style.HasStroke() {
+		strokePaint = r.writeGradient(style.StrokeGradient, m)
+	} else {
+		strokePaint = canvas.CSSColor(style.StrokeColor).String()
+	}
+
 	stroke := path
 	path = path.Transform(canvas.Identity.ReflectYAbout(r.height / 2.0).Mul(m))
 	fmt.Fprintf(r.w, `<path d="%s`, path.ToSVG())
@@ -184,8 +312,8 @@ func (r *SVG) RenderPath(path *canvas.Path, style canvas.Style, m canvas.Matrix)
 
 	if !style.HasStroke() {
 		if style.HasFill() {
-			if style.FillColor != canvas.Black {
-				fmt.Fprintf(r.w, `" fill="%v`, canvas.CSSColor(style.FillColor))
+			if fill != "" {
+				fmt.Fprintf(r.w, `" fill="%v`, fill)
 			}
 			if style.FillRule == canvas.EvenOdd {
 				fmt.Fprintf(r.w, `" fill-rule="evenodd`)
@@ -193,11 +321,14 @@ func (r *SVG) RenderPath(path *canvas.Path, style canvas.Style, m canvas.Matrix)
 		} else {
 			fmt.Fprintf(r.w, `" fill="none`)
 		}
+		if style.BlendMode != canvas.NormalBlend {
+			fmt.Fprintf(r.w, `" style="mix-blend-mode:%s`, style.BlendMode.CSS())
+		}
 	} else {
 		b := &strings.Builder{}
 		if style.HasFill() {
-			if style.FillColor != canvas.Black {
-				fmt.Fprintf(b, ";fill:%v", canvas.CSSColor(style.FillColor))
+			if fill != "" {
+				fmt.Fprintf(b, ";fill:%v", fill)
 			}
 			if style.FillRule == canvas.EvenOdd {
 				fmt.Fprintf(b, ";fill-rule:evenodd")
@@ -206,7 +337,7 @@ func (r *SVG) RenderPath(path *canvas.Path, style canvas.Style, m canvas.Matrix)
 			fmt.Fprintf(b, ";fill:none")
 		}
 		if style.HasStroke() && !strokeUnsupported {
-			fmt.Fprintf(b, `;stroke:%v`, canvas.CSSColor(style.StrokeColor))
+			fmt.Fprintf(b, `;stroke:%v`, strokePaint)
 			if style.StrokeWidth != 1.0 {
 				fmt.Fprintf(b, ";stroke-width:%v", dec(style.StrokeWidth))
 			}
@@ -245,6 +376,9 @@ func (r *SVG) RenderPath(path *canvas.Path, style canvas.Style, m canvas.Matrix)
 				}
 			}
 		}
+		if style.BlendMode != canvas.NormalBlend {
+			fmt.Fprintf(b, ";mix-blend-mode:%s", style.BlendMode.CSS())
+		}
 		if 0 < b.Len() {
 			fmt.Fprintf(r.w, `" style="%s`, b.String()[1:])
 		}
@@ -260,18 +394,167 @@ func (r *SVG) RenderPath(path *canvas.Path, style canvas.Style, m canvas.Matrix)
 		stroke = stroke.Stroke(style.StrokeWidth, style.StrokeCapper, style.StrokeJoiner)
 		stroke = stroke.Transform(canvas.Identity.ReflectYAbout(r.height / 2.0).Mul(m))
 		fmt.Fprintf(r.w, `<path d="%s`, stroke.ToSVG())
-		if style.StrokeColor != canvas.Black {
-			fmt.Fprintf(r.w, `" fill="%v`, canvas.CSSColor(style.StrokeColor))
+		if style.StrokePattern != nil || style.StrokeGradient != nil || style.StrokeColor != canvas.Black {
+			fmt.Fprintf(r.w, `" fill="%v`, strokePaint)
 		}
 		if style.FillRule == canvas.EvenOdd {
 			fmt.Fprintf(r.w, `" fill-rule="evenodd`)
 		}
+		if style.BlendMode != canvas.NormalBlend {
+			fmt.Fprintf(r.w, `" style="mix-blend-mode:%s`, style.BlendMode.CSS())
+		}
 		r.writeClasses(r.w)
 		fmt.Fprintf(r.w, `"/>`)
 	}
 }
 
-func (r *SVG) writeFontStyle(face, faceMain *canvas.FontFace) {
+// PushClip intersects the clipping region with a path using a fill rule and a transformation matrix. It opens a group that is clipped by the path, which is closed by PopClip.
+func (r *SVG) PushClip(path *canvas.Path, fillRule canvas.FillRule, m canvas.Matrix) {
+	id := fmt.Sprintf("c%v", r.clipID)
+	r.clipID++
+
+	path = path.Transform(canvas.Identity.ReflectYAbout(r.height / 2.0).Mul(m))
+	fmt.Fprintf(r.w, `<clipPath id="%s"><path d="%s`, id, path.ToSVG())
+	if fillRule == canvas.EvenOdd {
+		fmt.Fprintf(r.w, `" clip-rule="evenodd`)
+	}
+	fmt.Fprintf(r.w, `"/></clipPath><g clip-path="url(#%s)">`, id)
+	r.groups++
+}
+
+// PopClip removes the last pushed clipping path by closing its group.
+func (r *SVG) PopClip() {
+	r.popGroup()
+}
+
+// PushGroup starts a transparency group, where m is the transformation of the group's mask. It opens a group with the opacity and mask, which is closed by PopGroup.
+func (r *SVG) PushGroup(group canvas.Group, m canvas.Matrix) {
+	refMask := ""
+	if group.Mask != nil {
+		refMask = fmt.Sprintf("m%v", r.maskID)
+		r.maskID++
+
+		// luminance is computed from sRGB colors as by the other renderers, while the default for masks is linearRGB
+		fmt.Fprintf(r.w, `<mask id="%s" maskUnits="userSpaceOnUse" x="0" y="0" width="%v" height="%v" color-interpolation="sRGB`, refMask, num(r.width), num(r.height))
+		if group.MaskType == canvas.AlphaMask {
+			fmt.Fprintf(r.w, `" mask-type="alpha`)
+		}
+		fmt.Fprintf(r.w, `">`)
+		group.Mask.RenderTo(canvas.RendererViewer{Renderer: r, Matrix: m})
+		fmt.Fprintf(r.w, `</mask>`)
+	}
+
+	fmt.Fprintf(r.w, `<g`)
+	if group.Opacity != 1.0 {
+		fmt.Fprintf(r.w, ` opacity="%v"`, num(group.Opacity))
+	}
+	if refMask != "" {
+		fmt.Fprintf(r.w, ` mask="url(#%s)"`, refMask)
+	}
+	if group.BlendMode != canvas.NormalBlend {
+		fmt.Fprintf(r.w, ` style="mix-blend-mode:%s"`, group.BlendMode.CSS())
+	}
+	fmt.Fprintf(r.w, `>`)
+	r.groups++
+}
+
+// PopGroup ends the last started transparency group by closing its group.
+func (r *SVG) PopGroup() {
+	r.popGroup()
+}
+
+func (r *SVG) popGroup() {
+	if r.groups == 0 {
+		return
+	}
+	fmt.Fprintf(r.w, "</g>")
+	r.groups--
+}
+
+// writeGradient writes a gradient element for the given gradient and transformation matrix, and returns a reference to it.
+func (r *SVG) writeGradient(gradient canvas.Gradient, m canvas.Matrix) string {
+	id := fmt.Sprintf("g%v", r.gradientID)
+	r.gradientID++
+
+	var spread canvas.GradientSpread
+	switch g := gradient.(type) {
+	case *canvas.LinearGradient:
+		fmt.Fprintf(r.w, `<linearGradient id="%s" gradientUnits="userSpaceOnUse" x1="%v" y1="%v" x2="%v" y2="%v`, id, num(g.Start.X), num(g.Start.Y), num(g.End.X), num(g.End.Y))
+		spread = g.Spread
+	case *canvas.RadialGradient:
+		fmt.Fprintf(r.w, `<radialGradient id="%s" gradientUnits="userSpaceOnUse" cx="%v" cy="%v" r="%v" fx="%v" fy="%v`, id, num(g.C1.X), num(g.C1.Y), num(g.R1), num(g.C0.X), num(g.C0.Y))
+		if g.R0 != 0.0 {
+			fmt.Fprintf(r.w, `" fr="%v`, num(g.R0))
+		}
+		spread = g.Spread
+	default:
+		panic(fmt.Sprintf("SVG: gradient %T not supported", gradient))
+	}
+
+	m = canvas.Identity.ReflectYAbout(r.height / 2.0).Mul(m)
+	if !m.Equals(canvas.Identity) {
+		fmt.Fprintf(r.w, `" gradientTransform="matrix(%v %v %v %v %v %v)`, num(m[0][0]), num(m[1][0]), num(m[0][1]), num(m[1][1]), num(m[0][2]), num(m[1][2]))
+	}
+	if spread == canvas.ReflectSpread {
+		fmt.Fprintf(r.w, `" spreadMethod="reflect`)
+	} else if spread == canvas.RepeatSpread {
+		fmt.Fprintf(r.w, `" spreadMethod="repeat`)
+	}
+	fmt.Fprintf(r.w, `">`)
+
+	for _, stop := range gradient.GradientStops() {
+		fmt.Fprintf(r.w, `<stop offset="%v`, num(stop.Offset))
+		if stop.Color.A != 0 {
+			a := float64(stop.Color.A) / 255.0
+			col := color.RGBA{uint8(float64(stop.Color.R)/a + 0.5), uint8(float64(stop.Color.G)/a + 0.5), uint8(float64(stop.Color.B)/a + 0.5), 255}
+			if col != canvas.Black {
+				fmt.Fprintf(r.w, `" stop-color="%v`, canvas.CSSColor(col))
+			}
+		}
+		if stop.Color.A != 255 {
+			fmt.Fprintf(r.w, `" stop-opacity="%v`, dec(float64(stop.Color.A)/255.0))
+		}
+		fmt.Fprintf(r.w, `"/>`)
+	}
+
+	if _, ok := gradient.(*canvas.LinearGradient); ok {
+		fmt.Fprintf(r.w, `</linearGradient>`)
+	} else {
+		fmt.Fprintf(r.w, `</radialGradient>`)
+	}
+	return fmt.Sprintf("url(#%s)", id)
+}
+
+// svgPatternKey identifies a pattern element by the pattern and its transformation, so that repeated use of a pattern is written only once.
+type svgPatternKey struct {
+	pattern *canvas.Pattern
+	m       canvas.Matrix
+}
+
+// writePattern writes a pattern element for the given pattern and transformation matrix, and returns a reference to it. The pattern element is written only once for each transformation of the pattern.
+func (r *SVG) writePattern(pattern *canvas.Pattern, m canvas.Matrix) string {
+	key := svgPatternKey{pattern, m}
+	if id, ok := r.patterns[key]; ok {
+		return fmt.Sprintf("url(#%s)", id)
+	}
+	id := fmt.Sprintf("p%v", r.patternID)
+	r.patternID++
+	r.patterns[key] = id
+
+	// the pattern's content is in the coordinate system of the cell with the y-axis pointing down
+	cell := pattern.Cell()
+	m = canvas.Identity.ReflectYAbout(r.height / 2.0).Mul(m).Mul(pattern.Matrix).ReflectYAbout(cell.H / 2.0)
+	fmt.Fprintf(r.w, `<pattern id="%s" patternUnits="userSpaceOnUse" width="%v" height="%v`, id, num(cell.W), num(cell.H))
+	if !m.Equals(canvas.Identity) {
+		fmt.Fprintf(r.w, `" patternTransform="matrix(%v %v %v %v %v %v)`, num(m[0][0]), num(m[1][0]), num(m[0][1]), num(m[1][1]), num(m[0][2]), num(m[1][2]))
+	}
+	fmt.Fprintf(r.w, `">`)
+	pattern.Canvas.RenderTo(canvas.RendererViewer{Renderer: r, Matrix: canvas.Identity.Translate(0.0, r.height-cell.H)})
+	fmt.Fprintf(r.w, `</pattern>`)
+	return fmt.Sprintf("url(#%s)", id)
+}
+
+func (r *SVG) writeFontStyle(w io.Writer, face, faceMain *canvas.FontFace) {
 	differences := 0
 	boldness := face.Style.CSS()
 	if face.Style&canvas.FontItalic != faceMain.Style&canvas.FontItalic {
@@ -286,8 +569,8 @@ func (r *SVG) writeFontStyle(face, faceMain *canvas.FontFace) {
 	if face.Color != faceMain.Color {
 		differences++
 	}
-	if face.Name() != faceMain.Name() || face.Size != faceMain.Size || differences == 3 {
-		fmt.Fprintf(r.w, `" style="font:`)
+	if r.fontFamily(face) != r.fontFamily(faceMain) || face.Size != faceMain.Size || differences == 3 {
+		fmt.Fprintf(w, `" style="font:`)
 
 		buf := &bytes.Buffer{}
 		if face.Style&canvas.FontItalic != faceMain.Style&canvas.FontItalic {
@@ -304,17 +587,17 @@ func (r *SVG) writeFontStyle(face, faceMain *canvas.FontFace) {
 			fmt.Fprintf(buf, ` normal`)
 		}
 
-		fmt.Fprintf(buf, ` %vpx %s`, num(face.Size), face.Name())
+		fmt.Fprintf(buf, ` %vpx %s`, num(face.Size), r.fontFamily(face))
 		buf.ReadByte()
-		buf.WriteTo(r.w)
+		buf.WriteTo(w)
 
 		if face.Color != faceMain.Color {
-			fmt.Fprintf(r.w, `;fill:%v`, canvas.CSSColor(face.Color))
+			fmt.Fprintf(w, `;fill:%v`, canvas.CSSColor(face.Color))
 		}
 	} else if differences == 1 && face.Color != faceMain.Color {
-		fmt.Fprintf(r.w, `" fill="%v`, canvas.CSSColor(face.Color))
+		fmt.Fprintf(w, `" fill="%v`, canvas.CSSColor(face.Color))
 	} else if 0 < differences {
-		fmt.Fprintf(r.w, `" style="`)
+		fmt.Fprintf(w, `" style="`)
 		buf := &bytes.Buffer{}
 		if face.Style&canvas.FontItalic != faceMain.Style&canvas.FontItalic {
 			fmt.Fprintf(buf, `;font-style:italic`)
@@ -331,7 +614,7 @@ func (r *SVG) writeFontStyle(face, faceMain *canvas.FontFace) {
 			fmt.Fprintf(buf, `;fill:%v`, canvas.CSSColor(face.Color))
 		}
 		buf.ReadByte()
-		buf.WriteTo(r.w)
+		buf.WriteTo(w)
 	}
 }
 
@@ -350,15 +633,32 @@ func (r *SVG) RenderText(text *canvas.Text, m canvas.Matrix) {
 	text.WalkSpans(func(x, y float64, span canvas.TextSpan) {
 		if !span.IsText() {
 			for _, obj := range span.Objects {
-				rv := canvas.RendererViewer{r, m.Mul(obj.View(x, y, span.Face))}
+				rv := canvas.RendererViewer{Renderer: r, Matrix: m.Mul(obj.View(x, y, span.Face))}
+				obj.Canvas.RenderTo(rv)
+			}
+		}
+	})
+	text.WalkPathSpans(func(mSpan canvas.Matrix, span canvas.TextSpan) {
+		if !span.IsText() {
+			for _, obj := range span.Objects {
+				rv := canvas.RendererViewer{Renderer: r, Matrix: m.Mul(mSpan).Mul(obj.View(0.0, 0.0, span.Face))}
 				obj.Canvas.RenderTo(rv)
 			}
 		}
 	})
 
+	textPath := text.TextPath()
+	textPathID := ""
+	if textPath != nil {
+		// the path is in the coordinate system of the text element, whose y-axis points down
+		textPathID = fmt.Sprintf("t%v", r.textPathID)
+		r.textPathID++
+		fmt.Fprintf(r.w, `<defs><path id="%s" d="%s"/></defs>`, textPathID, textPath.Path.Transform(canvas.Identity.ReflectY()).ToSVG())
+	}
+
 	faceMain := text.MostCommonFontFace()
 	x0, y0 := 0.0, 0.0
-	if m.IsTranslation() {
+	if m.IsTranslation() && textPath == nil {
 		x0, y0 = m.Pos()
 		y0 = r.height - y0
 		fmt.Fprintf(r.w, `<text x="%v" y="%v`, num(x0), num(y0))
@@ -375,7 +675,7 @@ func (r *SVG) RenderText(text *canvas.Text, m canvas.Matrix) {
 	if faceMain.Variant == canvas.FontSmallcaps {
 		fmt.Fprintf(r.w, ` small-caps`)
 	}
-	fmt.Fprintf(r.w, ` %vpx %s`, num(faceMain.Size), faceMain.Name())
+	fmt.Fprintf(r.w, ` %vpx %s`, num(faceMain.Size), r.fontFamily(faceMain))
 	if faceMain.Color != canvas.Black {
 		fmt.Fprintf(r.w, `;fill:%v`, canvas.CSSColor(faceMain.Color))
 	}
@@ -389,26 +689,74 @@ func (r *SVG) RenderText(text *canvas.Text, m canvas.Matrix) {
 			fmt.Fprintf(r.w, `;text-orientation:upright`)
 		}
 	}
+	if textPath != nil {
+		if textPath.HAlign == canvas.Center || textPath.HAlign == canvas.Middle {
+			fmt.Fprintf(r.w, `;text-anchor:middle`)
+		} else if textPath.HAlign == canvas.Right {
+			fmt.Fprintf(r.w, `;text-anchor:end`)
+		}
+		if textPath.LetterSpacing != 0.0 {
+			fmt.Fprintf(r.w, `;letter-spacing:%vpx`, num(textPath.LetterSpacing))
+		}
+	}
 	r.writeClasses(r.w)
 	fmt.Fprintf(r.w, `">`)
 
-	text.WalkSpans(func(x, y float64, span canvas.TextSpan) {
-		if span.IsText() {
-			if ok, _ := r.fonts[span.Face.Font]; !ok {
-				r.fonts[span.Face.Font] = true
-				r.fontSubset[span.Face.Font] = canvas.NewFontSubsetter()
+	if textPath != nil {
+		// consecutive glyph clusters with the same font face are written as a single span
+		fmt.Fprintf(r.w, `<textPath xlink:href="#%s" startOffset="%v">`, textPathID, num(textPath.Offset))
+		var face *canvas.FontFace
+		open := false
+		text.WalkPathSpans(func(_ canvas.Matrix, span canvas.TextSpan) {
+			if !span.IsText() {
+				if span.IsColorGlyph() {
+					// color glyphs are rendered as objects, but keep their place in the layout of the text
+					if open {
+						fmt.Fprintf(r.w, `</tspan>`)
+						open = false
+					}
+					face = nil
+					r.registerGlyphs(span)
+					fmt.Fprintf(r.w, `<tspan fill-opacity="0`)
+					r.writeFontStyle(r.w, span.Face, faceMain)
+					fmt.Fprintf(r.w, `">`)
+					xml.EscapeText(r.w, []byte(span.Text))
+					fmt.Fprintf(r.w, `</tspan>`)
+				}
+				return
 			}
-
-			subset := r.fontSubset[span.Face.Font]
-			for _, r := range span.Text {
-				glyphID := span.Face.Font.SFNT.GlyphIndex(r)
-				_ = subset.Get(glyphID) // register usage of glyph for subsetting
+			r.registerGlyphs(span)
+			if span.Face != face {
+				if open {
+					fmt.Fprintf(r.w, `</tspan>`)
+				}
+				face = span.Face
+
+				// attributes are written as `" name="value`, which are closed and opened again here
+				attrs := &bytes.Buffer{}
+				r.writeFontStyle(attrs, span.Face, faceMain)
+				r.writeClasses(attrs)
+				if open = 0 < attrs.Len(); open {
+					fmt.Fprintf(r.w, `<tspan%s">`, attrs.Bytes()[1:])
+				}
 			}
+			xml.EscapeText(r.w, []byte(span.Text))
+		})
+		if open {
+			fmt.Fprintf(r.w, `</tspan>`)
+		}
+		fmt.Fprintf(r.w, `</textPath></text>`)
+		return
+	}
+
+	text.WalkSpans(func(x, y float64, span canvas.TextSpan) {
+		if span.IsText() {
+			r.registerGlyphs(span)
 
 			x += x0
 			y = y0 - y
 			fmt.Fprintf(r.w, `<tspan x="%v" y="%v`, num(x), num(y))
-			r.writeFontStyle(span.Face, faceMain)
+			r.writeFontStyle(r.w, span.Face, faceMain)
 			r.writeClasses(r.w)
 			fmt.Fprintf(r.w, `">`)
 			xml.EscapeText(r.w, []byte(span.Text))
@@ -418,6 +766,16 @@ func (r *SVG) RenderText(text *canvas.Text, m canvas.Matrix) {
 	fmt.Fprintf(r.w, `</text>`)
 }
 
+// registerGlyphs registers the usage of the font and glyphs of a span for embedding and subsetting.
+func (r *SVG) registerGlyphs(span canvas.TextSpan) {
+	r.fontFamily(span.Face)
+	subset := r.fontSubset[newSVGFontKey(span.Face.Font)]
+	for _, r := range span.Text {
+		glyphID := span.Face.Font.SFNT.GlyphIndex(r)
+		_ = subset.Get(glyphID) // register usage of glyph for subsetting
+	}
+}
+
 // RenderImage renders an image to the canvas using a transformation matrix.
 func (r *SVG) RenderImage(img image.Image, m canvas.Matrix) {
 	size := img.Bounds().Size()
@@ -443,6 +801,22 @@ func (r *SVG) RenderImage(img image.Image, m canvas.Matrix) {
 	fmt.Fprintf(r.w, `"/>`)
 }
 
+// RenderLink turns the area of a rectangle into a hyperlink to the URI using a transformation matrix. It writes an anchor around a transparent path so that the area can be clicked.
+func (r *SVG) RenderLink(uri string, rect canvas.Rect, m canvas.Matrix) {
+	path := rect.ToPath().Transform(canvas.Identity.ReflectYAbout(r.height / 2.0).Mul(m))
+	fmt.Fprintf(r.w, `<a xlink:href="`)
+	xml.EscapeText(r.w, []byte(uri))
+	fmt.Fprintf(r.w, `"><path d="%s" fill-opacity="0"/></a>`, path.ToSVG())
+}
+
+// RenderDestination defines a named destination at a position using a transformation matrix. It writes an empty element with the name as its ID, which links can refer to by #name.
+func (r *SVG) RenderDestination(name string, pos canvas.Point, m canvas.Matrix) {
+	pos = canvas.Identity.ReflectYAbout(r.height / 2.0).Mul(m).Dot(pos)
+	fmt.Fprintf(r.w, `<g id="`)
+	xml.EscapeText(r.w, []byte(name))
+	fmt.Fprintf(r.w, `" transform="translate(%v %v)"/>`, num(pos.X), num(pos.Y))
+}
+
 // return a WriterTo, a refMask and a mimetype
 func (r *SVG) encodableImage(img image.Image) (func(io.Writer) error, string, string) {
 	if cimg, ok := img.(canvas.Image); ok && 0 < len(cimg.Bytes) {
@@ -481,7 +855,7 @@ func (r *SVG) renderOpacityMask(img image.Image) (image.Image, string) {
 	r.maskID++
 
 	size := img.Bounds().Size()
-	fmt.Fprintf(r.w, `<mask id="%s"><image width="%d" height="%d" xlink:href="data:image/jpeg;base64,`, refMask, size.X, size.Y)
+	fmt.Fprintf(r.w, `<mask id="%s" color-interpolation="sRGB"><image width="%d" height="%d" xlink:href="data:image/jpeg;base64,`, refMask, size.X, size.Y)
 
 	encoder := base64.NewEncoder(base64.StdEncoding, r.w)
 	if err := jpeg.Encode(encoder, mask, nil); err != nil {
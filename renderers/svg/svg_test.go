@@ -1,7 +1,15 @@
 package svg
 
 import (
+	"bytes"
+	"encoding/base64"
+	"image/color"
+	"strings"
 	"testing"
+
+	"github.com/LaminoidStudio/Canvas"
+	canvasFont "github.com/LaminoidStudio/Canvas/font"
+	"github.com/tdewolff/test"
 )
 
 func TestSVGText(t *testing.T) {
@@ -30,3 +38,151 @@ func TestSVGText(t *testing.T) {
 	//s := regexp.MustCompile(`base64,.+'`).ReplaceAllString(buf.String(), "base64,'") // remove embedded font
 	//test.String(t, s, `<style>`+"\n"+`@font-face{font-family:'dejavu-serif';src:url('data:font/truetype;base64,');}`+"\n"+`@font-face{font-family:'eb-garamond';src:url('data:font/opentype;base64,');}`+"\n"+`</style><text x="0" y="0" style="font: 12px dejavu-serif"><tspan x="0" y="7.421875" style="font:8px dejavu-serif">dejaVu8</tspan><tspan x="0" y="20.453125" letter-spacing="1" style="font-style:italic;fill:#f00">glyphspacing</tspan><tspan x="0" y="33.725625" style="font:700 6.996px dejavu-serif">dejaVu12sub</tspan><tspan x="0" y="38.5" style="font:700 10px eb-garamond">garamond10</tspan></text><path d="M0 22.703125H91.71875V21.803125H0z" fill="#f00"/>`)
 }
+
+func TestSVGGradient(t *testing.T) {
+	gradient := canvas.NewRadialGradient(canvas.Point{X: 5.0, Y: 5.0}, 0.0, canvas.Point{X: 5.0, Y: 5.0}, 5.0)
+	gradient.Add(0.0, canvas.Red)
+	gradient.Add(1.0, color.RGBA{0, 0, 128, 128})
+	gradient.Spread = canvas.RepeatSpread
+
+	style := canvas.DefaultStyle
+	style.FillGradient = gradient
+
+	buf := &bytes.Buffer{}
+	svg := New(buf, 10, 10, nil)
+	svg.RenderPath(canvas.Rectangle(10.0, 10.0), style, canvas.Identity)
+	test.String(t, buf.String(), `<svg version="1.1" width="10mm" height="10mm" viewBox="0 0 10 10" xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><radialGradient id="g0" gradientUnits="userSpaceOnUse" cx="5" cy="5" r="5" fx="5" fy="5" gradientTransform="matrix(1 0 0 -1 0 10)" spreadMethod="repeat"><stop offset="0" stop-color="#f00"/><stop offset="1" stop-color="#00f" stop-opacity=".50196078"/></radialGradient><path d="M0 10H10V0H0z" fill="url(#g0)"/>`)
+}
+
+func TestSVGPattern(t *testing.T) {
+	cell := canvas.New(2.0, 2.0)
+	ctx := canvas.NewContext(cell)
+	ctx.SetFillColor(canvas.Red)
+	ctx.DrawPath(0.0, 0.0, canvas.Rectangle(1.0, 1.0))
+
+	style := canvas.DefaultStyle
+	style.FillPattern = canvas.NewPattern(cell, canvas.Identity, 1.0, 0.0)
+
+	buf := &bytes.Buffer{}
+	svg := New(buf, 10, 10, nil)
+	svg.RenderPath(canvas.Rectangle(10.0, 10.0), style, canvas.Identity)
+	svg.RenderPath(canvas.Rectangle(5.0, 5.0), style, canvas.Identity)
+	test.String(t, buf.String(), `<svg version="1.1" width="10mm" height="10mm" viewBox="0 0 10 10" xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><pattern id="p0" patternUnits="userSpaceOnUse" width="3" height="2" patternTransform="matrix(1 0 0 1 0 8)"><path d="M0 2H1V1H0z" fill="#f00"/></pattern><path d="M0 10H10V0H0z" fill="url(#p0)"/><path d="M0 10H5V5H0z" fill="url(#p0)"/>`)
+}
+
+func TestSVGClip(t *testing.T) {
+	buf := &bytes.Buffer{}
+	svg := New(buf, 10, 10, nil)
+	svg.PushClip(canvas.Rectangle(5.0, 5.0), canvas.EvenOdd, canvas.Identity)
+	svg.RenderPath(canvas.Rectangle(10.0, 10.0), canvas.DefaultStyle, canvas.Identity)
+	svg.Close()
+	test.String(t, buf.String(), `<svg version="1.1" width="10mm" height="10mm" viewBox="0 0 10 10" xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><clipPath id="c0"><path d="M0 10H5V5H0z" clip-rule="evenodd"/></clipPath><g clip-path="url(#c0)"><path d="M0 10H10V0H0z"/></g></svg>`)
+}
+
+func TestSVGGroup(t *testing.T) {
+	mask := canvas.New(10, 10)
+	mask.RenderPath(canvas.Rectangle(10.0, 5.0), canvas.DefaultStyle, canvas.Identity)
+
+	buf := &bytes.Buffer{}
+	svg := New(buf, 10, 10, nil)
+	svg.PushGroup(canvas.Group{Opacity: 0.5, Mask: mask, MaskType: canvas.AlphaMask}, canvas.Identity)
+	svg.RenderPath(canvas.Rectangle(10.0, 10.0), canvas.DefaultStyle, canvas.Identity)
+	svg.PopGroup()
+	svg.Close()
+	test.String(t, buf.String(), `<svg version="1.1" width="10mm" height="10mm" viewBox="0 0 10 10" xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><mask id="m0" maskUnits="userSpaceOnUse" x="0" y="0" width="10" height="10" color-interpolation="sRGB" mask-type="alpha"><path d="M0 10H10V5H0z"/></mask><g opacity=".5" mask="url(#m0)"><path d="M0 10H10V0H0z"/></g></svg>`)
+}
+
+func TestSVGBlendMode(t *testing.T) {
+	style := canvas.DefaultStyle
+	style.BlendMode = canvas.ColorDodgeBlend
+
+	buf := &bytes.Buffer{}
+	svg := New(buf, 10, 10, nil)
+	svg.RenderPath(canvas.Rectangle(10.0, 10.0), style, canvas.Identity)
+	svg.PushGroup(canvas.Group{Opacity: 1.0, BlendMode: canvas.MultiplyBlend}, canvas.Identity)
+	svg.PopGroup()
+	test.String(t, buf.String(), `<svg version="1.1" width="10mm" height="10mm" viewBox="0 0 10 10" xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><path d="M0 10H10V0H0z" style="mix-blend-mode:color-dodge"/><g style="mix-blend-mode:multiply"></g>`)
+}
+
+func TestSVGLink(t *testing.T) {
+	buf := &bytes.Buffer{}
+	svg := New(buf, 10, 10, nil)
+	svg.RenderLink("https://example.com/?a=1&b=2", canvas.Rect{X: 0.0, Y: 0.0, W: 5.0, H: 5.0}, canvas.Identity)
+	svg.RenderDestination("top", canvas.Point{X: 0.0, Y: 10.0}, canvas.Identity)
+	svg.Close()
+	test.String(t, buf.String(), `<svg version="1.1" width="10mm" height="10mm" viewBox="0 0 10 10" xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><a xlink:href="https://example.com/?a=1&amp;b=2"><path d="M0 10H5V5H0z" fill-opacity="0"/></a><g id="top" transform="translate(0 0)"/></svg>`)
+}
+
+func TestSVGTextPath(t *testing.T) {
+	font, err := canvas.LoadFontFile("../../resources/DejaVuSerif.ttf", canvas.FontRegular)
+	test.Error(t, err)
+	face := font.Face(12.0, canvas.Black)
+
+	path := &canvas.Path{}
+	path.MoveTo(0.0, 5.0)
+	path.LineTo(10.0, 5.0)
+	text := canvas.NewTextOnPath(face, "a&b", path, 5.0, canvas.Center, canvas.LeftOfPath, 0.5)
+
+	buf := &bytes.Buffer{}
+	svg := New(buf, 10, 10, &Options{})
+	svg.RenderText(text, canvas.Identity.Translate(0.0, 1.0))
+	test.String(t, buf.String(), `<svg version="1.1" width="10mm" height="10mm" viewBox="0 0 10 10" xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><defs><path id="t0" d="M0 -5H10"/></defs><text transform="translate(0,9)" style="font: 4.2333333px DejaVuSerif;text-anchor:middle;letter-spacing:.5px"><textPath xlink:href="#t0" startOffset="5">a&amp;b</textPath></text>`)
+}
+
+func TestSVGVariableFont(t *testing.T) {
+	font, err := canvas.LoadFontFile("../../resources/AdobeVFPrototype.otf", canvas.FontRegular)
+	test.Error(t, err)
+	font.SetVariations("wght=900")
+	face := font.Face(12.0, canvas.Black)
+
+	buf := &bytes.Buffer{}
+	svg := New(buf, 100, 100, nil)
+	svg.RenderText(canvas.NewTextLine(face, "A", canvas.Left), canvas.Identity)
+	svg.Close()
+
+	// the embedded font is a static instance at the variations of the font
+	s := buf.String()
+	i := strings.Index(s, "base64,") + 7
+	b, err := base64.StdEncoding.DecodeString(s[i : i+strings.Index(s[i:], "'")])
+	test.Error(t, err)
+	sfnt, err := canvasFont.ParseFont(b, 0)
+	test.Error(t, err)
+	test.That(t, !sfnt.IsVariable())
+	test.T(t, sfnt.GlyphAdvance(sfnt.GlyphIndex('A')), font.SFNT.GlyphAdvance(font.SFNT.GlyphIndex('A')))
+	test.T(t, sfnt.GlyphAdvance(sfnt.GlyphIndex('A')), uint16(680))
+}
+
+func TestSVGVariableFontInstances(t *testing.T) {
+	font, err := canvas.LoadFontFile("../../resources/AdobeVFPrototype.otf", canvas.FontRegular)
+	test.Error(t, err)
+	face := font.Face(12.0, canvas.Black)
+
+	buf := &bytes.Buffer{}
+	svg := New(buf, 100, 100, &Options{EmbedFonts: true, SubsetFonts: true, FontFormat: FontSFNT})
+	font.SetVariations("wght=900")
+	svg.RenderText(canvas.NewTextLine(face, "A", canvas.Left), canvas.Identity)
+	font.SetVariations("wght=200")
+	svg.RenderText(canvas.NewTextLine(face, "A", canvas.Left), canvas.Identity)
+	test.Error(t, svg.Close())
+
+	// each set of variations is embedded as a separate static instance with its own font family name
+	s := buf.String()
+	name := font.Name()
+	test.That(t, strings.Contains(s, "px "+name+`">`), "first text must use the font name")
+	test.That(t, strings.Contains(s, "px "+name+`-1">`), "second text must use the name of the second instance")
+
+	advances := map[string]uint16{}
+	for _, family := range []string{name, name + "-1"} {
+		i := strings.Index(s, "font-family:'"+family+"'")
+		test.That(t, i != -1, "font must be embedded")
+		i += strings.Index(s[i:], "base64,") + 7
+		b, err := base64.StdEncoding.DecodeString(s[i : i+strings.Index(s[i:], "'")])
+		test.Error(t, err)
+		sfnt, err := canvasFont.ParseSFNT(b, 0)
+		test.Error(t, err)
+		test.That(t, !sfnt.IsVariable())
+		advances[family] = sfnt.GlyphAdvance(sfnt.GlyphIndex('A'))
+	}
+	test.T(t, advances[name], uint16(680))
+	test.T(t, advances[name+"-1"], uint16(653))
+}
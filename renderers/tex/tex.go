@@ -6,6 +6,7 @@ import (
 	"image/color"
 	"io"
 	"math"
+	"strings"
 
 	"github.com/LaminoidStudio/Canvas"
 )
@@ -18,6 +19,13 @@ type TeX struct {
 	style      canvas.Style
 	miterLimit float64
 	colors     map[color.RGBA]string
+
+	scopes []texScope // styles saved by PushClip and PushGroup
+}
+
+type texScope struct {
+	style canvas.Style
+	group bool
 }
 
 // New returns a TeX/PGF renderer.
@@ -37,6 +45,9 @@ func New(w io.Writer, width, height float64) *TeX {
 
 // Close finished and closes the TeX file.
 func (r *TeX) Close() error {
+	for range r.scopes {
+		r.popScope()
+	}
 	_, err := fmt.Fprintf(r.w, "\n\\end{pgfpicture}")
 	return err
 }
@@ -164,6 +175,13 @@ func (r *TeX) setStrokeJoin(joiner canvas.Joiner) {
 	}
 }
 
+func (r *TeX) setBlendMode(mode canvas.BlendMode) {
+	if mode != r.style.BlendMode {
+		fmt.Fprintf(r.w, "\n\\pgfsetblendmode{%v}", strings.ReplaceAll(mode.CSS(), "-", " "))
+		r.style.BlendMode = mode
+	}
+}
+
 func (r *TeX) setDashes(offset float64, dashes []float64) {
 	if !float64sEqual(dashes, r.style.Dashes) || offset != r.style.DashOffset {
 		if 0 < len(dashes) {
@@ -185,6 +203,30 @@ func (r *TeX) RenderPath(path *canvas.Path, style canvas.Style, m canvas.Matrix)
 	if path.Empty() {
 		return
 	}
+	r.setBlendMode(style.BlendMode)
+
+	// TODO: (TeX) write patterns natively using PGF patterns
+	if style.HasFill() && style.FillPattern != nil {
+		style.FillPattern.RenderFill(r, path, style.FillRule, m)
+		style.FillColor, style.FillGradient, style.FillPattern = canvas.Transparent, nil, nil
+	}
+	if style.HasStroke() && style.StrokePattern != nil {
+		stroke := path
+		if style.IsDashed() {
+			stroke = stroke.Dash(style.DashOffset, style.Dashes...)
+		}
+		stroke = stroke.Stroke(style.StrokeWidth, style.StrokeCapper, style.StrokeJoiner)
+		style.StrokePattern.RenderFill(r, stroke, canvas.NonZero, m)
+		style.StrokeColor, style.StrokeGradient, style.StrokePattern = canvas.Transparent, nil, nil
+	}
+
+	// TODO: (TeX) write gradients natively using PGF shadings
+	if style.FillGradient != nil {
+		style.FillColor = style.FillGradient.GradientStops().Average()
+	}
+	if style.StrokeGradient != nil {
+		style.StrokeColor = style.StrokeGradient.GradientStops().Average()
+	}
 
 	strokeUnsupported := false
 	if m.IsSimilarity() {
@@ -235,6 +277,57 @@ func (r *TeX) RenderPath(path *canvas.Path, style canvas.Style, m canvas.Matrix)
 	}
 }
 
+// PushClip intersects the clipping region with a path using a fill rule and a transformation matrix.
+func (r *TeX) PushClip(path *canvas.Path, fillRule canvas.FillRule, m canvas.Matrix) {
+	r.scopes = append(r.scopes, texScope{style: r.style})
+	fmt.Fprintf(r.w, "\n\\begin{pgfscope}")
+	r.writePath(path.Transform(m))
+	if fillRule == canvas.EvenOdd {
+		fmt.Fprintf(r.w, "\n\\pgfseteorule\\pgfusepath{clip}\\pgfsetnonzerorule")
+	} else {
+		fmt.Fprintf(r.w, "\n\\pgfusepath{clip}")
+	}
+}
+
+// PopClip removes the last pushed clipping path.
+func (r *TeX) PopClip() {
+	r.popScope()
+}
+
+// PushGroup starts a transparency group, where m is the transformation of the group's mask. The group is painted with its opacity when PopGroup is called.
+func (r *TeX) PushGroup(group canvas.Group, m canvas.Matrix) {
+	// TODO: (TeX) use fadings for the group's mask
+	r.scopes = append(r.scopes, texScope{style: r.style, group: true})
+	fmt.Fprintf(r.w, "\n\\begin{pgfscope}\\pgfsetfillopacity{%v}\\pgfsetstrokeopacity{%v}", dec(group.Opacity), dec(group.Opacity))
+	r.setBlendMode(group.BlendMode)
+	fmt.Fprintf(r.w, "\\begin{pgftransparencygroup}")
+	fmt.Fprintf(r.w, "\n\\pgfsetfillopacity{1}\\pgfsetstrokeopacity{1}")
+	r.style.FillColor.A = 255
+	r.style.StrokeColor.A = 255
+	if group.BlendMode != canvas.NormalBlend {
+		r.setBlendMode(canvas.NormalBlend)
+	}
+}
+
+// PopGroup ends the last started transparency group.
+func (r *TeX) PopGroup() {
+	r.popScope()
+}
+
+func (r *TeX) popScope() {
+	if len(r.scopes) == 0 {
+		return
+	}
+	scope := r.scopes[len(r.scopes)-1]
+	if scope.group {
+		fmt.Fprintf(r.w, "\n\\end{pgftransparencygroup}\\end{pgfscope}")
+	} else {
+		fmt.Fprintf(r.w, "\n\\end{pgfscope}")
+	}
+	r.style = scope.style
+	r.scopes = r.scopes[:len(r.scopes)-1]
+}
+
 // RenderText renders a text object to the canvas using a transformation matrix.
 func (r *TeX) RenderText(text *canvas.Text, m canvas.Matrix) {
 	// TODO: (TeX) write text natively
@@ -0,0 +1,1460 @@
+package canvas
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/colornames"
+)
+
+const mmPerPx = 25.4 / 96.0
+
+const xlinkNamespace = "http://www.w3.org/1999/xlink"
+
+// svgNode is an element of an SVG document. Character data is stored as a node with an empty tag.
+type svgNode struct {
+	tag      string
+	attrs    map[string]string
+	children []*svgNode
+	text     string
+}
+
+// svgInheritedProperties are the presentation attributes that are inherited by child elements.
+var svgInheritedProperties = map[string]bool{
+	"fill":              true,
+	"fill-opacity":      true,
+	"fill-rule":         true,
+	"stroke":            true,
+	"stroke-width":      true,
+	"stroke-opacity":    true,
+	"stroke-linecap":    true,
+	"stroke-linejoin":   true,
+	"stroke-miterlimit": true,
+	"stroke-dasharray":  true,
+	"stroke-dashoffset": true,
+	"clip-rule":         true,
+	"color":             true,
+	"font-family":       true,
+	"font-size":         true,
+	"font-weight":       true,
+	"font-style":        true,
+	"text-anchor":       true,
+	"visibility":        true,
+}
+
+// svgProperties are the presentation attributes that are not inherited by child elements.
+var svgProperties = map[string]bool{
+	"opacity":        true,
+	"display":        true,
+	"clip-path":      true,
+	"mix-blend-mode": true,
+	"overflow":       true,
+	"stop-color":     true,
+	"stop-opacity":   true,
+}
+
+// ParseSVGDocument parses an SVG document and returns a canvas with its contents. The canvas has the size of the SVG document in millimeters, where one pixel (user unit) equals 1/96 inch. It supports groups and transformations, the basic shapes and paths, presentation attributes and inline styles, linear and radial gradients, clipping paths, opacity, <use> and <defs> elements, text, and embedded images. Fonts for text are looked up in the system's fonts, see ParseSVGDocumentWithFonts to specify font families explicitly.
+func ParseSVGDocument(r io.Reader) (*Canvas, error) {
+	return ParseSVGDocumentWithFonts(r, nil)
+}
+
+// ParseSVGDocumentWithFonts parses an SVG document like ParseSVGDocument, but uses the given font families for the font-family names of text elements. Font families that are not given are loaded from the system's fonts, and text is skipped when no font can be found.
+func ParseSVGDocumentWithFonts(r io.Reader, fonts map[string]*FontFamily) (*Canvas, error) {
+	root, err := parseSVGTree(r)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &svgParser{
+		ids:   map[string]*svgNode{},
+		fonts: map[string]*FontFamily{},
+	}
+	for name, family := range fonts {
+		p.fonts[name] = family
+	}
+	p.collectIDs(root)
+
+	viewBox := parseSVGNumbers(root.attrs["viewBox"])
+	if len(viewBox) != 4 || viewBox[2] <= 0.0 || viewBox[3] <= 0.0 {
+		viewBox = nil
+	}
+	width, height := 300.0, 150.0
+	if viewBox != nil {
+		width, height = viewBox[2], viewBox[3]
+	}
+	if w, ok := parseSVGLength(root.attrs["width"], 0.0, 16.0); ok && !strings.HasSuffix(root.attrs["width"], "%") {
+		if viewBox != nil && root.attrs["height"] == "" {
+			height = w * viewBox[3] / viewBox[2]
+		}
+		width = w
+	}
+	if h, ok := parseSVGLength(root.attrs["height"], 0.0, 16.0); ok && !strings.HasSuffix(root.attrs["height"], "%") {
+		if viewBox != nil && root.attrs["width"] == "" {
+			width = h * viewBox[2] / viewBox[3]
+		}
+		height = h
+	}
+
+	p.c = New(width*mmPerPx, height*mmPerPx)
+	p.viewports = [][2]float64{{width, height}}
+	if viewBox != nil {
+		p.viewports[0] = [2]float64{viewBox[2], viewBox[3]}
+	}
+	m := Identity.Translate(0.0, height*mmPerPx).Scale(mmPerPx, -mmPerPx)
+	m = m.Mul(svgViewBoxTransform(viewBox, root.attrs["preserveAspectRatio"], width, height))
+
+	props := p.properties(nil, root)
+	if props["display"] != "none" {
+		p.renderScope(root, props, m, func(m Matrix) {
+			p.renderChildren(root, props, m)
+		})
+	}
+	return p.c, nil
+}
+
+// renderSVGGlyph renders the element with ID "glyph" followed by the glyph ID of an SVG document from a font's SVG table onto the canvas. The matrix m transforms from font units, where the y-axis points down, to the coordinates of the canvas, em is the number of font units per em that is used for percentages, and col is the current color of the text. It returns false if the document does not contain the glyph.
+func renderSVGGlyph(c *Canvas, doc []byte, glyphID uint16, em float64, col color.RGBA, m Matrix) (bool, error) {
+	root, err := parseSVGTree(bytes.NewReader(doc))
+	if err != nil {
+		return false, err
+	}
+
+	p := &svgParser{
+		c:         c,
+		ids:       map[string]*svgNode{},
+		fonts:     map[string]*FontFamily{},
+		viewports: [][2]float64{{em, em}},
+	}
+	p.collectIDs(root)
+	node, ok := p.ids["glyph"+strconv.Itoa(int(glyphID))]
+	if !ok {
+		return false, nil
+	}
+
+	// the glyph inherits the properties and transformations of its ancestors
+	var ancestors func(*svgNode) []*svgNode
+	ancestors = func(parent *svgNode) []*svgNode {
+		for _, child := range parent.children {
+			if child == node {
+				return []*svgNode{parent}
+			} else if path := ancestors(child); path != nil {
+				return append([]*svgNode{parent}, path...)
+			}
+		}
+		return nil
+	}
+
+	props := map[string]string{"color": CSSColor(col).String()}
+	if node == root {
+		// the viewBox of the root element is ignored, as its coordinates are font units
+		props = p.properties(props, root)
+		if props["display"] != "none" {
+			p.renderScope(root, props, m, func(m Matrix) {
+				p.renderChildren(root, props, m)
+			})
+		}
+		return true, nil
+	}
+	for i, ancestor := range ancestors(root) {
+		props = p.properties(props, ancestor)
+		if props["display"] == "none" {
+			return true, nil
+		} else if 0 < i {
+			m = m.Mul(parseSVGTransform(ancestor.attrs["transform"]))
+		}
+	}
+	p.render(node, props, m)
+	return true, nil
+}
+
+func parseSVGTree(r io.Reader) (*svgNode, error) {
+	dec := xml.NewDecoder(r)
+	dec.Entity = xml.HTMLEntity
+
+	var root *svgNode
+	stack := []*svgNode{}
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("svg: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			node := &svgNode{
+				tag:   t.Name.Local,
+				attrs: map[string]string{},
+			}
+			for _, attr := range t.Attr {
+				if attr.Name.Space == "" || attr.Name.Space == xlinkNamespace || attr.Name.Space == "xml" {
+					if _, ok := node.attrs[attr.Name.Local]; !ok || attr.Name.Space == "" {
+						node.attrs[attr.Name.Local] = attr.Value
+					}
+				}
+			}
+			if 0 < len(stack) {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, node)
+			} else if root == nil {
+				root = node
+			}
+			stack = append(stack, node)
+		case xml.EndElement:
+			if 0 < len(stack) {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			if 0 < len(stack) {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, &svgNode{text: string(t)})
+			}
+		}
+	}
+	if root == nil || root.tag != "svg" {
+		return nil, fmt.Errorf("svg: root element must be svg")
+	}
+	return root, nil
+}
+
+type svgParser struct {
+	c         *Canvas
+	ids       map[string]*svgNode
+	fonts     map[string]*FontFamily
+	viewports [][2]float64 // width and height of the viewports in user units
+	depth     int          // nesting depth of <use> elements
+}
+
+func (p *svgParser) collectIDs(node *svgNode) {
+	if id, ok := node.attrs["id"]; ok {
+		if _, ok := p.ids[id]; !ok {
+			p.ids[id] = node
+		}
+	}
+	for _, child := range node.children {
+		if child.tag != "" {
+			p.collectIDs(child)
+		}
+	}
+}
+
+// ref returns the element referenced by an IRI such as "#id".
+func (p *svgParser) ref(iri string) *svgNode {
+	iri = strings.TrimSpace(iri)
+	if !strings.HasPrefix(iri, "#") {
+		return nil
+	}
+	return p.ids[iri[1:]]
+}
+
+// refURL returns the element referenced by a functional IRI such as "url(#id)".
+func (p *svgParser) refURL(s string) *svgNode {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "url(") {
+		return nil
+	}
+	end := strings.IndexByte(s, ')')
+	if end == -1 {
+		return nil
+	}
+	return p.ref(strings.Trim(strings.TrimSpace(s[4:end]), `"'`))
+}
+
+func (p *svgParser) viewport() (float64, float64) {
+	vp := p.viewports[len(p.viewports)-1]
+	return vp[0], vp[1]
+}
+
+// length parses a length attribute with percentages relative to the viewport width (dir 0), height (dir 1), or diagonal (dir 2).
+func (p *svgParser) length(s string, dir int, props map[string]string, def float64) float64 {
+	w, h := p.viewport()
+	ref := math.Sqrt((w*w + h*h) / 2.0)
+	if dir == 0 {
+		ref = w
+	} else if dir == 1 {
+		ref = h
+	}
+	if v, ok := parseSVGLength(s, ref, svgFontSize(props)); ok {
+		return v
+	}
+	return def
+}
+
+// properties returns the properties of the element, which are inherited from the parent properties and overridden by its presentation attributes and inline style, respectively.
+func (p *svgParser) properties(parent map[string]string, node *svgNode) map[string]string {
+	props := map[string]string{}
+	for key, val := range parent {
+		if svgInheritedProperties[key] {
+			props[key] = val
+		}
+	}
+	for key, val := range node.attrs {
+		if svgInheritedProperties[key] || svgProperties[key] {
+			props[key] = strings.TrimSpace(val)
+		}
+	}
+	for _, decl := range strings.Split(node.attrs["style"], ";") {
+		if colon := strings.IndexByte(decl, ':'); colon != -1 {
+			key := strings.ToLower(strings.TrimSpace(decl[:colon]))
+			val := strings.TrimSpace(decl[colon+1:])
+			val = strings.TrimSpace(strings.TrimSuffix(val, "!important"))
+			if svgInheritedProperties[key] || svgProperties[key] {
+				props[key] = val
+			}
+		}
+	}
+	for key, val := range props {
+		if val == "inherit" {
+			if parentVal, ok := parent[key]; ok {
+				props[key] = parentVal
+			} else {
+				delete(props, key)
+			}
+		}
+	}
+
+	// resolve relative font sizes
+	if size, ok := props["font-size"]; ok && size != parent["font-size"] {
+		parentSize := svgFontSize(parent)
+		fontSize := parentSize
+		switch size {
+		case "xx-small":
+			fontSize = 9.0
+		case "x-small":
+			fontSize = 10.0
+		case "small":
+			fontSize = 13.0
+		case "medium":
+			fontSize = 16.0
+		case "large":
+			fontSize = 18.0
+		case "x-large":
+			fontSize = 24.0
+		case "xx-large":
+			fontSize = 32.0
+		case "smaller":
+			fontSize = parentSize / 1.2
+		case "larger":
+			fontSize = parentSize * 1.2
+		default:
+			if v, ok := parseSVGLength(size, parentSize, parentSize); ok {
+				fontSize = v
+			}
+		}
+		props["font-size"] = strconv.FormatFloat(fontSize, 'g', -1, 64)
+	}
+	return props
+}
+
+// renderScope applies the transformation, opacity, blend mode, and clipping path of the element and calls render within that scope.
+func (p *svgParser) renderScope(node *svgNode, props map[string]string, m Matrix, render func(Matrix)) {
+	m = m.Mul(parseSVGTransform(node.attrs["transform"]))
+
+	opacity := parseSVGOpacity(props["opacity"])
+	blendMode := parseSVGBlendMode(props["mix-blend-mode"])
+	if opacity <= 0.0 {
+		return
+	} else if opacity < 1.0 || blendMode != NormalBlend {
+		p.c.PushGroup(Group{Opacity: opacity, BlendMode: blendMode}, m)
+		defer p.c.PopGroup()
+	}
+
+	if clip := p.refURL(props["clip-path"]); clip != nil && clip.tag == "clipPath" {
+		path, fillRule := p.clipPath(clip)
+		if path == nil {
+			return
+		}
+		clipView := m.Mul(parseSVGTransform(clip.attrs["transform"]))
+		if clip.attrs["clipPathUnits"] == "objectBoundingBox" {
+			bounds := p.bounds(node, Identity)
+			if bounds.W <= 0.0 || bounds.H <= 0.0 {
+				return
+			}
+			clipView = m.Translate(bounds.X, bounds.Y).Scale(bounds.W, bounds.H).Mul(parseSVGTransform(clip.attrs["transform"]))
+		}
+		p.c.PushClip(path, fillRule, clipView)
+		defer p.c.PopClip()
+	}
+	render(m)
+}
+
+func (p *svgParser) renderChildren(node *svgNode, props map[string]string, m Matrix) {
+	for _, child := range node.children {
+		if child.tag != "" {
+			p.render(child, props, m)
+		}
+	}
+}
+
+func (p *svgParser) render(node *svgNode, parent map[string]string, m Matrix) {
+	props := p.properties(parent, node)
+	if props["display"] == "none" {
+		return
+	}
+
+	switch node.tag {
+	case "g", "a":
+		p.renderScope(node, props, m, func(m Matrix) {
+			p.renderChildren(node, props, m)
+		})
+	case "switch":
+		p.renderScope(node, props, m, func(m Matrix) {
+			for _, child := range node.children {
+				if svgRenderable(child.tag) {
+					p.render(child, props, m)
+					break
+				}
+			}
+		})
+	case "svg":
+		p.renderScope(node, props, m, func(m Matrix) {
+			x := p.length(node.attrs["x"], 0, props, 0.0)
+			y := p.length(node.attrs["y"], 1, props, 0.0)
+			w := p.length(node.attrs["width"], 0, props, p.length("100%", 0, props, 0.0))
+			h := p.length(node.attrs["height"], 1, props, p.length("100%", 1, props, 0.0))
+			p.renderViewport(node, props, m.Translate(x, y), w, h)
+		})
+	case "use":
+		ref := p.ref(node.attrs["href"])
+		if ref == nil || 16 < p.depth {
+			return
+		}
+		p.depth++
+		p.renderScope(node, props, m, func(m Matrix) {
+			x := p.length(node.attrs["x"], 0, props, 0.0)
+			y := p.length(node.attrs["y"], 1, props, 0.0)
+			m = m.Translate(x, y)
+			if ref.tag == "symbol" || ref.tag == "svg" {
+				refProps := p.properties(props, ref)
+				if refProps["display"] == "none" {
+					return
+				}
+				w := p.length(node.attrs["width"], 0, props, p.length(ref.attrs["width"], 0, props, p.length("100%", 0, props, 0.0)))
+				h := p.length(node.attrs["height"], 1, props, p.length(ref.attrs["height"], 1, props, p.length("100%", 1, props, 0.0)))
+				p.renderScope(ref, refProps, m, func(m Matrix) {
+					p.renderViewport(ref, refProps, m, w, h)
+				})
+			} else if svgRenderable(ref.tag) {
+				p.render(ref, props, m)
+			}
+		})
+		p.depth--
+	case "rect", "circle", "ellipse", "line", "polyline", "polygon", "path":
+		path := p.shape(node, props)
+		if path == nil || path.Empty() {
+			return
+		}
+		p.renderScope(node, props, m, func(m Matrix) {
+			p.drawPath(path, props, m)
+		})
+	case "text":
+		p.renderScope(node, props, m, func(m Matrix) {
+			p.drawText(node, props, m)
+		})
+	case "image":
+		p.renderScope(node, props, m, func(m Matrix) {
+			p.drawImage(node, props, m)
+		})
+	}
+}
+
+func svgRenderable(tag string) bool {
+	switch tag {
+	case "g", "a", "switch", "svg", "use", "rect", "circle", "ellipse", "line", "polyline", "polygon", "path", "text", "image":
+		return true
+	}
+	return false
+}
+
+// renderViewport renders the children of an <svg> or <symbol> element in a new viewport of size (w,h) at the origin.
+func (p *svgParser) renderViewport(node *svgNode, props map[string]string, m Matrix, w, h float64) {
+	if w <= 0.0 || h <= 0.0 {
+		return
+	}
+	viewBox := parseSVGNumbers(node.attrs["viewBox"])
+	if len(viewBox) != 4 || viewBox[2] <= 0.0 || viewBox[3] <= 0.0 {
+		viewBox = nil
+	}
+	if overflow := props["overflow"]; overflow != "visible" && overflow != "auto" {
+		p.c.PushClip(Rectangle(w, h), NonZero, m)
+		defer p.c.PopClip()
+	}
+
+	if viewBox != nil {
+		p.viewports = append(p.viewports, [2]float64{viewBox[2], viewBox[3]})
+	} else {
+		p.viewports = append(p.viewports, [2]float64{w, h})
+	}
+	p.renderChildren(node, props, m.Mul(svgViewBoxTransform(viewBox, node.attrs["preserveAspectRatio"], w, h)))
+	p.viewports = p.viewports[:len(p.viewports)-1]
+}
+
+// shape returns the path of a basic shape or path element in user units.
+func (p *svgParser) shape(node *svgNode, props map[string]string) *Path {
+	switch node.tag {
+	case "rect":
+		x := p.length(node.attrs["x"], 0, props, 0.0)
+		y := p.length(node.attrs["y"], 1, props, 0.0)
+		w := p.length(node.attrs["width"], 0, props, 0.0)
+		h := p.length(node.attrs["height"], 1, props, 0.0)
+		if w <= 0.0 || h <= 0.0 {
+			return nil
+		}
+		rx := p.length(node.attrs["rx"], 0, props, -1.0)
+		ry := p.length(node.attrs["ry"], 1, props, -1.0)
+		if rx < 0.0 && ry < 0.0 {
+			rx, ry = 0.0, 0.0
+		} else if rx < 0.0 {
+			rx = ry
+		} else if ry < 0.0 {
+			ry = rx
+		}
+		rx = math.Min(rx, w/2.0)
+		ry = math.Min(ry, h/2.0)
+		if rx <= 0.0 || ry <= 0.0 {
+			return Rectangle(w, h).Translate(x, y)
+		}
+		path := &Path{}
+		path.MoveTo(x+rx, y)
+		path.LineTo(x+w-rx, y)
+		path.ArcTo(rx, ry, 0.0, false, true, x+w, y+ry)
+		path.LineTo(x+w, y+h-ry)
+		path.ArcTo(rx, ry, 0.0, false, true, x+w-rx, y+h)
+		path.LineTo(x+rx, y+h)
+		path.ArcTo(rx, ry, 0.0, false, true, x, y+h-ry)
+		path.LineTo(x, y+ry)
+		path.ArcTo(rx, ry, 0.0, false, true, x+rx, y)
+		path.Close()
+		return path
+	case "circle":
+		cx := p.length(node.attrs["cx"], 0, props, 0.0)
+		cy := p.length(node.attrs["cy"], 1, props, 0.0)
+		r := p.length(node.attrs["r"], 2, props, 0.0)
+		if r <= 0.0 {
+			return nil
+		}
+		return Circle(r).Translate(cx, cy)
+	case "ellipse":
+		cx := p.length(node.attrs["cx"], 0, props, 0.0)
+		cy := p.length(node.attrs["cy"], 1, props, 0.0)
+		rx := p.length(node.attrs["rx"], 0, props, -1.0)
+		ry := p.length(node.attrs["ry"], 1, props, -1.0)
+		if rx < 0.0 {
+			rx = ry
+		} else if ry < 0.0 {
+			ry = rx
+		}
+		if rx <= 0.0 || ry <= 0.0 {
+			return nil
+		}
+		return Ellipse(rx, ry).Translate(cx, cy)
+	case "line":
+		path := &Path{}
+		path.MoveTo(p.length(node.attrs["x1"], 0, props, 0.0), p.length(node.attrs["y1"], 1, props, 0.0))
+		path.LineTo(p.length(node.attrs["x2"], 0, props, 0.0), p.length(node.attrs["y2"], 1, props, 0.0))
+		return path
+	case "polyline", "polygon":
+		points := parseSVGNumbers(node.attrs["points"])
+		if len(points) < 4 {
+			return nil
+		}
+		path := &Path{}
+		path.MoveTo(points[0], points[1])
+		for i := 2; i+1 < len(points); i += 2 {
+			path.LineTo(points[i], points[i+1])
+		}
+		if node.tag == "polygon" {
+			path.Close()
+		}
+		return path
+	case "path":
+		path, err := ParseSVG(node.attrs["d"])
+		if err != nil {
+			return nil
+		}
+		return path
+	}
+	return nil
+}
+
+// bounds returns the bounding box of the element's geometry after transformation by m.
+func (p *svgParser) bounds(node *svgNode, m Matrix) Rect {
+	var bounds Rect
+	first := true
+	var add func(*svgNode, Matrix)
+	add = func(node *svgNode, m Matrix) {
+		if node.tag == "g" || node.tag == "a" || node.tag == "switch" {
+			for _, child := range node.children {
+				if child.tag != "" {
+					add(child, m.Mul(parseSVGTransform(child.attrs["transform"])))
+				}
+			}
+		} else if path := p.shape(node, nil); path != nil && !path.Empty() {
+			rect := path.Transform(m).Bounds()
+			if first {
+				bounds = rect
+				first = false
+			} else {
+				bounds = bounds.Add(rect)
+			}
+		}
+	}
+	add(node, m)
+	return bounds
+}
+
+// clipPath returns the union of the shapes of a <clipPath> element, or nil if it is empty.
+func (p *svgParser) clipPath(clip *svgNode) (*Path, FillRule) {
+	props := p.properties(nil, clip)
+	var path *Path
+	fillRule := NonZero
+	for _, child := range clip.children {
+		node, m := child, parseSVGTransform(child.attrs["transform"])
+		if node.tag == "use" {
+			if node = p.ref(child.attrs["href"]); node == nil {
+				continue
+			}
+			x := p.length(child.attrs["x"], 0, props, 0.0)
+			y := p.length(child.attrs["y"], 1, props, 0.0)
+			m = m.Translate(x, y).Mul(parseSVGTransform(node.attrs["transform"]))
+		}
+		childProps := p.properties(props, node)
+		if childProps["display"] == "none" || childProps["visibility"] == "hidden" || childProps["visibility"] == "collapse" {
+			continue
+		}
+		shape := p.shape(node, childProps)
+		if shape == nil || shape.Empty() {
+			continue
+		}
+		shape = shape.Transform(m)
+		if path == nil {
+			path = shape
+			if childProps["clip-rule"] == "evenodd" {
+				fillRule = EvenOdd
+			}
+		} else {
+			// TODO: respect the clip-rule of each shape in the union
+			path = path.Or(shape)
+			fillRule = NonZero
+		}
+	}
+	return path, fillRule
+}
+
+// svgPaint is a paint server for fills and strokes. Gradients are defined in the coordinate system given by m.
+type svgPaint struct {
+	color    color.RGBA
+	gradient Gradient
+	m        Matrix
+}
+
+func (paint svgPaint) IsNone() bool {
+	return paint.gradient == nil && paint.color.A == 0
+}
+
+// paint parses a fill or stroke value for the given path.
+func (p *svgParser) paint(s string, opacity float64, props map[string]string, path *Path) svgPaint {
+	s = strings.TrimSpace(s)
+	if s == "none" {
+		return svgPaint{}
+	} else if strings.HasPrefix(s, "url(") {
+		end := strings.IndexByte(s, ')')
+		if node := p.refURL(s); node != nil && (node.tag == "linearGradient" || node.tag == "radialGradient") {
+			return p.gradient(node, opacity, props, path)
+		} else if end != -1 && strings.TrimSpace(s[end+1:]) != "" {
+			return p.paint(s[end+1:], opacity, props, path)
+		}
+		return svgPaint{}
+	}
+	col, ok := parseSVGColor(s, props)
+	if !ok {
+		return svgPaint{}
+	}
+	return svgPaint{color: svgPremultiply(col, opacity)}
+}
+
+// gradient returns the paint for a <linearGradient> or <radialGradient> element, following href references to inherit attributes and stops.
+func (p *svgParser) gradient(node *svgNode, opacity float64, props map[string]string, path *Path) svgPaint {
+	attrs := map[string]string{}
+	var stops []*svgNode
+	for n, i := node, 0; n != nil && i < 16; n, i = p.ref(n.attrs["href"]), i+1 {
+		if n.tag != "linearGradient" && n.tag != "radialGradient" {
+			break
+		}
+		for key, val := range n.attrs {
+			if _, ok := attrs[key]; !ok {
+				attrs[key] = val
+			}
+		}
+		if stops == nil {
+			for _, child := range n.children {
+				if child.tag == "stop" {
+					stops = append(stops, child)
+				}
+			}
+		}
+	}
+
+	var gradStops Stops
+	prev := 0.0
+	for _, stop := range stops {
+		stopProps := p.properties(map[string]string{"color": props["color"]}, stop)
+		offset := prev
+		if s := strings.TrimSpace(stop.attrs["offset"]); strings.HasSuffix(s, "%") {
+			if v, err := strconv.ParseFloat(s[:len(s)-1], 64); err == nil {
+				offset = v / 100.0
+			}
+		} else if v, err := strconv.ParseFloat(s, 64); err == nil {
+			offset = v
+		}
+		offset = math.Max(prev, math.Min(1.0, offset))
+		prev = offset
+
+		col := color.NRGBA{0, 0, 0, 255}
+		if c, ok := parseSVGColor(stopProps["stop-color"], stopProps); ok {
+			col = c
+		}
+		gradStops = append(gradStops, Stop{
+			Offset: offset,
+			Color:  svgPremultiply(col, opacity*parseSVGOpacity(stopProps["stop-opacity"])),
+		})
+	}
+	if len(gradStops) == 0 {
+		return svgPaint{}
+	} else if len(gradStops) == 1 {
+		return svgPaint{color: gradStops[0].Color}
+	}
+
+	m := Identity
+	length := func(s string, dir int, def string) float64 {
+		if s == "" {
+			s = def
+		}
+		return p.length(s, dir, props, 0.0)
+	}
+	if attrs["gradientUnits"] != "userSpaceOnUse" {
+		bounds := path.Bounds()
+		if bounds.W <= 0.0 || bounds.H <= 0.0 {
+			return svgPaint{}
+		}
+		m = m.Translate(bounds.X, bounds.Y).Scale(bounds.W, bounds.H)
+		length = func(s string, dir int, def string) float64 {
+			if s == "" {
+				s = def
+			}
+			v, _ := parseSVGLength(s, 1.0, 0.0)
+			return v
+		}
+	}
+	m = m.Mul(parseSVGTransform(attrs["gradientTransform"]))
+
+	spread := PadSpread
+	if attrs["spreadMethod"] == "reflect" {
+		spread = ReflectSpread
+	} else if attrs["spreadMethod"] == "repeat" {
+		spread = RepeatSpread
+	}
+
+	var gradient Gradient
+	if node.tag == "linearGradient" {
+		start := Point{length(attrs["x1"], 0, "0%"), length(attrs["y1"], 1, "0%")}
+		end := Point{length(attrs["x2"], 0, "100%"), length(attrs["y2"], 1, "0%")}
+		if start.Equals(end) {
+			return svgPaint{color: gradStops[len(gradStops)-1].Color}
+		}
+		gradient = &LinearGradient{
+			Start:  start,
+			End:    end,
+			Stops:  gradStops,
+			Spread: spread,
+		}
+	} else {
+		cx := length(attrs["cx"], 0, "50%")
+		cy := length(attrs["cy"], 1, "50%")
+		r := length(attrs["r"], 2, "50%")
+		if r <= 0.0 {
+			return svgPaint{color: gradStops[len(gradStops)-1].Color}
+		}
+		fx := cx
+		if attrs["fx"] != "" {
+			fx = length(attrs["fx"], 0, "50%")
+		}
+		fy := cy
+		if attrs["fy"] != "" {
+			fy = length(attrs["fy"], 1, "50%")
+		}
+		gradient = &RadialGradient{
+			C0:     Point{fx, fy},
+			R0:     math.Max(0.0, length(attrs["fr"], 2, "0%")),
+			C1:     Point{cx, cy},
+			R1:     r,
+			Stops:  gradStops,
+			Spread: spread,
+		}
+	}
+	if m.IsSimilarity() {
+		// bake uniform scaling, rotation, and translation into the gradient
+		scale := math.Sqrt(math.Abs(m.Det()))
+		if linear, ok := gradient.(*LinearGradient); ok {
+			linear.Start = m.Dot(linear.Start)
+			linear.End = m.Dot(linear.End)
+		} else if radial, ok := gradient.(*RadialGradient); ok {
+			radial.C0 = m.Dot(radial.C0)
+			radial.R0 *= scale
+			radial.C1 = m.Dot(radial.C1)
+			radial.R1 *= scale
+		}
+		m = Identity
+	}
+	return svgPaint{gradient: gradient, m: m}
+}
+
+func (p *svgParser) drawPath(path *Path, props map[string]string, m Matrix) {
+	if props["visibility"] == "hidden" || props["visibility"] == "collapse" {
+		return
+	}
+
+	fillValue, ok := props["fill"]
+	if !ok {
+		fillValue = "black"
+	}
+	fill := p.paint(fillValue, parseSVGOpacity(props["fill-opacity"]), props, path)
+	stroke := p.paint(props["stroke"], parseSVGOpacity(props["stroke-opacity"]), props, path)
+
+	style := DefaultStyle
+	style.FillColor = Transparent
+	style.StrokeColor = Transparent
+	if props["fill-rule"] == "evenodd" {
+		style.FillRule = EvenOdd
+	} else {
+		style.FillRule = NonZero
+	}
+	style.StrokeWidth = p.length(props["stroke-width"], 2, props, 1.0)
+	switch props["stroke-linecap"] {
+	case "round":
+		style.StrokeCapper = RoundCap
+	case "square":
+		style.StrokeCapper = SquareCap
+	default:
+		style.StrokeCapper = ButtCap
+	}
+	miterLimit := 4.0
+	if v, err := strconv.ParseFloat(props["stroke-miterlimit"], 64); err == nil && 1.0 <= v {
+		miterLimit = v
+	}
+	switch props["stroke-linejoin"] {
+	case "round":
+		style.StrokeJoiner = RoundJoin
+	case "bevel":
+		style.StrokeJoiner = BevelJoin
+	case "arcs":
+		style.StrokeJoiner = ArcsJoiner{BevelJoin, miterLimit}
+	default:
+		style.StrokeJoiner = MiterJoiner{BevelJoin, miterLimit * style.StrokeWidth / 2.0}
+	}
+	style.DashOffset = p.length(props["stroke-dashoffset"], 2, props, 0.0)
+	style.Dashes = nil
+	if dasharray := props["stroke-dasharray"]; dasharray != "" && dasharray != "none" {
+		total := 0.0
+		for _, field := range strings.FieldsFunc(dasharray, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r' }) {
+			v, ok := parseSVGLength(field, p.length("100%", 2, props, 0.0), svgFontSize(props))
+			if !ok || v < 0.0 {
+				style.Dashes = nil
+				total = 0.0
+				break
+			}
+			style.Dashes = append(style.Dashes, v)
+			total += v
+		}
+		if total <= 0.0 {
+			style.Dashes = nil
+		} else if len(style.Dashes)%2 == 1 {
+			style.Dashes = append(style.Dashes, style.Dashes...)
+		}
+	}
+	if style.StrokeWidth <= 0.0 {
+		stroke = svgPaint{}
+	}
+
+	if fill.gradient == nil && stroke.gradient == nil || (fill.gradient == nil || fill.m.Equals(Identity)) && (stroke.gradient == nil || stroke.m.Equals(Identity)) {
+		if fill.IsNone() && stroke.IsNone() {
+			return
+		}
+		style.FillColor, style.FillGradient = fill.color, fill.gradient
+		style.StrokeColor, style.StrokeGradient = stroke.color, stroke.gradient
+		p.c.RenderPath(path, style, m)
+		return
+	}
+
+	// gradients that are transformed with respect to user space are drawn in their own coordinate system, with strokes converted to fills
+	if !fill.IsNone() {
+		fillStyle := style
+		fillStyle.FillColor, fillStyle.FillGradient = fill.color, fill.gradient
+		fillStyle.StrokeColor, fillStyle.StrokeGradient = Transparent, nil
+		if fill.gradient != nil {
+			p.c.RenderPath(path.Transform(fill.m.Inv()), fillStyle, m.Mul(fill.m))
+		} else {
+			p.c.RenderPath(path, fillStyle, m)
+		}
+	}
+	if !stroke.IsNone() {
+		strokeStyle := style
+		strokeStyle.FillColor, strokeStyle.FillGradient = Transparent, nil
+		strokeStyle.StrokeColor, strokeStyle.StrokeGradient = stroke.color, stroke.gradient
+		if stroke.gradient != nil {
+			strokePath := path
+			if style.IsDashed() {
+				strokePath = strokePath.Dash(style.DashOffset, style.Dashes...)
+			}
+			strokePath = strokePath.Stroke(style.StrokeWidth, style.StrokeCapper, style.StrokeJoiner)
+
+			strokeStyle = DefaultStyle
+			strokeStyle.FillColor = Transparent
+			strokeStyle.FillGradient = stroke.gradient
+			strokeStyle.StrokeColor = Transparent
+			strokeStyle.FillRule = NonZero
+			p.c.RenderPath(strokePath.Transform(stroke.m.Inv()), strokeStyle, m.Mul(stroke.m))
+		} else {
+			p.c.RenderPath(path, strokeStyle, m)
+		}
+	}
+}
+
+// svgTextRun is a run of text of a single style at a position in user units.
+type svgTextRun struct {
+	face   *FontFace
+	s      string
+	x, y   float64
+	chunk  bool // starts a new text chunk, which is aligned separately
+	anchor string
+}
+
+type svgTextState struct {
+	x, y      float64
+	anchor    string
+	newChunk  bool
+	start     bool // no text has been added yet
+	needSpace bool // collapsed whitespace precedes the next text
+	runs      []svgTextRun
+}
+
+func (p *svgParser) drawText(node *svgNode, props map[string]string, m Matrix) {
+	state := &svgTextState{start: true}
+	p.textPosition(node, props, state)
+	state.newChunk = true
+	p.textRuns(node, props, state)
+
+	// align the text chunks by their text anchor
+	for i := 0; i < len(state.runs); {
+		j := i + 1
+		for j < len(state.runs) && !state.runs[j].chunk {
+			j++
+		}
+		last := state.runs[j-1]
+		width := last.x + last.face.TextWidth(last.s) - state.runs[i].x
+		dx := 0.0
+		if state.runs[i].anchor == "middle" {
+			dx = -width / 2.0
+		} else if state.runs[i].anchor == "end" {
+			dx = -width
+		}
+		for _, run := range state.runs[i:j] {
+			p.c.RenderText(NewTextLine(run.face, run.s, Left), m.Translate(run.x+dx, run.y).Scale(1.0, -1.0))
+		}
+		i = j
+	}
+}
+
+// textPosition updates the current text position by the x, y, dx, and dy attributes of a <text> or <tspan> element.
+func (p *svgParser) textPosition(node *svgNode, props map[string]string, state *svgTextState) {
+	if x := parseSVGLengths(node.attrs["x"]); 0 < len(x) {
+		state.x = p.length(x[0], 0, props, state.x)
+		state.newChunk = true
+	}
+	if y := parseSVGLengths(node.attrs["y"]); 0 < len(y) {
+		state.y = p.length(y[0], 1, props, state.y)
+		state.newChunk = true
+	}
+	if dx := parseSVGLengths(node.attrs["dx"]); 0 < len(dx) {
+		state.x += p.length(dx[0], 0, props, 0.0)
+	}
+	if dy := parseSVGLengths(node.attrs["dy"]); 0 < len(dy) {
+		state.y += p.length(dy[0], 1, props, 0.0)
+	}
+	if state.newChunk || state.start {
+		state.anchor = props["text-anchor"]
+	}
+}
+
+func (p *svgParser) textRuns(node *svgNode, props map[string]string, state *svgTextState) {
+	for _, child := range node.children {
+		if child.tag == "" {
+			words := strings.Fields(child.text)
+			if 0 < len(child.text) && strings.ContainsRune(" \t\r\n", rune(child.text[0])) && !state.start {
+				state.needSpace = true
+			}
+			if len(words) == 0 {
+				continue
+			}
+			s := strings.Join(words, " ")
+			if state.needSpace {
+				s = " " + s
+			}
+			state.needSpace = strings.ContainsRune(" \t\r\n", rune(child.text[len(child.text)-1]))
+			state.start = false
+
+			face := p.face(props)
+			if face == nil || props["visibility"] == "hidden" || props["visibility"] == "collapse" {
+				if face != nil {
+					state.x += face.TextWidth(s)
+				}
+				continue
+			}
+			state.runs = append(state.runs, svgTextRun{
+				face:   face,
+				s:      s,
+				x:      state.x,
+				y:      state.y,
+				chunk:  state.newChunk || len(state.runs) == 0,
+				anchor: state.anchor,
+			})
+			state.newChunk = false
+			state.x += face.TextWidth(s)
+		} else if child.tag == "tspan" || child.tag == "a" {
+			childProps := p.properties(props, child)
+			if childProps["display"] == "none" {
+				continue
+			}
+			p.textPosition(child, childProps, state)
+			p.textRuns(child, childProps, state)
+		}
+	}
+}
+
+// face returns the font face for the font properties, or nil if no font family could be found or if the text has no fill.
+func (p *svgParser) face(props map[string]string) *FontFace {
+	fillValue, ok := props["fill"]
+	if !ok {
+		fillValue = "black"
+	}
+	fill := p.paint(fillValue, parseSVGOpacity(props["fill-opacity"]), props, Rectangle(1.0, 1.0))
+	col := fill.color
+	if fill.gradient != nil {
+		col = fill.gradient.GradientStops().Average()
+	} else if col.A == 0 {
+		return nil
+	}
+
+	var family *FontFamily
+	names := props["font-family"]
+	if names == "" {
+		names = "serif"
+	}
+	for _, name := range strings.Split(names, ",") {
+		name = strings.Trim(strings.TrimSpace(name), `"'`)
+		if name == "" {
+			continue
+		}
+		var ok bool
+		if family, ok = p.fonts[name]; !ok {
+			family = NewFontFamily(name)
+			if err := family.LoadLocalFont(name, FontRegular); err != nil {
+				family = nil
+			}
+			p.fonts[name] = family
+		}
+		if family != nil && family.fonts[FontRegular] != nil {
+			break
+		}
+		family = nil
+	}
+	if family == nil {
+		return nil
+	}
+
+	style := FontRegular
+	switch props["font-weight"] {
+	case "100":
+		style = FontExtraLight
+	case "200":
+		style = FontLight
+	case "300", "lighter":
+		style = FontBook
+	case "500":
+		style = FontMedium
+	case "600":
+		style = FontSemibold
+	case "700", "bold", "bolder":
+		style = FontBold
+	case "800":
+		style = FontBlack
+	case "900":
+		style = FontExtraBlack
+	}
+	if fontStyle := props["font-style"]; fontStyle == "italic" || strings.HasPrefix(fontStyle, "oblique") {
+		style |= FontItalic
+	}
+	return family.Face(svgFontSize(props)*ptPerMm, col, style, FontNormal)
+}
+
+func (p *svgParser) drawImage(node *svgNode, props map[string]string, m Matrix) {
+	if props["visibility"] == "hidden" || props["visibility"] == "collapse" {
+		return
+	}
+	img, err := parseSVGDataURI(node.attrs["href"])
+	if err != nil {
+		return
+	}
+	imgW, imgH := float64(img.Bounds().Dx()), float64(img.Bounds().Dy())
+	if imgW == 0.0 || imgH == 0.0 {
+		return
+	}
+
+	x := p.length(node.attrs["x"], 0, props, 0.0)
+	y := p.length(node.attrs["y"], 1, props, 0.0)
+	w := p.length(node.attrs["width"], 0, props, -1.0)
+	h := p.length(node.attrs["height"], 1, props, -1.0)
+	if w < 0.0 && h < 0.0 {
+		w, h = imgW, imgH
+	} else if w < 0.0 {
+		w = h * imgW / imgH
+	} else if h < 0.0 {
+		h = w * imgH / imgW
+	}
+	if w <= 0.0 || h <= 0.0 {
+		return
+	}
+
+	par := node.attrs["preserveAspectRatio"]
+	m = m.Translate(x, y)
+	if strings.HasSuffix(strings.TrimSpace(par), "slice") {
+		p.c.PushClip(Rectangle(w, h), NonZero, m)
+		defer p.c.PopClip()
+	}
+	m = m.Mul(svgViewBoxTransform([]float64{0.0, 0.0, imgW, imgH}, par, w, h))
+	p.c.RenderImage(img, m.Translate(0.0, imgH).Scale(1.0, -1.0))
+}
+
+////////////////////////////////////////////////////////////////
+
+// svgViewBoxTransform returns the transformation from the viewBox to a viewport of size (w,h), see https://www.w3.org/TR/SVG2/coords.html#ComputingAViewportsTransform.
+func svgViewBoxTransform(viewBox []float64, preserveAspectRatio string, w, h float64) Matrix {
+	if len(viewBox) != 4 || viewBox[2] <= 0.0 || viewBox[3] <= 0.0 {
+		return Identity
+	}
+	sx, sy := w/viewBox[2], h/viewBox[3]
+
+	fields := strings.Fields(preserveAspectRatio)
+	if 0 < len(fields) && fields[0] == "defer" {
+		fields = fields[1:]
+	}
+	align, slice := "xMidYMid", false
+	if 0 < len(fields) {
+		align = fields[0]
+	}
+	if 1 < len(fields) && fields[1] == "slice" {
+		slice = true
+	}
+
+	tx, ty := 0.0, 0.0
+	if align != "none" {
+		if slice {
+			sx = math.Max(sx, sy)
+		} else {
+			sx = math.Min(sx, sy)
+		}
+		sy = sx
+		if strings.Contains(align, "xMid") {
+			tx = (w - viewBox[2]*sx) / 2.0
+		} else if strings.Contains(align, "xMax") {
+			tx = w - viewBox[2]*sx
+		}
+		if strings.Contains(align, "YMid") {
+			ty = (h - viewBox[3]*sy) / 2.0
+		} else if strings.Contains(align, "YMax") {
+			ty = h - viewBox[3]*sy
+		}
+	}
+	return Identity.Translate(tx, ty).Scale(sx, sy).Translate(-viewBox[0], -viewBox[1])
+}
+
+// parseSVGTransform parses the transform attribute, see https://www.w3.org/TR/SVG11/coords.html#TransformAttribute. Invalid transformations are ignored.
+func parseSVGTransform(s string) Matrix {
+	m := Identity
+	for {
+		open, end := strings.IndexByte(s, '('), strings.IndexByte(s, ')')
+		if open == -1 || end < open {
+			break
+		}
+		name := strings.Trim(s[:open], " \t\r\n,")
+		v := parseSVGNumbers(s[open+1 : end])
+		s = s[end+1:]
+
+		switch {
+		case name == "matrix" && len(v) == 6:
+			m = m.Mul(Matrix{{v[0], v[2], v[4]}, {v[1], v[3], v[5]}})
+		case name == "translate" && len(v) == 1:
+			m = m.Translate(v[0], 0.0)
+		case name == "translate" && len(v) == 2:
+			m = m.Translate(v[0], v[1])
+		case name == "scale" && len(v) == 1:
+			m = m.Scale(v[0], v[0])
+		case name == "scale" && len(v) == 2:
+			m = m.Scale(v[0], v[1])
+		case name == "rotate" && len(v) == 1:
+			m = m.Rotate(v[0])
+		case name == "rotate" && len(v) == 3:
+			m = m.RotateAbout(v[0], v[1], v[2])
+		case name == "skewX" && len(v) == 1:
+			m = m.Shear(math.Tan(v[0]*math.Pi/180.0), 0.0)
+		case name == "skewY" && len(v) == 1:
+			m = m.Shear(0.0, math.Tan(v[0]*math.Pi/180.0))
+		}
+	}
+	return m
+}
+
+// parseSVGNumbers parses a list of numbers separated by whitespace and/or commas, stopping at the first invalid number.
+func parseSVGNumbers(s string) []float64 {
+	isDigit := func(c byte) bool {
+		return '0' <= c && c <= '9'
+	}
+
+	nums := []float64{}
+	for i := 0; i < len(s); {
+		for i < len(s) && (s[i] == ' ' || s[i] == ',' || s[i] == '\t' || s[i] == '\n' || s[i] == '\r') {
+			i++
+		}
+		if i == len(s) {
+			break
+		}
+
+		j := i
+		if s[j] == '+' || s[j] == '-' {
+			j++
+		}
+		dot := false
+		for j < len(s) && (isDigit(s[j]) || s[j] == '.' && !dot) {
+			if s[j] == '.' {
+				dot = true
+			}
+			j++
+		}
+		if j < len(s) && (s[j] == 'e' || s[j] == 'E') {
+			k := j + 1
+			if k < len(s) && (s[k] == '+' || s[k] == '-') {
+				k++
+			}
+			if k < len(s) && isDigit(s[k]) {
+				for j = k; j < len(s) && isDigit(s[j]); j++ {
+				}
+			}
+		}
+
+		f, err := strconv.ParseFloat(s[i:j], 64)
+		if err != nil {
+			break
+		}
+		nums = append(nums, f)
+		i = j
+	}
+	return nums
+}
+
+// parseSVGLengths splits a list of lengths separated by whitespace and/or commas.
+func parseSVGLengths(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	})
+}
+
+// parseSVGLength parses a length in user units (pixels), with percentages relative to ref and font-relative units relative to fontSize.
+func parseSVGLength(s string, ref, fontSize float64) (float64, bool) {
+	s = strings.TrimSpace(s)
+	if strings.HasSuffix(s, "%") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(s[:len(s)-1]), 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			return 0.0, false
+		}
+		return v * ref / 100.0, true
+	}
+
+	scale := 1.0
+	if 2 < len(s) {
+		switch s[len(s)-2:] {
+		case "px":
+			scale = 1.0
+		case "pt":
+			scale = 96.0 / 72.0
+		case "pc":
+			scale = 16.0
+		case "mm":
+			scale = 96.0 / 25.4
+		case "cm":
+			scale = 96.0 / 2.54
+		case "in":
+			scale = 96.0
+		case "em":
+			scale = fontSize
+		case "ex":
+			scale = fontSize / 2.0
+		default:
+			scale = 0.0
+		}
+		if scale != 0.0 {
+			s = s[:len(s)-2]
+		} else {
+			scale = 1.0
+		}
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0.0, false
+	}
+	return v * scale, true
+}
+
+func svgFontSize(props map[string]string) float64 {
+	if v, err := strconv.ParseFloat(props["font-size"], 64); err == nil {
+		return v
+	}
+	return 16.0
+}
+
+// parseSVGOpacity parses an opacity value as a number or percentage, which defaults to one.
+func parseSVGOpacity(s string) float64 {
+	s = strings.TrimSpace(s)
+	scale := 1.0
+	if strings.HasSuffix(s, "%") {
+		s, scale = s[:len(s)-1], 0.01
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 1.0
+	}
+	return math.Max(0.0, math.Min(1.0, v*scale))
+}
+
+func parseSVGBlendMode(s string) BlendMode {
+	for mode := NormalBlend; mode <= LuminosityBlend; mode++ {
+		if mode.CSS() == s {
+			return mode
+		}
+	}
+	return NormalBlend
+}
+
+// parseSVGColor parses a color keyword, hexadecimal color, or rgb() or rgba() function.
+func parseSVGColor(s string, props map[string]string) (color.NRGBA, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "currentcolor" {
+		if c := props["color"]; c != "" && strings.ToLower(strings.TrimSpace(c)) != "currentcolor" {
+			return parseSVGColor(c, props)
+		}
+		return color.NRGBA{0, 0, 0, 255}, true
+	} else if s == "transparent" {
+		return color.NRGBA{}, true
+	} else if strings.HasPrefix(s, "#") {
+		h := []byte(s[1:])
+		for i, c := range h {
+			if '0' <= c && c <= '9' {
+				h[i] = c - '0'
+			} else if 'a' <= c && c <= 'f' {
+				h[i] = c - 'a' + 10
+			} else {
+				return color.NRGBA{}, false
+			}
+		}
+		switch len(h) {
+		case 3:
+			return color.NRGBA{h[0] * 17, h[1] * 17, h[2] * 17, 255}, true
+		case 4:
+			return color.NRGBA{h[0] * 17, h[1] * 17, h[2] * 17, h[3] * 17}, true
+		case 6:
+			return color.NRGBA{h[0]<<4 | h[1], h[2]<<4 | h[3], h[4]<<4 | h[5], 255}, true
+		case 8:
+			return color.NRGBA{h[0]<<4 | h[1], h[2]<<4 | h[3], h[4]<<4 | h[5], h[6]<<4 | h[7]}, true
+		}
+		return color.NRGBA{}, false
+	} else if strings.HasPrefix(s, "rgb(") || strings.HasPrefix(s, "rgba(") {
+		open, end := strings.IndexByte(s, '('), strings.IndexByte(s, ')')
+		if end == -1 {
+			return color.NRGBA{}, false
+		}
+		args := strings.FieldsFunc(s[open+1:end], func(r rune) bool {
+			return r == ',' || r == ' ' || r == '/' || r == '\t'
+		})
+		if len(args) != 3 && len(args) != 4 {
+			return color.NRGBA{}, false
+		}
+		var c [4]uint8
+		c[3] = 255
+		for i, arg := range args {
+			if i == 3 {
+				c[i] = uint8(parseSVGOpacity(arg)*255.0 + 0.5)
+				continue
+			}
+			v, ok := parseSVGLength(arg, 255.0, 0.0)
+			if !ok {
+				return color.NRGBA{}, false
+			}
+			c[i] = uint8(math.Max(0.0, math.Min(255.0, v)) + 0.5)
+		}
+		return color.NRGBA{c[0], c[1], c[2], c[3]}, true
+	} else if col, ok := colornames.Map[s]; ok {
+		return color.NRGBA{col.R, col.G, col.B, col.A}, true
+	}
+	return color.NRGBA{}, false
+}
+
+// svgPremultiply returns the alpha-premultiplied color with its alpha multiplied by opacity.
+func svgPremultiply(col color.NRGBA, opacity float64) color.RGBA {
+	col.A = uint8(float64(col.A)*opacity + 0.5)
+	return color.RGBAModel.Convert(col).(color.RGBA)
+}
+
+// parseSVGDataURI decodes an image embedded as a data URI, see RFC 2397.
+func parseSVGDataURI(uri string) (image.Image, error) {
+	uri = strings.TrimSpace(uri)
+	comma := strings.IndexByte(uri, ',')
+	if !strings.HasPrefix(uri, "data:") || comma == -1 {
+		return nil, fmt.Errorf("svg: unsupported image reference")
+	}
+
+	var b []byte
+	if strings.HasSuffix(uri[:comma], ";base64") {
+		data := strings.Map(func(r rune) rune {
+			if r == ' ' || r == '\t' || r == '\n' || r == '\r' {
+				return -1
+			}
+			return r
+		}, uri[comma+1:])
+		var err error
+		if b, err = base64.StdEncoding.DecodeString(data); err != nil {
+			if b, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(data, "=")); err != nil {
+				return nil, fmt.Errorf("svg: %w", err)
+			}
+		}
+	} else {
+		data, err := url.PathUnescape(uri[comma+1:])
+		if err != nil {
+			return nil, fmt.Errorf("svg: %w", err)
+		}
+		b = []byte(data)
+	}
+	img, _, err := image.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("svg: %w", err)
+	}
+	return img, nil
+}
@@ -0,0 +1,288 @@
+package canvas
+
+import (
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tdewolff/test"
+)
+
+func svgLayers(t *testing.T, s string) (*Canvas, []layer) {
+	c, err := ParseSVGDocument(strings.NewReader(s))
+	test.Error(t, err)
+	return c, c.layers[0]
+}
+
+func TestParseSVGDocument(t *testing.T) {
+	c, layers := svgLayers(t, `<svg xmlns="http://www.w3.org/2000/svg" width="96" height="48" viewBox="0 0 200 100">
+	<g transform="translate(10,20)" fill="red" stroke="blue">
+		<rect width="50" height="30" style="stroke:none"/>
+		<circle cx="100" cy="50" r="10" fill="#00f" fill-opacity=".5" stroke-width="2"/>
+	</g>
+	<g display="none"><rect width="10" height="10"/></g>
+	<line x1="0" y1="0" x2="100" y2="0" stroke="rgb(0,100%,0)" stroke-dasharray="5 5" stroke-linecap="round"/>
+	<polygon points="0,0 10,0 10-10" fill-rule="evenodd"/>
+</svg>`)
+	test.Float(t, c.W, 25.4)
+	test.Float(t, c.H, 12.7)
+	test.T(t, len(layers), 4)
+
+	// y-axis is flipped and viewBox is scaled to millimeters
+	test.T(t, layers[0].path.Transform(layers[0].m).Bounds(), Rect{1.27, 6.35, 6.35, 3.81})
+	test.T(t, layers[0].style.FillColor, Red)
+	test.That(t, !layers[0].style.HasStroke(), "rect must have no stroke")
+
+	test.T(t, layers[1].style.FillColor, color.RGBA{0, 0, 128, 128})
+	test.T(t, layers[1].style.StrokeColor, Blue)
+	test.Float(t, layers[1].style.StrokeWidth, 2.0)
+
+	test.T(t, layers[2].style.FillColor, Black)
+	test.T(t, layers[2].style.StrokeColor, Lime)
+	test.T(t, layers[2].style.Dashes, []float64{5.0, 5.0})
+	test.T(t, layers[2].style.StrokeCapper, RoundCap)
+
+	test.T(t, layers[3].style.FillRule, EvenOdd)
+	test.T(t, layers[3].path.Transform(layers[3].m).Bounds(), Rect{0.0, 12.7, 1.27, 1.27})
+}
+
+func TestParseSVGDocumentErrors(t *testing.T) {
+	_, err := ParseSVGDocument(strings.NewReader(`<svg><rect></svg>`))
+	test.That(t, err != nil, "must fail on invalid XML")
+
+	_, err = ParseSVGDocument(strings.NewReader(`<html></html>`))
+	test.That(t, err != nil, "must fail when root is not svg")
+}
+
+func TestParseSVGDocumentUse(t *testing.T) {
+	_, layers := svgLayers(t, `<svg width="100" height="100" viewBox="0 0 100 100">
+	<defs>
+		<rect id="r" width="10" height="10" fill="inherit"/>
+		<symbol id="s" viewBox="0 0 10 10"><circle cx="5" cy="5" r="5"/></symbol>
+	</defs>
+	<use href="#r" x="20" y="30" fill="green"/>
+	<use xlink:href="#s" xmlns:xlink="http://www.w3.org/1999/xlink" width="20" height="20"/>
+	<use href="#missing"/>
+</svg>`)
+	test.T(t, len(layers), 2)
+	test.T(t, layers[0].style.FillColor, Green)
+	bounds := layers[0].path.Transform(layers[0].m).Bounds()
+	test.Float(t, bounds.X, 20.0*mmPerPx)
+	test.Float(t, bounds.Y, 60.0*mmPerPx)
+	test.Float(t, layers[1].path.Transform(layers[1].m).Bounds().W, 20.0*mmPerPx)
+	test.T(t, len(layers[1].scopes), 1) // clipped by the symbol's viewport
+}
+
+func TestParseSVGDocumentGradient(t *testing.T) {
+	_, layers := svgLayers(t, `<svg width="100" height="100">
+	<linearGradient id="g"><stop offset="0" stop-color="black"/><stop offset="100%" style="stop-color:white"/></linearGradient>
+	<radialGradient id="h" href="#g" gradientTransform="scale(2,1)"/>
+	<rect x="10" y="10" width="20" height="20" fill="url(#g)"/>
+	<rect x="10" y="10" width="20" height="20" fill="url(#h)" stroke="url(#missing) red"/>
+</svg>`)
+	test.T(t, len(layers), 3)
+	gradient, ok := layers[0].style.FillGradient.(*LinearGradient)
+	test.That(t, ok, "must be linear gradient")
+	test.T(t, gradient.Start, Point{10.0, 10.0})
+	test.T(t, gradient.End, Point{30.0, 10.0})
+	test.T(t, gradient.Stops[1].Color, White)
+
+	// transformed gradients are drawn in gradient space
+	_, ok = layers[1].style.FillGradient.(*RadialGradient)
+	test.That(t, ok, "must be radial gradient")
+	test.T(t, layers[1].path.Transform(layers[1].m).Bounds(), layers[2].path.Transform(layers[2].m).Bounds())
+	test.T(t, layers[2].style.StrokeColor, Red)
+	test.That(t, !layers[2].style.HasFill(), "stroke must be drawn separately")
+}
+
+func TestParseSVGDocumentScopes(t *testing.T) {
+	c, err := ParseSVGDocument(strings.NewReader(`<svg width="100" height="100">
+	<clipPath id="c"><rect width="50" height="50"/></clipPath>
+	<g opacity="0.5" clip-path="url(#c)">
+		<rect width="100" height="100"/>
+		<rect width="100" height="100" style="opacity:0"/>
+	</g>
+</svg>`))
+	test.Error(t, err)
+
+	r := &Recorder{}
+	c.RenderTo(r)
+	test.T(t, recordedOps(r), []string{"group .5", "push (0,26.458333333333332)", "path", "pop", "end"})
+}
+
+func TestParseSVGDocumentText(t *testing.T) {
+	family := NewFontFamily("dejavu-serif")
+	if err := family.LoadFontFile("resources/DejaVuSerif.ttf", FontRegular); err != nil {
+		test.Error(t, err)
+	}
+
+	c, err := ParseSVGDocumentWithFonts(strings.NewReader(`<svg width="100" height="100">
+	<text x="50" y="50" font-family="'dejavu-serif'" font-size="12pt" text-anchor="middle">  Hello <tspan fill="red" font-weight="bold">world</tspan>  </text>
+	<text font-family="unknown-family-name, dejavu-serif" fill="none">Hidden</text>
+</svg>`), map[string]*FontFamily{"dejavu-serif": family})
+	test.Error(t, err)
+	layers := c.layers[0]
+	test.T(t, len(layers), 2)
+
+	face := family.Face(16.0*ptPerMm, Black, FontRegular, FontNormal)
+	bold := family.Face(16.0*ptPerMm, Red, FontBold, FontNormal)
+	width := face.TextWidth("Hello") + bold.TextWidth(" world")
+	x0, y0 := layers[0].m.Pos()
+	x1, _ := layers[1].m.Pos()
+	test.Float(t, x0, (50.0-width/2.0)*mmPerPx)
+	test.Float(t, y0, 50.0*mmPerPx)
+	test.Float(t, x1, (50.0-width/2.0+face.TextWidth("Hello"))*mmPerPx)
+	test.T(t, layers[1].text.lines[0].spans[0].Face.Color, Red)
+}
+
+func TestParseSVGDocumentImage(t *testing.T) {
+	_, layers := svgLayers(t, `<svg width="100" height="100">
+	<image x="10" y="10" width="20" height="40" href="data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAAIAAAACCAIAAAD91JpzAAAAGElEQVR4nGJiYGD4z8DAwNAAIgABAAD//wygAYJr2xzBAAAAAElFTkSuQmCC"/>
+	<image width="20" height="20" href="image.png"/>
+</svg>`)
+	test.T(t, len(layers), 1)
+	test.T(t, layers[0].img.Bounds().Dx(), 2)
+
+	// centered vertically by the default preserveAspectRatio
+	test.T(t, layers[0].m.Dot(Point{0.0, 0.0}), Point{10.0 * mmPerPx, 60.0 * mmPerPx})
+	test.T(t, layers[0].m.Dot(Point{2.0, 2.0}), Point{30.0 * mmPerPx, 80.0 * mmPerPx})
+}
+
+func TestParseSVGTransform(t *testing.T) {
+	test.T(t, parseSVGTransform("translate(10) scale(2, 3)"), Identity.Translate(10.0, 0.0).Scale(2.0, 3.0))
+	test.T(t, parseSVGTransform("matrix(1 2 3 4 5 6)"), Matrix{{1.0, 3.0, 5.0}, {2.0, 4.0, 6.0}})
+	test.T(t, parseSVGTransform("rotate(90 10 10),skewX(45)"), Identity.RotateAbout(90.0, 10.0, 10.0).Shear(1.0, 0.0))
+	test.T(t, parseSVGTransform("scale(1,2,3) translate(5,5)"), Identity.Translate(5.0, 5.0))
+}
+
+func TestParseSVGValues(t *testing.T) {
+	test.T(t, parseSVGNumbers("1,2 -3.5e1-.5.5 x 1"), []float64{1.0, 2.0, -35.0, -0.5, 0.5})
+
+	var tts = []struct {
+		s   string
+		col color.NRGBA
+	}{
+		{"#f00", color.NRGBA{255, 0, 0, 255}},
+		{"#00FF0080", color.NRGBA{0, 255, 0, 128}},
+		{"rgb(0, 50%, 255)", color.NRGBA{0, 128, 255, 255}},
+		{"rgba(0 0 0 / 50%)", color.NRGBA{0, 0, 0, 128}},
+		{"SteelBlue", color.NRGBA{70, 130, 180, 255}},
+		{"currentColor", color.NRGBA{255, 255, 0, 255}},
+	}
+	for _, tt := range tts {
+		t.Run(tt.s, func(t *testing.T) {
+			col, ok := parseSVGColor(tt.s, map[string]string{"color": "yellow"})
+			test.That(t, ok, "must parse color")
+			test.T(t, col, tt.col)
+		})
+	}
+	_, ok := parseSVGColor("#ggg", nil)
+	test.That(t, !ok, "must not parse invalid color")
+
+	length, _ := parseSVGLength("1in", 0.0, 0.0)
+	test.Float(t, length, 96.0)
+	length, _ = parseSVGLength("50%", 20.0, 0.0)
+	test.Float(t, length, 10.0)
+	length, _ = parseSVGLength("2em", 0.0, 12.0)
+	test.Float(t, length, 24.0)
+}
+
+// svgRecordString returns the operations of a recorder as lines of text, which are compared to the expected output of the SVG conformance cases.
+func svgRecordString(r *Recorder) string {
+	sb := &strings.Builder{}
+	for _, op := range r.Ops {
+		m := op.Matrix
+		switch op.Op {
+		case "path":
+			style := op.Style
+			fmt.Fprintf(sb, "path %v", op.Path.Transform(m).ToSVG())
+			if style.HasFill() {
+				fmt.Fprintf(sb, " fill=%v", svgRecordPaint(style.FillColor, style.FillGradient, style.FillPattern, m))
+				if style.FillRule == EvenOdd {
+					fmt.Fprintf(sb, " fill-rule=evenodd")
+				}
+			}
+			if style.HasStroke() {
+				fmt.Fprintf(sb, " stroke=%v stroke-width=%v", svgRecordPaint(style.StrokeColor, style.StrokeGradient, style.StrokePattern, m), num(style.StrokeWidth))
+				if 0 < len(style.Dashes) {
+					fmt.Fprintf(sb, " dashes=%v", style.Dashes)
+				}
+			}
+			fmt.Fprintf(sb, "\n")
+		case "text":
+			for _, line := range op.Text.lines {
+				for _, span := range line.spans {
+					pos := m.Dot(Point{span.x, -line.y})
+					fmt.Fprintf(sb, "text %q at (%v,%v) font=%v size=%v fill=%v\n", span.Text, num(pos.X), num(pos.Y), span.Face.Font.Name(), num(span.Face.Size), CSSColor(span.Face.Color))
+				}
+			}
+		case "image":
+			size := op.Image.Bounds().Size()
+			p0, p1 := m.Dot(Point{0.0, 0.0}), m.Dot(Point{float64(size.X), float64(size.Y)})
+			fmt.Fprintf(sb, "image %dx%d from (%v,%v) to (%v,%v)\n", size.X, size.Y, num(p0.X), num(p0.Y), num(p1.X), num(p1.Y))
+		case "clip":
+			fmt.Fprintf(sb, "clip %v\n", op.Path.Transform(m).ToSVG())
+		case "pop":
+			fmt.Fprintf(sb, "end clip\n")
+		case "group":
+			fmt.Fprintf(sb, "group opacity=%v\n", num(op.Group.Opacity))
+		case "end":
+			fmt.Fprintf(sb, "end group\n")
+		}
+	}
+	return sb.String()
+}
+
+func svgRecordPaint(col color.RGBA, gradient Gradient, pattern *Pattern, m Matrix) string {
+	if pattern != nil {
+		return "pattern"
+	} else if gradient == nil {
+		return CSSColor(col).String()
+	}
+
+	sb := strings.Builder{}
+	switch g := gradient.(type) {
+	case *LinearGradient:
+		start, end := m.Dot(g.Start), m.Dot(g.End)
+		fmt.Fprintf(&sb, "linear(%v,%v %v,%v", num(start.X), num(start.Y), num(end.X), num(end.Y))
+	case *RadialGradient:
+		c0, c1 := m.Dot(g.C0), m.Dot(g.C1)
+		fmt.Fprintf(&sb, "radial(%v,%v %v %v,%v %v", num(c0.X), num(c0.Y), num(g.R0), num(c1.X), num(c1.Y), num(g.R1))
+	}
+	for _, stop := range gradient.GradientStops() {
+		fmt.Fprintf(&sb, " %v %v", num(stop.Offset), CSSColor(stop.Color))
+	}
+	return sb.String() + ")"
+}
+
+func TestParseSVGDocumentConformance(t *testing.T) {
+	// each case in tests/svg/testdata is an SVG document and the expected drawing operations of its canvas
+	family := NewFontFamily("dejavu-serif")
+	if err := family.LoadFontFile("resources/DejaVuSerif.ttf", FontRegular); err != nil {
+		test.Error(t, err)
+	}
+
+	filenames, err := filepath.Glob("tests/svg/testdata/*.svg")
+	test.Error(t, err)
+	test.That(t, 0 < len(filenames), "must have conformance cases")
+	for _, filename := range filenames {
+		name := strings.TrimSuffix(filepath.Base(filename), ".svg")
+		t.Run(name, func(t *testing.T) {
+			f, err := os.Open(filename)
+			test.Error(t, err)
+			defer f.Close()
+
+			c, err := ParseSVGDocumentWithFonts(f, map[string]*FontFamily{"dejavu-serif": family})
+			test.Error(t, err)
+			r := &Recorder{}
+			c.RenderTo(r)
+
+			expected, err := os.ReadFile(strings.TrimSuffix(filename, ".svg") + ".golden")
+			test.Error(t, err)
+			test.String(t, fmt.Sprintf("size %vx%v\n", num(c.W), num(c.H))+svgRecordString(r), string(expected))
+		})
+	}
+}
@@ -3,8 +3,22 @@
 
 package fuzz
 
+import (
+	"bytes"
+
+	"github.com/tdewolff/canvas"
+)
+
 // Fuzz is a fuzz test.
 func Fuzz(data []byte) int {
 	_, _ = canvas.ParseSVG(string(data))
 	return 1
 }
+
+// FuzzDocument is a fuzz test for SVG documents, run with go-fuzz -func FuzzDocument.
+func FuzzDocument(data []byte) int {
+	if _, err := canvas.ParseSVGDocument(bytes.NewReader(data)); err != nil {
+		return 0
+	}
+	return 1
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"unicode/utf8"
 
+	canvasFont "github.com/LaminoidStudio/Canvas/font"
 	canvasText "github.com/LaminoidStudio/Canvas/text"
 )
 
@@ -117,6 +118,9 @@ func (orient TextOrientation) String() string {
 type Text struct {
 	lines []line
 	fonts map[*Font]bool
+
+	path      *TextPath // path along which the text is set instead of in lines
+	pathSpans []pathSpan
 	WritingMode
 	TextOrientation
 }
@@ -124,6 +128,12 @@ type Text struct {
 type line struct {
 	y     float64
 	spans []TextSpan
+
+	ascent, descent, bottom float64 // line heights without line spacing, used to flow lines into frames
+	spaceBefore             float64 // additional space between the previous line and this line
+	start                   int     // position in runes of the start of the line in the logical text
+	paragraphEnd            bool    // line ends with a forced break or at the end of the text
+	tabs                    []int   // number of spans before each tab
 }
 
 // Heights returns the maximum top, ascent, descent, and bottom heights of the line, where top and bottom are equal to ascent and descent respectively with added line spacing.
@@ -203,24 +213,27 @@ type TextSpanObject struct {
 	VAlign        VerticalAlign
 }
 
+// Heights returns the extents of the object above and below the baseline, where Y moves the object up from its vertical alignment so that for example objects with a depth can be aligned on the baseline.
 func (obj TextSpanObject) Heights(face *FontFace) (float64, float64) {
+	ascent, descent := obj.Height, 0.0 // Baseline
 	switch obj.VAlign {
 	case FontTop:
-		ascent := face.Metrics().Ascent
-		return ascent, -(ascent - obj.Height)
+		ascent = face.Metrics().Ascent
+		descent = -(ascent - obj.Height)
 	case FontMiddle:
-		ascent, descent := face.Metrics().Ascent, face.Metrics().Descent
-		return (ascent - descent + obj.Height) / 2.0, -(ascent - descent - obj.Height) / 2.0
+		faceAscent, faceDescent := face.Metrics().Ascent, face.Metrics().Descent
+		ascent = (faceAscent - faceDescent + obj.Height) / 2.0
+		descent = -(faceAscent - faceDescent - obj.Height) / 2.0
 	case FontBottom:
-		descent := face.Metrics().Descent
-		return -descent + obj.Height, descent
+		descent = face.Metrics().Descent
+		ascent = -descent + obj.Height
 	}
-	return obj.Height, 0.0 // Baseline
+	return ascent + obj.Y, descent - obj.Y
 }
 
 func (obj TextSpanObject) View(x, y float64, face *FontFace) Matrix {
 	_, bottom := obj.Heights(face)
-	return Identity.Translate(x+obj.X, y+obj.Y-bottom)
+	return Identity.Translate(x+obj.X, y-bottom)
 }
 
 ////////////////////////////////////////////////////////////////
@@ -263,7 +276,7 @@ func NewTextLine(face *FontFace, s string, halign TextAlign) *Text {
 				continue
 			}
 			if i < j {
-				ppem := face.PPEM(DefaultResolution)
+				ppem := face.shapingPPEM()
 				lineWidth := 0.0
 				line := line{y: y, spans: []TextSpan{}}
 				itemsL, itemsV := itemizeString(s[i:j], face.Script)
@@ -308,7 +321,7 @@ func NewTextLine(face *FontFace, s string, halign TextAlign) *Text {
 	return t
 }
 
-// NewTextBox is an advanced text formatter that will format text placement based on the settings. It takes a single font face, a string, the width or height of the box (can be zero to disable), horizontal and vertical alignment (Left, Center, Right, Top, Bottom or Justify), text indentation for the first line and line stretch (percentage to stretch the line based on the line height).
+// NewTextBox is an advanced text formatter that will format text placement based on the settings. It takes a single font face, a string, the width or height of the box (can be zero to disable), horizontal and vertical alignment (Left, Center, Right, Top, Bottom or Justify), text indentation for the first line and line stretch (percentage to stretch the line based on the line height). Words are hyphenated with the hyphenator registered for the language of the font face, which is only US English by default, see text.RegisterHyphenator.
 func NewTextBox(face *FontFace, s string, width, height float64, halign, valign TextAlign, indent, lineStretch float64) *Text {
 	rt := NewRichText(face)
 	rt.WriteString(s)
@@ -336,6 +349,11 @@ type RichText struct {
 
 	defaultFace *FontFace
 	objects     []TextSpanObject
+	breaks      map[int]objectBreak // line breaks after objects by object index, such as after the pieces of inline formulas
+
+	styleLocs indexer // paragraph style locations in string by number of runes
+	styles    []*ParagraphStyle
+	continued bool // text starts in the middle of a paragraph
 }
 
 // NewRichText returns a new rich text with the given default font face.
@@ -350,6 +368,8 @@ func NewRichText(face *FontFace) *RichText {
 		mode:        HorizontalTB,
 		orient:      Natural,
 		defaultFace: face,
+		styleLocs:   indexer{0},
+		styles:      []*ParagraphStyle{nil},
 	}
 }
 
@@ -358,6 +378,8 @@ func (rt *RichText) Reset() {
 	rt.Builder.Reset()
 	rt.locs = rt.locs[:1]
 	rt.faces = rt.faces[:1]
+	rt.styleLocs = rt.styleLocs[:1]
+	rt.styles = rt.styles[:1]
 }
 
 // SetWritingMode sets the writing mode.
@@ -428,19 +450,23 @@ func (rt *RichText) Add(face *FontFace, text string) *RichText {
 func (rt *RichText) AddCanvas(c *Canvas, valign VerticalAlign) *RichText {
 
 	width, height := c.Size()
-	face := rt.faces[len(rt.faces)-1]
-	rt.setFace(nil)
-	rt.WriteRune(rune(len(rt.objects)))
-	rt.objects = append(rt.objects, TextSpanObject{
+	rt.addObject(TextSpanObject{
 		Canvas: c,
 		Width:  width,
 		Height: height,
 		VAlign: valign,
 	})
-	rt.setFace(face)
 	return rt
 }
 
+func (rt *RichText) addObject(obj TextSpanObject) {
+	face := rt.faces[len(rt.faces)-1]
+	rt.setFace(nil)
+	rt.WriteRune(rune(len(rt.objects)))
+	rt.objects = append(rt.objects, obj)
+	rt.setFace(face)
+}
+
 // AddPath adds a path.
 func (rt *RichText) AddPath(path *Path, col color.RGBA, valign VerticalAlign) *RichText {
 	style := DefaultStyle
@@ -461,13 +487,45 @@ func (rt *RichText) AddImage(img image.Image, res Resolution, valign VerticalAli
 	return rt
 }
 
-// AddLaTeX adds a LaTeX formula.
+// AddLaTeX adds an inline LaTeX math formula, without the surrounding dollar signs, in the size and color of the current font face. If the face's font has an OpenType MATH table, such as Latin Modern Math, the formula is typeset in text style like NewMathText and its baseline and math axis align with the text. Its glyphs are kept as text, and lines may break after the relations and binary operators of the formula. Otherwise, the formula is typeset by ParseLaTeX and added as a path.
 func (rt *RichText) AddLaTeX(s string) error {
-	p, err := ParseLaTeX(s)
+	face := rt.faces[len(rt.faces)-1]
+	if face.Font.Math == nil {
+		p, err := ParseLaTeX(s)
+		if err != nil {
+			return err
+		}
+		scale := face.Size / (10.0 * mmPerPt) // ParseLaTeX typesets at 10pt
+		rt.AddPath(p.Transform(Identity.Scale(scale, scale)), face.Color, Baseline)
+		return nil
+	}
+
+	list, err := parseMath(s)
 	if err != nil {
 		return err
 	}
-	rt.AddPath(p, Black, Baseline)
+	l, err := newMathLayout(face)
+	if err != nil {
+		return err
+	}
+	boxes, penalties, spaces := l.layoutPieces(list, mathState{style: TextStyle})
+	for i, box := range boxes {
+		c := New(box.width, box.height+box.depth)
+		c.RenderText(box.toText(face.Font), Identity.Translate(0.0, box.depth))
+		if penalties[i] != 0.0 {
+			if rt.breaks == nil {
+				rt.breaks = map[int]objectBreak{}
+			}
+			rt.breaks[len(rt.objects)] = objectBreak{penalty: penalties[i], space: spaces[i]}
+		}
+		rt.addObject(TextSpanObject{
+			Canvas: c,
+			Y:      -box.depth,
+			Width:  box.width,
+			Height: box.height + box.depth,
+			VAlign: Baseline,
+		})
+	}
 	return nil
 }
 
@@ -494,8 +552,16 @@ func scriptDirection(mode WritingMode, orient TextOrientation, script canvasText
 	return direction, rotation
 }
 
-// ToText takes the added text spans and fits them within a given box of certain width and height using Donald Knuth's line breaking algorithm.
+// ToText takes the added text spans and fits them within a given box of certain width and height using Donald Knuth's line breaking algorithm. The horizontal alignment and indentation of the first line are used for paragraphs without a paragraph style.
 func (rt *RichText) ToText(width, height float64, halign, valign TextAlign, indent, lineStretch float64) *Text {
+	if rt.hasParagraphStyles() && rt.mode == HorizontalTB {
+		return rt.toTextParagraphs(width, height, halign, valign, indent, lineStretch)
+	}
+	return rt.toText(width, height, halign, valign, indent, lineStretch, nil)
+}
+
+// toText fits the text within a box using a single horizontal alignment, indentation of the first line, and tab stops.
+func (rt *RichText) toText(width, height float64, halign, valign TextAlign, indent, lineStretch float64, tabStops []TabStop) *Text {
 	log := rt.String()
 	vis, mapV2L := canvasText.Bidi(log)
 	logRunes := []rune(log)
@@ -577,7 +643,7 @@ func (rt *RichText) ToText(width, height float64, halign, valign TextAlign, inde
 			}
 		} else {
 			// text
-			ppem := face.PPEM(DefaultResolution)
+			ppem := face.shapingPPEM()
 			direction, rotation := scriptDirection(rt.mode, rt.orient, script, face.Direction)
 			glyphsString = face.Font.shaper.Shape(text, ppem, direction, script, face.Language, face.Font.features, face.Font.variations)
 			for i := range glyphsString {
@@ -627,6 +693,9 @@ func (rt *RichText) ToText(width, height float64, halign, valign TextAlign, inde
 	// break glyphs into lines following Donald Knuth's line breaking algorithm
 	looseness := 0
 	items := canvasText.GlyphsToItems(glyphs, indent, align)
+	if rt.breaks != nil {
+		items = rt.breakAfterObjects(items, glyphs, faces, glyphIndices)
+	}
 
 	var breaks []*canvasText.Breakpoint
 	if width != 0.0 {
@@ -700,18 +769,20 @@ func (rt *RichText) ToText(width, height float64, halign, valign TextAlign, inde
 						XAdvance: int32(span.Face.Font.GlyphAdvance(id)),
 						Text:     "-",
 					}
-					span.Glyphs = append(span.Glyphs, glyph)
+					span.Glyphs = append(span.Glyphs[:len(span.Glyphs):len(span.Glyphs)], glyph) // don't overwrite the glyphs of the next line
 					span.Width += span.Face.textWidth([]canvasText.Glyph{glyph})
 					span.Text += "-"
 				}
 			}
 
-			var ascent, bottom float64
+			var ascent, descent, bottom float64
 			if len(t.lines[j].spans) == 0 {
-				_, ascent, _, bottom = faces[glyphIndices.index(i)].heights(rt.mode)
+				_, ascent, descent, bottom = faces[glyphIndices.index(i)].heights(rt.mode)
 			} else {
-				_, ascent, _, bottom = t.lines[j].Heights(rt.mode)
+				_, ascent, descent, bottom = t.lines[j].Heights(rt.mode)
 			}
+			t.lines[j].ascent, t.lines[j].descent, t.lines[j].bottom = ascent, descent, bottom
+			t.lines[j].paragraphEnd = item.Type == canvasText.PenaltyType && item.Penalty <= -canvasText.Infinity
 			if 0 < j {
 				ascent *= lineSpacing
 			}
@@ -719,12 +790,17 @@ func (rt *RichText) ToText(width, height float64, halign, valign TextAlign, inde
 
 			t.lines[j].y = y + ascent
 			y += ascent + bottom
-			if height != 0.0 && (height < y || position == len(items)-1) {
+			if position == len(items)-1 || height != 0.0 && height < y {
 				// doesn't fit or at the end of items
 				break
 			}
 
-			t.lines = append(t.lines, line{})
+			// position of the next line in the logical text
+			start := len(logRunes)
+			if k := utf8.RuneCountInString(vis[:glyphs[i+item.Size].Cluster]); k < len(mapV2L) {
+				start = mapV2L[k]
+			}
+			t.lines = append(t.lines, line{start: start})
 			if j+1 < len(breaks) {
 				j++
 			}
@@ -735,6 +811,13 @@ func (rt *RichText) ToText(width, height float64, halign, valign TextAlign, inde
 				x += (width - breaks[j].Width) / 2.0
 			}
 		} else if item.Type == canvasText.BoxType {
+			if len(t.lines[j].spans) == 0 && 0 < item.Size {
+				// position of the line in the logical text, skipping glue at the start of the line
+				if k := utf8.RuneCountInString(vis[:glyphs[i].Cluster]); k < len(mapV2L) {
+					t.lines[j].start = mapV2L[k]
+				}
+			}
+
 			// find index k into faces/texts
 			// find a,b index range into glyphs
 			a := i
@@ -836,6 +919,13 @@ func (rt *RichText) ToText(width, height float64, halign, valign TextAlign, inde
 					t.lines[j].spans[len(t.lines[j].spans)-1].Text += glyph.Text
 				}
 			}
+
+			// tabs are positioned after the spans before it
+			for _, glyph := range glyphs[i : i+item.Size] {
+				if glyph.Text == "\t" {
+					t.lines[j].tabs = append(t.lines[j].tabs, len(t.lines[j].spans))
+				}
+			}
 		}
 		i += item.Size
 	}
@@ -857,8 +947,55 @@ func (rt *RichText) ToText(width, height float64, halign, valign TextAlign, inde
 		y += descent
 	}
 
-	// vertical align
-	if rt.mode == VerticalRL {
+	if rt.mode == HorizontalTB {
+		t.positionTabs(width, tabStops)
+	}
+	t.alignVertically(valign, height, y)
+	return t
+}
+
+// objectBreak is a line break after an object with a penalty, followed by a space that is dropped when the line breaks.
+type objectBreak struct {
+	penalty, space float64
+}
+
+// breakAfterObjects splits boxes after objects that allow a line break, such as the pieces of inline formulas, and inserts a penalty item followed by glue for the space after the object.
+func (rt *RichText) breakAfterObjects(items []canvasText.Item, glyphs []canvasText.Glyph, faces []*FontFace, glyphIndices indexer) []canvasText.Item {
+	split := make([]canvasText.Item, 0, len(items))
+	i := 0 // index into glyphs
+	for _, item := range items {
+		size := item.Size
+		if item.Type == canvasText.BoxType {
+			// start of the remaining box and width of its glyphs up to n, which never breaks after the last glyph
+			start, width := 0, 0.0
+			for n := 0; n+1 < size; n++ {
+				glyph := glyphs[i+n]
+				if !glyph.Vertical {
+					width += float64(glyph.XAdvance) * glyph.Size / float64(glyph.SFNT.Head.UnitsPerEm)
+				} else {
+					width += float64(-glyph.YAdvance) * glyph.Size / float64(glyph.SFNT.Head.UnitsPerEm)
+				}
+				if faces[glyphIndices.index(i+n)] != nil {
+					continue
+				} else if brk, ok := rt.breaks[int(glyph.ID)]; ok {
+					box := canvasText.Box(width)
+					box.Size = n + 1 - start
+					split = append(split, box, canvasText.Penalty(0.0, brk.penalty, false), canvasText.Glue(brk.space, 0.0, 0.0))
+					item.Width -= width
+					item.Size -= box.Size
+					start, width = n+1, 0.0
+				}
+			}
+		}
+		split = append(split, item)
+		i += size
+	}
+	return split
+}
+
+// alignVertically aligns the lines vertically (Top, Center, Bottom or Justify) within the height, where y is the height of the lines.
+func (t *Text) alignVertically(valign TextAlign, height, y float64) {
+	if t.WritingMode == VerticalRL {
 		if valign == Top {
 			valign = Bottom
 		} else if valign == Bottom {
@@ -881,16 +1018,18 @@ func (rt *RichText) ToText(width, height float64, halign, valign TextAlign, inde
 			dy += ddy
 		}
 	}
-	if rt.mode == VerticalRL {
+	if t.WritingMode == VerticalRL {
 		for j := range t.lines {
 			t.lines[j].y = height - t.lines[j].y
 		}
 	}
-	return t
 }
 
 // Empty returns true if there are no text lines or text spans.
 func (t *Text) Empty() bool {
+	if t.path != nil {
+		return len(t.pathSpans) == 0
+	}
 	for _, line := range t.lines {
 		if len(line.spans) != 0 {
 			return false
@@ -913,6 +1052,14 @@ func (t *Text) Heights() (float64, float64) {
 
 // Bounds returns the bounding rectangle that defines the text box.
 func (t *Text) Bounds() Rect {
+	if t.path != nil {
+		rect := Rect{}
+		for _, ps := range t.pathSpans {
+			metrics := ps.span.Face.Metrics()
+			rect = rect.Add(Rect{0.0, -metrics.Descent, ps.span.Width, metrics.Ascent + metrics.Descent}.Transform(ps.m))
+		}
+		return rect
+	}
 	if len(t.lines) == 0 || len(t.lines[0].spans) == 0 {
 		return Rect{}
 	}
@@ -928,6 +1075,22 @@ func (t *Text) Bounds() Rect {
 
 // OutlineBounds returns the rectangle that contains the entire text box, i.e. the glyph outlines (slow).
 func (t *Text) OutlineBounds() Rect {
+	if t.path != nil {
+		r := Rect{}
+		for _, ps := range t.pathSpans {
+			if ps.span.IsText() {
+				p, _, err := ps.span.Face.toPath(ps.span.Glyphs, 0)
+				if err != nil {
+					panic(err)
+				}
+				r = r.Add(p.Transform(ps.m).Bounds())
+			}
+		}
+		t.WalkDecorations(func(col color.RGBA, p *Path) {
+			r = r.Add(p.Bounds())
+		})
+		return r
+	}
 	if len(t.lines) == 0 || len(t.lines[0].spans) == 0 {
 		return Rect{}
 	}
@@ -935,7 +1098,7 @@ func (t *Text) OutlineBounds() Rect {
 	for _, line := range t.lines {
 		for _, span := range line.spans {
 			// TODO: vertical text
-			p, _, err := span.Face.toPath(span.Glyphs, span.Face.PPEM(DefaultResolution))
+			p, _, err := span.Face.toPath(span.Glyphs, 0)
 			if err != nil {
 				panic(err)
 			}
@@ -974,15 +1137,21 @@ func (t *Text) MostCommonFontFace() *FontFace {
 	styles := map[FontStyle]int{}
 	variants := map[FontVariant]int{}
 	colors := map[color.RGBA]int{}
+	count := func(span TextSpan) {
+		fonts[span.Face.Font]++
+		sizes[span.Face.Size]++
+		styles[span.Face.Style]++
+		variants[span.Face.Variant]++
+		colors[span.Face.Color]++
+	}
 	for _, line := range t.lines {
 		for _, span := range line.spans {
-			fonts[span.Face.Font]++
-			sizes[span.Face.Size]++
-			styles[span.Face.Style]++
-			variants[span.Face.Variant]++
-			colors[span.Face.Color]++
+			count(span)
 		}
 	}
+	for _, ps := range t.pathSpans {
+		count(ps.span)
+	}
 	if len(fonts) == 0 {
 		return nil
 	}
@@ -1098,26 +1267,75 @@ func (t *Text) WalkDecorations(callback func(col color.RGBA, deco *Path)) {
 		}
 	}
 
+	// decorate each glyph cluster of a text along a path separately, following the path
+	for k, pathSpan := range t.pathSpans {
+		span := pathSpan.span
+		width := span.Width
+		if k+1 < len(t.pathSpans) {
+			width += t.path.LetterSpacing
+		}
+		for _, spanDeco := range span.Face.Deco {
+			xOffset := span.Face.mmPerEm * float64(span.Face.XOffset)
+			yOffset := span.Face.mmPerEm * float64(span.Face.YOffset)
+			p := spanDeco.Decorate(span.Face, width)
+			p = p.Transform(pathSpan.m.Translate(xOffset, yOffset))
+
+			foundColor := false
+			for j, col := range cs {
+				if col == span.Face.Color {
+					ps[j] = ps[j].Append(p)
+					foundColor = true
+				}
+			}
+			if !foundColor {
+				cs = append(cs, span.Face.Color)
+				ps = append(ps, p)
+			}
+		}
+	}
+
 	for i := 0; i < len(ps); i++ {
 		callback(cs[i], ps[i])
 	}
 }
 
-// WalkSpans calls the callback for each text span per line.
+// WalkSpans calls the callback for each text span per line. Color glyphs are passed as spans with an object each, splitting the text span they belong to.
 func (t *Text) WalkSpans(callback func(x, y float64, span TextSpan)) {
 	for _, line := range t.lines {
 		for _, span := range line.spans {
 			xOffset := span.Face.mmPerEm * float64(span.Face.XOffset)
 			yOffset := span.Face.mmPerEm * float64(span.Face.YOffset)
-			if t.WritingMode == HorizontalTB {
-				callback(span.x+xOffset, -line.y+yOffset, span)
+			x, y := span.x+xOffset, -line.y+yOffset
+			if t.WritingMode != HorizontalTB {
+				x, y = line.y+xOffset, -span.x+yOffset
+			}
+			if spans, offsets := span.colorGlyphSpans(); spans != nil {
+				for i, span := range spans {
+					callback(x+offsets[i].X, y+offsets[i].Y, span)
+				}
 			} else {
-				callback(line.y+xOffset, -span.x+yOffset, span)
+				callback(x, y, span)
 			}
 		}
 	}
 }
 
+// hinting returns the ppem at which the span is rasterized and moves the span's baseline onto the pixel grid, given the view matrix m and the span's placement mSpan. Hinting is disabled for rotated or skewed spans.
+func (span *TextSpan) hinting(m, mSpan Matrix, resolution Resolution) (uint16, Matrix) {
+	mView := m.Mul(mSpan)
+	if mView[0][1] != 0.0 || mView[1][0] != 0.0 || mView[1][1] == 0.0 || Equal(m.Det(), 0.0) {
+		return 0, mSpan
+	}
+	scale := math.Sqrt(math.Abs(mView.Det()))
+	ppem := span.Face.PPEM(Resolution(resolution.DPMM() * scale))
+
+	// snap in view space, which is axis-aligned even when m and mSpan are rotated
+	dpmm := resolution.DPMM()
+	_, y := mView.Pos()
+	dy := math.Round(y*dpmm)/dpmm - y
+	return ppem, m.Inv().Translate(0.0, dy).Mul(mView)
+}
+
 // RenderAsPath renders the text and its decorations converted to paths, calling r.RenderPath.
 func (t *Text) RenderAsPath(r Renderer, m Matrix, resolution Resolution) {
 	t.WalkDecorations(func(col color.RGBA, p *Path) {
@@ -1126,29 +1344,57 @@ func (t *Text) RenderAsPath(r Renderer, m Matrix, resolution Resolution) {
 		r.RenderPath(p, style, m)
 	})
 
+	// mSpan places the origin of the span, the face's offsets are included by toPath but not yet for objects
+	renderSpan := func(mSpan Matrix, span TextSpan) {
+		if span.IsText() {
+			style := DefaultStyle
+			style.FillColor = span.Face.Color
+			style.FillNative = span.Face.NativeColor
+			mSpan = mSpan.Rotate(float64(span.Rotation))
+			ppem := uint16(0)
+			if span.Face.Hinting != canvasFont.NoHinting {
+				ppem, mSpan = span.hinting(m, mSpan, resolution)
+			}
+			p, _, err := span.Face.toPath(span.Glyphs, ppem)
+			if err != nil {
+				panic(err)
+			}
+			p = p.Transform(mSpan)
+			r.RenderPath(p, style, m)
+		} else {
+			for _, obj := range span.Objects {
+				rv := RendererViewer{r, m.Mul(mSpan).Mul(obj.View(0.0, 0.0, span.Face))}
+				obj.RenderTo(rv)
+			}
+		}
+	}
+	renderSpans := func(mSpan Matrix, span TextSpan) {
+		spans, offsets := span.colorGlyphSpans()
+		if spans == nil {
+			renderSpan(mSpan, span)
+			return
+		}
+		xOffset := span.Face.mmPerEm * float64(span.Face.XOffset)
+		yOffset := span.Face.mmPerEm * float64(span.Face.YOffset)
+		for i, span := range spans {
+			if span.IsText() {
+				renderSpan(mSpan.Translate(offsets[i].X, offsets[i].Y), span)
+			} else {
+				renderSpan(mSpan.Translate(offsets[i].X+xOffset, offsets[i].Y+yOffset), span)
+			}
+		}
+	}
+
 	for _, line := range t.lines {
 		for _, span := range line.spans {
 			x, y := span.x, -line.y
 			if t.WritingMode != HorizontalTB {
 				x, y = line.y, -span.x
 			}
-
-			if span.IsText() {
-				style := DefaultStyle
-				style.FillColor = span.Face.Color
-				p, _, err := span.Face.toPath(span.Glyphs, span.Face.PPEM(resolution))
-				if err != nil {
-					panic(err)
-				}
-				p = p.Transform(Identity.Rotate(float64(span.Rotation)))
-				p = p.Translate(x, y)
-				r.RenderPath(p, style, m)
-			} else {
-				for _, obj := range span.Objects {
-					rv := RendererViewer{r, m.Mul(obj.View(x, y, span.Face))}
-					obj.RenderTo(rv)
-				}
-			}
+			renderSpans(Identity.Translate(x, y), span)
 		}
 	}
+	for _, ps := range t.pathSpans {
+		renderSpans(ps.m, ps.span)
+	}
 }
@@ -5,6 +5,7 @@ package text
 
 import (
 	"bytes"
+	"strings"
 	"unicode/utf8"
 
 	"github.com/LaminoidStudio/Canvas/font"
@@ -54,6 +55,16 @@ func (s Shaper) Shape(text string, ppem uint16, direction Direction, script Scri
 	}
 	reverse := buf.Props.Direction == harfbuzz.RightToLeft || buf.Props.Direction == harfbuzz.BottomToTop
 
+	if face, ok := s.font.Face().(truetype.FaceVariable); ok {
+		var vars []truetype.Variation
+		for _, variation := range strings.Split(variations, ",") {
+			if v, err := harfbuzz.ParseVariation(variation); err == nil {
+				vars = append(vars, v)
+			}
+		}
+		truetype.SetVariations(face, vars)
+	}
+
 	rtext := []rune(text)
 	buf.AddRunes(rtext, 0, -1)
 	buf.Shape(s.font, nil)
@@ -76,6 +87,7 @@ func (s Shaper) Shape(text string, ppem uint16, direction Direction, script Scri
 		glyphs[i].YAdvance = int32(position.YAdvance)
 		glyphs[i].XOffset = int32(position.XOffset)
 		glyphs[i].YOffset = int32(position.YOffset)
+		glyphs[i].Language = lang
 
 		if reverse {
 			// TODO: what about reverse?
@@ -73,8 +73,9 @@ func (s Shaper) Shape(text string, ppem uint16, direction Direction, script Scri
 		s.fonts[ppem] = font
 	}
 
+	// fonts are cached per ppem, so variations must be reset as well
+	var cvariations []C.hb_variation_t
 	if variations != "" {
-		var cvariations []C.hb_variation_t
 		for _, variation := range strings.Split(variations, ",") {
 			cvariation := C.CString(variation)
 			cvariations = append(cvariations, C.hb_variation_t{})
@@ -84,7 +85,11 @@ func (s Shaper) Shape(text string, ppem uint16, direction Direction, script Scri
 			}
 			C.free(unsafe.Pointer(cvariation))
 		}
+	}
+	if 0 < len(cvariations) {
 		C.hb_font_set_variations(font, &cvariations[0], C.uint(len(cvariations)))
+	} else {
+		C.hb_font_set_variations(font, nil, 0)
 	}
 
 	ctext := C.CString(text)
@@ -137,6 +142,7 @@ func (s Shaper) Shape(text string, ppem uint16, direction Direction, script Scri
 		glyphs[i].YAdvance = int32(position.y_advance)
 		glyphs[i].XOffset = int32(position.x_offset)
 		glyphs[i].YOffset = int32(position.y_offset)
+		glyphs[i].Language = language
 		if reverse {
 			if i != 0 {
 				glyphs[i].Text = text[glyphs[i].Cluster:glyphs[i-1].Cluster]
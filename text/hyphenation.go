@@ -0,0 +1,210 @@
+package text
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Hyphenator finds the hyphenation points of words using Frank Liang's algorithm as used by TeX. Patterns assign odd (break) and even (no break) priorities to the positions between letters of word fragments, where the highest priority of all matching patterns wins. Exceptions override the patterns for specific words.
+type Hyphenator struct {
+	LeftMin  int // minimum number of letters before a hyphen
+	RightMin int // minimum number of letters after a hyphen
+
+	patterns   map[string][]uint8 // letters to priorities between letters, including before the first and after the last letter
+	maxLen     int                // maximum number of letters of a pattern
+	exceptions map[string][]int   // word to hyphenation positions
+}
+
+// NewHyphenator returns a hyphenator with no patterns and the minimum fragment lengths of TeX for English, which are two letters before and three letters after a hyphen.
+func NewHyphenator() *Hyphenator {
+	return &Hyphenator{
+		LeftMin:    2,
+		RightMin:   3,
+		patterns:   map[string][]uint8{},
+		exceptions: map[string][]int{},
+	}
+}
+
+// ParseHyphenator parses the hyphenation patterns and exceptions from a TeX hyphenation file (such as hyph-en-us.tex from the hyph-utf8 project) in UTF-8. The patterns are read from \patterns{...} and the exceptions from \hyphenation{...}, other commands and comments are ignored.
+func ParseHyphenator(r io.Reader) (*Hyphenator, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// strip comments
+	lines := strings.Split(string(b), "\n")
+	for i, line := range lines {
+		if j := strings.IndexByte(line, '%'); j != -1 {
+			lines[i] = line[:j]
+		}
+	}
+	s := strings.Join(lines, "\n")
+
+	h := NewHyphenator()
+	found := false
+	for _, cmd := range []string{`\patterns`, `\hyphenation`} {
+		for k := 0; ; {
+			i := strings.Index(s[k:], cmd)
+			if i == -1 {
+				break
+			}
+			i += k + len(cmd)
+			i += len(s[i:]) - len(strings.TrimLeft(s[i:], " \t\r\n"))
+			if i == len(s) || s[i] != '{' {
+				k = i
+				continue // other command with the same prefix
+			}
+			j := strings.IndexByte(s[i:], '}')
+			if j == -1 {
+				return nil, fmt.Errorf("unterminated %s", cmd)
+			}
+			fields := strings.Fields(s[i+1 : i+j])
+			if cmd == `\patterns` {
+				h.AddPatterns(fields...)
+			} else {
+				h.AddExceptions(fields...)
+			}
+			found = true
+			k = i + j
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("no hyphenation patterns found")
+	}
+	return h, nil
+}
+
+// AddPatterns adds Liang patterns such as "hy3ph" or ".ach4", where digits are the priorities between letters and a dot matches the start or end of a word.
+func (h *Hyphenator) AddPatterns(patterns ...string) {
+	for _, pattern := range patterns {
+		letters := []rune{}
+		priorities := []uint8{0}
+		for _, r := range pattern {
+			if '0' <= r && r <= '9' {
+				priorities[len(priorities)-1] = uint8(r - '0')
+			} else {
+				letters = append(letters, unicode.ToLower(r))
+				priorities = append(priorities, 0)
+			}
+		}
+		if len(letters) == 0 {
+			continue
+		}
+		h.patterns[string(letters)] = priorities
+		if h.maxLen < len(letters) {
+			h.maxLen = len(letters)
+		}
+	}
+}
+
+// AddExceptions adds words with explicit hyphenation points such as "as-so-ciate". Words without hyphens are never hyphenated.
+func (h *Hyphenator) AddExceptions(words ...string) {
+	for _, word := range words {
+		letters := []rune{}
+		positions := []int{}
+		for _, r := range word {
+			if r == '-' {
+				positions = append(positions, len(letters))
+			} else {
+				letters = append(letters, unicode.ToLower(r))
+			}
+		}
+		h.exceptions[string(letters)] = positions
+	}
+}
+
+// Hyphenate returns the positions in runes where the word can be hyphenated, in increasing order, or nil if it cannot be hyphenated. The word should consist of letters only and is matched case-insensitively.
+func (h *Hyphenator) Hyphenate(word string) []int {
+	letters := []rune(strings.ToLower(word))
+	n := len(letters)
+	if n < h.LeftMin+h.RightMin {
+		return nil
+	}
+
+	var positions []int
+	if exception, ok := h.exceptions[string(letters)]; ok {
+		for _, pos := range exception {
+			if h.LeftMin <= pos && pos <= n-h.RightMin {
+				positions = append(positions, pos)
+			}
+		}
+		return positions
+	}
+
+	// priorities between the letters of the word enclosed in dots, where priorities[i] is before dotted[i]
+	dotted := make([]rune, 0, n+2)
+	dotted = append(dotted, '.')
+	dotted = append(dotted, letters...)
+	dotted = append(dotted, '.')
+	priorities := make([]uint8, len(dotted)+1)
+	for i := range dotted {
+		for j := i + 1; j <= len(dotted) && j-i <= h.maxLen; j++ {
+			if pattern, ok := h.patterns[string(dotted[i:j])]; ok {
+				for k, priority := range pattern {
+					if priorities[i+k] < priority {
+						priorities[i+k] = priority
+					}
+				}
+			}
+		}
+	}
+
+	// the position between letters[pos-1] and letters[pos] is before dotted[pos+1]
+	for pos := h.LeftMin; pos <= n-h.RightMin; pos++ {
+		if priorities[pos+1]%2 == 1 {
+			positions = append(positions, pos)
+		}
+	}
+	return positions
+}
+
+var hyphenators = map[string]*Hyphenator{}
+var hyphenatorsMu sync.RWMutex
+
+// hyphenTeX holds the US English patterns and exceptions of Frank Liang for plain TeX, which may be redistributed unmodified only.
+//
+//go:embed hyphen.tex
+var hyphenTeX string
+
+func init() {
+	h, err := ParseHyphenator(strings.NewReader(hyphenTeX))
+	if err != nil {
+		panic(err)
+	}
+	RegisterHyphenator("en", h) // TeX's default for English, register another hyphenator for "en-GB" to override it
+}
+
+// RegisterHyphenator sets the hyphenator for a BCP 47 language tag such as "en-US" or "de". Registering nil removes the hyphenator for the language. The US English patterns of plain TeX are registered for "en" by default, hyphenators for other languages must be registered before laying out text.
+func RegisterHyphenator(lang string, h *Hyphenator) {
+	lang = strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+	hyphenatorsMu.Lock()
+	if h == nil {
+		delete(hyphenators, lang)
+	} else {
+		hyphenators[lang] = h
+	}
+	hyphenatorsMu.Unlock()
+}
+
+// LookupHyphenator returns the hyphenator for a BCP 47 language tag, falling back to shorter tags when there is no exact match, so that "en-GB" uses the hyphenator of "en" if it has not been registered. It returns nil if no hyphenator applies.
+func LookupHyphenator(lang string) *Hyphenator {
+	lang = strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+	hyphenatorsMu.RLock()
+	defer hyphenatorsMu.RUnlock()
+	for lang != "" {
+		if h, ok := hyphenators[lang]; ok {
+			return h
+		}
+		i := strings.LastIndexByte(lang, '-')
+		if i == -1 {
+			break
+		}
+		lang = lang[:i]
+	}
+	return nil
+}
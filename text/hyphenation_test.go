@@ -0,0 +1,78 @@
+package text
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tdewolff/test"
+)
+
+// liangPatterns are the patterns from Frank Liang's thesis that hyphenate "hyphenation".
+var liangPatterns = []string{"hy3ph", "he2n", "hena4", "hen5at", "1na", "n2at", "1tio", "2io", "o2n"}
+
+func TestHyphenate(t *testing.T) {
+	h := NewHyphenator()
+	h.AddPatterns(liangPatterns...)
+	h.AddExceptions("ta-ble", "project")
+
+	var tests = []struct {
+		word      string
+		positions []int
+	}{
+		{"hyphenation", []int{2, 6}},
+		{"Hyphenation", []int{2, 6}},
+		{"table", []int{2}},
+		{"tables", nil}, // exception does not apply
+		{"project", nil},
+		{"hen", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			test.T(t, h.Hyphenate(tt.word), tt.positions)
+		})
+	}
+
+	h.RightMin = 4 // right fragment too short
+	test.T(t, h.Hyphenate("table"), []int(nil))
+}
+
+func TestParseHyphenator(t *testing.T) {
+	tex := `% hyphenation patterns
+\message{test patterns}
+\patterns{ % patterns from Liang's thesis
+` + strings.Join(liangPatterns, "\n") + `
+}
+\hyphenation{
+as-so-ciate
+}`
+	h, err := ParseHyphenator(strings.NewReader(tex))
+	test.Error(t, err)
+	test.T(t, h.Hyphenate("hyphenation"), []int{2, 6})
+	test.T(t, h.Hyphenate("associate"), []int{2, 4})
+
+	_, err = ParseHyphenator(strings.NewReader(`\patterns{ hy3ph`))
+	test.That(t, err != nil, "must fail on unterminated patterns")
+	_, err = ParseHyphenator(strings.NewReader(`\message{nothing}`))
+	test.That(t, err != nil, "must fail without patterns")
+}
+
+func TestLookupHyphenator(t *testing.T) {
+	h := NewHyphenator()
+	RegisterHyphenator("xx", h)
+	defer RegisterHyphenator("xx", nil)
+
+	test.That(t, LookupHyphenator("xx") == h, "exact match")
+	test.That(t, LookupHyphenator("XX_YY") == h, "fallback to language")
+	test.That(t, LookupHyphenator("yy") == nil, "no match")
+	test.That(t, LookupHyphenator("") == nil, "no language")
+}
+
+func TestEnglishHyphenator(t *testing.T) {
+	h := LookupHyphenator("en-US")
+	test.That(t, h != nil, "US English patterns must be registered")
+	test.That(t, LookupHyphenator("en") == h, "registered for English")
+	test.T(t, h.Hyphenate("hyphenation"), []int{2, 6})
+	test.T(t, h.Hyphenate("typesetting"), []int{4, 7})
+	test.T(t, h.Hyphenate("associate"), []int{2, 4}) // exception
+	test.T(t, h.Hyphenate("project"), []int(nil))    // exception without hyphens
+}
@@ -3,6 +3,7 @@ package text
 import (
 	"fmt"
 	"math"
+	"strings"
 	"unicode"
 	"unicode/utf8"
 
@@ -50,6 +51,12 @@ var DemeritsFitness = 100.0
 // HyphenPenalty is the aesthetic cost of ending a line in a hyphen.
 var HyphenPenalty = 50.0
 
+// RelPenalty is the aesthetic cost of breaking a line after a relation in an inline formula, such as an equals sign.
+var RelPenalty = 500.0
+
+// BinOpPenalty is the aesthetic cost of breaking a line after a binary operator in an inline formula, such as a plus sign.
+var BinOpPenalty = 700.0
+
 // Infinity specifies infinity as something finite to prevent numerical errors.
 var Infinity = 1000.0 // in case of ratio, demerits become about 1e22
 
@@ -551,6 +558,11 @@ func GlyphsToItems(glyphs []Glyph, indent float64, align Align) []Item {
 		stretchWidth /= n
 	}
 
+	var hyphens []bool
+	if align != Centered {
+		hyphens = hyphenationPoints(glyphs)
+	}
+
 	items := []Item{}
 	items = append(items, Box(indent))
 	if align == Centered {
@@ -614,6 +626,14 @@ func GlyphsToItems(glyphs []Glyph, indent float64, align Align) []Item {
 				items = append(items, Penalty(0.0, Infinity, false))
 				items = append(items, Glue(0.0, stretchWidth, 0.0))
 			}
+		} else if glyph.Text == "\t" {
+			// tabs are positioned at tab stops after line breaking
+			if items[len(items)-1].Type == GlueType {
+				items[len(items)-1].Size++
+			} else {
+				items = append(items, Glue(0.0, 0.0, 0.0))
+				items[len(items)-1].Size++
+			}
 		} else if isNewline(glyph.Text) {
 			if glyph.Text != "\n" || i == 0 || glyphs[i-1].Text != "\r" {
 				items = append(items, Penalty(0.0, -Infinity, false))
@@ -621,25 +641,7 @@ func GlyphsToItems(glyphs []Glyph, indent float64, align Align) []Item {
 			items[len(items)-1].Size++
 		} else if glyph.Text == "\u200B" {
 			// optional hyphens
-			var hyphenWidth float64
-			if !glyph.Vertical {
-				hyphenWidth = float64(glyph.SFNT.GlyphAdvance(glyph.SFNT.GlyphIndex('-')))
-			} else {
-				hyphenWidth = float64(glyph.SFNT.GlyphVerticalAdvance(glyph.SFNT.GlyphIndex('-')))
-			}
-			hyphenWidth *= glyph.Size / float64(glyph.SFNT.Head.UnitsPerEm)
-			if align == Justified {
-				items = append(items, Penalty(hyphenWidth, HyphenPenalty, true))
-				items[len(items)-1].Size++
-			} else if align == Left || align == Right {
-				items = append(items, Penalty(0.0, Infinity, false))
-				items = append(items, Glue(0.0, stretchWidth, 0.0))
-				items = append(items, Penalty(hyphenWidth, 10.0*HyphenPenalty, true))
-				items[len(items)-1].Size++
-				items = append(items, Glue(0.0, -stretchWidth, 0.0))
-			} else if align == Centered {
-				// nothing
-			}
+			items = appendHyphen(items, glyph, align, stretchWidth, 1)
 		} else {
 			// glyphs
 			var width float64
@@ -648,6 +650,10 @@ func GlyphsToItems(glyphs []Glyph, indent float64, align Align) []Item {
 			} else {
 				width = float64(-glyph.YAdvance) * glyph.Size / float64(glyph.SFNT.Head.UnitsPerEm)
 			}
+			if hyphens != nil && hyphens[i] {
+				// hyphenation point within a word
+				items = appendHyphen(items, glyph, align, stretchWidth, 0)
+			}
 			if 1 < len(items) && items[len(items)-1].Type == BoxType {
 				if IsSpacelessScript(glyph.Script) || IsSpacelessScript(glyphs[i-1].Script) {
 					// allow breaks around spaceless script glyphs, most commonly CJK
@@ -677,6 +683,89 @@ func GlyphsToItems(glyphs []Glyph, indent float64, align Align) []Item {
 	return items
 }
 
+// appendHyphen appends the items of an optional hyphen after the given glyph, where size is the number of glyphs that the penalty represents (one for an explicit soft hyphen and zero for a hyphenation point).
+func appendHyphen(items []Item, glyph Glyph, align Align, stretchWidth float64, size int) []Item {
+	var hyphenWidth float64
+	if !glyph.Vertical {
+		hyphenWidth = float64(glyph.SFNT.GlyphAdvance(glyph.SFNT.GlyphIndex('-')))
+	} else {
+		hyphenWidth = float64(glyph.SFNT.GlyphVerticalAdvance(glyph.SFNT.GlyphIndex('-')))
+	}
+	hyphenWidth *= glyph.Size / float64(glyph.SFNT.Head.UnitsPerEm)
+	if align == Justified {
+		items = append(items, Penalty(hyphenWidth, HyphenPenalty, true))
+		items[len(items)-1].Size += size
+	} else if align == Left || align == Right {
+		items = append(items, Penalty(0.0, Infinity, false))
+		items = append(items, Glue(0.0, stretchWidth, 0.0))
+		items = append(items, Penalty(hyphenWidth, 10.0*HyphenPenalty, true))
+		items[len(items)-1].Size += size
+		items = append(items, Glue(0.0, -stretchWidth, 0.0))
+	} else if align == Centered {
+		// nothing
+	}
+	return items
+}
+
+// hyphenationPoints returns for each glyph whether a hyphen may be inserted before it, using the hyphenator registered for the language of the glyphs. Words are runs of letters in logical order, and hyphens are only placed between clusters. It returns nil if no hyphenation points were found.
+func hyphenationPoints(glyphs []Glyph) []bool {
+	var hyphens []bool
+	for i := 0; i < len(glyphs); {
+		if glyphs[i].Text == "" || !isLetters(glyphs[i].Text) {
+			i++
+			continue
+		}
+
+		// find the end of the word, glyphs without text belong to the cluster of the previous glyph
+		j := i + 1
+		for j < len(glyphs) && glyphs[j].Language == glyphs[i].Language && glyphs[j].Vertical == glyphs[i].Vertical {
+			if glyphs[j].Text == "" && glyphs[j].Cluster != glyphs[j-1].Cluster || glyphs[j].Text != "" && (!isLetters(glyphs[j].Text) || glyphs[j].Cluster < glyphs[j-1].Cluster) {
+				break
+			}
+			j++
+		}
+
+		hyphenator := LookupHyphenator(glyphs[i].Language)
+		if hyphenator != nil && 1 < j-i {
+			// concatenate the clusters and keep the position in runes at which each glyph starts
+			word := strings.Builder{}
+			offsets := make([]int, j-i)
+			n := 0
+			for k := i; k < j; k++ {
+				offsets[k-i] = -1
+				if k == i || glyphs[k].Cluster != glyphs[k-1].Cluster {
+					offsets[k-i] = n
+					word.WriteString(glyphs[k].Text)
+					n += utf8.RuneCountInString(glyphs[k].Text)
+				}
+			}
+			for _, pos := range hyphenator.Hyphenate(word.String()) {
+				for k := i + 1; k < j; k++ {
+					if offsets[k-i] == pos {
+						if hyphens == nil {
+							hyphens = make([]bool, len(glyphs))
+						}
+						hyphens[k] = true
+						break
+					}
+				}
+			}
+		}
+		i = j
+	}
+	return hyphens
+}
+
+// isLetters returns true if the string consists of letters and combining marks only.
+func isLetters(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.Is(unicode.Mn, r) {
+			return false
+		}
+	}
+	return true
+}
+
 // LinebreakGlyphs breaks a slice of glyphs uing the given SFNT font and font size. The indent and width specify the first line's indentation and the maximum line's width respectively. Align sets the horizontal alignment of the text. The looseness specifies whether it is desirable to have less or more lines than optimal.
 func LinebreakGlyphs(sfnt *font.SFNT, size float64, glyphs []Glyph, indent, width float64, align Align, looseness int) [][]Glyph {
 	if len(glyphs) == 0 {
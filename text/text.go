@@ -11,7 +11,8 @@ type Glyph struct {
 	SFNT *font.SFNT
 	Size float64
 	Script
-	Vertical bool // is false for Latin/Mongolian/etc in a vertical layout
+	Vertical bool   // is false for Latin/Mongolian/etc in a vertical layout
+	Language string // BCP 47 language tag used for shaping and hyphenation
 
 	ID       uint16
 	Cluster  uint32
@@ -37,8 +38,6 @@ func (g Glyph) Rotation() Rotation {
 	return rot
 }
 
-// TODO: implement Liang's (soft) hyphenation algorithm?
-
 // IsParagraphSeparator returns true for paragraph separator runes.
 func IsParagraphSeparator(r rune) bool {
 	// line feed, vertical tab, form feed, carriage return, next line, line separator, paragraph separator
@@ -0,0 +1,222 @@
+package canvas
+
+import (
+	"math"
+	"strings"
+)
+
+// TextFlow flows a rich text through a chain of frames, such as the columns of a page followed by the columns of the next page. Each frame receives as many lines as fit and the remaining lines are kept for the next frame. Lines are broken using Donald Knuth's line breaking algorithm over the whole text, and the text is only broken again when a frame has a different line width than the previous frame. Only the horizontal writing mode is supported.
+type TextFlow struct {
+	Orphans int // minimum number of lines of a paragraph at the bottom of a frame
+	Widows  int // minimum number of lines of a paragraph at the top of a frame
+
+	rt                  *RichText // text of the current layout
+	halign              TextAlign
+	indent, lineStretch float64
+
+	laidOut bool
+	placed  bool    // whether lines have been placed in a frame
+	width   float64 // line width of the current layout
+	lines   []line  // remaining lines of the current layout
+}
+
+// Flow returns a text flow of the rich text with the given horizontal alignment (Left, Center, Right or Justify), the indentation of the first line, and the line stretch (percentage to stretch the line based on the line height). By default at least two lines of a paragraph are kept together at the bottom and at the top of a frame.
+func (rt *RichText) Flow(halign TextAlign, indent, lineStretch float64) *TextFlow {
+	return &TextFlow{
+		Orphans:     2,
+		Widows:      2,
+		rt:          rt,
+		halign:      halign,
+		indent:      indent,
+		lineStretch: lineStretch,
+	}
+}
+
+// slice returns the rich text between the given positions in runes, where end is clamped to the length of the text.
+func (rt *RichText) slice(start, end int) *RichText {
+	runes := []rune(rt.String())
+	if len(runes) < end {
+		end = len(runes)
+	}
+	if end < start {
+		start = end
+	}
+	k := rt.locs.index(start)
+	l := rt.styleLocs.index(start)
+	rt2 := &RichText{
+		Builder:     &strings.Builder{},
+		locs:        indexer{0},
+		faces:       []*FontFace{rt.faces[k]},
+		mode:        rt.mode,
+		orient:      rt.orient,
+		defaultFace: rt.defaultFace,
+		objects:     rt.objects,
+		styleLocs:   indexer{0},
+		styles:      []*ParagraphStyle{rt.styles[l]},
+		continued:   0 < start && !rt.isParagraphBreak(runes, start-1) || start == 0 && rt.continued,
+	}
+	rt2.WriteString(string(runes[start:end]))
+	for i := k + 1; i < len(rt.locs) && rt.locs[i] < end; i++ {
+		rt2.locs = append(rt2.locs, rt.locs[i]-start)
+		rt2.faces = append(rt2.faces, rt.faces[i])
+	}
+	for i := l + 1; i < len(rt.styleLocs) && rt.styleLocs[i] < end; i++ {
+		rt2.styleLocs = append(rt2.styleLocs, rt.styleLocs[i]-start)
+		rt2.styles = append(rt2.styles, rt.styles[i])
+	}
+	return rt2
+}
+
+// Done returns true when all text has been placed in frames.
+func (f *TextFlow) Done() bool {
+	return f.rt.Len() == 0 || f.laidOut && len(f.lines) == 0
+}
+
+// Remainder returns the text that has not yet been placed in frames, or nil if all text has been placed.
+func (f *TextFlow) Remainder() *RichText {
+	if f.Done() {
+		return nil
+	} else if !f.laidOut {
+		return f.rt
+	}
+	return f.rt.slice(f.lines[0].start, math.MaxInt)
+}
+
+// Next places the next lines of text in a frame of the given width and height, and returns them as a text that can be drawn with its top-left corner at the top-left corner of the frame. The returned text is empty when all text has been placed or when not even one line fits in the frame.
+func (f *TextFlow) Next(width, height float64) *Text {
+	return f.NextColumns(width, height, 1, 0.0, false)
+}
+
+// NextColumns places the next lines of text in a frame of the given width and height that is divided into columns separated by gap. Columns are filled from left to right and are returned together as a single text that can be drawn with its top-left corner at the top-left corner of the frame. When balanced is set and the remaining text fits in the frame, the columns are made as short and as equal in height as possible.
+func (f *TextFlow) NextColumns(width, height float64, columns int, gap float64, balanced bool) *Text {
+	if columns < 1 {
+		columns = 1
+	}
+	columnWidth := (width - float64(columns-1)*gap) / float64(columns)
+	f.layout(columnWidth)
+
+	if balanced {
+		// find the smallest column height that fits all remaining lines
+		if f.fits(height, columns) {
+			lo, hi := 0.0, height
+			for i := 0; i < 32 && Epsilon < hi-lo; i++ {
+				mid := (lo + hi) / 2.0
+				if f.fits(mid, columns) {
+					hi = mid
+				} else {
+					lo = mid
+				}
+			}
+			height = hi
+		}
+	}
+
+	t := &Text{
+		lines:           []line{},
+		fonts:           map[*Font]bool{},
+		WritingMode:     f.rt.mode,
+		TextOrientation: f.rt.orient,
+	}
+	for c := 0; c < columns && 0 < len(f.lines); c++ {
+		n := f.keepTogether(f.lines, f.fit(f.lines, height))
+		y := 0.0
+		dx := float64(c) * (columnWidth + gap)
+		for j, l := range f.lines[:n] {
+			y += f.advance(f.lines, j)
+			l.y = y
+			l.spans = append([]TextSpan{}, l.spans...)
+			for i := range l.spans {
+				l.spans[i].x += dx
+				if l.spans[i].IsText() {
+					t.fonts[l.spans[i].Face.Font] = true
+				}
+			}
+			t.lines = append(t.lines, l)
+		}
+		f.lines = f.lines[n:]
+		if 0 < n {
+			f.placed = true
+		}
+	}
+	return t
+}
+
+// layout breaks the remaining text into lines of the given width, unless it already has been.
+func (f *TextFlow) layout(width float64) {
+	if f.laidOut && (width == f.width || len(f.lines) == 0) {
+		return
+	}
+
+	indent := f.indent
+	if f.laidOut {
+		f.rt = f.rt.slice(f.lines[0].start, math.MaxInt)
+	}
+	if f.placed {
+		indent = 0.0
+	}
+	f.laidOut = true
+	f.width = width
+	f.lines = nil
+	if f.rt.Len() != 0 {
+		f.lines = f.rt.ToText(width, 0.0, f.halign, Top, indent, f.lineStretch).lines
+	}
+}
+
+// advance returns the distance from the baseline of the previous line, or from the top of the frame for the first line, to the baseline of line j.
+func (f *TextFlow) advance(lines []line, j int) float64 {
+	if j == 0 {
+		return lines[0].ascent
+	}
+	lineSpacing := 1.0 + f.lineStretch
+	return (lines[j-1].bottom+lines[j].ascent)*lineSpacing + lines[j].spaceBefore
+}
+
+// fit returns the number of lines that fit in a column of the given height.
+func (f *TextFlow) fit(lines []line, height float64) int {
+	y := 0.0
+	for j := range lines {
+		y += f.advance(lines, j)
+		if height < y+lines[j].descent-Epsilon {
+			return j
+		}
+	}
+	return len(lines)
+}
+
+// fits returns true if all remaining lines fit in the given number of columns of the given height.
+func (f *TextFlow) fits(height float64, columns int) bool {
+	lines := f.lines
+	for c := 0; c < columns && 0 < len(lines); c++ {
+		lines = lines[f.keepTogether(lines, f.fit(lines, height)):]
+	}
+	return len(lines) == 0
+}
+
+// keepTogether reduces the number of lines n that are placed in a frame so that the paragraph that is split at the end of the frame keeps at least Orphans lines in this frame and Widows lines in the next frame. It returns n if the paragraph cannot be moved to the next frame in its entirety because it starts at the top of the frame.
+func (f *TextFlow) keepTogether(lines []line, n int) int {
+	if n == 0 || n == len(lines) || lines[n-1].paragraphEnd {
+		return n
+	}
+
+	// the split paragraph consists of lines p through e
+	p := n - 1
+	for 0 < p && !lines[p-1].paragraphEnd {
+		p--
+	}
+	e := n
+	for e < len(lines)-1 && !lines[e].paragraphEnd {
+		e++
+	}
+
+	k := n
+	if e+1-k < f.Widows {
+		k = e + 1 - f.Widows
+	}
+	if k-p < f.Orphans {
+		k = p
+	}
+	if k <= 0 {
+		return n
+	}
+	return k
+}
@@ -0,0 +1,106 @@
+package canvas
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tdewolff/test"
+)
+
+func flowLines(text *Text) []string {
+	lines := []string{}
+	for _, line := range text.lines {
+		s := ""
+		for _, span := range line.spans {
+			s += span.Text
+		}
+		lines = append(lines, strings.TrimSpace(s))
+	}
+	return lines
+}
+
+func TestTextFlow(t *testing.T) {
+	font, err := LoadFontFile("resources/DejaVuSerif.ttf", FontRegular)
+	if err != nil {
+		t.Fatal(err)
+	}
+	face := font.Face(12, Black)
+	metrics := face.Metrics()
+	heights := func(n int) float64 {
+		// height of n lines
+		return float64(n)*(metrics.Ascent+metrics.Descent) + float64(n-1)*metrics.LineGap
+	}
+	width := face.TextWidth("111 222") + 1.0 // less than a space
+
+	rt := NewRichText(face)
+	rt.Add(face, "111 222 333 444 555 666 777 888 999 000")
+
+	// five lines, where the second frame would only get one line of the paragraph
+	flow := rt.Flow(Left, 0.0, 0.0)
+	text := flow.Next(width, heights(4))
+	test.T(t, flowLines(text), []string{"111 222", "333 444", "555 666"})
+	test.String(t, flow.Remainder().String(), "777 888 999 000")
+	test.That(t, !flow.Done())
+	text = flow.Next(width, heights(4))
+	test.T(t, flowLines(text), []string{"777 888", "999 000"})
+	test.Float(t, text.lines[0].y, metrics.Ascent)
+	test.That(t, flow.Done())
+	test.That(t, flow.Remainder() == nil)
+	test.That(t, flow.Next(width, heights(4)).Empty())
+
+	// without widow control
+	flow = rt.Flow(Left, 0.0, 0.0)
+	flow.Widows = 1
+	test.T(t, len(flowLines(flow.Next(width, heights(4)))), 4)
+
+	// the next frame is wider, so that the remainder is broken again
+	flow = rt.Flow(Left, 0.0, 0.0)
+	flow.Next(width, heights(2))
+	text = flow.Next(face.TextWidth("555 666 777")+1.0, heights(4))
+	test.T(t, flowLines(text), []string{"555 666 777", "888 999 000"})
+
+	// a frame too small for a single line
+	flow = rt.Flow(Left, 0.0, 0.0)
+	test.That(t, flow.Next(width, heights(1)/2.0).Empty())
+	test.That(t, !flow.Done())
+}
+
+func TestTextFlowColumns(t *testing.T) {
+	font, err := LoadFontFile("resources/DejaVuSerif.ttf", FontRegular)
+	if err != nil {
+		t.Fatal(err)
+	}
+	face := font.Face(12, Black)
+	metrics := face.Metrics()
+	heights := func(n int) float64 {
+		return float64(n)*(metrics.Ascent+metrics.Descent) + float64(n-1)*metrics.LineGap
+	}
+	columnWidth := face.TextWidth("111 222") + 1.0
+	gap := 5.0
+
+	rt := NewRichText(face)
+	rt.Add(face, "111 222 333 444\n555 666 777 888")
+
+	// two paragraphs of two lines each fill the first column
+	flow := rt.Flow(Left, 0.0, 0.0)
+	text := flow.NextColumns(2.0*columnWidth+gap, heights(10), 2, gap, false)
+	test.T(t, flowLines(text), []string{"111 222", "333 444", "555 666", "777 888"})
+	for _, line := range text.lines {
+		test.Float(t, line.spans[0].x, 0.0)
+	}
+
+	// balanced columns put each paragraph in a column
+	flow = rt.Flow(Left, 0.0, 0.0)
+	text = flow.NextColumns(2.0*columnWidth+gap, heights(10), 2, gap, true)
+	test.T(t, flowLines(text), []string{"111 222", "333 444", "555 666", "777 888"})
+	test.Float(t, text.lines[1].spans[0].x, 0.0)
+	test.Float(t, text.lines[2].spans[0].x, columnWidth+gap)
+	test.Float(t, text.lines[2].y, text.lines[0].y)
+	test.That(t, flow.Done())
+
+	// the second paragraph continues in the next frame
+	flow = rt.Flow(Left, 0.0, 0.0)
+	text = flow.NextColumns(2.0*columnWidth+gap, heights(1), 2, gap, false)
+	test.T(t, flowLines(text), []string{"111 222", "333 444"})
+	test.String(t, flow.Remainder().String(), "555 666 777 888")
+}
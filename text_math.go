@@ -0,0 +1,939 @@
+package canvas
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	canvasFont "github.com/LaminoidStudio/Canvas/font"
+	canvasText "github.com/LaminoidStudio/Canvas/text"
+)
+
+// MathStyle is the TeX style in which a formula is set, which determines the size of scripts and how fractions and large operators are laid out.
+type MathStyle int
+
+// see MathStyle
+const (
+	DisplayStyle MathStyle = iota
+	TextStyle
+	ScriptStyle
+	ScriptScriptStyle
+)
+
+func (style MathStyle) String() string {
+	switch style {
+	case DisplayStyle:
+		return "Display"
+	case TextStyle:
+		return "Text"
+	case ScriptStyle:
+		return "Script"
+	case ScriptScriptStyle:
+		return "ScriptScript"
+	}
+	return fmt.Sprintf("MathStyle(%d)", style)
+}
+
+// NewMathText typesets a LaTeX math formula, without the surrounding dollar signs, in the given style using the OpenType MATH table of the font face's font, such as Latin Modern Math. It supports fractions, binomials, sub- and superscripts, radicals, stretchy delimiters, large operators with limits, accents, and the matrix, array and cases environments. The baseline of the formula is at the origin. Glyphs are kept as text, while rules and stretched glyph variants that have no character are drawn as paths in the face's color.
+func NewMathText(face *FontFace, formula string, style MathStyle) (*Text, error) {
+	list, err := parseMath(formula)
+	if err != nil {
+		return nil, err
+	}
+	l, err := newMathLayout(face)
+	if err != nil {
+		return nil, err
+	}
+	box := l.layoutList(list, mathState{style: style})
+	return box.toText(face.Font), nil
+}
+
+////////////////////////////////////////////////////////////////
+
+// mathState is the style of a subformula, where cramped styles raise superscripts less.
+type mathState struct {
+	style   MathStyle
+	cramped bool
+}
+
+func (s mathState) sup() mathState {
+	if s.style <= TextStyle {
+		return mathState{ScriptStyle, s.cramped}
+	}
+	return mathState{ScriptScriptStyle, s.cramped}
+}
+
+func (s mathState) sub() mathState {
+	return mathState{s.sup().style, true}
+}
+
+func (s mathState) num() mathState {
+	if s.style == DisplayStyle {
+		return mathState{TextStyle, s.cramped}
+	}
+	return s.sup()
+}
+
+func (s mathState) den() mathState {
+	return mathState{s.num().style, true}
+}
+
+// mathItem is a glyph or a rule of a laid out formula, positioned relative to the baseline origin of its box with the y-axis pointing up.
+type mathItem struct {
+	x, y  float64
+	face  *FontFace
+	glyph canvasText.Glyph // glyphs without text are variants that have no character
+
+	rule bool
+	w, h float64
+}
+
+// mathBox is a laid out subformula with its baseline origin at (0,0). Height and depth are the extents above and below the baseline.
+type mathBox struct {
+	width, height, depth float64
+	italic               float64 // italics correction
+	accent               float64 // horizontal position at which to attach accents
+	glyph                bool    // box consists of a single glyph
+	extended             bool    // box is an extended shape such as a large operator
+	items                []mathItem
+}
+
+// add places the items of another box at (x,y) and grows the height and depth.
+func (b *mathBox) add(box *mathBox, x, y float64) {
+	for _, item := range box.items {
+		item.x += x
+		item.y += y
+		b.items = append(b.items, item)
+	}
+	b.height = math.Max(b.height, box.height+y)
+	b.depth = math.Max(b.depth, box.depth-y)
+}
+
+// addRule adds a rule with its bottom-left corner at (x,y).
+func (b *mathBox) addRule(face *FontFace, x, y, w, h float64) {
+	b.items = append(b.items, mathItem{x: x, y: y, face: face, rule: true, w: w, h: h})
+	b.height = math.Max(b.height, y+h)
+	b.depth = math.Max(b.depth, -y)
+}
+
+// shift moves the box up by dy.
+func (b *mathBox) shift(dy float64) {
+	for i := range b.items {
+		b.items[i].y += dy
+	}
+	b.height += dy
+	b.depth -= dy
+}
+
+type mathLayout struct {
+	sfnt  *canvasFont.SFNT
+	c     canvasFont.MathConstants
+	faces [3]*FontFace // for the display and text, script, and scriptscript styles
+}
+
+func newMathLayout(face *FontFace) (*mathLayout, error) {
+	if face.Font.Math == nil {
+		return nil, fmt.Errorf("latex: font %s has no MATH table", face.Font.Name())
+	}
+	l := &mathLayout{
+		sfnt: face.Font.SFNT,
+		c:    face.Font.Math.Constants,
+	}
+	for i, percent := range []int16{100, l.c.ScriptPercentScaleDown, l.c.ScriptScriptPercentScaleDown} {
+		if percent <= 0 {
+			percent = []int16{100, 70, 50}[i]
+		}
+		styleFace := *face
+		styleFace.Size = face.Size * float64(percent) / 100.0
+		styleFace.mmPerEm = styleFace.Size / float64(face.Font.Head.UnitsPerEm)
+		styleFace.Deco = nil
+		styleFace.XOffset, styleFace.YOffset = 0, 0
+		l.faces[i] = &styleFace
+	}
+	return l, nil
+}
+
+func (l *mathLayout) face(style MathStyle) *FontFace {
+	if style <= TextStyle {
+		return l.faces[0]
+	}
+	return l.faces[style-TextStyle]
+}
+
+// units converts a MATH constant in font units to millimeters for the given style.
+func (l *mathLayout) units(style MathStyle, v int16) float64 {
+	return l.face(style).mmPerEm * float64(v)
+}
+
+// mathSpacing is the spacing between atom classes following the TeXbook, where 1, 2, and 3 are thin, medium, and thick spaces and negative values are omitted in script styles.
+var mathSpacing = [8][8]int8{
+	// Ord Op Bin Rel Open Close Punct Inner
+	{0, 1, -2, -3, 0, 0, 0, -1},     // Ord
+	{1, 1, 0, -3, 0, 0, 0, -1},      // Op
+	{-2, -2, 0, 0, -2, 0, 0, -2},    // Bin
+	{-3, -3, 0, 0, -3, 0, 0, -3},    // Rel
+	{0, 0, 0, 0, 0, 0, 0, 0},        // Open
+	{0, 1, -2, -3, 0, 0, 0, -1},     // Close
+	{-1, -1, 0, -1, -1, -1, -1, -1}, // Punct
+	{-1, 1, -2, -3, -1, 0, -1, -1},  // Inner
+}
+
+// space returns the space between two atom classes.
+func (l *mathLayout) space(left, right mathClass, style MathStyle) float64 {
+	s := mathSpacing[left][right]
+	if s < 0 {
+		if ScriptStyle <= style {
+			return 0.0
+		}
+		s = -s
+	}
+	mu := l.face(style).Size / 18.0
+	return []float64{0.0, 3.0, 4.0, 5.0}[s] * mu
+}
+
+// glyphBox returns the box of a single glyph, where text is empty for glyph variants that have no character.
+func (l *mathLayout) glyphBox(glyphID uint16, text string, style MathStyle) *mathBox {
+	face := l.face(style)
+	f := face.mmPerEm
+	xmin, ymin, xmax, ymax, _ := l.sfnt.GlyphBounds(glyphID)
+	advance := int32(l.sfnt.GlyphAdvance(glyphID))
+	box := &mathBox{
+		width:  f * float64(advance),
+		height: math.Max(0.0, f*float64(ymax)),
+		depth:  math.Max(0.0, -f*float64(ymin)),
+		glyph:  true,
+		items: []mathItem{{
+			face: face,
+			glyph: canvasText.Glyph{
+				SFNT:     l.sfnt,
+				Size:     face.Size,
+				ID:       glyphID,
+				XAdvance: advance,
+				Text:     text,
+			},
+		}},
+	}
+	if italic, ok := l.sfnt.Math.ItalicsCorrection(glyphID); ok {
+		box.italic = f * float64(italic)
+	}
+	box.extended = l.sfnt.Math.IsExtendedShape(glyphID)
+	if accent, ok := l.sfnt.Math.TopAccentAttachment(glyphID); ok {
+		box.accent = f * float64(accent)
+	} else if advance == 0 {
+		box.accent = f * float64(xmin+xmax) / 2.0
+	} else {
+		box.accent = box.width / 2.0
+	}
+	return box
+}
+
+// charBox returns the box of a character in the given variant, falling back to the character itself if the font has no glyph for the variant.
+func (l *mathLayout) charBox(r rune, variant mathVariant, style MathStyle) *mathBox {
+	if rv := mathAlphanumeric(r, variant); rv != r {
+		if glyphID := l.sfnt.GlyphIndex(rv); glyphID != 0 {
+			return l.glyphBox(glyphID, string(rv), style)
+		}
+	}
+	return l.glyphBox(l.sfnt.GlyphIndex(r), string(r), style)
+}
+
+// stretch returns the box of a glyph that is stretched vertically to at least the given size by choosing a larger variant or by building a glyph assembly. The glyph's baseline is at the origin.
+func (l *mathLayout) stretch(r rune, size float64, style MathStyle) *mathBox {
+	glyphID := l.sfnt.GlyphIndex(r)
+	construction, ok := l.sfnt.Math.VerticalConstruction(glyphID)
+	if !ok {
+		return l.glyphBox(glyphID, string(r), style)
+	}
+	f := l.face(style).mmPerEm
+	for _, variant := range construction.Variants {
+		if size <= f*float64(variant.Advance) {
+			return l.variantBox(glyphID, variant.GlyphID, string(r), style)
+		}
+	}
+	if construction.Assembly == nil || len(construction.Assembly.Parts) == 0 {
+		if len(construction.Variants) == 0 {
+			return l.glyphBox(glyphID, string(r), style)
+		}
+		return l.variantBox(glyphID, construction.Variants[len(construction.Variants)-1].GlyphID, string(r), style)
+	}
+
+	// find the number of times the extenders are repeated
+	parts := construction.Assembly.Parts
+	overlap := float64(l.sfnt.Math.MinConnectorOverlap)
+	fixed, extenders := 0.0, 0.0
+	numFixed, numExtenders := 0, 0
+	for _, part := range parts {
+		if part.Extender {
+			extenders += float64(part.FullAdvance)
+			numExtenders++
+		} else {
+			fixed += float64(part.FullAdvance)
+			numFixed++
+		}
+	}
+	target := size / f
+	repeats := 0
+	if extenders-float64(numExtenders)*overlap <= 0.0 {
+		numExtenders = 0
+	} else if length := fixed - float64(numFixed-1)*overlap; length < target {
+		repeats = int(math.Ceil((target - length) / (extenders - float64(numExtenders)*overlap)))
+		if 1000 < repeats {
+			repeats = 1000
+		}
+	}
+	assembly := []canvasFont.MathGlyphPart{}
+	for _, part := range parts {
+		if !part.Extender {
+			assembly = append(assembly, part)
+		} else {
+			for i := 0; i < repeats; i++ {
+				assembly = append(assembly, part)
+			}
+		}
+	}
+
+	// distribute the excess length over the connectors
+	length := -float64(len(assembly)-1) * overlap
+	for _, part := range assembly {
+		length += float64(part.FullAdvance)
+	}
+	if 1 < len(assembly) && target < length {
+		overlap += (length - target) / float64(len(assembly)-1)
+	}
+
+	box := &mathBox{}
+	face := l.face(style)
+	y := 0.0
+	for i, part := range assembly {
+		if 0 < i {
+			maxOverlap := float64(assembly[i-1].EndConnectorLength)
+			if start := float64(part.StartConnectorLength); start < maxOverlap {
+				maxOverlap = start
+			}
+			y -= math.Max(0.0, math.Min(overlap, maxOverlap))
+		}
+		// parts are stacked by their ink boxes as not all fonts put their baseline at the bottom
+		_, ymin, _, _, _ := l.sfnt.GlyphBounds(part.GlyphID)
+		advance := int32(l.sfnt.GlyphAdvance(part.GlyphID))
+		box.items = append(box.items, mathItem{
+			y:    f * (y - float64(ymin)),
+			face: face,
+			glyph: canvasText.Glyph{
+				SFNT:     l.sfnt,
+				Size:     face.Size,
+				ID:       part.GlyphID,
+				XAdvance: advance,
+			},
+		})
+		box.width = math.Max(box.width, f*float64(advance))
+		y += float64(part.FullAdvance)
+	}
+	box.height = f * y
+	box.italic = f * float64(construction.Assembly.ItalicsCorrection)
+	box.accent = box.width / 2.0
+	box.extended = true
+	return box
+}
+
+// variantBox returns the box of a glyph variant, which keeps the text only if it is the original glyph.
+func (l *mathLayout) variantBox(glyphID, variantID uint16, text string, style MathStyle) *mathBox {
+	if variantID != glyphID {
+		text = ""
+	}
+	return l.glyphBox(variantID, text, style)
+}
+
+// delimiter returns a delimiter that is centered on the math axis and stretched to at least the given size, where zero is an empty delimiter.
+func (l *mathLayout) delimiter(r rune, size float64, style MathStyle) *mathBox {
+	if r == 0 {
+		return &mathBox{width: 0.12 * l.face(style).Size} // null delimiter space
+	}
+	box := l.stretch(r, size, style)
+	box.shift(l.units(style, l.c.AxisHeight) - (box.height-box.depth)/2.0)
+	return box
+}
+
+// delimiterSize returns the size of delimiters around a box, following the TeX parameters \delimiterfactor=901 and \delimitershortfall=5pt.
+func (l *mathLayout) delimiterSize(height, depth float64, style MathStyle) float64 {
+	axis := l.units(style, l.c.AxisHeight)
+	size := 2.0 * math.Max(height-axis, depth+axis)
+	return math.Max(size*0.901, size-0.5*l.face(style).Size)
+}
+
+// mathListAtom is an atom of a list that is laid out, with its class after reclassification.
+type mathListAtom struct {
+	box   *mathBox
+	class mathClass
+	style MathStyle
+	kern  float64 // explicit space before the atom
+}
+
+// layoutAtoms lays out the nodes of a list and reclassifies binary operators. It returns the atoms and the explicit space after the last atom.
+func (l *mathLayout) layoutAtoms(list mathList, state mathState) ([]mathListAtom, float64) {
+	atoms := []mathListAtom{}
+	kern := 0.0
+	for _, node := range list {
+		switch n := node.(type) {
+		case mathStyleChange:
+			state.style = MathStyle(n)
+			continue
+		case mathSpace:
+			kern += float64(n) * l.face(state.style).Size / 18.0
+			continue
+		}
+		box, class := l.layoutNode(node, state)
+		atoms = append(atoms, mathListAtom{box, class, state.style, kern})
+		kern = 0.0
+	}
+
+	// binary operators become ordinary when they don't have operands on both sides
+	for i := range atoms {
+		if atoms[i].class == mathBin {
+			if i == 0 || i+1 == len(atoms) {
+				atoms[i].class = mathOrd
+			} else {
+				switch atoms[i-1].class {
+				case mathBin, mathOp, mathRel, mathOpen, mathPunct:
+					atoms[i].class = mathOrd
+				}
+			}
+		} else if 0 < i && atoms[i-1].class == mathBin {
+			switch atoms[i].class {
+			case mathRel, mathClose, mathPunct:
+				atoms[i-1].class = mathOrd
+			}
+		}
+	}
+	return atoms, kern
+}
+
+// layoutList lays out a list of nodes horizontally with the spacing between atoms.
+func (l *mathLayout) layoutList(list mathList, state mathState) *mathBox {
+	atoms, kern := l.layoutAtoms(list, state)
+	box := &mathBox{}
+	x := 0.0
+	for i, a := range atoms {
+		if 0 < i {
+			x += l.space(atoms[i-1].class, a.class, a.style)
+		}
+		x += a.kern
+		box.add(a.box, x, 0.0)
+		x += a.box.width
+	}
+	box.width = x + kern
+	if len(atoms) == 1 && atoms[0].kern == 0.0 && kern == 0.0 {
+		box.italic = atoms[0].box.italic
+		box.accent = atoms[0].box.accent
+		box.glyph = atoms[0].box.glyph
+		box.extended = atoms[0].box.extended
+	} else {
+		box.accent = box.width / 2.0
+	}
+	return box
+}
+
+// layoutPieces lays out an inline formula and splits it after the relations and binary operators of the outer list, where TeX allows line breaks. It returns the pieces, the penalties of breaking a line after each piece, and the space after each piece, which follows the relation or operator and is dropped when the line breaks after it. The last piece has no penalty and no space.
+func (l *mathLayout) layoutPieces(list mathList, state mathState) ([]*mathBox, []float64, []float64) {
+	atoms, kern := l.layoutAtoms(list, state)
+	boxes := []*mathBox{{}}
+	penalties := []float64{0.0}
+	spaces := []float64{0.0}
+	x := 0.0
+	for i, a := range atoms {
+		box := boxes[len(boxes)-1]
+		x += a.kern
+		box.add(a.box, x, 0.0)
+		x += a.box.width
+		if i+1 < len(atoms) {
+			next := atoms[i+1]
+			space := l.space(a.class, next.class, next.style)
+			if a.class == mathRel && next.class != mathRel || a.class == mathBin {
+				box.width = x
+				spaces[len(spaces)-1] = space
+				if a.class == mathRel {
+					penalties[len(penalties)-1] = canvasText.RelPenalty
+				} else {
+					penalties[len(penalties)-1] = canvasText.BinOpPenalty
+				}
+				boxes = append(boxes, &mathBox{})
+				penalties = append(penalties, 0.0)
+				spaces = append(spaces, 0.0)
+				x = 0.0
+			} else {
+				x += space
+			}
+		}
+	}
+	boxes[len(boxes)-1].width = x + kern
+	return boxes, penalties, spaces
+}
+
+// layoutNode lays out a node and returns its box and atom class.
+func (l *mathLayout) layoutNode(node mathNode, state mathState) (*mathBox, mathClass) {
+	switch n := node.(type) {
+	case nil:
+		return &mathBox{}, mathOrd
+	case mathList:
+		return l.layoutList(n, state), mathOrd
+	case mathAtom:
+		return l.layoutAtom(n, state), n.class
+	case mathScripts:
+		return l.layoutScripts(n, state)
+	case mathFrac:
+		return l.layoutFrac(n, state), mathInner
+	case mathRadical:
+		return l.layoutRadical(n, state), mathOrd
+	case mathDelimited:
+		body, _ := l.layoutNode(n.body, state)
+		size := l.delimiterSize(body.height, body.depth, state.style)
+		return l.delimited(n.left, n.right, body, size, state), mathInner
+	case mathBig:
+		em := l.face(state.style).Size
+		size := l.delimiterSize(n.size*em, 0.0, state.style)
+		return l.delimiter(n.delim, size, state.style), n.class
+	case mathMatrix:
+		return l.layoutMatrix(n, state), mathOrd
+	case mathAccent:
+		return l.layoutAccent(n, state), mathOrd
+	case mathBar:
+		return l.layoutBar(n, state), mathOrd
+	case mathSpace, mathStyleChange:
+		return l.layoutList(mathList{n}, state), mathOrd
+	}
+	panic(fmt.Sprintf("unknown math node %T", node))
+}
+
+func (l *mathLayout) layoutAtom(atom mathAtom, state mathState) *mathBox {
+	rs := []rune(atom.text)
+	if atom.large && len(rs) == 1 {
+		glyphID := l.sfnt.GlyphIndex(rs[0])
+		box := l.glyphBox(glyphID, atom.text, state.style)
+		if state.style == DisplayStyle {
+			size := float64(l.c.DisplayOperatorMinHeight) * l.face(state.style).mmPerEm
+			if construction, ok := l.sfnt.Math.VerticalConstruction(glyphID); ok && 0 < len(construction.Variants) {
+				variantID := construction.Variants[len(construction.Variants)-1].GlyphID
+				for _, variant := range construction.Variants {
+					if size <= l.face(state.style).mmPerEm*float64(variant.Advance) {
+						variantID = variant.GlyphID
+						break
+					}
+				}
+				box = l.variantBox(glyphID, variantID, atom.text, state.style)
+			}
+		}
+		box.shift(l.units(state.style, l.c.AxisHeight) - (box.height-box.depth)/2.0)
+		box.extended = true
+		return box
+	}
+
+	variant := atom.variant
+	if 1 < len(rs) && variant == mathDefault {
+		variant = mathNormal
+	}
+	box := &mathBox{}
+	for _, r := range rs {
+		glyph := l.charBox(r, variant, state.style)
+		box.add(glyph, box.width, 0.0)
+		box.width += glyph.width
+		box.italic = glyph.italic
+		if len(rs) == 1 {
+			box.accent = glyph.accent
+			box.glyph = true
+			box.extended = glyph.extended
+		}
+	}
+	if 1 < len(rs) {
+		box.accent = box.width / 2.0
+	}
+	return box
+}
+
+func (l *mathLayout) layoutScripts(n mathScripts, state mathState) (*mathBox, mathClass) {
+	base, class := l.layoutNode(n.base, state)
+	if atom, ok := n.base.(mathAtom); ok && atom.class == mathOp && (atom.limits == mathLimitsAlways || atom.limits == mathDisplayLimits && state.style == DisplayStyle) {
+		return l.layoutLimits(base, n.sup, n.sub, state), class
+	}
+
+	var sup, sub *mathBox
+	if n.sup != nil {
+		sup, _ = l.layoutNode(n.sup, state.sup())
+	}
+	if n.sub != nil {
+		sub, _ = l.layoutNode(n.sub, state.sub())
+	}
+
+	// scripts are placed relative to the baseline for single glyphs and to the box for anything else
+	c, style := l.c, state.style
+	drop := !base.glyph || base.extended
+	supShift, subShift := 0.0, 0.0
+	if sup != nil {
+		supShift = l.units(style, c.SuperscriptShiftUp)
+		if state.cramped {
+			supShift = l.units(style, c.SuperscriptShiftUpCramped)
+		}
+		if drop {
+			supShift = math.Max(supShift, base.height-l.units(style, c.SuperscriptBaselineDropMax))
+		}
+		supShift = math.Max(supShift, l.units(style, c.SuperscriptBottomMin)+sup.depth)
+	}
+	if sub != nil {
+		subShift = l.units(style, c.SubscriptShiftDown)
+		if drop {
+			subShift = math.Max(subShift, base.depth+l.units(style, c.SubscriptBaselineDropMin))
+		}
+		subShift = math.Max(subShift, sub.height-l.units(style, c.SubscriptTopMax))
+	}
+	if sup != nil && sub != nil {
+		if gap := (supShift - sup.depth) - (sub.height - subShift); gap < l.units(style, c.SubSuperscriptGapMin) {
+			subShift += l.units(style, c.SubSuperscriptGapMin) - gap
+			if bottom := supShift - sup.depth; bottom < l.units(style, c.SuperscriptBottomMaxWithSubscript) {
+				delta := math.Min(l.units(style, c.SuperscriptBottomMaxWithSubscript)-bottom, subShift-l.units(style, c.SubscriptShiftDown))
+				if 0.0 < delta {
+					supShift += delta
+					subShift -= delta
+				}
+			}
+		}
+	}
+
+	// the italics correction moves superscripts to the right, except for large operators where it moves subscripts to the left
+	supX, subX := base.width+base.italic, base.width
+	if base.extended {
+		supX, subX = base.width, base.width-base.italic
+	}
+	box := &mathBox{}
+	box.add(base, 0.0, 0.0)
+	box.width = base.width
+	if sup != nil {
+		box.add(sup, supX, supShift)
+		box.width = math.Max(box.width, supX+sup.width)
+	}
+	if sub != nil {
+		box.add(sub, subX, -subShift)
+		box.width = math.Max(box.width, subX+sub.width)
+	}
+	box.width += l.units(style, c.SpaceAfterScript)
+	box.accent = base.accent
+	return box, class
+}
+
+// layoutLimits places the scripts of an operator centered above and below it.
+func (l *mathLayout) layoutLimits(base *mathBox, supNode, subNode mathNode, state mathState) *mathBox {
+	var sup, sub *mathBox
+	width := base.width
+	if supNode != nil {
+		sup, _ = l.layoutNode(supNode, state.sup())
+		width = math.Max(width, sup.width)
+	}
+	if subNode != nil {
+		sub, _ = l.layoutNode(subNode, state.sub())
+		width = math.Max(width, sub.width)
+	}
+
+	c, style := l.c, state.style
+	box := &mathBox{width: width}
+	box.add(base, (width-base.width)/2.0, 0.0)
+	if sup != nil {
+		y := base.height + math.Max(l.units(style, c.UpperLimitGapMin)+sup.depth, l.units(style, c.UpperLimitBaselineRiseMin))
+		box.add(sup, (width-sup.width+base.italic)/2.0, y)
+	}
+	if sub != nil {
+		y := base.depth + math.Max(l.units(style, c.LowerLimitGapMin)+sub.height, l.units(style, c.LowerLimitBaselineDropMin))
+		box.add(sub, math.Max(0.0, (width-sub.width-base.italic)/2.0), -y)
+	}
+	box.accent = width / 2.0
+	return box
+}
+
+func (l *mathLayout) layoutFrac(n mathFrac, state mathState) *mathBox {
+	if n.style != -1 {
+		state.style = n.style
+	}
+	num, _ := l.layoutNode(n.num, state.num())
+	den, _ := l.layoutNode(n.den, state.den())
+
+	c, style := l.c, state.style
+	display := style == DisplayStyle
+	axis := l.units(style, c.AxisHeight)
+	var numShift, denShift, thickness float64
+	if n.rule {
+		thickness = l.units(style, c.FractionRuleThickness)
+		numShift, denShift = l.units(style, c.FractionNumeratorShiftUp), l.units(style, c.FractionDenominatorShiftDown)
+		numGap, denGap := l.units(style, c.FractionNumeratorGapMin), l.units(style, c.FractionDenominatorGapMin)
+		if display {
+			numShift, denShift = l.units(style, c.FractionNumeratorDisplayStyleShiftUp), l.units(style, c.FractionDenominatorDisplayStyleShiftDown)
+			numGap, denGap = l.units(style, c.FractionNumDisplayStyleGapMin), l.units(style, c.FractionDenomDisplayStyleGapMin)
+		}
+		numShift = math.Max(numShift, axis+thickness/2.0+numGap+num.depth)
+		denShift = math.Max(denShift, den.height+denGap-axis+thickness/2.0)
+	} else {
+		numShift, denShift = l.units(style, c.StackTopShiftUp), l.units(style, c.StackBottomShiftDown)
+		gapMin := l.units(style, c.StackGapMin)
+		if display {
+			numShift, denShift = l.units(style, c.StackTopDisplayStyleShiftUp), l.units(style, c.StackBottomDisplayStyleShiftDown)
+			gapMin = l.units(style, c.StackDisplayStyleGapMin)
+		}
+		if gap := (numShift - num.depth) - (den.height - denShift); gap < gapMin {
+			numShift += (gapMin - gap) / 2.0
+			denShift += (gapMin - gap) / 2.0
+		}
+	}
+
+	pad := 0.12 * l.faces[0].Size // null delimiter space
+	width := math.Max(num.width, den.width)
+	box := &mathBox{width: width + 2.0*pad}
+	box.add(num, pad+(width-num.width)/2.0, numShift)
+	box.add(den, pad+(width-den.width)/2.0, -denShift)
+	if n.rule {
+		box.addRule(l.face(style), pad, axis-thickness/2.0, width, thickness)
+	}
+	box.accent = box.width / 2.0
+	if n.left != 0 || n.right != 0 {
+		size := l.delimiterSize(box.height, box.depth, style)
+		return l.delimited(n.left, n.right, box, size, state)
+	}
+	return box
+}
+
+// delimited places delimiters of the given size around the body.
+func (l *mathLayout) delimited(left, right rune, body *mathBox, size float64, state mathState) *mathBox {
+	box := &mathBox{}
+	x := 0.0
+	for i, b := range []*mathBox{l.delimiter(left, size, state.style), body, l.delimiter(right, size, state.style)} {
+		box.add(b, x, 0.0)
+		x += b.width
+		if i == 1 {
+			box.accent = x - b.width + b.accent
+		}
+	}
+	box.width = x
+	return box
+}
+
+func (l *mathLayout) layoutRadical(n mathRadical, state mathState) *mathBox {
+	body, _ := l.layoutNode(n.body, mathState{state.style, true})
+
+	c, style := l.c, state.style
+	gap := l.units(style, c.RadicalVerticalGap)
+	if style == DisplayStyle {
+		gap = l.units(style, c.RadicalDisplayStyleVerticalGap)
+	}
+	thickness := l.units(style, c.RadicalRuleThickness)
+	size := body.height + body.depth + gap + thickness
+	radical := l.stretch('√', size, style)
+	if delta := radical.height + radical.depth - size; 0.0 < delta {
+		gap += delta / 2.0
+	}
+	dy := body.height + gap + thickness - radical.height
+
+	box := &mathBox{}
+	x := 0.0
+	if n.degree != nil {
+		degree, _ := l.layoutNode(n.degree, mathState{ScriptScriptStyle, true})
+		raise := float64(c.RadicalDegreeBottomRaisePercent) / 100.0 * (radical.height + radical.depth)
+		before := l.units(style, c.RadicalKernBeforeDegree)
+		box.add(degree, before, dy-radical.depth+raise)
+		x = math.Max(0.0, before+degree.width+l.units(style, c.RadicalKernAfterDegree))
+	}
+	box.add(radical, x, dy)
+	x += radical.width
+	box.addRule(l.face(style), x, body.height+gap, body.width, thickness)
+	box.add(body, x, 0.0)
+	box.width = x + body.width
+	box.height = math.Max(box.height, body.height+gap+thickness+l.units(style, c.RadicalExtraAscender))
+	box.accent = x + body.accent
+	return box
+}
+
+// layoutMatrix lays out the cells in text style with struts for the row heights, and centers the table on the math axis.
+func (l *mathLayout) layoutMatrix(n mathMatrix, state mathState) *mathBox {
+	if state.style == DisplayStyle {
+		state.style = TextStyle
+	}
+	em := l.face(state.style).Size
+	strutHeight, strutDepth := 0.7*1.2*em, 0.3*1.2*em // \arraystretch=1 with \baselineskip=1.2em
+
+	cells := make([][]*mathBox, len(n.rows))
+	widths := []float64{}
+	heights := make([]float64, len(n.rows))
+	depths := make([]float64, len(n.rows))
+	for i, row := range n.rows {
+		cells[i] = make([]*mathBox, len(row))
+		heights[i], depths[i] = strutHeight, strutDepth
+		for j, cell := range row {
+			cells[i][j], _ = l.layoutNode(cell, state)
+			if len(widths) <= j {
+				widths = append(widths, 0.0)
+			}
+			widths[j] = math.Max(widths[j], cells[i][j].width)
+			heights[i] = math.Max(heights[i], cells[i][j].height)
+			depths[i] = math.Max(depths[i], cells[i][j].depth)
+		}
+	}
+
+	total := 0.0
+	for i := range n.rows {
+		total += heights[i] + depths[i]
+	}
+	box := &mathBox{}
+	y := l.units(state.style, l.c.AxisHeight) + total/2.0
+	for i, row := range cells {
+		y -= heights[i]
+		x := 0.0
+		for j, cell := range row {
+			align := byte('c')
+			if j < len(n.align) {
+				align = n.align[j]
+			}
+			switch align {
+			case 'c':
+				box.add(cell, x+(widths[j]-cell.width)/2.0, y)
+			case 'r':
+				box.add(cell, x+widths[j]-cell.width, y)
+			default:
+				box.add(cell, x, y)
+			}
+			x += widths[j] + n.colSep*em
+		}
+		y -= depths[i]
+	}
+	for j, width := range widths {
+		if 0 < j {
+			box.width += n.colSep * em
+		}
+		box.width += width
+	}
+	box.height = math.Max(box.height, y+total)
+	box.depth = math.Max(box.depth, -y)
+	box.accent = box.width / 2.0
+	return box
+}
+
+func (l *mathLayout) layoutAccent(n mathAccent, state mathState) *mathBox {
+	base, _ := l.layoutNode(n.base, mathState{state.style, true})
+
+	glyphID := l.sfnt.GlyphIndex(n.accent)
+	accent := l.glyphBox(glyphID, string(n.accent), state.style)
+	if construction, ok := l.sfnt.Math.HorizontalConstruction(glyphID); ok && n.wide {
+		// choose the widest variant that is not wider than the base
+		f := l.face(state.style).mmPerEm
+		for _, variant := range construction.Variants {
+			if base.width < f*float64(variant.Advance) {
+				break
+			}
+			accent = l.variantBox(glyphID, variant.GlyphID, string(n.accent), state.style)
+		}
+	}
+
+	box := &mathBox{}
+	box.add(base, 0.0, 0.0)
+	box.add(accent, base.accent-accent.accent, math.Max(0.0, base.height-l.units(state.style, l.c.AccentBaseHeight)))
+	box.width = base.width
+	box.italic = base.italic
+	box.accent = base.accent
+	return box
+}
+
+func (l *mathLayout) layoutBar(n mathBar, state mathState) *mathBox {
+	c, style := l.c, state.style
+	base, _ := l.layoutNode(n.base, mathState{style, state.cramped || !n.under})
+	box := &mathBox{width: base.width}
+	box.add(base, 0.0, 0.0)
+	if n.under {
+		thickness := l.units(style, c.UnderbarRuleThickness)
+		y := base.depth + l.units(style, c.UnderbarVerticalGap) + thickness
+		box.addRule(l.face(style), 0.0, -y, base.width, thickness)
+		box.depth = y + l.units(style, c.UnderbarExtraDescender)
+	} else {
+		thickness := l.units(style, c.OverbarRuleThickness)
+		y := base.height + l.units(style, c.OverbarVerticalGap)
+		box.addRule(l.face(style), 0.0, y, base.width, thickness)
+		box.height = y + thickness + l.units(style, c.OverbarExtraAscender)
+	}
+	box.accent = base.accent
+	return box
+}
+
+////////////////////////////////////////////////////////////////
+
+// toText converts the items to a text with a line per baseline, where consecutive glyphs of the same face are joined into spans. Rules and glyph variants that have no character are added as objects.
+func (b *mathBox) toText(font *Font) *Text {
+	t := &Text{
+		fonts: map[*Font]bool{font: true},
+	}
+	lines := map[float64]int{}
+	for _, item := range b.items {
+		i, ok := lines[item.y]
+		if !ok {
+			i = len(t.lines)
+			lines[item.y] = i
+			t.lines = append(t.lines, line{y: -item.y})
+		}
+
+		l := &t.lines[i]
+		if item.rule || item.glyph.Text == "" {
+			l.spans = append(l.spans, item.objectSpan())
+			continue
+		}
+		width := item.face.mmPerEm * float64(item.glyph.XAdvance)
+		if n := len(l.spans); 0 < n && l.spans[n-1].IsText() && l.spans[n-1].Face == item.face && math.Abs(l.spans[n-1].x+l.spans[n-1].Width-item.x) < Epsilon {
+			span := &l.spans[n-1]
+			item.glyph.Cluster = uint32(len(span.Text))
+			span.Glyphs = append(span.Glyphs, item.glyph)
+			span.Text += item.glyph.Text
+			span.Width += width
+			continue
+		}
+		l.spans = append(l.spans, TextSpan{
+			x:         item.x,
+			Width:     width,
+			Face:      item.face,
+			Text:      item.glyph.Text,
+			Glyphs:    []canvasText.Glyph{item.glyph},
+			Direction: canvasText.LeftToRight,
+		})
+	}
+	sort.SliceStable(t.lines, func(i, j int) bool {
+		return t.lines[i].y < t.lines[j].y
+	})
+	return t
+}
+
+// objectSpan returns a span with an object that draws the rule or glyph as a path.
+func (item mathItem) objectSpan() TextSpan {
+	var p *Path
+	if item.rule {
+		p = Rectangle(item.w, item.h)
+	} else {
+		var err error
+		if p, _, err = item.face.toPath([]canvasText.Glyph{item.glyph}, 0); err != nil {
+			p = &Path{}
+		}
+	}
+
+	bounds := p.Bounds()
+	c := New(bounds.W, bounds.H)
+	ctx := NewContext(c)
+	if item.face.NativeColor != nil {
+		ctx.SetFillColor(item.face.NativeColor)
+	} else {
+		ctx.SetFillColor(item.face.Color)
+	}
+	ctx.DrawPath(-bounds.X, -bounds.Y, p)
+	width := item.w
+	if !item.rule {
+		width = item.face.mmPerEm * float64(item.glyph.XAdvance)
+	}
+	return TextSpan{
+		x:     item.x,
+		Width: width,
+		Face:  item.face,
+		Objects: []TextSpanObject{{
+			Canvas: c,
+			X:      bounds.X,
+			Y:      bounds.Y,
+			Width:  bounds.W,
+			Height: bounds.H,
+		}},
+	}
+}
@@ -0,0 +1,857 @@
+package canvas
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// mathClass is the TeX class of a math atom, which determines the spacing between neighbouring atoms.
+type mathClass int
+
+// see mathClass
+const (
+	mathOrd mathClass = iota
+	mathOp
+	mathBin
+	mathRel
+	mathOpen
+	mathClose
+	mathPunct
+	mathInner
+)
+
+// mathVariant is the math alphabet that letters and digits are set in, see the Mathematical Alphanumeric Symbols block of Unicode.
+type mathVariant int
+
+// see mathVariant
+const (
+	mathDefault mathVariant = iota // italic letters and lowercase Greek, upright digits and uppercase Greek
+	mathNormal
+	mathItalic
+	mathBold
+	mathBoldItalic
+	mathDoubleStruck
+	mathScript
+	mathFraktur
+	mathSansSerif
+	mathMonospace
+)
+
+// mathNode is a node of the parsed formula, which is one of the math* types below.
+type mathNode interface{}
+
+// mathList is a list of nodes that are set next to each other, such as a group in braces.
+type mathList []mathNode
+
+// mathAtom is a symbol, a letter or digit, or a run of upright text such as a function name.
+type mathAtom struct {
+	text    string
+	class   mathClass
+	variant mathVariant
+	large   bool       // large operator that grows in display style
+	limits  mathLimits // placement of scripts for operators
+}
+
+// mathLimits specifies whether the scripts of an operator are set as limits above and below.
+type mathLimits int
+
+// see mathLimits
+const (
+	mathNoLimits mathLimits = iota
+	mathDisplayLimits
+	mathLimitsAlways
+)
+
+// mathScripts is a base with a superscript and/or subscript, either may be nil.
+type mathScripts struct {
+	base, sup, sub mathNode
+}
+
+// mathFrac is a fraction, optionally without a rule and with delimiters such as for binomials.
+type mathFrac struct {
+	num, den    mathNode
+	rule        bool
+	style       MathStyle // style of the fraction, or -1 to derive it from the surrounding style
+	left, right rune
+}
+
+// mathRadical is a square root, or a root with a degree.
+type mathRadical struct {
+	degree, body mathNode
+}
+
+// mathDelimited is a list between stretchy delimiters, where zero is an empty delimiter.
+type mathDelimited struct {
+	left, right rune
+	body        mathNode
+}
+
+// mathBig is a delimiter of a fixed size, such as from \big or \Bigg.
+type mathBig struct {
+	delim rune
+	class mathClass
+	size  float64 // height in em
+}
+
+// mathMatrix is a table of cells as set by the matrix and array environments.
+type mathMatrix struct {
+	rows   [][]mathNode
+	align  string  // alignment per column, one of 'l', 'c', or 'r'
+	colSep float64 // space between columns in em
+}
+
+// mathAccent is an accent over a base, where wide accents stretch to the width of the base.
+type mathAccent struct {
+	accent rune
+	wide   bool
+	base   mathNode
+}
+
+// mathBar is a rule over or under a base.
+type mathBar struct {
+	under bool
+	base  mathNode
+}
+
+// mathSpace is horizontal space in math units, where 18mu equals 1em.
+type mathSpace float64
+
+// mathStyleChange changes the style for the remainder of the list.
+type mathStyleChange MathStyle
+
+////////////////////////////////////////////////////////////////
+
+type mathSymbol struct {
+	r     rune
+	class mathClass
+}
+
+var mathChars = map[rune]mathSymbol{
+	'+':  {'+', mathBin},
+	'-':  {'−', mathBin},
+	'*':  {'∗', mathBin},
+	'=':  {'=', mathRel},
+	'<':  {'<', mathRel},
+	'>':  {'>', mathRel},
+	':':  {':', mathRel},
+	',':  {',', mathPunct},
+	';':  {';', mathPunct},
+	'(':  {'(', mathOpen},
+	'[':  {'[', mathOpen},
+	')':  {')', mathClose},
+	']':  {']', mathClose},
+	'!':  {'!', mathClose},
+	'?':  {'?', mathClose},
+	'\'': {'′', mathOrd},
+}
+
+var mathSymbols = map[string]mathSymbol{
+	// lowercase Greek
+	"alpha": {'α', mathOrd}, "beta": {'β', mathOrd}, "gamma": {'γ', mathOrd}, "delta": {'δ', mathOrd},
+	"epsilon": {'ϵ', mathOrd}, "varepsilon": {'ε', mathOrd}, "zeta": {'ζ', mathOrd}, "eta": {'η', mathOrd},
+	"theta": {'θ', mathOrd}, "vartheta": {'ϑ', mathOrd}, "iota": {'ι', mathOrd}, "kappa": {'κ', mathOrd},
+	"lambda": {'λ', mathOrd}, "mu": {'μ', mathOrd}, "nu": {'ν', mathOrd}, "xi": {'ξ', mathOrd},
+	"omicron": {'ο', mathOrd}, "pi": {'π', mathOrd}, "varpi": {'ϖ', mathOrd}, "rho": {'ρ', mathOrd},
+	"varrho": {'ϱ', mathOrd}, "sigma": {'σ', mathOrd}, "varsigma": {'ς', mathOrd}, "tau": {'τ', mathOrd},
+	"upsilon": {'υ', mathOrd}, "phi": {'ϕ', mathOrd}, "varphi": {'φ', mathOrd}, "chi": {'χ', mathOrd},
+	"psi": {'ψ', mathOrd}, "omega": {'ω', mathOrd},
+
+	// uppercase Greek
+	"Gamma": {'Γ', mathOrd}, "Delta": {'Δ', mathOrd}, "Theta": {'Θ', mathOrd}, "Lambda": {'Λ', mathOrd},
+	"Xi": {'Ξ', mathOrd}, "Pi": {'Π', mathOrd}, "Sigma": {'Σ', mathOrd}, "Upsilon": {'Υ', mathOrd},
+	"Phi": {'Φ', mathOrd}, "Psi": {'Ψ', mathOrd}, "Omega": {'Ω', mathOrd},
+
+	// ordinary symbols
+	"infty": {'∞', mathOrd}, "partial": {'∂', mathOrd}, "nabla": {'∇', mathOrd}, "forall": {'∀', mathOrd},
+	"exists": {'∃', mathOrd}, "nexists": {'∄', mathOrd}, "neg": {'¬', mathOrd}, "lnot": {'¬', mathOrd},
+	"emptyset": {'∅', mathOrd}, "varnothing": {'∅', mathOrd}, "hbar": {'ℏ', mathOrd}, "ell": {'ℓ', mathOrd},
+	"aleph": {'ℵ', mathOrd}, "Re": {'ℜ', mathOrd}, "Im": {'ℑ', mathOrd}, "wp": {'℘', mathOrd},
+	"prime": {'′', mathOrd}, "angle": {'∠', mathOrd}, "triangle": {'△', mathOrd}, "top": {'⊤', mathOrd},
+	"bot": {'⊥', mathOrd}, "vdots": {'⋮', mathOrd}, "ddots": {'⋱', mathInner}, "ldots": {'…', mathInner},
+	"cdots": {'⋯', mathInner}, "dots": {'…', mathInner}, "degree": {'°', mathOrd}, "surd": {'√', mathOrd},
+	"%": {'%', mathOrd}, "$": {'$', mathOrd}, "#": {'#', mathOrd}, "&": {'&', mathOrd}, "_": {'_', mathOrd},
+	"backslash": {'\\', mathOrd}, "vert": {'|', mathOrd}, "|": {'‖', mathOrd}, "Vert": {'‖', mathOrd},
+
+	// delimiters
+	"{": {'{', mathOpen}, "}": {'}', mathClose}, "lbrace": {'{', mathOpen}, "rbrace": {'}', mathClose},
+	"langle": {'⟨', mathOpen}, "rangle": {'⟩', mathClose}, "lfloor": {'⌊', mathOpen}, "rfloor": {'⌋', mathClose},
+	"lceil": {'⌈', mathOpen}, "rceil": {'⌉', mathClose}, "lvert": {'|', mathOpen}, "rvert": {'|', mathClose},
+	"lVert": {'‖', mathOpen}, "rVert": {'‖', mathClose}, "lbrack": {'[', mathOpen}, "rbrack": {']', mathClose},
+
+	// binary operators
+	"pm": {'±', mathBin}, "mp": {'∓', mathBin}, "times": {'×', mathBin}, "div": {'÷', mathBin},
+	"cdot": {'⋅', mathBin}, "ast": {'∗', mathBin}, "star": {'⋆', mathBin}, "circ": {'∘', mathBin},
+	"bullet": {'∙', mathBin}, "cap": {'∩', mathBin}, "cup": {'∪', mathBin}, "wedge": {'∧', mathBin},
+	"land": {'∧', mathBin}, "vee": {'∨', mathBin}, "lor": {'∨', mathBin}, "oplus": {'⊕', mathBin},
+	"ominus": {'⊖', mathBin}, "otimes": {'⊗', mathBin}, "odot": {'⊙', mathBin}, "setminus": {'∖', mathBin},
+	"sqcup": {'⊔', mathBin}, "sqcap": {'⊓', mathBin}, "uplus": {'⊎', mathBin}, "amalg": {'⨿', mathBin},
+
+	// relations
+	"leq": {'≤', mathRel}, "le": {'≤', mathRel}, "geq": {'≥', mathRel}, "ge": {'≥', mathRel},
+	"neq": {'≠', mathRel}, "ne": {'≠', mathRel}, "approx": {'≈', mathRel}, "equiv": {'≡', mathRel},
+	"sim": {'∼', mathRel}, "simeq": {'≃', mathRel}, "cong": {'≅', mathRel}, "propto": {'∝', mathRel},
+	"ll": {'≪', mathRel}, "gg": {'≫', mathRel}, "prec": {'≺', mathRel}, "succ": {'≻', mathRel},
+	"subset": {'⊂', mathRel}, "supset": {'⊃', mathRel}, "subseteq": {'⊆', mathRel}, "supseteq": {'⊇', mathRel},
+	"in": {'∈', mathRel}, "notin": {'∉', mathRel}, "ni": {'∋', mathRel}, "perp": {'⊥', mathRel},
+	"parallel": {'∥', mathRel}, "mid": {'∣', mathRel}, "models": {'⊨', mathRel}, "vdash": {'⊢', mathRel},
+	"to": {'→', mathRel}, "rightarrow": {'→', mathRel}, "leftarrow": {'←', mathRel}, "gets": {'←', mathRel},
+	"leftrightarrow": {'↔', mathRel}, "Rightarrow": {'⇒', mathRel}, "Leftarrow": {'⇐', mathRel},
+	"Leftrightarrow": {'⇔', mathRel}, "implies": {'⟹', mathRel}, "impliedby": {'⟸', mathRel},
+	"iff": {'⟺', mathRel}, "mapsto": {'↦', mathRel}, "longrightarrow": {'⟶', mathRel},
+	"longleftarrow": {'⟵', mathRel}, "uparrow": {'↑', mathRel}, "downarrow": {'↓', mathRel},
+	"doteq": {'≐', mathRel}, "asymp": {'≍', mathRel},
+
+	// punctuation
+	"colon": {':', mathPunct},
+}
+
+// mathOperators are the large operators, of which the ones with limits set their scripts above and below in display style.
+var mathOperators = map[string]struct {
+	r      rune
+	limits bool
+}{
+	"sum": {'∑', true}, "prod": {'∏', true}, "coprod": {'∐', true}, "bigcup": {'⋃', true},
+	"bigcap": {'⋂', true}, "bigvee": {'⋁', true}, "bigwedge": {'⋀', true}, "bigoplus": {'⨁', true},
+	"bigotimes": {'⨂', true}, "bigodot": {'⨀', true}, "biguplus": {'⨄', true}, "bigsqcup": {'⨆', true},
+	"int": {'∫', false}, "iint": {'∬', false}, "iiint": {'∭', false}, "oint": {'∮', false},
+}
+
+// mathFunctions are the function names that are set upright, of which the ones with limits set their scripts above and below in display style.
+var mathFunctions = map[string]bool{
+	"arccos": false, "arcsin": false, "arctan": false, "arg": false, "cos": false, "cosh": false,
+	"cot": false, "coth": false, "csc": false, "deg": false, "dim": false, "exp": false, "hom": false,
+	"ker": false, "lg": false, "ln": false, "log": false, "sec": false, "sin": false, "sinh": false,
+	"tan": false, "tanh": false, "det": true, "gcd": true, "inf": true, "lim": true, "liminf": true,
+	"limsup": true, "max": true, "min": true, "Pr": true, "sup": true,
+}
+
+// mathAccents maps accent commands to combining characters, of which the wide ones stretch over their base.
+var mathAccents = map[string]struct {
+	r    rune
+	wide bool
+}{
+	"hat": {'̂', false}, "check": {'̌', false}, "tilde": {'̃', false}, "acute": {'́', false},
+	"grave": {'̀', false}, "dot": {'̇', false}, "ddot": {'̈', false}, "breve": {'̆', false},
+	"bar": {'̄', false}, "vec": {'⃗', false}, "mathring": {'̊', false},
+	"widehat": {'̂', true}, "widetilde": {'̃', true}, "widecheck": {'̌', true},
+}
+
+var mathVariants = map[string]mathVariant{
+	"mathrm":       mathNormal,
+	"mathit":       mathItalic,
+	"mathbf":       mathBold,
+	"boldsymbol":   mathBoldItalic,
+	"mathbb":       mathDoubleStruck,
+	"mathcal":      mathScript,
+	"mathscr":      mathScript,
+	"mathfrak":     mathFraktur,
+	"mathsf":       mathSansSerif,
+	"mathtt":       mathMonospace,
+	"operatorname": mathNormal,
+}
+
+var mathSpaces = map[string]mathSpace{
+	",": 3.0, "thinspace": 3.0, ":": 4.0, ">": 4.0, "medspace": 4.0, ";": 5.0, "thickspace": 5.0,
+	"!": -3.0, "negthinspace": -3.0, " ": 6.0, "enspace": 9.0, "quad": 18.0, "qquad": 36.0,
+}
+
+var mathBigSizes = map[string]float64{
+	"big": 0.85, "Big": 1.15, "bigg": 1.45, "Bigg": 1.75,
+}
+
+var mathStyles = map[string]MathStyle{
+	"displaystyle":      DisplayStyle,
+	"textstyle":         TextStyle,
+	"scriptstyle":       ScriptStyle,
+	"scriptscriptstyle": ScriptScriptStyle,
+}
+
+// mathEnvironments are the matrix environments and their delimiters.
+var mathEnvironments = map[string][2]rune{
+	"matrix":      {0, 0},
+	"smallmatrix": {0, 0},
+	"array":       {0, 0},
+	"pmatrix":     {'(', ')'},
+	"bmatrix":     {'[', ']'},
+	"Bmatrix":     {'{', '}'},
+	"vmatrix":     {'|', '|'},
+	"Vmatrix":     {'‖', '‖'},
+	"cases":       {'{', 0},
+}
+
+////////////////////////////////////////////////////////////////
+
+type mathParser struct {
+	s       string
+	pos     int
+	variant mathVariant
+}
+
+// parseMath parses a LaTeX math formula, without the surrounding dollar signs.
+func parseMath(s string) (mathList, error) {
+	p := &mathParser{s: s}
+	list, err := p.parseList()
+	if err != nil {
+		return nil, err
+	} else if p.pos < len(p.s) {
+		return nil, p.errorf("unexpected %s", p.token())
+	}
+	return list, nil
+}
+
+func (p *mathParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("latex: "+format+" at position %d", append(args, p.pos)...)
+}
+
+// token returns the next token for error messages.
+func (p *mathParser) token() string {
+	if len(p.s) <= p.pos {
+		return "end of formula"
+	} else if p.s[p.pos] == '\\' {
+		return `\` + p.peekCommand()
+	}
+	r, _ := utf8.DecodeRuneInString(p.s[p.pos:])
+	return fmt.Sprintf("'%c'", r)
+}
+
+func (p *mathParser) skipSpace() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t' || p.s[p.pos] == '\n' || p.s[p.pos] == '\r') {
+		p.pos++
+	}
+}
+
+// peekCommand returns the name of the command at the current position without consuming it, which is either a sequence of letters or a single character.
+func (p *mathParser) peekCommand() string {
+	if len(p.s) <= p.pos+1 || p.s[p.pos] != '\\' {
+		return ""
+	}
+	i := p.pos + 1
+	for i < len(p.s) && ('a' <= p.s[i] && p.s[i] <= 'z' || 'A' <= p.s[i] && p.s[i] <= 'Z') {
+		i++
+	}
+	if i == p.pos+1 {
+		_, n := utf8.DecodeRuneInString(p.s[i:])
+		i += n
+	}
+	return p.s[p.pos+1 : i]
+}
+
+func (p *mathParser) readCommand() string {
+	name := p.peekCommand()
+	p.pos += 1 + len(name)
+	return name
+}
+
+// parseList parses nodes until the end of the group, a column or row separator, or a \right or \end command.
+func (p *mathParser) parseList() (mathList, error) {
+	list := mathList{}
+	for {
+		p.skipSpace()
+		if len(p.s) <= p.pos || p.s[p.pos] == '}' || p.s[p.pos] == '&' {
+			return list, nil
+		}
+		if cmd := p.peekCommand(); cmd == `\` || cmd == "cr" || cmd == "right" || cmd == "end" {
+			return list, nil
+		} else if cmd == "limits" || cmd == "nolimits" {
+			p.readCommand()
+			if 0 < len(list) {
+				if atom, ok := list[len(list)-1].(mathAtom); ok && atom.class == mathOp {
+					atom.limits = mathLimitsAlways
+					if cmd == "nolimits" {
+						atom.limits = mathNoLimits
+					}
+					list[len(list)-1] = atom
+					continue
+				}
+			}
+			return nil, p.errorf(`\%s must follow an operator`, cmd)
+		}
+
+		var node mathNode
+		if c := p.s[p.pos]; c != '^' && c != '_' {
+			var err error
+			if node, err = p.parseAtom(); err != nil {
+				return nil, err
+			}
+			switch node.(type) {
+			case mathSpace, mathStyleChange:
+				list = append(list, node)
+				continue
+			}
+		}
+
+		var err error
+		if node, err = p.parseScripts(node); err != nil {
+			return nil, err
+		}
+		list = append(list, node)
+	}
+}
+
+// parseScripts parses the superscripts, subscripts, and primes following a base.
+func (p *mathParser) parseScripts(base mathNode) (mathNode, error) {
+	var sup, sub mathNode
+	primes := ""
+	for {
+		p.skipSpace()
+		if len(p.s) <= p.pos {
+			break
+		} else if c := p.s[p.pos]; c == '\'' {
+			primes += "′"
+			p.pos++
+		} else if c == '^' || c == '_' {
+			p.pos++
+			arg, err := p.parseArgument()
+			if err != nil {
+				return nil, err
+			} else if c == '^' && sup != nil || c == '_' && sub != nil {
+				return nil, p.errorf("double %s", map[byte]string{'^': "superscript", '_': "subscript"}[c])
+			} else if c == '^' {
+				sup = arg
+			} else {
+				sub = arg
+			}
+		} else {
+			break
+		}
+	}
+	if primes != "" {
+		prime := mathAtom{text: primes, class: mathOrd, variant: mathNormal}
+		if sup == nil {
+			sup = prime
+		} else {
+			sup = mathList{prime, sup}
+		}
+	}
+	if sup == nil && sub == nil {
+		return base, nil
+	}
+	return mathScripts{base: base, sup: sup, sub: sub}, nil
+}
+
+// parseArgument parses a group in braces or a single token.
+func (p *mathParser) parseArgument() (mathNode, error) {
+	p.skipSpace()
+	if len(p.s) <= p.pos {
+		return nil, p.errorf("missing argument")
+	} else if p.s[p.pos] == '{' {
+		return p.parseGroup()
+	}
+	switch p.s[p.pos] {
+	case '}', '&', '^', '_':
+		return nil, p.errorf("missing argument")
+	}
+	return p.parseAtom()
+}
+
+func (p *mathParser) parseGroup() (mathList, error) {
+	p.pos++ // {
+	list, err := p.parseList()
+	if err != nil {
+		return nil, err
+	} else if len(p.s) <= p.pos || p.s[p.pos] != '}' {
+		return nil, p.errorf("expected '}' instead of %s", p.token())
+	}
+	p.pos++
+	return list, nil
+}
+
+// readText reads the raw content of an argument in braces.
+func (p *mathParser) readText() (string, error) {
+	p.skipSpace()
+	if len(p.s) <= p.pos || p.s[p.pos] != '{' {
+		return "", p.errorf("expected '{' instead of %s", p.token())
+	}
+	start, depth := p.pos+1, 0
+	for ; p.pos < len(p.s); p.pos++ {
+		if p.s[p.pos] == '\\' {
+			p.pos++
+		} else if p.s[p.pos] == '{' {
+			depth++
+		} else if p.s[p.pos] == '}' {
+			depth--
+			if depth == 0 {
+				p.pos++
+				return p.s[start : p.pos-1], nil
+			}
+		}
+	}
+	return "", p.errorf("expected '}' instead of %s", p.token())
+}
+
+// readOptional reads the raw content of an optional argument in brackets.
+func (p *mathParser) readOptional() (string, bool, error) {
+	p.skipSpace()
+	if len(p.s) <= p.pos || p.s[p.pos] != '[' {
+		return "", false, nil
+	}
+	start, depth := p.pos+1, 0
+	for p.pos++; p.pos < len(p.s); p.pos++ {
+		if p.s[p.pos] == '\\' {
+			p.pos++
+		} else if p.s[p.pos] == '{' {
+			depth++
+		} else if p.s[p.pos] == '}' {
+			depth--
+		} else if p.s[p.pos] == ']' && depth == 0 {
+			p.pos++
+			return p.s[start : p.pos-1], true, nil
+		}
+	}
+	return "", false, p.errorf("expected ']' instead of %s", p.token())
+}
+
+// parseSub parses a formula that is nested in the current one, such as an optional argument.
+func (p *mathParser) parseSub(s string) (mathList, error) {
+	sub := &mathParser{s: s, variant: p.variant}
+	list, err := sub.parseList()
+	if err != nil {
+		return nil, err
+	} else if sub.pos < len(sub.s) {
+		return nil, sub.errorf("unexpected %s", sub.token())
+	}
+	return list, nil
+}
+
+// parseDelimiter parses the delimiter after \left, \right or \big, where a period is an empty delimiter.
+func (p *mathParser) parseDelimiter() (rune, error) {
+	p.skipSpace()
+	if len(p.s) <= p.pos {
+		return 0, p.errorf("missing delimiter")
+	} else if p.s[p.pos] == '\\' {
+		cmd := p.readCommand()
+		if sym, ok := mathSymbols[cmd]; ok && (sym.class == mathOpen || sym.class == mathClose || sym.r == '|' || sym.r == '‖' || sym.r == '\\') {
+			return sym.r, nil
+		} else if cmd == "uparrow" || cmd == "downarrow" {
+			return mathSymbols[cmd].r, nil
+		}
+		return 0, p.errorf(`bad delimiter \%s`, cmd)
+	}
+	r, n := utf8.DecodeRuneInString(p.s[p.pos:])
+	p.pos += n
+	switch r {
+	case '.':
+		return 0, nil
+	case '(', ')', '[', ']', '|', '/', '<', '>':
+		if r == '<' {
+			r = '⟨'
+		} else if r == '>' {
+			r = '⟩'
+		}
+		return r, nil
+	}
+	return 0, p.errorf("bad delimiter '%c'", r)
+}
+
+// parseAtom parses a single character, a command with its arguments, or a group.
+func (p *mathParser) parseAtom() (mathNode, error) {
+	if p.s[p.pos] == '{' {
+		return p.parseGroup()
+	} else if p.s[p.pos] != '\\' {
+		r, n := utf8.DecodeRuneInString(p.s[p.pos:])
+		p.pos += n
+		if r == '~' {
+			return mathSpace(6.0), nil
+		} else if sym, ok := mathChars[r]; ok {
+			return mathAtom{text: string(sym.r), class: sym.class, variant: mathNormal}, nil
+		} else if r == '$' || r == '#' || r == '%' || r == '\\' {
+			return nil, p.errorf("unexpected '%c'", r)
+		}
+		return mathAtom{text: string(r), class: mathOrd, variant: p.variant}, nil
+	}
+
+	start := p.pos
+	cmd := p.readCommand()
+	if cmd == "" {
+		return nil, p.errorf("missing command")
+	} else if sym, ok := mathSymbols[cmd]; ok {
+		variant := p.variant
+		if sym.class != mathOrd || !unicode.IsLetter(sym.r) {
+			variant = mathNormal
+		}
+		return mathAtom{text: string(sym.r), class: sym.class, variant: variant}, nil
+	} else if op, ok := mathOperators[cmd]; ok {
+		limits := mathNoLimits
+		if op.limits {
+			limits = mathDisplayLimits
+		}
+		return mathAtom{text: string(op.r), class: mathOp, variant: mathNormal, large: true, limits: limits}, nil
+	} else if limits, ok := mathFunctions[cmd]; ok {
+		atom := mathAtom{text: cmd, class: mathOp, variant: mathNormal}
+		if limits {
+			atom.limits = mathDisplayLimits
+		}
+		if cmd == "liminf" || cmd == "limsup" {
+			atom.text = cmd[:3] + " " + cmd[3:]
+		}
+		return atom, nil
+	} else if space, ok := mathSpaces[cmd]; ok {
+		return space, nil
+	} else if style, ok := mathStyles[cmd]; ok {
+		return mathStyleChange(style), nil
+	} else if accent, ok := mathAccents[cmd]; ok {
+		base, err := p.parseArgument()
+		if err != nil {
+			return nil, err
+		}
+		return mathAccent{accent: accent.r, wide: accent.wide, base: base}, nil
+	} else if variant, ok := mathVariants[cmd]; ok {
+		if cmd == "operatorname" {
+			text, err := p.readText()
+			if err != nil {
+				return nil, err
+			}
+			return mathAtom{text: text, class: mathOp, variant: mathNormal}, nil
+		}
+		prev := p.variant
+		p.variant = variant
+		arg, err := p.parseArgument()
+		p.variant = prev
+		return arg, err
+	} else if size, ok := mathBigSizes[strings.TrimRight(cmd, "lrm")]; ok {
+		class := mathOrd
+		switch cmd[len(cmd)-1] {
+		case 'l':
+			class = mathOpen
+		case 'r':
+			class = mathClose
+		case 'm':
+			class = mathRel
+		}
+		delim, err := p.parseDelimiter()
+		if err != nil {
+			return nil, err
+		}
+		return mathBig{delim: delim, class: class, size: size}, nil
+	}
+
+	switch cmd {
+	case "text", "textrm", "mbox":
+		text, err := p.readText()
+		if err != nil {
+			return nil, err
+		}
+		return mathAtom{text: text, class: mathOrd, variant: mathNormal}, nil
+	case "frac", "dfrac", "tfrac", "cfrac", "binom", "dbinom", "tbinom":
+		num, err := p.parseArgument()
+		if err != nil {
+			return nil, err
+		}
+		den, err := p.parseArgument()
+		if err != nil {
+			return nil, err
+		}
+		frac := mathFrac{num: num, den: den, rule: true, style: -1}
+		if cmd == "dfrac" || cmd == "cfrac" || cmd == "dbinom" {
+			frac.style = DisplayStyle
+		} else if cmd == "tfrac" || cmd == "tbinom" {
+			frac.style = TextStyle
+		}
+		if strings.HasSuffix(cmd, "binom") {
+			frac.rule = false
+			frac.left, frac.right = '(', ')'
+		}
+		return frac, nil
+	case "sqrt":
+		degree, ok, err := p.readOptional()
+		if err != nil {
+			return nil, err
+		}
+		radical := mathRadical{}
+		if ok {
+			if radical.degree, err = p.parseSub(degree); err != nil {
+				return nil, err
+			}
+		}
+		if radical.body, err = p.parseArgument(); err != nil {
+			return nil, err
+		}
+		return radical, nil
+	case "overline", "underline":
+		base, err := p.parseArgument()
+		if err != nil {
+			return nil, err
+		}
+		return mathBar{under: cmd == "underline", base: base}, nil
+	case "left":
+		left, err := p.parseDelimiter()
+		if err != nil {
+			return nil, err
+		}
+		body, err := p.parseList()
+		if err != nil {
+			return nil, err
+		} else if p.peekCommand() != "right" {
+			return nil, p.errorf(`expected \right instead of %s`, p.token())
+		}
+		p.readCommand()
+		right, err := p.parseDelimiter()
+		if err != nil {
+			return nil, err
+		}
+		return mathDelimited{left: left, right: right, body: body}, nil
+	case "begin":
+		return p.parseEnvironment()
+	}
+	p.pos = start
+	return nil, p.errorf(`unknown command \%s`, cmd)
+}
+
+// parseEnvironment parses the matrix environments, where cells are separated by & and rows by \\.
+func (p *mathParser) parseEnvironment() (mathNode, error) {
+	name, err := p.readText()
+	if err != nil {
+		return nil, err
+	}
+	delims, ok := mathEnvironments[name]
+	if !ok {
+		return nil, p.errorf("unknown environment %s", name)
+	}
+
+	matrix := mathMatrix{colSep: 1.0}
+	if name == "array" {
+		spec, err := p.readText()
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range spec {
+			if c == 'l' || c == 'c' || c == 'r' {
+				matrix.align += string(c)
+			} else if c != '|' && c != ' ' {
+				return nil, p.errorf("bad column specification '%c'", c)
+			}
+		}
+	} else if name == "cases" {
+		matrix.align = "ll"
+	} else if name == "smallmatrix" {
+		matrix.colSep = 0.5
+	}
+
+	row := []mathNode{}
+	for {
+		cell, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		row = append(row, cell)
+		if len(p.s) <= p.pos {
+			return nil, p.errorf(`expected \end{%s}`, name)
+		} else if p.s[p.pos] == '&' {
+			p.pos++
+		} else if cmd := p.peekCommand(); cmd == `\` || cmd == "cr" {
+			p.readCommand()
+			matrix.rows = append(matrix.rows, row)
+			row = []mathNode{}
+		} else if cmd == "end" {
+			p.readCommand()
+			if end, err := p.readText(); err != nil {
+				return nil, err
+			} else if end != name {
+				return nil, p.errorf(`expected \end{%s} instead of \end{%s}`, name, end)
+			}
+			if 1 < len(row) || len(row[0].(mathList)) != 0 {
+				matrix.rows = append(matrix.rows, row) // no empty last row
+			}
+			break
+		} else {
+			return nil, p.errorf(`expected \end{%s} instead of %s`, name, p.token())
+		}
+	}
+	if delims[0] == 0 && delims[1] == 0 {
+		return matrix, nil
+	}
+	return mathDelimited{left: delims[0], right: delims[1], body: matrix}, nil
+}
+
+////////////////////////////////////////////////////////////////
+
+// mathAlphanumeric returns the character in the Mathematical Alphanumeric Symbols block for a letter or digit in the given variant.
+func mathAlphanumeric(r rune, variant mathVariant) rune {
+	isUpper, isLower, isDigit := 'A' <= r && r <= 'Z', 'a' <= r && r <= 'z', '0' <= r && r <= '9'
+	isGreek := 'α' <= r && r <= 'ω'
+	switch variant {
+	case mathDefault, mathItalic:
+		if r == 'h' {
+			return 'ℎ'
+		} else if isUpper {
+			return 0x1D434 + r - 'A'
+		} else if isLower {
+			return 0x1D44E + r - 'a'
+		} else if isGreek {
+			return 0x1D6FC + r - 'α'
+		} else if i := strings.IndexRune("ϵϑϰϕϱϖ", r); i != -1 {
+			return 0x1D716 + rune(utf8.RuneCountInString("ϵϑϰϕϱϖ"[:i]))
+		} else if variant == mathItalic && 'Α' <= r && r <= 'Ω' {
+			return 0x1D6E2 + r - 'Α'
+		}
+	case mathBold:
+		if isUpper {
+			return 0x1D400 + r - 'A'
+		} else if isLower {
+			return 0x1D41A + r - 'a'
+		} else if isDigit {
+			return 0x1D7CE + r - '0'
+		} else if isGreek {
+			return 0x1D6C2 + r - 'α'
+		} else if 'Α' <= r && r <= 'Ω' {
+			return 0x1D6A8 + r - 'Α'
+		}
+	case mathBoldItalic:
+		if isUpper {
+			return 0x1D468 + r - 'A'
+		} else if isLower {
+			return 0x1D482 + r - 'a'
+		} else if isDigit {
+			return 0x1D7CE + r - '0'
+		} else if isGreek {
+			return 0x1D736 + r - 'α'
+		} else if 'Α' <= r && r <= 'Ω' {
+			return 0x1D71C + r - 'Α'
+		}
+	case mathDoubleStruck:
+		if exception, ok := map[rune]rune{'C': 'ℂ', 'H': 'ℍ', 'N': 'ℕ', 'P': 'ℙ', 'Q': 'ℚ', 'R': 'ℝ', 'Z': 'ℤ'}[r]; ok {
+			return exception
+		} else if isUpper {
+			return 0x1D538 + r - 'A'
+		} else if isLower {
+			return 0x1D552 + r - 'a'
+		} else if isDigit {
+			return 0x1D7D8 + r - '0'
+		}
+	case mathScript:
+		if exception, ok := map[rune]rune{'B': 'ℬ', 'E': 'ℰ', 'F': 'ℱ', 'H': 'ℋ', 'I': 'ℐ', 'L': 'ℒ', 'M': 'ℳ', 'R': 'ℛ', 'e': 'ℯ', 'g': 'ℊ', 'o': 'ℴ'}[r]; ok {
+			return exception
+		} else if isUpper {
+			return 0x1D49C + r - 'A'
+		} else if isLower {
+			return 0x1D4B6 + r - 'a'
+		}
+	case mathFraktur:
+		if exception, ok := map[rune]rune{'C': 'ℭ', 'H': 'ℌ', 'I': 'ℑ', 'R': 'ℜ', 'Z': 'ℨ'}[r]; ok {
+			return exception
+		} else if isUpper {
+			return 0x1D504 + r - 'A'
+		} else if isLower {
+			return 0x1D51E + r - 'a'
+		}
+	case mathSansSerif:
+		if isUpper {
+			return 0x1D5A0 + r - 'A'
+		} else if isLower {
+			return 0x1D5BA + r - 'a'
+		} else if isDigit {
+			return 0x1D7E2 + r - '0'
+		}
+	case mathMonospace:
+		if isUpper {
+			return 0x1D670 + r - 'A'
+		} else if isLower {
+			return 0x1D68A + r - 'a'
+		} else if isDigit {
+			return 0x1D7F6 + r - '0'
+		}
+	}
+	return r
+}
@@ -0,0 +1,250 @@
+package canvas
+
+import (
+	"testing"
+
+	canvasText "github.com/LaminoidStudio/Canvas/text"
+	"github.com/go-fonts/latin-modern/lmmath"
+	"github.com/tdewolff/test"
+)
+
+func mathTestFace(t *testing.T) *FontFace {
+	font, err := LoadFont(lmmath.TTF, 0, FontRegular)
+	test.Error(t, err)
+	return font.Face(10.0, Black)
+}
+
+func TestParseMath(t *testing.T) {
+	var tts = []struct {
+		formula string
+		err     string
+	}{
+		{`x^2_i + \frac{a}{b} = \sqrt[3]{\left( y \right)}`, ""},
+		{`\begin{pmatrix} a & b \\ c & d \\ \end{pmatrix}`, ""},
+		{`\sum\limits_{i=0}^n \mathbf{x}' \text{ if } \big( \hat{x}`, ""},
+		{`\frac{a}`, "latex: missing argument at position 8"},
+		{`x^`, "latex: missing argument at position 2"},
+		{`x^2^3`, "latex: double superscript at position 5"},
+		{`{x`, "latex: expected '}' instead of end of formula at position 2"},
+		{`x}`, "latex: unexpected '}' at position 1"},
+		{`\foo`, `latex: unknown command \foo at position 0`},
+		{`\left( x`, `latex: expected \right instead of end of formula at position 8`},
+		{`\left< x \right\}`, ""},
+		{`\left x \right)`, "latex: bad delimiter 'x' at position 7"},
+		{`\sqrt[3{x}`, "latex: expected ']' instead of end of formula at position 10"},
+		{`\begin{pmatrix} a \end{bmatrix}`, `latex: expected \end{pmatrix} instead of \end{bmatrix} at position 31`},
+		{`\begin{foo} a \end{foo}`, "latex: unknown environment foo at position 11"},
+		{`x \nolimits`, `latex: \nolimits must follow an operator at position 11`},
+	}
+	for _, tt := range tts {
+		t.Run(tt.formula, func(t *testing.T) {
+			_, err := parseMath(tt.formula)
+			if tt.err == "" {
+				test.Error(t, err)
+			} else {
+				test.That(t, err != nil)
+				test.T(t, err.Error(), tt.err)
+			}
+		})
+	}
+
+	list, err := parseMath(`a_1' + \sin x`)
+	test.Error(t, err)
+	test.T(t, len(list), 4)
+	test.T(t, list[0], mathScripts{
+		base: mathAtom{text: "a", class: mathOrd},
+		sup:  mathAtom{text: "′", class: mathOrd, variant: mathNormal},
+		sub:  mathAtom{text: "1", class: mathOrd},
+	})
+	test.T(t, list[1], mathAtom{text: "+", class: mathBin, variant: mathNormal})
+	test.T(t, list[2], mathAtom{text: "sin", class: mathOp, variant: mathNormal})
+}
+
+func TestMathAlphanumeric(t *testing.T) {
+	test.T(t, mathAlphanumeric('x', mathDefault), '𝑥')
+	test.T(t, mathAlphanumeric('h', mathDefault), 'ℎ')
+	test.T(t, mathAlphanumeric('1', mathDefault), '1')
+	test.T(t, mathAlphanumeric('α', mathDefault), '𝛼')
+	test.T(t, mathAlphanumeric('ϕ', mathDefault), '𝜙')
+	test.T(t, mathAlphanumeric('Γ', mathDefault), 'Γ')
+	test.T(t, mathAlphanumeric('Γ', mathItalic), '𝛤')
+	test.T(t, mathAlphanumeric('x', mathNormal), 'x')
+	test.T(t, mathAlphanumeric('x', mathBold), '𝐱')
+	test.T(t, mathAlphanumeric('R', mathDoubleStruck), 'ℝ')
+	test.T(t, mathAlphanumeric('A', mathDoubleStruck), '𝔸')
+	test.T(t, mathAlphanumeric('L', mathScript), 'ℒ')
+	test.T(t, mathAlphanumeric('A', mathScript), '𝒜')
+	test.T(t, mathAlphanumeric('g', mathFraktur), '𝔤')
+	test.T(t, mathAlphanumeric('0', mathMonospace), '𝟶')
+}
+
+func TestMathLayout(t *testing.T) {
+	face := mathTestFace(t)
+	l, err := newMathLayout(face)
+	test.Error(t, err)
+	c := face.Font.Math.Constants
+	f := face.mmPerEm
+	test.Float(t, l.face(ScriptStyle).Size, 0.7*face.Size)
+	test.Float(t, l.face(ScriptScriptStyle).Size, 0.5*face.Size)
+
+	layout := func(formula string, style MathStyle) *mathBox {
+		list, err := parseMath(formula)
+		test.Error(t, err)
+		return l.layoutList(list, mathState{style: style})
+	}
+	width := func(formula string) float64 {
+		return layout(formula, TextStyle).width
+	}
+
+	// spacing between atoms and binary operators that become ordinary
+	mu := face.Size / 18.0
+	test.Float(t, width(`a+b`), width(`a`)+width(`+`)+width(`b`)+8.0*mu)
+	test.Float(t, width(`+b`), width(`+`)+width(`b`))
+	test.Float(t, width(`a=+b`), width(`a`)+width(`=`)+width(`+`)+width(`b`)+10.0*mu)
+	test.Float(t, width(`a\,b`), width(`a`)+width(`b`)+3.0*mu)
+
+	// superscripts and subscripts
+	box := layout(`x^2`, TextStyle)
+	test.T(t, len(box.items), 2)
+	test.Float(t, box.items[1].y, f*float64(c.SuperscriptShiftUp))
+	test.T(t, box.items[1].face, l.face(ScriptStyle))
+	box = layout(`x_2`, TextStyle)
+	test.Float(t, box.items[1].y, -f*float64(c.SubscriptShiftDown))
+	box = layout(`x_2^2`, TextStyle)
+	supBottom := box.items[1].y
+	subTop := box.items[2].y + l.face(ScriptStyle).mmPerEm*float64(mustGlyphBounds(face, '2')[3])
+	test.That(t, f*float64(c.SubSuperscriptGapMin)-Epsilon <= supBottom-subTop)
+
+	// fraction with rule on the math axis
+	box = layout(`\frac{a}{b}`, DisplayStyle)
+	test.T(t, len(box.items), 3)
+	test.Float(t, box.items[0].y, f*float64(c.FractionNumeratorDisplayStyleShiftUp))
+	test.Float(t, box.items[1].y, -f*float64(c.FractionDenominatorDisplayStyleShiftDown))
+	test.That(t, box.items[2].rule)
+	test.Float(t, box.items[2].y+box.items[2].h/2.0, f*float64(c.AxisHeight))
+	test.Float(t, box.items[2].h, f*float64(c.FractionRuleThickness))
+	test.T(t, layout(`\frac{a}{b}`, TextStyle).items[0].face, l.face(ScriptStyle))
+
+	// stretchy delimiters are centered on the math axis
+	box = layout(`\left( \frac{\frac{a}{b}}{\frac{c}{d}} \right)`, DisplayStyle)
+	test.T(t, box.items[0].glyph.Text, "")
+	delim := l.delimiter('(', 20.0, DisplayStyle)
+	test.That(t, 20.0 <= delim.height+delim.depth)
+	test.Float(t, (delim.height-delim.depth)/2.0, f*float64(c.AxisHeight))
+	small := layout(`\left( a \right)`, TextStyle)
+	test.T(t, small.items[0].glyph.Text, "(")
+
+	// assemblies grow without bound
+	tall := l.stretch('(', 100.0, TextStyle)
+	test.That(t, 100.0-Epsilon <= tall.height+tall.depth)
+	test.That(t, 3 < len(tall.items))
+
+	// matrices are centered on the math axis
+	box = layout(`\begin{matrix} a \\ b \end{matrix}`, TextStyle)
+	test.Float(t, (box.height-box.depth)/2.0, f*float64(c.AxisHeight))
+
+	// limits in display style and scripts in text style
+	box = layout(`\sum_{i}`, DisplayStyle)
+	test.That(t, box.items[1].y < -f*float64(c.LowerLimitBaselineDropMin))
+	test.That(t, box.items[0].x < box.items[1].x && box.items[1].x < box.items[0].x+box.width)
+	box = layout(`\sum_{i}`, TextStyle)
+	test.That(t, box.items[1].y > -f*float64(c.LowerLimitBaselineDropMin))
+	test.That(t, box.items[0].x < box.items[1].x)
+}
+
+func mustGlyphBounds(face *FontFace, r rune) [4]int16 {
+	xmin, ymin, xmax, ymax, _ := face.Font.GlyphBounds(face.Font.GlyphIndex(r))
+	return [4]int16{xmin, ymin, xmax, ymax}
+}
+
+func TestMathText(t *testing.T) {
+	face := mathTestFace(t)
+	text, err := NewMathText(face, `x^2 + \frac{a}{b}`, DisplayStyle)
+	test.Error(t, err)
+	test.T(t, len(text.lines), 5)
+	test.T(t, text.lines[0].spans[0].Text, "𝑎")
+	test.T(t, text.lines[1].spans[0].Text, "2")
+	test.That(t, !text.lines[2].spans[0].IsText())
+	test.T(t, text.lines[3].y, 0.0)
+	test.T(t, text.lines[3].spans[0].Text, "𝑥")
+	test.T(t, text.lines[3].spans[1].Text, "+")
+	test.T(t, text.lines[4].spans[0].Text, "𝑏")
+	test.T(t, text.Fonts(), []*Font{face.Font})
+
+	// consecutive glyphs are joined into a single span
+	text, err = NewMathText(face, `\sin x`, TextStyle)
+	test.Error(t, err)
+	test.T(t, len(text.lines), 1)
+	test.T(t, text.lines[0].spans[0].Text, "sin")
+	test.T(t, len(text.lines[0].spans[0].Glyphs), 3)
+	test.T(t, text.lines[0].spans[0].Glyphs[2].Cluster, uint32(2))
+	test.T(t, text.lines[0].spans[1].Text, "𝑥")
+
+	_, err = NewMathText(face, `\frac{`, DisplayStyle)
+	test.That(t, err != nil)
+
+	font, err := LoadFontFile("resources/Dynalight-Regular.otf", FontRegular)
+	test.Error(t, err)
+	_, err = NewMathText(font.Face(10.0, Black), `x`, DisplayStyle)
+	test.T(t, err.Error(), "latex: font "+font.Name()+" has no MATH table")
+}
+
+func TestRichTextLaTeX(t *testing.T) {
+	face := mathTestFace(t)
+	red := face.Font.Face(20.0, Red)
+	rt := NewRichText(face)
+	rt.WriteString("a ")
+	rt.SetFace(red)
+	test.Error(t, rt.AddLaTeX(`x = \frac{1}{2} + y`))
+	test.T(t, len(rt.objects), 3)
+	test.T(t, len(rt.breaks), 2)
+	test.T(t, rt.breaks[0].penalty, canvasText.RelPenalty)
+	test.T(t, rt.breaks[1].penalty, canvasText.BinOpPenalty)
+
+	// the space after the relation and operator is not part of the pieces
+	list, err := parseMath(`x = \frac{1}{2} + y`)
+	test.Error(t, err)
+	l, err := newMathLayout(red)
+	test.Error(t, err)
+	width := l.layoutList(list, mathState{style: TextStyle}).width
+	test.That(t, 0.0 < rt.breaks[0].space && 0.0 < rt.breaks[1].space)
+	test.Float(t, rt.objects[0].Width+rt.breaks[0].space+rt.objects[1].Width+rt.breaks[1].space+rt.objects[2].Width, width)
+
+	// formula takes the size and color of the face and has glyphs as text
+	obj := rt.objects[2]
+	text := obj.layers[0][0].text
+	test.T(t, len(text.lines), 1)
+	test.T(t, text.lines[0].spans[0].Text, "𝑦")
+	test.T(t, text.lines[0].spans[0].Face.Size, red.Size)
+	test.T(t, text.lines[0].spans[0].Face.Color, Red)
+	obj = rt.objects[1]
+	test.That(t, obj.Y < 0.0)
+	ascent, descent := obj.Heights(red)
+	test.Float(t, ascent, obj.Height+obj.Y)
+	test.Float(t, descent, -obj.Y)
+
+	// formula is aligned on the baseline and its depth is included in the line's heights
+	t1 := rt.ToText(0.0, 0.0, Left, Top, 0.0, 0.0)
+	test.T(t, len(t1.lines), 1)
+	test.T(t, len(t1.lines[0].spans), 4)
+	test.FloatDiff(t, t1.lines[0].spans[3].x, t1.lines[0].spans[2].x+rt.objects[1].Width+rt.breaks[1].space, 0.01) // object widths are rounded to font units
+	_, lineAscent, lineDescent, _ := t1.lines[0].Heights(HorizontalTB)
+	test.That(t, ascent <= lineAscent)
+	test.That(t, descent <= lineDescent)
+	test.T(t, obj.View(0.0, 0.0, red), Identity.Translate(0.0, obj.Y))
+
+	// lines break after the relation
+	t2 := rt.ToText(t1.lines[0].spans[2].x+1.0, 0.0, Left, Top, 0.0, 0.0)
+	test.T(t, len(t2.lines), 2)
+	test.T(t, len(t2.lines[0].spans), 2)
+	test.T(t, len(t2.lines[1].spans), 2)
+	test.T(t, t2.lines[1].spans[0].x, 0.0)
+
+	// fonts without a MATH table fall back to a path
+	font, err := LoadFontFile("resources/Dynalight-Regular.otf", FontRegular)
+	test.Error(t, err)
+	rt = NewRichText(font.Face(10.0, Black))
+	test.Error(t, rt.AddLaTeX(`x`))
+	test.T(t, len(rt.objects), 1)
+	test.T(t, rt.breaks, map[int]objectBreak(nil))
+}
@@ -0,0 +1,292 @@
+package canvas
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	canvasText "github.com/LaminoidStudio/Canvas/text"
+)
+
+// TabAlign specifies how text after a tab aligns to the tab stop.
+type TabAlign int
+
+// see TabAlign
+const (
+	LeftTab    TabAlign = iota // text starts at the tab stop
+	RightTab                   // text ends at the tab stop
+	CenterTab                  // text is centered on the tab stop
+	DecimalTab                 // decimal separator is at the tab stop, or text ends at the tab stop if it has none
+)
+
+func (ta TabAlign) String() string {
+	switch ta {
+	case RightTab:
+		return "Right"
+	case CenterTab:
+		return "Center"
+	case DecimalTab:
+		return "Decimal"
+	}
+	return "Left"
+}
+
+// TabStop is a position measured from the start of the lines of a paragraph, that is from its LeftIndent, to which text after a tab is aligned. The space before the text is filled with repetitions of Leader if it is not empty, such as "." for a table of contents. Decimal is the decimal separator for DecimalTab and defaults to a period.
+type TabStop struct {
+	Position float64
+	Align    TabAlign
+	Leader   string
+	Decimal  rune
+}
+
+// DefaultTabWidth is the distance between tab stops after the last tab stop of a paragraph, in multiples of the width of a space.
+var DefaultTabWidth = 8.0
+
+// ParagraphStyle is the formatting of a paragraph. HAlign is the horizontal alignment (Left, Center, Right or Justify), Indent is the indentation of the first line, LeftIndent and RightIndent narrow the lines from the left and right edges of the box, and SpaceBefore and SpaceAfter add space between paragraphs but not at the top and bottom of the box. Tab stops are resolved after line breaking and are sorted by position, and their positions are shifted by LeftIndent. Words are hyphenated with the hyphenator registered for the language of their font face, see text.RegisterHyphenator.
+type ParagraphStyle struct {
+	HAlign                  TextAlign
+	Indent                  float64
+	LeftIndent, RightIndent float64
+	SpaceBefore, SpaceAfter float64
+	TabStops                []TabStop
+}
+
+// SetParagraphStyle sets the paragraph style of the current paragraph, which is the paragraph at the end of the text, and of the following paragraphs until the style is set again.
+func (rt *RichText) SetParagraphStyle(style ParagraphStyle) *RichText {
+	style.TabStops = append([]TabStop{}, style.TabStops...)
+	sort.SliceStable(style.TabStops, func(i, j int) bool {
+		return style.TabStops[i].Position < style.TabStops[j].Position
+	})
+
+	runes := []rune(rt.String())
+	start := len(runes)
+	for 0 < start && !rt.isParagraphBreak(runes, start-1) {
+		start--
+	}
+	if rt.styleLocs[len(rt.styleLocs)-1] == start {
+		rt.styles[len(rt.styles)-1] = &style
+	} else {
+		rt.styleLocs = append(rt.styleLocs, start)
+		rt.styles = append(rt.styles, &style)
+	}
+	return rt
+}
+
+// hasParagraphStyles returns true if a paragraph style has been set.
+func (rt *RichText) hasParagraphStyles() bool {
+	return 1 < len(rt.styles) || rt.styles[0] != nil
+}
+
+// isParagraphBreak returns true if the rune at position i ends a paragraph, which excludes the runes that encode path/image objects.
+func (rt *RichText) isParagraphBreak(runes []rune, i int) bool {
+	switch runes[i] {
+	case '\n', '\v', '\f', '\r', '\u0085', '\u2029':
+		return rt.faces[rt.locs.index(i)] != nil
+	}
+	return false
+}
+
+// toTextParagraphs fits the text within a box like toText, but breaks each paragraph into lines separately using its paragraph style, where halign and indent are used for paragraphs without a style.
+func (rt *RichText) toTextParagraphs(width, height float64, halign, valign TextAlign, indent, lineStretch float64) *Text {
+	t := &Text{
+		lines:           []line{},
+		fonts:           map[*Font]bool{},
+		WritingMode:     rt.mode,
+		TextOrientation: rt.orient,
+	}
+
+	runes := []rune(rt.String())
+	lineSpacing := 1.0 + lineStretch
+	y, prevBottom, spaceAfter := 0.0, 0.0, 0.0
+	for start := 0; ; {
+		end := start
+		for end < len(runes) && !rt.isParagraphBreak(runes, end) {
+			end++
+		}
+
+		style := ParagraphStyle{HAlign: halign}
+		if start == 0 && !rt.continued {
+			style.Indent = indent
+		}
+		if s := rt.styles[rt.styleLocs.index(start)]; s != nil {
+			style = *s
+			if start == 0 && rt.continued {
+				style.Indent = 0.0
+			}
+		}
+
+		var lines []line
+		if start == end {
+			// empty paragraph
+			face := rt.defaultFace
+			if k := rt.locs.index(start); k < len(rt.faces) && rt.faces[k] != nil {
+				face = rt.faces[k]
+			}
+			_, ascent, descent, bottom := face.heights(rt.mode)
+			lines = []line{{ascent: ascent, descent: descent, bottom: bottom}}
+		} else {
+			lineWidth := 0.0
+			if width != 0.0 {
+				lineWidth = math.Max(0.0, width-style.LeftIndent-style.RightIndent)
+			}
+			paragraph := rt.slice(start, end)
+			paragraph.styleLocs, paragraph.styles = indexer{0}, []*ParagraphStyle{nil}
+			text := paragraph.toText(lineWidth, 0.0, style.HAlign, Top, style.Indent, lineStretch, style.TabStops)
+			for font := range text.fonts {
+				t.fonts[font] = true
+			}
+			lines = text.lines
+		}
+
+		full := false
+		for k, l := range lines {
+			if len(t.lines) == 0 {
+				y = l.ascent
+			} else {
+				if k == 0 {
+					l.spaceBefore = spaceAfter + style.SpaceBefore
+				}
+				y += (prevBottom+l.ascent)*lineSpacing + l.spaceBefore
+			}
+			if height != 0.0 && height < y+l.descent {
+				full = true
+				break
+			}
+			for i := range l.spans {
+				l.spans[i].x += style.LeftIndent
+			}
+			l.y = y
+			l.start += start
+			l.paragraphEnd = k == len(lines)-1
+			t.lines = append(t.lines, l)
+			prevBottom = l.bottom
+		}
+		spaceAfter = style.SpaceAfter
+
+		if full || end == len(runes) {
+			break
+		}
+		start = end + 1
+		if runes[end] == '\r' && start < len(runes) && runes[start] == '\n' {
+			start++
+		}
+	}
+
+	if 0 < len(t.lines) {
+		y += t.lines[len(t.lines)-1].descent
+	}
+	t.alignVertically(valign, height, y)
+	return t
+}
+
+// positionTabs moves the spans after each tab to the next tab stop, using default tab stops after the last tab stop. The space before a tab stop is filled with its leader. Since line breaking measures tabs as zero width, text that would end past the line width, if not zero, is moved left to end at the line width but never before the end of the previous text, in which case it overflows the line.
+func (t *Text) positionTabs(lineWidth float64, tabStops []TabStop) {
+	for j := range t.lines {
+		l := &t.lines[j]
+		if len(l.tabs) == 0 {
+			continue
+		}
+
+		spans := l.spans
+		l.spans = make([]TextSpan, 0, len(spans))
+		end := 0.0 // end of the previous segment
+		prev := 0
+		for k := 0; k <= len(l.tabs); k++ {
+			next := len(spans)
+			if k < len(l.tabs) {
+				next = l.tabs[k]
+			}
+			segment := spans[prev:next]
+			prev = next
+			if len(segment) == 0 {
+				continue
+			} else if k == 0 {
+				// text before the first tab
+				l.spans = append(l.spans, segment...)
+				end = segment[len(segment)-1].x + segment[len(segment)-1].Width
+				continue
+			}
+
+			// find the tab stop after the end of the previous segment
+			x0 := segment[0].x
+			width := segment[len(segment)-1].x + segment[len(segment)-1].Width - x0
+			stop := TabStop{}
+			found := false
+			for _, tabStop := range tabStops {
+				if end < tabStop.Position {
+					stop, found = tabStop, true
+					break
+				}
+			}
+			if !found {
+				interval := DefaultTabWidth * segment[0].Face.TextWidth(" ")
+				stop.Position = (math.Floor(end/interval) + 1.0) * interval
+			}
+
+			x := stop.Position
+			switch stop.Align {
+			case RightTab:
+				x -= width
+			case CenterTab:
+				x -= width / 2.0
+			case DecimalTab:
+				x -= width
+				decimal := stop.Decimal
+				if decimal == 0 {
+					decimal = '.'
+				}
+				for _, span := range segment {
+					if i := strings.IndexRune(span.Text, decimal); span.IsText() && i != -1 {
+						x = stop.Position - (span.x - x0) - span.Face.TextWidth(span.Text[:i])
+						break
+					}
+				}
+			}
+			if lineWidth != 0.0 {
+				x = math.Min(x, lineWidth-width)
+			}
+			x = math.Max(x, end)
+
+			if stop.Leader != "" {
+				if leader, ok := leaderSpan(segment[0].Face, stop.Leader, end, x); ok {
+					l.spans = append(l.spans, leader)
+				}
+			}
+			for _, span := range segment {
+				span.x += x - x0
+				l.spans = append(l.spans, span)
+			}
+			end = x + width
+		}
+	}
+}
+
+// leaderSpan returns a span that fills the space between x0 and x1 with repetitions of the leader. Repetitions are placed at multiples of the leader's width so that leaders on consecutive lines line up. It returns false if not even one repetition fits.
+func leaderSpan(face *FontFace, leader string, x0, x1 float64) (TextSpan, bool) {
+	glyphs := face.Font.shaper.Shape(leader, face.shapingPPEM(), canvasText.LeftToRight, face.Script, face.Language, face.Font.features, face.Font.variations)
+	for i := range glyphs {
+		glyphs[i].SFNT = face.Font.SFNT
+		glyphs[i].Size = face.Size
+	}
+	width := face.textWidth(glyphs)
+	if width <= 0.0 {
+		return TextSpan{}, false
+	}
+
+	x := math.Ceil(x0/width) * width
+	n := int((x1 - x) / width)
+	if n <= 0 {
+		return TextSpan{}, false
+	}
+	span := TextSpan{
+		x:         x,
+		Width:     float64(n) * width,
+		Face:      face,
+		Text:      strings.Repeat(leader, n),
+		Direction: canvasText.LeftToRight,
+	}
+	for i := 0; i < n; i++ {
+		span.Glyphs = append(span.Glyphs, glyphs...)
+	}
+	return span, true
+}
@@ -0,0 +1,114 @@
+package canvas
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tdewolff/test"
+)
+
+func TestParagraphStyle(t *testing.T) {
+	font, err := LoadFontFile("resources/DejaVuSerif.ttf", FontRegular)
+	if err != nil {
+		t.Fatal(err)
+	}
+	face := font.Face(12, Black)
+	metrics := face.Metrics()
+
+	rt := NewRichText(face)
+	rt.Add(face, "first\n")
+	rt.SetParagraphStyle(ParagraphStyle{
+		HAlign:      Right,
+		RightIndent: 10.0,
+		SpaceBefore: 3.0,
+	})
+	rt.Add(face, "second\n")
+	rt.SetParagraphStyle(ParagraphStyle{
+		HAlign:     Left,
+		Indent:     5.0,
+		LeftIndent: 10.0,
+		SpaceAfter: 2.0,
+	})
+	rt.Add(face, "third\nfourth")
+
+	text := rt.ToText(100.0, 0.0, Left, Top, 0.0, 0.0)
+	test.T(t, len(text.lines), 4)
+	lineHeight := metrics.Ascent + metrics.Descent + metrics.LineGap
+	test.Float(t, text.lines[0].y, metrics.Ascent)
+	test.Float(t, text.lines[0].spans[0].x, 0.0)
+	test.Float(t, text.lines[1].y, metrics.Ascent+lineHeight+3.0)
+	test.Float(t, text.lines[1].spans[0].x+text.lines[1].spans[0].Width, 90.0)
+	test.Float(t, text.lines[2].y, metrics.Ascent+2.0*lineHeight+3.0)
+	test.Float(t, text.lines[2].spans[0].x, 15.0)
+	test.Float(t, text.lines[3].y, metrics.Ascent+3.0*lineHeight+5.0)
+	test.Float(t, text.lines[3].spans[0].x, 15.0)
+
+	// the style applies to the paragraph that is being added
+	rt = NewRichText(face)
+	rt.Add(face, "first ")
+	rt.SetParagraphStyle(ParagraphStyle{LeftIndent: 10.0})
+	text = rt.ToText(100.0, 0.0, Left, Top, 0.0, 0.0)
+	test.Float(t, text.lines[0].spans[0].x, 10.0)
+}
+
+func TestParagraphTabStops(t *testing.T) {
+	font, err := LoadFontFile("resources/DejaVuSerif.ttf", FontRegular)
+	if err != nil {
+		t.Fatal(err)
+	}
+	face := font.Face(12, Black)
+
+	rt := NewRichText(face)
+	rt.SetParagraphStyle(ParagraphStyle{
+		TabStops: []TabStop{
+			{Position: 80.0, Align: DecimalTab},
+			{Position: 40.0, Align: RightTab, Leader: "."},
+		},
+	})
+	rt.Add(face, "Apples\t3\t12.50\nPears\t12\t7.125")
+	text := rt.ToText(100.0, 0.0, Left, Top, 0.0, 0.0)
+	test.T(t, len(text.lines), 2)
+
+	for _, line := range text.lines {
+		var leader, quantity, price *TextSpan
+		for i, span := range line.spans {
+			if strings.Trim(span.Text, ".") == "" {
+				leader = &line.spans[i]
+			} else if strings.HasPrefix(span.Text, "1") && strings.Contains(span.Text, ".") || strings.HasPrefix(span.Text, "7") {
+				price = &line.spans[i]
+			} else if i != 0 {
+				quantity = &line.spans[i]
+			}
+		}
+		test.That(t, leader != nil && quantity != nil && price != nil, "missing spans")
+
+		// leader fills the space before the quantity that ends at the tab stop
+		name := line.spans[0]
+		test.That(t, name.x+name.Width <= leader.x && leader.x+leader.Width <= quantity.x, "leader must be between name and quantity")
+		test.Float(t, quantity.x+quantity.Width, 40.0)
+
+		// decimal separator is at the tab stop
+		i := strings.IndexByte(price.Text, '.')
+		test.Float(t, price.x+face.TextWidth(price.Text[:i]), 80.0)
+	}
+
+	// default tab stops
+	rt = NewRichText(face)
+	rt.SetParagraphStyle(ParagraphStyle{})
+	rt.Add(face, "a\tb")
+	text = rt.ToText(100.0, 0.0, Left, Top, 0.0, 0.0)
+	test.Float(t, text.lines[0].spans[1].x, DefaultTabWidth*face.TextWidth(" "))
+
+	// tab stops past the line width are clamped so that the text and leader end at the line width, positions start at the left indent
+	rt = NewRichText(face)
+	rt.SetParagraphStyle(ParagraphStyle{
+		LeftIndent: 10.0,
+		TabStops:   []TabStop{{Position: 80.0, Align: RightTab, Leader: "."}},
+	})
+	rt.Add(face, "a\tb")
+	text = rt.ToText(60.0, 0.0, Left, Top, 0.0, 0.0)
+	test.T(t, len(text.lines[0].spans), 3)
+	leader, b := text.lines[0].spans[1], text.lines[0].spans[2]
+	test.Float(t, b.x+b.Width, 60.0)
+	test.That(t, leader.x+leader.Width <= b.x, "leader must end before the text")
+}
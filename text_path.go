@@ -0,0 +1,190 @@
+package canvas
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TextSide specifies on which side of a path the text is set, seen in the direction of the path.
+type TextSide int
+
+// see TextSide
+const (
+	LeftOfPath  TextSide = iota // text runs along the path and stands upright on its left side
+	RightOfPath                 // text runs along the reversed path and stands upright on the right side of the path
+)
+
+func (side TextSide) String() string {
+	switch side {
+	case LeftOfPath:
+		return "LeftOfPath"
+	case RightOfPath:
+		return "RightOfPath"
+	}
+	return "Invalid(" + strconv.Itoa(int(side)) + ")"
+}
+
+// TextPath is the path along which a text is set, see Text.OnPath.
+type TextPath struct {
+	Path          *Path     // path in the direction of the text, which is the reversed path for text on the right side
+	Offset        float64   // distance along the path of the anchor of the text
+	HAlign        TextAlign // alignment of the text with respect to the anchor, either Left, Center or Right
+	LetterSpacing float64   // space added after each character
+}
+
+// pathSpan is a glyph cluster or object of a text along a path, where m places the origin of the span on the path.
+type pathSpan struct {
+	m    Matrix
+	span TextSpan
+}
+
+// NewTextOnPath is a text line using a single font face that is set along a path, see Text.OnPath.
+func NewTextOnPath(face *FontFace, s string, path *Path, offset float64, halign TextAlign, side TextSide, letterSpacing float64) *Text {
+	return NewTextLine(face, s, Left).OnPath(path, offset, halign, side, letterSpacing)
+}
+
+// OnPath returns the first line of a horizontal text set along a path, where each glyph cluster is rotated to the tangent of the path at its center, like SVG's textPath. The text is anchored at offset along the path with horizontal alignment (Left, Center or Right) and letter spacing is added after each character. The baseline follows the path on the given side. Glyph clusters whose center falls outside of the path are dropped. The path is in the same coordinate system as the text and the returned text should be drawn at the origin to coincide with the path.
+func (t *Text) OnPath(path *Path, offset float64, halign TextAlign, side TextSide, letterSpacing float64) *Text {
+	if side == RightOfPath {
+		path = path.Reverse()
+	}
+	t2 := &Text{
+		fonts:           t.fonts,
+		WritingMode:     HorizontalTB,
+		TextOrientation: t.TextOrientation,
+		path: &TextPath{
+			Path:          path,
+			Offset:        offset,
+			HAlign:        halign,
+			LetterSpacing: letterSpacing,
+		},
+	}
+	if len(t.lines) == 0 {
+		return t2
+	}
+
+	// split spans into glyph clusters, keeping objects as a whole
+	spans := []TextSpan{}
+	for _, span := range t.lines[0].spans {
+		if !span.IsText() {
+			span.x = 0.0
+			spans = append(spans, span)
+			continue
+		}
+		for i := 0; i < len(span.Glyphs); {
+			j := i + 1
+			for j < len(span.Glyphs) && span.Glyphs[j].Cluster == span.Glyphs[i].Cluster {
+				j++
+			}
+			glyphs := span.Glyphs[i:j:j]
+			sb := strings.Builder{}
+			for _, glyph := range glyphs {
+				sb.WriteString(glyph.Text)
+			}
+			spans = append(spans, TextSpan{
+				Width:     span.Face.textWidth(glyphs),
+				Face:      span.Face,
+				Text:      sb.String(),
+				Glyphs:    glyphs,
+				Direction: span.Direction,
+				Rotation:  span.Rotation,
+			})
+			i = j
+		}
+	}
+	if len(spans) == 0 {
+		return t2
+	}
+
+	width := float64(len(spans)-1) * letterSpacing
+	for _, span := range spans {
+		width += span.Width
+	}
+	d := offset
+	if halign == Center || halign == Middle {
+		d -= width / 2.0
+	} else if halign == Right {
+		d -= width
+	}
+
+	measure := newPathMeasure(path)
+	for _, span := range spans {
+		if pos, angle, ok := measure.at(d + span.Width/2.0); ok {
+			m := Identity.Translate(pos.X, pos.Y).Rotate(angle*180.0/math.Pi).Translate(-span.Width/2.0, 0.0)
+			t2.pathSpans = append(t2.pathSpans, pathSpan{m, span})
+		}
+		d += span.Width + letterSpacing
+	}
+	return t2
+}
+
+// TextPath returns the path along which the text is set, or nil if the text consists of lines.
+func (t *Text) TextPath() *TextPath {
+	return t.path
+}
+
+// WalkPathSpans calls the callback for each glyph cluster or object of a text along a path, where m places the origin of the span on the path. Color glyphs are passed as spans with an object. It does nothing for a text that consists of lines.
+func (t *Text) WalkPathSpans(callback func(m Matrix, span TextSpan)) {
+	for _, ps := range t.pathSpans {
+		xOffset := ps.span.Face.mmPerEm * float64(ps.span.Face.XOffset)
+		yOffset := ps.span.Face.mmPerEm * float64(ps.span.Face.YOffset)
+		if spans, offsets := ps.span.colorGlyphSpans(); spans != nil {
+			for i, span := range spans {
+				callback(ps.m.Translate(xOffset+offsets[i].X, yOffset+offsets[i].Y), span)
+			}
+		} else {
+			callback(ps.m.Translate(xOffset, yOffset), ps.span)
+		}
+	}
+}
+
+// pathMeasure finds positions and tangents along a flattened path by their distance from the start of the path.
+type pathMeasure struct {
+	starts, ends []Point
+	dists        []float64 // distance along the path at the end of each segment
+}
+
+// newPathMeasure returns the measure of a path, where moves between subpaths do not count towards the distance.
+func newPathMeasure(p *Path) pathMeasure {
+	// flatten finely since the direction of the segments determines the rotation of the glyphs
+	oldTolerance := Tolerance
+	Tolerance /= 100.0
+	p = p.Flatten()
+	Tolerance = oldTolerance
+
+	pm := pathMeasure{}
+	d := 0.0
+	for scanner := p.Scanner(); scanner.Scan(); {
+		if scanner.Cmd() == MoveToCmd {
+			continue
+		}
+		start, end := scanner.Start(), scanner.End()
+		if length := end.Sub(start).Length(); !Equal(length, 0.0) {
+			d += length
+			pm.starts = append(pm.starts, start)
+			pm.ends = append(pm.ends, end)
+			pm.dists = append(pm.dists, d)
+		}
+	}
+	return pm
+}
+
+// at returns the position and the angle of the tangent in radians at distance d along the path, and false if d is outside of the path.
+func (pm pathMeasure) at(d float64) (Point, float64, bool) {
+	if len(pm.dists) == 0 || d < 0.0 || pm.dists[len(pm.dists)-1] < d {
+		return Point{}, 0.0, false
+	}
+	i := sort.SearchFloat64s(pm.dists, d)
+	if i == len(pm.dists) {
+		i--
+	}
+	d0 := 0.0
+	if 0 < i {
+		d0 = pm.dists[i-1]
+	}
+	start, end := pm.starts[i], pm.ends[i]
+	pos := start.Interpolate(end, (d-d0)/(pm.dists[i]-d0))
+	return pos, end.Sub(start).Angle(), true
+}
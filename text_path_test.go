@@ -0,0 +1,73 @@
+package canvas
+
+import (
+	"testing"
+
+	"github.com/tdewolff/test"
+)
+
+func TestTextOnPath(t *testing.T) {
+	font, err := LoadFontFile("resources/DejaVuSerif.ttf", FontRegular)
+	if err != nil {
+		t.Fatal(err)
+	}
+	face := font.Face(12, Black)
+
+	line := &Path{}
+	line.MoveTo(0.0, 0.0)
+	line.LineTo(100.0, 0.0)
+
+	spans := func(text *Text) ([]Matrix, []TextSpan) {
+		ms, spans := []Matrix{}, []TextSpan{}
+		text.WalkPathSpans(func(m Matrix, span TextSpan) {
+			ms = append(ms, m)
+			spans = append(spans, span)
+		})
+		return ms, spans
+	}
+
+	// straight path gives the same advances as a text line
+	text := NewTextOnPath(face, "AVA", line, 10.0, Left, LeftOfPath, 0.0)
+	test.That(t, text.TextPath() != nil)
+	ms, ss := spans(text)
+	test.T(t, len(ss), 3)
+	test.T(t, ss[1].Text, "V")
+	test.T(t, ms[0], Identity.Translate(10.0, 0.0))
+	test.T(t, ms[1], Identity.Translate(10.0+ss[0].Width, 0.0))
+	test.Float(t, ss[0].Width+ss[1].Width+ss[2].Width, face.TextWidth("AVA"))
+
+	// letter spacing and alignment
+	text = NewTextOnPath(face, "AVA", line, 50.0, Right, LeftOfPath, 1.0)
+	ms, ss = spans(text)
+	x, _ := ms[2].Pos()
+	test.Float(t, x+ss[2].Width, 50.0)
+	x, _ = ms[1].Pos()
+	test.Float(t, x+ss[1].Width+1.0, 50.0-ss[2].Width)
+
+	// glyphs beyond the end of the path are dropped
+	text = NewTextOnPath(face, "AVA", line, 100.0-face.TextWidth("AV"), Left, LeftOfPath, 0.0)
+	_, ss = spans(text)
+	test.T(t, len(ss), 2)
+
+	// text on the right side runs along the reversed path
+	text = NewTextOnPath(face, "A", line, 10.0, Left, RightOfPath, 0.0)
+	ms, ss = spans(text)
+	test.T(t, ms[0], Identity.Translate(90.0, 0.0).Rotate(180.0))
+
+	// glyphs are rotated to the tangent at their center
+	circle := Circle(20.0)
+	quarter := circle.Length() / 4.0
+	text = NewTextOnPath(face, "A", circle, quarter, Center, LeftOfPath, 0.0)
+	ms, _ = spans(text)
+	center := ms[0].Translate(face.TextWidth("A")/2.0, 0.0)
+	x, y := center.Pos()
+	_, _, theta, _, _, _ := center.Decompose()
+	test.T(t, len(ms), 1)
+	test.FloatDiff(t, x, 0.0, 0.1) // path is flattened
+	test.FloatDiff(t, y, 20.0, 0.1)
+	test.FloatDiff(t, theta, 180.0, 0.5)
+
+	bounds := NewTextOnPath(face, "AVA", line, 10.0, Left, LeftOfPath, 0.0).Bounds()
+	test.Float(t, bounds.X, 10.0)
+	test.Float(t, bounds.W, face.TextWidth("AVA"))
+}
@@ -1,8 +1,11 @@
 package canvas
 
 import (
+	"math"
 	"testing"
 
+	canvasFont "github.com/LaminoidStudio/Canvas/font"
+	canvasText "github.com/LaminoidStudio/Canvas/text"
 	"github.com/tdewolff/test"
 )
 
@@ -197,3 +200,67 @@ func TestTextBox(t *testing.T) {
 	ctx.DrawText(0, 0, NewTextBox(face, "\ntext", 100, 100, Left, Top, 0, 0))
 	ctx.DrawText(0, 0, NewTextBox(face, "text\n\ntext2", 100, 100, Left, Top, 0, 0))
 }
+
+func TestTextHyphenation(t *testing.T) {
+	hyphenator := canvasText.NewHyphenator()
+	hyphenator.AddPatterns("hy3ph", "he2n", "hena4", "hen5at", "1na", "n2at", "1tio", "2io", "o2n")
+	canvasText.RegisterHyphenator("xx-hyph", hyphenator)
+	defer canvasText.RegisterHyphenator("xx-hyph", nil)
+
+	font, err := LoadFontFile("resources/DejaVuSerif.ttf", FontRegular)
+	if err != nil {
+		t.Fatal(err)
+	}
+	face := font.Face(12, Black)
+	face.Language = "xx-hyph"
+	width := face.TextWidth("hyphenation hyphen-")
+
+	text := NewTextBox(face, "hyphenation hyphenation", width, 100.0, Justify, Top, 0.0, 0.0)
+	lines := []string{}
+	for _, line := range text.lines {
+		s := ""
+		for _, span := range line.spans {
+			s += span.Text
+		}
+		lines = append(lines, s)
+	}
+	test.T(t, lines, []string{"hyphenation hyphen-", "ation"})
+
+	// no hyphenation without a hyphenator for the language
+	face.Language = "yy"
+	text = NewTextBox(face, "hyphenation hyphenation", width, 100.0, Justify, Top, 0.0, 0.0)
+	test.T(t, len(text.lines), 2)
+	test.T(t, text.lines[0].spans[len(text.lines[0].spans)-1].Text, "hyphenation ")
+}
+
+func TestTextSpanHinting(t *testing.T) {
+	family := NewFontFamily("dejavu-serif")
+	if err := family.LoadFontFile("resources/DejaVuSerif.ttf", FontRegular); err != nil {
+		test.Error(t, err)
+	}
+	face := family.Face(12.0, Black, FontRegular, FontNormal)
+	face.Hinting = canvasFont.AutoHinting
+	span := TextSpan{Face: face}
+	resolution := DPMM(10.0)
+
+	var tts = []struct {
+		m, mSpan Matrix
+	}{
+		{Identity.Scale(2.0, 2.0), Identity.Translate(1.23, 4.56)},
+		{Identity.Translate(5.0, 5.0).Rotate(90.0), Identity.Translate(1.23, 4.56).Rotate(-90.0)}, // rotation cancels out
+	}
+	for _, tt := range tts {
+		ppem, mSpan := span.hinting(tt.m, tt.mSpan, resolution)
+		test.That(t, ppem != 0, "must hint axis-aligned text")
+
+		// the baseline is moved onto the pixel grid in view space
+		x0, y0 := tt.m.Mul(tt.mSpan).Pos()
+		x, y := tt.m.Mul(mSpan).Pos()
+		test.Float(t, x, x0)
+		test.Float(t, y*10.0, math.Round(y0*10.0))
+	}
+
+	ppem, mSpan := span.hinting(Identity.Rotate(30.0), Identity, resolution)
+	test.T(t, ppem, uint16(0))
+	test.T(t, mSpan, Identity)
+}